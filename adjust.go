@@ -14,7 +14,9 @@ package excelize
 import (
 	"bytes"
 	"encoding/xml"
+	"errors"
 	"io"
+	"regexp"
 	"strconv"
 	"strings"
 	"unicode"
@@ -29,8 +31,32 @@ const (
 	rows    adjustDirection = true
 )
 
+var (
+	// x14CondFmtRegexp and x14SparklineRegexp matches the conditional
+	// formatting and sparkline elements in the worksheet extension list.
+	x14CondFmtRegexp   = regexp.MustCompile(`(?s)<x14:conditionalFormatting[ >].*?</x14:conditionalFormatting>`)
+	x14SparklineRegexp = regexp.MustCompile(`(?s)<x14:sparkline>.*?</x14:sparkline>`)
+	// x14RefRegexp matches the formula and cell reference of the elements in
+	// the worksheet extension list.
+	x14RefRegexp = regexp.MustCompile(`<xm:(f|sqref)>([^<]*)</xm:(?:f|sqref)>`)
+	// chartFormulaRegexp matches the formula elements in the chart part.
+	chartFormulaRegexp = regexp.MustCompile(`<((?:c:)?f)>([^<]*)</(?:c:)?f>`)
+	// chartFormulaUnescaper unescapes the character entities in the formula
+	// of the chart part, which includes the numeric character references
+	// generated by the XML encoder.
+	chartFormulaUnescaper = strings.NewReplacer(
+		`&#39;`, `'`,
+		`&apos;`, `'`,
+		`&#34;`, `"`,
+		`&quot;`, `"`,
+		`&amp;`, `&`,
+		`&lt;`, `<`,
+		`&gt;`, `>`,
+	)
+)
+
 // adjustHelperFunc defines functions to adjust helper.
-var adjustHelperFunc = [9]func(*File, *xlsxWorksheet, string, adjustDirection, int, int, int) error{
+var adjustHelperFunc = [14]func(*File, *xlsxWorksheet, string, adjustDirection, int, int, int) error{
 	func(f *File, ws *xlsxWorksheet, sheet string, dir adjustDirection, num, offset, sheetID int) error {
 		return f.adjustConditionalFormats(ws, sheet, dir, num, offset, sheetID)
 	},
@@ -40,6 +66,9 @@ var adjustHelperFunc = [9]func(*File, *xlsxWorksheet, string, adjustDirection, i
 	func(f *File, ws *xlsxWorksheet, sheet string, dir adjustDirection, num, offset, sheetID int) error {
 		return f.adjustDefinedNames(ws, sheet, dir, num, offset, sheetID)
 	},
+	func(f *File, ws *xlsxWorksheet, sheet string, dir adjustDirection, num, offset, sheetID int) error {
+		return f.adjustCharts(ws, sheet, dir, num, offset, sheetID)
+	},
 	func(f *File, ws *xlsxWorksheet, sheet string, dir adjustDirection, num, offset, sheetID int) error {
 		return f.adjustDrawings(ws, sheet, dir, num, offset, sheetID)
 	},
@@ -52,12 +81,24 @@ var adjustHelperFunc = [9]func(*File, *xlsxWorksheet, string, adjustDirection, i
 	func(f *File, ws *xlsxWorksheet, sheet string, dir adjustDirection, num, offset, sheetID int) error {
 		return f.adjustCalcChain(ws, sheet, dir, num, offset, sheetID)
 	},
+	func(f *File, ws *xlsxWorksheet, sheet string, dir adjustDirection, num, offset, sheetID int) error {
+		return f.adjustPivotCaches(ws, sheet, dir, num, offset, sheetID)
+	},
+	func(f *File, ws *xlsxWorksheet, sheet string, dir adjustDirection, num, offset, sheetID int) error {
+		return f.adjustSparklines(ws, sheet, dir, num, offset, sheetID)
+	},
 	func(f *File, ws *xlsxWorksheet, sheet string, dir adjustDirection, num, offset, sheetID int) error {
 		return f.adjustTable(ws, sheet, dir, num, offset, sheetID)
 	},
 	func(f *File, ws *xlsxWorksheet, sheet string, dir adjustDirection, num, offset, sheetID int) error {
 		return f.adjustVolatileDeps(ws, sheet, dir, num, offset, sheetID)
 	},
+	func(f *File, ws *xlsxWorksheet, sheet string, dir adjustDirection, num, offset, sheetID int) error {
+		return f.adjustCellWatches(ws, sheet, dir, num, offset, sheetID)
+	},
+	func(f *File, ws *xlsxWorksheet, sheet string, dir adjustDirection, num, offset, sheetID int) error {
+		return f.adjustTags(ws, sheet, dir, num, offset, sheetID)
+	},
 }
 
 // adjustHelper provides a function to adjust rows and columns dimensions,
@@ -98,6 +139,26 @@ func (f *File) adjustHelper(sheet string, dir adjustDirection, num, offset int)
 	return nil
 }
 
+// adjustNumber returns the row or column number after inserting or deleting
+// rows or columns by given row or column number, pivot number and offset. The
+// number within the deleted rows or columns will be moved to the previous row
+// or column of the deleted area.
+func adjustNumber(n, num, offset int) int {
+	if n < num {
+		return n
+	}
+	if isDeletedNumber(n, num, offset) {
+		return num - 1
+	}
+	return n + offset
+}
+
+// isDeletedNumber returns whether the row or column number is within the
+// deleted rows or columns by given pivot number and offset.
+func isDeletedNumber(n, num, offset int) bool {
+	return offset < 0 && n >= num && n < num-offset
+}
+
 // adjustCols provides a function to update column style when inserting or
 // deleting columns.
 func (f *File) adjustCols(ws *xlsxWorksheet, col, offset int) error {
@@ -120,17 +181,17 @@ func (f *File) adjustCols(ws *xlsxWorksheet, col, offset int) error {
 			}
 			continue
 		}
-		if ws.Cols.Col[i].Min == col && ws.Cols.Col[i].Max == col {
+		if isDeletedNumber(ws.Cols.Col[i].Min, col, offset) && isDeletedNumber(ws.Cols.Col[i].Max, col, offset) {
 			ws.Cols.Col = append(ws.Cols.Col[:i], ws.Cols.Col[i+1:]...)
 			i--
 			continue
 		}
 		if ws.Cols.Col[i].Min > col {
-			ws.Cols.Col[i].Min += offset
-		}
-		if ws.Cols.Col[i].Max >= col {
-			ws.Cols.Col[i].Max += offset
+			if ws.Cols.Col[i].Min = adjustNumber(ws.Cols.Col[i].Min, col, offset); ws.Cols.Col[i].Min < col {
+				ws.Cols.Col[i].Min = col
+			}
 		}
+		ws.Cols.Col[i].Max = adjustNumber(ws.Cols.Col[i].Max, col, offset)
 	}
 	if len(ws.Cols.Col) == 0 {
 		ws.Cols = nil
@@ -153,7 +214,7 @@ func (f *File) adjustColDimensions(sheet string, ws *xlsxWorksheet, col, offset
 	for _, sheetN := range f.GetSheetList() {
 		worksheet, err := f.workSheetReader(sheetN)
 		if err != nil {
-			if err.Error() == newNotWorksheetError(sheetN).Error() {
+			if errors.Is(err, ErrNotWorksheet{SheetName: sheetN}) {
 				continue
 			}
 			return err
@@ -183,7 +244,7 @@ func (f *File) adjustRowDimensions(sheet string, ws *xlsxWorksheet, row, offset
 		}
 		worksheet, err := f.workSheetReader(sheetN)
 		if err != nil {
-			if err.Error() == newNotWorksheetError(sheetN).Error() {
+			if errors.Is(err, ErrNotWorksheet{SheetName: sheetN}) {
 				continue
 			}
 			return err
@@ -240,18 +301,15 @@ func (f *File) adjustSingleRowFormulas(sheet, sheetN string, r *xlsxRow, num, of
 func (f *File) adjustCellRef(cellRef string, dir adjustDirection, num, offset int) (string, error) {
 	var SQRef []string
 	applyOffset := func(coordinates []int, idx1, idx2, maxVal int) []int {
-		if coordinates[idx1] >= num {
-			coordinates[idx1] += offset
-		}
-		if coordinates[idx2] >= num {
-			if coordinates[idx2] += offset; coordinates[idx2] > maxVal {
-				coordinates[idx2] = maxVal
-			}
+		coordinates[idx1] = adjustNumber(coordinates[idx1], num, offset)
+		if coordinates[idx2] = adjustNumber(coordinates[idx2], num, offset); coordinates[idx2] > maxVal {
+			coordinates[idx2] = maxVal
 		}
 		return coordinates
 	}
 	for _, ref := range strings.Split(cellRef, " ") {
-		if !strings.Contains(ref, ":") {
+		cell := !strings.Contains(ref, ":")
+		if cell {
 			ref += ":" + ref
 		}
 		coordinates, err := rangeRefToCoordinates(ref)
@@ -259,12 +317,12 @@ func (f *File) adjustCellRef(cellRef string, dir adjustDirection, num, offset in
 			return "", err
 		}
 		if dir == columns {
-			if offset < 0 && coordinates[0] == coordinates[2] {
+			if isDeletedNumber(coordinates[0], num, offset) && isDeletedNumber(coordinates[2], num, offset) {
 				continue
 			}
 			coordinates = applyOffset(coordinates, 0, 2, MaxColumns)
 		} else {
-			if offset < 0 && coordinates[1] == coordinates[3] {
+			if isDeletedNumber(coordinates[1], num, offset) && isDeletedNumber(coordinates[3], num, offset) {
 				continue
 			}
 			coordinates = applyOffset(coordinates, 1, 3, TotalRows)
@@ -272,6 +330,9 @@ func (f *File) adjustCellRef(cellRef string, dir adjustDirection, num, offset in
 		if ref, err = coordinatesToRangeRef(coordinates); err != nil {
 			return "", err
 		}
+		if cell {
+			ref = strings.Split(ref, ":")[0]
+		}
 		SQRef = append(SQRef, ref)
 	}
 	return strings.Join(SQRef, " "), nil
@@ -296,6 +357,23 @@ func (f *File) adjustFormula(sheet, sheetN string, cell *xlsxC, dir adjustDirect
 		if si && cell.F.Si != nil {
 			cell.F.Si = intPtr(*cell.F.Si + 1)
 		}
+		if cell.F.T == STCellFormulaTypeDataTable {
+			for _, input := range []struct {
+				ref *string
+				del *bool
+			}{{&cell.F.R1, &cell.F.Del1}, {&cell.F.R2, &cell.F.Del2}} {
+				if *input.ref == "" || *input.del {
+					continue
+				}
+				ref, err := f.adjustCellRef(*input.ref, dir, num, offset)
+				if err != nil {
+					return err
+				}
+				if *input.del = ref == ""; !*input.del {
+					*input.ref = ref
+				}
+			}
+		}
 	}
 	if cell.F.Content != "" {
 		if cell.F.Content, err = f.adjustFormulaRef(sheet, sheetN, cell.F.Content, false, dir, num, offset); err != nil {
@@ -473,8 +551,8 @@ func transformParenthesesToken(token efp.Token) string {
 }
 
 // adjustRangeSheetName returns replaced range reference by given source and
-// target sheet name.
-func adjustRangeSheetName(rng, source, target string) string {
+// target sheet names map.
+func adjustRangeSheetName(rng string, names map[string]string) string {
 	cellRefs := strings.Split(rng, ",")
 	for i, cellRef := range cellRefs {
 		rangeRefs := strings.Split(cellRef, ":")
@@ -485,11 +563,17 @@ func adjustRangeSheetName(rng, source, target string) string {
 				if singleQuote {
 					part = strings.TrimPrefix(strings.TrimSuffix(part, "'"), "'")
 				}
-				if part == source {
-					if part = target; singleQuote {
-						part = "'" + part + "'"
+				for source, target := range names {
+					if strings.EqualFold(part, source) {
+						if part = target; target == "" {
+							part, singleQuote = formulaErrorREF[:len(formulaErrorREF)-1], false
+						}
+						break
 					}
 				}
+				if singleQuote {
+					part = "'" + part + "'"
+				}
 				parts[k] = part
 			}
 			rangeRefs[j] = strings.Join(parts, "!")
@@ -499,6 +583,181 @@ func adjustRangeSheetName(rng, source, target string) string {
 	return strings.Join(cellRefs, ",")
 }
 
+// adjustFormulaSheetNames returns the formula with replaced sheet names in
+// the references by given source and target sheet names map. The original
+// formula will be returned if there is no sheet name has been replaced.
+func adjustFormulaSheetNames(formula string, names map[string]string) string {
+	var (
+		val      string
+		replaced bool
+		ps       = efp.ExcelParser()
+	)
+	for _, token := range ps.Parse(formula) {
+		if token.TType == efp.TokenTypeUnknown {
+			return formula
+		}
+		if token.TType == efp.TokenTypeOperand && token.TSubType == efp.TokenSubTypeRange && !strings.ContainsAny(token.TValue, "[]") {
+			operand, ok := adjustOperandSheetNames(token.TValue, names)
+			val += operand
+			replaced = replaced || ok
+			continue
+		}
+		if paren := transformParenthesesToken(token); paren != "" {
+			val += paren
+			continue
+		}
+		if token.TType == efp.TokenTypeOperand && token.TSubType == efp.TokenSubTypeText {
+			val += string(efp.QuoteDouble) + strings.ReplaceAll(token.TValue, "\"", "\"\"") + string(efp.QuoteDouble)
+			continue
+		}
+		val += token.TValue
+	}
+	if !replaced {
+		return formula
+	}
+	if strings.HasPrefix(formula, "=") {
+		return "=" + val
+	}
+	return val
+}
+
+// adjustOperandSheetNames returns the reference with replaced sheet names by
+// given unquoted reference operand and source and target sheet names map, the
+// sheet names in the returned reference will be quoted if necessary, and the
+// reference to the sheet with an empty target name will be replaced with the
+// #REF! error. The second returned value indicates whether a sheet name has
+// been replaced.
+func adjustOperandSheetNames(operand string, names map[string]string) (string, bool) {
+	var (
+		replaced bool
+		first    *string
+		parts    = strings.Split(operand, ":")
+	)
+	rename := func(name string) string {
+		for source, target := range names {
+			if strings.EqualFold(source, name) {
+				replaced = true
+				return target
+			}
+		}
+		return name
+	}
+	if len(parts) > 1 && !strings.Contains(parts[0], "!") && strings.Contains(parts[1], "!") {
+		name := rename(parts[0])
+		first, parts = &name, parts[1:]
+	}
+	for i := range parts {
+		idx := strings.LastIndex(parts[i], "!")
+		if idx == -1 {
+			continue
+		}
+		sheet := rename(parts[i][:idx])
+		prefix := escapeSheetName(sheet)
+		if first != nil {
+			if prefix = *first + ":" + sheet; escapeSheetName(*first) != *first || escapeSheetName(sheet) != sheet {
+				prefix = "'" + strings.ReplaceAll(prefix, "'", "''") + "'"
+			}
+			if *first == "" {
+				sheet = ""
+			}
+			first = nil
+		}
+		if sheet == "" {
+			prefix = formulaErrorREF[:len(formulaErrorREF)-1]
+		}
+		parts[i] = prefix + parts[i][idx:]
+	}
+	return strings.Join(parts, ":"), replaced
+}
+
+// adjustSheetNames updates the sheet names in the cell formulas, conditional
+// formats, data validations, hyperlinks and the formulas in the extension list
+// of the worksheet by given source and target sheet names map.
+func (ws *xlsxWorksheet) adjustSheetNames(names map[string]string) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	for r := range ws.SheetData.Row {
+		for c := range ws.SheetData.Row[r].C {
+			if cell := &ws.SheetData.Row[r].C[c]; cell.F != nil && cell.F.Content != "" {
+				cell.F.Content, cell.f = adjustFormulaSheetNames(cell.F.Content, names), ""
+			}
+		}
+	}
+	for _, cf := range ws.ConditionalFormatting {
+		for _, rule := range cf.CfRule {
+			for i, formula := range rule.Formula {
+				rule.Formula[i] = adjustFormulaSheetNames(formula, names)
+			}
+		}
+	}
+	if ws.DataValidations != nil {
+		for _, dv := range ws.DataValidations.DataValidation {
+			for _, formula := range []*xlsxInnerXML{dv.Formula1, dv.Formula2} {
+				if formula == nil {
+					continue
+				}
+				if val := formulaUnescaper.Replace(formula.Content); adjustFormulaSheetNames(val, names) != val {
+					formula.Content = formulaEscaper.Replace(adjustFormulaSheetNames(val, names))
+				}
+			}
+		}
+	}
+	if ws.Hyperlinks != nil {
+		for i, link := range ws.Hyperlinks.Hyperlink {
+			if link.Location != "" {
+				ws.Hyperlinks.Hyperlink[i].Location = adjustFormulaSheetNames(link.Location, names)
+			}
+		}
+	}
+	if ws.ExtLst != nil {
+		ws.ExtLst.Ext = x14RefRegexp.ReplaceAllStringFunc(ws.ExtLst.Ext, func(ref string) string {
+			matches := x14RefRegexp.FindStringSubmatch(ref)
+			val := formulaUnescaper.Replace(matches[2])
+			if matches[1] != "f" || adjustFormulaSheetNames(val, names) == val {
+				return ref
+			}
+			return "<xm:f>" + formulaEscaper.Replace(adjustFormulaSheetNames(val, names)) + "</xm:f>"
+		})
+	}
+}
+
+// isFormulaReferSheet returns if the formula contains the reference to the
+// sheet by given formula and sheet name.
+func isFormulaReferSheet(formula, sheet string) bool {
+	ps := efp.ExcelParser()
+	for _, token := range ps.Parse(formula) {
+		if token.TType == efp.TokenTypeOperand && token.TSubType == efp.TokenSubTypeRange && !strings.ContainsAny(token.TValue, "[]") {
+			if _, ok := adjustOperandSheetNames(token.TValue, map[string]string{sheet: sheet}); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// adjustChartSheetNames updates the sheet names in the series formulas of the
+// charts by given source and target sheet names map.
+func (f *File) adjustChartSheetNames(names map[string]string) {
+	var charts []string
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.HasPrefix(k.(string), "xl/charts/chart") && strings.HasSuffix(k.(string), ".xml") {
+			charts = append(charts, k.(string))
+		}
+		return true
+	})
+	for _, chartXML := range charts {
+		content := chartFormulaRegexp.ReplaceAllStringFunc(string(f.readXML(chartXML)), func(s string) string {
+			matches := chartFormulaRegexp.FindStringSubmatch(s)
+			formula := chartFormulaUnescaper.Replace(matches[2])
+			if formula = adjustFormulaSheetNames(formula, names); formula == chartFormulaUnescaper.Replace(matches[2]) {
+				return s
+			}
+			return "<" + matches[1] + ">" + formulaEscaper.Replace(formula) + "</" + matches[1] + ">"
+		})
+		f.Pkg.Store(chartXML, []byte(content))
+	}
+}
+
 // arrayFormulaOperandToken defines meta fields for transforming the array
 // formula to the normal formula.
 type arrayFormulaOperandToken struct {
@@ -618,7 +877,7 @@ func (f *File) adjustHyperlinks(ws *xlsxWorksheet, sheet string, dir adjustDirec
 			linkData := ws.Hyperlinks.Hyperlink[i]
 			colNum, rowNum, _ := CellNameToCoordinates(linkData.Ref)
 
-			if (dir == rows && num == rowNum) || (dir == columns && num == colNum) {
+			if (dir == rows && isDeletedNumber(rowNum, num, offset)) || (dir == columns && isDeletedNumber(colNum, num, offset)) {
 				f.deleteSheetRelationships(sheet, linkData.RID)
 				if len(ws.Hyperlinks.Hyperlink) > 1 {
 					ws.Hyperlinks.Hyperlink = append(ws.Hyperlinks.Hyperlink[:i],
@@ -638,6 +897,40 @@ func (f *File) adjustHyperlinks(ws *xlsxWorksheet, sheet string, dir adjustDirec
 	}
 }
 
+// adjustCellWatches provides a function to update the watched cells when
+// inserting or deleting rows or columns, the watched cells within the deleted
+// rows or columns will be removed.
+func (f *File) adjustCellWatches(ws *xlsxWorksheet, sheet string, dir adjustDirection, num, offset, sheetID int) error {
+	if ws.CellWatches == nil {
+		return nil
+	}
+	var cellWatch []xlsxCellWatch
+	for _, watch := range ws.CellWatches.CellWatch {
+		col, row, err := CellNameToCoordinates(watch.R)
+		if err != nil {
+			return err
+		}
+		if dir == rows {
+			if isDeletedNumber(row, num, offset) {
+				continue
+			}
+			row = adjustNumber(row, num, offset)
+		} else {
+			if isDeletedNumber(col, num, offset) {
+				continue
+			}
+			col = adjustNumber(col, num, offset)
+		}
+		if watch.R, err = CoordinatesToCellName(col, row); err == nil {
+			cellWatch = append(cellWatch, watch)
+		}
+	}
+	if ws.CellWatches.CellWatch = cellWatch; len(cellWatch) == 0 {
+		ws.CellWatches = nil
+	}
+	return nil
+}
+
 // adjustTable provides a function to update the table when inserting or
 // deleting rows or columns.
 func (f *File) adjustTable(ws *xlsxWorksheet, sheet string, dir adjustDirection, num, offset, sheetID int) error {
@@ -662,7 +955,7 @@ func (f *File) adjustTable(ws *xlsxWorksheet, sheet string, dir adjustDirection,
 			return err
 		}
 		// Remove the table when deleting the header row of the table
-		if dir == rows && num == coordinates[0] && offset == -1 {
+		if dir == rows && isDeletedNumber(coordinates[1], num, offset) {
 			ws.TableParts.TableParts = append(ws.TableParts.TableParts[:idx], ws.TableParts.TableParts[idx+1:]...)
 			ws.TableParts.Count = len(ws.TableParts.TableParts)
 			idx--
@@ -702,7 +995,8 @@ func (f *File) adjustAutoFilter(ws *xlsxWorksheet, sheet string, dir adjustDirec
 	}
 	x1, y1, x2, y2 := coordinates[0], coordinates[1], coordinates[2], coordinates[3]
 
-	if (dir == rows && y1 == num && offset < 0) || (dir == columns && x1 == num && x2 == num) {
+	if (dir == rows && isDeletedNumber(y1, num, offset)) ||
+		(dir == columns && isDeletedNumber(x1, num, offset) && isDeletedNumber(x2, num, offset)) {
 		ws.AutoFilter = nil
 		for rowIdx := range ws.SheetData.Row {
 			rowData := &ws.SheetData.Row[rowIdx]
@@ -725,20 +1019,12 @@ func (f *File) adjustAutoFilter(ws *xlsxWorksheet, sheet string, dir adjustDirec
 // operation reference and offset.
 func (f *File) adjustAutoFilterHelper(dir adjustDirection, coordinates []int, num, offset int) []int {
 	if dir == rows {
-		if coordinates[1] >= num {
-			coordinates[1] += offset
-		}
-		if coordinates[3] >= num {
-			coordinates[3] += offset
-		}
+		coordinates[1] = adjustNumber(coordinates[1], num, offset)
+		coordinates[3] = adjustNumber(coordinates[3], num, offset)
 		return coordinates
 	}
-	if coordinates[0] >= num {
-		coordinates[0] += offset
-	}
-	if coordinates[2] >= num {
-		coordinates[2] += offset
-	}
+	coordinates[0] = adjustNumber(coordinates[0], num, offset)
+	coordinates[2] = adjustNumber(coordinates[2], num, offset)
 	return coordinates
 }
 
@@ -761,7 +1047,7 @@ func (f *File) adjustMergeCells(ws *xlsxWorksheet, sheet string, dir adjustDirec
 		}
 		x1, y1, x2, y2 := coordinates[0], coordinates[1], coordinates[2], coordinates[3]
 		if dir == rows {
-			if y1 == num && y2 == num && offset < 0 {
+			if isDeletedNumber(y1, num, offset) && isDeletedNumber(y2, num, offset) {
 				f.deleteMergeCell(ws, i)
 				i--
 				continue
@@ -769,7 +1055,7 @@ func (f *File) adjustMergeCells(ws *xlsxWorksheet, sheet string, dir adjustDirec
 
 			y1, y2 = f.adjustMergeCellsHelper(y1, y2, num, offset)
 		} else {
-			if x1 == num && x2 == num && offset < 0 {
+			if isDeletedNumber(x1, num, offset) && isDeletedNumber(x2, num, offset) {
 				f.deleteMergeCell(ws, i)
 				i--
 				continue
@@ -807,13 +1093,12 @@ func (f *File) adjustMergeCellsHelper(p1, p2, num, offset int) (int, int) {
 		}
 		return p1, p2
 	}
-	if num < p1 || (num == p1 && num == p2) {
-		p1 += offset
-		p2 += offset
-	} else if num <= p2 {
-		p2 += offset
+	if p1 > num {
+		if p1 = adjustNumber(p1, num, offset); p1 < num {
+			p1 = num
+		}
 	}
-	return p1, p2
+	return p1, adjustNumber(p2, num, offset)
 }
 
 // deleteMergeCell provides a function to delete merged cell by given index.
@@ -861,7 +1146,7 @@ func (f *File) adjustCalcChain(ws *xlsxWorksheet, sheet string, dir adjustDirect
 			return err
 		}
 		if dir == rows && num <= rowNum {
-			if num == rowNum && offset == -1 {
+			if isDeletedNumber(rowNum, num, offset) {
 				_ = f.deleteCalcChain(c.I, c.R)
 				i--
 				continue
@@ -869,7 +1154,7 @@ func (f *File) adjustCalcChain(ws *xlsxWorksheet, sheet string, dir adjustDirect
 			f.CalcChain.C[i].R, _ = adjustCellName(c.R, dir, colNum, rowNum, offset)
 		}
 		if dir == columns && num <= colNum {
-			if num == colNum && offset == -1 {
+			if isDeletedNumber(colNum, num, offset) {
 				_ = f.deleteCalcChain(c.I, c.R)
 				i--
 				continue
@@ -889,7 +1174,7 @@ func (vt *xlsxVolTypes) adjustVolatileDepsTopic(cell string, dir adjustDirection
 		return i4, err
 	}
 	if dir == rows && num <= rowNum {
-		if num == rowNum && offset == -1 {
+		if isDeletedNumber(rowNum, num, offset) {
 			vt.deleteVolTopicRef(i1, i2, i3, i4)
 			i4--
 			return i4, err
@@ -897,7 +1182,7 @@ func (vt *xlsxVolTypes) adjustVolatileDepsTopic(cell string, dir adjustDirection
 		vt.VolType[i1].Main[i2].Tp[i3].Tr[i4].R, _ = adjustCellName(cell, dir, colNum, rowNum, offset)
 	}
 	if dir == columns && num <= colNum {
-		if num == colNum && offset == -1 {
+		if isDeletedNumber(colNum, num, offset) {
 			vt.deleteVolTopicRef(i1, i2, i3, i4)
 			i4--
 			return i4, err
@@ -953,17 +1238,76 @@ func (f *File) adjustConditionalFormats(ws *xlsxWorksheet, sheet string, dir adj
 			continue
 		}
 		ws.ConditionalFormatting[i].SQRef = ref
+		for _, rule := range cf.CfRule {
+			for j, formula := range rule.Formula {
+				if rule.Formula[j], err = f.adjustFormulaRef(sheet, sheet, formula, false, dir, num, offset); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return f.adjustExtLstRefs(ws, sheet, sheet, x14CondFmtRegexp, dir, num, offset)
+}
+
+// adjustSparklines updates the location and data range of the sparklines
+// when inserting or deleting rows or columns.
+func (f *File) adjustSparklines(ws *xlsxWorksheet, sheet string, dir adjustDirection, num, offset, sheetID int) error {
+	for _, sheetN := range f.GetSheetList() {
+		worksheet, err := f.workSheetReader(sheetN)
+		if err != nil {
+			if errors.Is(err, ErrNotWorksheet{SheetName: sheetN}) {
+				continue
+			}
+			return err
+		}
+		if err = f.adjustExtLstRefs(worksheet, sheet, sheetN, x14SparklineRegexp, dir, num, offset); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+// adjustExtLstRefs updates the formulas and cell references of the elements
+// which matched by given regular expression in the worksheet extension list,
+// the element will be removed if its cell reference has been deleted.
+func (f *File) adjustExtLstRefs(ws *xlsxWorksheet, sheet, sheetN string, elem *regexp.Regexp, dir adjustDirection, num, offset int) error {
+	if ws.ExtLst == nil {
+		return nil
+	}
+	var err error
+	ws.ExtLst.Ext = elem.ReplaceAllStringFunc(ws.ExtLst.Ext, func(s string) string {
+		var deleted bool
+		s = x14RefRegexp.ReplaceAllStringFunc(s, func(ref string) string {
+			matches := x14RefRegexp.FindStringSubmatch(ref)
+			val := formulaUnescaper.Replace(matches[2])
+			if err != nil || (matches[1] == "sqref" && sheet != sheetN) {
+				return ref
+			}
+			if matches[1] == "f" {
+				if val, err = f.adjustFormulaRef(sheet, sheetN, val, false, dir, num, offset); err != nil {
+					return ref
+				}
+			} else if val, err = f.adjustCellRef(val, dir, num, offset); err != nil || val == "" {
+				deleted = err == nil
+				return ref
+			}
+			return "<xm:" + matches[1] + ">" + formulaEscaper.Replace(val) + "</xm:" + matches[1] + ">"
+		})
+		if deleted {
+			return ""
+		}
+		return s
+	})
+	return err
+}
+
 // adjustDataValidations updates the range of data validations for the worksheet
 // when inserting or deleting rows or columns.
 func (f *File) adjustDataValidations(ws *xlsxWorksheet, sheet string, dir adjustDirection, num, offset, sheetID int) error {
 	for _, sheetN := range f.GetSheetList() {
 		worksheet, err := f.workSheetReader(sheetN)
 		if err != nil {
-			if err.Error() == newNotWorksheetError(sheetN).Error() {
+			if errors.Is(err, ErrNotWorksheet{SheetName: sheetN}) {
 				continue
 			}
 			return err
@@ -1050,35 +1394,39 @@ func (to *xlsxTo) adjustDrawings(dir adjustDirection, num, offset int, editAs st
 	return nil
 }
 
-// adjustDrawings updates the two cell anchor pictures and charts object when
-// inserting or deleting rows or columns.
+// adjustDrawings updates the one cell anchor and two cell anchor pictures,
+// charts and shapes object when inserting or deleting rows or columns. The
+// one cell anchor object only moves with the starting anchor, and the ending
+// anchor of the two cell anchor object which moves and sizes with cells will
+// be resized if the inserted or deleted rows or columns within the anchor.
 func (a *xdrCellAnchor) adjustDrawings(dir adjustDirection, num, offset int) error {
 	editAs := a.EditAs
-	if a.From == nil || a.To == nil || editAs == "absolute" {
+	if a.From == nil || editAs == "absolute" {
 		return nil
 	}
 	ok, err := a.From.adjustDrawings(dir, num, offset, editAs)
-	if err != nil {
+	if err != nil || a.To == nil {
 		return err
 	}
-	return a.To.adjustDrawings(dir, num, offset, editAs, ok || editAs == "")
+	return a.To.adjustDrawings(dir, num, offset, editAs, ok || editAs != "oneCell")
 }
 
-// adjustDrawings updates the existing two cell anchor pictures and charts
-// object when inserting or deleting rows or columns.
+// adjustDrawings updates the existing one cell anchor and two cell anchor
+// pictures, charts and shapes object when inserting or deleting rows or
+// columns.
 func (a *xlsxCellAnchorPos) adjustDrawings(dir adjustDirection, num, offset int, editAs string) error {
-	if a.From == nil || a.To == nil || editAs == "absolute" {
+	if a.From == nil || editAs == "absolute" {
 		return nil
 	}
 	ok, err := a.From.adjustDrawings(dir, num, offset, editAs)
-	if err != nil {
+	if err != nil || a.To == nil {
 		return err
 	}
-	return a.To.adjustDrawings(dir, num, offset, editAs, ok || editAs == "")
+	return a.To.adjustDrawings(dir, num, offset, editAs, ok || editAs != "oneCell")
 }
 
-// adjustDrawings updates the pictures and charts object when inserting or
-// deleting rows or columns.
+// adjustDrawings updates the pictures, charts and shapes object when inserting
+// or deleting rows or columns.
 func (f *File) adjustDrawings(ws *xlsxWorksheet, sheet string, dir adjustDirection, num, offset, sheetID int) error {
 	if ws.Drawing == nil {
 		return nil
@@ -1093,7 +1441,7 @@ func (f *File) adjustDrawings(ws *xlsxWorksheet, sheet string, dir adjustDirecti
 		return err
 	}
 	anchorCb := func(a *xdrCellAnchor) error {
-		if a.GraphicFrame == "" {
+		if a.GraphicFrame == "" || a.From != nil {
 			return a.adjustDrawings(dir, num, offset)
 		}
 		deCellAnchor := decodeCellAnchor{}
@@ -1123,10 +1471,80 @@ func (f *File) adjustDrawings(ws *xlsxWorksheet, sheet string, dir adjustDirecti
 		a.GraphicFrame = strings.TrimSuffix(strings.TrimPrefix(string(cellAnchor), "<xlsxCellAnchorPos>"), "</xlsxCellAnchorPos>")
 		return err
 	}
-	for _, anchor := range wsDr.TwoCellAnchor {
-		if err = anchorCb(anchor); err != nil {
+	for _, anchors := range [][]*xdrCellAnchor{wsDr.OneCellAnchor, wsDr.TwoCellAnchor} {
+		for _, anchor := range anchors {
+			if err = anchorCb(anchor); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// adjustCharts updates the series, categories and titles reference formulas
+// of the charts which refer to the worksheet when inserting or deleting rows
+// or columns.
+func (f *File) adjustCharts(ws *xlsxWorksheet, sheet string, dir adjustDirection, num, offset, sheetID int) error {
+	var charts []string
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.HasPrefix(k.(string), "xl/charts/chart") && strings.HasSuffix(k.(string), ".xml") {
+			charts = append(charts, k.(string))
+		}
+		return true
+	})
+	for _, chartXML := range charts {
+		var err error
+		content := chartFormulaRegexp.ReplaceAllStringFunc(string(f.readXML(chartXML)), func(s string) string {
+			matches := chartFormulaRegexp.FindStringSubmatch(s)
+			if err != nil {
+				return s
+			}
+			var formula string
+			if formula, err = f.adjustFormulaRef(sheet, "", formulaUnescaper.Replace(matches[2]), false, dir, num, offset); err != nil {
+				return s
+			}
+			return "<" + matches[1] + ">" + formulaEscaper.Replace(formula) + "</" + matches[1] + ">"
+		})
+		if err != nil {
+			return err
+		}
+		f.Pkg.Store(chartXML, []byte(content))
+	}
+	return nil
+}
+
+// adjustPivotCaches updates the source range of the pivot caches which refer
+// to the worksheet when inserting or deleting rows or columns.
+func (f *File) adjustPivotCaches(ws *xlsxWorksheet, sheet string, dir adjustDirection, num, offset, sheetID int) error {
+	var pivotCaches []string
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.HasPrefix(k.(string), "xl/pivotCache/pivotCacheDefinition") {
+			pivotCaches = append(pivotCaches, k.(string))
+		}
+		return true
+	})
+	for _, pivotCacheXML := range pivotCaches {
+		pc, err := f.pivotCacheReader(pivotCacheXML)
+		if err != nil {
 			return err
 		}
+		if pc.CacheSource == nil || pc.CacheSource.WorksheetSource == nil {
+			continue
+		}
+		source := pc.CacheSource.WorksheetSource
+		if source.Sheet != sheet || source.Ref == "" {
+			continue
+		}
+		ref, err := f.adjustCellRef(source.Ref, dir, num, offset)
+		if err != nil {
+			return err
+		}
+		if ref == "" || ref == source.Ref {
+			continue
+		}
+		source.Ref = ref
+		pivotCache, _ := xml.Marshal(pc)
+		f.saveFileList(pivotCacheXML, pivotCache)
 	}
 	return nil
 }
@@ -1022,6 +1022,78 @@ func TestAdjustConditionalFormats(t *testing.T) {
 	assert.NoError(t, f.RemoveCol("Sheet1", "B"))
 }
 
+func TestAdjustConditionalFormatsRefs(t *testing.T) {
+	f := NewFile()
+	formatID, err := f.NewConditionalStyle(&Style{Font: &Font{Color: "09600B"}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A5:A10", []ConditionalFormatOptions{
+		{Type: "formula", Criteria: "$B5>$C$1", Format: &formatID},
+	}))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "C3", []ConditionalFormatOptions{
+		{Type: "cell", Criteria: ">", Value: "0", Format: &formatID},
+	}))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).ExtLst = &xlsxExtLst{Ext: `<ext uri="{78C0D931-6437-407d-A8EE-F0AAD7539E65}"><x14:conditionalFormattings><x14:conditionalFormatting xmlns:xm="http://schemas.microsoft.com/office/excel/2006/main"><x14:cfRule type="dataBar" id="{00000000-0000-0000-0001-000000000001}"><x14:dataBar minLength="0" maxLength="100"><x14:cfvo type="num"><xm:f>$E$5</xm:f></x14:cfvo><x14:cfvo type="autoMax"/></x14:dataBar></x14:cfRule><xm:sqref>D5:D10</xm:sqref></x14:conditionalFormatting></x14:conditionalFormattings></ext>`}
+	// Test adjust conditional formats rules and data bar on inserting rows
+	assert.NoError(t, f.InsertRows("Sheet1", 2, 2))
+	opts, err := f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "$B7>$C$1", opts["A7:A12"][0].Criteria)
+	assert.Len(t, opts["C5"], 1)
+	assert.Contains(t, ws.(*xlsxWorksheet).ExtLst.Ext, "<xm:f>$E$7</xm:f>")
+	assert.Contains(t, ws.(*xlsxWorksheet).ExtLst.Ext, "<xm:sqref>D7:D12</xm:sqref>")
+	// Test the single row conditional format is kept on deleting other rows
+	assert.NoError(t, f.RemoveRow("Sheet1", 1))
+	opts, err = f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, opts["C4"], 1)
+	// Test remove the data bar on deleting the column
+	assert.NoError(t, f.RemoveCol("Sheet1", "D"))
+	assert.NotContains(t, ws.(*xlsxWorksheet).ExtLst.Ext, "<x14:conditionalFormatting ")
+	// Test adjust conditional formats with invalid formula
+	ws.(*xlsxWorksheet).ExtLst.Ext = `<ext uri="{78C0D931-6437-407d-A8EE-F0AAD7539E65}"><x14:conditionalFormattings><x14:conditionalFormatting><xm:sqref>-</xm:sqref></x14:conditionalFormatting></x14:conditionalFormattings></ext>`
+	assert.Equal(t, newCellNameToCoordinatesError("-", newInvalidCellNameError("-")), f.InsertRows("Sheet1", 1, 1))
+	assert.NoError(t, f.Close())
+}
+
+func TestAdjustSparklines(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddSparkline("Sheet1", &SparklineOptions{
+		Location: []string{"A5", "A6"},
+		Range:    []string{"Sheet2!A1:J1", "Sheet2!A2:J2"},
+	}))
+	assert.NoError(t, f.AddSparkline("Sheet2", &SparklineOptions{
+		Location: []string{"K1"},
+		Range:    []string{"Sheet2!A1:J1"},
+	}))
+	// Test adjust sparklines location on inserting rows
+	assert.NoError(t, f.InsertRows("Sheet1", 1, 2))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Contains(t, ws.(*xlsxWorksheet).ExtLst.Ext, "<x14:sparkline><xm:f>Sheet2!A1:J1</xm:f><xm:sqref>A7</xm:sqref></x14:sparkline>")
+	// Test adjust sparklines data range on inserting columns
+	assert.NoError(t, f.InsertCols("Sheet2", "B", 1))
+	assert.Contains(t, ws.(*xlsxWorksheet).ExtLst.Ext, "<x14:sparkline><xm:f>Sheet2!A2:K2</xm:f><xm:sqref>A8</xm:sqref></x14:sparkline>")
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet2.xml")
+	assert.True(t, ok)
+	assert.Contains(t, ws.(*xlsxWorksheet).ExtLst.Ext, "<x14:sparkline><xm:f>Sheet2!A1:K1</xm:f><xm:sqref>L1</xm:sqref></x14:sparkline>")
+	// Test remove sparkline on deleting its location
+	assert.NoError(t, f.RemoveRow("Sheet1", 7))
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.NotContains(t, ws.(*xlsxWorksheet).ExtLst.Ext, "Sheet2!A1:K1")
+	assert.Contains(t, ws.(*xlsxWorksheet).ExtLst.Ext, "<x14:sparkline><xm:f>Sheet2!A2:K2</xm:f><xm:sqref>A7</xm:sqref></x14:sparkline>")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAdjustSparklines.xlsx")))
+	// Test adjust sparklines with unsupported charset
+	f.Sheet.Delete("xl/worksheets/sheet2.xml")
+	f.Pkg.Store("xl/worksheets/sheet2.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.InsertRows("Sheet1", 1, 1), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestAdjustDataValidations(t *testing.T) {
 	f := NewFile()
 	dv := NewDataValidation(true)
@@ -1197,6 +1269,117 @@ func TestAdjustDrawings(t *testing.T) {
 	assert.NoError(t, err)
 	f.Pkg.Store("xl/drawings/drawing1.xml", []byte(xml.Header+`<wsDr xmlns="http://schemas.openxmlformats.org/drawingml/2006/spreadsheetDrawing"><twoCellAnchor><from><col>0</col><colOff>0</colOff><row>0</row><rowOff>0</rowOff></from><to><col>1</col><colOff>0</colOff><row>1</row><rowOff>0</rowOff></to><mc:AlternateContent xmlns:mc="http://schemas.openxmlformats.org/markup-compatibility/2006"></mc:AlternateContent><clientData/></twoCellAnchor></wsDr>`))
 	assert.NoError(t, f.InsertCols("Sheet1", "A", 1))
+
+	// Test adjust existing one cell anchor pictures on inserting rows
+	f, err = OpenFile(wb)
+	assert.NoError(t, err)
+	f.Pkg.Store("xl/drawings/drawing1.xml", []byte(xml.Header+`<wsDr xmlns="http://schemas.openxmlformats.org/drawingml/2006/spreadsheetDrawing"><oneCellAnchor><from><col>1</col><colOff>0</colOff><row>4</row><rowOff>0</rowOff></from><ext cx="1" cy="1"/><clientData/></oneCellAnchor></wsDr>`))
+	assert.NoError(t, f.InsertRows("Sheet1", 2, 2))
+	drawing, ok := f.Drawings.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	assert.Contains(t, drawing.(*xlsxWsDr).OneCellAnchor[0].GraphicFrame, "<xdr:row>6</xdr:row>")
+}
+
+func TestAdjustDrawingsAnchor(t *testing.T) {
+	f := NewFile()
+	series := []ChartSeries{{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"}}
+	assert.NoError(t, f.AddChart("Sheet1", "B2:E10", &Chart{Type: Col, Series: series}))
+	assert.NoError(t, f.AddChart("Sheet1", "G2:J10", &Chart{Type: Col, Series: series, Format: GraphicOptions{Positioning: "twoCell"}}))
+	assert.NoError(t, f.AddChart("Sheet1", "L2:N10", &Chart{Type: Col, Series: series, Format: GraphicOptions{Positioning: "oneCell"}}))
+	wsDr, _, err := f.drawingParser("xl/drawings/drawing1.xml")
+	assert.NoError(t, err)
+	wsDr.OneCellAnchor = append(wsDr.OneCellAnchor, &xdrCellAnchor{From: &xlsxFrom{Col: 1, Row: 11}, Ext: &aExt{Cx: 1, Cy: 1}})
+	// Test resize the two cell anchor charts on inserting rows within the anchor
+	assert.NoError(t, f.InsertRows("Sheet1", 5, 2))
+	for i, row := range []int{12, 12, 10} {
+		assert.Equal(t, 1, wsDr.TwoCellAnchor[i].From.Row)
+		assert.Equal(t, row, wsDr.TwoCellAnchor[i].To.Row)
+	}
+	// Test move the one cell anchor on inserting rows
+	assert.Equal(t, 13, wsDr.OneCellAnchor[0].From.Row)
+	// Test resize the two cell anchor charts on deleting columns within the anchor
+	assert.NoError(t, f.RemoveCol("Sheet1", "C"))
+	for i, col := range []int{4, 9, 13} {
+		assert.Equal(t, col, wsDr.TwoCellAnchor[i].To.Col)
+	}
+	assert.Equal(t, 1, wsDr.OneCellAnchor[0].From.Col)
+	assert.NoError(t, f.Close())
+}
+
+func TestAdjustCharts(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	series := []ChartSeries{{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"}}
+	assert.NoError(t, f.AddChart("Sheet2", "E1", &Chart{Type: Col, Series: series, Title: []RichTextRun{{Text: "=Sheet1!$A$1"}}}))
+	// Test adjust chart series reference on inserting rows and columns
+	assert.NoError(t, f.InsertRows("Sheet1", 2, 1))
+	assert.NoError(t, f.InsertCols("Sheet1", "C", 2))
+	assert.NoError(t, f.InsertRows("Sheet2", 1, 1))
+	content, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	for _, formula := range []string{"Sheet1!$A$3", "Sheet1!$B$1:$F$1", "Sheet1!$B$3:$F$3", "Sheet1!$A$1"} {
+		assert.Contains(t, string(content.([]byte)), "<f>"+formula+"</f>")
+	}
+	// Test adjust chart series reference on deleting column
+	assert.NoError(t, f.RemoveCol("Sheet1", "C"))
+	content, ok = f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	assert.Contains(t, string(content.([]byte)), "<f>Sheet1!$B$3:$E$3</f>")
+	// Test adjust chart with invalid reference
+	f.Pkg.Store("xl/charts/chart1.xml", []byte(`<c:chartSpace><c:f>Sheet1!A1048576</c:f></c:chartSpace>`))
+	assert.Equal(t, ErrMaxRows, f.InsertRows("Sheet1", 1, 1))
+	assert.NoError(t, f.Close())
+}
+
+func TestAdjustFormulaSheetNames(t *testing.T) {
+	names := map[string]string{"Sheet1": "Sheet 1", "Data": "Sales"}
+	for _, item := range [][]string{
+		{"Sheet1!A1", "'Sheet 1'!A1"},
+		{"=SUM(data!A:A)", "=SUM(Sales!A:A)"},
+		{"Sheet1!A1:Sheet1!B2", "'Sheet 1'!A1:'Sheet 1'!B2"},
+		{"Data:Sheet2!A1", "Sales:Sheet2!A1"},
+		{"'Data:Sheet1'!A1:B2", "'Sales:Sheet 1'!A1:B2"},
+		{"'It''s'!A1+Data!A1", "'It''s'!A1+Sales!A1"},
+		{"[1]Data!A1", "[1]Data!A1"},
+		{"Sheet2!A1", "Sheet2!A1"},
+		{"\"", "\""},
+	} {
+		assert.Equal(t, item[1], adjustFormulaSheetNames(item[0], names), item[0])
+	}
+}
+
+func TestAdjustPivotCaches(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{{"Month", "Year", "Sales"}, {"Jan", 2023, 100}, {"Feb", 2023, 200}} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", idx+1), &row))
+	}
+	assert.NoError(t, f.AddPivotTable(&PivotTableOptions{
+		DataRange:       "Sheet1!A1:C3",
+		PivotTableRange: "Sheet1!G2:M20",
+		Rows:            []PivotTableField{{Data: "Month"}},
+		Data:            []PivotTableField{{Data: "Sales"}},
+	}))
+	// Test adjust pivot cache source range on inserting rows within the range
+	assert.NoError(t, f.InsertRows("Sheet1", 3, 2))
+	pc, err := f.pivotCacheReader("xl/pivotCache/pivotCacheDefinition1.xml")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1:C5", pc.CacheSource.WorksheetSource.Ref)
+	// Test adjust pivot cache source range on deleting columns
+	assert.NoError(t, f.RemoveCol("Sheet1", "B"))
+	pc, err = f.pivotCacheReader("xl/pivotCache/pivotCacheDefinition1.xml")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1:B5", pc.CacheSource.WorksheetSource.Ref)
+	// Test adjust pivot cache with invalid source range
+	pc.CacheSource.WorksheetSource.Ref = "A"
+	pivotCache, err := xml.Marshal(pc)
+	assert.NoError(t, err)
+	f.saveFileList("xl/pivotCache/pivotCacheDefinition1.xml", pivotCache)
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.InsertRows("Sheet1", 1, 1))
+	// Test adjust pivot cache with unsupported charset
+	f.Pkg.Store("xl/pivotCache/pivotCacheDefinition1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.InsertRows("Sheet1", 1, 1), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
 }
 
 func TestAdjustDefinedNames(t *testing.T) {
@@ -1262,3 +1445,37 @@ func TestAdjustDefinedNames(t *testing.T) {
 	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
 	assert.EqualError(t, f.adjustDefinedNames(nil, "Sheet1", columns, 0, 0, 1), "XML syntax error on line 1: invalid UTF-8")
 }
+
+func TestAdjustCellWatches(t *testing.T) {
+	f := NewFile()
+	for _, cell := range []string{"A1", "B3", "C5", "E2"} {
+		assert.NoError(t, f.AddCellWatch("Sheet1", cell))
+	}
+	assert.NoError(t, f.InsertRows("Sheet1", 3, 2))
+	cells, err := f.GetCellWatches("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A1", "B5", "C7", "E2"}, cells)
+	assert.NoError(t, f.RemoveRow("Sheet1", 5))
+	cells, err = f.GetCellWatches("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A1", "C6", "E2"}, cells)
+	assert.NoError(t, f.InsertCols("Sheet1", "B", 1))
+	assert.NoError(t, f.RemoveCol("Sheet1", "F"))
+	cells, err = f.GetCellWatches("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A1", "D6"}, cells)
+	for _, cell := range cells {
+		assert.NoError(t, f.DeleteCellWatch("Sheet1", cell))
+	}
+	assert.NoError(t, f.AddCellWatch("Sheet1", "A1"))
+	assert.NoError(t, f.RemoveRow("Sheet1", 1))
+	cells, err = f.GetCellWatches("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, cells)
+	// Test adjust cell watches with invalid cell reference
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).CellWatches = &xlsxCellWatches{CellWatch: []xlsxCellWatch{{R: "A"}}}
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.RemoveRow("Sheet1", 1))
+	assert.NoError(t, f.Close())
+}
@@ -0,0 +1,113 @@
+// Copyright 2016 - 2024 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.18 or later.
+
+package excelize
+
+import "strconv"
+
+// Batch defined the batch mode for setting the workbook, the errors of each
+// set function call in the batch mode will be collected instead of failing at
+// the first error.
+type Batch struct {
+	file   *File
+	errors []error
+}
+
+// Batch provides a function to run multiple set function calls in batch
+// mode, the errors of each call will be collected, and returns an error of
+// type BatchError listing all failed calls at once, instead of failing at the
+// first error. The calls without error will be applied to the workbook. The
+// error returned by the given function will be collected too. For example,
+// set the cell values and styles in batch mode:
+//
+//	err := f.Batch(func(b *excelize.Batch) error {
+//	    b.SetCellValue("Sheet1", "A1", "Name")
+//	    b.SetCellValue("Sheet1", "B1", 100)
+//	    b.SetCellStyle("Sheet1", "A1", "B1", style)
+//	    return nil
+//	})
+//	var batchErr excelize.BatchError
+//	if errors.As(err, &batchErr) {
+//	    for _, err := range batchErr.Errors {
+//	        fmt.Println(err)
+//	    }
+//	}
+func (f *File) Batch(fn func(b *Batch) error) error {
+	b := &Batch{file: f}
+	if err := fn(b); err != nil {
+		b.errors = append(b.errors, err)
+	}
+	if len(b.errors) == 0 {
+		return nil
+	}
+	return BatchError{Errors: b.errors}
+}
+
+// Errors returns the errors which have been collected in the batch mode.
+func (b *Batch) Errors() []error {
+	return b.errors
+}
+
+// collect provides a function to collect the error of the set function call
+// by given function name, worksheet name, reference and error.
+func (b *Batch) collect(fn, sheet, ref string, err error) {
+	if err != nil {
+		b.errors = append(b.errors, BatchOpError{Op: fn, Sheet: sheet, Ref: ref, Err: err})
+	}
+}
+
+// SetCellValue provides a function to set the value of a cell in batch mode,
+// the usage is the same as the SetCellValue function of File.
+func (b *Batch) SetCellValue(sheet, cell string, value interface{}) {
+	b.collect("SetCellValue", sheet, cell, b.file.SetCellValue(sheet, cell, value))
+}
+
+// SetCellFormula provides a function to set the formula of a cell in batch
+// mode, the usage is the same as the SetCellFormula function of File.
+func (b *Batch) SetCellFormula(sheet, cell, formula string, opts ...FormulaOpts) {
+	b.collect("SetCellFormula", sheet, cell, b.file.SetCellFormula(sheet, cell, formula, opts...))
+}
+
+// SetCellHyperLink provides a function to set the hyperlink of a cell in
+// batch mode, the usage is the same as the SetCellHyperLink function of File.
+func (b *Batch) SetCellHyperLink(sheet, cell, link, linkType string, opts ...HyperlinkOpts) {
+	b.collect("SetCellHyperLink", sheet, cell, b.file.SetCellHyperLink(sheet, cell, link, linkType, opts...))
+}
+
+// SetCellStyle provides a function to set the style of a cell range in batch
+// mode, the usage is the same as the SetCellStyle function of File.
+func (b *Batch) SetCellStyle(sheet, topLeftCell, bottomRightCell string, styleID int) {
+	b.collect("SetCellStyle", sheet, topLeftCell+":"+bottomRightCell, b.file.SetCellStyle(sheet, topLeftCell, bottomRightCell, styleID))
+}
+
+// SetSheetRow provides a function to set the values of a row in batch mode,
+// the usage is the same as the SetSheetRow function of File.
+func (b *Batch) SetSheetRow(sheet, cell string, slice interface{}) {
+	b.collect("SetSheetRow", sheet, cell, b.file.SetSheetRow(sheet, cell, slice))
+}
+
+// MergeCell provides a function to merge a cell range in batch mode, the
+// usage is the same as the MergeCell function of File.
+func (b *Batch) MergeCell(sheet, topLeftCell, bottomRightCell string) {
+	b.collect("MergeCell", sheet, topLeftCell+":"+bottomRightCell, b.file.MergeCell(sheet, topLeftCell, bottomRightCell))
+}
+
+// SetRowHeight provides a function to set the height of a row in batch mode,
+// the usage is the same as the SetRowHeight function of File.
+func (b *Batch) SetRowHeight(sheet string, row int, height float64) {
+	b.collect("SetRowHeight", sheet, strconv.Itoa(row), b.file.SetRowHeight(sheet, row, height))
+}
+
+// SetColWidth provides a function to set the width of columns in batch mode,
+// the usage is the same as the SetColWidth function of File.
+func (b *Batch) SetColWidth(sheet, startCol, endCol string, width float64) {
+	b.collect("SetColWidth", sheet, startCol+":"+endCol, b.file.SetColWidth(sheet, startCol, endCol, width))
+}
@@ -0,0 +1,73 @@
+package excelize
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatch(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.Batch(func(b *Batch) error {
+		b.SetCellValue("Sheet1", "A1", "Name")
+		b.SetCellFormula("Sheet1", "B1", "SUM(1,2)")
+		b.SetCellHyperLink("Sheet1", "C1", "https://github.com/xuri/excelize", "External")
+		b.SetCellStyle("Sheet1", "A1", "C1", style)
+		b.SetSheetRow("Sheet1", "A2", &[]interface{}{1, 2, 3})
+		b.MergeCell("Sheet1", "A3", "C3")
+		b.SetRowHeight("Sheet1", 1, 30)
+		b.SetColWidth("Sheet1", "A", "C", 20)
+		assert.Empty(t, b.Errors())
+		return nil
+	}))
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Name", val)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestBatch.xlsx")))
+
+	// Test collect all errors in batch mode
+	errCallback := errors.New("callback error")
+	err = f.Batch(func(b *Batch) error {
+		b.SetCellValue("SheetN", "A1", "Name")
+		b.SetCellValue("Sheet1", "A", "Name")
+		b.SetCellValue("Sheet1", "D1", "Value")
+		b.SetCellFormula("Sheet1", "A", "SUM(1,2)")
+		b.SetCellHyperLink("Sheet1", "A", "https://github.com/xuri/excelize", "External")
+		b.SetCellStyle("Sheet1", "A1", "C1", 100)
+		b.SetSheetRow("Sheet1", "A", &[]interface{}{1})
+		b.MergeCell("Sheet1", "A", "C3")
+		b.SetRowHeight("Sheet1", 0, 30)
+		b.SetColWidth("Sheet1", "-", "C", 20)
+		assert.Len(t, b.Errors(), 9)
+		return errCallback
+	})
+	var batchErr BatchError
+	assert.True(t, errors.As(err, &batchErr))
+	assert.Len(t, batchErr.Errors, 10)
+	assert.Equal(t, errCallback, batchErr.Errors[9])
+	assert.Equal(t, batchErr.Errors, batchErr.Unwrap())
+	var opErr BatchOpError
+	assert.True(t, errors.As(batchErr.Errors[0], &opErr))
+	assert.Equal(t, BatchOpError{Op: "SetCellValue", Sheet: "SheetN", Ref: "A1", Err: ErrSheetNotExist{"SheetN"}}, opErr)
+	assert.True(t, errors.Is(batchErr.Errors[0], ErrSheetNotExist{"SheetN"}))
+	// Test match the collected errors on the batch error by errors.Is and errors.As
+	assert.True(t, errors.Is(err, ErrSheetNotExist{"SheetN"}))
+	assert.True(t, errors.Is(err, errCallback))
+	assert.True(t, batchErr.Is(errCallback))
+	assert.False(t, batchErr.Is(ErrParameterInvalid))
+	var rowErr ErrInvalidRowNumber
+	assert.True(t, batchErr.As(&rowErr))
+	assert.Equal(t, ErrInvalidRowNumber{Row: 0}, rowErr)
+	assert.False(t, batchErr.As(&ErrTableNotExist{}))
+	assert.EqualError(t, batchErr.Errors[7], "SetRowHeight Sheet1!0: invalid row number 0")
+	assert.Contains(t, err.Error(), "10 errors occurred in batch mode:\nSetCellValue SheetN!A1: sheet SheetN does not exist\n")
+	// Test the calls without error will be applied
+	val, err = f.GetCellValue("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Value", val)
+	assert.NoError(t, f.Close())
+}
@@ -220,6 +220,146 @@ type calcContext struct {
 	maxCalcIterations uint
 	iterations        map[string]uint
 	iterationsCache   map[string]formulaArg
+	inputs            map[string]formulaArg
+	trace             *CalcTrace
+	cache             *CalcCache
+	depth             int
+	decimal           bool
+}
+
+// CalcTraceNodeType is the type of the node in the calculation trace.
+type CalcTraceNodeType byte
+
+// This section defines the currently supported calculation trace node types
+// enumeration.
+const (
+	CalcTraceNodeCell CalcTraceNodeType = iota
+	CalcTraceNodeFunction
+	CalcTraceNodeRange
+)
+
+// CalcTraceNode directly maps the node in the calculation trace. The Name is
+// the reference of the cell with the worksheet name for the cell node, the
+// function name for the function node, and the reference of the cell range
+// with the worksheet name for the range node. The Depth is the nesting level
+// of the formula cell in which the node has been evaluated, the entry cell of
+// the calculation is at level 0. The Duration of the cell node includes the
+// time of evaluating all nodes in the formula of the cell, and the Duration of
+// the function node excludes the time of evaluating the function arguments.
+// The Result will be empty if the result of the node is a matrix.
+type CalcTraceNode struct {
+	Type     CalcTraceNodeType
+	Name     string
+	Result   string
+	Depth    int
+	Duration time.Duration
+}
+
+// CalcTrace directly maps the evaluation trace of the formula calculation,
+// which can be used for debugging why a particular formula is slow or wrong by
+// the CalcTrace option of the CalcCellValue function. The nodes will be
+// appended in the order of the evaluation finished, so that the nodes of the
+// cell will be placed before the cell node. For example, print the functions
+// called, ranges read and the time per node of the formula in cell A1 on
+// Sheet1:
+//
+//	trace := &excelize.CalcTrace{}
+//	result, err := f.CalcCellValue("Sheet1", "A1", excelize.Options{CalcTrace: trace})
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, node := range trace.Nodes {
+//	    fmt.Println(strings.Repeat("  ", node.Depth), node.Name, node.Result, node.Duration)
+//	}
+type CalcTrace struct {
+	mu    sync.Mutex
+	Nodes []CalcTraceNode
+}
+
+// CalcCache directly maps the cache of the intermediate results of the
+// formula cells, which can be shared across multiple CalcCellValue calls by the
+// CalcCache option to avoid calculating the same formula cell repeatedly. The
+// cache should be used with the same workbook only, and should be reset after
+// the cell values or formulas have been changed. A calculation cache is
+// concurrency safe. For example:
+//
+//	cache := excelize.NewCalcCache()
+//	for _, cell := range []string{"B1", "B2", "B3"} {
+//	    result, err := f.CalcCellValue("Sheet1", cell, excelize.Options{CalcCache: cache})
+//	    if err != nil {
+//	        fmt.Println(err)
+//	        return
+//	    }
+//	    fmt.Println(result)
+//	}
+type CalcCache struct {
+	mu     sync.RWMutex
+	values map[string]formulaArg
+}
+
+// NewCalcCache provides a function to create an empty calculation cache.
+func NewCalcCache() *CalcCache {
+	return &CalcCache{values: make(map[string]formulaArg)}
+}
+
+// Len provides a function to get the number of the cached results in the
+// calculation cache.
+func (c *CalcCache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.values)
+}
+
+// Reset provides a function to remove all cached results in the calculation
+// cache.
+func (c *CalcCache) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values = make(map[string]formulaArg)
+}
+
+// load provides a function to get the cached result by given cell reference
+// with the worksheet name.
+func (c *CalcCache) load(ref string) (formulaArg, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	arg, ok := c.values[strings.ToUpper(ref)]
+	return arg, ok
+}
+
+// store provides a function to cache the result by given cell reference with
+// the worksheet name.
+func (c *CalcCache) store(ref string, arg formulaArg) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.values == nil {
+		c.values = make(map[string]formulaArg)
+	}
+	c.values[strings.ToUpper(ref)] = arg
+}
+
+// getDepth provides a function to get the depth of the nested calculation of
+// the formula execution context concurrency safe.
+func (ctx *calcContext) getDepth() int {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	return ctx.depth
+}
+
+// traceNode provides a function to append the node to the calculation trace
+// by given node type, name, result and start time of the evaluation.
+func (ctx *calcContext) traceNode(typ CalcTraceNodeType, name string, arg formulaArg, start time.Time) {
+	if ctx == nil || ctx.trace == nil {
+		return
+	}
+	node := CalcTraceNode{Type: typ, Name: name, Depth: ctx.getDepth(), Duration: time.Since(start)}
+	if arg.Type != ArgMatrix {
+		node.Result = arg.Value()
+	}
+	ctx.trace.mu.Lock()
+	ctx.trace.Nodes = append(ctx.trace.Nodes, node)
+	ctx.trace.mu.Unlock()
 }
 
 // cellRef defines the structure of a cell reference.
@@ -255,6 +395,10 @@ const (
 	ArgEmpty
 )
 
+// FormulaArg is the argument and result of the custom formula function which
+// registered by the RegisterCalcFunc function.
+type FormulaArg = formulaArg
+
 // formulaArg is the argument of a formula or function.
 type formulaArg struct {
 	SheetName            string
@@ -819,18 +963,34 @@ func (f *File) CalcCellValue(sheet, cell string, opts ...Options) (result string
 		styleIdx     int
 		token        formulaArg
 	)
-	if token, err = f.calcCellValue(&calcContext{
-		entry:             fmt.Sprintf("%s!%s", sheet, cell),
-		maxCalcIterations: options.MaxCalcIterations,
-		iterations:        make(map[string]uint),
-		iterationsCache:   make(map[string]formulaArg),
-	}, sheet, cell); err != nil {
-		result = token.String
-		return
+	ref, cached := fmt.Sprintf("%s!%s", sheet, cell), false
+	if options.CalcCache != nil {
+		token, cached = options.CalcCache.load(ref)
+	}
+	if !cached {
+		if token, err = f.calcCellValue(&calcContext{
+			entry:             ref,
+			maxCalcIterations: options.MaxCalcIterations,
+			iterations:        make(map[string]uint),
+			iterationsCache:   make(map[string]formulaArg),
+			trace:             options.CalcTrace,
+			cache:             options.CalcCache,
+			decimal:           options.CalcDecimal,
+		}, sheet, cell); err != nil {
+			result = token.String
+			return
+		}
+		if options.CalcCache != nil {
+			options.CalcCache.store(ref, token)
+		}
 	}
 	if !rawCellValue {
 		styleIdx, _ = f.GetCellStyle(sheet, cell)
 	}
+	if token.Type == ArgNumber && !token.Boolean && token.String != "" {
+		result, err = f.formattedValue(&xlsxC{S: styleIdx, V: token.String}, rawCellValue, CellTypeNumber)
+		return
+	}
 	if token.Type == ArgNumber && !token.Boolean {
 		_, precision, decimal := isNumeric(token.Value())
 		if precision > 15 {
@@ -846,6 +1006,165 @@ func (f *File) CalcCellValue(sheet, cell string, opts ...Options) (result string
 	return
 }
 
+// GoalSeek provides a function to find the input value of the changing cell
+// which makes the formula of the target cell returns the target value by
+// given worksheet name, target cell reference, target value and changing cell
+// reference, that corresponding to the "Goal Seek" in Excel. The target cell
+// must contain a formula, and the changing cell must contain a constant value
+// or be empty. The changing cell will be set to the solved value and the
+// solved value will be returned, the changing cell will be restored and the
+// ErrGoalSeek error will be returned if the solution could not be found after
+// the maximum iterations. For example, find the interest rate in cell B1 which
+// makes the monthly payment calculated by the formula in cell B4 equals -900:
+//
+//	rate, err := f.GoalSeek("Sheet1", "B4", -900, "B1")
+func (f *File) GoalSeek(sheet, targetCell string, targetValue float64, byChangingCell string) (float64, error) {
+	formula, err := f.GetCellFormula(sheet, targetCell)
+	if err != nil {
+		return 0, err
+	}
+	if formula == "" {
+		return 0, ErrParameterInvalid
+	}
+	if formula, err = f.GetCellFormula(sheet, byChangingCell); err != nil {
+		return 0, err
+	}
+	value, err := f.GetCellValue(sheet, byChangingCell, Options{RawCellValue: true})
+	if err != nil {
+		return 0, err
+	}
+	x0, err := strconv.ParseFloat(value, 64)
+	if formula != "" || (value != "" && err != nil) {
+		return 0, ErrParameterInvalid
+	}
+	ws, _ := f.workSheetReader(sheet)
+	ws.mu.Lock()
+	c, _, _, _ := ws.prepareCell(byChangingCell)
+	origin := *c
+	ws.mu.Unlock()
+	restore := func(err error) (float64, error) {
+		ws.mu.Lock()
+		c, _, _, _ := ws.prepareCell(byChangingCell)
+		*c = origin
+		ws.mu.Unlock()
+		return 0, err
+	}
+	eval := func(x float64) (float64, error) {
+		if err := f.SetCellFloat(sheet, byChangingCell, x, -1, 64); err != nil {
+			return 0, err
+		}
+		token, err := f.calcCellValue(&calcContext{
+			entry:             fmt.Sprintf("%s!%s", sheet, targetCell),
+			maxCalcIterations: f.options.MaxCalcIterations,
+			iterations:        make(map[string]uint),
+			iterationsCache:   make(map[string]formulaArg),
+		}, sheet, targetCell)
+		if err != nil {
+			return 0, err
+		}
+		if num := token.ToNumber(); num.Type == ArgNumber {
+			return num.Number - targetValue, nil
+		}
+		return 0, ErrGoalSeek
+	}
+	precision := financialPrecision * math.Max(1, math.Abs(targetValue))
+	y0, err := eval(x0)
+	if err != nil {
+		return restore(err)
+	}
+	if math.Abs(y0) < precision {
+		return x0, err
+	}
+	x1 := x0 + 0.01
+	if x0 != 0 {
+		x1 = x0 * 1.01
+	}
+	for i := 0; i < maxFinancialIterations; i++ {
+		y1, err := eval(x1)
+		if err != nil {
+			return restore(err)
+		}
+		if math.Abs(y1) < precision {
+			return x1, err
+		}
+		if y1 == y0 {
+			break
+		}
+		x0, y0, x1 = x1, y1, x1-y1*(x1-x0)/(y1-y0)
+		if math.IsNaN(x1) || math.IsInf(x1, 0) {
+			break
+		}
+	}
+	return restore(ErrGoalSeek)
+}
+
+// RegisterCalcFunc provides a function to register the custom formula
+// function for the CalcCellValue function by given function name and the
+// function implementation, the function name is case-insensitive. The
+// arguments of the custom function are the evaluated arguments of the
+// function in the formula, the cell range reference argument will be passed
+// as a matrix. The registered function takes precedence over the built-in
+// function with the same name, and the registered function will be removed if
+// the function implementation is nil. For example, register a custom function
+// named "DISCOUNT" which returns the price after discount:
+//
+//	err := f.RegisterCalcFunc("DISCOUNT", func(args ...excelize.FormulaArg) excelize.FormulaArg {
+//	    if len(args) != 2 {
+//	        return excelize.FormulaArg{Type: excelize.ArgError, String: "#VALUE!", Error: "DISCOUNT requires 2 arguments"}
+//	    }
+//	    price, rate := args[0].ToNumber(), args[1].ToNumber()
+//	    if price.Type != excelize.ArgNumber || rate.Type != excelize.ArgNumber {
+//	        return excelize.FormulaArg{Type: excelize.ArgError, String: "#VALUE!", Error: "#VALUE!"}
+//	    }
+//	    return excelize.FormulaArg{Type: excelize.ArgNumber, Number: price.Number * (1 - rate.Number)}
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err := f.SetCellFormula("Sheet1", "C1", "DISCOUNT(A1,B1)"); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	result, err := f.CalcCellValue("Sheet1", "C1")
+func (f *File) RegisterCalcFunc(name string, fn func(args ...FormulaArg) FormulaArg) error {
+	if name = strings.TrimSpace(name); name == "" {
+		return ErrParameterRequired
+	}
+	for i, r := range name {
+		if (i == 0 && unicode.IsDigit(r)) || (!unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '.' && r != '_') {
+			return ErrParameterInvalid
+		}
+	}
+	if fn == nil {
+		f.calcFuncs.Delete(strings.ToUpper(name))
+		return nil
+	}
+	f.calcFuncs.Store(strings.ToUpper(name), fn)
+	return nil
+}
+
+// callCustomFunc provides a function to call the registered custom formula
+// function by given function name and arguments. The second return value will
+// be false if the function has not been registered.
+func (f *File) callCustomFunc(name string, argsList *list.List) (formulaArg, bool) {
+	for _, prefix := range []string{"_xlfn.", "_xludf.", "_xll."} {
+		if len(name) > len(prefix) && strings.EqualFold(name[:len(prefix)], prefix) {
+			name = name[len(prefix):]
+			break
+		}
+	}
+	fn, ok := f.calcFuncs.Load(strings.ToUpper(name))
+	if !ok {
+		return newEmptyFormulaArg(), ok
+	}
+	var args []FormulaArg
+	for arg := argsList.Front(); arg != nil; arg = arg.Next() {
+		args = append(args, arg.Value.(formulaArg))
+	}
+	return fn.(func(args ...FormulaArg) FormulaArg)(args...), ok
+}
+
 // calcCellValue calculate cell value by given context, worksheet name and cell
 // reference.
 func (f *File) calcCellValue(ctx *calcContext, sheet, cell string) (result formulaArg, err error) {
@@ -853,6 +1172,16 @@ func (f *File) calcCellValue(ctx *calcContext, sheet, cell string) (result formu
 	if formula, err = f.getCellFormula(sheet, cell, true); err != nil {
 		return
 	}
+	if ctx != nil && ctx.trace != nil {
+		start := time.Now()
+		defer func() { ctx.traceNode(CalcTraceNodeCell, fmt.Sprintf("%s!%s", sheet, cell), result, start) }()
+	}
+	if formula == "" || strings.HasPrefix(formula, "TABLE(") {
+		var ok bool
+		if result, ok, err = f.calcDataTable(ctx, sheet, cell); ok || err != nil {
+			return
+		}
+	}
 	ps := efp.ExcelParser()
 	tokens := ps.Parse(formula)
 	if tokens == nil {
@@ -862,6 +1191,82 @@ func (f *File) calcCellValue(ctx *calcContext, sheet, cell string) (result formu
 	return
 }
 
+// calcDataTable provides a function to calculate the result of the cell in
+// the what-if analysis data table by given worksheet name and cell reference,
+// the formula of the data table will be calculated with the input cells
+// replaced by the input values of the row and column of the cell. The second
+// return value will be false if the cell is not in any data table.
+func (f *File) calcDataTable(ctx *calcContext, sheet, cell string) (formulaArg, bool, error) {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return newEmptyFormulaArg(), false, err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return newEmptyFormulaArg(), false, err
+	}
+	var (
+		fx     *xlsxF
+		x1, y1 int
+	)
+	ws.mu.Lock()
+	for _, r := range ws.SheetData.Row {
+		if r.R > row {
+			break
+		}
+		for _, c := range r.C {
+			if c.F == nil || c.F.T != STCellFormulaTypeDataTable || c.F.Ref == "" {
+				continue
+			}
+			ref := c.F.Ref
+			if !strings.Contains(ref, ":") {
+				ref += ":" + ref
+			}
+			if coordinates, err := rangeRefToCoordinates(ref); err == nil && cellInRange([]int{col, row}, coordinates) {
+				fx, x1, y1 = c.F, coordinates[0]-1, coordinates[1]-1
+			}
+		}
+	}
+	ws.mu.Unlock()
+	if fx == nil {
+		return newEmptyFormulaArg(), false, err
+	}
+	if fx.Del1 || fx.Del2 || x1 < 1 || y1 < 1 {
+		return newErrorFormulaArg(formulaErrorREF, formulaErrorREF), true, errors.New(formulaErrorREF)
+	}
+	cellName := func(col, row int) string {
+		name, _ := CoordinatesToCellName(col, row)
+		return name
+	}
+	formulaCell, inputs := cellName(col, y1), map[string]string{fx.R1: cellName(x1, row)}
+	if fx.Dt2D {
+		formulaCell, inputs = cellName(x1, y1), map[string]string{fx.R1: cellName(col, y1), fx.R2: cellName(x1, row)}
+	} else if fx.Dtr {
+		formulaCell, inputs = cellName(x1, row), map[string]string{fx.R1: cellName(col, y1)}
+	}
+	dtCtx := &calcContext{
+		entry:             fmt.Sprintf("%s!%s", sheet, formulaCell),
+		maxCalcIterations: ctx.maxCalcIterations,
+		iterations:        make(map[string]uint),
+		iterationsCache:   make(map[string]formulaArg),
+		inputs:            make(map[string]formulaArg),
+		trace:             ctx.trace,
+		depth:             ctx.getDepth() + 1,
+	}
+	for ref, arg := range ctx.inputs {
+		dtCtx.inputs[ref] = arg
+	}
+	for inputCell, valueCell := range inputs {
+		arg, err := f.cellResolver(ctx, sheet, valueCell)
+		if err != nil {
+			return arg, true, err
+		}
+		dtCtx.inputs[strings.ToUpper(fmt.Sprintf("%s!%s", sheet, strings.ReplaceAll(inputCell, "$", "")))] = arg
+	}
+	arg, err := f.calcCellValue(dtCtx, sheet, formulaCell)
+	return arg, true, err
+}
+
 // getPriority calculate arithmetic operator priority.
 func getPriority(token efp.Token) (pri int) {
 	pri = tokenPriority[token.TValue]
@@ -1024,7 +1429,7 @@ func (f *File) evalInfixExp(ctx *calcContext, sheet, cell string, tokens []efp.T
 				for opftStack.Peek().(efp.Token) != opfStack.Peek().(efp.Token) {
 					// calculate trigger
 					topOpt := opftStack.Peek().(efp.Token)
-					if err := calculate(opfdStack, topOpt); err != nil {
+					if err := calculate(ctx, opfdStack, topOpt); err != nil {
 						argsStack.Peek().(*list.List).PushFront(newErrorFormulaArg(formulaErrorVALUE, err.Error()))
 					}
 					opftStack.Pop()
@@ -1056,7 +1461,7 @@ func (f *File) evalInfixExp(ctx *calcContext, sheet, cell string, tokens []efp.T
 	}
 	for optStack.Len() != 0 {
 		topOpt := optStack.Peek().(efp.Token)
-		if err = calculate(opdStack, topOpt); err != nil {
+		if err = calculate(ctx, opdStack, topOpt); err != nil {
 			return newEmptyFormulaArg(), err
 		}
 		optStack.Pop()
@@ -1072,11 +1477,16 @@ func (f *File) evalInfixExpFunc(ctx *calcContext, sheet, cell string, token, nex
 	if !isFunctionStopToken(token) {
 		return newEmptyFormulaArg()
 	}
-	prepareEvalInfixExp(opfStack, opftStack, opfdStack, argsStack)
+	prepareEvalInfixExp(ctx, opfStack, opftStack, opfdStack, argsStack)
 	// call formula function to evaluate
-	arg := callFuncByName(&formulaFuncs{f: f, sheet: sheet, cell: cell, ctx: ctx}, strings.NewReplacer(
-		"_xlfn.", "", ".", "dot").Replace(opfStack.Peek().(efp.Token).TValue),
-		[]reflect.Value{reflect.ValueOf(argsStack.Peek().(*list.List))})
+	start := time.Now()
+	arg, ok := f.callCustomFunc(opfStack.Peek().(efp.Token).TValue, argsStack.Peek().(*list.List))
+	if !ok {
+		arg = callFuncByName(&formulaFuncs{f: f, sheet: sheet, cell: cell, ctx: ctx}, strings.NewReplacer(
+			"_xlfn.", "", ".", "dot").Replace(opfStack.Peek().(efp.Token).TValue),
+			[]reflect.Value{reflect.ValueOf(argsStack.Peek().(*list.List))})
+	}
+	ctx.traceNode(CalcTraceNodeFunction, strings.ToUpper(opfStack.Peek().(efp.Token).TValue), arg, start)
 	if arg.Type == ArgError && opfStack.Len() == 1 {
 		return arg
 	}
@@ -1102,12 +1512,12 @@ func (f *File) evalInfixExpFunc(ctx *calcContext, sheet, cell string, token, nex
 
 // prepareEvalInfixExp check the token and stack state for formula function
 // evaluate.
-func prepareEvalInfixExp(opfStack, opftStack, opfdStack, argsStack *Stack) {
+func prepareEvalInfixExp(ctx *calcContext, opfStack, opftStack, opfdStack, argsStack *Stack) {
 	// current token is function stop
 	for opftStack.Peek().(efp.Token) != opfStack.Peek().(efp.Token) {
 		// calculate trigger
 		topOpt := opftStack.Peek().(efp.Token)
-		if err := calculate(opfdStack, topOpt); err != nil {
+		if err := calculate(ctx, opfdStack, topOpt); err != nil {
 			argsStack.Peek().(*list.List).PushBack(newErrorFormulaArg(err.Error(), err.Error()))
 			opftStack.Pop()
 			continue
@@ -1293,13 +1703,94 @@ func calcDiv(rOpd, lOpd formulaArg, opdStack *Stack) error {
 	return nil
 }
 
+// decimalRat returns the exact rational number of the formula argument by the
+// decimal number text of it, the false will be returned if the argument is
+// not a number.
+func decimalRat(arg formulaArg) (*big.Rat, bool) {
+	text := arg.String
+	switch arg.Type {
+	case ArgNumber:
+		if arg.Boolean {
+			return nil, false
+		}
+		if text == "" {
+			text = strconv.FormatFloat(arg.Number, 'g', -1, 64)
+		}
+	case ArgString:
+		if text == "" {
+			return new(big.Rat), true
+		}
+		if !decimalExp.MatchString(text) {
+			return nil, false
+		}
+	default:
+		return nil, false
+	}
+	// avoid the huge rational numbers by the exponent out of the float64 range
+	if i := strings.IndexAny(text, "eE"); i != -1 {
+		if exp, _ := strconv.Atoi(text[i+1:]); exp > 308 || exp < -324 {
+			return nil, false
+		}
+	}
+	return new(big.Rat).SetString(text)
+}
+
+// calcDecimal evaluate addition, subtraction and multiplication arithmetic
+// operations with the exact decimal numbers, the result will be kept as the
+// decimal number text in the formula argument. The false will be returned if
+// any of the operands is not a number.
+func calcDecimal(operator string, rOpd, lOpd formulaArg) (formulaArg, bool) {
+	if operator != "+" && operator != "-" && operator != "*" {
+		return newEmptyFormulaArg(), false
+	}
+	lRat, ok := decimalRat(lOpd)
+	if !ok {
+		return newEmptyFormulaArg(), false
+	}
+	rRat, ok := decimalRat(rOpd)
+	if !ok {
+		return newEmptyFormulaArg(), false
+	}
+	result := new(big.Rat)
+	switch operator {
+	case "+":
+		result.Add(lRat, rRat)
+	case "-":
+		result.Sub(lRat, rRat)
+	default:
+		result.Mul(lRat, rRat)
+	}
+	// The denominator of the sum, difference or product of the decimal
+	// numbers only has the prime factors 2 and 5, the number of the decimal
+	// places is the greater exponent of them.
+	var places int
+	two, five, rem := big.NewInt(2), big.NewInt(5), new(big.Int)
+	for denom := new(big.Int).Set(result.Denom()); !denom.IsInt64() || denom.Int64() != 1; places++ {
+		if rem.Rem(denom, two).Sign() == 0 {
+			denom.Quo(denom, two)
+		}
+		if rem.Rem(denom, five).Sign() == 0 {
+			denom.Quo(denom, five)
+		}
+	}
+	num, _ := result.Float64()
+	return formulaArg{Type: ArgNumber, Number: num, String: result.FloatString(places)}, true
+}
+
 // calculate evaluate basic arithmetic operations.
-func calculate(opdStack *Stack, opt efp.Token) error {
+func calculate(ctx *calcContext, opdStack *Stack, opt efp.Token) error {
+	decimal := ctx != nil && ctx.decimal
 	if opt.TValue == "-" && opt.TType == efp.TokenTypeOperatorPrefix {
 		if opdStack.Len() < 1 {
 			return ErrInvalidFormula
 		}
 		opd := opdStack.Pop().(formulaArg)
+		if decimal {
+			if arg, ok := calcDecimal(opt.TValue, opd, newNumberFormulaArg(0)); ok {
+				opdStack.Push(arg)
+				return nil
+			}
+		}
 		opdStack.Push(newNumberFormulaArg(0 - opd.ToNumber().Number))
 	}
 	if opt.TValue == "-" && opt.TType == efp.TokenTypeOperatorInfix {
@@ -1308,6 +1799,12 @@ func calculate(opdStack *Stack, opt efp.Token) error {
 		}
 		rOpd := opdStack.Pop().(formulaArg)
 		lOpd := opdStack.Pop().(formulaArg)
+		if decimal {
+			if arg, ok := calcDecimal(opt.TValue, rOpd, lOpd); ok {
+				opdStack.Push(arg)
+				return nil
+			}
+		}
 		if err := calcSubtract(rOpd, lOpd, opdStack); err != nil {
 			return err
 		}
@@ -1345,13 +1842,19 @@ func calculate(opdStack *Stack, opt efp.Token) error {
 		if lOpd.Type == ArgError {
 			return errors.New(lOpd.Value())
 		}
+		if decimal {
+			if arg, ok := calcDecimal(opt.TValue, rOpd, lOpd); ok {
+				opdStack.Push(arg)
+				return nil
+			}
+		}
 		return fn(rOpd, lOpd, opdStack)
 	}
 	return nil
 }
 
 // parseOperatorPrefixToken parse operator prefix token.
-func (f *File) parseOperatorPrefixToken(optStack, opdStack *Stack, token efp.Token) (err error) {
+func (f *File) parseOperatorPrefixToken(ctx *calcContext, optStack, opdStack *Stack, token efp.Token) (err error) {
 	if optStack.Len() == 0 {
 		optStack.Push(token)
 		return
@@ -1369,7 +1872,7 @@ func (f *File) parseOperatorPrefixToken(optStack, opdStack *Stack, token efp.Tok
 	}
 	for tokenPriority <= topOptPriority {
 		optStack.Pop()
-		if err = calculate(opdStack, topOpt); err != nil {
+		if err = calculate(ctx, opdStack, topOpt); err != nil {
 			return
 		}
 		if optStack.Len() > 0 {
@@ -1454,10 +1957,15 @@ func (f *File) parseToken(ctx *calcContext, sheet string, token efp.Token, opdSt
 		if err != nil {
 			return errors.New(formulaErrorNAME)
 		}
+		if result.Type == ArgNumber && result.String != "" {
+			// keep the decimal number text of the cell value
+			opdStack.Push(result)
+			return nil
+		}
 		token = formulaArgToToken(result)
 	}
 	if isOperatorPrefixToken(token) {
-		if err := f.parseOperatorPrefixToken(optStack, opdStack, token); err != nil {
+		if err := f.parseOperatorPrefixToken(ctx, optStack, opdStack, token); err != nil {
 			return err
 		}
 	}
@@ -1467,7 +1975,7 @@ func (f *File) parseToken(ctx *calcContext, sheet string, token efp.Token, opdSt
 	if isEndParenthesesToken(token) { // )
 		for !isBeginParenthesesToken(optStack.Peek().(efp.Token)) { // != (
 			topOpt := optStack.Peek().(efp.Token)
-			if err := calculate(opdStack, topOpt); err != nil {
+			if err := calculate(ctx, opdStack, topOpt); err != nil {
 				return err
 			}
 			optStack.Pop()
@@ -1539,8 +2047,15 @@ func (cr *cellRange) prepareCellRange(col, row bool, cellRef cellRef) error {
 
 // parseReference parse reference and extract values by given reference
 // characters and default sheet name.
-func (f *File) parseReference(ctx *calcContext, sheet, reference string) (formulaArg, error) {
+func (f *File) parseReference(ctx *calcContext, sheet, reference string) (arg formulaArg, err error) {
 	reference = strings.ReplaceAll(reference, "$", "")
+	if ctx != nil && ctx.trace != nil {
+		name, start := reference, time.Now()
+		if !strings.Contains(name, "!") {
+			name = sheet + "!" + name
+		}
+		defer func() { ctx.traceNode(CalcTraceNodeRange, name, arg, start) }()
+	}
 	ranges, cellRanges, cellRefs := strings.Split(reference, ":"), list.New(), list.New()
 	if len(ranges) > 1 {
 		var cr cellRange
@@ -1620,18 +2135,35 @@ func (f *File) cellResolver(ctx *calcContext, sheet, cell string) (formulaArg, e
 		err   error
 	)
 	ref := fmt.Sprintf("%s!%s", sheet, cell)
+	if arg, ok := ctx.inputs[strings.ToUpper(ref)]; ok {
+		return arg, err
+	}
 	if formula, _ := f.getCellFormula(sheet, cell, true); len(formula) != 0 {
 		ctx.mu.Lock()
 		if ctx.entry != ref {
+			if ctx.cache != nil && len(ctx.inputs) == 0 {
+				if arg, ok := ctx.cache.load(ref); ok {
+					ctx.mu.Unlock()
+					return arg, nil
+				}
+			}
 			if ctx.iterations[ref] <= f.options.MaxCalcIterations {
 				ctx.iterations[ref]++
+				ctx.depth++
 				ctx.mu.Unlock()
 				arg, _ = f.calcCellValue(ctx, sheet, cell)
+				ctx.mu.Lock()
+				ctx.depth--
 				ctx.iterationsCache[ref] = arg
+				ctx.mu.Unlock()
+				if ctx.cache != nil && len(ctx.inputs) == 0 {
+					ctx.cache.store(ref, arg)
+				}
 				return arg, nil
 			}
+			arg = ctx.iterationsCache[ref]
 			ctx.mu.Unlock()
-			return ctx.iterationsCache[ref], nil
+			return arg, nil
 		}
 		ctx.mu.Unlock()
 	}
@@ -1647,7 +2179,10 @@ func (f *File) cellResolver(ctx *calcContext, sheet, cell string) (formulaArg, e
 		if arg.Value() == "" {
 			return newEmptyFormulaArg(), err
 		}
-		return arg.ToNumber(), err
+		if arg = arg.ToNumber(); ctx.decimal && arg.Type == ArgNumber {
+			arg.String = value
+		}
+		return arg, err
 	case CellTypeInlineString, CellTypeSharedString:
 		return arg, err
 	case CellTypeFormula:
@@ -4,8 +4,11 @@ import (
 	"container/list"
 	"math"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/xuri/efp"
@@ -6446,3 +6449,211 @@ func TestParseToken(t *testing.T) {
 		efp.Token{TSubType: efp.TokenSubTypeRange, TValue: "1A"}, nil, nil,
 	).Error())
 }
+
+func TestGoalSeek(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Rate", 0.05}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"Periods", 360}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A3", &[]interface{}{"Amount", 100000}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B4", "PMT(B1/12,B2,B3)"))
+	rate, err := f.GoalSeek("Sheet1", "B4", -900, "B1")
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.1030, rate, 0.0001)
+	result, err := f.CalcCellValue("Sheet1", "B4")
+	assert.NoError(t, err)
+	payment, err := strconv.ParseFloat(result, 64)
+	assert.NoError(t, err)
+	assert.InDelta(t, -900, payment, 1e-6)
+	value, err := f.GetCellValue("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, "0.103024135197266", value)
+	// Test goal seek with the target value has been reached
+	solved, err := f.GoalSeek("Sheet1", "B4", -900, "B1")
+	assert.NoError(t, err)
+	assert.InDelta(t, rate, solved, 1e-12)
+	// Test goal seek with empty changing cell
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "C1*C1-2"))
+	root, err := f.GoalSeek("Sheet1", "D1", 0, "C1")
+	assert.NoError(t, err)
+	assert.InDelta(t, math.Sqrt2, root, 1e-8)
+	// Test goal seek without solution
+	assert.NoError(t, f.SetCellValue("Sheet1", "C2", 3))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D2", "C2*C2+1"))
+	_, err = f.GoalSeek("Sheet1", "D2", 0, "C2")
+	assert.Equal(t, ErrGoalSeek, err)
+	value, err = f.GetCellValue("Sheet1", "C2")
+	assert.NoError(t, err)
+	assert.Equal(t, "3", value)
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D3", "IF(C3>0,1,0)"))
+	_, err = f.GoalSeek("Sheet1", "D3", 2, "C3")
+	assert.Equal(t, ErrGoalSeek, err)
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D4", "\"text\"&C4"))
+	_, err = f.GoalSeek("Sheet1", "D4", 2, "C4")
+	assert.Equal(t, ErrGoalSeek, err)
+	// Test goal seek with formula error
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D5", "1/C5"))
+	_, err = f.GoalSeek("Sheet1", "D5", 2, "C5")
+	assert.EqualError(t, err, formulaErrorDIV)
+	assert.NoError(t, f.SetCellValue("Sheet1", "C5", 1))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D5", "1/(C5-1.01)"))
+	_, err = f.GoalSeek("Sheet1", "D5", 2, "C5")
+	assert.EqualError(t, err, formulaErrorDIV)
+	value, err = f.GetCellValue("Sheet1", "C5")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", value)
+	// Test goal seek with invalid cells
+	_, err = f.GoalSeek("Sheet1", "B3", 0, "B1")
+	assert.Equal(t, ErrParameterInvalid, err)
+	_, err = f.GoalSeek("Sheet1", "B4", 0, "D1")
+	assert.Equal(t, ErrParameterInvalid, err)
+	_, err = f.GoalSeek("Sheet1", "B4", 0, "A1")
+	assert.Equal(t, ErrParameterInvalid, err)
+	_, err = f.GoalSeek("Sheet1", "A", 0, "B1")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	_, err = f.GoalSeek("Sheet1", "B4", 0, "B")
+	assert.Equal(t, newCellNameToCoordinatesError("B", newInvalidCellNameError("B")), err)
+	_, err = f.GoalSeek("SheetN", "B4", 0, "B1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
+func TestCalcTraceAndCache(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{1, 2, 3}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B2", "SUM(A1:C1)"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C2", "ABS(B2)*2"))
+	// Test calculate with trace
+	trace := &CalcTrace{}
+	result, err := f.CalcCellValue("Sheet1", "C2", Options{CalcTrace: trace})
+	assert.NoError(t, err)
+	assert.Equal(t, "12", result)
+	var nodes []CalcTraceNode
+	for _, node := range trace.Nodes {
+		assert.GreaterOrEqual(t, node.Duration, time.Duration(0))
+		node.Duration = 0
+		nodes = append(nodes, node)
+	}
+	assert.Equal(t, []CalcTraceNode{
+		{Type: CalcTraceNodeRange, Name: "Sheet1!A1:C1", Depth: 1},
+		{Type: CalcTraceNodeFunction, Name: "SUM", Result: "6", Depth: 1},
+		{Type: CalcTraceNodeCell, Name: "Sheet1!B2", Result: "6", Depth: 1},
+		{Type: CalcTraceNodeRange, Name: "Sheet1!B2", Result: "6"},
+		{Type: CalcTraceNodeFunction, Name: "ABS", Result: "6"},
+		{Type: CalcTraceNodeCell, Name: "Sheet1!C2", Result: "12"},
+	}, nodes)
+	// Test calculate with cache shared across multiple calls
+	cache := NewCalcCache()
+	result, err = f.CalcCellValue("Sheet1", "C2", Options{CalcCache: cache})
+	assert.NoError(t, err)
+	assert.Equal(t, "12", result)
+	assert.Equal(t, 2, cache.Len())
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 5))
+	trace = &CalcTrace{}
+	result, err = f.CalcCellValue("Sheet1", "C2", Options{CalcCache: cache, CalcTrace: trace})
+	assert.NoError(t, err)
+	assert.Equal(t, "12", result)
+	assert.Empty(t, trace.Nodes)
+	result, err = f.CalcCellValue("Sheet1", "B2", Options{CalcCache: cache})
+	assert.NoError(t, err)
+	assert.Equal(t, "6", result)
+	// Test calculate after reset the cache
+	cache.Reset()
+	assert.Zero(t, cache.Len())
+	result, err = f.CalcCellValue("Sheet1", "C2", Options{CalcCache: cache, CalcTrace: trace})
+	assert.NoError(t, err)
+	assert.Equal(t, "20", result)
+	assert.Len(t, trace.Nodes, 6)
+	// Test calculate with cache in zero value
+	result, err = f.CalcCellValue("Sheet1", "C2", Options{CalcCache: &CalcCache{}})
+	assert.NoError(t, err)
+	assert.Equal(t, "20", result)
+	// Test calculate with invalid cell reference
+	_, err = f.CalcCellValue("Sheet1", "A", Options{CalcCache: cache, CalcTrace: trace})
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	// Test resolve cells with trace in the same context concurrency
+	ctx := &calcContext{
+		entry:             "Sheet1!D2",
+		maxCalcIterations: f.options.MaxCalcIterations,
+		iterations:        make(map[string]uint),
+		iterationsCache:   make(map[string]formulaArg),
+		trace:             &CalcTrace{},
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := f.cellResolver(ctx, "Sheet1", "C2")
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	assert.Zero(t, ctx.getDepth())
+	assert.NotEmpty(t, ctx.trace.Nodes)
+}
+
+func TestRegisterCalcFunc(t *testing.T) {
+	f := NewFile()
+	discount := func(args ...FormulaArg) FormulaArg {
+		if len(args) != 2 {
+			return newErrorFormulaArg(formulaErrorVALUE, "DISCOUNT requires 2 arguments")
+		}
+		price, rate := args[0].ToNumber(), args[1].ToNumber()
+		if price.Type != ArgNumber || rate.Type != ArgNumber {
+			return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+		}
+		return newNumberFormulaArg(price.Number * (1 - rate.Number))
+	}
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{200, 0.25, 3}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{1, 2, 3}))
+	// Test calculate with unregistered custom function
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "DISCOUNT(A1,B1)"))
+	result, err := f.CalcCellValue("Sheet1", "D1")
+	assert.EqualError(t, err, "not support DISCOUNT function")
+	assert.Equal(t, formulaErrorVALUE, result)
+	// Test register custom function
+	assert.NoError(t, f.RegisterCalcFunc("discount", discount))
+	result, err = f.CalcCellValue("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, "150", result)
+	// Test calculate nested and prefixed custom function
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "SUM(_xludf.DISCOUNT(A1*2,B1),C1)"))
+	result, err = f.CalcCellValue("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, "303", result)
+	// Test calculate custom function with error result
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "DISCOUNT(A1)"))
+	result, err = f.CalcCellValue("Sheet1", "D1")
+	assert.EqualError(t, err, "DISCOUNT requires 2 arguments")
+	assert.Equal(t, formulaErrorVALUE, result)
+	// Test calculate custom function with cell range reference argument
+	assert.NoError(t, f.RegisterCalcFunc("COUNTARGS", func(args ...FormulaArg) FormulaArg {
+		var count int
+		for _, arg := range args {
+			count += len(arg.ToList())
+		}
+		return newNumberFormulaArg(float64(count))
+	}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "COUNTARGS(A1:C2,A1)"))
+	result, err = f.CalcCellValue("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, "7", result)
+	// Test override built-in function
+	assert.NoError(t, f.RegisterCalcFunc("ABS", func(args ...FormulaArg) FormulaArg {
+		return newStringFormulaArg("custom")
+	}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "ABS(-1)"))
+	result, err = f.CalcCellValue("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, "custom", result)
+	// Test unregister custom function
+	assert.NoError(t, f.RegisterCalcFunc("ABS", nil))
+	result, err = f.CalcCellValue("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", result)
+	// Test register custom function with invalid name
+	assert.Equal(t, ErrParameterRequired, f.RegisterCalcFunc(" ", discount))
+	for _, name := range []string{"1DISCOUNT", "DIS COUNT", "DIS-COUNT"} {
+		assert.Equal(t, ErrParameterInvalid, f.RegisterCalcFunc(name, discount))
+	}
+}
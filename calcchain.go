@@ -15,6 +15,8 @@ import (
 	"bytes"
 	"encoding/xml"
 	"io"
+	"sort"
+	"strings"
 )
 
 // calcChainReader provides a function to get the pointer to the structure
@@ -52,20 +54,167 @@ func (f *File) deleteCalcChain(index int, cell string) error {
 		})
 	}
 	if len(calc.C) == 0 {
-		f.CalcChain = nil
-		f.Pkg.Delete(defaultXMLPathCalcChain)
-		content, err := f.contentTypesReader()
+		return f.removeCalcChain()
+	}
+	return err
+}
+
+// removeCalcChain provides a function to remove the calculation chain part
+// and the content type of it in the workbook.
+func (f *File) removeCalcChain() error {
+	f.CalcChain = nil
+	f.Pkg.Delete(defaultXMLPathCalcChain)
+	content, err := f.contentTypesReader()
+	if err != nil {
+		return err
+	}
+	content.mu.Lock()
+	defer content.mu.Unlock()
+	for k, v := range content.Overrides {
+		if v.PartName == "/xl/calcChain.xml" {
+			content.Overrides = append(content.Overrides[:k], content.Overrides[k+1:]...)
+		}
+	}
+	return err
+}
+
+// GetCalcChain provides a function to get the cells in the calculation chain
+// of the workbook, the cells are returned in the order of the calculation
+// chain. For example:
+//
+//	cells, err := f.GetCalcChain()
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, c := range cells {
+//	    fmt.Println(c.Sheet, c.Cell)
+//	}
+func (f *File) GetCalcChain() ([]CalcChainCell, error) {
+	var cells []CalcChainCell
+	calc, err := f.calcChainReader()
+	if err != nil {
+		return cells, err
+	}
+	sheetMap, sheetID := f.GetSheetMap(), 0
+	for _, c := range calc.C {
+		if c.I != 0 {
+			sheetID = c.I
+		}
+		cells = append(cells, CalcChainCell{Sheet: sheetMap[sheetID], Cell: c.R, Array: c.A})
+	}
+	return cells, err
+}
+
+// calcChainNode defined the formula cell for rebuilding the calculation
+// chain.
+type calcChainNode struct {
+	sheet, cell string
+	sheetID     int
+	col, row    int
+	array       bool
+	refs        []cellRange
+	state       byte
+}
+
+// RebuildCalcChain provides a function to regenerate the calculation chain by
+// the formulas in all worksheets of the workbook. The formula cells will be
+// ordered by the dependencies of the formulas, the referenced formula cells
+// will be calculated before the formula cells which referenced them. The
+// calculation chain will be removed if there are no formulas in the workbook.
+// This function is useful when the calculation chain is corrupted after heavy
+// editing. For example:
+//
+//	err := f.RebuildCalcChain()
+func (f *File) RebuildCalcChain() error {
+	var (
+		nodes        []*calcChainNode
+		definedNames = f.GetDefinedName()
+		cells        = map[string][]*calcChainNode{}
+	)
+	for _, sheet := range f.GetSheetList() {
+		ws, err := f.workSheetReader(sheet)
 		if err != nil {
+			if _, ok := err.(ErrNotWorksheet); ok {
+				continue
+			}
 			return err
 		}
-		content.mu.Lock()
-		defer content.mu.Unlock()
-		for k, v := range content.Overrides {
-			if v.PartName == "/xl/calcChain.xml" {
-				content.Overrides = append(content.Overrides[:k], content.Overrides[k+1:]...)
+		sheetID, sheetKey := f.getSheetID(sheet), strings.ToLower(sheet)
+		ws.mu.Lock()
+		for _, r := range ws.SheetData.Row {
+			for _, c := range r.C {
+				if c.F == nil {
+					continue
+				}
+				formula := c.F.Content
+				if c.F.T == STCellFormulaTypeShared && c.F.Si != nil {
+					formula = getSharedFormula(ws, *c.F.Si, c.R)
+				}
+				if formula == "" {
+					continue
+				}
+				col, row, err := CellNameToCoordinates(c.R)
+				if err != nil {
+					ws.mu.Unlock()
+					return err
+				}
+				node := &calcChainNode{
+					sheet: sheet, cell: c.R, sheetID: sheetID, col: col, row: row,
+					array: c.F.T == STCellFormulaTypeArray, refs: getFormulaRefs(sheet, formula, definedNames),
+				}
+				cells[sheetKey] = append(cells[sheetKey], node)
+				nodes = append(nodes, node)
+			}
+		}
+		ws.mu.Unlock()
+	}
+	if len(nodes) == 0 {
+		if _, ok := f.Pkg.Load(defaultXMLPathCalcChain); ok || f.CalcChain != nil {
+			return f.removeCalcChain()
+		}
+		return nil
+	}
+	calc := &xlsxCalcChain{}
+	var visit func(node *calcChainNode)
+	visit = func(node *calcChainNode) {
+		if node.state != 0 {
+			return
+		}
+		node.state = 1
+		for _, ref := range node.refs {
+			sheetCells := cells[strings.ToLower(ref.From.Sheet)]
+			for i := sort.Search(len(sheetCells), func(i int) bool {
+				return sheetCells[i].row >= ref.From.Row
+			}); i < len(sheetCells) && sheetCells[i].row <= ref.To.Row; i++ {
+				if precedent := sheetCells[i]; precedent.col >= ref.From.Col && precedent.col <= ref.To.Col {
+					visit(precedent)
+				}
+			}
+		}
+		node.state = 2
+		calc.C = append(calc.C, xlsxCalcChainC{R: node.cell, I: node.sheetID, A: node.array})
+	}
+	for _, node := range nodes {
+		visit(node)
+	}
+	f.CalcChain = calc
+	if err := f.addContentTypePart(0, "calcChain"); err != nil {
+		return err
+	}
+	relPath := f.getWorkbookRelsPath()
+	rels, err := f.relsReader(relPath)
+	if err != nil {
+		return err
+	}
+	if rels != nil {
+		for _, rel := range rels.Relationships {
+			if rel.Type == SourceRelationshipCalcChain {
+				return err
 			}
 		}
 	}
+	f.addRels(relPath, SourceRelationshipCalcChain, "calcChain.xml", "")
 	return err
 }
 
@@ -1,6 +1,8 @@
 package excelize
 
 import (
+	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -44,3 +46,85 @@ func TestDeleteCalcChain(t *testing.T) {
 	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
 	assert.EqualError(t, f.deleteCalcChain(1, "A1"), "XML syntax error on line 1: invalid UTF-8")
 }
+
+func TestRebuildCalcChain(t *testing.T) {
+	f := NewFile()
+	// Test rebuild calculation chain without formulas
+	assert.NoError(t, f.RebuildCalcChain())
+	cells, err := f.GetCalcChain()
+	assert.NoError(t, err)
+	assert.Empty(t, cells)
+
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "B1+Sheet2!A1"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "C1*2"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "SUM(Sheet2!A:A)"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "D1+1"))
+	assert.NoError(t, f.SetCellFormula("Sheet2", "A1", "B1"))
+	formulaType, ref := STCellFormulaTypeArray, "E1:E2"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "E1", "A1:A2*2", FormulaOpts{Ref: &ref, Type: &formulaType}))
+	assert.NoError(t, f.RebuildCalcChain())
+	expected := []CalcChainCell{
+		{Sheet: "Sheet2", Cell: "A1"},
+		{Sheet: "Sheet1", Cell: "C1"},
+		{Sheet: "Sheet1", Cell: "B1"},
+		{Sheet: "Sheet1", Cell: "A1"},
+		{Sheet: "Sheet1", Cell: "D1"},
+		{Sheet: "Sheet1", Cell: "E1", Array: true},
+	}
+	cells, err = f.GetCalcChain()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, cells)
+	// Test rebuild calculation chain again
+	assert.NoError(t, f.RebuildCalcChain())
+	cells, err = f.GetCalcChain()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, cells)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRebuildCalcChain.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestRebuildCalcChain.xlsx"))
+	assert.NoError(t, err)
+	cells, err = f.GetCalcChain()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, cells)
+	// Test get calculation chain with omitted sheet index
+	f.CalcChain.C[1].I = 0
+	cells, err = f.GetCalcChain()
+	assert.NoError(t, err)
+	assert.Equal(t, "Sheet2", cells[1].Sheet)
+	// Test rebuild calculation chain after removed all formulas
+	for _, cell := range []string{"A1", "B1", "C1", "D1", "E1"} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", cell, ""))
+	}
+	assert.NoError(t, f.SetCellFormula("Sheet2", "A1", ""))
+	f.CalcChain = &xlsxCalcChain{C: []xlsxCalcChainC{{R: "A1", I: 1}}}
+	assert.NoError(t, f.RebuildCalcChain())
+	assert.Nil(t, f.CalcChain)
+	_, ok := f.Pkg.Load(defaultXMLPathCalcChain)
+	assert.False(t, ok)
+	assert.NoError(t, f.Close())
+
+	// Test rebuild calculation chain with unsupported charset worksheet
+	f = NewFile()
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	f.checked = sync.Map{}
+	assert.EqualError(t, f.RebuildCalcChain(), "XML syntax error on line 1: invalid UTF-8")
+	// Test rebuild calculation chain with unsupported charset workbook relationships
+	f = NewFile()
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "B1"))
+	f.Relationships.Delete(defaultXMLPathWorkbookRels)
+	f.Pkg.Store(defaultXMLPathWorkbookRels, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.RebuildCalcChain(), "XML syntax error on line 1: invalid UTF-8")
+	// Test rebuild calculation chain with unsupported charset content types
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.RebuildCalcChain(), "XML syntax error on line 1: invalid UTF-8")
+	// Test get calculation chain with unsupported charset calculation chain
+	f.CalcChain = nil
+	f.Pkg.Store(defaultXMLPathCalcChain, MacintoshCyrillicCharset)
+	_, err = f.GetCalcChain()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
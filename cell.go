@@ -14,13 +14,18 @@ package excelize
 import (
 	"bytes"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"os"
 	"reflect"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 	"unicode/utf8"
+
+	"github.com/xuri/efp"
 )
 
 // CellType is the type of cell value type.
@@ -38,6 +43,34 @@ const (
 	CellTypeSharedString
 )
 
+// CellErrorType is the type of cell error value type.
+type CellErrorType byte
+
+// Cell error value types enumeration.
+const (
+	CellErrorNone CellErrorType = iota
+	CellErrorNull
+	CellErrorDiv0
+	CellErrorValue
+	CellErrorRef
+	CellErrorName
+	CellErrorNum
+	CellErrorNA
+	CellErrorGettingData
+	CellErrorSpill
+	CellErrorCalc
+)
+
+// ValueSource is the type of the source of the formula cell values.
+type ValueSource byte
+
+// Formula cell value sources enumeration.
+const (
+	ValueSourceCached ValueSource = iota
+	ValueSourceCalculated
+	ValueSourceCachedWithFallback
+)
+
 const (
 	// STCellFormulaTypeArray defined the formula is an array formula.
 	STCellFormulaTypeArray = "array"
@@ -60,6 +93,27 @@ var cellTypes = map[string]CellType{
 	"inlineStr": CellTypeInlineString,
 }
 
+// cellErrorTypes mapping the cell's error value and enumeration.
+var cellErrorTypes = map[CellErrorType]string{
+	CellErrorNull:        formulaErrorNULL,
+	CellErrorDiv0:        formulaErrorDIV,
+	CellErrorValue:       formulaErrorVALUE,
+	CellErrorRef:         formulaErrorREF,
+	CellErrorName:        formulaErrorNAME,
+	CellErrorNum:         formulaErrorNUM,
+	CellErrorNA:          formulaErrorNA,
+	CellErrorGettingData: formulaErrorGETTINGDATA,
+	CellErrorSpill:       formulaErrorSPILL,
+	CellErrorCalc:        formulaErrorCALC,
+}
+
+// String returns the error value text of the cell error type, such as
+// "#N/A" for the CellErrorNA, and returns an empty string for the
+// CellErrorNone or unknown error type.
+func (e CellErrorType) String() string {
+	return cellErrorTypes[e]
+}
+
 // GetCellValue provides a function to get formatted value from cell by given
 // worksheet name and cell reference in spreadsheet. The return value is
 // converted to the 'string' data type. This function is concurrency safe. If
@@ -67,18 +121,133 @@ var cellTypes = map[string]CellType{
 // will be returned, otherwise the original value will be returned. All cells'
 // values will be the same in a merged range.
 func (f *File) GetCellValue(sheet, cell string, opts ...Options) (string, error) {
-	return f.getCellStringFunc(sheet, cell, func(x *xlsxWorksheet, c *xlsxC) (string, bool, error) {
+	var formula *xlsxC
+	options := f.getOptions(opts...)
+	val, err := f.getCellStringFunc(sheet, cell, func(x *xlsxWorksheet, c *xlsxC) (string, bool, error) {
 		sst, err := f.sharedStringsReader()
 		if err != nil {
 			return "", true, err
 		}
-		val, err := c.getValueFrom(f, sst, f.getOptions(opts...).RawCellValue)
+		if c.F != nil {
+			formula = &xlsxC{R: c.R, V: c.V, F: c.F}
+		}
+		val, err := c.getValueFrom(f, sst, options.RawCellValue)
 		return val, true, err
 	})
+	if err != nil || formula == nil {
+		return val, err
+	}
+	if formula.R == "" {
+		formula.R = cell
+	}
+	return f.getFormulaValue(sheet, formula, val, options)
+}
+
+// getFormulaValue provides a function to get the value of the formula cell by
+// given worksheet name, cell, cached value and the options. The formula will
+// be recalculated by the calculation engine depending on the ValueSource
+// option, and the calculation error will be returned as the error, so that
+// it can be distinguished from the text value.
+func (f *File) getFormulaValue(sheet string, c *xlsxC, cached string, opts *Options) (string, error) {
+	if c.F == nil || opts.ValueSource == ValueSourceCached ||
+		(opts.ValueSource == ValueSourceCachedWithFallback && c.V != "") {
+		return cached, nil
+	}
+	return f.CalcCellValue(sheet, c.R, *opts)
+}
+
+// GetCellValues provides a function to get formatted values of the cells by
+// given worksheet name and cell references in spreadsheet, the values will be
+// resolved in one traversal of the worksheet, and returned in the order of
+// the given cell references. This function is concurrency safe. For example,
+// get the values of cells A1, C5 and F10 on Sheet1:
+//
+//	values, err := f.GetCellValues("Sheet1", []string{"A1", "C5", "F10"})
+func (f *File) GetCellValues(sheet string, cells []string, opts ...Options) ([]string, error) {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return nil, err
+	}
+	f.mu.Unlock()
+	sst, err := f.sharedStringsReader()
+	if err != nil {
+		return nil, err
+	}
+	ws.mu.Lock()
+	values, refs, rows := make([]string, len(cells)), map[[2]int][]int{}, map[int]bool{}
+	for i, cell := range cells {
+		if cell, err = ws.mergeCellsParser(cell); err != nil {
+			ws.mu.Unlock()
+			return nil, err
+		}
+		col, row, err := CellNameToCoordinates(cell)
+		if err != nil {
+			ws.mu.Unlock()
+			return nil, err
+		}
+		refs[[2]int{col, row}] = append(refs[[2]int{col, row}], i)
+		rows[row] = true
+	}
+	options := f.getOptions(opts...)
+	formulas, err := f.getCellValues(ws, sst, refs, rows, values, options)
+	ws.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range formulas {
+		col, row, _ := CellNameToCoordinates(c.R)
+		val, err := f.getFormulaValue(sheet, c, values[refs[[2]int{col, row}][0]], options)
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range refs[[2]int{col, row}] {
+			values[i] = val
+		}
+	}
+	return values, err
+}
+
+// getCellValues provides a function to fill the values of the cells by given
+// worksheet, shared string table, the indexes of the cell references, the
+// rows to be read and the options, and returns the formula cells which need
+// to be recalculated by the ValueSource option.
+func (f *File) getCellValues(ws *xlsxWorksheet, sst *xlsxSST, refs map[[2]int][]int, rows map[int]bool, values []string, opts *Options) ([]*xlsxC, error) {
+	var formulas []*xlsxC
+	for rowIdx := range ws.SheetData.Row {
+		rowData := &ws.SheetData.Row[rowIdx]
+		if !rows[rowData.R] {
+			continue
+		}
+		for colIdx := range rowData.C {
+			colData := &rowData.C[colIdx]
+			col, row, err := CellNameToCoordinates(colData.R)
+			if err != nil {
+				return nil, err
+			}
+			indexes, ok := refs[[2]int{col, row}]
+			if !ok {
+				continue
+			}
+			if colData.F != nil && opts.ValueSource != ValueSourceCached {
+				formulas = append(formulas, &xlsxC{R: colData.R, V: colData.V, F: colData.F})
+			}
+			val, err := colData.getValueFrom(f, sst, opts.RawCellValue)
+			if err != nil {
+				return nil, err
+			}
+			for _, i := range indexes {
+				values[i] = val
+			}
+		}
+	}
+	return formulas, nil
 }
 
 // GetCellType provides a function to get the cell's data type by given
-// worksheet name and cell reference in spreadsheet file.
+// worksheet name and cell reference in spreadsheet file. For the error type
+// cell, use the GetCellError function to get the typed error value.
 func (f *File) GetCellType(sheet, cell string) (CellType, error) {
 	var (
 		err         error
@@ -94,6 +263,44 @@ func (f *File) GetCellType(sheet, cell string) (CellType, error) {
 	return cellType, err
 }
 
+// GetCellError provides a function to get the error value type of the cell by
+// given worksheet name and cell reference. This function returns
+// CellErrorNone if the cell doesn't contain an error value. For the formula
+// cell, the type of the cached error result will be returned. For example,
+// check whether the cell A1 on Sheet1 is a #N/A error:
+//
+//	errType, err := f.GetCellError("Sheet1", "A1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if errType == excelize.CellErrorNA {
+//	    fmt.Println("value not available")
+//	}
+func (f *File) GetCellError(sheet, cell string) (CellErrorType, error) {
+	value, err := f.getCellStringFunc(sheet, cell, func(x *xlsxWorksheet, c *xlsxC) (string, bool, error) {
+		if c.T != "e" {
+			return "", true, nil
+		}
+		return c.V, true, nil
+	})
+	if err != nil || value == "" {
+		return CellErrorNone, err
+	}
+	return getCellErrorType(value), err
+}
+
+// getCellErrorType provides a function to get the cell error value type by
+// given error value text.
+func getCellErrorType(value string) CellErrorType {
+	for errType, text := range cellErrorTypes {
+		if strings.EqualFold(text, value) {
+			return errType
+		}
+	}
+	return CellErrorNone
+}
+
 // SetCellValue provides a function to set the value of a cell. This function
 // is concurrency safe. The specified coordinates should not be in the first
 // row of the table, a complex number can be set with string text. The
@@ -116,8 +323,12 @@ func (f *File) GetCellType(sheet, cell string) (CellType, error) {
 //	time.Duration
 //	time.Time
 //	bool
+//	CellErrorType
 //	nil
 //
+// Set the value with nil will clear the value and formula of the cell but keep
+// its style, the same as the SetCellBlank function.
+//
 // Note that default date format is m/d/yy h:mm of time.Time type value. You
 // can set numbers format by the SetCellStyle function. If you need to set the
 // specialized date in Excel like January 0, 1900 or February 29, 1900, these
@@ -148,8 +359,10 @@ func (f *File) SetCellValue(sheet, cell string, value interface{}) error {
 		err = f.setCellTimeFunc(sheet, cell, v)
 	case bool:
 		err = f.SetCellBool(sheet, cell, v)
+	case CellErrorType:
+		err = f.SetCellError(sheet, cell, v)
 	case nil:
-		err = f.SetCellDefault(sheet, cell, "")
+		err = f.SetCellBlank(sheet, cell)
 	default:
 		err = f.SetCellStr(sheet, cell, fmt.Sprint(value))
 	}
@@ -175,7 +388,13 @@ func (x xlsxSI) String() string {
 
 // hasValue determine if cell non-blank value.
 func (c *xlsxC) hasValue() bool {
-	return c.S != 0 || c.V != "" || c.F != nil || c.T != ""
+	return c.S != 0 || c.hasData()
+}
+
+// hasData determine if cell contains value, formula, inline string or data
+// type, the cell which only has style will not be counted.
+func (c *xlsxC) hasData() bool {
+	return c.V != "" || c.F != nil || c.IS != nil || c.T != ""
 }
 
 // removeFormula delete formula for the cell.
@@ -236,6 +455,9 @@ func (f *File) setCellTimeFunc(sheet, cell string, value time.Time) error {
 	if err != nil {
 		return err
 	}
+	if err = f.checkProtectedCell(ws, sheet, cell); err != nil {
+		return err
+	}
 	c, col, row, err := ws.prepareCell(cell)
 	if err != nil {
 		return err
@@ -297,6 +519,9 @@ func (f *File) SetCellInt(sheet, cell string, value int) error {
 	f.mu.Unlock()
 	ws.mu.Lock()
 	defer ws.mu.Unlock()
+	if err = f.checkProtectedCell(ws, sheet, cell); err != nil {
+		return err
+	}
 	c, col, row, err := ws.prepareCell(cell)
 	if err != nil {
 		return err
@@ -325,6 +550,9 @@ func (f *File) SetCellUint(sheet, cell string, value uint64) error {
 	f.mu.Unlock()
 	ws.mu.Lock()
 	defer ws.mu.Unlock()
+	if err = f.checkProtectedCell(ws, sheet, cell); err != nil {
+		return err
+	}
 	c, col, row, err := ws.prepareCell(cell)
 	if err != nil {
 		return err
@@ -354,6 +582,9 @@ func (f *File) SetCellBool(sheet, cell string, value bool) error {
 	f.mu.Unlock()
 	ws.mu.Lock()
 	defer ws.mu.Unlock()
+	if err = f.checkProtectedCell(ws, sheet, cell); err != nil {
+		return err
+	}
 	c, col, row, err := ws.prepareCell(cell)
 	if err != nil {
 		return err
@@ -364,6 +595,71 @@ func (f *File) SetCellBool(sheet, cell string, value bool) error {
 	return f.removeFormula(c, ws, sheet)
 }
 
+// SetCellError provides a function to set the error type value of a cell by
+// given worksheet name, cell reference and error value type. For example, set
+// the #N/A error value in the cell A1 on Sheet1:
+//
+//	err := f.SetCellError("Sheet1", "A1", excelize.CellErrorNA)
+func (f *File) SetCellError(sheet, cell string, errType CellErrorType) error {
+	t, v, err := setCellError(errType)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if err = f.checkProtectedCell(ws, sheet, cell); err != nil {
+		return err
+	}
+	c, col, row, err := ws.prepareCell(cell)
+	if err != nil {
+		return err
+	}
+	c.S = ws.prepareCellStyle(col, row, c.S)
+	c.T, c.V = t, v
+	c.IS = nil
+	return f.removeFormula(c, ws, sheet)
+}
+
+// setCellError prepares cell type and string type cell value by a given error
+// value type.
+func setCellError(errType CellErrorType) (t string, v string, err error) {
+	if v = errType.String(); v == "" {
+		err = ErrParameterInvalid
+		return
+	}
+	t = "e"
+	return
+}
+
+// SetCellCheckbox provides a function to set the boolean type value of a cell
+// and displays it as the in-cell checkbox by given worksheet name, cell
+// reference and checked status. Note that the in-cell checkbox is supported
+// since Microsoft 365, the cell will be displayed as TRUE or FALSE in the
+// earlier version of the spreadsheet applications. For example, set a checked
+// checkbox in Sheet1!A1:
+//
+//	err := f.SetCellCheckbox("Sheet1", "A1", true)
+func (f *File) SetCellCheckbox(sheet, cell string, checked bool) error {
+	if err := f.SetCellBool(sheet, cell, checked); err != nil {
+		return err
+	}
+	styleID, err := f.GetCellStyle(sheet, cell)
+	if err != nil {
+		return err
+	}
+	if styleID, err = f.getCheckboxStyleID(styleID); err != nil {
+		return err
+	}
+	return f.SetCellStyle(sheet, cell, cell, styleID)
+}
+
 // setCellBool prepares cell type and string type cell value by a given boolean
 // value.
 func setCellBool(value bool) (t string, v string) {
@@ -394,6 +690,9 @@ func (f *File) SetCellFloat(sheet, cell string, value float64, precision, bitSiz
 	f.mu.Unlock()
 	ws.mu.Lock()
 	defer ws.mu.Unlock()
+	if err = f.checkProtectedCell(ws, sheet, cell); err != nil {
+		return err
+	}
 	c, col, row, err := ws.prepareCell(cell)
 	if err != nil {
 		return err
@@ -411,6 +710,72 @@ func setCellFloat(value float64, precision, bitSize int) (t string, v string) {
 	return
 }
 
+// decimalExp defined the regular expression for matching the decimal number
+// text.
+var decimalExp = regexp.MustCompile(`^[-+]?(\d+(\.\d*)?|\.\d+)([eE][-+]?\d+)?$`)
+
+// SetCellDecimal provides a function to set the numeric value of a cell by
+// given worksheet name, cell reference and the decimal number text. The text
+// will be stored in the cell as is, without converting to float64, so that
+// the value like 19.99 will not become 19.989999999999998 in the workbook.
+// For the value of the big.Rat type, use the FloatString function of it to
+// get the decimal number text. For example, set the value of the cell A1 on
+// Sheet1:
+//
+//	err := f.SetCellDecimal("Sheet1", "A1", "19.99")
+func (f *File) SetCellDecimal(sheet, cell, value string) error {
+	if !decimalExp.MatchString(value) {
+		return newInvalidDecimalError(value)
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if err = f.checkProtectedCell(ws, sheet, cell); err != nil {
+		return err
+	}
+	c, col, row, err := ws.prepareCell(cell)
+	if err != nil {
+		return err
+	}
+	c.S = ws.prepareCellStyle(col, row, c.S)
+	c.T, c.V = "", strings.TrimPrefix(value, "+")
+	c.IS = nil
+	return f.removeFormula(c, ws, sheet)
+}
+
+// GetCellDecimal provides a function to get the decimal number text of a
+// numeric cell by given worksheet name and cell reference, the text will be
+// returned as it stored in the workbook without float64 rounding. For the
+// formula cell, the cached result of the formula will be returned. This
+// function will return an error if the cell is not a numeric cell, such as
+// the shared string, inline string, boolean or error cell, or the value of
+// the cell is not a decimal number. For example, get the value of the cell A1
+// on Sheet1:
+//
+//	value, err := f.GetCellDecimal("Sheet1", "A1")
+func (f *File) GetCellDecimal(sheet, cell string) (string, error) {
+	return f.getCellStringFunc(sheet, cell, func(x *xlsxWorksheet, c *xlsxC) (string, bool, error) {
+		if (c.T == "" || c.T == "n") && (c.V == "" || decimalExp.MatchString(c.V)) {
+			return c.V, true, nil
+		}
+		sst, err := f.sharedStringsReader()
+		if err != nil {
+			return "", true, err
+		}
+		val, err := c.getValueFrom(f, sst, true)
+		if err != nil || val == "" {
+			return "", true, err
+		}
+		return "", true, newInvalidDecimalError(val)
+	})
+}
+
 // SetCellStr provides a function to set string type value of a cell. Total
 // number of characters that a cell can contain 32767 characters.
 func (f *File) SetCellStr(sheet, cell, value string) error {
@@ -423,11 +788,16 @@ func (f *File) SetCellStr(sheet, cell, value string) error {
 	f.mu.Unlock()
 	ws.mu.Lock()
 	defer ws.mu.Unlock()
+	if err = f.checkProtectedCell(ws, sheet, cell); err != nil {
+		return err
+	}
 	c, col, row, err := ws.prepareCell(cell)
 	if err != nil {
 		return err
 	}
-	c.S = ws.prepareCellStyle(col, row, c.S)
+	if c.S, err = f.prepareQuotePrefixStyle(ws.prepareCellStyle(col, row, c.S), value); err != nil {
+		return err
+	}
 	if c.T, c.V, err = f.setCellString(value); err != nil {
 		return err
 	}
@@ -634,35 +1004,293 @@ func (c *xlsxC) getValueFrom(f *File, d *xlsxSST, raw bool) (string, error) {
 				c.V = strconv.FormatFloat(decimal, 'f', -1, 64)
 			}
 		}
-		return f.formattedValue(c, raw, CellTypeNumber)
+		return f.formattedValue(c, raw, CellTypeNumber)
+	}
+}
+
+// SetCellDefault provides a function to set string type value of a cell as
+// default format without escaping the cell.
+func (f *File) SetCellDefault(sheet, cell, value string) error {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if err = f.checkProtectedCell(ws, sheet, cell); err != nil {
+		return err
+	}
+	c, col, row, err := ws.prepareCell(cell)
+	if err != nil {
+		return err
+	}
+	c.S = ws.prepareCellStyle(col, row, c.S)
+	c.setCellDefault(value)
+	return f.removeFormula(c, ws, sheet)
+}
+
+// SetCellBlank provides a function to clear the value and formula of a cell
+// by given worksheet name and cell reference, the style of the cell will be
+// kept, so that the cell still displays its fill, border and number format.
+// This is the same as setting the cell value with nil by the SetCellValue
+// function. Use the ClearCell function to remove the style as well. For
+// example, clear the value of Sheet1!A1 and keep its style:
+//
+//	err := f.SetCellBlank("Sheet1", "A1")
+func (f *File) SetCellBlank(sheet, cell string) error {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if err = f.checkProtectedCell(ws, sheet, cell); err != nil {
+		return err
+	}
+	c, col, row, err := ws.prepareCell(cell)
+	if err != nil {
+		return err
+	}
+	c.S = ws.prepareCellStyle(col, row, c.S)
+	c.T, c.V, c.IS, c.Cm, c.Vm = "", "", nil, nil, nil
+	return f.removeFormula(c, ws, sheet)
+}
+
+// ClearCell provides a function to remove the value, formula and style of a
+// cell by given worksheet name and cell reference, the cell will be treated
+// as a cell that never been set, and will not be written into the workbook.
+// Note that the hyperlinks, comments and data validations of the cell will
+// not be affected. For example, clear everything of the Sheet1!A1:
+//
+//	err := f.ClearCell("Sheet1", "A1")
+func (f *File) ClearCell(sheet, cell string) error {
+	return f.ClearRange(sheet, cell, cell)
+}
+
+// ClearRange provides a function to remove the value, formula and style of
+// the cells in a range by given worksheet name, top-left and bottom-right cell
+// reference in the same way as the ClearCell function. For example, clear
+// everything of the cells in the range Sheet1!A1:C3:
+//
+//	err := f.ClearRange("Sheet1", "A1", "C3")
+func (f *File) ClearRange(sheet, topLeftCell, bottomRightCell string) error {
+	hCol, hRow, err := CellNameToCoordinates(topLeftCell)
+	if err != nil {
+		return err
+	}
+	vCol, vRow, err := CellNameToCoordinates(bottomRightCell)
+	if err != nil {
+		return err
+	}
+	if vCol < hCol {
+		vCol, hCol = hCol, vCol
+	}
+	if vRow < hRow {
+		vRow, hRow = hRow, vRow
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	var cells []*xlsxC
+	for r := range ws.SheetData.Row {
+		row := &ws.SheetData.Row[r]
+		if row.R < hRow || row.R > vRow {
+			continue
+		}
+		for i := range row.C {
+			c := &row.C[i]
+			col, _, err := CellNameToCoordinates(c.R)
+			if err != nil {
+				return err
+			}
+			if col < hCol || col > vCol {
+				continue
+			}
+			if err = f.checkProtectedCell(ws, sheet, c.R); err != nil {
+				return err
+			}
+			cells = append(cells, c)
+		}
+	}
+	for _, c := range cells {
+		c.S, c.T, c.V, c.IS, c.Cm, c.Vm = 0, "", "", nil, nil, nil
+		if err = f.removeFormula(c, ws, sheet); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// GetCellFormula provides a function to get formula from cell by given
+// worksheet name and cell reference in spreadsheet.
+func (f *File) GetCellFormula(sheet, cell string) (string, error) {
+	return f.getCellFormula(sheet, cell, false)
+}
+
+// GetSheetFormulas provides a function to get all formulas of the cells in
+// the worksheet by given worksheet name in a single traversal, the returned
+// map is keyed by the cell reference. The formulas of the shared formula
+// cells will be expanded for each cell. For example, print all formulas on
+// Sheet1:
+//
+//	formulas, err := f.GetSheetFormulas("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for cell, formula := range formulas {
+//	    fmt.Println(cell, formula)
+//	}
+func (f *File) GetSheetFormulas(sheet string) (map[string]string, error) {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return nil, err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	formulas, shared := make(map[string]string), make(map[int]*xlsxC)
+	for rowIdx := range ws.SheetData.Row {
+		for colIdx := range ws.SheetData.Row[rowIdx].C {
+			c := &ws.SheetData.Row[rowIdx].C[colIdx]
+			if c.F != nil && c.F.Ref != "" && c.F.T == STCellFormulaTypeShared && c.F.Si != nil {
+				if _, ok := shared[*c.F.Si]; !ok {
+					shared[*c.F.Si] = c
+				}
+			}
+		}
+	}
+	for rowIdx := range ws.SheetData.Row {
+		for colIdx := range ws.SheetData.Row[rowIdx].C {
+			c := &ws.SheetData.Row[rowIdx].C[colIdx]
+			if c.F == nil {
+				continue
+			}
+			formula := c.F.Content
+			if c.F.T == STCellFormulaTypeShared && c.F.Si != nil {
+				formula = ""
+				if master, ok := shared[*c.F.Si]; ok {
+					formula = shiftSharedFormula(master, c.R)
+				}
+			}
+			if c.F.T == STCellFormulaTypeDataTable && c.F.Content == "" && c.F.R1 != "" {
+				formula = c.F.dataTableFormula()
+			}
+			if formula != "" {
+				formulas[c.R] = formula
+			}
+		}
+	}
+	return formulas, err
+}
+
+// GetCellFormulaOpts provides a function to get the formula type settings of
+// the cell by given worksheet name and cell reference in spreadsheet. For the
+// cells in the range of an array formula, the Ref of the returned options is
+// the array formula range, and the Dynamic specifies whether the array formula
+// is a dynamic array formula. For example, get the array formula range of the
+// cell "C2" on "Sheet1":
+//
+//	opts, err := f.GetCellFormulaOpts("Sheet1", "C2")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if opts.Ref != nil {
+//	    fmt.Println(*opts.Ref)
+//	}
+func (f *File) GetCellFormulaOpts(sheet, cell string) (FormulaOpts, error) {
+	var opts FormulaOpts
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return opts, err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if cell, err = ws.mergeCellsParser(cell); err != nil {
+		return opts, err
+	}
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return opts, err
+	}
+	for rowIdx := range ws.SheetData.Row {
+		rowData := &ws.SheetData.Row[rowIdx]
+		if rowData.R > row {
+			break
+		}
+		for colIdx := range rowData.C {
+			c := &rowData.C[colIdx]
+			if c.F == nil {
+				continue
+			}
+			if c.R == cell {
+				if c.F.T != "" {
+					opts.Type = stringPtr(c.F.T)
+				}
+				if c.F.Ref != "" {
+					opts.Ref = stringPtr(c.F.Ref)
+				}
+				opts.Volatile = c.F.Ca
+				opts.Dynamic, err = f.isDynamicArrayFormula(c)
+				return opts, err
+			}
+			if c.F.T != STCellFormulaTypeArray || !strings.Contains(c.F.Ref, ":") {
+				continue
+			}
+			coordinates, err := rangeRefToCoordinates(c.F.Ref)
+			if err != nil {
+				return opts, err
+			}
+			_ = sortCoordinates(coordinates)
+			if cellInRange([]int{col, row}, coordinates) {
+				opts.Type, opts.Ref, opts.Volatile = stringPtr(c.F.T), stringPtr(c.F.Ref), c.F.Ca
+				opts.Dynamic, err = f.isDynamicArrayFormula(c)
+				return opts, err
+			}
+		}
 	}
+	return opts, err
 }
 
-// SetCellDefault provides a function to set string type value of a cell as
-// default format without escaping the cell.
-func (f *File) SetCellDefault(sheet, cell, value string) error {
-	f.mu.Lock()
-	ws, err := f.workSheetReader(sheet)
-	if err != nil {
-		f.mu.Unlock()
-		return err
+// isDynamicArrayFormula provides a function to check if the cell has the
+// dynamic array formula by the cell metadata.
+func (f *File) isDynamicArrayFormula(c *xlsxC) (bool, error) {
+	if c.Cm == nil || c.F == nil || c.F.T != STCellFormulaTypeArray {
+		return false, nil
 	}
-	f.mu.Unlock()
-	ws.mu.Lock()
-	defer ws.mu.Unlock()
-	c, col, row, err := ws.prepareCell(cell)
+	metadata, err := f.metadataReader()
 	if err != nil {
-		return err
+		return false, err
 	}
-	c.S = ws.prepareCellStyle(col, row, c.S)
-	c.setCellDefault(value)
-	return f.removeFormula(c, ws, sheet)
-}
-
-// GetCellFormula provides a function to get formula from cell by given
-// worksheet name and cell reference in spreadsheet.
-func (f *File) GetCellFormula(sheet, cell string) (string, error) {
-	return f.getCellFormula(sheet, cell, false)
+	cmd := metadata.CellMetadata
+	if cmd == nil || *c.Cm == 0 || int(*c.Cm) > len(cmd.Bk) || metadata.MetadataTypes == nil {
+		return false, err
+	}
+	for _, rc := range cmd.Bk[*c.Cm-1].Rc {
+		if rc.T > 0 && rc.T <= len(metadata.MetadataTypes.MetadataType) &&
+			metadata.MetadataTypes.MetadataType[rc.T-1].Name == "XLDAPR" {
+			return true, err
+		}
+	}
+	return false, err
 }
 
 // getCellFormula provides a function to get transformed formula from cell by
@@ -684,14 +1312,25 @@ func (f *File) getCellFormula(sheet, cell string, transformed bool) (string, err
 		if c.F.T == STCellFormulaTypeShared && c.F.Si != nil {
 			return getSharedFormula(x, *c.F.Si, c.R), true, nil
 		}
+		if c.F.T == STCellFormulaTypeDataTable && c.F.Content == "" && c.F.R1 != "" {
+			return c.F.dataTableFormula(), true, nil
+		}
 		return c.F.Content, true, nil
 	})
 }
 
 // FormulaOpts can be passed to SetCellFormula to use other formula types.
+// The Dynamic specifies the array formula as a dynamic array formula and the
+// Ref is the spill range of the formula result. The Volatile specifies the
+// formula needs to be recalculated the next time calculation is performed,
+// the formulas which contain volatile functions such as NOW and RAND will be
+// marked as volatile automatically.
 type FormulaOpts struct {
-	Type *string // Formula type
-	Ref  *string // Shared formula ref
+	Type      *string // Formula type
+	Ref       *string // Shared formula ref or array formula range
+	Dynamic   bool    // Dynamic array formula
+	Volatile  bool    // Calculate cell on every recalculation
+	Localized bool    // Formula in the localized names and separators of the CultureInfo
 }
 
 // SetCellFormula provides a function to set formula on the cell is taken
@@ -729,14 +1368,28 @@ type FormulaOpts struct {
 //	err := f.SetCellFormula("Sheet1", "A3", "=A1:A2",
 //	       excelize.FormulaOpts{Ref: &ref, Type: &formulaType})
 //
-// Example 6, set shared formula "=A1+B1" for the cell "C1:C5"
+// Example 6, set legacy array formula "=A1:A3*B1:B3" over the range "C1:C3"
+// on "Sheet1", "C1" is the master cell:
+//
+//	formulaType, ref := excelize.STCellFormulaTypeArray, "C1:C3"
+//	err := f.SetCellFormula("Sheet1", "C1", "=A1:A3*B1:B3",
+//	    excelize.FormulaOpts{Ref: &ref, Type: &formulaType})
+//
+// Example 7, set dynamic array formula "=SORT(A1:A3)" for the cell "C1" on
+// "Sheet1", and the result spills into the range "C1:C3":
+//
+//	ref := "C1:C3"
+//	err := f.SetCellFormula("Sheet1", "C1", "=SORT(A1:A3)",
+//	    excelize.FormulaOpts{Ref: &ref, Dynamic: true})
+//
+// Example 8, set shared formula "=A1+B1" for the cell "C1:C5"
 // on "Sheet1", "C1" is the master cell:
 //
 //	formulaType, ref := excelize.STCellFormulaTypeShared, "C1:C5"
 //	err := f.SetCellFormula("Sheet1", "C1", "=A1+B1",
 //	    excelize.FormulaOpts{Ref: &ref, Type: &formulaType})
 //
-// Example 7, set table formula "=SUM(Table1[[A]:[B]])" for the cell "C2"
+// Example 9, set table formula "=SUM(Table1[[A]:[B]])" for the cell "C2"
 // on "Sheet1":
 //
 //	package main
@@ -776,36 +1429,72 @@ type FormulaOpts struct {
 //	        fmt.Println(err)
 //	    }
 //	}
+//
+// Example 10, set the formula written in German function names and separators
+// for the cell "A3" on "Sheet1", the formula will be stored as
+// "=SUM(A1,2.5)":
+//
+//	f := excelize.NewFile(excelize.Options{CultureInfo: excelize.CultureNameDeDE})
+//	err := f.SetCellFormula("Sheet1", "A3", "=SUMME(A1;2,5)",
+//	    excelize.FormulaOpts{Localized: true})
 func (f *File) SetCellFormula(sheet, cell, formula string, opts ...FormulaOpts) error {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
 	}
-	c, _, _, err := ws.prepareCell(cell)
+	if err = f.checkProtectedCell(ws, sheet, cell); err != nil {
+		return err
+	}
+	c, col, row, err := ws.prepareCell(cell)
 	if err != nil {
 		return err
 	}
 	if formula == "" {
-		c.F = nil
+		c.F, c.Cm = nil, nil
 		return f.deleteCalcChain(f.getSheetID(sheet), cell)
 	}
-
+	for _, opt := range opts {
+		if opt.Localized {
+			formula = f.canonicalFormula(formula)
+			break
+		}
+	}
 	if c.F != nil {
 		c.F.Content = formula
 	} else {
 		c.F = &xlsxF{Content: formula}
 	}
+	c.Cm, c.F.Ca = nil, isVolatileFormula(formula)
 
 	for _, opt := range opts {
-		if opt.Type != nil {
-			if *opt.Type == STCellFormulaTypeDataTable {
+		if opt.Volatile {
+			c.F.Ca = true
+		}
+		formulaType := opt.Type
+		if opt.Dynamic {
+			formulaType = stringPtr(STCellFormulaTypeArray)
+		}
+		if formulaType != nil {
+			if *formulaType == STCellFormulaTypeDataTable {
 				return err
 			}
-			c.F.T = *opt.Type
-			if c.F.T == STCellFormulaTypeArray && opt.Ref != nil {
-				if err = ws.setArrayFormula(sheet, &xlsxF{Ref: *opt.Ref, Content: formula}, f.GetDefinedName()); err != nil {
+			c.F.T = *formulaType
+			if c.F.T == STCellFormulaTypeArray {
+				ref := cell
+				if opt.Ref != nil {
+					ref = *opt.Ref
+				}
+				if err = f.setArrayFormulaRange(ws, sheet, ref, formula, col, row); err != nil {
 					return err
 				}
+				c = &ws.SheetData.Row[row-1].C[col-1]
+				c.F.Ref = ref
+				if opt.Dynamic {
+					if c.Cm, err = f.addDynamicArrayMetadata(); err != nil {
+						return err
+					}
+				}
+				continue
 			}
 			if c.F.T == STCellFormulaTypeShared {
 				if err = ws.setSharedFormula(*opt.Ref); err != nil {
@@ -821,6 +1510,233 @@ func (f *File) SetCellFormula(sheet, cell, formula string, opts ...FormulaOpts)
 	return err
 }
 
+// SetDataTable provides a function to set the what-if analysis data table by
+// given worksheet name, cell range reference and data table settings, that
+// corresponding to the "Data Table" in Excel. The cell range reference
+// contains the input values and the formulas, the results are located in the
+// range except the first row and the first column. For a one-variable data
+// table with the column input cell, the input values are listed down the
+// first column, and the formulas are placed in the first row. For a
+// one-variable data table with the row input cell, the input values are listed
+// across the first row, and the formulas are placed in the first column. For a
+// two-variable data table, the formula is placed in the top-left cell of the
+// range, the input values for the row input cell are listed across the first
+// row, and the input values for the column input cell are listed down the first
+// column. The results of the data table can be calculated by the
+// CalcCellValue function. For example, create a two-variable data table in
+// the range A4:D7 of Sheet1, which calculates the monthly payments by the
+// interest rates in the range A5:A7 and the periods in the range B4:D4:
+//
+//	if err := f.SetCellFormula("Sheet1", "A4", "=PMT(B1/12,B2,B3)"); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err := f.SetDataTable("Sheet1", "A4:D7", &excelize.DataTableOptions{
+//	    RowInputCell:    "B2",
+//	    ColumnInputCell: "B1",
+//	})
+func (f *File) SetDataTable(sheet, rangeRef string, opts *DataTableOptions) error {
+	if opts == nil || (opts.RowInputCell == "" && opts.ColumnInputCell == "") {
+		return ErrParameterRequired
+	}
+	coordinates, err := rangeRefToCoordinates(rangeRef)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	if coordinates[0] == coordinates[2] || coordinates[1] == coordinates[3] {
+		return ErrParameterInvalid
+	}
+	var inputCells []string
+	for _, cell := range []string{opts.RowInputCell, opts.ColumnInputCell} {
+		if cell = strings.ReplaceAll(cell, "$", ""); cell == "" {
+			inputCells = append(inputCells, cell)
+			continue
+		}
+		col, row, err := CellNameToCoordinates(cell)
+		if err != nil {
+			return err
+		}
+		if cellInRange([]int{col, row}, coordinates) {
+			return ErrParameterInvalid
+		}
+		cell, _ = CoordinatesToCellName(col, row)
+		inputCells = append(inputCells, cell)
+	}
+	formula := &xlsxF{T: STCellFormulaTypeDataTable}
+	formula.Ref, _ = coordinatesToRangeRef([]int{coordinates[0] + 1, coordinates[1] + 1, coordinates[2], coordinates[3]})
+	switch {
+	case inputCells[0] != "" && inputCells[1] != "":
+		formula.Dt2D, formula.Dtr, formula.R1, formula.R2 = true, true, inputCells[0], inputCells[1]
+	case inputCells[0] != "":
+		formula.Dtr, formula.R1 = true, inputCells[0]
+	default:
+		formula.R1 = inputCells[1]
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	for row := coordinates[1] + 1; row <= coordinates[3]; row++ {
+		for col := coordinates[0] + 1; col <= coordinates[2]; col++ {
+			ws.prepareSheetXML(col, row)
+			c := &ws.SheetData.Row[row-1].C[col-1]
+			if err = f.removeFormula(c, ws, sheet); err != nil {
+				return err
+			}
+			c.T, c.V, c.IS, c.f = "", "", nil, ""
+		}
+	}
+	ws.SheetData.Row[coordinates[1]].C[coordinates[0]].F = formula
+	return err
+}
+
+// dataTableFormula provides a function to get the formula text of the data
+// table, which is displayed as the TABLE function in the spreadsheet
+// application.
+func (fx *xlsxF) dataTableFormula() string {
+	if fx.Dt2D {
+		return fmt.Sprintf("TABLE(%s,%s)", fx.R1, fx.R2)
+	}
+	if fx.Dtr {
+		return fmt.Sprintf("TABLE(%s,)", fx.R1)
+	}
+	return fmt.Sprintf("TABLE(,%s)", fx.R1)
+}
+
+// isVolatileFormula provides a function to check if the formula contains
+// volatile functions, which results are changed on every recalculation.
+func isVolatileFormula(formula string) bool {
+	ps := efp.ExcelParser()
+	for _, token := range ps.Parse(formula) {
+		if isFunctionStartToken(token) && inStrSlice([]string{
+			"CELL", "INDIRECT", "INFO", "NOW", "OFFSET", "RAND", "RANDARRAY", "RANDBETWEEN", "TODAY",
+		}, strings.TrimPrefix(strings.ToUpper(token.TValue), "_XLFN."), true) != -1 {
+			return true
+		}
+	}
+	return false
+}
+
+// setArrayFormulaRange provides a function to prepare the cells in the array
+// formula range by given worksheet, formula range reference, formula and the
+// coordinates of the master cell. The formulas of the other cells in the range
+// will be removed, the array formula only be stored in the master cell.
+func (f *File) setArrayFormulaRange(ws *xlsxWorksheet, sheet, ref, formula string, col, row int) error {
+	rangeRef := ref
+	if !strings.Contains(rangeRef, ":") {
+		rangeRef += ":" + rangeRef
+	}
+	coordinates, err := rangeRefToCoordinates(rangeRef)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	if coordinates[0] != col || coordinates[1] != row {
+		return newInvalidArrayFormulaRangeError(ref)
+	}
+	for r := coordinates[1]; r <= coordinates[3]; r++ {
+		for c := coordinates[0]; c <= coordinates[2]; c++ {
+			ws.prepareSheetXML(c, r)
+			cell := &ws.SheetData.Row[r-1].C[c-1]
+			if cell.f = ""; c != col || r != row {
+				cell.F, cell.Cm = nil, nil
+			}
+		}
+	}
+	return ws.setArrayFormula(sheet, &xlsxF{Ref: ref, Content: formula}, f.GetDefinedName())
+}
+
+// addDynamicArrayMetadata provides a function to add the dynamic array
+// properties to the cell metadata in the workbook, and returns the index of
+// the cell metadata block for the cell.
+func (f *File) addDynamicArrayMetadata() (*uint, error) {
+	metadata, err := f.metadataReader()
+	if err != nil {
+		return nil, err
+	}
+	if metadata.MetadataTypes == nil {
+		metadata.MetadataTypes = &xlsxMetadataTypes{}
+	}
+	typeIdx := -1
+	for i, metadataType := range metadata.MetadataTypes.MetadataType {
+		if metadataType.Name == "XLDAPR" {
+			typeIdx = i
+			break
+		}
+	}
+	if typeIdx == -1 {
+		metadata.MetadataTypes.MetadataType = append(metadata.MetadataTypes.MetadataType, xlsxMetadataType{
+			Name: "XLDAPR", MinSupportedVersion: 120000, Copy: true, PasteAll: true,
+			PasteValues: true, Merge: true, SplitFirst: true, RowColShift: true,
+			ClearFormats: true, ClearComments: true, Assign: true, Coerce: true, CellMeta: true,
+		})
+		typeIdx = len(metadata.MetadataTypes.MetadataType) - 1
+	}
+	metadata.MetadataTypes.Count = len(metadata.MetadataTypes.MetadataType)
+	futureIdx := -1
+	for i, futureMetadata := range metadata.FutureMetadata {
+		if futureMetadata.Name == "XLDAPR" {
+			futureIdx = i
+			break
+		}
+	}
+	if futureIdx == -1 {
+		metadata.FutureMetadata = append(metadata.FutureMetadata, xlsxFutureMetadata{
+			Name: "XLDAPR", Count: 1,
+			Bk: []xlsxFutureMetadataBlock{{ExtLst: &xlsxInnerXML{Content: fmt.Sprintf(
+				`<ext uri="%s" xmlns:%s="%s"><xda:dynamicArrayProperties fDynamic="1" fCollapsed="0"/></ext>`,
+				ExtURIDynamicArrayProperties, NameSpaceSpreadSheetXDA.Name.Local, NameSpaceSpreadSheetXDA.Value)}}},
+		})
+	}
+	if metadata.CellMetadata == nil {
+		metadata.CellMetadata = &xlsxMetadataBlocks{}
+	}
+	cm := -1
+	for i, bk := range metadata.CellMetadata.Bk {
+		if len(bk.Rc) == 1 && bk.Rc[0].T == typeIdx+1 && bk.Rc[0].V == 0 {
+			cm = i
+			break
+		}
+	}
+	if cm == -1 {
+		metadata.CellMetadata.Bk = append(metadata.CellMetadata.Bk, xlsxMetadataBlock{
+			Rc: []xlsxMetadataRecord{{T: typeIdx + 1, V: 0}},
+		})
+		cm = len(metadata.CellMetadata.Bk) - 1
+	}
+	metadata.CellMetadata.Count = len(metadata.CellMetadata.Bk)
+	metadata.XMLNS = NameSpaceSpreadSheet.Value
+	output, err := xml.Marshal(metadata)
+	if err != nil {
+		return nil, err
+	}
+	f.saveFileList(defaultXMLMetadata, output)
+	if err = f.addContentTypePart(0, "metadata"); err != nil {
+		return nil, err
+	}
+	relPath := f.getWorkbookRelsPath()
+	rels, err := f.relsReader(relPath)
+	if err != nil {
+		return nil, err
+	}
+	var exist bool
+	if rels != nil {
+		for _, rel := range rels.Relationships {
+			if rel.Type == SourceRelationshipMetadata {
+				exist = true
+				break
+			}
+		}
+	}
+	if !exist {
+		f.addRels(relPath, SourceRelationshipMetadata, "metadata.xml", "")
+	}
+	return uintPtr(uint(cm + 1)), err
+}
+
 // setArrayFormula transform the array formula in an array formula range to the
 // normal formula and set cells in this range to the formula as the normal
 // formula.
@@ -864,7 +1780,7 @@ func (f *File) setArrayFormulaCells() error {
 	for _, sheetN := range f.GetSheetList() {
 		ws, err := f.workSheetReader(sheetN)
 		if err != nil {
-			if err.Error() == newNotWorksheetError(sheetN).Error() {
+			if errors.Is(err, ErrNotWorksheet{SheetName: sheetN}) {
 				continue
 			}
 			return err
@@ -916,6 +1832,166 @@ func (ws *xlsxWorksheet) countSharedFormula() (count int) {
 	return
 }
 
+// GetCellPrecedents provides a function to get the cell references which are
+// referenced by the formula of the cell by given worksheet name and cell
+// reference. Each reference in the result is returned with the worksheet
+// name, such as "Sheet1!A1" or "Sheet2!A1:B2", and the references by the
+// defined names will be resolved. This function returns an empty list if the
+// cell doesn't contain a formula. For example, get the precedents of the cell
+// "C1" on "Sheet1":
+//
+//	refs, err := f.GetCellPrecedents("Sheet1", "C1")
+func (f *File) GetCellPrecedents(sheet, cell string) ([]string, error) {
+	var precedents []string
+	formula, err := f.GetCellFormula(sheet, cell)
+	if err != nil || formula == "" {
+		return precedents, err
+	}
+	for _, cr := range getFormulaRefs(sheet, formula, f.GetDefinedName()) {
+		ref := cr.String()
+		if inStrSlice(precedents, ref, true) == -1 {
+			precedents = append(precedents, ref)
+		}
+	}
+	return precedents, err
+}
+
+// GetCellDependents provides a function to get the formula cells which
+// directly reference the cell by given worksheet name and cell reference, the
+// formulas in all worksheets of the workbook will be checked. Each cell in
+// the result is returned with the worksheet name, such as "Sheet2!B1". For
+// example, get the dependents of the cell "A1" on "Sheet1":
+//
+//	cells, err := f.GetCellDependents("Sheet1", "A1")
+func (f *File) GetCellDependents(sheet, cell string) ([]string, error) {
+	var dependents []string
+	if err := checkSheetName(sheet); err != nil {
+		return dependents, err
+	}
+	if _, err := f.workSheetReader(sheet); err != nil {
+		return dependents, err
+	}
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return dependents, err
+	}
+	definedNames := f.GetDefinedName()
+	for _, sheetN := range f.GetSheetList() {
+		ws, err := f.workSheetReader(sheetN)
+		if err != nil {
+			if errors.Is(err, ErrNotWorksheet{SheetName: sheetN}) {
+				continue
+			}
+			return dependents, err
+		}
+		ws.mu.Lock()
+		for _, r := range ws.SheetData.Row {
+			for _, c := range r.C {
+				if c.F == nil {
+					continue
+				}
+				formula := c.F.Content
+				if c.F.T == STCellFormulaTypeShared && c.F.Si != nil {
+					formula = getSharedFormula(ws, *c.F.Si, c.R)
+				}
+				for _, cr := range getFormulaRefs(sheetN, formula, definedNames) {
+					if strings.EqualFold(cr.From.Sheet, sheet) &&
+						cellInRange([]int{col, row}, []int{cr.From.Col, cr.From.Row, cr.To.Col, cr.To.Row}) {
+						dependents = append(dependents, sheetN+"!"+c.R)
+						break
+					}
+				}
+			}
+		}
+		ws.mu.Unlock()
+	}
+	return dependents, err
+}
+
+// getFormulaRefs provides a function to get the cell ranges which referenced
+// by the formula with given worksheet name, formula and defined names. The
+// invalid references, such as the table structured references will be
+// ignored.
+func getFormulaRefs(sheet, formula string, definedNames []DefinedName) []cellRange {
+	var (
+		ps     = efp.ExcelParser()
+		ranges []cellRange
+	)
+	for _, token := range ps.Parse(formula) {
+		if token.TType != efp.TokenTypeOperand || token.TSubType != efp.TokenSubTypeRange {
+			continue
+		}
+		reference := token.TValue
+		var workbookRefTo, worksheetRefTo string
+		for _, definedName := range definedNames {
+			if definedName.Name == reference {
+				if definedName.Scope == "Workbook" {
+					workbookRefTo = definedName.RefersTo
+				}
+				if definedName.Scope == sheet {
+					worksheetRefTo = definedName.RefersTo
+				}
+			}
+		}
+		if worksheetRefTo != "" {
+			workbookRefTo = worksheetRefTo
+		}
+		if workbookRefTo != "" {
+			reference = strings.TrimPrefix(workbookRefTo, "=")
+		}
+		for _, ref := range strings.Split(reference, ",") {
+			if cr, ok := parseFormulaRef(sheet, ref); ok {
+				ranges = append(ranges, cr)
+			}
+		}
+	}
+	return ranges
+}
+
+// parseFormulaRef provides a function to convert the cell reference or cell
+// range reference in the formula to the cell range with given default
+// worksheet name.
+func parseFormulaRef(sheet, reference string) (cellRange, bool) {
+	var cr cellRange
+	refs := strings.Split(strings.ReplaceAll(reference, "$", ""), ":")
+	for i, ref := range refs {
+		if parts := strings.Split(ref, "!"); len(parts) == 2 {
+			ref = strings.Trim(parts[0], "'") + "!" + parts[1]
+		}
+		cellRef, col, row, err := parseRef(ref)
+		if err != nil || (len(refs) == 1 && (col || row)) {
+			return cr, false
+		}
+		if i == 0 {
+			if col {
+				cellRef.Row = 1
+			}
+			if row {
+				cellRef.Col = 1
+			}
+			if cellRef.Sheet == "" {
+				cellRef.Sheet = sheet
+			}
+			cr.From, cr.To = cellRef, cellRef
+			continue
+		}
+		if err = cr.prepareCellRange(col, row, cellRef); err != nil {
+			return cr, false
+		}
+	}
+	return cr, true
+}
+
+// String returns the cell range reference with worksheet name.
+func (cr cellRange) String() string {
+	from, _ := CoordinatesToCellName(cr.From.Col, cr.From.Row)
+	if cr.From == cr.To {
+		return cr.From.Sheet + "!" + from
+	}
+	to, _ := CoordinatesToCellName(cr.To.Col, cr.To.Row)
+	return cr.From.Sheet + "!" + from + ":" + to
+}
+
 // GetCellHyperLink gets a cell hyperlink based on the given worksheet name and
 // cell reference. If the cell has a hyperlink, it will return 'true' and
 // the link address, otherwise it will return 'false' and an empty link
@@ -1310,6 +2386,9 @@ func (f *File) SetCellRichText(sheet, cell string, runs []RichTextRun) error {
 	if err != nil {
 		return err
 	}
+	if err = f.checkProtectedCell(ws, sheet, cell); err != nil {
+		return err
+	}
 	c, col, row, err := ws.prepareCell(cell)
 	if err != nil {
 		return err
@@ -1423,10 +2502,18 @@ func (f *File) getCellStringFunc(sheet, cell string, fn func(x *xlsxWorksheet, c
 	if err != nil {
 		return "", err
 	}
-	_, row, err := CellNameToCoordinates(cell)
+	col, row, err := CellNameToCoordinates(cell)
 	if err != nil {
 		return "", err
 	}
+	if f.options != nil && f.options.CellIndex {
+		if colData := ws.lookupCell(col, row, cell); colData != nil {
+			if val, ok, err := fn(ws, colData); ok || err != nil {
+				return val, err
+			}
+		}
+		return "", nil
+	}
 	lastRowNum := 0
 	if l := len(ws.SheetData.Row); l > 0 {
 		lastRowNum = ws.SheetData.Row[l-1].R
@@ -1459,6 +2546,36 @@ func (f *File) getCellStringFunc(sheet, cell string, fn func(x *xlsxWorksheet, c
 	return "", nil
 }
 
+// lookupCell provides a function to find the cell by given column number, row
+// number and cell reference with the index of the rows, the index will be
+// built if it doesn't exist or is outdated. It returns nil if the cell does
+// not exist.
+func (ws *xlsxWorksheet) lookupCell(col, row int, cell string) *xlsxC {
+	rowIdx, ok := ws.rowIndex[row]
+	if ws.rowIndex == nil || (ok && (rowIdx >= len(ws.SheetData.Row) || ws.SheetData.Row[rowIdx].R != row)) {
+		ws.rowIndex = make(map[int]int, len(ws.SheetData.Row))
+		for idx := range ws.SheetData.Row {
+			ws.rowIndex[ws.SheetData.Row[idx].R] = idx
+		}
+		rowIdx, ok = ws.rowIndex[row]
+	}
+	if !ok {
+		return nil
+	}
+	cells := ws.SheetData.Row[rowIdx].C
+	if col <= len(cells) && cells[col-1].R == cell {
+		return &cells[col-1]
+	}
+	idx := sort.Search(len(cells), func(i int) bool {
+		c, _, _ := CellNameToCoordinates(cells[i].R)
+		return c >= col
+	})
+	if idx < len(cells) && cells[idx].R == cell {
+		return &cells[idx]
+	}
+	return nil
+}
+
 // formattedValue provides a function to returns a value after formatted. If
 // it is possible to apply a format to the cell value, it will do so, if not
 // then an error will be returned, along with the raw value of the cell.
@@ -1480,6 +2597,12 @@ func (f *File) formattedValue(c *xlsxC, raw bool, cellType CellType) (string, er
 	if styleSheet.CellXfs.Xf[c.S].NumFmtID != nil {
 		numFmtID = *styleSheet.CellXfs.Xf[c.S].NumFmtID
 	}
+	return f.formatNumFmtValue(styleSheet, c, numFmtID, cellType)
+}
+
+// formatNumFmtValue provides a function to returns a value after formatted
+// with the custom or built-in number format by given number format ID.
+func (f *File) formatNumFmtValue(styleSheet *xlsxStyleSheet, c *xlsxC, numFmtID int, cellType CellType) (string, error) {
 	date1904 := false
 	wb, err := f.workbookReader()
 	if err != nil {
@@ -1497,6 +2620,29 @@ func (f *File) formattedValue(c *xlsxC, raw bool, cellType CellType) (string, er
 	return c.V, err
 }
 
+// FormatCellValue provides a function to format the raw value by given raw
+// cell value and number format ID, the number format ID could be a built-in
+// number format ID or the custom number format ID in the workbook, which
+// could be reused for formatting the values outside the worksheets in the same
+// way as the GetCellValue function. The raw value which is not a number will
+// be formatted by the text section of the number format. For example, format
+// the number with the built-in fraction number format:
+//
+//	result, err := f.FormatCellValue("1.5", 12)
+//
+// The result will be "1 1/2".
+func (f *File) FormatCellValue(raw string, numFmtID int) (string, error) {
+	styleSheet, err := f.stylesReader()
+	if err != nil {
+		return raw, err
+	}
+	cellType := CellTypeNumber
+	if isNum, _, _ := isNumeric(raw); !isNum {
+		cellType = CellTypeInlineString
+	}
+	return f.formatNumFmtValue(styleSheet, &xlsxC{V: raw}, numFmtID, cellType)
+}
+
 // getCustomNumFmtCode provides a function to returns custom number format code.
 func (ss *xlsxStyleSheet) getCustomNumFmtCode(numFmtID int) (string, bool) {
 	if ss.NumFmts == nil {
@@ -1534,6 +2680,56 @@ func (ws *xlsxWorksheet) prepareCellStyle(col, row, style int) int {
 	return style
 }
 
+// checkProtectedCell provides a function to check if the cell is editable by
+// given worksheet and cell reference when the ProtectedCellGuard option is
+// enabled, which should be called before preparing the cell to avoid changing
+// the worksheet on rejected writes. The cell is editable if the worksheet is
+// not protected, the cell is unlocked or the cell is in the allow edit ranges
+// without password.
+func (f *File) checkProtectedCell(ws *xlsxWorksheet, sheet, cell string) error {
+	if f.options == nil || !f.options.ProtectedCellGuard || ws.SheetProtection == nil || !ws.SheetProtection.Sheet {
+		return nil
+	}
+	cell, err := ws.mergeCellsParser(cell)
+	if err != nil {
+		return err
+	}
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return err
+	}
+	s, err := f.stylesReader()
+	if err != nil {
+		return err
+	}
+	if styleID, _ := ws.getCellStyleSource(col, row); s.CellXfs != nil && styleID < len(s.CellXfs.Xf) {
+		if xf := s.CellXfs.Xf[styleID]; xf.Protection != nil && xf.Protection.Locked != nil && !*xf.Protection.Locked {
+			return err
+		}
+	}
+	if ws.ProtectedRanges != nil {
+		for _, pr := range ws.ProtectedRanges.ProtectedRange {
+			if pr == nil || pr.Password != "" || pr.HashValue != "" {
+				continue
+			}
+			for _, ref := range strings.Fields(pr.Sqref) {
+				if !strings.Contains(ref, ":") {
+					ref += ":" + ref
+				}
+				coordinates, err := rangeRefToCoordinates(ref)
+				if err != nil {
+					return err
+				}
+				_ = sortCoordinates(coordinates)
+				if cellInRange([]int{col, row}, coordinates) {
+					return nil
+				}
+			}
+		}
+	}
+	return ErrProtectedCell{SheetName: sheet, Cell: cell}
+}
+
 // mergeCellsParser provides a function to check merged cells in worksheet by
 // given cell reference.
 func (ws *xlsxWorksheet) mergeCellsParser(cell string) (string, error) {
@@ -1594,16 +2790,10 @@ func cellInRange(cell, ref []int) bool {
 	return cell[0] >= ref[0] && cell[0] <= ref[2] && cell[1] >= ref[1] && cell[1] <= ref[3]
 }
 
-// isOverlap find if the given two rectangles overlap or not.
+// isOverlap find if the given two rectangles overlap or not, the coordinates
+// of the rectangles should be sorted.
 func isOverlap(rect1, rect2 []int) bool {
-	return cellInRange([]int{rect1[0], rect1[1]}, rect2) ||
-		cellInRange([]int{rect1[2], rect1[1]}, rect2) ||
-		cellInRange([]int{rect1[0], rect1[3]}, rect2) ||
-		cellInRange([]int{rect1[2], rect1[3]}, rect2) ||
-		cellInRange([]int{rect2[0], rect2[1]}, rect1) ||
-		cellInRange([]int{rect2[2], rect2[1]}, rect1) ||
-		cellInRange([]int{rect2[0], rect2[3]}, rect1) ||
-		cellInRange([]int{rect2[2], rect2[3]}, rect1)
+	return rect1[0] <= rect2[2] && rect2[0] <= rect1[2] && rect1[1] <= rect2[3] && rect2[1] <= rect1[3]
 }
 
 // parseSharedFormula generate dynamic part of shared formula for target cell
@@ -1662,22 +2852,29 @@ func getSharedFormula(ws *xlsxWorksheet, si int, cell string) string {
 		for column := 0; column < len(r.C); column++ {
 			c := &r.C[column]
 			if c.F != nil && c.F.Ref != "" && c.F.T == STCellFormulaTypeShared && c.F.Si != nil && *c.F.Si == si {
-				col, row, _ := CellNameToCoordinates(cell)
-				sharedCol, sharedRow, _ := CellNameToCoordinates(c.R)
-				dCol := col - sharedCol
-				dRow := row - sharedRow
-				orig := []byte(c.F.Content)
-				res, start := parseSharedFormula(dCol, dRow, orig)
-				if start < len(orig) {
-					res += string(orig[start:])
-				}
-				return res
+				return shiftSharedFormula(c, cell)
 			}
 		}
 	}
 	return ""
 }
 
+// shiftSharedFormula provides a function to get the formula of the cell in
+// the shared formula by given master cell of the shared formula and cell
+// reference.
+func shiftSharedFormula(master *xlsxC, cell string) string {
+	col, row, _ := CellNameToCoordinates(cell)
+	sharedCol, sharedRow, _ := CellNameToCoordinates(master.R)
+	dCol := col - sharedCol
+	dRow := row - sharedRow
+	orig := []byte(master.F.Content)
+	res, start := parseSharedFormula(dCol, dRow, orig)
+	if start < len(orig) {
+		res += string(orig[start:])
+	}
+	return res
+}
+
 // shiftCell returns the cell shifted according to dCol and dRow taking into
 // consideration absolute references with dollar sign ($)
 func shiftCell(cellID string, dCol, dRow int) string {
@@ -29,6 +29,11 @@ func TestConcurrency(t *testing.T) {
 			// Concurrency get cell value
 			_, err := f.GetCellValue("Sheet1", fmt.Sprintf("A%d", val))
 			assert.NoError(t, err)
+			// Concurrency get hidden rows and columns
+			_, err = f.GetHiddenRows("Sheet1")
+			assert.NoError(t, err)
+			_, err = f.GetHiddenCols("Sheet1")
+			assert.NoError(t, err)
 			// Concurrency set rows
 			assert.NoError(t, f.SetSheetRow("Sheet1", "B6", &[]interface{}{
 				" Hello",
@@ -501,6 +506,98 @@ func TestGetCellValue(t *testing.T) {
 	assert.Equal(t, ErrSheetNameInvalid, err)
 }
 
+func TestGetCellValues(t *testing.T) {
+	f := NewFile()
+	for cell, value := range map[string]interface{}{"A1": "A1", "C5": 5.5, "F10": true, "B2": "B2"} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+	}
+	assert.NoError(t, f.MergeCell("Sheet1", "B2", "C3"))
+	style, err := f.NewStyle(&Style{NumFmt: 9})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "C5", "C5", style))
+	values, err := f.GetCellValues("Sheet1", []string{"F10", "A1", "C3", "C5", "A1", "Z100", "D4"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"TRUE", "A1", "B2", "550%", "A1", "", ""}, values)
+	values, err = f.GetCellValues("Sheet1", []string{"C5"}, Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"5.5"}, values)
+	cells := []string{"F10", "A1", "C3", "C5"}
+	values, err = f.GetCellValues("Sheet1", cells)
+	assert.NoError(t, err)
+	for i, cell := range cells {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, value, values[i])
+	}
+	// Test get cell values with invalid sheet name
+	_, err = f.GetCellValues("Sheet:1", []string{"A1"})
+	assert.Equal(t, ErrSheetNameInvalid, err)
+	// Test get cell values with invalid cell reference
+	_, err = f.GetCellValues("Sheet1", []string{"A1", "A"})
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	// Test get cell values with invalid cell reference in the worksheet
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[0].R = "A"
+	_, err = f.GetCellValues("Sheet1", []string{"A1"})
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	// Test get cell values with unsupported charset shared strings table
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	_, err = f.GetCellValues("Sheet1", []string{"A1"})
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestCellIndex(t *testing.T) {
+	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"), Options{CellIndex: true})
+	assert.NoError(t, err)
+	f2, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
+	assert.NoError(t, err)
+	check := func() {
+		rows, err := f2.GetRows("Sheet1")
+		assert.NoError(t, err)
+		for r := 1; r <= len(rows)+2; r++ {
+			for c := 1; c <= 10; c++ {
+				cell, _ := CoordinatesToCellName(c, r)
+				expected, err := f2.GetCellValue("Sheet1", cell)
+				assert.NoError(t, err)
+				value, err := f.GetCellValue("Sheet1", cell)
+				assert.NoError(t, err)
+				assert.Equal(t, expected, value, cell)
+			}
+		}
+	}
+	check()
+	for _, fn := range []func(f *File) error{
+		func(f *File) error { return f.SetCellValue("Sheet1", "B30", "B30") },
+		func(f *File) error { return f.InsertRows("Sheet1", 2, 3) },
+		func(f *File) error { return f.RemoveRow("Sheet1", 4) },
+		func(f *File) error { return f.DuplicateRowTo("Sheet1", 1, 40) },
+		func(f *File) error { return f.InsertCols("Sheet1", "B", 2) },
+		func(f *File) error { return f.RemoveCol("Sheet1", "A") },
+		func(f *File) error { return f.SetCellFormula("Sheet1", "C45", "SUM(A1:A2)") },
+	} {
+		assert.NoError(t, fn(f))
+		assert.NoError(t, fn(f2))
+		check()
+	}
+	// Test lookup cell with the outdated index
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).rowIndex[1] = len(ws.(*xlsxWorksheet).SheetData.Row)
+	check()
+	// Test lookup cell in the row with sparse cells
+	ws.(*xlsxWorksheet).SheetData.Row[0].C = []xlsxC{{R: "B1", V: "1"}, {R: "D1", V: "2"}}
+	for cell, expected := range map[string]string{"A1": "", "B1": "1", "C1": "", "D1": "2", "E1": ""} {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, cell)
+	}
+	assert.NoError(t, f.Close())
+	assert.NoError(t, f2.Close())
+}
+
 func TestGetCellType(t *testing.T) {
 	f := NewFile()
 	cellType, err := f.GetCellType("Sheet1", "A1")
@@ -517,6 +614,132 @@ func TestGetCellType(t *testing.T) {
 	assert.Equal(t, ErrSheetNameInvalid, err)
 }
 
+func TestSetCellError(t *testing.T) {
+	f := NewFile()
+	for i, errType := range []CellErrorType{
+		CellErrorNull, CellErrorDiv0, CellErrorValue, CellErrorRef, CellErrorName,
+		CellErrorNum, CellErrorNA, CellErrorGettingData, CellErrorSpill, CellErrorCalc,
+	} {
+		cell, err := CoordinatesToCellName(1, i+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, errType))
+		cellType, err := f.GetCellType("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, CellTypeError, cellType)
+		result, err := f.GetCellError("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, errType, result)
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, errType.String(), value)
+	}
+	// Test get error value type of the non-error cells
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", "#N/A"))
+	for _, cell := range []string{"B1", "B2"} {
+		result, err := f.GetCellError("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, CellErrorNone, result)
+	}
+	// Test get error value type of the formula cell with cached error result
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "1/0"))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[2].T, ws.(*xlsxWorksheet).SheetData.Row[0].C[2].V = "e", "#DIV/0!"
+	result, err := f.GetCellError("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, CellErrorDiv0, result)
+	// Test set error value type will remove the formula
+	assert.NoError(t, f.SetCellError("Sheet1", "C1", CellErrorValue))
+	formula, err := f.GetCellFormula("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Empty(t, formula)
+	// Test set cell error with invalid error value type
+	assert.Equal(t, ErrParameterInvalid, f.SetCellError("Sheet1", "A1", CellErrorNone))
+	assert.Equal(t, ErrParameterInvalid, f.SetCellError("Sheet1", "A1", CellErrorCalc+1))
+	assert.Empty(t, CellErrorNone.String())
+	// Test get error value type with unknown error value
+	assert.Equal(t, CellErrorNone, getCellErrorType("#UNKNOWN!"))
+	// Test set and get cell error with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetCellError("Sheet1", "A", CellErrorNA))
+	_, err = f.GetCellError("Sheet1", "A")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	// Test set and get cell error on not exists worksheet
+	assert.EqualError(t, f.SetCellError("SheetN", "A1", CellErrorNA), "sheet SheetN does not exist")
+	_, err = f.GetCellError("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
+func TestSetCellBlank(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(&Style{Fill: Fill{Type: "pattern", Pattern: 1, Color: []string{"FFFF00"}}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 100))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A2", "A1*2"))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A2", style))
+	// Test clear the value of the cells and keep the style
+	assert.NoError(t, f.SetCellBlank("Sheet1", "A1"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", nil))
+	for _, cell := range []string{"A1", "A2"} {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Empty(t, value)
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Empty(t, formula)
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, style, styleID)
+	}
+	// Test set cell blank with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetCellBlank("Sheet1", "A"))
+	// Test set cell blank on not exists worksheet
+	assert.EqualError(t, f.SetCellBlank("SheetN", "A1"), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
+func TestClearRange(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(&Style{Fill: Fill{Type: "pattern", Pattern: 1, Color: []string{"FFFF00"}}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{1, 2, 3}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{4, 5, 6}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A3", "SUM(A1:A2)"))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "C3", style))
+	// Test clear everything of the cell
+	assert.NoError(t, f.ClearCell("Sheet1", "A1"))
+	// Test clear everything of the cells in the range with reversed reference
+	assert.NoError(t, f.ClearRange("Sheet1", "B3", "A2"))
+	for cell, expected := range map[string][]interface{}{
+		"A1": {"", 0}, "B1": {"2", style}, "C1": {"3", style}, "A2": {"", 0},
+		"B2": {"", 0}, "C2": {"6", style}, "A3": {"", 0}, "C3": {"", style},
+	} {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected[0], value, cell)
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected[1], styleID, cell)
+	}
+	formula, err := f.GetCellFormula("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.Empty(t, formula)
+	// Test the cleared cells will not be written into the workbook
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestClearRange.xlsx")))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, ws.SheetData.Row[1].C, 1)
+	// Test clear range with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.ClearRange("Sheet1", "A", "B1"))
+	assert.Equal(t, newCellNameToCoordinatesError("B", newInvalidCellNameError("B")), f.ClearRange("Sheet1", "A1", "B"))
+	// Test clear range on not exists worksheet
+	assert.EqualError(t, f.ClearCell("SheetN", "A1"), "sheet SheetN does not exist")
+	// Test clear range with invalid cell reference in the worksheet
+	ws.SheetData.Row[0].C[1].R = "B"
+	assert.Equal(t, newCellNameToCoordinatesError("B", newInvalidCellNameError("B")), f.ClearRange("Sheet1", "A1", "B1"))
+	assert.NoError(t, f.Close())
+}
+
 func TestGetValueFrom(t *testing.T) {
 	f := NewFile()
 	c := xlsxC{T: "s"}
@@ -589,6 +812,29 @@ func TestGetCellFormula(t *testing.T) {
 	assert.EqualError(t, f.setArrayFormulaCells(), "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestGetSheetFormulas(t *testing.T) {
+	f := NewFile()
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData><row r="1"><c r="A1"><v>1</v></c><c r="B1"><f>2*A1</f></c></row><row r="2"><c r="A2"><v>2</v></c><c r="B2"><f t="shared" ref="B2:B4" si="0">2*A2</f></c><c r="C2"><f t="shared" si="1"/></c></row><row r="3"><c r="A3"><v>3</v></c><c r="B3"><f t="shared" si="0"/></c></row><row r="4"><c r="A4"><v>4</v></c><c r="B4"><f t="shared" si="0"/></c></row></sheetData></worksheet>`))
+	assert.NoError(t, f.SetDataTable("Sheet1", "D1:E3", &DataTableOptions{ColumnInputCell: "A1"}))
+	formulas, err := f.GetSheetFormulas("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"B1": "2*A1", "B2": "2*A2", "B3": "2*A3", "B4": "2*A4", "E2": "TABLE(,A1)",
+	}, formulas)
+	for cell, formula := range formulas {
+		expected, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula)
+	}
+	// Test get formulas on not exist worksheet
+	_, err = f.GetSheetFormulas("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get formulas with invalid sheet name
+	_, err = f.GetSheetFormulas("Sheet:1")
+	assert.Equal(t, ErrSheetNameInvalid, err)
+}
+
 func ExampleFile_SetCellFloat() {
 	f := NewFile()
 	defer func() {
@@ -700,6 +946,186 @@ func TestSetCellFormula(t *testing.T) {
 	// Test set array formula with invalid cell reference
 	formulaType, ref = STCellFormulaTypeArray, "A1:A2"
 	assert.Equal(t, ErrColumnNumber, f.SetCellFormula("Sheet1", "A1", "SUM(XFE1:XFE2)", FormulaOpts{Ref: &ref, Type: &formulaType}))
+
+	// Test set array formula with the range doesn't start with the formula cell
+	formulaType, ref = STCellFormulaTypeArray, "A1:A2"
+	assert.Equal(t, newInvalidArrayFormulaRangeError(ref), f.SetCellFormula("Sheet1", "A2", "=B1:B2", FormulaOpts{Ref: &ref, Type: &formulaType}))
+
+	// Test set volatile formula
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A3", "=NOW()"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A4", "=A1", FormulaOpts{Volatile: true}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A5", "=A1"))
+	for cell, expected := range map[string]bool{"A3": true, "A4": true, "A5": false} {
+		opts, err := f.GetCellFormulaOpts("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, opts.Volatile, cell)
+	}
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A3", "=A1"))
+	opts, err := f.GetCellFormulaOpts("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.False(t, opts.Volatile)
+
+	// Test set legacy array formula over a range of cells
+	f = NewFile()
+	for r := 1; r <= 3; r++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", r), &[]interface{}{r, r * 2}))
+	}
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C2", "=A2"))
+	formulaType, ref = STCellFormulaTypeArray, "C1:C3"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "=A1:A3*B1:B3", FormulaOpts{Ref: &ref, Type: &formulaType}))
+	formula, err := f.GetCellFormula("Sheet1", "C2")
+	assert.NoError(t, err)
+	assert.Empty(t, formula)
+	for _, cell := range []string{"C1", "C2", "C3"} {
+		opts, err := f.GetCellFormulaOpts("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, FormulaOpts{Type: &formulaType, Ref: &ref}, opts)
+	}
+	for cell, expected := range map[string]string{"C1": "2", "C2": "8", "C3": "18"} {
+		result, err := f.CalcCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result, cell)
+	}
+	opts, err = f.GetCellFormulaOpts("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, FormulaOpts{}, opts)
+	// Test set array formula without range reference
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "=SUM(A1:A3)", FormulaOpts{Type: &formulaType}))
+	opts, err = f.GetCellFormulaOpts("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, "D1", *opts.Ref)
+	assert.False(t, opts.Dynamic)
+
+	// Test set dynamic array formula
+	ref = "E1:E3"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "E1", "=SORT(A1:A3,,-1)", FormulaOpts{Ref: &ref, Dynamic: true}))
+	ref = "F1:F3"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "F1", "=SORT(B1:B3,,-1)", FormulaOpts{Ref: &ref, Dynamic: true}))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, uintPtr(1), ws.SheetData.Row[0].C[4].Cm)
+	assert.Equal(t, uintPtr(1), ws.SheetData.Row[0].C[5].Cm)
+	opts, err = f.GetCellFormulaOpts("Sheet1", "F3")
+	assert.NoError(t, err)
+	assert.Equal(t, FormulaOpts{Type: &formulaType, Ref: &ref, Dynamic: true}, opts)
+	metadata, err := f.metadataReader()
+	assert.NoError(t, err)
+	assert.Len(t, metadata.MetadataTypes.MetadataType, 1)
+	assert.Len(t, metadata.FutureMetadata, 1)
+	assert.Equal(t, 1, metadata.CellMetadata.Count)
+	dynamicArrayFormulaSpreadsheet := filepath.Join("test", "TestSetCellFormula7.xlsx")
+	assert.NoError(t, f.SaveAs(dynamicArrayFormulaSpreadsheet))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(dynamicArrayFormulaSpreadsheet)
+	assert.NoError(t, err)
+	opts, err = f.GetCellFormulaOpts("Sheet1", "E2")
+	assert.NoError(t, err)
+	assert.True(t, opts.Dynamic)
+	assert.Equal(t, "E1:E3", *opts.Ref)
+	// Test overwrite dynamic array formula by the normal formula
+	assert.NoError(t, f.SetCellFormula("Sheet1", "E1", "=A1"))
+	opts, err = f.GetCellFormulaOpts("Sheet1", "E1")
+	assert.NoError(t, err)
+	assert.False(t, opts.Dynamic)
+	// Test get cell formula options with invalid sheet name
+	_, err = f.GetCellFormulaOpts("Sheet:1", "A1")
+	assert.Equal(t, ErrSheetNameInvalid, err)
+	// Test get cell formula options with invalid cell reference
+	_, err = f.GetCellFormulaOpts("Sheet1", "A")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	// Test set dynamic array formula with unsupported charset metadata
+	f.Pkg.Store(defaultXMLMetadata, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCellFormula("Sheet1", "G1", "=A1:A3", FormulaOpts{Dynamic: true}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestSetCellFormulaLocalized(t *testing.T) {
+	f := NewFile(Options{CultureInfo: CultureNameDeDE})
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 1))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A2", "=SUMME(A1;2,5)", FormulaOpts{Localized: true}))
+	formula, err := f.GetCellFormula("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, "=SUM(A1,2.5)", formula)
+	result, err := f.CalcCellValue("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, "3.5", result)
+	// Test set the formula without localized option
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A3", "=SUM(A1,2)"))
+	formula, err = f.GetCellFormula("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.Equal(t, "=SUM(A1,2)", formula)
+	assert.NoError(t, f.Close())
+}
+
+func TestGetCellPrecedentsAndDependents(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet 2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Amount", RefersTo: "'Sheet 2'!$A$1:$A$3"}))
+	for cell, formula := range map[string]string{
+		"B1": "A1*2",
+		"B2": "SUM($A$1:A3)+A1",
+		"B3": "SUM(Amount)",
+		"B4": "'Sheet 2'!B1+SUM(C:C)",
+		"B5": "SUM(2:2,Table1[Col])",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", cell, formula))
+	}
+	assert.NoError(t, f.SetCellFormula("Sheet 2", "C1", "Sheet1!A2+Sheet1!B1"))
+	formulaType, ref := STCellFormulaTypeShared, "D1:D3"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "A1+1", FormulaOpts{Ref: &ref, Type: &formulaType}))
+
+	for cell, expected := range map[string][]string{
+		"A1": nil,
+		"B1": {"Sheet1!A1"},
+		"B2": {"Sheet1!A1:A3", "Sheet1!A1"},
+		"B3": {"Sheet 2!A1:A3"},
+		"B4": {"Sheet 2!B1", "Sheet1!C1:C1048576"},
+		"B5": {"Sheet1!A2:XFD2"},
+		"D2": {"Sheet1!A2"},
+	} {
+		precedents, err := f.GetCellPrecedents("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, precedents, cell)
+	}
+	for cell, expected := range map[string][]string{
+		"A1": {"Sheet1!B1", "Sheet1!D1", "Sheet1!B2"},
+		"A2": {"Sheet1!B2", "Sheet1!D2", "Sheet1!B5", "Sheet 2!C1"},
+		"C5": {"Sheet1!B4"},
+		"E1": nil,
+	} {
+		dependents, err := f.GetCellDependents("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, dependents, cell)
+	}
+	dependents, err := f.GetCellDependents("Sheet 2", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Sheet1!B3"}, dependents)
+	dependents, err = f.GetCellDependents("Sheet 2", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Sheet1!B4"}, dependents)
+
+	// Test get cell precedents and dependents with invalid sheet name
+	_, err = f.GetCellPrecedents("Sheet:1", "A1")
+	assert.Equal(t, ErrSheetNameInvalid, err)
+	_, err = f.GetCellDependents("Sheet:1", "A1")
+	assert.Equal(t, ErrSheetNameInvalid, err)
+	// Test get cell precedents and dependents on not exists worksheet
+	_, err = f.GetCellPrecedents("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	_, err = f.GetCellDependents("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get cell dependents with invalid cell reference
+	_, err = f.GetCellDependents("Sheet1", "A")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	// Test get cell dependents with unsupported charset worksheet
+	f.Sheet.Delete("xl/worksheets/sheet2.xml")
+	f.Pkg.Store("xl/worksheets/sheet2.xml", MacintoshCyrillicCharset)
+	f.checked = sync.Map{}
+	_, err = f.GetCellDependents("Sheet1", "A1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
 }
 
 func TestGetCellRichText(t *testing.T) {
@@ -1110,3 +1536,576 @@ func TestSharedStringsError(t *testing.T) {
 func TestSIString(t *testing.T) {
 	assert.Empty(t, xlsxSI{}.String())
 }
+
+func TestSetCellCheckbox(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B1", "B1", style))
+	for cell, checked := range map[string]bool{"A1": true, "A2": false, "B1": true} {
+		assert.NoError(t, f.SetCellCheckbox("Sheet1", cell, checked))
+	}
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "TRUE", val)
+	// Test the cells without custom style share the same checkbox style
+	styleA1, err := f.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	styleA2, err := f.GetCellStyle("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, styleA1, styleA2)
+	// Test the checkbox style keeps the origin cell formatting
+	styleB1, err := f.GetCellStyle("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.NotEqual(t, styleA1, styleB1)
+	s, err := f.GetStyle(styleB1)
+	assert.NoError(t, err)
+	assert.True(t, s.Font.Bold)
+	assert.Equal(t, `<ext uri="{C7286773-470A-42A8-94C5-96B5CB345126}" xmlns:xfpb="http://schemas.microsoft.com/office/spreadsheetml/2022/featurepropertybag"><xfpb:xfComplement i="0"/></ext>`, f.Styles.CellXfs.Xf[styleB1].ExtLst.Ext)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetCellCheckbox.xlsx")))
+	assert.NoError(t, f.Close())
+	// Test set checkbox on the workbook which already contains checkboxes
+	f, err = OpenFile(filepath.Join("test", "TestSetCellCheckbox.xlsx"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellCheckbox("Sheet1", "A3", true))
+	styleA3, err := f.GetCellStyle("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.Equal(t, styleA1, styleA3)
+	bags, err := f.featurePropertyBagReader()
+	assert.NoError(t, err)
+	assert.Len(t, bags.Bag, 4)
+	rels, err := f.relsReader(defaultXMLPathWorkbookRels)
+	assert.NoError(t, err)
+	var count int
+	for _, rel := range rels.Relationships {
+		if rel.Type == SourceRelationshipFeaturePropertyBag {
+			count++
+		}
+	}
+	assert.Equal(t, 1, count)
+	// Test append checkbox property bags on the exist feature property bags
+	f.Pkg.Store(defaultXMLPathFeaturePropertyBag, []byte(`<FeaturePropertyBags xmlns="http://schemas.microsoft.com/office/spreadsheetml/2022/featurepropertybag"><bag type="Checkbox"/><bag type="XFComplements" extRef="XFComplementsMapperExtRef"><a k="MappedFeaturePropertyBags"><bagId>0</bagId></a></bag></FeaturePropertyBags>`))
+	idx, err := f.addCheckboxFeaturePropertyBag()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, idx)
+	// Test set checkbox with not exist worksheet
+	assert.EqualError(t, f.SetCellCheckbox("SheetN", "A1", true), "sheet SheetN does not exist")
+	// Test set checkbox with invalid style ID
+	_, err = f.getCheckboxStyleID(-1)
+	assert.Equal(t, newInvalidStyleID(-1), err)
+	// Test set checkbox with unsupported charset feature property bags
+	f.Pkg.Store(defaultXMLPathFeaturePropertyBag, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCellCheckbox("Sheet1", "A4", true), "XML syntax error on line 1: invalid UTF-8")
+	// Test set checkbox with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCellCheckbox("Sheet1", "A4", true), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestSetCellDecimal(t *testing.T) {
+	f := NewFile()
+	for cell, value := range map[string]string{
+		"A1": "19.99", "A2": "+0.1", "A3": "-12345678901234567890.123456789", "A4": "1.5E-3", "A5": ".5",
+	} {
+		assert.NoError(t, f.SetCellDecimal("Sheet1", cell, value))
+	}
+	for cell, expected := range map[string]string{
+		"A1": "19.99", "A2": "0.1", "A3": "-12345678901234567890.123456789", "A4": "1.5E-3", "A5": ".5", "A6": "",
+	} {
+		value, err := f.GetCellDecimal("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value)
+	}
+	cellType, err := f.GetCellType("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeUnset, cellType)
+	// Test calculate the formula without float64 artifacts
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "=A1*3"))
+	result, err := f.CalcCellValue("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, "59.97", result)
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B2", "=A2+0.2"))
+	result, err = f.CalcCellValue("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.Equal(t, "0.3", result)
+	// Test overwrite the formula cell with decimal
+	assert.NoError(t, f.SetCellDecimal("Sheet1", "B2", "1"))
+	formula, err := f.GetCellFormula("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.Empty(t, formula)
+	// Test set and get invalid decimal
+	for _, value := range []string{"", "1.2.3", "abc", "1e", "0x10", "1_000"} {
+		assert.EqualError(t, f.SetCellDecimal("Sheet1", "A1", value), fmt.Sprintf("invalid decimal %q", value))
+	}
+	assert.NoError(t, f.SetCellStr("Sheet1", "C1", "text"))
+	_, err = f.GetCellDecimal("Sheet1", "C1")
+	assert.EqualError(t, err, "invalid decimal \"text\"")
+	// Test get decimal on the shared string, inline string and boolean cells
+	assert.NoError(t, f.SetCellStr("Sheet1", "C2", "12"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C3", true))
+	assert.NoError(t, f.SetCellRichText("Sheet1", "C4", []RichTextRun{{Text: "12"}}))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[3].C[2] = xlsxC{R: "C4", T: "inlineStr", IS: &xlsxSI{T: &xlsxT{Val: "12"}}}
+	for cell, expected := range map[string]string{"C2": "12", "C3": "1", "C4": "12"} {
+		_, err = f.GetCellDecimal("Sheet1", cell)
+		assert.EqualError(t, err, fmt.Sprintf("invalid decimal %q", expected), cell)
+	}
+	// Test calculate the formula with the exact decimal numbers
+	for cell, formula := range map[string]string{
+		"D1": "=A3*2", "D2": "=A3+A1-0.99", "D3": "=-A3", "D4": "=0.1+0.2", "D5": "=A4*A5", "D6": "=A1/2",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", cell, formula))
+	}
+	for cell, expected := range map[string]string{
+		"D1": "-24691357802469135780.246913578", "D2": "-12345678901234567871.123456789",
+		"D3": "12345678901234567890.123456789", "D4": "0.3", "D5": "0.00075", "D6": "9.995",
+	} {
+		result, err = f.CalcCellValue("Sheet1", cell, Options{CalcDecimal: true})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result, cell)
+	}
+	result, err = f.CalcCellValue("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, "-2.46913578024691E+19", result)
+	// Test calculate the formula with the exact decimal numbers on the
+	// non-numeric operands
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D7", "=C1*2"))
+	expected, expectedErr := f.CalcCellValue("Sheet1", "D7")
+	result, err = f.CalcCellValue("Sheet1", "D7", Options{CalcDecimal: true})
+	assert.Equal(t, expectedErr, err)
+	assert.Equal(t, expected, result)
+	assert.NoError(t, f.SetCellDecimal("Sheet1", "C5", "1E+400"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D8", "=C5+1"))
+	expected, expectedErr = f.CalcCellValue("Sheet1", "D8")
+	result, err = f.CalcCellValue("Sheet1", "D8", Options{CalcDecimal: true})
+	assert.Equal(t, expectedErr, err)
+	assert.Equal(t, expected, result)
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D8", "=TRUE+1"))
+	result, err = f.CalcCellValue("Sheet1", "D8", Options{CalcDecimal: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "2", result)
+	// Test set and get decimal with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetCellDecimal("Sheet1", "A", "1"))
+	_, err = f.GetCellDecimal("Sheet1", "A")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	// Test set and get decimal on not exists worksheet
+	assert.EqualError(t, f.SetCellDecimal("SheetN", "A1", "1"), "sheet SheetN does not exist")
+	_, err = f.GetCellDecimal("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
+func TestFormatCellValue(t *testing.T) {
+	f := NewFile()
+	for _, item := range [][]interface{}{
+		{"1.5", 12, "1 1/2"},
+		{"3.25", 13, "3 1/4"},
+		{"12345", 48, "12.3E+3"},
+		{"0.5", 10, "50.00%"},
+		{"text", 2, "text"},
+		{"1.5", 0, "1.5"},
+	} {
+		result, err := f.FormatCellValue(item[0].(string), item[1].(int))
+		assert.NoError(t, err)
+		assert.Equal(t, item[2], result, item)
+	}
+	// Test format value with custom number format
+	customNumFmt := "0.00;-0.00;\"zero\";\"T:\"@"
+	_, err := f.NewStyle(&Style{CustomNumFmt: &customNumFmt})
+	assert.NoError(t, err)
+	for raw, expected := range map[string]string{"1": "1.00", "-1": "-1.00", "0": "zero", "text": "T:text"} {
+		result, err := f.FormatCellValue(raw, 164)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	}
+	// Test format value with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	_, err = f.FormatCellValue("1", 2)
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestSetDataTable(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetCol("Sheet1", "B1", &[]interface{}{0.05, 360, 100000}))
+	// Test two-variable data table
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A4", "PMT(B1/12,B2,B3)"))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "B4", &[]interface{}{180, 240, 360}))
+	assert.NoError(t, f.SetSheetCol("Sheet1", "A5", &[]interface{}{0.04, 0.05, 0.06}))
+	assert.NoError(t, f.SetDataTable("Sheet1", "A4:D7", &DataTableOptions{RowInputCell: "$B$2", ColumnInputCell: "B1"}))
+	// Test one-variable data table with column input cell
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B10", "B1*2"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C10", "B1+1"))
+	assert.NoError(t, f.SetSheetCol("Sheet1", "A11", &[]interface{}{1, 2, 3}))
+	assert.NoError(t, f.SetDataTable("Sheet1", "C13:A10", &DataTableOptions{ColumnInputCell: "B1"}))
+	// Test one-variable data table with row input cell
+	assert.NoError(t, f.SetSheetRow("Sheet1", "B15", &[]interface{}{1, 2, 3}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A16", "B1*10"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A17", "SUM(B1:B2)"))
+	assert.NoError(t, f.SetDataTable("Sheet1", "A15:D17", &DataTableOptions{RowInputCell: "B1"}))
+
+	for _, formula := range []struct{ cell, formula string }{
+		{"B5", "TABLE(B2,B1)"}, {"C6", ""}, {"B11", "TABLE(,B1)"}, {"B16", "TABLE(B1,)"},
+	} {
+		result, err := f.GetCellFormula("Sheet1", formula.cell)
+		assert.NoError(t, err)
+		assert.Equal(t, formula.formula, result, formula.cell)
+	}
+	assert.NoError(t, f.SetCellFormula("Sheet1", "F1", "PMT(0.04/12,180,100000)"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "F2", "PMT(0.06/12,360,100000)"))
+	expected := map[string]string{"B11": "2", "C12": "3", "C11": "2", "B13": "6", "B16": "10", "C17": "362", "D16": "30"}
+	for _, cell := range []string{"F1", "F2"} {
+		result, err := f.CalcCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		expected[map[string]string{"F1": "B5", "F2": "D7"}[cell]] = result
+	}
+	checkResults := func(f *File) {
+		for cell, value := range expected {
+			result, err := f.CalcCellValue("Sheet1", cell)
+			assert.NoError(t, err)
+			assert.Equal(t, value, result, cell)
+		}
+		// Test calculate the formula which references the data table
+		result, err := f.CalcCellValue("Sheet1", "B1")
+		assert.NoError(t, err)
+		assert.Equal(t, "0.05", result)
+	}
+	checkResults(f)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetDataTable.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err := OpenFile(filepath.Join("test", "TestSetDataTable.xlsx"))
+	assert.NoError(t, err)
+	checkResults(f)
+	// Test adjust the input cells of the data table
+	assert.NoError(t, f.InsertRows("Sheet1", 1, 1))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	fx := ws.(*xlsxWorksheet).SheetData.Row[5].C[1].F
+	assert.Equal(t, &xlsxF{T: STCellFormulaTypeDataTable, Ref: "B6:D8", Dt2D: true, Dtr: true, R1: "B3", R2: "B2"}, fx)
+	result, err := f.CalcCellValue("Sheet1", "B6")
+	assert.NoError(t, err)
+	assert.Equal(t, expected["B5"], result)
+	assert.NoError(t, f.RemoveRow("Sheet1", 3))
+	assert.True(t, fx.Del1)
+	assert.False(t, fx.Del2)
+	assert.NoError(t, f.RemoveRow("Sheet1", 2))
+	assert.True(t, fx.Del2)
+	result, err = f.CalcCellValue("Sheet1", "B4")
+	assert.EqualError(t, err, formulaErrorREF)
+	assert.Equal(t, formulaErrorREF, result)
+	assert.NoError(t, f.Close())
+
+	// Test set data table with invalid parameters
+	f = NewFile()
+	assert.Equal(t, ErrParameterRequired, f.SetDataTable("Sheet1", "A1:B2", nil))
+	assert.Equal(t, ErrParameterRequired, f.SetDataTable("Sheet1", "A1:B2", &DataTableOptions{}))
+	assert.Equal(t, ErrParameterInvalid, f.SetDataTable("Sheet1", "A1:A3", &DataTableOptions{RowInputCell: "C1"}))
+	assert.Equal(t, ErrParameterInvalid, f.SetDataTable("Sheet1", "A1:C1", &DataTableOptions{RowInputCell: "D1"}))
+	assert.Equal(t, ErrParameterInvalid, f.SetDataTable("Sheet1", "A1:B2", &DataTableOptions{RowInputCell: "B2"}))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetDataTable("Sheet1", "A:B2", &DataTableOptions{RowInputCell: "C1"}))
+	assert.Equal(t, newCellNameToCoordinatesError("C", newInvalidCellNameError("C")), f.SetDataTable("Sheet1", "A1:B2", &DataTableOptions{RowInputCell: "C"}))
+	assert.EqualError(t, f.SetDataTable("SheetN", "A1:B2", &DataTableOptions{RowInputCell: "C1"}), "sheet SheetN does not exist")
+	// Test set data table with unsupported charset calculation chain
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B2", "A1"))
+	f.CalcChain = nil
+	f.Pkg.Store(defaultXMLPathCalcChain, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetDataTable("Sheet1", "A1:B2", &DataTableOptions{RowInputCell: "C1"}), "XML syntax error on line 1: invalid UTF-8")
+	f.Pkg.Delete(defaultXMLPathCalcChain)
+	// Test calculate data table with invalid range
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 1))
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[0].F = &xlsxF{T: STCellFormulaTypeDataTable, Ref: "A1", R1: "C1"}
+	_, err = f.CalcCellValue("Sheet1", "A1")
+	assert.EqualError(t, err, formulaErrorREF)
+	// Test calculate data table with not exist worksheet
+	_, _, err = f.calcDataTable(&calcContext{}, "SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	_, _, err = f.calcDataTable(&calcContext{}, "Sheet1", "A")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	assert.NoError(t, f.Close())
+}
+
+func TestProtectedCellGuard(t *testing.T) {
+	f := NewFile(Options{ProtectedCellGuard: true})
+	// Test set cell values without the worksheet protection
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 1))
+	assert.NoError(t, f.ProtectSheet("Sheet1", &SheetProtectionOptions{}))
+	unlocked, err := f.NewStyle(&Style{Protection: &Protection{Locked: false}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B1", "B2", unlocked))
+	assert.NoError(t, f.SetColStyle("Sheet1", "D", unlocked))
+	assert.NoError(t, f.AddAllowEditRange("Sheet1", "Range1", "E1:F2 G3", ""))
+	assert.NoError(t, f.AddAllowEditRange("Sheet1", "Range2", "H1", "password"))
+	for _, cell := range []string{"B1", "B2", "D10", "F2", "G3"} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, "Excelize"), cell)
+	}
+	for cell, fn := range map[string]func() error{
+		"A1": func() error { return f.SetCellValue("Sheet1", "A1", 2) },
+		"A2": func() error { return f.SetCellValue("Sheet1", "A2", time.Now()) },
+		"A3": func() error { return f.SetCellInt("Sheet1", "A3", 1) },
+		"A4": func() error { return f.SetCellUint("Sheet1", "A4", 1) },
+		"A5": func() error { return f.SetCellBool("Sheet1", "A5", true) },
+		"A6": func() error { return f.SetCellError("Sheet1", "A6", CellErrorNA) },
+		"A7": func() error { return f.SetCellFloat("Sheet1", "A7", 1.5, -1, 64) },
+		"A8": func() error { return f.SetCellDecimal("Sheet1", "A8", "1.5") },
+		"A9": func() error { return f.SetCellStr("Sheet1", "A9", "Excelize") },
+		"B3": func() error { return f.SetCellDefault("Sheet1", "B3", "1") },
+		"C1": func() error { return f.SetCellBlank("Sheet1", "C1") },
+		"C2": func() error { return f.SetCellFormula("Sheet1", "C2", "SUM(A1:B1)") },
+		"C3": func() error { return f.SetCellRichText("Sheet1", "C3", []RichTextRun{{Text: "Excelize"}}) },
+		"H3": func() error { return f.SetSheetRow("Sheet1", "G3", &[]interface{}{1, 2}) },
+		"H1": func() error { return f.SetCellValue("Sheet1", "H1", 1) },
+	} {
+		err := fn()
+		assert.Equal(t, ErrProtectedCell{SheetName: "Sheet1", Cell: cell}, err, cell)
+		if cell == "A1" {
+			assert.EqualError(t, err, "cell A1 in sheet Sheet1 is locked by the worksheet protection")
+		}
+	}
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", val)
+	// Test the worksheet is not changed by the rejected modification
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, ws.SheetData.Row, 10)
+	assert.Len(t, ws.SheetData.Row[0].C, 4)
+	assert.Empty(t, ws.SheetData.Row[8].C)
+	assert.NoError(t, f.SetCellValue("Sheet1", "E1", "Excelize"))
+	// Test clear the locked cells
+	assert.Equal(t, ErrProtectedCell{SheetName: "Sheet1", Cell: "A1"}, f.ClearCell("Sheet1", "A1"))
+	assert.Equal(t, ErrProtectedCell{SheetName: "Sheet1", Cell: "A1"}, f.ClearRange("Sheet1", "A1", "E1"))
+	for _, cell := range []string{"A1", "B1", "E1"} {
+		val, err = f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, val, cell)
+	}
+	assert.NoError(t, f.ClearRange("Sheet1", "B1", "B2"))
+	val, err = f.GetCellValue("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Empty(t, val)
+	assert.NoError(t, f.UnprotectSheet("Sheet1"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 2))
+	assert.NoError(t, f.Close())
+
+	// Test set cell value with invalid allow edit range reference
+	f = NewFile(Options{ProtectedCellGuard: true})
+	assert.NoError(t, f.ProtectSheet("Sheet1", &SheetProtectionOptions{}))
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.ProtectedRanges = &xlsxProtectedRanges{ProtectedRange: []*xlsxProtectedRange{nil, {Sqref: "A"}}}
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetCellValue("Sheet1", "A1", 1))
+	// Test set cell value with invalid merged cell reference
+	ws.MergeCells = &xlsxMergeCells{Cells: []*xlsxMergeCell{{Ref: "A"}}}
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetCellValue("Sheet1", "A1", 1))
+	ws.MergeCells = nil
+	// Test set cell value with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetCellValue("Sheet1", "A", 1))
+	// Test set cell value with unsupported charset styles
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCellValue("Sheet1", "A1", 1), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestNoFormulaConversion(t *testing.T) {
+	f := NewFile(Options{NoFormulaConversion: true})
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A2", "A2", style))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"=1+1", "+1", []byte("-1"), "@SUM(1)", "Excelize", "", 1}))
+	assert.NoError(t, f.SetCellStr("Sheet1", "A2", "=cmd|' /C calc'!A0"))
+	assert.NoError(t, f.SetCellStr("Sheet1", "A3", "=1+1"))
+	s, err := f.stylesReader()
+	assert.NoError(t, err)
+	for cell, quotePrefix := range map[string]bool{"A1": true, "B1": true, "C1": true, "D1": true, "E1": false, "F1": false, "G1": false, "A2": true} {
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		xf := s.CellXfs.Xf[styleID]
+		assert.Equal(t, quotePrefix, xf.QuotePrefix != nil && *xf.QuotePrefix, cell)
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Empty(t, formula)
+		if quotePrefix {
+			assert.NotEmpty(t, val)
+		}
+	}
+	// Test the quote prefix cell formatting keeps the other formatting
+	styleID, err := f.GetCellStyle("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, s.CellXfs.Xf[style].FontID, s.CellXfs.Xf[styleID].FontID)
+	// Test reuse the exist quote prefix cell formatting
+	styleID, err = f.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	quoteStyleID, err := f.GetCellStyle("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.Equal(t, styleID, quoteStyleID)
+	assert.Len(t, s.CellXfs.Xf, 4)
+	assert.NoError(t, f.SetCellStr("Sheet1", "A3", "=1+1"))
+	assert.Len(t, s.CellXfs.Xf, 4)
+	assert.Equal(t, map[int]int{0: styleID, style: 3}, s.quoteIDs)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestNoFormulaConversion.xlsx")))
+
+	// Test set cell value with invalid cell formatting index
+	_, err = f.prepareQuotePrefixStyle(10, "=1")
+	assert.Equal(t, newInvalidStyleID(10), err)
+	// Test set cell value with exceeds the maximum cell formatting
+	s.CellXfs.Xf, s.quoteIDs = make([]xlsxXf, MaxCellStyles), nil
+	assert.Equal(t, ErrCellStyles, f.SetCellStr("Sheet1", "A4", "=1"))
+	assert.NoError(t, f.Close())
+
+	// Test drop the cached quote prefix cell formatting after removing the
+	// trailing cell formatting
+	f = NewFile(Options{NoFormulaConversion: true})
+	styles := make([]int, 3)
+	for i := range styles {
+		styles[i], err = f.NewStyle(&Style{NumFmt: i + 1})
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", styles[0]))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A5", "A5", styles[1]))
+	assert.NoError(t, f.SetCellStr("Sheet1", "A1", "=1"))
+	assert.NoError(t, f.SetCellStr("Sheet1", "A5", "=2"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A5", nil))
+	s, err = f.stylesReader()
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]int{1: 4, 2: 5}, s.quoteIDs)
+	assert.NoError(t, f.TrimSheet("Sheet1"))
+	assert.Equal(t, map[int]int{1: 4}, s.quoteIDs)
+	assert.Len(t, s.CellXfs.Xf, 5)
+	assert.NoError(t, f.Close())
+
+	// Test set cell value with unsupported charset styles
+	f = NewFile(Options{NoFormulaConversion: true})
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCellStr("Sheet1", "A1", "=1"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestValueSource(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{1, 2}))
+	for cell, formula := range map[string]string{"C1": "A1+B1", "C2": "A1*10"} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", cell, formula))
+	}
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	// Set a stale cached value of the formula cell
+	cell := &ws.(*xlsxWorksheet).SheetData.Row[0].C[2]
+	cell.T, cell.V = "", "5"
+	for _, c := range []struct {
+		source  ValueSource
+		rows    [][]string
+		cols    [][]string
+		values  []string
+		formula []string
+	}{
+		{
+			source: ValueSourceCached,
+			rows:   [][]string{{"1", "2", "5"}, {"", "", ""}},
+			cols:   [][]string{{"1", ""}, {"2", ""}, {"5", ""}},
+			values: []string{"5", ""},
+		},
+		{
+			source: ValueSourceCalculated,
+			rows:   [][]string{{"1", "2", "3"}, {"", "", "10"}},
+			cols:   [][]string{{"1", ""}, {"2", ""}, {"3", "10"}},
+			values: []string{"3", "10"},
+		},
+		{
+			source: ValueSourceCachedWithFallback,
+			rows:   [][]string{{"1", "2", "5"}, {"", "", "10"}},
+			cols:   [][]string{{"1", ""}, {"2", ""}, {"5", "10"}},
+			values: []string{"5", "10"},
+		},
+	} {
+		opts := Options{ValueSource: c.source}
+		for i, cell := range []string{"C1", "C2"} {
+			val, err := f.GetCellValue("Sheet1", cell, opts)
+			assert.NoError(t, err)
+			assert.Equal(t, c.values[i], val)
+		}
+		values, err := f.GetCellValues("Sheet1", []string{"C2", "C1", "C1"}, opts)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{c.values[1], c.values[0], c.values[0]}, values)
+		rows, err := f.GetRows("Sheet1", opts)
+		assert.NoError(t, err)
+		assert.Equal(t, c.rows, rows)
+		cols, err := f.GetCols("Sheet1", opts)
+		assert.NoError(t, err)
+		assert.Equal(t, c.cols, cols)
+		cells, err := f.Cells("Sheet1", opts)
+		assert.NoError(t, err)
+		var cellValues []string
+		for cells.Next() {
+			if col, _ := cells.Coordinates(); col == 3 {
+				cellValues = append(cellValues, cells.Value())
+			}
+		}
+		assert.NoError(t, cells.Error())
+		assert.NoError(t, cells.Close())
+		assert.Equal(t, c.values, cellValues)
+	}
+	// Test get the recalculated value with the options of the workbook
+	f.options.ValueSource = ValueSourceCalculated
+	val, err := f.GetCellValue("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, "3", val)
+	f.options.ValueSource = ValueSourceCached
+	// Test get the recalculated value with unsupported function
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C2", "UNSUPPORT(A1)"))
+	opts := Options{ValueSource: ValueSourceCalculated}
+	_, err = f.GetCellValue("Sheet1", "C2", opts)
+	assert.EqualError(t, err, "not support UNSUPPORT function")
+	_, err = f.GetCellValues("Sheet1", []string{"C2"}, opts)
+	assert.EqualError(t, err, "not support UNSUPPORT function")
+	rows, err := f.Rows("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, rows.Next())
+	assert.True(t, rows.Next())
+	_, err = rows.Columns(opts)
+	assert.EqualError(t, err, "not support UNSUPPORT function")
+	assert.NoError(t, rows.Close())
+	cols, err := f.Cols("Sheet1")
+	assert.NoError(t, err)
+	for cols.Next() {
+		_, err = cols.Rows(opts)
+	}
+	assert.EqualError(t, err, "not support UNSUPPORT function")
+	cells, err := f.Cells("Sheet1", opts)
+	assert.NoError(t, err)
+	for cells.Next() {
+	}
+	assert.EqualError(t, cells.Error(), "not support UNSUPPORT function")
+	assert.NoError(t, cells.Close())
+	// Test get the recalculated value with the formula error
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C2", "1/0"))
+	_, err = f.GetCellValue("Sheet1", "C2", opts)
+	assert.EqualError(t, err, "#DIV/0!")
+	rows, err = f.Rows("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, rows.Next())
+	assert.True(t, rows.Next())
+	_, err = rows.Columns(opts)
+	assert.EqualError(t, err, "#DIV/0!")
+	assert.NoError(t, rows.Close())
+	cells, err = f.Cells("Sheet1", opts)
+	assert.NoError(t, err)
+	for cells.Next() {
+	}
+	assert.EqualError(t, cells.Error(), "#DIV/0!")
+	assert.NoError(t, cells.Close())
+	// Test get the cached value with the formula error
+	val, err = f.GetCellValue("Sheet1", "C2")
+	assert.NoError(t, err)
+	assert.Empty(t, val)
+	assert.NoError(t, f.Close())
+}
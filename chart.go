@@ -12,8 +12,13 @@
 package excelize
 
 import (
+	"bytes"
 	"encoding/xml"
 	"fmt"
+	"io"
+	"path"
+	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -527,6 +532,18 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 	if opts.ShowBlanksAs == "" {
 		opts.ShowBlanksAs = defaultChartShowBlanksAs
 	}
+	fills := []Fill{opts.Fill, opts.PlotArea.Fill}
+	for _, ser := range opts.Series {
+		fills = append(fills, ser.Fill, ser.Marker.Fill)
+		for _, dataPoint := range ser.DataPoint {
+			fills = append(fills, dataPoint.Fill)
+		}
+	}
+	for _, fill := range fills {
+		if fill.Transparency < 0 || fill.Transparency > 100 {
+			return opts, ErrTransparency
+		}
+	}
 	return opts, nil
 }
 
@@ -690,6 +707,8 @@ func (opts *Chart) parseTitle() {
 //	Categories
 //	Values
 //	Fill
+//	Explosion
+//	DataPoint
 //	Line
 //	Marker
 //	DataLabelPosition
@@ -710,13 +729,43 @@ func (opts *Chart) parseTitle() {
 // Sizes: This sets the bubble size in a data series. The 'Sizes' property is
 // optional and the default value was same with 'Values'.
 //
-// Fill: This set the format for the data series fill. The 'Fill' property is
-// optional
+// Fill: This set the format for the data series fill of the bar, area and pie
+// slices. The 'Fill' property is optional. Set the 'Type' as 'pattern' and
+// 'Pattern' as 1 for a solid fill by the first color of 'Color', set the
+// 'Pattern' between 2 and 18 for a pattern fill with the foreground and
+// background color in 'Color', the pattern styles are the same as the cell
+// fill. Set the 'Type' as 'gradient' for a gradient fill, the gradient stops
+// are evenly distributed by two or more colors in 'Color', and the 'Shading'
+// specifies the gradient styles same as the cell fill. Set the 'Type' as
+// 'none' for no fill. The optional 'Transparency' specifies the transparency percentage of the fill colors, the
+// value should be between 0 and 100. For example, set a semi-transparent red
+// solid fill:
+//
+//	Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"FF0000"}, Transparency: 50}
+//
+// Explosion: This set the distance of the slices from the center of the pie
+// chart in percentage of the radius for pie, 3D pie, doughnut, pie of pie and
+// bar of pie charts. The 'Explosion' property is optional, and the value should
+// be between 0 and 400.
+//
+// DataPoint: This set the format for the individual data points by the
+// zero-based point index in the series, which used to vary colors by point.
+// The 'Fill' of the data point has the same options as the series fill, and
+// the 'Explosion' of the data point set the explosion of the single pie slice. For
+// example, set the fill color of the second and third bars:
+//
+//	DataPoint: []excelize.ChartDataPoint{
+//	    {Index: 1, Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"00B050"}}},
+//	    {Index: 2, Fill: excelize.Fill{Type: "gradient", Color: []string{"FFFFFF", "0070C0"}, Shading: 3}},
+//	}
 //
 // Line: This sets the line format of the line chart. The 'Line' property is
 // optional and if it isn't supplied it will default style. The options that
 // can be set are width and color. The range of width is 0.25pt - 999pt. If the
 // value of width is outside the range, the default width of the line is 2pt.
+// Set the 'Smooth' of the 'Line' to smooth the line of the series for line and
+// scatter charts, and set the 'DashType' of the 'Line' to specify the preset
+// dash type of the line.
 //
 // Marker: This sets the marker of the line chart and scatter chart. The range
 // of optional field 'Size' is 2-72 (default value is 5). The enumeration value
@@ -741,6 +790,10 @@ func (opts *Chart) parseTitle() {
 //
 //	Position
 //	ShowLegendKey
+//	Font
+//	Fill
+//	Border
+//	DeletedEntries
 //
 // Position: Set the position of the chart legend. The default legend position
 // is bottom. The available positions are:
@@ -755,6 +808,19 @@ func (opts *Chart) parseTitle() {
 // ShowLegendKey: Set the legend keys shall be shown in data labels. The default
 // value is false.
 //
+// Font: Set the font of the legend text. The 'Font' property is optional.
+//
+// Fill: Set the fill of the legend with the same options as the series fill.
+// The 'Fill' property is optional.
+//
+// Border: Set the border line of the legend with the same options as the
+// chart area border. The 'Border' property is optional.
+//
+// DeletedEntries: Set the zero-based index of the legend entries to be
+// deleted from the legend, the index is the series index for most charts, and
+// the data point index for pie and doughnut charts. The 'DeletedEntries'
+// property is optional.
+//
 // Set properties of the chart title. The properties that can be set are:
 //
 //	Title
@@ -762,7 +828,13 @@ func (opts *Chart) parseTitle() {
 // Title: Set the name (title) for the chart. The name is displayed above the
 // chart. The name can also be a formula such as Sheet1!$A$1 or a list with a
 // sheet name. The name property is optional. The default is to have no chart
-// title.
+// title. Each rich text run of the title will be displayed in a new line with
+// its own font, and the line breaks in the text will also start new lines. Set
+// a single rich text run with the text begins with an equal sign to reference
+// the title from a worksheet cell, so the title updates with the data, for
+// example:
+//
+//	Title: []excelize.RichTextRun{{Text: "=Sheet1!$A$1", Font: &excelize.Font{Bold: true}}}
 //
 // Specifies how blank cells are plotted on the chart by 'ShowBlanksAs'. The
 // default value is gap. The options that can be set are:
@@ -793,6 +865,8 @@ func (opts *Chart) parseTitle() {
 //	ShowPercent
 //	ShowSerName
 //	ShowVal
+//	Fill
+//	Border
 //	NumFmt
 //
 // SecondPlotValues: Specifies the values in second plot for the 'pieOfPie' and
@@ -816,17 +890,104 @@ func (opts *Chart) parseTitle() {
 // ShowVal: Specifies that the value shall be shown in a data label.
 // The 'ShowVal' property is optional. The default value is false.
 //
+// Fill: Specifies the fill of the plot area with the same options as the
+// series fill, set the 'Type' as 'none' for no fill. The 'Fill' property is
+// optional.
+//
+// Border: Specifies the border line of the plot area. The 'Border' property is
+// optional.
+//
 // NumFmt: Specifies that if linked to source and set custom number format code
 // for data labels. The 'NumFmt' property is optional. The default format code
 // is 'General'.
 //
+// Set the fill and border of the chart area by 'Fill' and 'Border', and set
+// the 'RoundedCorners' as true to round the corners of the chart area. The
+// 'Fill' has the same options as the series fill, for example, create a chart
+// with a transparent background:
+//
+//	Fill: excelize.Fill{Type: "none"}
+//
+// The 'Type' of the chart area, plot area and legend border can be
+// 'ChartLineSolid' or 'ChartLineNone', the 'Width' specifies the border width
+// in points, the optional 'Color' specifies the border color in hex, and the
+// optional 'DashType' specifies the preset dash type of the border. The plot
+// area and legend border only been drawn when the 'Width' has been set or the
+// 'Type' is 'ChartLineNone'. The default width of the chart area border is
+// 0.75. The enumeration value of the dash types are:
+//
+//	solid
+//	dot
+//	dash
+//	lgDash
+//	dashDot
+//	lgDashDot
+//	lgDashDotDot
+//	sysDash
+//	sysDot
+//	sysDashDot
+//	sysDashDotDot
+//
+// Set the 'DataTable' to show a data table beneath the chart, it only works
+// for the column, bar, line and area charts with category axis. The properties
+// of 'DataTable' that can be set are:
+//
+//	ShowHorizontalBorder
+//	ShowVerticalBorder
+//	ShowOutline
+//	ShowLegendKeys
+//	Font
+//
+// For example, show a data table with borders and legend keys:
+//
+//	DataTable: &excelize.ChartDataTable{
+//	    ShowHorizontalBorder: true,
+//	    ShowVerticalBorder:   true,
+//	    ShowOutline:          true,
+//	    ShowLegendKeys:       true,
+//	}
+//
+// Set the view of the 3D chart by 'View3D', the default view of the chart
+// type will be used if the property is not specified or out of range. The
+// properties of 'View3D' that can be set are:
+//
+//	RotX
+//	RotY
+//	Perspective
+//	RightAngleAxes
+//	DepthPercent
+//
+// RotX: Specifies the X rotation of the 3D view in degrees, the value should be
+// between -90 and 90.
+//
+// RotY: Specifies the Y rotation of the 3D view in degrees, the value should be
+// between 0 and 360.
+//
+// Perspective: Specifies the field of view angle for the 3D view in half
+// degrees, the value should be between 0 and 240.
+//
+// RightAngleAxes: Specifies whether the chart axes are at right angles rather
+// than drawn in perspective.
+//
+// DepthPercent: Specifies the depth of the 3D chart as a percentage of the
+// chart width, the value should be between 20 and 2000.
+//
 // Set the primary horizontal and vertical axis options by 'XAxis' and 'YAxis'.
 // The properties of 'XAxis' that can be set are:
 //
 //	None
 //	MajorGridLines
 //	MinorGridLines
+//	MajorUnit
+//	MinorUnit
+//	DateAxis
+//	BaseTimeUnit
+//	MajorTimeUnit
+//	MinorTimeUnit
+//	MajorTickMark
+//	MinorTickMark
 //	TickLabelSkip
+//	TickLabelRotation
 //	ReverseOrder
 //	Maximum
 //	Minimum
@@ -840,6 +1001,12 @@ func (opts *Chart) parseTitle() {
 //	MajorGridLines
 //	MinorGridLines
 //	MajorUnit
+//	MinorUnit
+//	MajorTickMark
+//	MinorTickMark
+//	TickLabelRotation
+//	DisplayUnits
+//	DisplayUnitsVisible
 //	Secondary
 //	ReverseOrder
 //	Maximum
@@ -859,13 +1026,69 @@ func (opts *Chart) parseTitle() {
 // positive floating-point number. The 'MajorUnit' property is optional. The
 // default value is auto.
 //
+// MinorUnit: Specifies the distance between minor ticks. Shall contain a
+// positive floating-point number. The 'MinorUnit' property is optional. The
+// default value is auto.
+//
+// DateAxis: Specifies the horizontal axis as a date axis, the categories will
+// be arranged in chronological order and spaced by the dates instead of
+// treating the dates as text categories. This only works for the chart types
+// which have category axis, such as area, bar, column and line charts. The
+// number format of the date axis is linked to the source data if the 'NumFmt'
+// isn't specified. The 'DateAxis' property is optional. The default value is
+// false.
+//
+// BaseTimeUnit: Specifies the base unit of the date axis, the 'MajorUnit' and
+// 'MinorUnit' of the date axis are measured in the 'MajorTimeUnit' and
+// 'MinorTimeUnit'. These properties are optional, the default value is auto.
+// The enumeration value of the time units are:
+//
+//	days
+//	months
+//	years
+//
 // Secondary: Specifies the current series vertical axis as the secondary axis,
 // this only works for the second and later chart in the combo chart. The
 // default value is false.
 //
+// MajorTickMark: Specifies the major tick mark type of the axis. The
+// 'MajorTickMark' property is optional. The default value is 'none'. The
+// enumeration value of the tick mark type are:
+//
+//	none
+//	in
+//	out
+//	cross
+//
+// MinorTickMark: Specifies the minor tick mark type of the axis, with the same
+// enumeration value as the 'MajorTickMark'. The 'MinorTickMark' property is
+// optional. The default value is 'none'.
+//
 // TickLabelSkip: Specifies how many tick labels to skip between label that is
 // drawn. The 'TickLabelSkip' property is optional. The default value is auto.
 //
+// TickLabelRotation: Specifies the rotation angle of the tick labels in
+// degrees, the value should be between -90 and 90, and the positive angle
+// rotates the labels counterclockwise. The 'TickLabelRotation' property is
+// optional. The default value 0 is auto.
+//
+// DisplayUnits: Specifies the display units of the vertical axis, the values
+// on the axis will be divided by the units. The 'DisplayUnits' property is
+// optional. The enumeration value of the display units are:
+//
+//	hundreds
+//	thousands
+//	tenThousands
+//	hundredThousands
+//	millions
+//	tenMillions
+//	hundredMillions
+//	billions
+//	trillions
+//
+// DisplayUnitsVisible: Specifies whether to show the display units label on
+// the chart. The default value is false.
+//
 // ReverseOrder: Specifies that the categories or values on reverse order
 // (orientation of the chart). The 'ReverseOrder' property is optional. The
 // default value is false.
@@ -895,7 +1118,10 @@ func (opts *Chart) parseTitle() {
 // 'General'.
 //
 // Title: Specifies that the primary horizontal or vertical axis title and
-// resize chart. The 'Title' property is optional.
+// resize chart, the text and font of the title can be set by each rich text
+// run. The same as the chart title, each rich text run and each line break in
+// the text of the axis title will start a new line. The 'Title' property is
+// optional.
 //
 // Set chart size by 'Dimension' property. The 'Dimension' property is optional.
 // The default width is 480, and height is 260.
@@ -909,6 +1135,74 @@ func (opts *Chart) parseTitle() {
 // 'HoleSize' property. The 'HoleSize' property is optional. The default width
 // is 75, and the value should be great than 0 and less or equal than 90.
 //
+// Set the space between the bar or column clusters by 'GapWidth' property in
+// percentage of the bar width for bar and column charts. The 'GapWidth'
+// property is optional. The default value is 150, and the value should be
+// between 0 and 500.
+//
+// Set how much the bars or columns in the cluster overlap by 'Overlap'
+// property in percentage for the 2D bar and column charts, the negative value
+// specifies the gap between the bars. The 'Overlap' property is optional. The
+// value should be between -100 and 100.
+//
+// Set the angle of the first pie or doughnut slice in degrees clockwise from
+// the top by 'FirstSliceAngle' property. The 'FirstSliceAngle' property is
+// optional, and the value should be between 0 and 360.
+//
+// Set the drop lines and high-low lines for the line chart by 'DropLines' and
+// 'HighLowLines' property, the lines have the same options as the 'Border' of
+// the plot area. For example, show the high-low lines with dashed line:
+//
+//	HighLowLines: &excelize.ChartLine{Width: 0.75, DashType: "dash"},
+//
+// Set the up and down bars for the line chart by 'UpDownBars' property. The
+// properties of 'UpDownBars' that can be set are:
+//
+//	GapWidth
+//	UpBarsFill
+//	DownBarsFill
+//	Border
+//
+// GapWidth: Specifies the space between the up and down bars in percentage of
+// the bar width. The 'GapWidth' property is optional. The default value is 150,
+// and the value should be between 0 and 500.
+//
+// UpBarsFill: Specifies the fill of the up bars with the same options as the
+// series fill. The 'UpBarsFill' property is optional.
+//
+// DownBarsFill: Specifies the fill of the down bars with the same options as
+// the series fill. The 'DownBarsFill' property is optional.
+//
+// Border: Specifies the border line of the up and down bars. The 'Border'
+// property is optional.
+//
+// Format: Specifies the graph options of the chart, the optional "AltText",
+// "AltTextTitle" and "Decorative" are used to set the alternative text
+// description, the alternative text title and whether the chart is marked as
+// decorative for accessibility tools.
+//
+// The 'cell' can be a range reference, such as "E4:M20", to make the chart
+// exactly cover the cells of the range and be moved and sized with the cells,
+// the 'Dimension', scale and offset settings will be ignored in this case. For example:
+//
+//	err := f.AddChart("Sheet1", "E4:M20", &excelize.Chart{
+//	    Type:   excelize.Col,
+//	    Series: []excelize.ChartSeries{{Values: "Sheet1!$B$2:$D$2"}},
+//	})
+//
+// The optional parameter "Positioning" of the 'Format' defines 3 types of the
+// position of the chart in a spreadsheet: "oneCell" (Move but don't size with
+// cells), "twoCell" (Move and size with cells), and "absolute" (Don't move or
+// size with cells). If you don't set this parameter, the default positioning
+// is to move and size with cells.
+//
+// The optional parameters "Unit", "Absolute", "PositionX", "PositionY",
+// "Width" and "Height" of the 'Format' specify the absolute position and the
+// physical size of the chart in the same way as the AddPicture function, the
+// 'Dimension' will be used to keep the aspect ratio if only one of the "Width"
+// and "Height" is specified. These settings are not supported when the 'cell'
+// is a range reference.
+//
 // combo: Specifies the create a chart that combines two or more chart types in
 // a single chart. For example, create a clustered column - line chart with
 // data Sheet1!$E$1:$L$15:
@@ -1036,7 +1330,7 @@ func (f *File) AddChart(sheet, cell string, chart *Chart, combo ...*Chart) error
 	if err != nil {
 		return err
 	}
-	f.addChart(opts, comboCharts)
+	f.addChart("xl/charts/chart"+strconv.Itoa(chartID)+".xml", opts, comboCharts)
 	if err = f.addContentTypePart(chartID, "chart"); err != nil {
 		return err
 	}
@@ -1079,28 +1373,481 @@ func (f *File) AddChartSheet(sheet string, chart *Chart, combo ...*Chart) error
 	path := "xl/chartsheets/sheet" + strconv.Itoa(sheetID) + ".xml"
 	f.sheetMap[sheet] = path
 	f.Sheet.Store(path, nil)
+	if err = f.addChartSheetDrawing(sheet, &cs, opts, comboCharts); err != nil {
+		return err
+	}
+	_ = f.addContentTypePart(sheetID, "chartsheet")
+	// Update workbook.xml.rels
+	rID := f.addRels(f.getWorkbookRelsPath(), SourceRelationshipChartsheet, fmt.Sprintf("/xl/chartsheets/sheet%d.xml", sheetID), "")
+	// Update workbook.xml
+	f.setWorkbook(sheet, sheetID, rID)
+	f.addSheetNameSpace(sheet, NameSpaceSpreadSheet)
+	f.chartSheetWriter(path, &cs)
+	return err
+}
+
+// addChartSheetDrawing provides a function to create the drawing part and the
+// chart part for the chart sheet by given chart sheet name and format sets.
+func (f *File) addChartSheetDrawing(sheet string, cs *xlsxChartsheet, opts *Chart, comboCharts []*Chart) error {
 	drawingID := f.countDrawings() + 1
 	chartID := f.countCharts() + 1
 	drawingXML := "xl/drawings/drawing" + strconv.Itoa(drawingID) + ".xml"
-	f.prepareChartSheetDrawing(&cs, drawingID, sheet)
+	f.prepareChartSheetDrawing(cs, drawingID, sheet)
 	drawingRels := "xl/drawings/_rels/drawing" + strconv.Itoa(drawingID) + ".xml.rels"
 	drawingRID := f.addRels(drawingRels, SourceRelationshipChart, "../charts/chart"+strconv.Itoa(chartID)+".xml", "")
-	if err = f.addSheetDrawingChart(drawingXML, drawingRID, &opts.Format); err != nil {
+	if err := f.addSheetDrawingChart(drawingXML, drawingRID, &opts.Format); err != nil {
 		return err
 	}
-	f.addChart(opts, comboCharts)
-	if err = f.addContentTypePart(chartID, "chart"); err != nil {
+	f.addChart("xl/charts/chart"+strconv.Itoa(chartID)+".xml", opts, comboCharts)
+	if err := f.addContentTypePart(chartID, "chart"); err != nil {
 		return err
 	}
-	_ = f.addContentTypePart(sheetID, "chartsheet")
-	_ = f.addContentTypePart(drawingID, "drawings")
-	// Update workbook.xml.rels
-	rID := f.addRels(f.getWorkbookRelsPath(), SourceRelationshipChartsheet, fmt.Sprintf("/xl/chartsheets/sheet%d.xml", sheetID), "")
-	// Update workbook.xml
-	f.setWorkbook(sheet, sheetID, rID)
+	return f.addContentTypePart(drawingID, "drawings")
+}
+
+// chartSheetReader provides a function to get the pointer to the structure
+// after deserialization and the part path by given chart sheet name.
+func (f *File) chartSheetReader(sheet string) (*xlsxChartsheet, string, error) {
+	if err := checkSheetName(sheet); err != nil {
+		return nil, "", err
+	}
+	name, ok := f.getSheetXMLPath(sheet)
+	if !ok {
+		return nil, name, ErrSheetNotExist{sheet}
+	}
+	if !strings.HasPrefix(name, "xl/chartsheets") {
+		return nil, name, newNotChartSheetError(sheet)
+	}
+	if _, ok = f.xmlAttr.Load(name); !ok {
+		d := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readBytes(name))))
+		f.xmlAttr.Store(name, getRootElement(d))
+	}
+	cs := new(xlsxChartsheet)
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readBytes(name)))).
+		Decode(cs); err != nil && err != io.EOF {
+		return cs, name, err
+	}
+	return cs, name, nil
+}
+
+// chartSheetWriter provides a function to save the chart sheet part after
+// serialize structure by given part path.
+func (f *File) chartSheetWriter(path string, cs *xlsxChartsheet) {
 	chartsheet, _ := xml.Marshal(cs)
-	f.addSheetNameSpace(sheet, NameSpaceSpreadSheet)
 	f.saveFileList(path, replaceRelationshipsBytes(f.replaceNameSpaceBytes(path, chartsheet)))
+}
+
+// getChartSheetChartPath provides a function to get the chart part path of
+// the chart sheet by given chart sheet name, returns an empty string if the
+// chart sheet doesn't contain a chart.
+func (f *File) getChartSheetChartPath(sheet string, cs *xlsxChartsheet) string {
+	if cs.Drawing == nil {
+		return ""
+	}
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	sheetRels := "xl/chartsheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/chartsheets/") + ".rels"
+	drawingRel := f.getDrawingRelationships(sheetRels, cs.Drawing.RID)
+	if drawingRel == nil {
+		return ""
+	}
+	drawingXML := strings.TrimPrefix(strings.ReplaceAll(drawingRel.Target, "..", "xl"), "/")
+	drawingRels, _ := f.relsReader("xl/drawings/_rels/" + path.Base(drawingXML) + ".rels")
+	if drawingRels == nil {
+		return ""
+	}
+	drawingRels.mu.Lock()
+	defer drawingRels.mu.Unlock()
+	for _, rel := range drawingRels.Relationships {
+		if rel.Type == SourceRelationshipChart {
+			return strings.TrimPrefix(strings.ReplaceAll(rel.Target, "..", "xl"), "/")
+		}
+	}
+	return ""
+}
+
+// GetChartSheets provides the method to get all chart sheets in the workbook,
+// including the chart format sets, the view and page layout settings of each
+// chart sheet. The chart type is recognized by the chart groups in the plot
+// area, the chart group which contains the first series will be the primary
+// chart and the others will be the combo charts, and chart groups with
+// unsupported chart types will be ignored. For example, print the chart type
+// and series of each chart sheet:
+//
+//	chartSheets, err := f.GetChartSheets()
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, chartSheet := range chartSheets {
+//	    if chartSheet.Chart == nil {
+//	        continue
+//	    }
+//	    fmt.Println(chartSheet.Name, chartSheet.Chart.Type, chartSheet.Chart.Series)
+//	}
+func (f *File) GetChartSheets() ([]ChartSheet, error) {
+	var chartSheets []ChartSheet
+	for _, sheet := range f.GetSheetList() {
+		if name, _ := f.getSheetXMLPath(sheet); !strings.HasPrefix(name, "xl/chartsheets") {
+			continue
+		}
+		cs, _, err := f.chartSheetReader(sheet)
+		if err != nil {
+			return chartSheets, err
+		}
+		chartSheet := ChartSheet{Name: sheet, Options: cs.getOptions()}
+		if chartXML := f.getChartSheetChartPath(sheet, cs); chartXML != "" {
+			if chartSheet.Chart, chartSheet.Combo, err = f.readCharts(chartXML); err != nil {
+				return chartSheets, err
+			}
+		}
+		chartSheets = append(chartSheets, chartSheet)
+	}
+	return chartSheets, nil
+}
+
+// getOptions provides a function to get the view and page layout settings of
+// the chart sheet.
+func (cs *xlsxChartsheet) getOptions() ChartSheetOptions {
+	pageLayout := getPageLayout(cs.PageSetup)
+	opts := ChartSheetOptions{ZoomScale: float64Ptr(100), ZoomToFit: boolPtr(false), PageLayout: &pageLayout}
+	if cs.SheetViews != nil && len(cs.SheetViews.SheetView) > 0 {
+		view := cs.SheetViews.SheetView[0]
+		if view.ZoomScaleAttr >= 10 && view.ZoomScaleAttr <= 400 {
+			opts.ZoomScale = float64Ptr(float64(view.ZoomScaleAttr))
+		}
+		opts.ZoomToFit = boolPtr(view.ZoomToFitAttr)
+	}
+	return opts
+}
+
+// readCharts provides a function to get the primary chart and combo charts
+// format sets by given chart part path.
+func (f *File) readCharts(chartXML string) (*Chart, []*Chart, error) {
+	content := namespaceStrictToTransitional(f.readBytes(chartXML))
+	chartSpace, chartAxes := new(decodeChartSpace), new(decodeChartAxes)
+	if err := f.xmlNewDecoder(bytes.NewReader(content)).Decode(chartSpace); err != nil && err != io.EOF {
+		return nil, nil, err
+	}
+	_ = f.xmlNewDecoder(bytes.NewReader(content)).Decode(chartAxes)
+	chart, combo := f.getCharts(chartSpace, chartAxes)
+	return chart, combo, nil
+}
+
+// getCharts provides a function to get the primary chart and combo charts
+// format sets by given deserialized chart part and axis titles.
+func (f *File) getCharts(chartSpace *decodeChartSpace, chartAxes *decodeChartAxes) (*Chart, []*Chart) {
+	if chartSpace.Chart.PlotArea == nil {
+		return nil, nil
+	}
+	var (
+		charts []*Chart
+		orders = map[*Chart]int{}
+		valAx  = map[*Chart]int{}
+		axes   = getChartAxes(chartSpace.Chart.PlotArea, chartAxes)
+	)
+	plotArea := reflect.ValueOf(chartSpace.Chart.PlotArea).Elem()
+	for i := 0; i < plotArea.NumField(); i++ {
+		group, ok := plotArea.Field(i).Interface().(*cCharts)
+		if !ok || group == nil {
+			continue
+		}
+		chartType, ok := f.getChartType(plotArea.Type().Field(i).Name, group)
+		if !ok {
+			continue
+		}
+		chart := &Chart{Type: chartType}
+		chart.Series, orders[chart] = getChartSeries(group)
+		if len(group.AxID) > 1 && group.AxID[0].Val != nil && group.AxID[1].Val != nil {
+			chart.XAxis, chart.YAxis = axes[*group.AxID[0].Val], axes[*group.AxID[1].Val]
+			valAx[chart] = *group.AxID[1].Val
+		}
+		charts = append(charts, chart)
+	}
+	if len(charts) == 0 {
+		return nil, nil
+	}
+	sort.SliceStable(charts, func(i, j int) bool { return orders[charts[i]] < orders[charts[j]] })
+	chart := charts[0]
+	for _, combo := range charts[1:] {
+		if id, ok := valAx[combo]; ok && id != valAx[chart] {
+			combo.YAxis.Secondary = true
+		}
+	}
+	chart.Title = getChartTitle(chartSpace.Chart.Title)
+	chart.Legend.Position = "none"
+	if legend := chartSpace.Chart.Legend; legend != nil {
+		chart.Legend.Position = defaultChartLegendPosition
+		for position, val := range chartLegendPosition {
+			if legend.LegendPos != nil && legend.LegendPos.Val != nil && *legend.LegendPos.Val == val {
+				chart.Legend.Position = position
+			}
+		}
+	}
+	if dispBlanksAs := chartSpace.Chart.DispBlanksAs; dispBlanksAs != nil && dispBlanksAs.Val != nil {
+		chart.ShowBlanksAs = *dispBlanksAs.Val
+	}
+	return chart, charts[1:]
+}
+
+// getChartTitle provides a function to get the rich text runs of the chart
+// title or axis title by given deserialized title, the title which references
+// to a cell will be returned as a formula text run.
+func getChartTitle(title *decodeChartTitle) []RichTextRun {
+	if title == nil {
+		return nil
+	}
+	var runs []RichTextRun
+	if title.F != "" {
+		runs = append(runs, RichTextRun{Text: "=" + title.F})
+	}
+	for _, p := range title.P {
+		for _, r := range p.R {
+			runs = append(runs, RichTextRun{Text: r.T})
+		}
+	}
+	return runs
+}
+
+// getChartAxes provides a function to get the format sets of the chart axes
+// in the plot area by given plot area and deserialized axis titles, the result
+// is indexed by the axis ID.
+func getChartAxes(plotArea *cPlotArea, chartAxes *decodeChartAxes) map[int]ChartAxis {
+	axes := map[int]ChartAxis{}
+	for _, ax := range append(append(plotArea.CatAx, plotArea.ValAx...), plotArea.SerAx...) {
+		if ax.AxID != nil && ax.AxID.Val != nil {
+			axes[*ax.AxID.Val] = getChartAxis(ax)
+		}
+	}
+	for _, ax := range plotArea.DateAx {
+		if ax.AxID == nil || ax.AxID.Val == nil {
+			continue
+		}
+		axis := getChartAxis(&cAxs{
+			Scaling: ax.Scaling, Delete: ax.Delete, MajorGridlines: ax.MajorGridlines,
+			MinorGridlines: ax.MinorGridlines, NumFmt: ax.NumFmt, MajorTickMark: ax.MajorTickMark,
+			MinorTickMark: ax.MinorTickMark, MajorUnit: ax.MajorUnit, MinorUnit: ax.MinorUnit,
+		})
+		axis.DateAxis = true
+		for _, unit := range []struct {
+			val *attrValString
+			dst *string
+		}{
+			{ax.BaseTimeUnit, &axis.BaseTimeUnit},
+			{ax.MajorTimeUnit, &axis.MajorTimeUnit},
+			{ax.MinorTimeUnit, &axis.MinorTimeUnit},
+		} {
+			if unit.val != nil && unit.val.Val != nil {
+				*unit.dst = *unit.val.Val
+			}
+		}
+		axes[*ax.AxID.Val] = axis
+	}
+	for _, titles := range [][]decodeChartAxis{chartAxes.CatAx, chartAxes.DateAx, chartAxes.ValAx, chartAxes.SerAx} {
+		for _, title := range titles {
+			if title.AxID.Val == nil {
+				continue
+			}
+			if axis, ok := axes[*title.AxID.Val]; ok {
+				axis.Title = getChartTitle(title.Title)
+				axes[*title.AxID.Val] = axis
+			}
+		}
+	}
+	return axes
+}
+
+// getChartAxis provides a function to get the format sets of the chart axis
+// by given axis element.
+func getChartAxis(ax *cAxs) ChartAxis {
+	var axis ChartAxis
+	if ax.Delete != nil && ax.Delete.Val != nil {
+		axis.None = *ax.Delete.Val
+	}
+	axis.MajorGridLines, axis.MinorGridLines = ax.MajorGridlines != nil, ax.MinorGridlines != nil
+	if ax.MajorUnit != nil && ax.MajorUnit.Val != nil {
+		axis.MajorUnit = *ax.MajorUnit.Val
+	}
+	if ax.MinorUnit != nil && ax.MinorUnit.Val != nil {
+		axis.MinorUnit = *ax.MinorUnit.Val
+	}
+	if ax.MajorTickMark != nil && ax.MajorTickMark.Val != nil && *ax.MajorTickMark.Val != "none" {
+		axis.MajorTickMark = *ax.MajorTickMark.Val
+	}
+	if ax.MinorTickMark != nil && ax.MinorTickMark.Val != nil && *ax.MinorTickMark.Val != "none" {
+		axis.MinorTickMark = *ax.MinorTickMark.Val
+	}
+	if ax.TickLblSkip != nil && ax.TickLblSkip.Val != nil {
+		axis.TickLabelSkip = *ax.TickLblSkip.Val
+	}
+	if ax.DispUnits != nil && ax.DispUnits.BuiltInUnit != nil && ax.DispUnits.BuiltInUnit.Val != nil {
+		axis.DisplayUnits = *ax.DispUnits.BuiltInUnit.Val
+		axis.DisplayUnitsVisible = ax.DispUnits.DispUnitsLbl != nil
+	}
+	if scaling := ax.Scaling; scaling != nil {
+		if scaling.Orientation != nil && scaling.Orientation.Val != nil {
+			axis.ReverseOrder = *scaling.Orientation.Val == orientation[true]
+		}
+		if scaling.Max != nil && scaling.Max.Val != nil {
+			axis.Maximum = float64Ptr(*scaling.Max.Val)
+		}
+		if scaling.Min != nil && scaling.Min.Val != nil {
+			axis.Minimum = float64Ptr(*scaling.Min.Val)
+		}
+		if scaling.LogBase != nil && scaling.LogBase.Val != nil {
+			axis.LogBase = *scaling.LogBase.Val
+		}
+	}
+	if ax.NumFmt != nil && (ax.NumFmt.FormatCode != "General" || ax.NumFmt.SourceLinked) {
+		axis.NumFmt = ChartNumFmt{CustomNumFmt: ax.NumFmt.FormatCode, SourceLinked: ax.NumFmt.SourceLinked}
+	}
+	return axis
+}
+
+// getChartType provides a function to recognize the chart type by given chart
+// group element name and settings, by comparing them with the chart groups
+// generated for each of the supported chart types.
+func (f *File) getChartType(name string, group *cCharts) (ChartType, bool) {
+	signature := getChartGroupSignature(name, group)
+	plotAreaFunc := f.getPlotAreaFuncs()
+	for chartType := Area; chartType <= Bubble3D; chartType++ {
+		opts, _ := parseChartOptions(&Chart{Type: chartType, Series: []ChartSeries{{Values: "Sheet1!$A$1"}}})
+		plotArea := reflect.ValueOf(plotAreaFunc[chartType](opts)).Elem()
+		for i := 0; i < plotArea.NumField(); i++ {
+			if g, ok := plotArea.Field(i).Interface().(*cCharts); ok && g != nil &&
+				getChartGroupSignature(plotArea.Type().Field(i).Name, g) == signature {
+				return chartType, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// getChartGroupSignature provides a function to get the string which
+// identifies the type of the chart group by given element name and settings.
+func getChartGroupSignature(name string, group *cCharts) string {
+	val := func(v *attrValString) string {
+		if v != nil && v.Val != nil {
+			return *v.Val
+		}
+		return ""
+	}
+	var wireframe, bubble3D bool
+	if group.Wireframe != nil && group.Wireframe.Val != nil {
+		wireframe = *group.Wireframe.Val
+	}
+	if group.Ser != nil && len(*group.Ser) > 0 {
+		if ser := (*group.Ser)[0]; ser.Bubble3D != nil && ser.Bubble3D.Val != nil {
+			bubble3D = *ser.Bubble3D.Val
+		}
+	}
+	return strings.Join([]string{name, val(group.BarDir), val(group.Grouping), val(group.Shape),
+		val(group.OfPieType), strconv.FormatBool(wireframe), strconv.FormatBool(bubble3D)}, ",")
+}
+
+// getChartSeries provides a function to get the series in order and the
+// smallest order of the series by given chart group.
+func getChartSeries(group *cCharts) ([]ChartSeries, int) {
+	if group.Ser == nil || len(*group.Ser) == 0 {
+		return nil, 0
+	}
+	sers := make([]cSer, len(*group.Ser))
+	copy(sers, *group.Ser)
+	order := func(ser cSer) int {
+		if ser.Order != nil && ser.Order.Val != nil {
+			return *ser.Order.Val
+		}
+		return 0
+	}
+	sort.SliceStable(sers, func(i, j int) bool { return order(sers[i]) < order(sers[j]) })
+	series := make([]ChartSeries, len(sers))
+	for i, ser := range sers {
+		if ser.Tx != nil && ser.Tx.StrRef != nil {
+			series[i].Name = ser.Tx.StrRef.F
+		}
+		for _, cat := range []*cCat{ser.Cat, ser.XVal} {
+			if cat != nil && cat.StrRef != nil {
+				series[i].Categories = cat.StrRef.F
+			}
+		}
+		for _, val := range []*cVal{ser.Val, ser.YVal} {
+			if val != nil && val.NumRef != nil {
+				series[i].Values = val.NumRef.F
+			}
+		}
+		if ser.BubbleSize != nil && ser.BubbleSize.NumRef != nil && ser.BubbleSize.NumRef.F != series[i].Values {
+			series[i].Sizes = ser.BubbleSize.NumRef.F
+		}
+	}
+	return series, order(sers[0])
+}
+
+// UpdateChartSheet provides the method to replace the chart of the chart
+// sheet by given chart sheet name and chart format sets, the view and page
+// layout settings of the chart sheet will be kept. For example, change the
+// chart in the chart sheet named Chart1 to a line chart:
+//
+//	err := f.UpdateChartSheet("Chart1", &excelize.Chart{
+//	    Type: excelize.Line,
+//	    Series: []excelize.ChartSeries{
+//	        {
+//	            Name:       "Sheet1!$A$2",
+//	            Categories: "Sheet1!$B$1:$D$1",
+//	            Values:     "Sheet1!$B$2:$D$2",
+//	        },
+//	    },
+//	    Title: []excelize.RichTextRun{{Text: "Fruit Line Chart"}},
+//	})
+func (f *File) UpdateChartSheet(sheet string, chart *Chart, combo ...*Chart) error {
+	cs, name, err := f.chartSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	opts, comboCharts, err := f.getChartOptions(chart, combo)
+	if err != nil {
+		return err
+	}
+	if chartXML := f.getChartSheetChartPath(sheet, cs); chartXML != "" {
+		f.addChart(chartXML, opts, comboCharts)
+		return err
+	}
+	if err = f.addChartSheetDrawing(sheet, cs, opts, comboCharts); err != nil {
+		return err
+	}
+	f.chartSheetWriter(name, cs)
+	return err
+}
+
+// SetChartSheetOptions provides the method to set the view and page layout
+// settings of the chart sheet by given chart sheet name and options. For
+// example, set the zoom scale and page orientation of the chart sheet named
+// Chart1:
+//
+//	zoomScale, orientation := 150.0, "landscape"
+//	err := f.SetChartSheetOptions("Chart1", &excelize.ChartSheetOptions{
+//	    ZoomScale:  &zoomScale,
+//	    PageLayout: &excelize.PageLayoutOptions{Orientation: &orientation},
+//	})
+func (f *File) SetChartSheetOptions(sheet string, opts *ChartSheetOptions) error {
+	cs, name, err := f.chartSheetReader(sheet)
+	if err != nil || opts == nil {
+		return err
+	}
+	if cs.SheetViews == nil || len(cs.SheetViews.SheetView) == 0 {
+		cs.SheetViews = &xlsxChartsheetViews{SheetView: []*xlsxChartsheetView{{}}}
+	}
+	view := cs.SheetViews.SheetView[0]
+	if opts.ZoomScale != nil && *opts.ZoomScale >= 10 && *opts.ZoomScale <= 400 {
+		view.ZoomScaleAttr = uint32(*opts.ZoomScale)
+	}
+	if opts.ZoomToFit != nil {
+		view.ZoomToFitAttr = *opts.ZoomToFit
+	}
+	if opts.PageLayout != nil {
+		ws := xlsxWorksheet{PageSetUp: cs.PageSetup}
+		ws.setPageSetUp(opts.PageLayout)
+		cs.PageSetup = ws.PageSetUp
+	}
+	f.chartSheetWriter(name, cs)
 	return err
 }
 
@@ -1128,6 +1875,118 @@ func (f *File) getChartOptions(opts *Chart, combo []*Chart) (*Chart, []*Chart, e
 	return options, comboCharts, err
 }
 
+// GetCharts provides a function to get the charts in the worksheet by given
+// worksheet name, including the cell reference of the top-left anchor, the
+// chart type, series, axes, legend, title, dimension and the format settings
+// of each chart. The chart type is recognized by the chart groups in the plot
+// area, the chart group which contains the first series will be the primary
+// chart and the others will be the combo charts, and the charts with
+// unsupported chart types will be ignored. The returned charts could be used
+// to modify and re-add the charts by the AddChart function. For example, print
+// the anchor cell, chart type and series of each chart in the worksheet named
+// Sheet1:
+//
+//	charts, err := f.GetCharts("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, chart := range charts {
+//	    fmt.Println(chart.Cell, chart.Chart.Type, chart.Chart.Series)
+//	}
+func (f *File) GetCharts(sheet string) ([]SheetChart, error) {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return nil, err
+	}
+	f.mu.Unlock()
+	if ws.Drawing == nil {
+		return nil, err
+	}
+	target := f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID)
+	drawingXML := strings.TrimPrefix(strings.ReplaceAll(target, "..", "xl"), "/")
+	wsDr, _, err := f.drawingParser(drawingXML)
+	if err != nil {
+		return nil, err
+	}
+	wsDr.mu.Lock()
+	anchors := append(append([]*xdrCellAnchor{}, wsDr.TwoCellAnchor...), wsDr.OneCellAnchor...)
+	wsDr.mu.Unlock()
+	var charts []SheetChart
+	for _, anchor := range anchors {
+		deCellAnchor := new(decodeCellAnchor)
+		if err = f.xmlNewDecoder(strings.NewReader("<decodeCellAnchor>" + anchor.GraphicFrame + "</decodeCellAnchor>")).
+			Decode(deCellAnchor); err != nil && err != io.EOF {
+			return charts, err
+		}
+		if deCellAnchor.GraphicFrame == nil || deCellAnchor.GraphicFrame.Chart == nil {
+			continue
+		}
+		drawingRel := f.getDrawingRelationships(getRelsPath(drawingXML), deCellAnchor.GraphicFrame.Chart.RID)
+		if drawingRel == nil {
+			continue
+		}
+		chart, combo, err := f.readCharts(getRelsPartPath(drawingXML, drawingRel.Target))
+		if err != nil {
+			return charts, err
+		}
+		if chart == nil {
+			continue
+		}
+		cell, err := f.getChartAnchorOptions(sheet, anchor, deCellAnchor, chart)
+		if err != nil {
+			return charts, err
+		}
+		charts = append(charts, SheetChart{Cell: cell, Chart: chart, Combo: combo})
+	}
+	return charts, nil
+}
+
+// getChartAnchorOptions provides a function to set the dimension and format
+// settings of the chart by given worksheet name, drawing cell anchor and the
+// deserialized cell anchor, and returns the cell reference of the top-left
+// anchor of the chart.
+func (f *File) getChartAnchorOptions(sheet string, anchor *xdrCellAnchor, deCellAnchor *decodeCellAnchor, chart *Chart) (string, error) {
+	from, to := anchor.From, anchor.To
+	if from == nil && deCellAnchor.From != nil {
+		from = &xlsxFrom{Col: deCellAnchor.From.Col, ColOff: deCellAnchor.From.ColOff, Row: deCellAnchor.From.Row, RowOff: deCellAnchor.From.RowOff}
+	}
+	if to == nil && deCellAnchor.To != nil {
+		to = &xlsxTo{Col: deCellAnchor.To.Col, ColOff: deCellAnchor.To.ColOff, Row: deCellAnchor.To.Row, RowOff: deCellAnchor.To.RowOff}
+	}
+	if from == nil {
+		from = &xlsxFrom{}
+	}
+	chart.Format = GraphicOptions{
+		PrintObject: boolPtr(true), Locked: boolPtr(false), ScaleX: defaultDrawingScale, ScaleY: defaultDrawingScale,
+		OffsetX: from.ColOff / EMU, OffsetY: from.RowOff / EMU, Positioning: anchor.EditAs,
+	}
+	if anchor.ClientData != nil {
+		chart.Format.PrintObject, chart.Format.Locked = boolPtr(anchor.ClientData.FPrintsWithSheet), boolPtr(anchor.ClientData.FLocksWithSheet)
+	}
+	if deCellAnchor.ClientData != nil {
+		chart.Format.PrintObject, chart.Format.Locked = boolPtr(deCellAnchor.ClientData.FPrintsWithSheet), boolPtr(deCellAnchor.ClientData.FLocksWithSheet)
+	}
+	if cNvPr := deCellAnchor.GraphicFrame.CNvPr; cNvPr != nil {
+		chart.Format.AltText, chart.Format.AltTextTitle, chart.Format.Decorative = cNvPr.Descr, cNvPr.Title, cNvPr.isDecorative()
+	}
+	if to != nil {
+		width, height := to.ColOff/EMU-from.ColOff/EMU, to.RowOff/EMU-from.RowOff/EMU
+		for col := from.Col; col < to.Col; col++ {
+			width += f.getColWidth(sheet, col+1)
+		}
+		for row := from.Row; row < to.Row; row++ {
+			height += f.getRowHeight(sheet, row+1)
+		}
+		if width > 0 && height > 0 {
+			chart.Dimension = ChartDimension{Width: uint(width), Height: uint(height)}
+		}
+	}
+	return CoordinatesToCellName(from.Col+1, from.Row+1)
+}
+
 // DeleteChart provides a function to delete chart in spreadsheet by given
 // worksheet name and cell reference.
 func (f *File) DeleteChart(sheet, cell string) error {
@@ -5,6 +5,7 @@ import (
 	"encoding/xml"
 	"fmt"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -509,3 +510,694 @@ func TestChartWithLogarithmicBase(t *testing.T) {
 		}
 	}
 }
+
+func TestGetChartSheets(t *testing.T) {
+	f := NewFile()
+	series := []ChartSeries{
+		{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"},
+		{Name: "Sheet1!$A$3", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$3:$D$3", Sizes: "Sheet1!$B$4:$D$4"},
+	}
+	for chartType := Area; chartType <= Bubble3D; chartType++ {
+		assert.NoError(t, f.AddChartSheet(fmt.Sprintf("Chart%d", chartType), &Chart{Type: chartType, Series: series}))
+	}
+	chartSheets, err := f.GetChartSheets()
+	assert.NoError(t, err)
+	assert.Len(t, chartSheets, int(Bubble3D)+1)
+	for idx, chartSheet := range chartSheets {
+		assert.Equal(t, fmt.Sprintf("Chart%d", idx), chartSheet.Name)
+		assert.Equal(t, ChartType(idx), chartSheet.Chart.Type)
+		assert.Empty(t, chartSheet.Combo)
+	}
+	assert.Equal(t, series[:1], chartSheets[Col].Chart.Series[:1])
+	assert.Empty(t, chartSheets[Col].Chart.Series[1].Sizes)
+	assert.Equal(t, series, chartSheets[Bubble].Chart.Series)
+	assert.Equal(t, ChartSheetOptions{
+		ZoomScale: float64Ptr(100), ZoomToFit: boolPtr(true),
+		PageLayout: &PageLayoutOptions{Size: intPtr(0), Orientation: stringPtr("portrait"), FirstPageNumber: uintPtr(1), AdjustTo: uintPtr(100)},
+	}, chartSheets[0].Options)
+
+	// Test get chart sheets with title, legend and combo charts
+	f = NewFile()
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{
+		Type: Col, Series: series[:1], Title: []RichTextRun{{Text: "Fruit "}, {Text: "Chart"}},
+		Legend: ChartLegend{Position: "top"}, ShowBlanksAs: "zero",
+	}, &Chart{Type: Line, Series: series[1:]}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetChartSheets.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestGetChartSheets.xlsx"))
+	assert.NoError(t, err)
+	chartSheets, err = f.GetChartSheets()
+	assert.NoError(t, err)
+	assert.Len(t, chartSheets, 1)
+	assert.Equal(t, Col, chartSheets[0].Chart.Type)
+	assert.Equal(t, series[:1], chartSheets[0].Chart.Series)
+	assert.Equal(t, []RichTextRun{{Text: "Fruit "}, {Text: "Chart"}}, chartSheets[0].Chart.Title)
+	assert.Equal(t, "top", chartSheets[0].Chart.Legend.Position)
+	assert.Equal(t, "zero", chartSheets[0].Chart.ShowBlanksAs)
+	assert.Len(t, chartSheets[0].Combo, 1)
+	assert.Equal(t, Line, chartSheets[0].Combo[0].Type)
+	assert.Equal(t, []ChartSeries{{Name: "Sheet1!$A$3", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$3:$D$3"}}, chartSheets[0].Combo[0].Series)
+
+	// Test get chart sheets without legend and with unsupported chart group
+	f.Pkg.Store("xl/charts/chart1.xml", []byte(`<chartSpace xmlns="http://schemas.openxmlformats.org/drawingml/2006/chart"><chart><plotArea><stockChart/></plotArea></chart></chartSpace>`))
+	chartSheets, err = f.GetChartSheets()
+	assert.NoError(t, err)
+	assert.Nil(t, chartSheets[0].Chart)
+	f.Pkg.Store("xl/charts/chart1.xml", []byte(`<chartSpace xmlns="http://schemas.openxmlformats.org/drawingml/2006/chart"><chart/></chartSpace>`))
+	chartSheets, err = f.GetChartSheets()
+	assert.NoError(t, err)
+	assert.Nil(t, chartSheets[0].Chart)
+	// Test get chart sheets with unsupported charset chart
+	f.Pkg.Store("xl/charts/chart1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetChartSheets()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get chart sheets with unsupported charset chart sheet
+	f.Pkg.Store("xl/chartsheets/sheet2.xml", MacintoshCyrillicCharset)
+	_, err = f.GetChartSheets()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestGetCharts(t *testing.T) {
+	f := NewFile()
+	series := []ChartSeries{
+		{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"},
+		{Name: "Sheet1!$A$3", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$3:$D$3"},
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "E4", &Chart{
+		Type: Col, Series: series[:1], Title: []RichTextRun{{Text: "Fruit Chart"}},
+		Legend: ChartLegend{Position: "top"}, Dimension: ChartDimension{Width: 640, Height: 300},
+		Format: GraphicOptions{OffsetX: 15, OffsetY: 10, AltText: "Chart", Positioning: "oneCell"},
+		XAxis:  ChartAxis{MajorGridLines: true, ReverseOrder: true, TickLabelSkip: 2, Title: []RichTextRun{{Text: "Fruits"}}},
+		YAxis: ChartAxis{
+			Maximum: float64Ptr(100), Minimum: float64Ptr(0), MajorUnit: 10, MajorTickMark: "out",
+			NumFmt: ChartNumFmt{CustomNumFmt: "0.00"}, DisplayUnits: "hundreds", DisplayUnitsVisible: true,
+		},
+	}))
+	assert.NoError(t, f.AddChart("Sheet1", "H20:N30", &Chart{
+		Type: Line, Series: series, XAxis: ChartAxis{DateAxis: true, BaseTimeUnit: "days", MajorUnit: 2, MajorTimeUnit: "months"},
+	}))
+	assert.NoError(t, f.AddChart("Sheet1", "P1", &Chart{Type: Pie, Series: series[:1]}))
+	assert.NoError(t, f.AddChart("Sheet1", "X1", &Chart{Type: Col, Series: series[:1]},
+		&Chart{Type: Line, Series: series[1:], YAxis: ChartAxis{Secondary: true}}))
+	assert.NoError(t, f.AddPicture("Sheet1", "A20", filepath.Join("test", "images", "excel.png"), nil))
+	check := func(charts []SheetChart) {
+		assert.Len(t, charts, 4)
+		assert.Equal(t, "E4", charts[0].Cell)
+		chart := charts[0].Chart
+		assert.Equal(t, Col, chart.Type)
+		assert.Equal(t, series[:1], chart.Series)
+		assert.Equal(t, []RichTextRun{{Text: "Fruit Chart"}}, chart.Title)
+		assert.Equal(t, "top", chart.Legend.Position)
+		assert.Equal(t, ChartDimension{Width: 640, Height: 300}, chart.Dimension)
+		assert.Equal(t, 15, chart.Format.OffsetX)
+		assert.Equal(t, 10, chart.Format.OffsetY)
+		assert.Equal(t, "Chart", chart.Format.AltText)
+		assert.Equal(t, "oneCell", chart.Format.Positioning)
+		assert.Equal(t, boolPtr(true), chart.Format.PrintObject)
+		assert.Equal(t, boolPtr(false), chart.Format.Locked)
+		assert.Equal(t, ChartAxis{MajorGridLines: true, ReverseOrder: true, TickLabelSkip: 2, Title: []RichTextRun{{Text: "Fruits"}}}, chart.XAxis)
+		assert.Equal(t, ChartAxis{
+			Maximum: float64Ptr(100), Minimum: float64Ptr(0), MajorUnit: 10, MajorTickMark: "out",
+			NumFmt: ChartNumFmt{CustomNumFmt: "0.00"}, DisplayUnits: "hundreds", DisplayUnitsVisible: true,
+		}, chart.YAxis)
+		assert.Empty(t, charts[0].Combo)
+		assert.Equal(t, "H20", charts[1].Cell)
+		assert.Equal(t, ChartDimension{Width: 64 * 7, Height: 18 * 11}, charts[1].Chart.Dimension)
+		assert.Equal(t, ChartAxis{DateAxis: true, BaseTimeUnit: "days", MajorUnit: 2, MajorTimeUnit: "months", NumFmt: ChartNumFmt{CustomNumFmt: "m/d/yyyy", SourceLinked: true}}, charts[1].Chart.XAxis)
+		assert.Equal(t, "P1", charts[2].Cell)
+		assert.Equal(t, Pie, charts[2].Chart.Type)
+		assert.Equal(t, ChartAxis{}, charts[2].Chart.XAxis)
+		assert.Equal(t, "X1", charts[3].Cell)
+		assert.Equal(t, Col, charts[3].Chart.Type)
+		assert.False(t, charts[3].Chart.YAxis.Secondary)
+		assert.Len(t, charts[3].Combo, 1)
+		assert.Equal(t, Line, charts[3].Combo[0].Type)
+		assert.Equal(t, series[1:], charts[3].Combo[0].Series)
+		assert.True(t, charts[3].Combo[0].YAxis.Secondary)
+	}
+	charts, err := f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	check(charts)
+	// Test re-add the chart with the returned format settings
+	assert.NoError(t, f.AddChart("Sheet1", "A40", charts[0].Chart))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetCharts.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestGetCharts.xlsx"))
+	assert.NoError(t, err)
+	charts, err = f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, charts, 5)
+	assert.Equal(t, "A40", charts[4].Cell)
+	assert.Equal(t, charts[0].Chart, charts[4].Chart)
+	check(charts[:4])
+	// Test get charts on the worksheet without drawing
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	charts, err = f.GetCharts("Sheet2")
+	assert.NoError(t, err)
+	assert.Nil(t, charts)
+	// Test get charts with invalid sheet name
+	_, err = f.GetCharts("Sheet:1")
+	assert.Equal(t, ErrSheetNameInvalid, err)
+	// Test get charts on not exists worksheet
+	_, err = f.GetCharts("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get charts with unsupported chart group
+	f.Pkg.Store("xl/charts/chart3.xml", []byte(`<chartSpace xmlns="http://schemas.openxmlformats.org/drawingml/2006/chart"><chart><plotArea><stockChart/></plotArea></chart></chartSpace>`))
+	charts, err = f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, charts, 4)
+	// Test get charts with unsupported charset chart
+	f.Pkg.Store("xl/charts/chart3.xml", MacintoshCyrillicCharset)
+	_, err = f.GetCharts("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get charts with the chart relationship doesn't exist
+	f.Relationships.Delete("xl/drawings/_rels/drawing1.xml.rels")
+	f.Pkg.Delete("xl/drawings/_rels/drawing1.xml.rels")
+	charts, err = f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, charts)
+	assert.NoError(t, f.Close())
+
+	// Test get charts with unsupported charset drawing
+	f = NewFile()
+	assert.NoError(t, f.AddChart("Sheet1", "E4", &Chart{Type: Col, Series: series}))
+	f.Drawings.Delete("xl/drawings/drawing1.xml")
+	f.Pkg.Store("xl/drawings/drawing1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetCharts("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get charts with unsupported charset worksheet
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	f.checked.Delete("xl/worksheets/sheet1.xml")
+	_, err = f.GetCharts("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestUpdateChartSheet(t *testing.T) {
+	f := NewFile()
+	series := []ChartSeries{{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"}}
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{Type: Col, Series: series}))
+	assert.NoError(t, f.UpdateChartSheet("Chart1", &Chart{Type: Pie, Series: series, Title: []RichTextRun{{Text: "Pie Chart"}}}))
+	chartSheets, err := f.GetChartSheets()
+	assert.NoError(t, err)
+	assert.Equal(t, Pie, chartSheets[0].Chart.Type)
+	assert.Equal(t, []RichTextRun{{Text: "Pie Chart"}}, chartSheets[0].Chart.Title)
+	assert.Equal(t, 1, f.countCharts())
+	// Test update chart sheet which doesn't contain a chart
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	f.Pkg.Store("xl/chartsheets/sheet2.xml", []byte(`<chartsheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"/>`))
+	assert.NoError(t, f.UpdateChartSheet("Chart1", &Chart{Type: Line, Series: series}))
+	chartSheets, err = f.GetChartSheets()
+	assert.NoError(t, err)
+	assert.Equal(t, Line, chartSheets[0].Chart.Type)
+	assert.Equal(t, 2, f.countCharts())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestUpdateChartSheet.xlsx")))
+	// Test update chart sheet with invalid sheet name
+	assert.EqualError(t, f.UpdateChartSheet("Sheet:1", &Chart{Type: Line, Series: series}), ErrSheetNameInvalid.Error())
+	// Test update chart sheet on not exists sheet
+	assert.EqualError(t, f.UpdateChartSheet("SheetN", &Chart{Type: Line, Series: series}), "sheet SheetN does not exist")
+	// Test update chart sheet on worksheet
+	assert.EqualError(t, f.UpdateChartSheet("Sheet1", &Chart{Type: Line, Series: series}), "sheet Sheet1 is not a chart sheet")
+	// Test update chart sheet with unsupported chart type
+	assert.EqualError(t, f.UpdateChartSheet("Chart1", &Chart{Type: 0x37, Series: series}), newUnsupportedChartType(0x37).Error())
+	// Test update chart sheet with unsupported charset chart sheet
+	f.Pkg.Store("xl/chartsheets/sheet2.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.UpdateChartSheet("Chart1", &Chart{Type: Line, Series: series}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestSetChartSheetOptions(t *testing.T) {
+	f := NewFile()
+	series := []ChartSeries{{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"}}
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{Type: Col, Series: series}))
+	assert.NoError(t, f.SetChartSheetOptions("Chart1", nil))
+	assert.NoError(t, f.SetChartSheetOptions("Chart1", &ChartSheetOptions{
+		ZoomScale: float64Ptr(150), ZoomToFit: boolPtr(false),
+		PageLayout: &PageLayoutOptions{Size: intPtr(9), Orientation: stringPtr("landscape")},
+	}))
+	chartSheets, err := f.GetChartSheets()
+	assert.NoError(t, err)
+	assert.Equal(t, float64Ptr(150), chartSheets[0].Options.ZoomScale)
+	assert.Equal(t, boolPtr(false), chartSheets[0].Options.ZoomToFit)
+	assert.Equal(t, intPtr(9), chartSheets[0].Options.PageLayout.Size)
+	assert.Equal(t, stringPtr("landscape"), chartSheets[0].Options.PageLayout.Orientation)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetChartSheetOptions.xlsx")))
+	// Test set chart sheet options without sheet views
+	f.Pkg.Store("xl/chartsheets/sheet2.xml", []byte(`<chartsheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"/>`))
+	assert.NoError(t, f.SetChartSheetOptions("Chart1", &ChartSheetOptions{ZoomToFit: boolPtr(true)}))
+	chartSheets, err = f.GetChartSheets()
+	assert.NoError(t, err)
+	assert.Equal(t, boolPtr(true), chartSheets[0].Options.ZoomToFit)
+	// Test set chart sheet options on worksheet
+	assert.EqualError(t, f.SetChartSheetOptions("Sheet1", &ChartSheetOptions{}), "sheet Sheet1 is not a chart sheet")
+	assert.NoError(t, f.Close())
+}
+
+func TestChartSeriesFill(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{
+		{nil, "Apple", "Orange", "Pear"}, {"Small", 2, 3, 3},
+		{"Normal", 5, 2, 4}, {"Large", 6, 7, 8},
+	} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{
+		Type: Col,
+		Series: []ChartSeries{
+			{
+				Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2",
+				Fill: Fill{Type: "pattern", Pattern: 1, Color: []string{"#FF0000"}, Transparency: 40},
+				DataPoint: []ChartDataPoint{
+					{Index: 2, Fill: Fill{Type: "gradient", Color: []string{"FFFFFF", "0070C0"}, Shading: 16}},
+					{Index: 1, Fill: Fill{Type: "pattern", Pattern: 1, Color: []string{"00B050"}}},
+					{Index: 0},
+				},
+			},
+			{
+				Name: "Sheet1!$A$3", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$3:$D$3",
+				Fill: Fill{Type: "gradient", Color: []string{"FFFFFF", "0070C0"}, Shading: 5},
+			},
+			{
+				Name: "Sheet1!$A$4", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$4:$D$4",
+				Fill: Fill{Type: "pattern", Pattern: 7, Color: []string{"0070C0"}},
+			},
+		},
+	}))
+	assert.NoError(t, f.AddChart("Sheet1", "E16", &Chart{
+		Type: Pie,
+		Series: []ChartSeries{{
+			Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2",
+			DataPoint: []ChartDataPoint{
+				{Index: 1, Fill: Fill{Type: "pattern", Pattern: 1, Color: []string{"00B050"}}},
+				{Index: 0, Fill: Fill{Type: "pattern", Pattern: 1, Color: []string{"FFC000"}}},
+			},
+		}},
+	}))
+	var chartSpace xlsxChartSpace
+	content, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	sers := *chartSpace.Chart.PlotArea.BarChart.Ser
+	assert.Contains(t, string(content.([]byte)), `<a:srgbClr val="FF0000"><a:alpha val="60000"></a:alpha></a:srgbClr>`)
+	assert.Len(t, sers[0].DPt, 2)
+	assert.Equal(t, 1, *sers[0].DPt[0].IDx.Val)
+	assert.Equal(t, 2, *sers[0].DPt[1].IDx.Val)
+	assert.Contains(t, string(content.([]byte)), `<a:path path="circle"><a:fillToRect l="50000" t="50000" r="50000" b="50000"></a:fillToRect></a:path>`)
+	assert.Contains(t, string(content.([]byte)), `<a:gs pos="0"><a:srgbClr val="FFFFFF"></a:srgbClr></a:gs><a:gs pos="50000"><a:srgbClr val="0070C0"></a:srgbClr></a:gs><a:gs pos="100000"><a:srgbClr val="FFFFFF"></a:srgbClr></a:gs></a:gsLst><a:lin ang="0" scaled="false"></a:lin>`)
+	assert.Contains(t, string(content.([]byte)), `<a:pattFill prst="dkDnDiag"><a:fgClr><a:srgbClr val="0070C0"></a:srgbClr></a:fgClr><a:bgClr><a:srgbClr val="FFFFFF"></a:srgbClr></a:bgClr></a:pattFill>`)
+	content, ok = f.Pkg.Load("xl/charts/chart2.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	dPts := (*chartSpace.Chart.PlotArea.PieChart.Ser)[0].DPt
+	assert.Len(t, dPts, 2)
+	assert.Equal(t, 0, *dPts[0].IDx.Val)
+	assert.Equal(t, 1, *dPts[1].IDx.Val)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestChartSeriesFill.xlsx")))
+	// Test add chart with invalid fill transparency
+	assert.Equal(t, ErrTransparency, f.AddChart("Sheet1", "E32", &Chart{
+		Type: Col,
+		Series: []ChartSeries{{
+			Values:    "Sheet1!$B$2:$D$2",
+			DataPoint: []ChartDataPoint{{Fill: Fill{Type: "pattern", Pattern: 1, Transparency: 101}}},
+		}},
+	}))
+	assert.NoError(t, f.Close())
+}
+
+func TestDrawShapeGradFill(t *testing.T) {
+	f := NewFile()
+	colors := []string{"FF0000", "00FF00"}
+	for shading, expected := range map[int]*aGradFill{
+		1:  {RotWithShape: true, GsLst: []aGs{{Pos: 0, SrgbClr: &aSrgbClr{Val: stringPtr("00FF00")}}, {Pos: 100000, SrgbClr: &aSrgbClr{Val: stringPtr("FF0000")}}}, Lin: &aLin{Ang: 5400000}},
+		6:  {RotWithShape: true, GsLst: []aGs{{Pos: 0, SrgbClr: &aSrgbClr{Val: stringPtr("FF0000")}}, {Pos: 100000, SrgbClr: &aSrgbClr{Val: stringPtr("00FF00")}}}, Lin: &aLin{Ang: 2700000}},
+		13: {RotWithShape: true, GsLst: []aGs{{Pos: 0, SrgbClr: &aSrgbClr{Val: stringPtr("FF0000")}}, {Pos: 100000, SrgbClr: &aSrgbClr{Val: stringPtr("00FF00")}}}, Path: &aGradPath{Path: "rect", FillToRect: &aFillToRect{L: 100000, B: 100000}}},
+	} {
+		assert.Equal(t, expected, f.drawShapeGradFill(Fill{Type: "gradient", Color: colors, Shading: shading}))
+	}
+	// Test draw shape fill with unsupported gradient shading
+	assert.Nil(t, f.drawShapeFill(Fill{Type: "gradient", Color: colors, Shading: 17}, nil))
+}
+
+func TestChartAxisOptions(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"}},
+		XAxis:  ChartAxis{MajorTickMark: "out", MinorTickMark: "unknown", TickLabelRotation: 45},
+		YAxis: ChartAxis{
+			MajorTickMark: "CROSS", MinorTickMark: "in", TickLabelRotation: 91,
+			DisplayUnits: "Thousands", DisplayUnitsVisible: true,
+			NumFmt: ChartNumFmt{CustomNumFmt: "#,##0"},
+		},
+	}))
+	var chartSpace xlsxChartSpace
+	content, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	catAx, valAx := chartSpace.Chart.PlotArea.CatAx[0], chartSpace.Chart.PlotArea.ValAx[0]
+	assert.Equal(t, "out", *catAx.MajorTickMark.Val)
+	assert.Equal(t, "none", *catAx.MinorTickMark.Val)
+	assert.Equal(t, "cross", *valAx.MajorTickMark.Val)
+	assert.Equal(t, "in", *valAx.MinorTickMark.Val)
+	assert.Equal(t, "#,##0", valAx.NumFmt.FormatCode)
+	assert.Equal(t, "thousands", *valAx.DispUnits.BuiltInUnit.Val)
+	assert.NotNil(t, valAx.DispUnits.DispUnitsLbl)
+	assert.Equal(t, 1, strings.Count(string(content.([]byte)), `rot="-2700000"`))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestChartAxisOptions.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
+func TestChartDateAxis(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{
+		{"Date", "Price"}, {"2024-01-01", 10}, {"2024-02-15", 12}, {"2024-05-01", 9},
+	} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	series := []ChartSeries{{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$4", Values: "Sheet1!$B$2:$B$4"}}
+	assert.NoError(t, f.AddChart("Sheet1", "D1", &Chart{
+		Type: Line, Series: series,
+		XAxis: ChartAxis{
+			DateAxis: true, BaseTimeUnit: "days", MajorUnit: 1, MajorTimeUnit: "months",
+			MinorUnit: 7, MinorTimeUnit: "weeks",
+		},
+	}, &Chart{Type: Col, Series: series, YAxis: ChartAxis{MinorUnit: 0.5}}))
+	var chartSpace xlsxChartSpace
+	content, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	plotArea := chartSpace.Chart.PlotArea
+	assert.Nil(t, plotArea.CatAx)
+	assert.Len(t, plotArea.DateAx, 1)
+	dateAx := plotArea.DateAx[0]
+	assert.Equal(t, 100000000, *dateAx.AxID.Val)
+	assert.Equal(t, &cNumFmt{FormatCode: "m/d/yyyy", SourceLinked: true}, dateAx.NumFmt)
+	assert.False(t, *dateAx.Auto.Val)
+	assert.Equal(t, "days", *dateAx.BaseTimeUnit.Val)
+	assert.Equal(t, 1.0, *dateAx.MajorUnit.Val)
+	assert.Equal(t, "months", *dateAx.MajorTimeUnit.Val)
+	assert.Equal(t, 7.0, *dateAx.MinorUnit.Val)
+	assert.Nil(t, dateAx.MinorTimeUnit)
+	assert.Equal(t, 0.5, *plotArea.ValAx[0].MinorUnit.Val)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestChartDateAxis.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
+func TestChartGapWidthAndOverlap(t *testing.T) {
+	f := NewFile()
+	series := []ChartSeries{
+		{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"},
+		{Name: "Sheet1!$A$3", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$3:$D$3"},
+	}
+	gapWidth, overlap, invalid := 50, -20, 600
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{Type: Col, Series: series, GapWidth: &gapWidth, Overlap: &overlap}))
+	assert.NoError(t, f.AddChart("Sheet1", "E16", &Chart{Type: Col3DClustered, Series: series, GapWidth: &gapWidth, Overlap: &overlap}))
+	assert.NoError(t, f.AddChart("Sheet1", "E31", &Chart{Type: Bar, Series: series, GapWidth: &invalid}))
+	assert.NoError(t, f.AddChart("Sheet1", "E46", &Chart{Type: Line, Series: series, GapWidth: &gapWidth}))
+	for chartPath, expected := range map[string][]string{
+		"xl/charts/chart1.xml": {`<gapWidth val="50"></gapWidth><overlap val="-20"></overlap>`},
+		"xl/charts/chart2.xml": {`<gapWidth val="50"></gapWidth><axId val="100000000"></axId>`},
+	} {
+		content, ok := f.Pkg.Load(chartPath)
+		assert.True(t, ok)
+		for _, str := range expected {
+			assert.Contains(t, string(content.([]byte)), str)
+		}
+	}
+	for _, chartPath := range []string{"xl/charts/chart2.xml", "xl/charts/chart3.xml", "xl/charts/chart4.xml"} {
+		content, ok := f.Pkg.Load(chartPath)
+		assert.True(t, ok)
+		assert.NotContains(t, string(content.([]byte)), "<overlap")
+		if chartPath != "xl/charts/chart2.xml" {
+			assert.NotContains(t, string(content.([]byte)), "<gapWidth")
+		}
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestChartGapWidthAndOverlap.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
+func TestChartPieSliceOptions(t *testing.T) {
+	f := NewFile()
+	series := []ChartSeries{{
+		Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2", Explosion: 10,
+		DataPoint: []ChartDataPoint{{Index: 2, Explosion: 25}, {Index: 0, Explosion: 500}},
+	}}
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{Type: Pie, Series: series, FirstSliceAngle: 90}))
+	assert.NoError(t, f.AddChart("Sheet1", "E16", &Chart{Type: Doughnut, Series: series, FirstSliceAngle: 400}))
+	assert.NoError(t, f.AddChart("Sheet1", "E31", &Chart{Type: Col, Series: series}))
+	var chartSpace xlsxChartSpace
+	content, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	pieChart := chartSpace.Chart.PlotArea.PieChart
+	assert.Equal(t, 90, *pieChart.FirstSliceAng.Val)
+	ser := (*pieChart.Ser)[0]
+	assert.Equal(t, 10, *ser.Explosion.Val)
+	assert.Len(t, ser.DPt, 2)
+	assert.Nil(t, ser.DPt[0].Explosion)
+	assert.Equal(t, 25, *ser.DPt[1].Explosion.Val)
+	content, ok = f.Pkg.Load("xl/charts/chart2.xml")
+	assert.True(t, ok)
+	assert.NotContains(t, string(content.([]byte)), "<firstSliceAng")
+	assert.Contains(t, string(content.([]byte)), `<explosion val="10"></explosion>`)
+	content, ok = f.Pkg.Load("xl/charts/chart3.xml")
+	assert.True(t, ok)
+	assert.NotContains(t, string(content.([]byte)), "<explosion")
+	assert.NotContains(t, string(content.([]byte)), "<dPt>")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestChartPieSliceOptions.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
+func TestChartTitleAndLegend(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Fruit Sales"))
+	series := []ChartSeries{
+		{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"},
+		{Name: "Sheet1!$A$3", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$3:$D$3"},
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{
+		Type: Col, Series: series,
+		Title: []RichTextRun{{Text: "=Sheet1!$A$1", Font: &Font{Bold: true, Color: "FF0000"}}},
+		Legend: ChartLegend{
+			Position:       "right",
+			Font:           Font{Italic: true, Size: 10},
+			Fill:           Fill{Type: "pattern", Pattern: 1, Color: []string{"F2F2F2"}},
+			Border:         ChartLine{Type: ChartLineSolid, Width: 1},
+			DeletedEntries: []int{1, -1},
+		},
+		YAxis: ChartAxis{Title: []RichTextRun{{Text: "Amount\nin USD"}}},
+	}))
+	var chartSpace xlsxChartSpace
+	content, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	assert.Equal(t, "Sheet1!$A$1", chartSpace.Chart.Title.Tx.StrRef.F)
+	assert.Nil(t, chartSpace.Chart.Title.Tx.Rich)
+	assert.Contains(t, string(content.([]byte)), `<title><tx><strRef><f>Sheet1!$A$1</f></strRef></tx><overlay val="0"></overlay><txPr>`)
+	legend := chartSpace.Chart.Legend
+	assert.Len(t, legend.LegendEntry, 1)
+	assert.Equal(t, 1, *legend.LegendEntry[0].IDx.Val)
+	assert.True(t, *legend.LegendEntry[0].Delete.Val)
+	assert.NotNil(t, legend.SpPr)
+	assert.NotNil(t, legend.TxPr)
+	assert.Contains(t, string(content.([]byte)), `<legend><legendPos val="r"></legendPos><legendEntry><idx val="1"></idx><delete val="1"></delete></legendEntry><overlay val="0"></overlay><spPr><a:solidFill><a:srgbClr val="F2F2F2"></a:srgbClr></a:solidFill><a:ln algn="ctr" cap="flat" cmpd="sng" w="12700">`)
+	assert.Equal(t, 2, strings.Count(string(content.([]byte)), `<a:t>`))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestChartTitleAndLegend.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test get chart sheet with the title references the cell
+	f = NewFile()
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{Type: Col, Series: series, Title: []RichTextRun{{Text: "=Sheet1!$A$1"}}}))
+	chartSheets, err := f.GetChartSheets()
+	assert.NoError(t, err)
+	assert.Equal(t, []RichTextRun{{Text: "=Sheet1!$A$1"}}, chartSheets[0].Chart.Title)
+	assert.NoError(t, f.Close())
+}
+
+func TestChartAreaAndPlotAreaStyle(t *testing.T) {
+	f := NewFile()
+	series := []ChartSeries{{
+		Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2",
+		Line: ChartLine{DashType: "sysDash"},
+	}}
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{
+		Type: Line, Series: series,
+		Fill:           Fill{Type: "none"},
+		Border:         ChartLine{Type: ChartLineSolid, Width: 1.5, Color: "#4472C4", DashType: "dash"},
+		RoundedCorners: true,
+		PlotArea: ChartPlotArea{
+			Fill:   Fill{Type: "gradient", Color: []string{"FFFFFF", "D9E1F2"}},
+			Border: ChartLine{Type: ChartLineNone},
+		},
+	}))
+	var chartSpace xlsxChartSpace
+	content, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	assert.True(t, *chartSpace.RoundedCorners.Val)
+	assert.Contains(t, string(content.([]byte)), `<spPr><a:noFill></a:noFill><a:ln algn="ctr" cap="flat" cmpd="sng" w="19050"><a:solidFill><a:srgbClr val="4472C4"></a:srgbClr></a:solidFill><a:prstDash val="dash"></a:prstDash></a:ln></spPr><printSettings>`)
+	assert.Contains(t, string(content.([]byte)), `<a:lin ang="5400000" scaled="false"></a:lin></a:gradFill><a:ln algn="ctr" cap="flat" cmpd="sng" w="25400"><a:noFill></a:noFill></a:ln></spPr></plotArea>`)
+	assert.Contains(t, string(content.([]byte)), `<a:prstDash val="sysDash"></a:prstDash>`)
+	assert.NotContains(t, string(content.([]byte)), `<legend><legendPos val="b"></legendPos><overlay val="0"></overlay><spPr>`)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestChartAreaAndPlotAreaStyle.xlsx")))
+	// Test the pattern fill without pattern keeps the default fill
+	assert.Nil(t, f.drawShapeFill(Fill{Type: "pattern"}, nil))
+	spPr := &cSpPr{SolidFill: &aSolidFill{}}
+	assert.Equal(t, spPr, f.drawShapeFill(Fill{Type: "pattern", Color: []string{"FF0000"}}, spPr))
+	assert.Equal(t, &cSpPr{NoFill: stringPtr("")}, f.drawShapeFill(Fill{Type: "none"}, spPr))
+	assert.NoError(t, f.Close())
+}
+
+func TestChartDataTable(t *testing.T) {
+	f := NewFile()
+	series := []ChartSeries{{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"}}
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{
+		Type: Col, Series: series,
+		DataTable: &ChartDataTable{ShowHorizontalBorder: true, ShowOutline: true, ShowLegendKeys: true, Font: Font{Bold: true}},
+	}))
+	content, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	var chartSpace xlsxChartSpace
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	dTable := chartSpace.Chart.PlotArea.DTable
+	assert.NotNil(t, dTable)
+	assert.True(t, *dTable.ShowHorzBorder.Val)
+	assert.False(t, *dTable.ShowVertBorder.Val)
+	assert.True(t, *dTable.ShowOutline.Val)
+	assert.True(t, *dTable.ShowKeys.Val)
+	assert.Contains(t, string(content.([]byte)), `</valAx><dTable><showHorzBorder val="1"></showHorzBorder>`)
+	// Test add data table for the chart without category axis
+	assert.NoError(t, f.AddChart("Sheet1", "E20", &Chart{Type: Pie, Series: series, DataTable: &ChartDataTable{}}))
+	content, ok = f.Pkg.Load("xl/charts/chart2.xml")
+	assert.True(t, ok)
+	assert.NotContains(t, string(content.([]byte)), "<dTable>")
+	assert.NoError(t, f.AddChart("Sheet1", "E40", &Chart{Type: Scatter, Series: series, DataTable: &ChartDataTable{}}))
+	content, ok = f.Pkg.Load("xl/charts/chart3.xml")
+	assert.True(t, ok)
+	assert.NotContains(t, string(content.([]byte)), "<dTable>")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestChartDataTable.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
+func TestChartLinesAndUpDownBars(t *testing.T) {
+	f := NewFile()
+	series := []ChartSeries{
+		{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"},
+		{Name: "Sheet1!$A$3", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$3:$D$3"},
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{
+		Type: Line, Series: series,
+		DropLines:    &ChartLine{},
+		HighLowLines: &ChartLine{Width: 0.75, DashType: "dash"},
+		UpDownBars: &ChartUpDownBars{
+			GapWidth:     intPtr(50),
+			UpBarsFill:   Fill{Type: "pattern", Pattern: 1, Color: []string{"70AD47"}},
+			DownBarsFill: Fill{Type: "pattern", Pattern: 1, Color: []string{"FF0000"}},
+			Border:       ChartLine{Type: ChartLineNone},
+		},
+	}))
+	content, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	var chartSpace xlsxChartSpace
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	lineChart := chartSpace.Chart.PlotArea.LineChart
+	assert.NotNil(t, lineChart.DropLines)
+	assert.Nil(t, lineChart.DropLines.SpPr)
+	assert.NotNil(t, lineChart.HiLowLines)
+	assert.Equal(t, 50, *lineChart.UpDownBars.GapWidth.Val)
+	assert.Contains(t, string(content.([]byte)), `<dropLines></dropLines><hiLowLines><spPr><a:ln algn="ctr" cap="flat" cmpd="sng" w="9525"><a:solidFill>`)
+	assert.Contains(t, string(content.([]byte)), `<upBars><spPr><a:solidFill><a:srgbClr val="70AD47"></a:srgbClr></a:solidFill><a:ln algn="ctr" cap="flat" cmpd="sng" w="25400"><a:noFill></a:noFill></a:ln></spPr></upBars>`)
+	assert.Contains(t, string(content.([]byte)), `<downBars><spPr><a:solidFill><a:srgbClr val="FF0000"></a:srgbClr></a:solidFill>`)
+	// Test up and down bars with default format
+	assert.NoError(t, f.AddChart("Sheet1", "E20", &Chart{Type: Line, Series: series, UpDownBars: &ChartUpDownBars{GapWidth: intPtr(600)}}))
+	content, ok = f.Pkg.Load("xl/charts/chart2.xml")
+	assert.True(t, ok)
+	assert.Contains(t, string(content.([]byte)), `<upDownBars><gapWidth val="150"></gapWidth><upBars></upBars><downBars></downBars></upDownBars>`)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestChartLinesAndUpDownBars.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
+func TestChartView3D(t *testing.T) {
+	f := NewFile()
+	series := []ChartSeries{{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"}}
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{
+		Type: Col3DClustered, Series: series,
+		View3D: ChartView3D{RotX: intPtr(-20), RotY: intPtr(60), Perspective: intPtr(45), RightAngleAxes: boolPtr(false), DepthPercent: intPtr(200)},
+	}))
+	var chartSpace xlsxChartSpace
+	content, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	assert.Equal(t, -20, *chartSpace.Chart.View3D.RotX.Val)
+	assert.Equal(t, 60, *chartSpace.Chart.View3D.RotY.Val)
+	assert.Equal(t, 45, *chartSpace.Chart.View3D.Perspective.Val)
+	assert.Equal(t, 0, *chartSpace.Chart.View3D.RAngAx.Val)
+	assert.Equal(t, 200, *chartSpace.Chart.View3D.DepthPercent.Val)
+	// Test add chart with out of range view options
+	assert.NoError(t, f.AddChart("Sheet1", "E20", &Chart{
+		Type: Pie3D, Series: series,
+		View3D: ChartView3D{RotX: intPtr(100), RotY: intPtr(-1), Perspective: intPtr(300), RightAngleAxes: boolPtr(true), DepthPercent: intPtr(10)},
+	}))
+	chartSpace = xlsxChartSpace{}
+	content, ok = f.Pkg.Load("xl/charts/chart2.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	assert.Equal(t, 30, *chartSpace.Chart.View3D.RotX.Val)
+	assert.Equal(t, 0, *chartSpace.Chart.View3D.RotY.Val)
+	assert.Equal(t, 0, *chartSpace.Chart.View3D.Perspective.Val)
+	assert.Equal(t, 1, *chartSpace.Chart.View3D.RAngAx.Val)
+	assert.Nil(t, chartSpace.Chart.View3D.DepthPercent)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestChartView3D.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartToRange(t *testing.T) {
+	f := NewFile()
+	series := []ChartSeries{{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"}}
+	assert.NoError(t, f.AddChart("Sheet1", "M20:E4", &Chart{Type: Col, Series: series, Format: GraphicOptions{OffsetX: 10, Positioning: "oneCell"}}))
+	drawing, ok := f.Drawings.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	anchor := drawing.(*xlsxWsDr).TwoCellAnchor[0]
+	assert.Equal(t, "oneCell", anchor.EditAs)
+	assert.Equal(t, xlsxFrom{Col: 4, Row: 3}, *anchor.From)
+	assert.Equal(t, xlsxTo{Col: 13, Row: 20}, *anchor.To)
+	// Test add chart with invalid range reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.AddChart("Sheet1", "A:B2", &Chart{Type: Col, Series: series}))
+	// Test add chart with unsupported positioning
+	assert.Equal(t, ErrParameterInvalid, f.AddChart("Sheet1", "E4:M20", &Chart{Type: Col, Series: series, Format: GraphicOptions{Positioning: "x"}}))
+	// Test add chart to range with absolute position
+	assert.Equal(t, ErrParameterInvalid, f.AddChart("Sheet1", "E4:M20", &Chart{Type: Col, Series: series, Format: GraphicOptions{Absolute: true}}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartToRange.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartPhysicalUnits(t *testing.T) {
+	f := NewFile()
+	series := []ChartSeries{{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"}}
+	colWidth, rowHeight := f.getColWidth("Sheet1", 1)*EMU, f.getRowHeight("Sheet1", 1)*EMU
+	// Test add chart by absolute position with the width in centimeters
+	assert.NoError(t, f.AddChart("Sheet1", "A1", &Chart{
+		Type: Col, Series: series, Dimension: ChartDimension{Width: 400, Height: 200},
+		Format: GraphicOptions{Unit: "cm", Absolute: true, PositionX: 2, PositionY: 3, Width: 5},
+	}))
+	drawing, ok := f.Drawings.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	anchor := drawing.(*xlsxWsDr).TwoCellAnchor[0]
+	x, y, width, height := 720000, 1080000, 1800000, 900000
+	assert.Equal(t, &xlsxFrom{Col: x / colWidth, ColOff: x % colWidth, Row: y / rowHeight, RowOff: y % rowHeight}, anchor.From)
+	assert.Equal(t, &xlsxTo{Col: (x + width) / colWidth, ColOff: (x + width) % colWidth, Row: (y + height) / rowHeight, RowOff: (y + height) % rowHeight}, anchor.To)
+	// Test add chart with unsupported unit
+	assert.Equal(t, ErrParameterInvalid, f.AddChart("Sheet1", "A1", &Chart{Type: Col, Series: series, Format: GraphicOptions{Unit: "px", Width: 1}}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartPhysicalUnits.xlsx")))
+	assert.NoError(t, f.Close())
+}
@@ -15,6 +15,7 @@ import (
 	"bytes"
 	"encoding/xml"
 	"math"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -34,9 +35,10 @@ const (
 type Cols struct {
 	err                                    error
 	curCol, totalCols, totalRows, stashCol int
-	rawCellValue                           bool
+	rawCellValue, reverse                  bool
 	sheet                                  string
 	f                                      *File
+	options                                *Options
 	sheetXML                               []byte
 	sst                                    *xlsxSST
 }
@@ -75,12 +77,56 @@ func (f *File) GetCols(sheet string, opts ...Options) ([][]string, error) {
 	return results, nil
 }
 
-// Next will return true if the next column is found.
+// Next will return true if the next column is found. For the reverse columns
+// iterator, it will move to the previous column.
 func (cols *Cols) Next() bool {
+	if cols.reverse {
+		cols.curCol--
+		return cols.curCol >= 1
+	}
 	cols.curCol++
 	return cols.curCol <= cols.totalCols
 }
 
+// Seek moves the columns iterator to the given column number, and the next
+// call of the 'Next' function will move to that column. For example, get the
+// cell values of the columns start from the column 100:
+//
+//	if err = cols.Seek(100); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for cols.Next() {
+//	    col, err := cols.Rows()
+//	    if err != nil {
+//	        fmt.Println(err)
+//	    }
+//	    fmt.Println(cols.CurrentCol(), col)
+//	}
+//
+// For the reverse columns iterator, the next call of the 'Next' function will
+// move to the given column, and then move to the previous columns.
+func (cols *Cols) Seek(col int) error {
+	if col < MinColumns || col > MaxColumns {
+		return ErrColumnNumber
+	}
+	if cols.reverse {
+		if col > cols.totalCols {
+			col = cols.totalCols
+		}
+		cols.curCol = col + 1
+		return nil
+	}
+	cols.curCol = col - 1
+	return nil
+}
+
+// CurrentCol returns the column number of the current column of the columns
+// iterator.
+func (cols *Cols) CurrentCol() int {
+	return cols.curCol
+}
+
 // Error will return an error when the error occurs.
 func (cols *Cols) Error() error {
 	return cols.err
@@ -92,7 +138,8 @@ func (cols *Cols) Rows(opts ...Options) ([]string, error) {
 	if cols.stashCol >= cols.curCol {
 		return rowIterator.cells, rowIterator.err
 	}
-	cols.rawCellValue = cols.f.getOptions(opts...).RawCellValue
+	cols.options = cols.f.getOptions(opts...)
+	cols.rawCellValue = cols.options.RawCellValue
 	if cols.sst, rowIterator.err = cols.f.sharedStringsReader(); rowIterator.err != nil {
 		return rowIterator.cells, rowIterator.err
 	}
@@ -182,7 +229,17 @@ func (cols *Cols) rowXMLHandler(rowIterator *rowXMLIterator, xmlElement *xml.Sta
 		if rowIterator.cellCol == cols.curCol {
 			colCell := xlsxC{}
 			_ = decoder.DecodeElement(&colCell, xmlElement)
+			cached := colCell.V
 			val, _ := colCell.getValueFrom(cols.f, cols.sst, cols.rawCellValue)
+			if colCell.F != nil {
+				colCell.V = cached
+				if colCell.R, rowIterator.err = CoordinatesToCellName(cols.curCol, rowIterator.cellRow); rowIterator.err != nil {
+					return
+				}
+				if val, rowIterator.err = cols.f.getFormulaValue(cols.sheet, &colCell, val, cols.options); rowIterator.err != nil {
+					return
+				}
+			}
 			rowIterator.cells = append(rowIterator.cells, val)
 		}
 	}
@@ -247,6 +304,32 @@ func (f *File) Cols(sheet string) (*Cols, error) {
 	return &colIterator.cols, nil
 }
 
+// ReverseCols returns a reverse columns iterator, used for streaming reading
+// data for a worksheet from the last column to the first column. This
+// function is concurrency safe. For example, get the last 10 columns of the
+// worksheet:
+//
+//	cols, err := f.ReverseCols("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for i := 0; i < 10 && cols.Next(); i++ {
+//	    col, err := cols.Rows()
+//	    if err != nil {
+//	        fmt.Println(err)
+//	    }
+//	    fmt.Println(cols.CurrentCol(), col)
+//	}
+func (f *File) ReverseCols(sheet string) (*Cols, error) {
+	cols, err := f.Cols(sheet)
+	if err != nil {
+		return cols, err
+	}
+	cols.reverse, cols.curCol = true, cols.totalCols+1
+	return cols, err
+}
+
 // GetColVisible provides a function to get visible of a single column by given
 // worksheet name and column name. This function is concurrency safe. For
 // example, get visible state of column D in Sheet1:
@@ -326,6 +409,39 @@ func (f *File) SetColVisible(sheet, columns string, visible bool) error {
 	return nil
 }
 
+// GetHiddenCols provides a function to get the column name of all hidden
+// columns by given worksheet name. This function is concurrency safe. For
+// example, get hidden columns in Sheet1:
+//
+//	cols, err := f.GetHiddenCols("Sheet1")
+func (f *File) GetHiddenCols(sheet string) ([]string, error) {
+	var cols []string
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.Cols == nil {
+		return cols, err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	var colNums []int
+	for _, col := range ws.Cols.Col {
+		if !col.Hidden {
+			continue
+		}
+		for colNum := col.Min; colNum <= col.Max; colNum++ {
+			colNums = append(colNums, colNum)
+		}
+	}
+	sort.Ints(colNums)
+	for _, colNum := range colNums {
+		colName, err := ColumnNumberToName(colNum)
+		if err != nil {
+			return cols, err
+		}
+		cols = append(cols, colName)
+	}
+	return cols, err
+}
+
 // GetColOutlineLevel provides a function to get outline level of a single
 // column by given worksheet name and column name. For example, get outline
 // level of column D in Sheet1:
@@ -759,26 +875,88 @@ func (f *File) InsertCols(sheet, col string, n int) error {
 // worksheet, it will cause a file error when you open it. The excelize only
 // partially updates these references currently.
 func (f *File) RemoveCol(sheet, col string) error {
+	return f.RemoveCols(sheet, col, 1)
+}
+
+// RemoveCols provides a function to remove the given number of contiguous
+// columns start from the given column name in one pass. For example, remove 3
+// columns start from column C (column C, D and E) in Sheet1:
+//
+//	err := f.RemoveCols("Sheet1", "C", 3)
+//
+// Use this method with caution, which will affect changes in references such
+// as formulas, charts, and so on. If there is any referenced value of the
+// worksheet, it will cause a file error when you open it. The excelize only
+// partially updates these references currently.
+func (f *File) RemoveCols(sheet, col string, n int) error {
 	num, err := ColumnNameToNumber(col)
 	if err != nil {
 		return err
 	}
+	if n < 1 {
+		return ErrParameterInvalid
+	}
+	if n > MaxColumns {
+		return ErrColumnNumber
+	}
+	return f.removeCols(sheet, [][2]int{{num, n}})
+}
 
+// RemoveColSet provides a function to remove the set of columns by given
+// worksheet name and the column names, the columns don't need to be
+// contiguous or sorted, and the duplicate columns will be ignored. All column
+// names will be validated before removing, so either all of the columns will
+// be removed or none of them. For example, remove column B, E and F in
+// Sheet1:
+//
+//	err := f.RemoveColSet("Sheet1", []string{"B", "E", "F"})
+//
+// Use this method with caution, which will affect changes in references such
+// as formulas, charts, and so on. If there is any referenced value of the
+// worksheet, it will cause a file error when you open it. The excelize only
+// partially updates these references currently.
+func (f *File) RemoveColSet(sheet string, cols []string) error {
+	if len(cols) == 0 {
+		return ErrParameterInvalid
+	}
+	nums := make([]int, 0, len(cols))
+	for _, col := range cols {
+		num, err := ColumnNameToNumber(col)
+		if err != nil {
+			return err
+		}
+		nums = append(nums, num)
+	}
+	return f.removeCols(sheet, contiguousRuns(nums))
+}
+
+// removeCols provides a function to remove the columns by given worksheet
+// name and the sorted runs of the contiguous columns. The cells of all
+// columns will be removed in one pass, and the references will be adjusted
+// from the last run to the first run, so that the column numbers of the
+// previous runs are not affected by the adjustment.
+func (f *File) removeCols(sheet string, runs [][2]int) error {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
 	}
 	for rowIdx := range ws.SheetData.Row {
 		rowData := &ws.SheetData.Row[rowIdx]
+		keep := 0
 		for colIdx := range rowData.C {
-			colName, _, _ := SplitCellName(rowData.C[colIdx].R)
-			if colName == col {
-				rowData.C = append(rowData.C[:colIdx], rowData.C[colIdx+1:]...)[:len(rowData.C)-1]
-				break
+			if cellCol, _, _ := CellNameToCoordinates(rowData.C[colIdx].R); !inRuns(runs, cellCol) {
+				rowData.C[keep] = rowData.C[colIdx]
+				keep++
 			}
 		}
+		rowData.C = rowData.C[:keep]
+	}
+	for i := len(runs) - 1; i >= 0; i-- {
+		if err = f.adjustHelper(sheet, columns, runs[i][0], -runs[i][1]); err != nil {
+			return err
+		}
 	}
-	return f.adjustHelper(sheet, columns, num, -1)
+	return err
 }
 
 // convertColWidthToPixels provides function to convert the width of a cell
@@ -116,6 +116,73 @@ func TestColumnsIterator(t *testing.T) {
 	assert.Equal(t, expectedNumCol, colCount)
 }
 
+func TestColsSeek(t *testing.T) {
+	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
+	require.NoError(t, err)
+	expected, err := f.GetCols("Sheet2")
+	require.NoError(t, err)
+	cols, err := f.Cols("Sheet2")
+	require.NoError(t, err)
+	for _, col := range []int{5, 2, 8, 1} {
+		assert.NoError(t, cols.Seek(col))
+		assert.True(t, cols.Next())
+		assert.Equal(t, col, cols.CurrentCol())
+		rows, err := cols.Rows()
+		assert.NoError(t, err)
+		assert.Equal(t, expected[col-1], rows)
+		assert.True(t, cols.Next())
+		rows, err = cols.Rows()
+		assert.NoError(t, err)
+		assert.Equal(t, expected[col], rows)
+	}
+	// Test seek beyond the last column
+	assert.NoError(t, cols.Seek(len(expected)+1))
+	assert.False(t, cols.Next())
+	// Test seek with invalid column number
+	assert.Equal(t, ErrColumnNumber, cols.Seek(0))
+	assert.Equal(t, ErrColumnNumber, cols.Seek(MaxColumns+1))
+	assert.NoError(t, f.Close())
+}
+
+func TestReverseCols(t *testing.T) {
+	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
+	require.NoError(t, err)
+	expected, err := f.GetCols("Sheet2")
+	require.NoError(t, err)
+	cols, err := f.ReverseCols("Sheet2")
+	require.NoError(t, err)
+	results := make([][]string, len(expected))
+	for cols.Next() {
+		rows, err := cols.Rows()
+		assert.NoError(t, err)
+		results[cols.CurrentCol()-1] = rows
+	}
+	assert.NoError(t, cols.Error())
+	assert.Equal(t, expected, results)
+	// Test seek the reverse columns iterator
+	for _, col := range []int{3, MaxColumns} {
+		assert.NoError(t, cols.Seek(col))
+		if col > len(expected) {
+			col = len(expected)
+		}
+		for ; col > 0; col-- {
+			assert.True(t, cols.Next())
+			assert.Equal(t, col, cols.CurrentCol())
+			rows, err := cols.Rows()
+			assert.NoError(t, err)
+			assert.Equal(t, expected[col-1], rows)
+		}
+		assert.False(t, cols.Next())
+	}
+	assert.NoError(t, f.Close())
+
+	// Test get reverse columns with invalid sheet name
+	f = NewFile()
+	_, err = f.ReverseCols("Sheet:1")
+	assert.Equal(t, ErrSheetNameInvalid, err)
+	assert.NoError(t, f.Close())
+}
+
 func TestColsError(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	if !assert.NoError(t, err) {
@@ -483,6 +550,98 @@ func TestRemoveCol(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRemoveCol.xlsx")))
 }
 
+func TestGetHiddenCols(t *testing.T) {
+	f := NewFile()
+	cols, err := f.GetHiddenCols("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, cols)
+	assert.NoError(t, f.SetColVisible("Sheet1", "B:D", false))
+	assert.NoError(t, f.SetColVisible("Sheet1", "F", false))
+	assert.NoError(t, f.SetColVisible("Sheet1", "C", true))
+	cols, err = f.GetHiddenCols("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"B", "D", "F"}, cols)
+	// Test get hidden columns on not exist worksheet
+	_, err = f.GetHiddenCols("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get hidden columns with invalid column number
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).Cols.Col = []xlsxCol{{Min: MaxColumns + 1, Max: MaxColumns + 1, Hidden: true}}
+	_, err = f.GetHiddenCols("Sheet1")
+	assert.Equal(t, ErrColumnNumber, err)
+}
+
+func TestRemoveCols(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, fillCells(f, "Sheet1", 10, 5))
+	assert.NoError(t, f.SetColWidth("Sheet1", "B", "D", 20))
+	assert.NoError(t, f.SetColWidth("Sheet1", "G", "H", 30))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A6", "SUM(G1:J1)"))
+	assert.NoError(t, f.AutoFilter("Sheet1", "E1:J5", nil))
+	// Test remove columns B to D in one pass
+	assert.NoError(t, f.RemoveCols("Sheet1", "B", 3))
+	cols, err := f.GetCols("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, cols, 7)
+	assert.Equal(t, "A1", cols[0][0])
+	assert.Equal(t, "E1", cols[1][0])
+	formula, err := f.GetCellFormula("Sheet1", "A6")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(D1:G1)", formula)
+	width, err := f.GetColWidth("Sheet1", "B")
+	assert.NoError(t, err)
+	assert.Equal(t, defaultColWidth, width)
+	width, err = f.GetColWidth("Sheet1", "D")
+	assert.NoError(t, err)
+	assert.Equal(t, 30.0, width)
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, "B1:G5", ws.(*xlsxWorksheet).AutoFilter.Ref)
+	// Test remove columns with invalid arguments
+	assert.Equal(t, ErrParameterInvalid, f.RemoveCols("Sheet1", "A", 0))
+	assert.Equal(t, ErrColumnNumber, f.RemoveCols("Sheet1", "A", MaxColumns+1))
+	assert.Equal(t, newInvalidColumnNameError("*"), f.RemoveCols("Sheet1", "*", 1))
+	assert.EqualError(t, f.RemoveCols("SheetN", "A", 1), "sheet SheetN does not exist")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRemoveCols.xlsx")))
+}
+
+func TestRemoveColSet(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, fillCells(f, "Sheet1", 10, 5))
+	assert.NoError(t, f.SetColWidth("Sheet1", "F", "F", 30))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A6", "SUM(G1:J1)"))
+	assert.NoError(t, f.MergeCell("Sheet1", "C2", "E3"))
+	// Test remove the non-contiguous columns B, E and F
+	assert.NoError(t, f.RemoveColSet("Sheet1", []string{"F", "B", "e", "E"}))
+	cols, err := f.GetCols("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, cols, 7)
+	var heads []string
+	for _, col := range cols {
+		heads = append(heads, col[0])
+	}
+	assert.Equal(t, []string{"A1", "C1", "D1", "G1", "H1", "I1", "J1"}, heads)
+	formula, err := f.GetCellFormula("Sheet1", "A6")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(D1:G1)", formula)
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	assert.Equal(t, "B2:C3", mergeCells[0][0])
+	width, err := f.GetColWidth("Sheet1", "D")
+	assert.NoError(t, err)
+	assert.Equal(t, defaultColWidth, width)
+	// Test remove columns set with invalid arguments
+	assert.Equal(t, ErrParameterInvalid, f.RemoveColSet("Sheet1", nil))
+	assert.Equal(t, newInvalidColumnNameError("*"), f.RemoveColSet("Sheet1", []string{"A", "*"}))
+	cols, err = f.GetCols("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, cols, 7)
+	assert.EqualError(t, f.RemoveColSet("SheetN", []string{"A"}), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
 func TestConvertColWidthToPixels(t *testing.T) {
 	assert.Equal(t, -11.0, convertColWidthToPixels(-1))
 }
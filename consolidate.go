@@ -0,0 +1,181 @@
+// Copyright 2016 - 2024 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.18 or later.
+
+package excelize
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// AggregateFunc is the type of the function used to aggregate the values in
+// the consolidation.
+type AggregateFunc byte
+
+// Aggregate functions enumeration.
+const (
+	AggregateSum AggregateFunc = iota
+	AggregateCount
+	AggregateAverage
+	AggregateMax
+	AggregateMin
+	AggregateProduct
+	AggregateStdDev
+	AggregateStdDevP
+	AggregateVar
+	AggregateVarP
+)
+
+// Consolidate provides a function to consolidate the identical ranges across
+// the workbooks by given workbooks, worksheet name, range reference and
+// aggregate function, like the consolidate feature of the spreadsheet
+// application, and returns a new workbook which contains the consolidated
+// worksheet with the same name. The numeric values of the matching cells in
+// the workbooks will be aggregated, the cached values of the formula cells
+// will be used, and the text values will be kept as the labels of the
+// consolidated range if the matching cells have no numeric values. The
+// AggregateCount function counts the numeric values. The cell will be empty
+// if the sample standard deviation or variance can't be calculated with less
+// than two numeric values. For example, sum the range A1:D10 of Sheet1 in the
+// submissions of the entities:
+//
+//	result, err := excelize.Consolidate([]*excelize.File{f1, f2, f3}, "Sheet1", "A1:D10", excelize.AggregateSum)
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err := result.SaveAs("Summary.xlsx"); err != nil {
+//	    fmt.Println(err)
+//	}
+func Consolidate(files []*File, sheet, ref string, fn AggregateFunc) (*File, error) {
+	if len(files) == 0 {
+		return nil, ErrParameterRequired
+	}
+	if fn > AggregateVarP {
+		return nil, ErrParameterInvalid
+	}
+	if !strings.Contains(ref, ":") {
+		ref += ":" + ref
+	}
+	coordinates, err := rangeRefToCoordinates(ref)
+	if err != nil {
+		return nil, err
+	}
+	_ = sortCoordinates(coordinates)
+	if err = checkSheetName(sheet); err != nil {
+		return nil, err
+	}
+	result := NewFile()
+	if sheet != "Sheet1" {
+		if err = result.SetSheetName("Sheet1", sheet); err != nil {
+			return nil, err
+		}
+	}
+	for row := coordinates[1]; row <= coordinates[3]; row++ {
+		for col := coordinates[0]; col <= coordinates[2]; col++ {
+			cell, _ := CoordinatesToCellName(col, row)
+			values, label, err := getConsolidateValues(files, sheet, cell)
+			if err != nil {
+				return nil, err
+			}
+			if len(values) == 0 {
+				if label != "" {
+					err = result.SetCellStr(sheet, cell, label)
+				}
+			} else if val, ok := aggregate(fn, values); ok {
+				err = result.SetCellFloat(sheet, cell, val, -1, 64)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return result, err
+}
+
+// getConsolidateValues provides a function to get the numeric values and the
+// first text value of the cells in the workbooks by given workbooks,
+// worksheet name and cell reference.
+func getConsolidateValues(files []*File, sheet, cell string) ([]float64, string, error) {
+	var (
+		values []float64
+		label  string
+	)
+	for _, f := range files {
+		if f == nil {
+			return values, label, ErrParameterInvalid
+		}
+		cellType, err := f.GetCellType(sheet, cell)
+		if err != nil {
+			return values, label, err
+		}
+		val, err := f.GetCellValue(sheet, cell, Options{RawCellValue: true})
+		if err != nil {
+			return values, label, err
+		}
+		switch cellType {
+		case CellTypeUnset, CellTypeNumber:
+			if num, err := strconv.ParseFloat(val, 64); err == nil {
+				values = append(values, num)
+			}
+		case CellTypeFormula, CellTypeInlineString, CellTypeSharedString:
+			if label == "" {
+				label = val
+			}
+		}
+	}
+	return values, label, nil
+}
+
+// aggregate provides a function to aggregate the numeric values by given
+// aggregate function, and returns false if the result can't be calculated.
+func aggregate(fn AggregateFunc, values []float64) (float64, bool) {
+	var sum float64
+	product, minimum, maximum := 1.0, values[0], values[0]
+	for _, val := range values {
+		sum += val
+		product *= val
+		minimum, maximum = math.Min(minimum, val), math.Max(maximum, val)
+	}
+	n := float64(len(values))
+	variance := func(sample bool) (float64, bool) {
+		if sample && n < 2 {
+			return 0, false
+		}
+		var squares float64
+		for _, val := range values {
+			squares += (val - sum/n) * (val - sum/n)
+		}
+		if sample {
+			return squares / (n - 1), true
+		}
+		return squares / n, true
+	}
+	switch fn {
+	case AggregateCount:
+		return n, true
+	case AggregateAverage:
+		return sum / n, true
+	case AggregateMax:
+		return maximum, true
+	case AggregateMin:
+		return minimum, true
+	case AggregateProduct:
+		return product, true
+	case AggregateStdDev, AggregateStdDevP:
+		val, ok := variance(fn == AggregateStdDev)
+		return math.Sqrt(val), ok
+	case AggregateVar, AggregateVarP:
+		return variance(fn == AggregateVar)
+	}
+	return sum, true
+}
@@ -0,0 +1,72 @@
+package excelize
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsolidate(t *testing.T) {
+	var files []*File
+	for _, row := range [][]interface{}{
+		{"Region", 10, 2.5, true},
+		{"Region", 20, nil, "N/A"},
+		{"Region", 60, 7.5, nil},
+	} {
+		f := NewFile()
+		assert.NoError(t, f.SetSheetName("Sheet1", "Summary"))
+		assert.NoError(t, f.SetSheetRow("Summary", "A1", &row))
+		files = append(files, f)
+	}
+	assert.NoError(t, files[2].SetCellFormula("Summary", "B1", "=30*2"))
+	ws, ok := files[2].Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[1].T, ws.(*xlsxWorksheet).SheetData.Row[0].C[1].V = "", "60"
+	for fn, expected := range map[AggregateFunc][]string{
+		AggregateSum:     {"Region", "90", "10"},
+		AggregateCount:   {"Region", "3", "2"},
+		AggregateAverage: {"Region", "30", "5"},
+		AggregateMax:     {"Region", "60", "7.5"},
+		AggregateMin:     {"Region", "10", "2.5"},
+		AggregateProduct: {"Region", "12000", "18.75"},
+		AggregateStdDev:  {"Region", "26.4575131106459", "3.53553390593274"},
+		AggregateStdDevP: {"Region", "21.6024689946929", "2.5"},
+		AggregateVar:     {"Region", "700", "12.5"},
+		AggregateVarP:    {"Region", "466.666666666667", "6.25"},
+	} {
+		result, err := Consolidate(files, "Summary", "A1:D1", fn)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Summary"}, result.GetSheetList())
+		rows, err := result.GetRows("Summary")
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{append(expected, "N/A")}, rows, fn)
+		assert.NoError(t, result.Close())
+	}
+	// Test consolidate single cell with sample standard deviation
+	result, err := Consolidate(files[:1], "Summary", "B1", AggregateStdDev)
+	assert.NoError(t, err)
+	val, err := result.GetCellValue("Summary", "B1")
+	assert.NoError(t, err)
+	assert.Empty(t, val)
+	assert.NoError(t, result.SaveAs(filepath.Join("test", "TestConsolidate.xlsx")))
+	assert.NoError(t, result.Close())
+	// Test consolidate with invalid parameters
+	_, err = Consolidate(nil, "Summary", "A1", AggregateSum)
+	assert.Equal(t, ErrParameterRequired, err)
+	_, err = Consolidate(files, "Summary", "A1", AggregateVarP+1)
+	assert.Equal(t, ErrParameterInvalid, err)
+	_, err = Consolidate(files, "Summary", "A1:B", AggregateSum)
+	assert.EqualError(t, err, newCellNameToCoordinatesError("B", newInvalidCellNameError("B")).Error())
+	_, err = Consolidate(files, "Sheet:1", "A1", AggregateSum)
+	assert.Equal(t, ErrSheetNameInvalid, err)
+	_, err = Consolidate([]*File{nil}, "Summary", "A1", AggregateSum)
+	assert.Equal(t, ErrParameterInvalid, err)
+	_, err = Consolidate(files, "SheetN", "A1", AggregateSum)
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	_, err = Consolidate(files, "Summary", "XFE1", AggregateSum)
+	assert.Equal(t, ErrColumnNumber, err)
+	for _, f := range files {
+		assert.NoError(t, f.Close())
+	}
+}
@@ -253,7 +253,15 @@ func (dv *DataValidation) SetSqref(sqref string) {
 //	dv.Sqref = "A5:B6"
 //	dv.SetDropList([]string{"1", "2", "3"})
 //	err = f.AddDataValidation("Sheet1", dv)
-func (f *File) AddDataValidation(sheet string, dv *DataValidation) error {
+//
+// Set the ErrorOnOverlap field of the optional options to true for returning
+// the ErrRangeOverlap error if the range of the data validation overlaps with
+// the existing data validations, for example:
+//
+//	err = f.AddDataValidation("Sheet1", dv, excelize.RangeOverlapOptions{
+//	    ErrorOnOverlap: true,
+//	})
+func (f *File) AddDataValidation(sheet string, dv *DataValidation, opts ...RangeOverlapOptions) error {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
@@ -263,6 +271,17 @@ func (f *File) AddDataValidation(sheet string, dv *DataValidation) error {
 	if nil == ws.DataValidations {
 		ws.DataValidations = new(xlsxDataValidations)
 	}
+	if getRangeOverlapOptions(opts).ErrorOnOverlap {
+		var sqrefs []string
+		for _, dataValidation := range ws.DataValidations.DataValidation {
+			if dataValidation != nil {
+				sqrefs = append(sqrefs, dataValidation.Sqref)
+			}
+		}
+		if err = checkSqrefOverlap(sqrefs, dv.Sqref); err != nil {
+			return err
+		}
+	}
 	dataValidation := &xlsxDataValidation{
 		AllowBlank:       dv.AllowBlank,
 		Error:            dv.Error,
@@ -288,6 +307,32 @@ func (f *File) AddDataValidation(sheet string, dv *DataValidation) error {
 	return err
 }
 
+// SetCellInputMessage provides a function to set the input message shown when
+// the cells in the given range are selected, without imposing any validation
+// constraint on the cell value, by given worksheet name, range reference,
+// title and message of the prompt. The title is limited to 32 characters and
+// the message is limited to 255 characters. For example, show the input
+// message on selecting the cells Sheet1!B2:B10:
+//
+//	err := f.SetCellInputMessage("Sheet1", "B2:B10", "Quantity", "Enter the quantity in boxes")
+//
+// The prompt is stored as a data validation with "none" type, so the
+// ErrRangeOverlap error will be returned if the range overlaps with an
+// existing data validation, set the input message by the SetInput function of
+// the existing data validation in this case.
+func (f *File) SetCellInputMessage(sheet, sqref, title, msg string) error {
+	if sqref == "" {
+		return ErrParameterRequired
+	}
+	if len(utf16.Encode([]rune(title))) > 32 || len(utf16.Encode([]rune(msg))) > MaxFieldLength {
+		return ErrParameterInvalid
+	}
+	dv := NewDataValidation(true)
+	dv.Sqref, dv.Type = sqref, dataValidationTypeMap[DataValidationTypeNone]
+	dv.SetInput(title, msg)
+	return f.AddDataValidation(sheet, dv, RangeOverlapOptions{ErrorOnOverlap: true})
+}
+
 // GetDataValidations returns data validations list by given worksheet name.
 func (f *File) GetDataValidations(sheet string) ([]*DataValidation, error) {
 	ws, err := f.workSheetReader(sheet)
@@ -442,6 +487,51 @@ func squashSqref(cells [][]int) []string {
 	return append(refs, ref)
 }
 
+// sqrefToRects provides a function to convert the sequence of references to
+// the sorted range coordinates, the invalid references will be ignored.
+func sqrefToRects(sqref string) [][]int {
+	var rects [][]int
+	for _, ref := range strings.Fields(sqref) {
+		if !strings.Contains(ref, ":") {
+			ref += ":" + ref
+		}
+		if rect, err := rangeRefToCoordinates(ref); err == nil {
+			_ = sortCoordinates(rect)
+			rects = append(rects, rect)
+		}
+	}
+	return rects
+}
+
+// getRangeOverlapOptions provides a function to get the settings of checking
+// the overlapped range by given optional options.
+func getRangeOverlapOptions(opts []RangeOverlapOptions) RangeOverlapOptions {
+	var options RangeOverlapOptions
+	for _, opt := range opts {
+		options = opt
+	}
+	return options
+}
+
+// checkSqrefOverlap provides a function to check if the sequence of
+// references overlaps with the given sequences of references of the existing
+// ranges.
+func checkSqrefOverlap(existing []string, sqref string) error {
+	rects := sqrefToRects(sqref)
+	for _, existingSqref := range existing {
+		for _, rect := range sqrefToRects(existingSqref) {
+			for _, r := range rects {
+				if isOverlap(r, rect) {
+					ref, _ := coordinatesToRangeRef(r)
+					overlapRef, _ := coordinatesToRangeRef(rect)
+					return ErrRangeOverlap{Ref: ref, OverlapRef: overlapRef}
+				}
+			}
+		}
+	}
+	return nil
+}
+
 // isFormulaDataValidation returns whether the data validation rule is a formula.
 func (dv *xlsxInnerXML) isFormula() bool {
 	return dv != nil && !(strings.HasPrefix(dv.Content, "&quot;") && strings.HasSuffix(dv.Content, "&quot;"))
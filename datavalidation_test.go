@@ -175,7 +175,13 @@ func TestDataValidationError(t *testing.T) {
 			"Formula1 should be unchanged for invalid input %v", keys)
 		assert.EqualError(t, err, ErrDataValidationFormulaLength.Error())
 	}
-	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	// Test add data validation which overlaps with the existing data validation
+	overlapOpts := RangeOverlapOptions{ErrorOnOverlap: true}
+	assert.Equal(t, ErrRangeOverlap{Ref: "A9:B10", OverlapRef: "A9:B10"}, f.AddDataValidation("Sheet1", dv, overlapOpts))
+	dv.Sqref = "B10:C11 D1"
+	assert.Equal(t, ErrRangeOverlap{Ref: "B10:C11", OverlapRef: "A9:B10"}, f.AddDataValidation("Sheet1", dv, overlapOpts))
+	dv.Sqref = "C11:D12"
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv, overlapOpts))
 	assert.NoError(t, dv.SetRange(
 		-math.MaxFloat32, math.MaxFloat32,
 		DataValidationTypeWhole, DataValidationOperatorGreaterThan))
@@ -243,3 +249,27 @@ func TestDeleteDataValidation(t *testing.T) {
 	assert.NoError(t, f.DeleteDataValidation("Sheet1"))
 	assert.Nil(t, ws.(*xlsxWorksheet).DataValidations)
 }
+
+func TestSetCellInputMessage(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellInputMessage("Sheet1", "B2:B10", "Quantity", "Enter the quantity in boxes"))
+	dvs, err := f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 1)
+	assert.Equal(t, "none", dvs[0].Type)
+	assert.Equal(t, "B2:B10", dvs[0].Sqref)
+	assert.True(t, dvs[0].ShowInputMessage)
+	assert.False(t, dvs[0].ShowErrorMessage)
+	assert.Equal(t, "Quantity", *dvs[0].PromptTitle)
+	assert.Equal(t, "Enter the quantity in boxes", *dvs[0].Prompt)
+	assert.Empty(t, dvs[0].Formula1)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetCellInputMessage.xlsx")))
+	// Test set input message on the range overlaps with existing data validation
+	assert.Equal(t, ErrRangeOverlap{Ref: "B5:B5", OverlapRef: "B2:B10"}, f.SetCellInputMessage("Sheet1", "B5", "", ""))
+	// Test set input message with invalid parameters
+	assert.Equal(t, ErrParameterRequired, f.SetCellInputMessage("Sheet1", "", "", ""))
+	assert.Equal(t, ErrParameterInvalid, f.SetCellInputMessage("Sheet1", "C1", strings.Repeat("c", 33), ""))
+	assert.Equal(t, ErrParameterInvalid, f.SetCellInputMessage("Sheet1", "C1", "", strings.Repeat("c", MaxFieldLength+1)))
+	// Test set input message on not exists worksheet
+	assert.EqualError(t, f.SetCellInputMessage("SheetN", "C1", "", ""), "sheet SheetN does not exist")
+}
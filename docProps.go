@@ -16,8 +16,15 @@ import (
 	"encoding/xml"
 	"io"
 	"reflect"
+	"regexp"
+	"strings"
+	"time"
 )
 
+// externalReferenceFormat defined the regular expression for matching the
+// formula which references to an external workbook or path.
+var externalReferenceFormat = regexp.MustCompile(`\[\d+\]|[A-Za-z]:\\|\\\\|://`)
+
 // SetAppProps provides a function to set document application properties. The
 // properties that can be set are:
 //
@@ -39,10 +46,18 @@ import (
 //	                   |
 //	 Company           | The name of a company associated with the document.
 //	                   |
+//	 Manager           | The name of a supervisor associated with the document.
+//	                   |
 //	 LinksUpToDate     | Indicates whether hyperlinks in a document are up-to-date. Set this
 //	                   | element to 'true' to indicate that hyperlinks are updated. Set this
 //	                   | element to 'false' to indicate that hyperlinks are outdated.
 //	                   |
+//	 SharedDoc         | Indicates if this document is currently shared between multiple
+//	                   | producers.
+//	                   |
+//	 HyperlinkBase     | The base string used for evaluating relative hyperlinks in this
+//	                   | document.
+//	                   |
 //	 HyperlinksChanged | Specifies that one or more hyperlinks in this part were updated
 //	                   | exclusively in this part by a producer. The next producer to open this
 //	                   | document shall update the hyperlink relationships with the new
@@ -60,7 +75,10 @@ import (
 //	    ScaleCrop:         true,
 //	    DocSecurity:       3,
 //	    Company:           "Company Name",
+//	    Manager:           "Manager Name",
 //	    LinksUpToDate:     true,
+//	    SharedDoc:         false,
+//	    HyperlinkBase:     "https://github.com/xuri/excelize",
 //	    HyperlinksChanged: true,
 //	    AppVersion:        "16.0000",
 //	})
@@ -78,7 +96,10 @@ func (f *File) SetAppProps(appProperties *AppProperties) error {
 		Decode(app); err != nil && err != io.EOF {
 		return err
 	}
-	fields = []string{"Application", "ScaleCrop", "DocSecurity", "Company", "LinksUpToDate", "HyperlinksChanged", "AppVersion"}
+	fields = []string{
+		"Application", "ScaleCrop", "DocSecurity", "Company", "Manager", "LinksUpToDate",
+		"SharedDoc", "HyperlinkBase", "HyperlinksChanged", "AppVersion",
+	}
 	immutable, mutable = reflect.ValueOf(*appProperties), reflect.ValueOf(app).Elem()
 	for _, field = range fields {
 		immutableField := immutable.FieldByName(field)
@@ -109,7 +130,10 @@ func (f *File) GetAppProps() (ret *AppProperties, err error) {
 		ScaleCrop:         app.ScaleCrop,
 		DocSecurity:       app.DocSecurity,
 		Company:           app.Company,
+		Manager:           app.Manager,
 		LinksUpToDate:     app.LinksUpToDate,
+		SharedDoc:         app.SharedDoc,
+		HyperlinkBase:     app.HyperlinkBase,
 		HyperlinksChanged: app.HyperlinksChanged,
 		AppVersion:        app.AppVersion,
 	}, nil
@@ -181,7 +205,6 @@ func (f *File) GetAppProps() (ret *AppProperties, err error) {
 //	})
 func (f *File) SetDocProps(docProperties *DocProperties) error {
 	var (
-		core               *decodeCoreProperties
 		err                error
 		field, val         string
 		fields             []string
@@ -189,13 +212,144 @@ func (f *File) SetDocProps(docProperties *DocProperties) error {
 		newProps           *xlsxCoreProperties
 		output             []byte
 	)
+	if newProps, err = f.docPropsCoreReader(); err != nil {
+		return err
+	}
+	fields = []string{
+		"Category", "ContentStatus", "Creator", "Description", "Identifier", "Keywords",
+		"LastModifiedBy", "Revision", "Subject", "Title", "Language", "Version",
+	}
+	immutable, mutable = reflect.ValueOf(*docProperties), reflect.ValueOf(newProps).Elem()
+	for _, field = range fields {
+		if val = immutable.FieldByName(field).String(); val != "" {
+			mutable.FieldByName(field).SetString(val)
+		}
+	}
+	if docProperties.Created != "" {
+		newProps.Created = &xlsxDcTerms{Type: "dcterms:W3CDTF", Text: docProperties.Created}
+	}
+	if docProperties.Modified != "" {
+		newProps.Modified = &xlsxDcTerms{Type: "dcterms:W3CDTF", Text: docProperties.Modified}
+	}
+	output, err = xml.Marshal(newProps)
+	f.saveFileList(defaultXMLPathDocPropsCore, output)
 
-	core = new(decodeCoreProperties)
-	if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(defaultXMLPathDocPropsCore)))).
+	return err
+}
+
+// docPropsWriter provides a function to update the document properties on
+// saving the workbook. The heading pairs and titles of parts in the document
+// application properties will be updated with the current worksheets, chart
+// sheets and defined names if which exists, and the modified time of the
+// document core properties will be updated if the UpdateModifiedTime option
+// was enabled. The document application properties will be kept as is if the
+// heading pairs and titles of parts are not changed.
+func (f *File) docPropsWriter() {
+	if f.options != nil && f.options.UpdateModifiedTime {
+		if core, err := f.docPropsCoreReader(); err == nil {
+			core.Modified = &xlsxDcTerms{Type: "dcterms:W3CDTF", Text: time.Now().UTC().Format("2006-01-02T15:04:05Z")}
+			output, _ := xml.Marshal(core)
+			f.saveFileList(defaultXMLPathDocPropsCore, output)
+		}
+	}
+	app := new(xlsxProperties)
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(defaultXMLPathDocPropsApp)))).
+		Decode(app); (err != nil && err != io.EOF) || (app.HeadingPairs == nil && app.TitlesOfParts == nil) {
+		return
+	}
+	headingPairs, titlesOfParts := f.getAppPropsParts()
+	if app.HeadingPairs != nil && app.TitlesOfParts != nil &&
+		f.equalAppPropsVector(app.HeadingPairs.Content, headingPairs) &&
+		f.equalAppPropsVector(app.TitlesOfParts.Content, titlesOfParts) {
+		return
+	}
+	output, _ := xml.Marshal(headingPairs)
+	app.HeadingPairs = &xlsxVectorVariant{Content: string(output)}
+	output, _ = xml.Marshal(titlesOfParts)
+	app.TitlesOfParts = &xlsxVectorLpstr{Content: string(output)}
+	app.Vt = NameSpaceDocumentPropertiesVariantTypes.Value
+	output, _ = xml.Marshal(app)
+	f.saveFileList(defaultXMLPathDocPropsApp, output)
+}
+
+// equalAppPropsVector provides a function to check if the vector in the
+// document application properties is equal to the given vector.
+func (f *File) equalAppPropsVector(content string, vector xlsxVector) bool {
+	var decoded decodeVector
+	if err := f.xmlNewDecoder(strings.NewReader(content)).Decode(&decoded); err != nil {
+		return false
+	}
+	if decoded.Size != vector.Size || decoded.BaseType != vector.BaseType ||
+		len(decoded.Variant) != len(vector.Variant) || len(decoded.Lpstr) != len(vector.Lpstr) {
+		return false
+	}
+	for i, variant := range decoded.Variant {
+		if variant.Lpstr != vector.Variant[i].Lpstr || variant.I4 != vector.Variant[i].I4 {
+			return false
+		}
+	}
+	for i, lpstr := range decoded.Lpstr {
+		if lpstr != vector.Lpstr[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// getAppPropsParts provides a function to get the heading pairs and titles of
+// parts of the document application properties by the worksheets, chart
+// sheets and defined names in the workbook.
+func (f *File) getAppPropsParts() (xlsxVector, xlsxVector) {
+	headingPairs := xlsxVector{BaseType: "variant"}
+	titlesOfParts := xlsxVector{BaseType: "lpstr"}
+	var worksheets, chartsheets, definedNames []string
+	sheets := f.GetSheetList()
+	for _, sheet := range sheets {
+		if sheetXMLPath, _ := f.getSheetXMLPath(sheet); strings.HasPrefix(sheetXMLPath, "xl/chartsheets/") {
+			chartsheets = append(chartsheets, sheet)
+			continue
+		}
+		worksheets = append(worksheets, sheet)
+	}
+	if wb, _ := f.workbookReader(); wb != nil && wb.DefinedNames != nil {
+		for _, dn := range wb.DefinedNames.DefinedName {
+			if dn.Hidden {
+				continue
+			}
+			if dn.LocalSheetID != nil && *dn.LocalSheetID >= 0 && *dn.LocalSheetID < len(sheets) {
+				definedNames = append(definedNames, sheets[*dn.LocalSheetID]+"!"+dn.Name)
+				continue
+			}
+			definedNames = append(definedNames, dn.Name)
+		}
+	}
+	for _, parts := range []struct {
+		name   string
+		titles []string
+	}{
+		{name: "Worksheets", titles: worksheets},
+		{name: "Charts", titles: chartsheets},
+		{name: "Named Ranges", titles: definedNames},
+	} {
+		if len(parts.titles) == 0 {
+			continue
+		}
+		headingPairs.Variant = append(headingPairs.Variant, xlsxVariant{Lpstr: parts.name}, xlsxVariant{I4: len(parts.titles)})
+		titlesOfParts.Lpstr = append(titlesOfParts.Lpstr, parts.titles...)
+	}
+	headingPairs.Size, titlesOfParts.Size = len(headingPairs.Variant), len(titlesOfParts.Lpstr)
+	return headingPairs, titlesOfParts
+}
+
+// docPropsCoreReader provides a function to get the structure of the document
+// core properties for serialization.
+func (f *File) docPropsCoreReader() (*xlsxCoreProperties, error) {
+	core := new(decodeCoreProperties)
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(defaultXMLPathDocPropsCore)))).
 		Decode(core); err != nil && err != io.EOF {
-		return err
+		return nil, err
 	}
-	newProps = &xlsxCoreProperties{
+	props := &xlsxCoreProperties{
 		Dc:             NameSpaceDublinCore,
 		Dcterms:        NameSpaceDublinCoreTerms,
 		Dcmitype:       NameSpaceDublinCoreMetadataInitiative,
@@ -214,31 +368,12 @@ func (f *File) SetDocProps(docProperties *DocProperties) error {
 		Version:        core.Version,
 	}
 	if core.Created != nil {
-		newProps.Created = &xlsxDcTerms{Type: core.Created.Type, Text: core.Created.Text}
+		props.Created = &xlsxDcTerms{Type: core.Created.Type, Text: core.Created.Text}
 	}
 	if core.Modified != nil {
-		newProps.Modified = &xlsxDcTerms{Type: core.Modified.Type, Text: core.Modified.Text}
-	}
-	fields = []string{
-		"Category", "ContentStatus", "Creator", "Description", "Identifier", "Keywords",
-		"LastModifiedBy", "Revision", "Subject", "Title", "Language", "Version",
-	}
-	immutable, mutable = reflect.ValueOf(*docProperties), reflect.ValueOf(newProps).Elem()
-	for _, field = range fields {
-		if val = immutable.FieldByName(field).String(); val != "" {
-			mutable.FieldByName(field).SetString(val)
-		}
-	}
-	if docProperties.Created != "" {
-		newProps.Created = &xlsxDcTerms{Type: "dcterms:W3CDTF", Text: docProperties.Created}
-	}
-	if docProperties.Modified != "" {
-		newProps.Modified = &xlsxDcTerms{Type: "dcterms:W3CDTF", Text: docProperties.Modified}
+		props.Modified = &xlsxDcTerms{Type: core.Modified.Type, Text: core.Modified.Text}
 	}
-	output, err = xml.Marshal(newProps)
-	f.saveFileList(defaultXMLPathDocPropsCore, output)
-
-	return err
+	return props, nil
 }
 
 // GetDocProps provides a function to get document core properties.
@@ -271,3 +406,258 @@ func (f *File) GetDocProps() (ret *DocProperties, err error) {
 	}
 	return
 }
+
+// RemoveHiddenInformation provides a function to remove the hidden and
+// personal information from the workbook like the document inspector of the
+// spreadsheet application. The optional settings specify the categories of
+// the information to be removed, all categories will be removed if the
+// settings are nil. The categories that can be removed are:
+//
+//	 Category             | Description
+//	----------------------+------------------------------------------------------
+//	 DocumentProperties   | The author, last modified by, company and manager of
+//	                      | the document properties.
+//	                      |
+//	 Comments             | All comments in the worksheets and the comment authors.
+//	                      |
+//	 HiddenSheets         | The hidden and very hidden worksheets.
+//	                      |
+//	 HiddenRowsAndColumns | The hidden rows and columns, which contain data, will
+//	                      | be deleted and the hidden rows and columns without
+//	                      | data will be unhidden.
+//	                      |
+//	 ExternalDefinedNames | The defined names which reference to external
+//	                      | workbook or path.
+//	                      |
+//	 PrinterSettings      | The printer settings binary parts of the worksheets.
+//
+// Use this method with caution, deleting hidden worksheets, rows and columns
+// will affect changes in references such as formulas, charts, and so on. For
+// example, remove the comments and the hidden worksheets:
+//
+//	err := f.RemoveHiddenInformation(&excelize.InspectorOptions{
+//	    Comments:     true,
+//	    HiddenSheets: true,
+//	})
+func (f *File) RemoveHiddenInformation(opts *InspectorOptions) error {
+	if opts == nil {
+		opts = &InspectorOptions{
+			DocumentProperties: true, Comments: true, HiddenSheets: true,
+			HiddenRowsAndColumns: true, ExternalDefinedNames: true, PrinterSettings: true,
+		}
+	}
+	if opts.DocumentProperties {
+		if err := f.removePersonalDocProps(); err != nil {
+			return err
+		}
+	}
+	if opts.HiddenSheets {
+		for _, sheet := range f.GetSheetList() {
+			if visible, _ := f.GetSheetVisible(sheet); !visible {
+				if err := f.DeleteSheet(sheet); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if opts.ExternalDefinedNames {
+		if err := f.removeExternalDefinedNames(); err != nil {
+			return err
+		}
+	}
+	for _, sheet := range f.GetSheetList() {
+		if opts.Comments {
+			if err := f.removeComments(sheet); err != nil {
+				return err
+			}
+		}
+		if opts.HiddenRowsAndColumns {
+			if err := f.removeHiddenRowsAndCols(sheet); err != nil {
+				return err
+			}
+		}
+		if opts.PrinterSettings {
+			if err := f.removePrinterSettings(sheet); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// removePersonalDocProps provides a function to remove the personal
+// information in the document core and application properties.
+func (f *File) removePersonalDocProps() error {
+	core, err := f.docPropsCoreReader()
+	if err != nil {
+		return err
+	}
+	core.Creator, core.LastModifiedBy = "", ""
+	output, _ := xml.Marshal(core)
+	f.saveFileList(defaultXMLPathDocPropsCore, output)
+	app := new(xlsxProperties)
+	if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(defaultXMLPathDocPropsApp)))).
+		Decode(app); err != nil && err != io.EOF {
+		return err
+	}
+	app.Company, app.Manager = "", ""
+	app.Vt = NameSpaceDocumentPropertiesVariantTypes.Value
+	output, _ = xml.Marshal(app)
+	f.saveFileList(defaultXMLPathDocPropsApp, output)
+	return nil
+}
+
+// removeExternalDefinedNames provides a function to remove the defined names
+// which reference to external workbook or path.
+func (f *File) removeExternalDefinedNames() error {
+	wb, err := f.workbookReader()
+	if err != nil || wb.DefinedNames == nil {
+		return err
+	}
+	var definedNames []xlsxDefinedName
+	for _, dn := range wb.DefinedNames.DefinedName {
+		if !externalReferenceFormat.MatchString(dn.Data) {
+			definedNames = append(definedNames, dn)
+		}
+	}
+	if wb.DefinedNames.DefinedName = definedNames; len(definedNames) == 0 {
+		wb.DefinedNames = nil
+	}
+	return err
+}
+
+// removeComments provides a function to remove all comments and the comment
+// authors in the worksheet by given worksheet name.
+func (f *File) removeComments(sheet string) error {
+	comments, err := f.GetComments(sheet)
+	if err != nil {
+		return err
+	}
+	for _, comment := range comments {
+		if err = f.DeleteComment(sheet, comment.Cell); err != nil {
+			return err
+		}
+	}
+	for _, cmts := range f.Comments {
+		if cmts != nil && len(cmts.CommentList.Comment) == 0 {
+			cmts.Authors.Author = nil
+		}
+	}
+	return err
+}
+
+// removeHiddenRowsAndCols provides a function to delete the hidden rows and
+// columns which contain data in the worksheet by given worksheet name. The
+// hidden rows and columns without data will be unhidden.
+func (f *File) removeHiddenRowsAndCols(sheet string) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	var hiddenRows, hiddenCols []int
+	dataCols := make(map[int]bool)
+	for rowIdx := range ws.SheetData.Row {
+		row := &ws.SheetData.Row[rowIdx]
+		var hasData bool
+		for _, c := range row.C {
+			if !c.hasData() {
+				continue
+			}
+			if col, _, err := CellNameToCoordinates(c.R); err == nil {
+				dataCols[col], hasData = true, true
+			}
+		}
+		if row.Hidden && hasData {
+			hiddenRows = append(hiddenRows, row.R)
+		}
+		row.Hidden = row.Hidden && hasData
+	}
+	if ws.Cols != nil {
+		for _, col := range ws.Cols.Col {
+			for num := col.Min; col.Hidden && num <= col.Max; num++ {
+				if dataCols[num] {
+					hiddenCols = append(hiddenCols, num)
+				}
+			}
+		}
+	}
+	if err = f.removeRows(sheet, contiguousRuns(hiddenRows)); err != nil {
+		return err
+	}
+	if err = f.removeCols(sheet, contiguousRuns(hiddenCols)); err != nil {
+		return err
+	}
+	if ws.Cols != nil {
+		for i := range ws.Cols.Col {
+			ws.Cols.Col[i].Hidden = false
+		}
+	}
+	return err
+}
+
+// SetWorkbookThumbnail provides a function to set the thumbnail image of the
+// workbook by given JPEG or PNG image data. The thumbnail will be stored in
+// the docProps/thumbnail.jpeg or docProps/thumbnail.png part of the package,
+// file explorers and document libraries use it as the preview image of the
+// workbook. Set the image data with empty to remove the thumbnail. For
+// example, set the thumbnail of the workbook:
+//
+//	img, err := os.ReadFile("preview.jpg")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err := f.SetWorkbookThumbnail(img); err != nil {
+//	    fmt.Println(err)
+//	}
+func (f *File) SetWorkbookThumbnail(img []byte) error {
+	var ext string
+	switch {
+	case len(img) == 0:
+	case bytes.HasPrefix(img, []byte{0xFF, 0xD8, 0xFF}):
+		ext = "jpeg"
+	case bytes.HasPrefix(img, []byte("\x89PNG\r\n\x1a\n")):
+		ext = "png"
+	default:
+		return ErrImgExt
+	}
+	rels, err := f.relsReader("_rels/.rels")
+	if err != nil {
+		return err
+	}
+	if rels != nil {
+		rels.mu.Lock()
+		for i := 0; i < len(rels.Relationships); i++ {
+			if rels.Relationships[i].Type == SourceRelationshipThumbnail {
+				f.Pkg.Delete(strings.TrimPrefix(rels.Relationships[i].Target, "/"))
+				rels.Relationships = append(rels.Relationships[:i], rels.Relationships[i+1:]...)
+				i--
+			}
+		}
+		rels.mu.Unlock()
+	}
+	if ext == "" {
+		return err
+	}
+	if err = f.setContentTypePartImageExtensions(); err != nil {
+		return err
+	}
+	target := "docProps/thumbnail." + ext
+	f.Pkg.Store(target, img)
+	f.addRels("_rels/.rels", SourceRelationshipThumbnail, target, "")
+	return err
+}
+
+// removePrinterSettings provides a function to remove the printer settings
+// binary part of the worksheet by given worksheet name.
+func (f *File) removePrinterSettings(sheet string) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.PageSetUp == nil || ws.PageSetUp.RID == "" {
+		return err
+	}
+	target := f.getPrinterSettingsPath(sheet, ws.PageSetUp.RID)
+	f.Pkg.Delete(target)
+	f.deleteSheetRelationships(sheet, ws.PageSetUp.RID)
+	ws.PageSetUp.RID = ""
+	return f.removeContentTypesPart(ContentTypeSpreadSheetMLPrinterSettings, "/"+target)
+}
@@ -12,8 +12,13 @@
 package excelize
 
 import (
+	"fmt"
+	"os"
 	"path/filepath"
+	"strconv"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -25,15 +30,22 @@ func TestSetAppProps(t *testing.T) {
 	if !assert.NoError(t, err) {
 		t.FailNow()
 	}
-	assert.NoError(t, f.SetAppProps(&AppProperties{
+	expected := &AppProperties{
 		Application:       "Microsoft Excel",
 		ScaleCrop:         true,
 		DocSecurity:       3,
 		Company:           "Company Name",
+		Manager:           "Manager Name",
 		LinksUpToDate:     true,
+		SharedDoc:         true,
+		HyperlinkBase:     "https://github.com/xuri/excelize",
 		HyperlinksChanged: true,
 		AppVersion:        "16.0000",
-	}))
+	}
+	assert.NoError(t, f.SetAppProps(expected))
+	props, err := f.GetAppProps()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, props)
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetAppProps.xlsx")))
 	f.Pkg.Store(defaultXMLPathDocPropsApp, nil)
 	assert.NoError(t, f.SetAppProps(&AppProperties{}))
@@ -45,6 +57,66 @@ func TestSetAppProps(t *testing.T) {
 	assert.EqualError(t, f.SetAppProps(&AppProperties{}), "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestDocPropsWriter(t *testing.T) {
+	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	// Test update heading pairs and titles of parts on saving
+	assert.NoError(t, f.SetSheetName("Sheet2", "Data"))
+	_, err = f.NewSheet("Sheet3")
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Name: "Sheet1!$A$1", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"}},
+	}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Amount", RefersTo: "Sheet1!$A$2:$D$5"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Local", RefersTo: "Data!$A$1", Scope: "Data"}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDocPropsWriter.xlsx")))
+	app, ok := f.Pkg.Load(defaultXMLPathDocPropsApp)
+	assert.True(t, ok)
+	assert.Contains(t, string(app.([]byte)), `<HeadingPairs><vt:vector size="6" baseType="variant"><vt:variant><vt:lpstr>Worksheets</vt:lpstr></vt:variant><vt:variant><vt:i4>3</vt:i4></vt:variant><vt:variant><vt:lpstr>Charts</vt:lpstr></vt:variant><vt:variant><vt:i4>1</vt:i4></vt:variant><vt:variant><vt:lpstr>Named Ranges</vt:lpstr></vt:variant><vt:variant><vt:i4>2</vt:i4></vt:variant></vt:vector></HeadingPairs>`)
+	assert.Contains(t, string(app.([]byte)), `<TitlesOfParts><vt:vector size="6" baseType="lpstr"><vt:lpstr>Sheet1</vt:lpstr><vt:lpstr>Data</vt:lpstr><vt:lpstr>Sheet3</vt:lpstr><vt:lpstr>Chart1</vt:lpstr><vt:lpstr>Amount</vt:lpstr><vt:lpstr>Data!Local</vt:lpstr></vt:vector></TitlesOfParts>`)
+	assert.NoError(t, f.Close())
+
+	// Test the document application properties will be kept as is on saving
+	// without changing the heading pairs and titles of parts
+	f = NewFile()
+	unchanged := []byte(`<Properties xmlns="http://schemas.openxmlformats.org/officeDocument/2006/extended-properties" xmlns:vt="http://schemas.openxmlformats.org/officeDocument/2006/docPropsVTypes"><Template>Normal</Template><HeadingPairs><vt:vector size="2" baseType="variant"><vt:variant><vt:lpstr>Worksheets</vt:lpstr></vt:variant><vt:variant><vt:i4>1</vt:i4></vt:variant></vt:vector></HeadingPairs><TitlesOfParts><vt:vector size="1" baseType="lpstr"><vt:lpstr>Sheet1</vt:lpstr></vt:vector></TitlesOfParts></Properties>`)
+	f.Pkg.Store(defaultXMLPathDocPropsApp, unchanged)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDocPropsWriter.xlsx")))
+	app, ok = f.Pkg.Load(defaultXMLPathDocPropsApp)
+	assert.True(t, ok)
+	assert.Equal(t, unchanged, app.([]byte))
+	// Test update the document application properties after adding a worksheet
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDocPropsWriter.xlsx")))
+	app, ok = f.Pkg.Load(defaultXMLPathDocPropsApp)
+	assert.True(t, ok)
+	assert.Contains(t, string(app.([]byte)), `<TitlesOfParts><vt:vector size="2" baseType="lpstr"><vt:lpstr>Sheet1</vt:lpstr><vt:lpstr>Sheet2</vt:lpstr></vt:vector></TitlesOfParts>`)
+	// Test check the vector with invalid content
+	assert.False(t, f.equalAppPropsVector("<", xlsxVector{}))
+	assert.False(t, f.equalAppPropsVector(`<vt:vector size="1" baseType="lpstr"><vt:lpstr>Sheet</vt:lpstr></vt:vector>`, xlsxVector{Size: 1, BaseType: "lpstr", Lpstr: []string{"Sheet1"}}))
+	assert.False(t, f.equalAppPropsVector(`<vt:vector size="2" baseType="variant"><vt:variant><vt:lpstr>Worksheets</vt:lpstr></vt:variant><vt:variant><vt:i4>2</vt:i4></vt:variant></vt:vector>`, xlsxVector{Size: 2, BaseType: "variant", Variant: []xlsxVariant{{Lpstr: "Worksheets"}, {I4: 1}}}))
+	assert.NoError(t, f.Close())
+
+	// Test the heading pairs and titles of parts will not be added on saving
+	f = NewFile()
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDocPropsWriter.xlsx")))
+	app, ok = f.Pkg.Load(defaultXMLPathDocPropsApp)
+	assert.True(t, ok)
+	assert.NotContains(t, string(app.([]byte)), "HeadingPairs")
+	// Test update the modified time on saving
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDocPropsWriter.xlsx"), Options{UpdateModifiedTime: true}))
+	props, err := f.GetDocProps()
+	assert.NoError(t, err)
+	assert.NotEqual(t, "2006-09-16T00:00:00Z", props.Modified)
+	_, err = time.Parse(time.RFC3339, props.Modified)
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+}
+
 func TestGetAppProps(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	if !assert.NoError(t, err) {
@@ -116,3 +188,165 @@ func TestGetDocProps(t *testing.T) {
 	_, err = f.GetDocProps()
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
+
+func TestRemoveHiddenInformation(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetDocProps(&DocProperties{Creator: "Author", LastModifiedBy: "Author", Title: "Title"}))
+	assert.NoError(t, f.SetAppProps(&AppProperties{Company: "Company", Application: "Microsoft Excel"}))
+	for r, row := range [][]interface{}{{"A1", "B1", "C1"}, {"A2", "B2", "C2"}, {"A3", "B3", "C3"}} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", r+1), &row))
+	}
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Author", Text: "Comment"}))
+	assert.NoError(t, f.SetRowVisible("Sheet1", 2, false))
+	assert.NoError(t, f.SetRowVisible("Sheet1", 5, false))
+	style, err := f.NewStyle(&Style{Fill: Fill{Type: "pattern", Pattern: 1, Color: []string{"FFFF00"}}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "E6", "E6", style))
+	assert.NoError(t, f.SetRowVisible("Sheet1", 6, false))
+	assert.NoError(t, f.SetColVisible("Sheet1", "E", false))
+	assert.NoError(t, f.SetColVisible("Sheet1", "B", false))
+	assert.NoError(t, f.SetColVisible("Sheet1", "F:H", false))
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetVisible("Sheet2", false))
+	for _, dn := range []*DefinedName{
+		{Name: "Local", RefersTo: "Sheet1!$A$1"},
+		{Name: "Table", RefersTo: "Table1[Column]"},
+		{Name: "Link", RefersTo: "[1]Sheet1!$A$1"},
+		{Name: "Path", RefersTo: `'C:\Data\[Book1.xlsx]Sheet1'!$A$1`},
+		{Name: "URL", RefersTo: "'https://example.com/[Book1.xlsx]Sheet1'!$A$1"},
+	} {
+		assert.NoError(t, f.SetDefinedName(dn))
+	}
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	f.Pkg.Store("xl/printerSettings/printerSettings1.bin", []byte{0})
+	rID := f.addRels("xl/worksheets/_rels/sheet1.xml.rels", "http://schemas.openxmlformats.org/officeDocument/2006/relationships/printerSettings", "../printerSettings/printerSettings1.bin", "")
+	ws.PageSetUp = &xlsxPageSetUp{RID: "rId" + strconv.Itoa(rID)}
+
+	assert.NoError(t, f.RemoveHiddenInformation(nil))
+	docProps, err := f.GetDocProps()
+	assert.NoError(t, err)
+	assert.Equal(t, "", docProps.Creator)
+	assert.Equal(t, "", docProps.LastModifiedBy)
+	assert.Equal(t, "Title", docProps.Title)
+	appProps, err := f.GetAppProps()
+	assert.NoError(t, err)
+	assert.Equal(t, "", appProps.Company)
+	assert.Equal(t, "Microsoft Excel", appProps.Application)
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, comments)
+	for _, cmts := range f.Comments {
+		assert.Empty(t, cmts.Authors.Author)
+	}
+	assert.Equal(t, []string{"Sheet1"}, f.GetSheetList())
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"A1", "C1"}, {"A3", "C3"}}, rows)
+	for _, col := range []string{"A", "B", "D", "G"} {
+		visible, err := f.GetColVisible("Sheet1", col)
+		assert.NoError(t, err)
+		assert.True(t, visible)
+	}
+	// Test the hidden rows without data are unhidden instead of deleted
+	for _, row := range []int{4, 5} {
+		visible, err := f.GetRowVisible("Sheet1", row)
+		assert.NoError(t, err)
+		assert.True(t, visible)
+	}
+	styleID, err := f.GetCellStyle("Sheet1", "D5")
+	assert.NoError(t, err)
+	assert.Equal(t, style, styleID)
+	var names []string
+	for _, dn := range f.GetDefinedName() {
+		names = append(names, dn.Name)
+	}
+	assert.Equal(t, []string{"Local", "Table"}, names)
+	_, ok := f.Pkg.Load("xl/printerSettings/printerSettings1.bin")
+	assert.False(t, ok)
+	assert.Empty(t, ws.PageSetUp.RID)
+	assert.Empty(t, f.getSheetRelationshipsTargetByID("Sheet1", "rId"+strconv.Itoa(rID)))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRemoveHiddenInformation.xlsx")))
+
+	// Test remove all external defined names
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Link2", RefersTo: "[2]Sheet1!$A$1"}))
+	assert.NoError(t, f.DeleteDefinedName(&DefinedName{Name: "Local"}))
+	assert.NoError(t, f.DeleteDefinedName(&DefinedName{Name: "Table"}))
+	assert.NoError(t, f.RemoveHiddenInformation(&InspectorOptions{ExternalDefinedNames: true}))
+	assert.Empty(t, f.GetDefinedName())
+	assert.NoError(t, f.Close())
+
+	// Test remove hidden information with unsupported charset
+	for _, c := range []struct {
+		path string
+		opts *InspectorOptions
+	}{
+		{defaultXMLPathDocPropsCore, &InspectorOptions{DocumentProperties: true}},
+		{defaultXMLPathDocPropsApp, &InspectorOptions{DocumentProperties: true}},
+		{defaultXMLPathWorkbook, &InspectorOptions{ExternalDefinedNames: true}},
+		{"xl/comments1.xml", &InspectorOptions{Comments: true}},
+		{"xl/worksheets/sheet1.xml", &InspectorOptions{HiddenRowsAndColumns: true}},
+		{"xl/worksheets/sheet1.xml", &InspectorOptions{PrinterSettings: true}},
+	} {
+		f = NewFile()
+		assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Author", Text: "Comment"}))
+		f.WorkBook, f.Comments = nil, make(map[string]*xlsxComments)
+		f.Sheet.Delete("xl/worksheets/sheet1.xml")
+		f.Pkg.Store(c.path, MacintoshCyrillicCharset)
+		f.checked = sync.Map{}
+		assert.EqualError(t, f.RemoveHiddenInformation(c.opts), "XML syntax error on line 1: invalid UTF-8", c.path)
+	}
+}
+
+func TestSetWorkbookThumbnail(t *testing.T) {
+	f := NewFile()
+	jpeg, err := os.ReadFile(filepath.Join("test", "images", "excel.jpg"))
+	assert.NoError(t, err)
+	png, err := os.ReadFile(filepath.Join("test", "images", "excel.png"))
+	assert.NoError(t, err)
+	getThumbnailRels := func() (targets []string) {
+		rels, err := f.relsReader("_rels/.rels")
+		assert.NoError(t, err)
+		for _, rel := range rels.Relationships {
+			if rel.Type == SourceRelationshipThumbnail {
+				targets = append(targets, rel.Target)
+			}
+		}
+		return
+	}
+	assert.NoError(t, f.SetWorkbookThumbnail(jpeg))
+	assert.Equal(t, []string{"docProps/thumbnail.jpeg"}, getThumbnailRels())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetWorkbookThumbnail.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestSetWorkbookThumbnail.xlsx"))
+	assert.NoError(t, err)
+	data, err := f.GetPart("docProps/thumbnail.jpeg")
+	assert.NoError(t, err)
+	assert.Equal(t, jpeg, data)
+	// Test replace the thumbnail with PNG image
+	assert.NoError(t, f.SetWorkbookThumbnail(png))
+	assert.Equal(t, []string{"docProps/thumbnail.png"}, getThumbnailRels())
+	_, ok := f.Pkg.Load("docProps/thumbnail.jpeg")
+	assert.False(t, ok)
+	// Test remove the thumbnail
+	assert.NoError(t, f.SetWorkbookThumbnail(nil))
+	assert.Empty(t, getThumbnailRels())
+	_, ok = f.Pkg.Load("docProps/thumbnail.png")
+	assert.False(t, ok)
+	// Test set the thumbnail with unsupported image
+	assert.Equal(t, ErrImgExt, f.SetWorkbookThumbnail([]byte("GIF89a")))
+	assert.NoError(t, f.Close())
+
+	// Test set the thumbnail with unsupported charset relationships
+	f = NewFile()
+	f.Relationships.Delete("_rels/.rels")
+	f.Pkg.Store("_rels/.rels", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetWorkbookThumbnail(jpeg), "XML syntax error on line 1: invalid UTF-8")
+	// Test set the thumbnail with unsupported charset content types
+	f = NewFile()
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetWorkbookThumbnail(jpeg), "XML syntax error on line 1: invalid UTF-8")
+}
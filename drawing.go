@@ -16,6 +16,7 @@ import (
 	"encoding/xml"
 	"io"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -55,22 +56,16 @@ func (f *File) prepareChartSheetDrawing(cs *xlsxChartsheet, drawingID int, sheet
 }
 
 // addChart provides a function to create chart as xl/charts/chart%d.xml by
-// given format sets.
-func (f *File) addChart(opts *Chart, comboCharts []*Chart) {
-	count := f.countCharts()
+// given chart part path and format sets.
+func (f *File) addChart(chartXML string, opts *Chart, comboCharts []*Chart) {
 	xlsxChartSpace := xlsxChartSpace{
 		XMLNSa:         NameSpaceDrawingML.Value,
 		Date1904:       &attrValBool{Val: boolPtr(false)},
 		Lang:           &attrValString{Val: stringPtr("en-US")},
-		RoundedCorners: &attrValBool{Val: boolPtr(false)},
+		RoundedCorners: &attrValBool{Val: boolPtr(opts.RoundedCorners)},
 		Chart: cChart{
-			Title: f.drawPlotAreaTitles(opts.Title, ""),
-			View3D: &cView3D{
-				RotX:        &attrValInt{Val: intPtr(chartView3DRotX[opts.Type])},
-				RotY:        &attrValInt{Val: intPtr(chartView3DRotY[opts.Type])},
-				Perspective: &attrValInt{Val: intPtr(chartView3DPerspective[opts.Type])},
-				RAngAx:      &attrValInt{Val: intPtr(chartView3DRAngAx[opts.Type])},
-			},
+			Title:  f.drawPlotAreaTitles(opts.Title, ""),
+			View3D: f.drawChartView3D(opts),
 			Floor: &cThicknessSpPr{
 				Thickness: &attrValInt{Val: intPtr(0)},
 			},
@@ -81,10 +76,7 @@ func (f *File) addChart(opts *Chart, comboCharts []*Chart) {
 				Thickness: &attrValInt{Val: intPtr(0)},
 			},
 			PlotArea: &cPlotArea{},
-			Legend: &cLegend{
-				LegendPos: &attrValString{Val: stringPtr(chartLegendPosition[opts.Legend.Position])},
-				Overlay:   &attrValBool{Val: boolPtr(false)},
-			},
+			Legend:   f.drawChartLegend(opts),
 
 			PlotVisOnly:      &attrValBool{Val: boolPtr(false)},
 			DispBlanksAs:     &attrValString{Val: stringPtr(opts.ShowBlanksAs)},
@@ -108,7 +100,74 @@ func (f *File) addChart(opts *Chart, comboCharts []*Chart) {
 		},
 	}
 	xlsxChartSpace.SpPr = f.drawShapeFill(opts.Fill, xlsxChartSpace.SpPr)
-	plotAreaFunc := map[ChartType]func(*Chart) *cPlotArea{
+	plotAreaFunc := f.getPlotAreaFuncs()
+	xlsxChartSpace.Chart.PlotArea.SpPr = f.drawShapeFill(opts.PlotArea.Fill, xlsxChartSpace.Chart.PlotArea.SpPr)
+	if ln := f.drawChartBorder(&opts.PlotArea.Border); ln != nil {
+		if xlsxChartSpace.Chart.PlotArea.SpPr == nil {
+			xlsxChartSpace.Chart.PlotArea.SpPr = &cSpPr{}
+		}
+		xlsxChartSpace.Chart.PlotArea.SpPr.Ln = ln
+	}
+	addChart := func(c, p *cPlotArea) {
+		immutable, mutable := reflect.ValueOf(c).Elem(), reflect.ValueOf(p).Elem()
+		for i := 0; i < mutable.NumField(); i++ {
+			field := mutable.Field(i)
+			if field.IsNil() {
+				continue
+			}
+			immutable.FieldByName(mutable.Type().Field(i).Name).Set(field)
+		}
+	}
+	addChart(xlsxChartSpace.Chart.PlotArea, plotAreaFunc[opts.Type](opts))
+	order := len(opts.Series)
+	for idx := range comboCharts {
+		comboCharts[idx].order = order
+		addChart(xlsxChartSpace.Chart.PlotArea, plotAreaFunc[comboCharts[idx].Type](comboCharts[idx]))
+		order += len(comboCharts[idx].Series)
+	}
+	if opts.XAxis.DateAxis {
+		f.drawPlotAreaDateAx(xlsxChartSpace.Chart.PlotArea, opts)
+	}
+	xlsxChartSpace.Chart.PlotArea.DTable = f.drawPlotAreaDTable(xlsxChartSpace.Chart.PlotArea, opts)
+	chart, _ := xml.Marshal(xlsxChartSpace)
+	f.saveFileList(chartXML, chart)
+}
+
+// drawChartView3D provides a function to draw the c:view3D element by given
+// format sets, the default view settings of the chart type will be used if
+// the view options are not specified or out of range.
+func (f *File) drawChartView3D(opts *Chart) *cView3D {
+	view3D := &cView3D{
+		RotX:        &attrValInt{Val: intPtr(chartView3DRotX[opts.Type])},
+		RotY:        &attrValInt{Val: intPtr(chartView3DRotY[opts.Type])},
+		Perspective: &attrValInt{Val: intPtr(chartView3DPerspective[opts.Type])},
+		RAngAx:      &attrValInt{Val: intPtr(chartView3DRAngAx[opts.Type])},
+	}
+	if opts.View3D.RotX != nil && *opts.View3D.RotX >= -90 && *opts.View3D.RotX <= 90 {
+		view3D.RotX.Val = intPtr(*opts.View3D.RotX)
+	}
+	if opts.View3D.RotY != nil && *opts.View3D.RotY >= 0 && *opts.View3D.RotY <= 360 {
+		view3D.RotY.Val = intPtr(*opts.View3D.RotY)
+	}
+	if opts.View3D.Perspective != nil && *opts.View3D.Perspective >= 0 && *opts.View3D.Perspective <= 240 {
+		view3D.Perspective.Val = intPtr(*opts.View3D.Perspective)
+	}
+	if opts.View3D.RightAngleAxes != nil {
+		view3D.RAngAx.Val = intPtr(0)
+		if *opts.View3D.RightAngleAxes {
+			view3D.RAngAx.Val = intPtr(1)
+		}
+	}
+	if opts.View3D.DepthPercent != nil && *opts.View3D.DepthPercent >= 20 && *opts.View3D.DepthPercent <= 2000 {
+		view3D.DepthPercent = &attrValInt{Val: intPtr(*opts.View3D.DepthPercent)}
+	}
+	return view3D
+}
+
+// getPlotAreaFuncs provides a function to get the functions which draw the
+// c:plotArea element for each of the supported chart types.
+func (f *File) getPlotAreaFuncs() map[ChartType]func(*Chart) *cPlotArea {
+	return map[ChartType]func(*Chart) *cPlotArea{
 		Area:                        f.drawBaseChart,
 		AreaStacked:                 f.drawBaseChart,
 		AreaPercentStacked:          f.drawBaseChart,
@@ -165,30 +224,6 @@ func (f *File) addChart(opts *Chart, comboCharts []*Chart) {
 		Bubble:                      f.drawBubbleChart,
 		Bubble3D:                    f.drawBubbleChart,
 	}
-	if opts.Legend.Position == "none" {
-		xlsxChartSpace.Chart.Legend = nil
-	}
-	xlsxChartSpace.Chart.PlotArea.SpPr = f.drawShapeFill(opts.PlotArea.Fill, xlsxChartSpace.Chart.PlotArea.SpPr)
-	addChart := func(c, p *cPlotArea) {
-		immutable, mutable := reflect.ValueOf(c).Elem(), reflect.ValueOf(p).Elem()
-		for i := 0; i < mutable.NumField(); i++ {
-			field := mutable.Field(i)
-			if field.IsNil() {
-				continue
-			}
-			immutable.FieldByName(mutable.Type().Field(i).Name).Set(field)
-		}
-	}
-	addChart(xlsxChartSpace.Chart.PlotArea, plotAreaFunc[opts.Type](opts))
-	order := len(opts.Series)
-	for idx := range comboCharts {
-		comboCharts[idx].order = order
-		addChart(xlsxChartSpace.Chart.PlotArea, plotAreaFunc[comboCharts[idx].Type](comboCharts[idx]))
-		order += len(comboCharts[idx].Series)
-	}
-	chart, _ := xml.Marshal(xlsxChartSpace)
-	media := "xl/charts/chart" + strconv.Itoa(count+1) + ".xml"
-	f.saveFileList(media, chart)
 }
 
 // drawBaseChart provides a function to draw the c:plotArea element for bar,
@@ -431,7 +466,17 @@ func (f *File) drawBaseChart(opts *Chart) *cPlotArea {
 			ValAx:       valAx,
 		},
 	}
-	return charts[opts.Type]
+	plotArea := charts[opts.Type]
+	if plotArea.BarChart == nil && plotArea.Bar3DChart == nil {
+		return plotArea
+	}
+	if opts.GapWidth != nil && *opts.GapWidth >= 0 && *opts.GapWidth <= 500 {
+		c.GapWidth = &attrValInt{Val: intPtr(*opts.GapWidth)}
+	}
+	if plotArea.BarChart != nil && opts.Overlap != nil && *opts.Overlap >= -100 && *opts.Overlap <= 100 {
+		c.Overlap = &attrValInt{Val: intPtr(*opts.Overlap)}
+	}
+	return plotArea
 }
 
 // drawDoughnutChart provides a function to draw the c:plotArea element for
@@ -447,12 +492,22 @@ func (f *File) drawDoughnutChart(opts *Chart) *cPlotArea {
 			VaryColors: &attrValBool{
 				Val: opts.VaryColors,
 			},
-			Ser:      f.drawChartSeries(opts),
-			HoleSize: &attrValInt{Val: intPtr(holeSize)},
+			Ser:           f.drawChartSeries(opts),
+			FirstSliceAng: f.drawChartFirstSliceAng(opts),
+			HoleSize:      &attrValInt{Val: intPtr(holeSize)},
 		},
 	}
 }
 
+// drawChartFirstSliceAng provides a function to draw the c:firstSliceAng
+// element for pie and doughnut chart by given format sets.
+func (f *File) drawChartFirstSliceAng(opts *Chart) *attrValInt {
+	if opts.FirstSliceAngle > 0 && opts.FirstSliceAngle <= 360 {
+		return &attrValInt{Val: intPtr(opts.FirstSliceAngle)}
+	}
+	return nil
+}
+
 // drawLineChart provides a function to draw the c:plotArea element for line
 // chart by given format sets.
 func (f *File) drawLineChart(opts *Chart) *cPlotArea {
@@ -464,15 +519,58 @@ func (f *File) drawLineChart(opts *Chart) *cPlotArea {
 			VaryColors: &attrValBool{
 				Val: boolPtr(false),
 			},
-			Ser:   f.drawChartSeries(opts),
-			DLbls: f.drawChartDLbls(opts),
-			AxID:  f.genAxID(opts),
+			Ser:        f.drawChartSeries(opts),
+			DLbls:      f.drawChartDLbls(opts),
+			DropLines:  f.drawChartLines(opts.DropLines),
+			HiLowLines: f.drawChartLines(opts.HighLowLines),
+			UpDownBars: f.drawChartUpDownBars(opts.UpDownBars),
+			AxID:       f.genAxID(opts),
 		},
 		CatAx: f.drawPlotAreaCatAx(opts),
 		ValAx: f.drawPlotAreaValAx(opts),
 	}
 }
 
+// drawChartLines provides a function to draw the drop lines or high-low lines
+// element for the line chart by given line format sets.
+func (f *File) drawChartLines(opts *ChartLine) *cChartLines {
+	if opts == nil {
+		return nil
+	}
+	if ln := f.drawChartBorder(opts); ln != nil {
+		return &cChartLines{SpPr: &cSpPr{Ln: ln}}
+	}
+	return &cChartLines{}
+}
+
+// drawChartUpDownBars provides a function to draw the c:upDownBars element for
+// the line chart by given format sets.
+func (f *File) drawChartUpDownBars(opts *ChartUpDownBars) *cUpDownBars {
+	if opts == nil {
+		return nil
+	}
+	upDownBars := &cUpDownBars{
+		GapWidth: &attrValInt{Val: intPtr(150)},
+		UpBars:   &cChartLines{},
+		DownBars: &cChartLines{},
+	}
+	if opts.GapWidth != nil && *opts.GapWidth >= 0 && *opts.GapWidth <= 500 {
+		upDownBars.GapWidth.Val = intPtr(*opts.GapWidth)
+	}
+	for i, bars := range []*cChartLines{upDownBars.UpBars, upDownBars.DownBars} {
+		if fill := []Fill{opts.UpBarsFill, opts.DownBarsFill}[i]; fill.Type != "" {
+			bars.SpPr = f.drawShapeFill(fill, &cSpPr{})
+		}
+		if ln := f.drawChartBorder(&opts.Border); ln != nil {
+			if bars.SpPr == nil {
+				bars.SpPr = &cSpPr{}
+			}
+			bars.SpPr.Ln = ln
+		}
+	}
+	return upDownBars
+}
+
 // drawLine3DChart provides a function to draw the c:plotArea element for line
 // chart by given format sets.
 func (f *File) drawLine3DChart(opts *Chart) *cPlotArea {
@@ -501,7 +599,8 @@ func (f *File) drawPieChart(opts *Chart) *cPlotArea {
 			VaryColors: &attrValBool{
 				Val: opts.VaryColors,
 			},
-			Ser: f.drawChartSeries(opts),
+			Ser:           f.drawChartSeries(opts),
+			FirstSliceAng: f.drawChartFirstSliceAng(opts),
 		},
 	}
 }
@@ -610,9 +709,9 @@ func (f *File) drawSurface3DChart(opts *Chart) *cPlotArea {
 		Surface3DChart: &cCharts{
 			Ser: f.drawChartSeries(opts),
 			AxID: []*attrValInt{
-				{Val: intPtr(100000000)},
-				{Val: intPtr(100000001)},
-				{Val: intPtr(100000005)},
+				{Val: intPtr(primaryCatAxID)},
+				{Val: intPtr(primaryValAxID)},
+				{Val: intPtr(seriesAxID)},
 			},
 		},
 		CatAx: f.drawPlotAreaCatAx(opts),
@@ -632,9 +731,9 @@ func (f *File) drawSurfaceChart(opts *Chart) *cPlotArea {
 		SurfaceChart: &cCharts{
 			Ser: f.drawChartSeries(opts),
 			AxID: []*attrValInt{
-				{Val: intPtr(100000000)},
-				{Val: intPtr(100000001)},
-				{Val: intPtr(100000005)},
+				{Val: intPtr(primaryCatAxID)},
+				{Val: intPtr(primaryValAxID)},
+				{Val: intPtr(seriesAxID)},
 			},
 		},
 		CatAx: f.drawPlotAreaCatAx(opts),
@@ -713,6 +812,7 @@ func (f *File) drawChartSeries(opts *Chart) *[]cSer {
 				},
 			},
 			SpPr:             f.drawChartSeriesSpPr(k, opts),
+			Explosion:        f.drawChartSeriesExplosion(opts.Series[k].Explosion, opts),
 			Marker:           f.drawChartSeriesMarker(k, opts),
 			DPt:              f.drawChartSeriesDPt(k, opts),
 			DLbls:            f.drawChartSeriesDLbls(k, opts),
@@ -729,23 +829,110 @@ func (f *File) drawChartSeries(opts *Chart) *[]cSer {
 	return &ser
 }
 
-// drawShapeFill provides a function to draw the a:solidFill element by given
-// fill format sets.
+// drawShapeFill provides a function to draw the solid, gradient, pattern or
+// no fill element by given fill format sets.
 func (f *File) drawShapeFill(fill Fill, spPr *cSpPr) *cSpPr {
-	if fill.Type == "pattern" && fill.Pattern == 1 {
+	if fill.Type == "none" {
+		if spPr == nil {
+			spPr = &cSpPr{}
+		}
+		spPr.SolidFill, spPr.GradFill, spPr.PattFill = nil, nil, nil
+		spPr.NoFill = stringPtr("")
+		return spPr
+	}
+	if fill.Type == "gradient" && len(fill.Color) > 1 && fill.Shading >= 0 && fill.Shading <= 16 {
 		if spPr == nil {
 			spPr = &cSpPr{}
 		}
+		spPr.NoFill, spPr.SolidFill, spPr.PattFill = nil, nil, nil
+		spPr.GradFill = f.drawShapeGradFill(fill)
+		return spPr
+	}
+	if fill.Type != "pattern" || fill.Pattern < 1 || fill.Pattern > 18 {
+		return spPr
+	}
+	if spPr == nil {
+		spPr = &cSpPr{}
+	}
+	spPr.NoFill, spPr.SolidFill, spPr.GradFill, spPr.PattFill = nil, nil, nil, nil
+	if fill.Pattern == 1 {
 		if len(fill.Color) == 1 {
-			spPr.SolidFill = &aSolidFill{SrgbClr: &attrValString{Val: stringPtr(strings.TrimPrefix(fill.Color[0], "#"))}}
+			spPr.SolidFill = &aSolidFill{SrgbClr: drawSrgbClr(fill.Color[0], fill.Transparency)}
 			return spPr
 		}
-		spPr.SolidFill = nil
 		spPr.NoFill = stringPtr("")
+		return spPr
+	}
+	fgColor, bgColor := "000000", "FFFFFF"
+	if len(fill.Color) > 0 {
+		fgColor = fill.Color[0]
+	}
+	if len(fill.Color) > 1 {
+		bgColor = fill.Color[1]
+	}
+	spPr.PattFill = &aPattFill{
+		Prst:  supportedDrawingPatternTypes[fill.Pattern],
+		FgClr: aPattColor{SrgbClr: drawSrgbClr(fgColor, fill.Transparency)},
+		BgClr: aPattColor{SrgbClr: drawSrgbClr(bgColor, fill.Transparency)},
 	}
 	return spPr
 }
 
+// drawShapeGradFill provides a function to draw the a:gradFill element by
+// given fill format sets. The gradient stops are evenly distributed by the
+// colors, and the shading index follows the gradient variants of the cell
+// fill: horizontal, vertical, diagonal up, diagonal down, from corner and from
+// center.
+func (f *File) drawShapeGradFill(fill Fill) *aGradFill {
+	colors := fill.Color
+	if fill.Shading < 12 {
+		switch fill.Shading % 3 {
+		case 1:
+			colors = make([]string, len(fill.Color))
+			for i, color := range fill.Color {
+				colors[len(fill.Color)-1-i] = color
+			}
+		case 2:
+			colors = append([]string{}, fill.Color...)
+			for i := len(fill.Color) - 2; i >= 0; i-- {
+				colors = append(colors, fill.Color[i])
+			}
+		}
+	}
+	gradFill := &aGradFill{RotWithShape: true}
+	for i, color := range colors {
+		gradFill.GsLst = append(gradFill.GsLst, aGs{
+			Pos:     i * 100000 / (len(colors) - 1),
+			SrgbClr: drawSrgbClr(color, fill.Transparency),
+		})
+	}
+	if fill.Shading < 12 {
+		gradFill.Lin = &aLin{Ang: []int{5400000, 0, 2700000, 8100000}[fill.Shading/3]}
+		return gradFill
+	}
+	gradFill.Path = &aGradPath{Path: "rect", FillToRect: map[int]*aFillToRect{
+		12: {R: 100000, B: 100000},
+		13: {L: 100000, B: 100000},
+		14: {T: 100000, R: 100000},
+		15: {L: 100000, T: 100000},
+		16: {L: 50000, T: 50000, R: 50000, B: 50000},
+	}[fill.Shading]}
+	if fill.Shading == 16 {
+		gradFill.Path.Path = "circle"
+	}
+	return gradFill
+}
+
+// drawSrgbClr provides a function to draw the a:srgbClr element by given
+// color and transparency percentage.
+func drawSrgbClr(color string, transparency int) *aSrgbClr {
+	clr := &aSrgbClr{Val: stringPtr(strings.TrimPrefix(color, "#"))}
+	if transparency > 0 {
+		clr.Alpha = &attrValInt{Val: intPtr((100 - transparency) * 1000)}
+	}
+	return clr
+}
+
 // drawChartSeriesSpPr provides a function to draw the c:spPr element by given
 // format sets.
 func (f *File) drawChartSeriesSpPr(i int, opts *Chart) *cSpPr {
@@ -764,44 +951,85 @@ func (f *File) drawChartSeriesSpPr(i int, opts *Chart) *cSpPr {
 			SolidFill: spPr.SolidFill,
 		},
 	}
+	if idx := inStrSlice(supportedChartDashTypes, opts.Series[i].Line.DashType, false); idx != -1 {
+		spPrLine.Ln.PrstDash = &attrValString{Val: stringPtr(supportedChartDashTypes[idx])}
+	}
 	if chartSeriesSpPr, ok := map[ChartType]*cSpPr{
 		Line: spPrLine, Scatter: spPrScatter,
 	}[opts.Type]; ok {
 		return chartSeriesSpPr
 	}
-	if spPr.SolidFill.SrgbClr != nil {
+	if spPr.SolidFill == nil || spPr.SolidFill.SrgbClr != nil {
 		return spPr
 	}
 	return nil
 }
 
+// drawChartSeriesExplosion provides a function to draw the c:explosion element
+// of the pie chart series or data point by given explosion percentage and
+// format sets.
+func (f *File) drawChartSeriesExplosion(explosion int, opts *Chart) *attrValInt {
+	if explosion <= 0 || explosion > 400 {
+		return nil
+	}
+	if map[ChartType]bool{Pie: true, Pie3D: true, Doughnut: true, PieOfPie: true, BarOfPie: true}[opts.Type] {
+		return &attrValInt{Val: intPtr(explosion)}
+	}
+	return nil
+}
+
 // drawChartSeriesDPt provides a function to draw the c:dPt element by given
 // data index and format sets.
 func (f *File) drawChartSeriesDPt(i int, opts *Chart) []*cDPt {
-	dpt := []*cDPt{{
-		IDx:      &attrValInt{Val: intPtr(i)},
-		Bubble3D: &attrValBool{Val: boolPtr(false)},
-		SpPr: &cSpPr{
-			SolidFill: &aSolidFill{
-				SchemeClr: &aSchemeClr{Val: "accent" + strconv.Itoa(i+1)},
-			},
-			Ln: &aLn{
-				W:   25400,
-				Cap: "rnd",
+	var dPts []*cDPt
+	if opts.Type == Pie || opts.Type == Pie3D {
+		dPts = append(dPts, &cDPt{
+			IDx:      &attrValInt{Val: intPtr(i)},
+			Bubble3D: &attrValBool{Val: boolPtr(false)},
+			SpPr: &cSpPr{
 				SolidFill: &aSolidFill{
-					SchemeClr: &aSchemeClr{Val: "lt" + strconv.Itoa(i+1)},
+					SchemeClr: &aSchemeClr{Val: "accent" + strconv.Itoa(i+1)},
 				},
-			},
-			Sp3D: &aSp3D{
-				ContourW: 25400,
-				ContourClr: &aContourClr{
-					SchemeClr: &aSchemeClr{Val: "lt" + strconv.Itoa(i+1)},
+				Ln: &aLn{
+					W:   25400,
+					Cap: "rnd",
+					SolidFill: &aSolidFill{
+						SchemeClr: &aSchemeClr{Val: "lt" + strconv.Itoa(i+1)},
+					},
+				},
+				Sp3D: &aSp3D{
+					ContourW: 25400,
+					ContourClr: &aContourClr{
+						SchemeClr: &aSchemeClr{Val: "lt" + strconv.Itoa(i+1)},
+					},
 				},
 			},
-		},
-	}}
-	chartSeriesDPt := map[ChartType][]*cDPt{Pie: dpt, Pie3D: dpt}
-	return chartSeriesDPt[opts.Type]
+		})
+	}
+	for _, dataPoint := range opts.Series[i].DataPoint {
+		spPr := f.drawShapeFill(dataPoint.Fill, nil)
+		explosion := f.drawChartSeriesExplosion(dataPoint.Explosion, opts)
+		if (spPr == nil && explosion == nil) || dataPoint.Index < 0 {
+			continue
+		}
+		dPt := &cDPt{
+			IDx:       &attrValInt{Val: intPtr(dataPoint.Index)},
+			Bubble3D:  &attrValBool{Val: boolPtr(false)},
+			Explosion: explosion,
+			SpPr:      spPr,
+		}
+		idx := sort.Search(len(dPts), func(j int) bool { return *dPts[j].IDx.Val >= dataPoint.Index })
+		if idx < len(dPts) && *dPts[idx].IDx.Val == dataPoint.Index {
+			dPts[idx].Explosion = explosion
+			if spPr != nil {
+				dPts[idx].SpPr.NoFill, dPts[idx].SpPr.SolidFill = spPr.NoFill, spPr.SolidFill
+				dPts[idx].SpPr.GradFill, dPts[idx].SpPr.PattFill = spPr.GradFill, spPr.PattFill
+			}
+			continue
+		}
+		dPts = append(dPts[:idx], append([]*cDPt{dPt}, dPts[idx:]...)...)
+	}
+	return dPts
 }
 
 // drawChartSeriesCat provides a function to draw the c:cat element by given
@@ -990,7 +1218,7 @@ func (f *File) drawPlotAreaCatAx(opts *Chart) []*cAxs {
 	}
 	axs := []*cAxs{
 		{
-			AxID: &attrValInt{Val: intPtr(100000000)},
+			AxID: &attrValInt{Val: intPtr(primaryCatAxID)},
 			Scaling: &cScaling{
 				Orientation: &attrValString{Val: stringPtr(orientation[opts.XAxis.ReverseOrder])},
 				Max:         maxVal,
@@ -1005,7 +1233,7 @@ func (f *File) drawPlotAreaCatAx(opts *Chart) []*cAxs {
 			TickLblPos:    &attrValString{Val: stringPtr("nextTo")},
 			SpPr:          f.drawPlotAreaSpPr(),
 			TxPr:          f.drawPlotAreaTxPr(&opts.YAxis),
-			CrossAx:       &attrValInt{Val: intPtr(100000001)},
+			CrossAx:       &attrValInt{Val: intPtr(primaryValAxID)},
 			Crosses:       &attrValString{Val: stringPtr("autoZero")},
 			Auto:          &attrValBool{Val: boolPtr(true)},
 			LblAlgn:       &attrValString{Val: stringPtr("ctr")},
@@ -1025,6 +1253,7 @@ func (f *File) drawPlotAreaCatAx(opts *Chart) []*cAxs {
 	if opts.XAxis.TickLabelSkip != 0 {
 		axs[0].TickLblSkip = &attrValInt{Val: intPtr(opts.XAxis.TickLabelSkip)}
 	}
+	f.drawChartAxisTicks(axs[0], &opts.XAxis)
 	if opts.order > 0 && opts.YAxis.Secondary {
 		axs = append(axs, &cAxs{
 			AxID: &attrValInt{Val: intPtr(opts.XAxis.axID)},
@@ -1066,7 +1295,7 @@ func (f *File) drawPlotAreaValAx(opts *Chart) []*cAxs {
 	}
 	axs := []*cAxs{
 		{
-			AxID: &attrValInt{Val: intPtr(100000001)},
+			AxID: &attrValInt{Val: intPtr(primaryValAxID)},
 			Scaling: &cScaling{
 				LogBase:     logBase,
 				Orientation: &attrValString{Val: stringPtr(orientation[opts.YAxis.ReverseOrder])},
@@ -1084,7 +1313,7 @@ func (f *File) drawPlotAreaValAx(opts *Chart) []*cAxs {
 			TickLblPos:    &attrValString{Val: stringPtr("nextTo")},
 			SpPr:          f.drawPlotAreaSpPr(),
 			TxPr:          f.drawPlotAreaTxPr(&opts.XAxis),
-			CrossAx:       &attrValInt{Val: intPtr(100000000)},
+			CrossAx:       &attrValInt{Val: intPtr(primaryCatAxID)},
 			Crosses:       &attrValString{Val: stringPtr("autoZero")},
 			CrossBetween:  &attrValString{Val: stringPtr(chartValAxCrossBetween[opts.Type])},
 		},
@@ -1104,6 +1333,16 @@ func (f *File) drawPlotAreaValAx(opts *Chart) []*cAxs {
 	if opts.YAxis.MajorUnit != 0 {
 		axs[0].MajorUnit = &attrValFloat{Val: float64Ptr(opts.YAxis.MajorUnit)}
 	}
+	if opts.YAxis.MinorUnit != 0 {
+		axs[0].MinorUnit = &attrValFloat{Val: float64Ptr(opts.YAxis.MinorUnit)}
+	}
+	f.drawChartAxisTicks(axs[0], &opts.YAxis)
+	if idx := inStrSlice(supportedChartDisplayUnits, opts.YAxis.DisplayUnits, false); idx != -1 {
+		axs[0].DispUnits = &cDispUnits{BuiltInUnit: &attrValString{Val: stringPtr(supportedChartDisplayUnits[idx])}}
+		if opts.YAxis.DisplayUnitsVisible {
+			axs[0].DispUnits.DispUnitsLbl = &cDispUnitsLbl{}
+		}
+	}
 	if opts.order > 0 && opts.YAxis.Secondary {
 		axs = append(axs, &cAxs{
 			AxID: &attrValInt{Val: intPtr(opts.YAxis.axID)},
@@ -1139,7 +1378,7 @@ func (f *File) drawPlotAreaSerAx(opts *Chart) []*cAxs {
 	}
 	return []*cAxs{
 		{
-			AxID: &attrValInt{Val: intPtr(100000005)},
+			AxID: &attrValInt{Val: intPtr(seriesAxID)},
 			Scaling: &cScaling{
 				Orientation: &attrValString{Val: stringPtr(orientation[opts.YAxis.ReverseOrder])},
 				Max:         maxVal,
@@ -1150,11 +1389,96 @@ func (f *File) drawPlotAreaSerAx(opts *Chart) []*cAxs {
 			TickLblPos: &attrValString{Val: stringPtr("nextTo")},
 			SpPr:       f.drawPlotAreaSpPr(),
 			TxPr:       f.drawPlotAreaTxPr(nil),
-			CrossAx:    &attrValInt{Val: intPtr(100000001)},
+			CrossAx:    &attrValInt{Val: intPtr(primaryValAxID)},
 		},
 	}
 }
 
+// drawPlotAreaDateAx provides a function to convert the primary category axis
+// of the plot area to the c:dateAx element by given format sets.
+func (f *File) drawPlotAreaDateAx(plotArea *cPlotArea, opts *Chart) {
+	for i, ax := range plotArea.CatAx {
+		if *ax.AxID.Val != primaryCatAxID {
+			continue
+		}
+		dateAx := &cDateAx{
+			AxID:           ax.AxID,
+			Scaling:        ax.Scaling,
+			Delete:         ax.Delete,
+			AxPos:          ax.AxPos,
+			MajorGridlines: ax.MajorGridlines,
+			MinorGridlines: ax.MinorGridlines,
+			Title:          ax.Title,
+			NumFmt:         ax.NumFmt,
+			MajorTickMark:  ax.MajorTickMark,
+			MinorTickMark:  ax.MinorTickMark,
+			TickLblPos:     ax.TickLblPos,
+			SpPr:           ax.SpPr,
+			TxPr:           ax.TxPr,
+			CrossAx:        ax.CrossAx,
+			Crosses:        ax.Crosses,
+			Auto:           &attrValBool{Val: boolPtr(false)},
+			LblOffset:      ax.LblOffset,
+		}
+		if f.drawChartNumFmt(opts.XAxis.NumFmt) == nil {
+			dateAx.NumFmt = &cNumFmt{FormatCode: "m/d/yyyy", SourceLinked: true}
+		}
+		if idx := inStrSlice(supportedChartTimeUnits, opts.XAxis.BaseTimeUnit, false); idx != -1 {
+			dateAx.BaseTimeUnit = &attrValString{Val: stringPtr(supportedChartTimeUnits[idx])}
+		}
+		if opts.XAxis.MajorUnit > 0 {
+			dateAx.MajorUnit = &attrValFloat{Val: float64Ptr(opts.XAxis.MajorUnit)}
+		}
+		if idx := inStrSlice(supportedChartTimeUnits, opts.XAxis.MajorTimeUnit, false); idx != -1 {
+			dateAx.MajorTimeUnit = &attrValString{Val: stringPtr(supportedChartTimeUnits[idx])}
+		}
+		if opts.XAxis.MinorUnit > 0 {
+			dateAx.MinorUnit = &attrValFloat{Val: float64Ptr(opts.XAxis.MinorUnit)}
+		}
+		if idx := inStrSlice(supportedChartTimeUnits, opts.XAxis.MinorTimeUnit, false); idx != -1 {
+			dateAx.MinorTimeUnit = &attrValString{Val: stringPtr(supportedChartTimeUnits[idx])}
+		}
+		plotArea.DateAx = append(plotArea.DateAx, dateAx)
+		if plotArea.CatAx = append(plotArea.CatAx[:i], plotArea.CatAx[i+1:]...); len(plotArea.CatAx) == 0 {
+			plotArea.CatAx = nil
+		}
+		return
+	}
+}
+
+// drawPlotAreaDTable provides a function to draw the c:dTable element for the
+// chart which has category axis by given plot area and format sets.
+func (f *File) drawPlotAreaDTable(plotArea *cPlotArea, opts *Chart) *cDTable {
+	if opts.DataTable == nil || (plotArea.CatAx == nil && plotArea.DateAx == nil) ||
+		plotArea.BubbleChart != nil || plotArea.RadarChart != nil || plotArea.ScatterChart != nil {
+		return nil
+	}
+	dTable := &cDTable{
+		ShowHorzBorder: &attrValBool{Val: boolPtr(opts.DataTable.ShowHorizontalBorder)},
+		ShowVertBorder: &attrValBool{Val: boolPtr(opts.DataTable.ShowVerticalBorder)},
+		ShowOutline:    &attrValBool{Val: boolPtr(opts.DataTable.ShowOutline)},
+		ShowKeys:       &attrValBool{Val: boolPtr(opts.DataTable.ShowLegendKeys)},
+	}
+	if opts.DataTable.Font != (Font{}) {
+		dTable.TxPr = f.drawPlotAreaTxPr(&ChartAxis{Font: opts.DataTable.Font})
+	}
+	return dTable
+}
+
+// drawChartAxisTicks provides a function to set the major and minor tick mark
+// types and the rotation angle of the tick labels for the chart axis.
+func (f *File) drawChartAxisTicks(ax *cAxs, opts *ChartAxis) {
+	if idx := inStrSlice(supportedChartTickMarkTypes, opts.MajorTickMark, false); idx != -1 {
+		ax.MajorTickMark = &attrValString{Val: stringPtr(supportedChartTickMarkTypes[idx])}
+	}
+	if idx := inStrSlice(supportedChartTickMarkTypes, opts.MinorTickMark, false); idx != -1 {
+		ax.MinorTickMark = &attrValString{Val: stringPtr(supportedChartTickMarkTypes[idx])}
+	}
+	if opts.TickLabelRotation != 0 && opts.TickLabelRotation >= -90 && opts.TickLabelRotation <= 90 {
+		ax.TxPr.BodyPr.Rot = -opts.TickLabelRotation * 60000
+	}
+}
+
 // drawChartFont provides a function to draw the a:rPr element.
 func drawChartFont(fnt *Font, r *aRPr) {
 	if fnt == nil {
@@ -1170,7 +1494,7 @@ func drawChartFont(fnt *Font, r *aRPr) {
 			r.SolidFill = &aSolidFill{}
 		}
 		r.SolidFill.SchemeClr = nil
-		r.SolidFill.SrgbClr = &attrValString{Val: stringPtr(strings.ReplaceAll(strings.ToUpper(fnt.Color), "#", ""))}
+		r.SolidFill.SrgbClr = &aSrgbClr{Val: stringPtr(strings.ReplaceAll(strings.ToUpper(fnt.Color), "#", ""))}
 	}
 	if fnt.Family != "" {
 		r.Latin.Typeface = fnt.Family
@@ -1183,20 +1507,66 @@ func drawChartFont(fnt *Font, r *aRPr) {
 	}
 }
 
-// drawPlotAreaTitles provides a function to draw the c:title element.
+// drawChartLegend provides a function to draw the c:legend element by given
+// format sets.
+func (f *File) drawChartLegend(opts *Chart) *cLegend {
+	if opts.Legend.Position == "none" {
+		return nil
+	}
+	legend := &cLegend{
+		LegendPos: &attrValString{Val: stringPtr(chartLegendPosition[opts.Legend.Position])},
+		Overlay:   &attrValBool{Val: boolPtr(false)},
+		SpPr:      f.drawShapeFill(opts.Legend.Fill, nil),
+	}
+	for _, idx := range opts.Legend.DeletedEntries {
+		if idx < 0 {
+			continue
+		}
+		legend.LegendEntry = append(legend.LegendEntry, &cLegendEntry{
+			IDx:    &attrValInt{Val: intPtr(idx)},
+			Delete: &attrValBool{Val: boolPtr(true)},
+		})
+	}
+	if ln := f.drawChartBorder(&opts.Legend.Border); ln != nil {
+		if legend.SpPr == nil {
+			legend.SpPr = &cSpPr{}
+		}
+		legend.SpPr.Ln = ln
+	}
+	if opts.Legend.Font != (Font{}) {
+		legend.TxPr = f.drawPlotAreaTxPr(&ChartAxis{Font: opts.Legend.Font})
+	}
+	return legend
+}
+
+// drawPlotAreaTitles provides a function to draw the c:title element. The
+// title will reference the cell when the text of the single rich text run
+// begins with an equal sign, and the line breaks in the text of each run will
+// be split into paragraphs.
 func (f *File) drawPlotAreaTitles(runs []RichTextRun, vert string) *cTitle {
 	if len(runs) == 0 {
 		return nil
 	}
 	title := &cTitle{Tx: cTx{Rich: &cRich{}}, Overlay: &attrValBool{Val: boolPtr(false)}}
+	if len(runs) == 1 && strings.HasPrefix(runs[0].Text, "=") {
+		title.Tx = cTx{StrRef: &cStrRef{F: strings.TrimPrefix(runs[0].Text, "=")}}
+		title.TxPr = &cTxPr{P: aP{PPr: &aPPr{DefRPr: aRPr{}}, EndParaRPr: &aEndParaRPr{Lang: "en-US"}}}
+		drawChartFont(runs[0].Font, &title.TxPr.P.PPr.DefRPr)
+		if vert == "horz" {
+			title.TxPr.BodyPr = aBodyPr{Rot: -5400000, Vert: vert}
+		}
+		return title
+	}
 	for _, run := range runs {
-		r := &aR{T: run.Text}
-		drawChartFont(run.Font, &r.RPr)
-		title.Tx.Rich.P = append(title.Tx.Rich.P, aP{
-			PPr:        &aPPr{DefRPr: aRPr{}},
-			R:          r,
-			EndParaRPr: &aEndParaRPr{Lang: "en-US", AltLang: "en-US"},
-		})
+		for _, text := range strings.Split(run.Text, "\n") {
+			r := &aR{T: text}
+			drawChartFont(run.Font, &r.RPr)
+			title.Tx.Rich.P = append(title.Tx.Rich.P, aP{
+				PPr:        &aPPr{DefRPr: aRPr{}},
+				R:          r,
+				EndParaRPr: &aEndParaRPr{Lang: "en-US", AltLang: "en-US"},
+			})
+		}
 	}
 	if vert == "horz" {
 		title.Tx.Rich.BodyPr = aBodyPr{Rot: -5400000, Vert: vert}
@@ -1287,6 +1657,12 @@ func (f *File) drawChartLn(opts *ChartLine) *aLn {
 				},
 			},
 		}
+		if opts.Color != "" {
+			ln.SolidFill = &aSolidFill{SrgbClr: drawSrgbClr(opts.Color, 0)}
+		}
+		if idx := inStrSlice(supportedChartDashTypes, opts.DashType, false); idx != -1 {
+			ln.PrstDash = &attrValString{Val: stringPtr(supportedChartDashTypes[idx])}
+		}
 		return ln
 	case ChartLineNone:
 		ln.NoFill = &attrValString{}
@@ -1296,6 +1672,16 @@ func (f *File) drawChartLn(opts *ChartLine) *aLn {
 	}
 }
 
+// drawChartBorder provides a function to draw the a:ln element for the border
+// of the chart elements, which only been drawn when the width of the border
+// has been set or the border type is none.
+func (f *File) drawChartBorder(opts *ChartLine) *aLn {
+	if opts.Type != ChartLineNone && opts.Width == 0 {
+		return nil
+	}
+	return f.drawChartLn(opts)
+}
+
 // drawingParser provides a function to parse drawingXML. In order to solve
 // the problem that the label structure is changed after serialization and
 // deserialization, two different structures: decodeWsDr and encodeWsDr are
@@ -1349,34 +1735,99 @@ func (f *File) drawingParser(path string) (*xlsxWsDr, int, error) {
 	return wsDr, len(wsDr.OneCellAnchor) + len(wsDr.TwoCellAnchor) + 2, nil
 }
 
-// addDrawingChart provides a function to add chart graphic frame by given
-// sheet, drawingXML, cell, width, height, relationship index and format sets.
-func (f *File) addDrawingChart(sheet, drawingXML, cell string, width, height, rID int, opts *GraphicOptions) error {
+// setAltText provides a function to set the alternative text description,
+// title and decorative flag of the non-visual drawing properties by given
+// graphic options.
+func (c *xlsxCNvPr) setAltText(opts *GraphicOptions) {
+	c.Descr, c.Title = opts.AltText, opts.AltTextTitle
+	if opts.Decorative {
+		c.ExtLst = &xlsxCNvPrExtLst{Ext: []xlsxCNvPrExt{{
+			URI: ExtURIDecorative,
+			Decorative: &xlsxDecorative{
+				XMLNSAdec: NameSpaceDrawing2017Decorative.Value,
+				Val:       true,
+			},
+		}}}
+	}
+}
+
+// isDecorative returns whether the drawing object has been marked as
+// decorative.
+func (c *xlsxCNvPr) isDecorative() bool {
+	if c.ExtLst != nil {
+		for _, ext := range c.ExtLst.Ext {
+			if ext.URI == ExtURIDecorative && ext.Decorative != nil {
+				return ext.Decorative.Val
+			}
+		}
+	}
+	return false
+}
+
+// isDecorative returns whether the decoded drawing object has been marked as
+// decorative.
+func (c *decodeCNvPr) isDecorative() bool {
+	if c.ExtLst != nil {
+		for _, ext := range c.ExtLst.Ext {
+			if ext.URI == ExtURIDecorative && ext.Decorative != nil {
+				return ext.Decorative.Val
+			}
+		}
+	}
+	return false
+}
+
+// getChartAnchor provides a function to get the start and end anchor of the
+// chart by given worksheet name, cell reference or range reference, width,
+// height and format sets. The chart exactly covers the cells if a range
+// reference has been given, and the width, height, scale and offsets will be
+// ignored, the absolute position and physical size are not supported for the
+// range reference.
+func (f *File) getChartAnchor(sheet, cell string, width, height int, opts *GraphicOptions) (*xlsxFrom, *xlsxTo, error) {
+	if strings.Contains(cell, ":") {
+		if opts.hasAbsoluteAnchor() {
+			return nil, nil, ErrParameterInvalid
+		}
+		coordinates, err := rangeRefToCoordinates(cell)
+		if err != nil {
+			return nil, nil, err
+		}
+		_ = sortCoordinates(coordinates)
+		return &xlsxFrom{Col: coordinates[0] - 1, Row: coordinates[1] - 1},
+			&xlsxTo{Col: coordinates[2], Row: coordinates[3]}, err
+	}
 	col, row, err := CellNameToCoordinates(cell)
 	if err != nil {
-		return err
+		return nil, nil, err
+	}
+	if opts.hasAbsoluteAnchor() {
+		return f.getGraphicAnchorEMUs(sheet, col, row, width, height, opts)
 	}
 	width = int(float64(width) * opts.ScaleX)
 	height = int(float64(height) * opts.ScaleY)
 	colStart, rowStart, colEnd, rowEnd, x2, y2 := f.positionObjectPixels(sheet, col, row, opts.OffsetX, opts.OffsetY, width, height)
+	return &xlsxFrom{Col: colStart, ColOff: opts.OffsetX * EMU, Row: rowStart, RowOff: opts.OffsetY * EMU},
+		&xlsxTo{Col: colEnd, ColOff: x2 * EMU, Row: rowEnd, RowOff: y2 * EMU}, err
+}
+
+// addDrawingChart provides a function to add chart graphic frame by given
+// sheet, drawingXML, cell, width, height, relationship index and format sets.
+func (f *File) addDrawingChart(sheet, drawingXML, cell string, width, height, rID int, opts *GraphicOptions) error {
+	from, to, err := f.getChartAnchor(sheet, cell, width, height, opts)
+	if err != nil {
+		return err
+	}
+	if opts.Positioning != "" && inStrSlice(supportedPositioning, opts.Positioning, true) == -1 {
+		return ErrParameterInvalid
+	}
 	content, cNvPrID, err := f.drawingParser(drawingXML)
 	if err != nil {
 		return err
 	}
 	twoCellAnchor := xdrCellAnchor{}
 	twoCellAnchor.EditAs = opts.Positioning
-	from := xlsxFrom{}
-	from.Col = colStart
-	from.ColOff = opts.OffsetX * EMU
-	from.Row = rowStart
-	from.RowOff = opts.OffsetY * EMU
-	to := xlsxTo{}
-	to.Col = colEnd
-	to.ColOff = x2 * EMU
-	to.Row = rowEnd
-	to.RowOff = y2 * EMU
-	twoCellAnchor.From = &from
-	twoCellAnchor.To = &to
+	twoCellAnchor.From = from
+	twoCellAnchor.To = to
 
 	graphicFrame := xlsxGraphicFrame{
 		NvGraphicFramePr: xlsxNvGraphicFramePr{
@@ -1396,6 +1847,7 @@ func (f *File) addDrawingChart(sheet, drawingXML, cell string, width, height, rI
 			},
 		},
 	}
+	graphicFrame.NvGraphicFramePr.CNvPr.setAltText(opts)
 	graphic, _ := xml.Marshal(graphicFrame)
 	twoCellAnchor.GraphicFrame = string(graphic)
 	twoCellAnchor.ClientData = &xdrClientData{
@@ -1439,6 +1891,7 @@ func (f *File) addSheetDrawingChart(drawingXML string, rID int, opts *GraphicOpt
 			},
 		},
 	}
+	graphicFrame.NvGraphicFramePr.CNvPr.setAltText(opts)
 	graphic, _ := xml.Marshal(graphicFrame)
 	absoluteAnchor.GraphicFrame = string(graphic)
 	absoluteAnchor.ClientData = &xdrClientData{
@@ -1542,9 +1995,9 @@ func (f *File) deleteDrawingRels(rels, rID string) {
 // genAxID provides a function to generate ID for primary and secondary
 // horizontal or vertical axis.
 func (f *File) genAxID(opts *Chart) []*attrValInt {
-	opts.XAxis.axID, opts.YAxis.axID = 100000000, 100000001
+	opts.XAxis.axID, opts.YAxis.axID = primaryCatAxID, primaryValAxID
 	if opts.order > 0 && opts.YAxis.Secondary {
-		opts.XAxis.axID, opts.YAxis.axID = 100000003, 100000004
+		opts.XAxis.axID, opts.YAxis.axID = secondaryCatAxID, secondaryValAxID
 	}
 	return []*attrValInt{{Val: intPtr(opts.XAxis.axID)}, {Val: intPtr(opts.YAxis.axID)}}
 }
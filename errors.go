@@ -14,6 +14,7 @@ package excelize
 import (
 	"errors"
 	"fmt"
+	"strings"
 )
 
 var (
@@ -53,6 +54,15 @@ var (
 	ErrDefinedNameScope = errors.New("no defined name on the scope")
 	// ErrExistsSheet defined the error message on given sheet already exists.
 	ErrExistsSheet = errors.New("the same name sheet already exists")
+	// ErrExistsAllowEditRange defined the error message on given allow edit
+	// range title already exists.
+	ErrExistsAllowEditRange = errors.New("the same title allow edit range already exists")
+	// ErrExistsFormControlName defined the error message on given form
+	// control name already exists.
+	ErrExistsFormControlName = errors.New("the same name form control already exists")
+	// ErrExistsScenario defined the error message on given scenario name
+	// already exists.
+	ErrExistsScenario = errors.New("the same name scenario already exists")
 	// ErrExistsTableName defined the error message on given table already exists.
 	ErrExistsTableName = errors.New("the same name table already exists")
 	// ErrFontLength defined the error message on the length of the font
@@ -63,6 +73,9 @@ var (
 	// ErrFormControlValue defined the error message for receiving a scroll
 	// value exceeds limit.
 	ErrFormControlValue = fmt.Errorf("scroll value must be between 0 and %d", MaxFormControlValue)
+	// ErrGoalSeek defined the error message on the goal seek could not find a
+	// solution.
+	ErrGoalSeek = errors.New("goal seek may not have found a solution")
 	// ErrGroupSheets defined the error message on group sheets.
 	ErrGroupSheets = errors.New("group worksheet must contain an active worksheet")
 	// ErrImgExt defined the error message on receive an unsupported image
@@ -71,6 +84,9 @@ var (
 	// ErrInvalidFormula defined the error message on receive an invalid
 	// formula.
 	ErrInvalidFormula = errors.New("formula not valid")
+	// ErrLastVisibleSheet defined the error message on hiding the last visible
+	// sheet of the workbook.
+	ErrLastVisibleSheet = errors.New("a workbook must contain at least one visible sheet")
 	// ErrMaxFilePathLength defined the error message on receive the file path
 	// length overflow.
 	ErrMaxFilePathLength = fmt.Errorf("file path length exceeds maximum limit %d characters", MaxFilePathLength)
@@ -97,8 +113,17 @@ var (
 	// ErrPasswordLengthInvalid defined the error message on invalid password
 	// length.
 	ErrPasswordLengthInvalid = errors.New("password length invalid")
+	// ErrRenderCells defined the error message on the number of cells to be
+	// rendered exceeds the limit.
+	ErrRenderCells = fmt.Errorf("the number of cells to render exceeds the %d limit", MaxRenderCells)
+	// ErrRenderPixels defined the error message on the number of pixels of
+	// the image to be rendered exceeds the limit.
+	ErrRenderPixels = fmt.Errorf("the number of pixels to render exceeds the %d limit", MaxRenderPixels)
 	// ErrSave defined the error message for saving file.
 	ErrSave = errors.New("no path defined for file, consider File.WriteTo or File.Write")
+	// ErrScenarioCells defined the error message on receive the invalid number
+	// of the changing cells of the scenario.
+	ErrScenarioCells = fmt.Errorf("the number of changing cells of the scenario must be between 1 and %d", MaxScenarioCells)
 	// ErrSheetIdx defined the error message on receive the invalid worksheet
 	// index.
 	ErrSheetIdx = errors.New("invalid worksheet index")
@@ -132,12 +157,21 @@ var (
 	// ErrStreamSetColWidth defined the error message on set column width in
 	// stream writing mode.
 	ErrStreamSetColWidth = errors.New("must call the SetColWidth function before the SetRow function")
+	// ErrStreamSetColStyle defined the error message on set column style in
+	// stream writing mode.
+	ErrStreamSetColStyle = errors.New("must call the SetColStyle function before the SetRow function")
+	// ErrStreamSetDefaultRowHeight defined the error message on set default
+	// row height in stream writing mode.
+	ErrStreamSetDefaultRowHeight = errors.New("must call the SetDefaultRowHeight function before the SetRow function")
 	// ErrStreamSetPanes defined the error message on set panes in stream
 	// writing mode.
 	ErrStreamSetPanes = errors.New("must call the SetPanes function before the SetRow function")
 	// ErrTotalSheetHyperlinks defined the error message on hyperlinks count
 	// overflow.
 	ErrTotalSheetHyperlinks = errors.New("over maximum limit hyperlinks in a worksheet")
+	// ErrTransparency defined the error message for receiving an invalid
+	// transparency of the fill.
+	ErrTransparency = errors.New("transparency must be between 0 and 100")
 	// ErrUnknownEncryptMechanism defined the error message on unsupported
 	// encryption mechanism.
 	ErrUnknownEncryptMechanism = errors.New("unknown encryption mechanism")
@@ -180,140 +214,684 @@ func (err ErrSheetNotExist) Error() string {
 	return fmt.Sprintf("sheet %s does not exist", err.SheetName)
 }
 
+// ErrSheetDependencies defined an error of deleting the sheet which is
+// referenced by the other parts of the workbook.
+type ErrSheetDependencies struct {
+	SheetName    string
+	Dependencies []SheetDependency
+}
+
+// Error returns the error message on deleting the sheet which has
+// dependencies.
+func (err ErrSheetDependencies) Error() string {
+	return fmt.Sprintf("sheet %s is referenced by %d dependencies", err.SheetName, len(err.Dependencies))
+}
+
+// ErrNotWorksheet defined an error of sheet that is not a worksheet.
+type ErrNotWorksheet struct {
+	SheetName string
+}
+
+// Error returns the error message on receiving a sheet which not a worksheet.
+func (err ErrNotWorksheet) Error() string {
+	return fmt.Sprintf("sheet %s is not a worksheet", err.SheetName)
+}
+
+// ErrNotChartSheet defined an error of sheet that is not a chart sheet.
+type ErrNotChartSheet struct {
+	SheetName string
+}
+
+// Error returns the error message on receiving a sheet which not a chart
+// sheet.
+func (err ErrNotChartSheet) Error() string {
+	return fmt.Sprintf("sheet %s is not a chart sheet", err.SheetName)
+}
+
+// ErrRangeOverlap defined an error of range reference which overlaps with the
+// existing range, such as merged cells and data validations.
+type ErrRangeOverlap struct {
+	Ref        string
+	OverlapRef string
+}
+
+// Error returns the error message on receiving the range reference which
+// overlaps with the existing range.
+func (err ErrRangeOverlap) Error() string {
+	return fmt.Sprintf("range %s overlaps with the existing range %s", err.Ref, err.OverlapRef)
+}
+
+// ErrProtectedCell defined an error of modifying the locked cell of a
+// protected worksheet when the ProtectedCellGuard option is enabled.
+type ErrProtectedCell struct {
+	SheetName string
+	Cell      string
+}
+
+// Error returns the error message on modifying the locked cell of a protected
+// worksheet.
+func (err ErrProtectedCell) Error() string {
+	return fmt.Sprintf("cell %s in sheet %s is locked by the worksheet protection", err.Cell, err.SheetName)
+}
+
+// ErrCellNameToCoordinates defined an error of cell name that cannot be
+// converted to coordinates, the underlying error can be retrieved by the
+// errors.Unwrap function.
+type ErrCellNameToCoordinates struct {
+	Cell string
+	Err  error
+}
+
+// Error returns the error message on converts alphanumeric cell name to
+// coordinates.
+func (err ErrCellNameToCoordinates) Error() string {
+	return fmt.Sprintf("cannot convert cell %q to coordinates: %v", err.Cell, err.Err)
+}
+
+// Unwrap returns the underlying error of converts cell name to coordinates.
+func (err ErrCellNameToCoordinates) Unwrap() error {
+	return err.Err
+}
+
+// ErrCoordinatesToCellName defined an error of coordinates that cannot be
+// converted to cell name.
+type ErrCoordinatesToCellName struct {
+	Col, Row int
+}
+
+// Error returns the error message on converts [X, Y] coordinates to
+// alpha-numeric cell name.
+func (err ErrCoordinatesToCellName) Error() string {
+	return fmt.Sprintf("invalid cell reference [%d, %d]", err.Col, err.Row)
+}
+
+// ErrInvalidCellName defined an error of invalid cell name.
+type ErrInvalidCellName struct {
+	Cell string
+}
+
+// Error returns the error message on receiving the invalid cell name.
+func (err ErrInvalidCellName) Error() string {
+	return fmt.Sprintf("invalid cell name %q", err.Cell)
+}
+
+// ErrInvalidColumnName defined an error of invalid column name.
+type ErrInvalidColumnName struct {
+	Column string
+}
+
+// Error returns the error message on receiving the invalid column name.
+func (err ErrInvalidColumnName) Error() string {
+	return fmt.Sprintf("invalid column name %q", err.Column)
+}
+
+// ErrInvalidRowNumber defined an error of invalid row number.
+type ErrInvalidRowNumber struct {
+	Row int
+}
+
+// Error returns the error message on receiving the invalid row number.
+func (err ErrInvalidRowNumber) Error() string {
+	return fmt.Sprintf("invalid row number %d", err.Row)
+}
+
+// ErrInvalidPartName defined an error of invalid package part name.
+type ErrInvalidPartName struct {
+	Part string
+}
+
+// Error returns the error message on receiving the invalid package part name.
+func (err ErrInvalidPartName) Error() string {
+	return fmt.Sprintf("invalid part name %q", err.Part)
+}
+
+// ErrPartNotExist defined an error of package part that does not exist.
+type ErrPartNotExist struct {
+	Part string
+}
+
+// Error returns the error message on receiving the non existing package part
+// name.
+func (err ErrPartNotExist) Error() string {
+	return fmt.Sprintf("part %s does not exist", err.Part)
+}
+
+// ErrRelationshipNotExist defined an error of relationship that does not
+// exist.
+type ErrRelationshipNotExist struct {
+	RID string
+}
+
+// Error returns the error message on receiving the non existing relationship
+// ID.
+func (err ErrRelationshipNotExist) Error() string {
+	return fmt.Sprintf("relationship %s does not exist", err.RID)
+}
+
+// ErrTableNotExist defined an error of table that does not exist.
+type ErrTableNotExist struct {
+	Name string
+}
+
+// Error returns the error message on receiving the non existing table name.
+func (err ErrTableNotExist) Error() string {
+	return fmt.Sprintf("table %s does not exist", err.Name)
+}
+
+// ErrFormControlNotExist defined an error of form control that does not
+// exist.
+type ErrFormControlNotExist struct {
+	Name string
+}
+
+// Error returns the error message on receiving the non existing form control.
+func (err ErrFormControlNotExist) Error() string {
+	return fmt.Sprintf("form control %s does not exist", err.Name)
+}
+
+// ErrAllowEditRangeNotExist defined an error of allow edit range that does
+// not exist.
+type ErrAllowEditRangeNotExist struct {
+	Title string
+}
+
+// Error returns the error message on receiving the non existing allow edit
+// range title.
+func (err ErrAllowEditRangeNotExist) Error() string {
+	return fmt.Sprintf("allow edit range %s does not exist", err.Title)
+}
+
+// ErrScenarioNotExist defined an error of scenario that does not exist.
+type ErrScenarioNotExist struct {
+	Name string
+}
+
+// Error returns the error message on receiving the non existing scenario name.
+func (err ErrScenarioNotExist) Error() string {
+	return fmt.Sprintf("scenario %s does not exist", err.Name)
+}
+
+// ErrInvalidStyleID defined an error of invalid style ID.
+type ErrInvalidStyleID struct {
+	StyleID int
+}
+
+// Error returns the error message on receiving the invalid style ID.
+func (err ErrInvalidStyleID) Error() string {
+	return fmt.Sprintf("invalid style ID %d", err.StyleID)
+}
+
+// ErrFieldLength defined an error of field length overflow.
+type ErrFieldLength struct {
+	Field string
+}
+
+// Error returns the error message on receiving the field length overflow.
+func (err ErrFieldLength) Error() string {
+	return fmt.Sprintf("field %s must be less than or equal to 255 characters", err.Field)
+}
+
+// BatchOpError defined an error of the set function call in batch mode.
+type BatchOpError struct {
+	Op    string
+	Sheet string
+	Ref   string
+	Err   error
+}
+
+// Error returns the error message of the set function call in batch mode.
+func (err BatchOpError) Error() string {
+	return fmt.Sprintf("%s %s!%s: %v", err.Op, err.Sheet, err.Ref, err.Err)
+}
+
+// Unwrap returns the underlying error of the set function call in batch mode.
+func (err BatchOpError) Unwrap() error {
+	return err.Err
+}
+
+// BatchError defined an error which collected all errors of the set function
+// calls in batch mode.
+type BatchError struct {
+	Errors []error
+}
+
+// Error returns the error message which listing all errors in batch mode.
+func (err BatchError) Error() string {
+	msgs := make([]string, len(err.Errors))
+	for i, e := range err.Errors {
+		msgs[i] = e.Error()
+	}
+	return fmt.Sprintf("%d errors occurred in batch mode:\n%s", len(err.Errors), strings.Join(msgs, "\n"))
+}
+
+// Unwrap returns all errors which collected in batch mode.
+func (err BatchError) Unwrap() []error {
+	return err.Errors
+}
+
+// Is reports whether any of the errors which collected in batch mode matches
+// the target, the errors.Is function doesn't traverse the Unwrap() []error
+// method before Go 1.20.
+func (err BatchError) Is(target error) bool {
+	for _, e := range err.Errors {
+		if errors.Is(e, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first error which collected in batch mode that matches the
+// target, and if one is found, sets the target to that error value and
+// returns true, the errors.As function doesn't traverse the Unwrap() []error
+// method before Go 1.20.
+func (err BatchError) As(target interface{}) bool {
+	for _, e := range err.Errors {
+		if errors.As(e, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrBrokenSheet defined an error of worksheet that could not be loaded on
+// opening the spreadsheet with partial recovery.
+type ErrBrokenSheet struct {
+	SheetName string
+	Part      string
+	Err       error
+}
+
+// Error returns the error message on loading the broken worksheet.
+func (err ErrBrokenSheet) Error() string {
+	return fmt.Sprintf("sheet %s is broken in part %s: %v", err.SheetName, err.Part, err.Err)
+}
+
+// Unwrap returns the underlying error of loading the broken worksheet.
+func (err ErrBrokenSheet) Unwrap() error {
+	return err.Err
+}
+
+// ErrInvalidArrayFormulaRange defined an error of array formula range which
+// doesn't start with the formula cell.
+type ErrInvalidArrayFormulaRange struct {
+	Ref string
+}
+
+// Error returns the error message on receiving the array formula range which
+// doesn't start with the formula cell.
+func (err ErrInvalidArrayFormulaRange) Error() string {
+	return fmt.Sprintf("the array formula range %q must start with the formula cell", err.Ref)
+}
+
+// ErrInvalidAutoFilterColumn defined an error of incorrect column index of
+// the auto filter.
+type ErrInvalidAutoFilterColumn struct {
+	Column string
+}
+
+// Error returns the error message on receiving the incorrect column index of
+// the auto filter.
+func (err ErrInvalidAutoFilterColumn) Error() string {
+	return fmt.Sprintf("incorrect index of column %q", err.Column)
+}
+
+// ErrInvalidAutoFilterExp defined an error of incorrect number of tokens in
+// the criteria expression of the auto filter.
+type ErrInvalidAutoFilterExp struct {
+	Exp string
+}
+
+// Error returns the error message on receiving the incorrect number of tokens
+// in the criteria expression.
+func (err ErrInvalidAutoFilterExp) Error() string {
+	return fmt.Sprintf("incorrect number of tokens in criteria %q", err.Exp)
+}
+
+// ErrInvalidAutoFilterOperator defined an error of invalid operator in the
+// criteria expression of the auto filter.
+type ErrInvalidAutoFilterOperator struct {
+	Operator string
+	Exp      string
+}
+
+// Error returns the error message on receiving the invalid operator in the
+// criteria expression.
+func (err ErrInvalidAutoFilterOperator) Error() string {
+	return fmt.Sprintf("the operator %q in expression %q is not valid in relation to Blanks/NonBlanks", err.Operator, err.Exp)
+}
+
+// ErrInvalidDecimal defined an error of invalid decimal number text.
+type ErrInvalidDecimal struct {
+	Value string
+}
+
+// Error returns the error message on receiving the invalid decimal number
+// text.
+func (err ErrInvalidDecimal) Error() string {
+	return fmt.Sprintf("invalid decimal %q", err.Value)
+}
+
+// ErrInvalidExcelDate defined an error of negative date value.
+type ErrInvalidExcelDate struct {
+	Value float64
+}
+
+// Error returns the error message on receiving the negative date value.
+func (err ErrInvalidExcelDate) Error() string {
+	return fmt.Sprintf("invalid date value %f, negative values are not supported", err.Value)
+}
+
+// ErrInvalidLinkType defined an error of invalid hyperlink type.
+type ErrInvalidLinkType struct {
+	LinkType string
+}
+
+// Error returns the error message on receiving the invalid hyperlink type.
+func (err ErrInvalidLinkType) Error() string {
+	return fmt.Sprintf("invalid link type %q", err.LinkType)
+}
+
+// ErrInvalidName defined an error of invalid defined name or table name.
+type ErrInvalidName struct {
+	Name string
+}
+
+// Error returns the error message on receiving the invalid defined name or
+// table name.
+func (err ErrInvalidName) Error() string {
+	return fmt.Sprintf("invalid name %q, the name should be starts with a letter or underscore, can not include a space or character, and can not conflict with an existing name in the workbook", err.Name)
+}
+
+// ErrInvalidSlicerName defined an error of invalid slicer name.
+type ErrInvalidSlicerName struct {
+	Name string
+}
+
+// Error returns the error message on receiving the invalid slicer name.
+func (err ErrInvalidSlicerName) Error() string {
+	return fmt.Sprintf("invalid slicer name %q", err.Name)
+}
+
+// ErrPivotTableRange defined an error of invalid data range or pivot table
+// range of the pivot table, the underlying error can be retrieved by the
+// errors.Unwrap function.
+type ErrPivotTableRange struct {
+	Parameter string
+	Err       error
+}
+
+// Error returns the error message on receiving the invalid range of the pivot
+// table.
+func (err ErrPivotTableRange) Error() string {
+	return fmt.Sprintf("parameter '%s' parsing error: %v", err.Parameter, err.Err)
+}
+
+// Unwrap returns the underlying error of parsing the range of the pivot table.
+func (err ErrPivotTableRange) Unwrap() error {
+	return err.Err
+}
+
+// ErrStreamSetRow defined an error of the stream writer receiving the row
+// number which has already been written.
+type ErrStreamSetRow struct {
+	Row int
+}
+
+// Error returns the error message on the stream writer receiving the
+// non-ascending row number.
+func (err ErrStreamSetRow) Error() string {
+	return fmt.Sprintf("row %d has already been written", err.Row)
+}
+
+// ErrStreamRowBufferExceeded defined an error of writing a row which is out
+// of the row buffer of the stream writer.
+type ErrStreamRowBufferExceeded struct {
+	Row     int
+	Written int
+}
+
+// Error returns the error message on writing a row which is out of the row
+// buffer of the stream writer.
+func (err ErrStreamRowBufferExceeded) Error() string {
+	return fmt.Sprintf("row %d exceeds the row buffer, rows up to %d have already been written", err.Row, err.Written)
+}
+
+// ErrUnknownFilterToken defined an error of unknown filter operator token.
+type ErrUnknownFilterToken struct {
+	Token string
+}
+
+// Error returns the error message on receiving the unknown filter operator
+// token.
+func (err ErrUnknownFilterToken) Error() string {
+	return fmt.Sprintf("unknown operator: %s", err.Token)
+}
+
+// ErrUnsupportedChartType defined an error of unsupported chart type.
+type ErrUnsupportedChartType struct {
+	ChartType ChartType
+}
+
+// Error returns the error message on receiving the unsupported chart type.
+func (err ErrUnsupportedChartType) Error() string {
+	return fmt.Sprintf("unsupported chart type %d", err.ChartType)
+}
+
+// ErrUnzipSizeLimit defined an error of the unzip size exceeds the limit.
+type ErrUnzipSizeLimit struct {
+	Limit int64
+}
+
+// Error returns the error message on the unzip size exceeds the limit.
+func (err ErrUnzipSizeLimit) Error() string {
+	return fmt.Sprintf("unzip size exceeds the %d bytes limit", err.Limit)
+}
+
+// ErrViewIndex defined an error of sheet view index out of range.
+type ErrViewIndex struct {
+	Index int
+}
+
+// Error returns the error message on receiving the invalid sheet view index.
+func (err ErrViewIndex) Error() string {
+	return fmt.Sprintf("view index %d out of range", err.Index)
+}
+
 // newCellNameToCoordinatesError defined the error message on converts
 // alphanumeric cell name to coordinates.
 func newCellNameToCoordinatesError(cell string, err error) error {
-	return fmt.Errorf("cannot convert cell %q to coordinates: %v", cell, err)
+	return ErrCellNameToCoordinates{Cell: cell, Err: err}
 }
 
 // newCoordinatesToCellNameError defined the error message on converts [X, Y]
 // coordinates to alpha-numeric cell name.
 func newCoordinatesToCellNameError(col, row int) error {
-	return fmt.Errorf("invalid cell reference [%d, %d]", col, row)
+	return ErrCoordinatesToCellName{Col: col, Row: row}
 }
 
 // newFieldLengthError defined the error message on receiving the field length
 // overflow.
 func newFieldLengthError(name string) error {
-	return fmt.Errorf("field %s must be less than or equal to 255 characters", name)
+	return ErrFieldLength{Field: name}
+}
+
+// newInvalidArrayFormulaRangeError defined the error message on receiving the
+// array formula range which doesn't start with the master cell.
+func newInvalidArrayFormulaRangeError(ref string) error {
+	return ErrInvalidArrayFormulaRange{Ref: ref}
 }
 
 // newInvalidAutoFilterColumnError defined the error message on receiving the
 // incorrect index of column.
 func newInvalidAutoFilterColumnError(col string) error {
-	return fmt.Errorf("incorrect index of column %q", col)
+	return ErrInvalidAutoFilterColumn{Column: col}
 }
 
 // newInvalidAutoFilterExpError defined the error message on receiving the
 // incorrect number of tokens in criteria expression.
 func newInvalidAutoFilterExpError(exp string) error {
-	return fmt.Errorf("incorrect number of tokens in criteria %q", exp)
+	return ErrInvalidAutoFilterExp{Exp: exp}
 }
 
 // newInvalidAutoFilterOperatorError defined the error message on receiving the
 // incorrect expression operator.
 func newInvalidAutoFilterOperatorError(op, exp string) error {
-	return fmt.Errorf("the operator %q in expression %q is not valid in relation to Blanks/NonBlanks", op, exp)
+	return ErrInvalidAutoFilterOperator{Operator: op, Exp: exp}
 }
 
 // newInvalidCellNameError defined the error message on receiving the invalid
 // cell name.
 func newInvalidCellNameError(cell string) error {
-	return fmt.Errorf("invalid cell name %q", cell)
+	return ErrInvalidCellName{Cell: cell}
 }
 
 // newInvalidColumnNameError defined the error message on receiving the
 // invalid column name.
 func newInvalidColumnNameError(col string) error {
-	return fmt.Errorf("invalid column name %q", col)
+	return ErrInvalidColumnName{Column: col}
+}
+
+// newInvalidDecimalError defined the error message on receiving the invalid
+// decimal number text.
+func newInvalidDecimalError(value string) error {
+	return ErrInvalidDecimal{Value: value}
 }
 
 // newInvalidExcelDateError defined the error message on receiving the data
 // with negative values.
 func newInvalidExcelDateError(dateValue float64) error {
-	return fmt.Errorf("invalid date value %f, negative values are not supported", dateValue)
+	return ErrInvalidExcelDate{Value: dateValue}
 }
 
 // newInvalidLinkTypeError defined the error message on receiving the invalid
 // hyper link type.
 func newInvalidLinkTypeError(linkType string) error {
-	return fmt.Errorf("invalid link type %q", linkType)
+	return ErrInvalidLinkType{LinkType: linkType}
 }
 
 // newInvalidNameError defined the error message on receiving the invalid
 // defined name or table name.
 func newInvalidNameError(name string) error {
-	return fmt.Errorf("invalid name %q, the name should be starts with a letter or underscore, can not include a space or character, and can not conflict with an existing name in the workbook", name)
+	return ErrInvalidName{Name: name}
+}
+
+// newInvalidPartNameError defined the error message on receiving the invalid
+// package part name.
+func newInvalidPartNameError(name string) error {
+	return ErrInvalidPartName{Part: name}
 }
 
 // newInvalidRowNumberError defined the error message on receiving the invalid
 // row number.
 func newInvalidRowNumberError(row int) error {
-	return fmt.Errorf("invalid row number %d", row)
+	return ErrInvalidRowNumber{Row: row}
 }
 
 // newInvalidSlicerNameError defined the error message on receiving the invalid
 // slicer name.
 func newInvalidSlicerNameError(name string) error {
-	return fmt.Errorf("invalid slicer name %q", name)
+	return ErrInvalidSlicerName{Name: name}
 }
 
 // newInvalidStyleID defined the error message on receiving the invalid style
 // ID.
 func newInvalidStyleID(styleID int) error {
-	return fmt.Errorf("invalid style ID %d", styleID)
+	return ErrInvalidStyleID{StyleID: styleID}
+}
+
+// newNoExistAllowEditRangeError defined the error message on receiving the
+// non existing allow edit range title.
+func newNoExistAllowEditRangeError(title string) error {
+	return ErrAllowEditRangeNotExist{Title: title}
+}
+
+// newNoExistScenarioError defined the error message on receiving the non
+// existing scenario name.
+func newNoExistScenarioError(name string) error {
+	return ErrScenarioNotExist{Name: name}
+}
+
+// newNoExistFormControlError defined the error message on receiving the non
+// existing form control.
+func newNoExistFormControlError(name string) error {
+	return ErrFormControlNotExist{Name: name}
+}
+
+// newNoExistPartError defined the error message on receiving the non existing
+// package part name.
+func newNoExistPartError(name string) error {
+	return ErrPartNotExist{Part: name}
+}
+
+// newNoExistRelationshipError defined the error message on receiving the non
+// existing relationship ID.
+func newNoExistRelationshipError(rID string) error {
+	return ErrRelationshipNotExist{RID: rID}
 }
 
 // newNoExistTableError defined the error message on receiving the non existing
 // table name.
 func newNoExistTableError(name string) error {
-	return fmt.Errorf("table %s does not exist", name)
+	return ErrTableNotExist{Name: name}
+}
+
+// newNotChartSheetError defined the error message on receiving a sheet which
+// not a chart sheet.
+func newNotChartSheetError(name string) error {
+	return ErrNotChartSheet{SheetName: name}
 }
 
 // newNotWorksheetError defined the error message on receiving a sheet which
 // not a worksheet.
 func newNotWorksheetError(name string) error {
-	return fmt.Errorf("sheet %s is not a worksheet", name)
+	return ErrNotWorksheet{SheetName: name}
 }
 
 // newPivotTableDataRangeError defined the error message on receiving the
-// invalid pivot table data range.
-func newPivotTableDataRangeError(msg string) error {
-	return fmt.Errorf("parameter 'DataRange' parsing error: %s", msg)
+// invalid pivot table data range, the underlying error will be wrapped.
+func newPivotTableDataRangeError(err error) error {
+	return ErrPivotTableRange{Parameter: "DataRange", Err: err}
 }
 
 // newPivotTableRangeError defined the error message on receiving the invalid
-// pivot table range.
-func newPivotTableRangeError(msg string) error {
-	return fmt.Errorf("parameter 'PivotTableRange' parsing error: %s", msg)
+// pivot table range, the underlying error will be wrapped.
+func newPivotTableRangeError(err error) error {
+	return ErrPivotTableRange{Parameter: "PivotTableRange", Err: err}
 }
 
 // newStreamSetRowError defined the error message on the stream writer
 // receiving the non-ascending row number.
 func newStreamSetRowError(row int) error {
-	return fmt.Errorf("row %d has already been written", row)
+	return ErrStreamSetRow{Row: row}
+}
+
+// newStreamRowBufferExceededError defined the error message on writing a row
+// which is out of the row buffer of the stream writer.
+func newStreamRowBufferExceededError(row, written int) error {
+	return ErrStreamRowBufferExceeded{Row: row, Written: written}
 }
 
 // newUnknownFilterTokenError defined the error message on receiving a unknown
 // filter operator token.
 func newUnknownFilterTokenError(token string) error {
-	return fmt.Errorf("unknown operator: %s", token)
+	return ErrUnknownFilterToken{Token: token}
 }
 
 // newUnsupportedChartType defined the error message on receiving the chart
 // type are unsupported.
 func newUnsupportedChartType(chartType ChartType) error {
-	return fmt.Errorf("unsupported chart type %d", chartType)
+	return ErrUnsupportedChartType{ChartType: chartType}
 }
 
 // newUnzipSizeLimitError defined the error message on unzip size exceeds the
 // limit.
 func newUnzipSizeLimitError(unzipSizeLimit int64) error {
-	return fmt.Errorf("unzip size exceeds the %d bytes limit", unzipSizeLimit)
+	return ErrUnzipSizeLimit{Limit: unzipSizeLimit}
 }
 
 // newViewIdxError defined the error message on receiving a invalid sheet view
 // index.
 func newViewIdxError(viewIndex int) error {
-	return fmt.Errorf("view index %d out of range", viewIndex)
+	return ErrViewIndex{Index: viewIndex}
 }
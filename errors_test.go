@@ -1,6 +1,7 @@
 package excelize
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -23,3 +24,71 @@ func TestNewInvalidCellNameError(t *testing.T) {
 func TestNewInvalidExcelDateError(t *testing.T) {
 	assert.EqualError(t, newInvalidExcelDateError(-1), "invalid date value -1.000000, negative values are not supported")
 }
+
+func TestTypedErrors(t *testing.T) {
+	f := NewFile()
+	// Test get the structured fields of the typed errors by errors.As
+	_, err := f.GetCellValue("SheetN", "A1")
+	var sheetErr ErrSheetNotExist
+	assert.True(t, errors.As(err, &sheetErr))
+	assert.Equal(t, "SheetN", sheetErr.SheetName)
+	_, err = f.GetCellValue("Sheet1", "A")
+	var cellErr ErrCellNameToCoordinates
+	assert.True(t, errors.As(err, &cellErr))
+	assert.Equal(t, "A", cellErr.Cell)
+	assert.True(t, errors.Is(err, ErrInvalidCellName{Cell: "A"}))
+	assert.Equal(t, ErrInvalidCellName{Cell: "A"}, errors.Unwrap(err))
+	_, err = f.GetPart("xl/unknown.xml")
+	var partErr ErrPartNotExist
+	assert.True(t, errors.As(err, &partErr))
+	assert.Equal(t, "xl/unknown.xml", partErr.Part)
+	// Test match the typed errors wrapped in the pivot table range errors
+	err = f.AddPivotTable(&PivotTableOptions{DataRange: "SheetN!A1:B2", PivotTableRange: "Sheet1!D1:E5"})
+	assert.True(t, errors.Is(err, ErrSheetNotExist{SheetName: "SheetN"}))
+	// Test match the not worksheet error by errors.Is on iterating sheets
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Name: "Sheet1!$A$1", Values: "Sheet1!$B$1:$B$2"}},
+	}))
+	assert.NoError(t, f.SetCellFormula("Sheet2", "A1", "Sheet1!A1+1"))
+	dependents, err := f.GetCellDependents("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Len(t, dependents, 1)
+	// Test the error messages of the typed errors
+	for err, msg := range map[error]string{
+		ErrNotWorksheet{SheetName: "Chart1"}:          "sheet Chart1 is not a worksheet",
+		ErrNotChartSheet{SheetName: "Sheet1"}:         "sheet Sheet1 is not a chart sheet",
+		ErrCoordinatesToCellName{Col: 0, Row: 1}:      "invalid cell reference [0, 1]",
+		ErrInvalidColumnName{Column: "-"}:             "invalid column name \"-\"",
+		ErrInvalidRowNumber{Row: -1}:                  "invalid row number -1",
+		ErrInvalidPartName{Part: "/"}:                 "invalid part name \"/\"",
+		ErrRelationshipNotExist{RID: "rId1"}:          "relationship rId1 does not exist",
+		ErrTableNotExist{Name: "Table1"}:              "table Table1 does not exist",
+		ErrFormControlNotExist{Name: "Button 1"}:      "form control Button 1 does not exist",
+		ErrAllowEditRangeNotExist{Title: "Range1"}:    "allow edit range Range1 does not exist",
+		ErrInvalidStyleID{StyleID: -1}:                "invalid style ID -1",
+		ErrFieldLength{Field: "OddHeader"}:            "field OddHeader must be less than or equal to 255 characters",
+		ErrCellNameToCoordinates{Cell: "A", Err: nil}: "cannot convert cell \"A\" to coordinates: <nil>",
+		ErrInvalidArrayFormulaRange{Ref: "B1:B2"}:     "the array formula range \"B1:B2\" must start with the formula cell",
+		ErrInvalidAutoFilterColumn{Column: "-"}:       "incorrect index of column \"-\"",
+		ErrInvalidAutoFilterExp{Exp: "x"}:             "incorrect number of tokens in criteria \"x\"",
+		ErrInvalidDecimal{Value: "x"}:                 "invalid decimal \"x\"",
+		ErrInvalidLinkType{LinkType: "x"}:             "invalid link type \"x\"",
+		ErrInvalidSlicerName{Name: "x"}:               "invalid slicer name \"x\"",
+		ErrStreamSetRow{Row: 1}:                       "row 1 has already been written",
+		ErrUnknownFilterToken{Token: "x"}:             "unknown operator: x",
+		ErrUnzipSizeLimit{Limit: 1}:                   "unzip size exceeds the 1 bytes limit",
+		ErrViewIndex{Index: 1}:                        "view index 1 out of range",
+	} {
+		assert.EqualError(t, err, msg)
+	}
+	// Test match the wrapped error of the pivot table range by errors.Is
+	err = ErrPivotTableRange{Parameter: "DataRange", Err: ErrParameterInvalid}
+	assert.EqualError(t, err, "parameter 'DataRange' parsing error: parameter is invalid")
+	assert.ErrorIs(t, err, ErrParameterInvalid)
+	var rangeErr ErrPivotTableRange
+	assert.True(t, errors.As(newPivotTableRangeError(ErrParameterInvalid), &rangeErr))
+	assert.Equal(t, "PivotTableRange", rangeErr.Parameter)
+}
@@ -15,7 +15,9 @@ package excelize
 import (
 	"archive/zip"
 	"bytes"
+	"encoding/binary"
 	"encoding/xml"
+	"errors"
 	"io"
 	"os"
 	"path/filepath"
@@ -23,20 +25,27 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/richardlehane/mscfb"
 	"golang.org/x/net/html/charset"
 )
 
 // File define a populated spreadsheet file struct.
 type File struct {
 	mu               sync.Mutex
+	brokenSheets     sync.Map
+	calcFuncs        sync.Map
 	checked          sync.Map
 	formulaChecked   bool
+	lazyMedia        sync.Map
 	options          *Options
 	sharedStringItem [][]uint
 	sharedStringsMap map[string]int
 	sharedStringTemp *os.File
 	sheetMap         map[string]string
+	source           *os.File
 	streams          map[string]*StreamWriter
+	tags             sync.Map
+	tagOptions       *TagOptions
 	tempFiles        sync.Map
 	xmlAttr          sync.Map
 	CalcChain        *xlsxCalcChain
@@ -96,17 +105,148 @@ type charsetTranscoderFn func(charset string, input io.Reader) (rdr io.Reader, e
 // LongTimePattern specifies the long time number format code.
 //
 // CultureInfo specifies the country code for applying built-in language number
-// format code these effect by the system's local language settings.
+// format code these effect by the system's local language settings. The
+// localized function names, boolean and error values, and separators of the
+// formula set by the SetCellFormula function with the Localized option will be
+// translated to the canonical form by the culture, the de-DE, fr-FR and es-ES
+// cultures are supported for the formulas currently.
+//
+// SkipHiddenRows specifies if skip the hidden rows when getting rows by the
+// GetRows function, the skipped rows will not be included in the result.
+//
+// MaxRows specifies the maximum number of rows returned by the GetRows
+// function, the worksheet will not be read after the limit is reached, the
+// default value is 0, which means no limit.
+//
+// ColumnRange specifies the columns range to be read by the GetRows function
+// and the Columns function of the rows iterator, for example "B:D", the cells
+// outside the range will be skipped without parsing, and the first cell in
+// each returned row is the value of the first column in the range.
+//
+// The SkipHiddenRows, MaxRows and ColumnRange are the options of reading rows,
+// which only take effect when specified per call of the GetRows function or
+// the Columns function of the rows iterator, and will be ignored when
+// specified for the workbook by the OpenFile, OpenReader and NewFile
+// functions, and by the other functions.
+//
+// Canonical specifies if write the spreadsheet in canonical form on saving,
+// the root element attributes of the parts will be written in stable order,
+// and the zip entries will be written in fixed order with fixed modification
+// time, so that identical input produces byte-identical output.
+//
+// UpdateModifiedTime specifies if update the modified time of the document
+// core properties with the current time on saving the spreadsheet.
+//
+// ScrubMetadata specifies if scrub the metadata of the spreadsheet on saving
+// for privacy-compliant distribution, the created and modified time of the
+// document core properties will be normalized, the last modified by will be
+// removed, and the relationship IDs of each part will be renumbered in order
+// deterministically. This option takes precedence over UpdateModifiedTime.
+//
+// CellIndex specifies if maintain the index of the rows for the loaded
+// worksheets to speed up the random access of the cells, the index will be
+// built lazily and rebuilt after the structural editing of the worksheet.
+//
+// StyleRegistry specifies the style registry shared across multiple
+// workbooks, the identical style settings created by the NewStyle function
+// will be resolved to the same definition in the registry.
+//
+// PartialRecovery specifies if open the spreadsheet with partial recovery,
+// the corrupt worksheet parts will not cause the opening to fail, all healthy
+// worksheets will be loaded, and the broken worksheets can be retrieved by the
+// BrokenSheets function.
+//
+// CalcTrace specifies the calculation trace for the CalcCellValue function,
+// the functions called, ranges read and the time per node of the evaluation
+// will be recorded in the trace.
+//
+// CalcCache specifies the cache of the intermediate results for the
+// CalcCellValue function, the cache can be shared across multiple
+// CalcCellValue calls on the same workbook.
+//
+// RowSpans specifies if write the spans attribute of the rows on saving the
+// spreadsheet, the spans is an optimization hint which specifies the range of
+// non-empty columns for each block of 16 rows, the spreadsheet applications
+// load the large worksheets faster when the spans are present.
+//
+// AutoDimension specifies if keep the used range of the worksheets accurate,
+// the used range will be recalculated from the cells by the GetSheetDimension
+// function and on saving the spreadsheet.
+//
+// OptimizeForSize specifies if optimize the spreadsheet for the output size on
+// saving, the parts will be compressed with the best compression level, the
+// cells formatted by the identical cell formats will reference the same cell
+// format, and the relationships of each part will be sorted in the output
+// without changing the workbook.
+//
+// LowMemorySave specifies if serialize the loaded worksheets directly into the
+// zip on saving the spreadsheet, instead of building the whole content of each
+// worksheet in memory first, which reduces the peak memory usage by the size
+// of the largest worksheet. The loaded worksheets will be kept in memory after
+// saving. This option will be ignored when the Canonical or ScrubMetadata
+// option is enabled.
+//
+// ProtectedCellGuard specifies if the functions for setting cell values,
+// formulas and rich text, and the functions for clearing cells return the
+// ErrProtectedCell error when modifying the locked cells of a protected
+// worksheet, which simulates the behavior of the spreadsheet application. The
+// cells in the allow edit ranges without password are editable, and the
+// worksheet will not be changed when the modification is rejected.
+//
+// NoFormulaConversion specifies if keep the text values which start with the
+// "=", "+", "-" or "@" characters as text when they are edited in the
+// spreadsheet application, which is a safeguard against the formula
+// injection of the untrusted text. The text values set by the SetCellValue,
+// SetCellStr, SetSheetRow, SetSheetCol functions and the stream writer will be
+// formatted with the quote prefix, the same as the text values starting with
+// an apostrophe entered in the spreadsheet application.
+//
+// ValueSource specifies the source of the formula cell values returned by
+// the GetCellValue, GetCellValues, GetRows, GetCols functions and the rows,
+// columns and cells iterators. The default value ValueSourceCached returns the
+// cached values stored in the workbook, the ValueSourceCalculated recalculates
+// the formula cells by the calculation engine, and the
+// ValueSourceCachedWithFallback recalculates the formula cells only when the
+// cached values are absent. The calculation error of the formula, such as
+// "#DIV/0!", will be returned as the error. Note that each formula cell will
+// be recalculated by the calculation engine one by one, including the formula
+// cells read by the streaming iterators, which is much slower than reading
+// the cached values for a worksheet with a large number of formulas.
+//
+// CalcDecimal specifies if evaluate the addition, subtraction and
+// multiplication arithmetic operations of the formulas with the exact decimal
+// numbers by the CalcCellValue function, the result will be returned in full
+// precision without float64 rounding, instead of rounding to 15 significant
+// digits.
 type Options struct {
-	MaxCalcIterations uint
-	Password          string
-	RawCellValue      bool
-	UnzipSizeLimit    int64
-	UnzipXMLSizeLimit int64
-	ShortDatePattern  string
-	LongDatePattern   string
-	LongTimePattern   string
-	CultureInfo       CultureName
+	MaxCalcIterations   uint
+	Password            string
+	RawCellValue        bool
+	UnzipSizeLimit      int64
+	UnzipXMLSizeLimit   int64
+	ShortDatePattern    string
+	LongDatePattern     string
+	LongTimePattern     string
+	CultureInfo         CultureName
+	SkipHiddenRows      bool
+	MaxRows             int
+	ColumnRange         string
+	Canonical           bool
+	UpdateModifiedTime  bool
+	ScrubMetadata       bool
+	CellIndex           bool
+	StyleRegistry       *StyleRegistry
+	PartialRecovery     bool
+	CalcTrace           *CalcTrace
+	CalcCache           *CalcCache
+	RowSpans            bool
+	AutoDimension       bool
+	OptimizeForSize     bool
+	LowMemorySave       bool
+	ProtectedCellGuard  bool
+	NoFormulaConversion bool
+	ValueSource         ValueSource
+	CalcDecimal         bool
 }
 
 // OpenFile take the name of a spreadsheet file and returns a populated
@@ -115,12 +255,37 @@ type Options struct {
 //
 //	f, err := excelize.OpenFile("Book1.xlsx", excelize.Options{Password: "password"})
 //
-// Close the file by Close function after opening the spreadsheet.
+// The media parts of the unencrypted spreadsheet will be read from the file on
+// demand, so the file will be kept open, and it should not be modified by
+// others until closing the spreadsheet. Close the file by Close function after
+// opening the spreadsheet.
 func OpenFile(filename string, opts ...Options) (*File, error) {
 	file, err := os.Open(filepath.Clean(filename))
 	if err != nil {
 		return nil, err
 	}
+	if zr := newZipFileReader(file); zr != nil {
+		f := newFile()
+		f.options = f.getOptions(opts...)
+		if err = f.checkOpenReaderOptions(); err != nil {
+			_ = file.Close()
+			return nil, err
+		}
+		f.source = file
+		parts, sheetCount, err := f.ReadZipReader(zr)
+		if err != nil {
+			_ = f.Close()
+			return nil, err
+		}
+		if err = f.loadPackage(parts, sheetCount); err != nil {
+			if closeErr := f.Close(); closeErr != nil {
+				return f, closeErr
+			}
+			return f, err
+		}
+		f.Path = filename
+		return f, err
+	}
 	f, err := OpenReader(file, opts...)
 	if err != nil {
 		if closeErr := file.Close(); closeErr != nil {
@@ -132,6 +297,25 @@ func OpenFile(filename string, opts ...Options) (*File, error) {
 	return f, file.Close()
 }
 
+// newZipFileReader provides a function to create the zip reader which reads
+// from the given unencrypted spreadsheet file directly, the nil will be
+// returned if the file is not a zip package.
+func newZipFileReader(file *os.File) *zip.Reader {
+	fi, err := file.Stat()
+	if err != nil {
+		return nil
+	}
+	header := make([]byte, 4)
+	if _, err = file.ReadAt(header, 0); err != nil || !bytes.Equal(header, []byte("PK\x03\x04")) {
+		return nil
+	}
+	zr, err := zip.NewReader(file, fi.Size())
+	if err != nil {
+		return nil
+	}
+	return zr
+}
+
 // newFile is object builder
 func newFile() *File {
 	return &File{
@@ -140,6 +324,7 @@ func newFile() *File {
 		checked:          sync.Map{},
 		sheetMap:         make(map[string]string),
 		tempFiles:        sync.Map{},
+		lazyMedia:        sync.Map{},
 		Comments:         make(map[string]*xlsxComments),
 		Drawings:         sync.Map{},
 		sharedStringsMap: make(map[string]int),
@@ -200,21 +385,75 @@ func OpenReader(r io.Reader, opts ...Options) (*File, error) {
 	if err != nil {
 		return nil, err
 	}
+	return f, f.loadPackage(file, sheetCount)
+}
+
+// loadPackage provides a function to load the parts of the spreadsheet by
+// given parts extracted from the package and the count of worksheets.
+func (f *File) loadPackage(file map[string][]byte, sheetCount int) error {
+	var err error
 	f.SheetCount = sheetCount
 	for k, v := range file {
 		f.Pkg.Store(k, v)
 	}
 	if f.CalcChain, err = f.calcChainReader(); err != nil {
-		return f, err
+		return err
 	}
 	if f.sheetMap, err = f.getSheetMap(); err != nil {
-		return f, err
+		return err
 	}
 	if f.Styles, err = f.stylesReader(); err != nil {
-		return f, err
+		return err
+	}
+	if f.options.PartialRecovery {
+		f.loadHealthySheets()
 	}
 	f.Theme, err = f.themeReader()
-	return f, err
+	return err
+}
+
+// loadHealthySheets provides a function to load all worksheets of the
+// spreadsheet, and mark the worksheets which could not be loaded as broken.
+func (f *File) loadHealthySheets() {
+	for _, sheet := range f.GetSheetList() {
+		name, ok := f.getSheetXMLPath(sheet)
+		if !ok || !strings.HasPrefix(name, "xl/worksheets/") {
+			continue
+		}
+		if v, ok := f.brokenSheets.Load(name); ok {
+			err := v.(ErrBrokenSheet)
+			err.SheetName = sheet
+			f.brokenSheets.Store(name, err)
+			continue
+		}
+		if _, err := f.workSheetReader(sheet); err != nil {
+			f.brokenSheets.Store(name, ErrBrokenSheet{SheetName: sheet, Part: name, Err: err})
+		}
+	}
+}
+
+// BrokenSheets provides a function to get the worksheets which could not be
+// loaded on opening the spreadsheet with the PartialRecovery option, the
+// result is in the order of the sheets in the workbook. For example:
+//
+//	f, err := excelize.OpenFile("Book1.xlsx", excelize.Options{PartialRecovery: true})
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, broken := range f.BrokenSheets() {
+//	    fmt.Println(broken.SheetName, broken.Part, broken.Err)
+//	}
+func (f *File) BrokenSheets() []ErrBrokenSheet {
+	var sheets []ErrBrokenSheet
+	for _, sheet := range f.GetSheetList() {
+		if name, ok := f.getSheetXMLPath(sheet); ok {
+			if v, ok := f.brokenSheets.Load(name); ok {
+				sheets = append(sheets, v.(ErrBrokenSheet))
+			}
+		}
+	}
+	return sheets
 }
 
 // getOptions provides a function to parse the optional settings for open
@@ -271,6 +510,10 @@ func (f *File) workSheetReader(sheet string) (ws *xlsxWorksheet, err error) {
 		ws = worksheet.(*xlsxWorksheet)
 		return
 	}
+	if broken, ok := f.brokenSheets.Load(name); ok {
+		err = broken.(ErrBrokenSheet)
+		return
+	}
 	for _, sheetType := range []string{"xl/chartsheets", "xl/dialogsheet", "xl/macrosheet"} {
 		if strings.HasPrefix(name, sheetType) {
 			err = newNotWorksheetError(sheet)
@@ -386,7 +629,7 @@ func (ws *xlsxWorksheet) checkSheetR0(sheetData *xlsxSheetData, rowData *xlsxRow
 			checkRow(col, row, r0, cell)
 		}
 	}
-	ws.SheetData = *sheetData
+	ws.SheetData, ws.rowIndex = *sheetData, nil
 }
 
 // setRels provides a function to set relationships by given relationship ID,
@@ -484,7 +727,7 @@ func (f *File) UpdateLinkedValue() error {
 	for _, name := range f.GetSheetList() {
 		ws, err := f.workSheetReader(name)
 		if err != nil {
-			if err.Error() == newNotWorksheetError(name).Error() {
+			if errors.Is(err, ErrNotWorksheet{SheetName: name}) {
 				continue
 			}
 			return err
@@ -502,8 +745,10 @@ func (f *File) UpdateLinkedValue() error {
 }
 
 // AddVBAProject provides the method to add vbaProject.bin file which contains
-// functions and/or macros. The file extension should be XLSM or XLTM. For
-// example:
+// functions and/or macros. The file extension should be XLSM or XLTM. This
+// function only checks the compound file signature of the data, use the
+// AddVBAProjectFromReader function to validate the OLE header and the project
+// information of the VBA project. For example:
 //
 //	codeName := "Sheet1"
 //	if err := f.SetSheetProps("Sheet1", &excelize.SheetPropsOptions{
@@ -526,22 +771,68 @@ func (f *File) UpdateLinkedValue() error {
 //	    return
 //	}
 func (f *File) AddVBAProject(file []byte) error {
-	var err error
-	// Check vbaProject.bin exists first.
-	if !bytes.Contains(file, oleIdentifier) {
-		return ErrAddVBAProject
+	_, err := f.addVBAProject(file, false)
+	return err
+}
+
+// AddVBAProjectFromReader provides the method to add the VBA project which
+// contains functions and/or macros from the io.Reader, and returns the
+// properties of the added VBA project. The data should be a compound file
+// which contains the VBA storage, the ErrAddVBAProject error will be returned
+// if the OLE header or the project information is invalid. When replacing the
+// VBA project of the workbook, the existing digital signature parts of the
+// project will be preserved, note that the spreadsheet application will treat
+// the signatures as invalid if the modules have been changed. For example:
+//
+//	file, err := os.Open("vbaProject.bin")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	defer file.Close()
+//	project, err := f.AddVBAProjectFromReader(file)
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	fmt.Println(project.CodePage)
+func (f *File) AddVBAProjectFromReader(r io.Reader) (VBAProject, error) {
+	file, err := io.ReadAll(r)
+	if err != nil {
+		return VBAProject{}, err
+	}
+	return f.addVBAProject(file, true)
+}
+
+// addVBAProject provides a function to validate and add the VBA project by
+// given compound file data, and returns the properties of the VBA project.
+// The OLE header and the project information of the VBA project will be
+// parsed when the strict parameter is true, otherwise only check the compound
+// file signature.
+func (f *File) addVBAProject(file []byte, strict bool) (VBAProject, error) {
+	var (
+		project VBAProject
+		err     error
+	)
+	if !strict && !bytes.Contains(file, oleIdentifier) {
+		return project, ErrAddVBAProject
+	}
+	if strict {
+		if project, err = parseVBAProject(file); err != nil {
+			return project, err
+		}
 	}
 	rels, err := f.relsReader(f.getWorkbookRelsPath())
 	if err != nil {
-		return err
+		return project, err
 	}
 	rels.mu.Lock()
 	defer rels.mu.Unlock()
 	var rID int
-	var ok bool
+	var vbaProjectPath string
 	for _, rel := range rels.Relationships {
-		if rel.Target == "vbaProject.bin" && rel.Type == SourceRelationshipVBAProject {
-			ok = true
+		if rel.Type == SourceRelationshipVBAProject {
+			vbaProjectPath = getRelsPartPath(f.getWorkbookPath(), rel.Target)
 			continue
 		}
 		t, _ := strconv.Atoi(strings.TrimPrefix(rel.ID, "rId"))
@@ -550,15 +841,133 @@ func (f *File) AddVBAProject(file []byte) error {
 		}
 	}
 	rID++
-	if !ok {
+	if vbaProjectPath == "" {
+		vbaProjectPath = "xl/vbaProject.bin"
 		rels.Relationships = append(rels.Relationships, xlsxRelationship{
 			ID:     "rId" + strconv.Itoa(rID),
 			Target: "vbaProject.bin",
 			Type:   SourceRelationshipVBAProject,
 		})
 	}
-	f.Pkg.Store("xl/vbaProject.bin", file)
-	return err
+	if project.Signed, err = f.hasVBAProjectSignature(vbaProjectPath); err != nil {
+		return project, err
+	}
+	f.Pkg.Store(vbaProjectPath, file)
+	return project, err
+}
+
+// hasVBAProjectSignature provides a function to check if the digital
+// signature parts of the VBA project exist by given VBA project part path.
+func (f *File) hasVBAProjectSignature(path string) (bool, error) {
+	rels, err := f.relsReader(getRelsPath(path))
+	if err != nil || rels == nil {
+		return false, err
+	}
+	for _, rel := range rels.Relationships {
+		switch rel.Type {
+		case SourceRelationshipVBAProjectSignature, SourceRelationshipVBAProjectSignatureAgile, SourceRelationshipVBAProjectSignatureV3:
+			if _, ok := f.Pkg.Load(getRelsPartPath(path, rel.Target)); ok {
+				return true, err
+			}
+		}
+	}
+	return false, err
+}
+
+// parseVBAProject provides a function to validate the OLE header of the
+// compound file and parse the properties of the VBA project from the
+// information records in the "VBA/dir" stream.
+func parseVBAProject(file []byte) (VBAProject, error) {
+	var project VBAProject
+	if !bytes.HasPrefix(file, oleIdentifier) {
+		return project, ErrAddVBAProject
+	}
+	doc, err := mscfb.New(bytes.NewReader(file))
+	if err != nil {
+		return project, ErrAddVBAProject
+	}
+	var dir []byte
+	for entry, err := doc.Next(); err == nil; entry, err = doc.Next() {
+		if entry.Name == "dir" && len(entry.Path) > 0 && entry.Path[len(entry.Path)-1] == "VBA" {
+			buf := make([]byte, entry.Size)
+			if _, err = doc.Read(buf); err != nil && err != io.EOF {
+				return project, ErrAddVBAProject
+			}
+			dir = buf
+		}
+	}
+	if dir, err = decompressVBAContainer(dir); err != nil {
+		return project, err
+	}
+	// Each record begins with a 2 bytes identifier and a 4 bytes size, the
+	// PROJECTCODEPAGE record is identified by 0x0003 with 2 bytes value.
+	for len(dir) >= 6 {
+		id, size := binary.LittleEndian.Uint16(dir[:2]), binary.LittleEndian.Uint32(dir[2:6])
+		if uint32(len(dir)-6) < size {
+			break
+		}
+		if id == 0x0003 && size == 2 {
+			project.CodePage = int(binary.LittleEndian.Uint16(dir[6:8]))
+			return project, nil
+		}
+		dir = dir[6+size:]
+	}
+	return project, ErrAddVBAProject
+}
+
+// decompressVBAContainer provides a function to decompress the compressed
+// container of the VBA project streams which compressed by the algorithm in
+// [MS-OVBA] section 2.4.1.
+func decompressVBAContainer(data []byte) ([]byte, error) {
+	if len(data) == 0 || data[0] != 0x01 {
+		return nil, ErrAddVBAProject
+	}
+	var buf []byte
+	for pos := 1; pos < len(data); {
+		if pos+2 > len(data) {
+			return nil, ErrAddVBAProject
+		}
+		header := binary.LittleEndian.Uint16(data[pos : pos+2])
+		chunkEnd := pos + int(header&0x0FFF) + 3
+		if header>>12&0x07 != 0x03 || chunkEnd > len(data) {
+			return nil, ErrAddVBAProject
+		}
+		if pos += 2; header&0x8000 == 0 {
+			buf = append(buf, data[pos:chunkEnd]...)
+			pos = chunkEnd
+			continue
+		}
+		chunkStart := len(buf)
+		for pos < chunkEnd {
+			flags := data[pos]
+			pos++
+			for bit := 0; bit < 8 && pos < chunkEnd; bit++ {
+				if flags&(1<<bit) == 0 {
+					buf = append(buf, data[pos])
+					pos++
+					continue
+				}
+				if pos+2 > chunkEnd {
+					return nil, ErrAddVBAProject
+				}
+				token := int(binary.LittleEndian.Uint16(data[pos : pos+2]))
+				pos += 2
+				bitCount := 4
+				for 1<<bitCount < len(buf)-chunkStart {
+					bitCount++
+				}
+				lengthMask := 0xFFFF >> bitCount
+				length, offset := token&lengthMask+3, token>>(16-bitCount)+1
+				if offset > len(buf)-chunkStart {
+					return nil, ErrAddVBAProject
+				}
+				for i := 0; i < length; i++ {
+					buf = append(buf, buf[len(buf)-offset])
+				}
+			}
+		}
+	}
+	return buf, nil
 }
 
 // setContentTypePartProjectExtensions provides a function to set the content
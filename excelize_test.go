@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"compress/gzip"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"image/color"
 	_ "image/gif"
@@ -18,6 +19,7 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"testing/iotest"
 	"time"
 
 	"github.com/stretchr/testify/assert"
@@ -329,6 +331,68 @@ func TestOpenReader(t *testing.T) {
 	assert.EqualError(t, err, zip.ErrAlgorithm.Error())
 }
 
+func TestOpenReaderPartialRecovery(t *testing.T) {
+	f := NewFile()
+	for _, sheet := range []string{"Sheet2", "Sheet3"} {
+		_, err := f.NewSheet(sheet)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "healthy"))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	// Prepare workbook with a worksheet contains unsupported charset and a
+	// worksheet with unsupported compression algorithm
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	damaged := new(bytes.Buffer)
+	zw := zip.NewWriter(damaged)
+	for _, item := range zr.File {
+		switch item.Name {
+		case "xl/worksheets/sheet2.xml":
+			fi, err := zw.Create(item.Name)
+			assert.NoError(t, err)
+			_, err = fi.Write(MacintoshCyrillicCharset)
+			assert.NoError(t, err)
+		case "xl/worksheets/sheet3.xml":
+			fi, err := zw.CreateRaw(&zip.FileHeader{Name: item.Name, Method: 99})
+			assert.NoError(t, err)
+			_, err = fi.Write([]byte("damaged"))
+			assert.NoError(t, err)
+		default:
+			assert.NoError(t, zw.Copy(item))
+		}
+	}
+	assert.NoError(t, zw.Close())
+
+	_, err = OpenReader(bytes.NewReader(damaged.Bytes()))
+	assert.EqualError(t, err, zip.ErrAlgorithm.Error())
+
+	f, err = OpenReader(bytes.NewReader(damaged.Bytes()), Options{PartialRecovery: true})
+	assert.NoError(t, err)
+	broken := f.BrokenSheets()
+	assert.Len(t, broken, 2)
+	assert.Equal(t, "Sheet2", broken[0].SheetName)
+	assert.Equal(t, "xl/worksheets/sheet2.xml", broken[0].Part)
+	assert.EqualError(t, broken[0].Err, "XML syntax error on line 1: invalid UTF-8")
+	assert.Equal(t, "Sheet3", broken[1].SheetName)
+	assert.Equal(t, "xl/worksheets/sheet3.xml", broken[1].Part)
+	assert.ErrorIs(t, broken[1], zip.ErrAlgorithm)
+	assert.EqualError(t, broken[1], "sheet Sheet3 is broken in part xl/worksheets/sheet3.xml: zip: unsupported compression algorithm")
+	// Test get cell value on healthy and broken worksheets
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "healthy", val)
+	_, err = f.GetCellValue("Sheet2", "A1")
+	assert.Equal(t, broken[0], err)
+	// Test delete the broken worksheets and save the spreadsheet
+	assert.NoError(t, f.DeleteSheet("Sheet2"))
+	assert.NoError(t, f.DeleteSheet("Sheet3"))
+	assert.Empty(t, f.BrokenSheets())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestOpenReaderPartialRecovery.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
 func TestBrokenFile(t *testing.T) {
 	// Test write file with broken file struct
 	f := File{}
@@ -742,10 +806,10 @@ func TestSetCellStyleNumberFormat(t *testing.T) {
 	idxTbl := []int{0, 1, 2, 3, 4, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49}
 	value := []string{"37947.7500001", "-37947.7500001", "0.007", "2.1", "String"}
 	expected := [][]string{
-		{"37947.75", "37948", "37947.75", "37,948", "37,947.75", "3794775%", "3794775.00%", "3.79E+04", "37947 3/4", "37947 3/4", "11-22-03", "22-Nov-03", "22-Nov", "Nov-03", "6:00 PM", "6:00:00 PM", "18:00", "18:00:00", "11/22/03 18:00", "37,948 ", "37,948 ", "37,947.75 ", "37,947.75 ", " 37,948 ", " $37,948 ", " 37,947.75 ", " $37,947.75 ", "00:00", "910746:00:00", "00:00.0", "37947.7500001", "37947.7500001"},
-		{"-37947.75", "-37948", "-37947.75", "-37,948", "-37,947.75", "-3794775%", "-3794775.00%", "-3.79E+04", "-37947 3/4", "-37947 3/4", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "(37,948)", "(37,948)", "(37,947.75)", "(37,947.75)", " (37,948)", " $(37,948)", " (37,947.75)", " $(37,947.75)", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001"},
-		{"0.007", "0", "0.01", "0", "0.01", "1%", "0.70%", "7.00E-03", "0    ", "0    ", "12-30-99", "30-Dec-99", "30-Dec", "Dec-99", "12:10 AM", "12:10:05 AM", "00:10", "00:10:05", "12/30/99 00:10", "0 ", "0 ", "0.01 ", "0.01 ", " 0 ", " $0 ", " 0.01 ", " $0.01 ", "10:05", "0:10:05", "10:04.8", "0.007", "0.007"},
-		{"2.1", "2", "2.10", "2", "2.10", "210%", "210.00%", "2.10E+00", "2 1/9", "2 1/10", "01-01-00", "1-Jan-00", "1-Jan", "Jan-00", "2:24 AM", "2:24:00 AM", "02:24", "02:24:00", "1/1/00 02:24", "2 ", "2 ", "2.10 ", "2.10 ", " 2 ", " $2 ", " 2.10 ", " $2.10 ", "24:00", "50:24:00", "24:00.0", "2.1", "2.1"},
+		{"37947.75", "37948", "37947.75", "37,948", "37,947.75", "3794775%", "3794775.00%", "3.79E+04", "37947 3/4", "37947 3/4", "11-22-03", "22-Nov-03", "22-Nov", "Nov-03", "6:00 PM", "6:00:00 PM", "18:00", "18:00:00", "11/22/03 18:00", "37,948 ", "37,948 ", "37,947.75 ", "37,947.75 ", " 37,948 ", " $37,948 ", " 37,947.75 ", " $37,947.75 ", "00:00", "910746:00:00", "00:00.0", "37.9E+3", "37947.7500001"},
+		{"-37947.75", "-37948", "-37947.75", "-37,948", "-37,947.75", "-3794775%", "-3794775.00%", "-3.79E+04", "-37947 3/4", "-37947 3/4", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "(37,948)", "(37,948)", "(37,947.75)", "(37,947.75)", " (37,948)", " $(37,948)", " (37,947.75)", " $(37,947.75)", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37.9E+3", "-37947.7500001"},
+		{"0.007", "0", "0.01", "0", "0.01", "1%", "0.70%", "7.00E-03", "0    ", "0    ", "12-30-99", "30-Dec-99", "30-Dec", "Dec-99", "12:10 AM", "12:10:05 AM", "00:10", "00:10:05", "12/30/99 00:10", "0 ", "0 ", "0.01 ", "0.01 ", " 0 ", " $0 ", " 0.01 ", " $0.01 ", "10:05", "0:10:05", "10:04.8", "7.0E-3", "0.007"},
+		{"2.1", "2", "2.10", "2", "2.10", "210%", "210.00%", "2.10E+00", "2 1/9", "2 1/10", "01-01-00", "1-Jan-00", "1-Jan", "Jan-00", "2:24 AM", "2:24:00 AM", "02:24", "02:24:00", "1/1/00 02:24", "2 ", "2 ", "2.10 ", "2.10 ", " 2 ", " $2 ", " 2.10 ", " $2.10 ", "24:00", "50:24:00", "24:00.0", "2.1E+0", "2.1"},
 		{"String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", " String ", " String ", " String ", " String ", "String", "String", "String", "String", "String"},
 	}
 
@@ -981,7 +1045,7 @@ func TestSheetVisibility(t *testing.T) {
 
 	assert.NoError(t, f.SetSheetVisible("Sheet2", false))
 	assert.NoError(t, f.SetSheetVisible("Sheet2", false, true))
-	assert.NoError(t, f.SetSheetVisible("Sheet1", false))
+	assert.Equal(t, ErrLastVisibleSheet, f.SetSheetVisible("Sheet1", false))
 	assert.NoError(t, f.SetSheetVisible("Sheet1", true))
 	visible, err := f.GetSheetVisible("Sheet1")
 	assert.Equal(t, true, visible)
@@ -1501,6 +1565,108 @@ func TestAddVBAProject(t *testing.T) {
 	f.Relationships.Delete(defaultXMLPathWorkbookRels)
 	f.Pkg.Store(defaultXMLPathWorkbookRels, MacintoshCyrillicCharset)
 	assert.EqualError(t, f.AddVBAProject(file), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+
+	// Test add VBA project only check the compound file signature
+	f = NewFile()
+	assert.NoError(t, f.AddVBAProject(append(oleIdentifier, make([]byte, 8)...)))
+	assert.NoError(t, f.Close())
+}
+
+func TestAddVBAProjectFromReader(t *testing.T) {
+	f := NewFile()
+	file, err := os.Open(filepath.Join("test", "vbaProject.bin"))
+	assert.NoError(t, err)
+	project, err := f.AddVBAProjectFromReader(file)
+	assert.NoError(t, err)
+	assert.NoError(t, file.Close())
+	assert.Equal(t, VBAProject{CodePage: 936}, project)
+	// Test replace VBA project with digital signatures
+	f.Pkg.Store("xl/_rels/vbaProject.bin.rels", []byte(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="`+SourceRelationshipVBAProjectSignatureAgile+`" Target="vbaProjectSignatureAgile.bin"/></Relationships>`))
+	f.Pkg.Store("xl/vbaProjectSignatureAgile.bin", []byte("signature"))
+	buf, err := os.ReadFile(filepath.Join("test", "vbaProject.bin"))
+	assert.NoError(t, err)
+	project, err = f.AddVBAProjectFromReader(bytes.NewReader(buf))
+	assert.NoError(t, err)
+	assert.Equal(t, VBAProject{CodePage: 936, Signed: true}, project)
+	_, ok := f.Pkg.Load("xl/vbaProjectSignatureAgile.bin")
+	assert.True(t, ok)
+	rels, err := f.relsReader(defaultXMLPathWorkbookRels)
+	assert.NoError(t, err)
+	var count int
+	for _, rel := range rels.Relationships {
+		if rel.Type == SourceRelationshipVBAProject {
+			count++
+		}
+	}
+	assert.Equal(t, 1, count)
+	assert.NoError(t, f.SetWorkbookProps(&WorkbookPropsOptions{CodeName: stringPtr("ThisWorkbook")}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddVBAProjectFromReader.xlsm")))
+	assert.NoError(t, f.Close())
+
+	// Test replace VBA project which located by absolute path
+	f, err = OpenFile(filepath.Join("test", "TestAddVBAProjectFromReader.xlsm"))
+	assert.NoError(t, err)
+	rels, err = f.relsReader(defaultXMLPathWorkbookRels)
+	assert.NoError(t, err)
+	for idx, rel := range rels.Relationships {
+		if rel.Type == SourceRelationshipVBAProject {
+			rels.Relationships[idx].Target = "/xl/vbaProject.bin"
+		}
+	}
+	project, err = f.AddVBAProjectFromReader(bytes.NewReader(buf))
+	assert.NoError(t, err)
+	assert.True(t, project.Signed)
+	assert.Len(t, rels.Relationships, 4)
+	assert.NoError(t, f.Close())
+
+	f = NewFile()
+	// Test add VBA project with reader error
+	_, err = f.AddVBAProjectFromReader(iotest.ErrReader(errors.New("read error")))
+	assert.EqualError(t, err, "read error")
+	// Test add VBA project with unsupported charset VBA project relationships
+	f.Pkg.Store("xl/_rels/vbaProject.bin.rels", MacintoshCyrillicCharset)
+	_, err = f.AddVBAProjectFromReader(bytes.NewReader(buf))
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test add VBA project with invalid compound file
+	_, err = f.AddVBAProjectFromReader(bytes.NewReader(append(oleIdentifier, make([]byte, 8)...)))
+	assert.EqualError(t, err, ErrAddVBAProject.Error())
+	// Test add VBA project without VBA storage
+	raw, err := Encrypt([]byte("Excelize"), &Options{Password: "password"})
+	assert.NoError(t, err)
+	_, err = f.AddVBAProjectFromReader(bytes.NewReader(raw))
+	assert.EqualError(t, err, ErrAddVBAProject.Error())
+	assert.NoError(t, f.Close())
+}
+
+func TestDecompressVBAContainer(t *testing.T) {
+	// Test decompress the compressed chunk
+	buf, err := decompressVBAContainer([]byte{
+		0x01, 0x2F, 0xB0, 0x00, 0x23, 0x61, 0x61, 0x61, 0x62, 0x63, 0x64, 0x65, 0x82, 0x66, 0x00, 0x70, 0x61, 0x67, 0x68,
+		0x69, 0x6A, 0x01, 0x38, 0x08, 0x61, 0x6B, 0x6C, 0x00, 0x30, 0x6D, 0x6E, 0x6F, 0x70, 0x06, 0x71, 0x02, 0x70, 0x04,
+		0x10, 0x72, 0x73, 0x74, 0x75, 0x76, 0x10, 0x77, 0x78, 0x79, 0x7A, 0x00, 0x3C,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "#aaabcdefaaaaghijaaaaaklaaamnopqaaaaaaaaaaaarstuvwxyzaaa", string(buf))
+	// Test decompress the uncompressed chunk
+	buf, err = decompressVBAContainer(append([]byte{0x01, 0xFF, 0x3F}, bytes.Repeat([]byte("a"), 4096)...))
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Repeat("a", 4096), string(buf))
+	// Test decompress the invalid compressed container
+	for _, data := range [][]byte{
+		nil,
+		{0x00},
+		{0x01, 0x00},
+		{0x01, 0x00, 0x00},
+		{0x01, 0x01, 0xB0, 0x01, 0x00},
+		{0x01, 0x02, 0xB0, 0x01, 0x00, 0x00},
+	} {
+		_, err = decompressVBAContainer(data)
+		assert.EqualError(t, err, ErrAddVBAProject.Error())
+	}
+	// Test parse VBA project without code page record
+	_, err = parseVBAProject(oleIdentifier)
+	assert.EqualError(t, err, ErrAddVBAProject.Error())
 }
 
 func TestContentTypesReader(t *testing.T) {
@@ -14,13 +14,37 @@ package excelize
 import (
 	"archive/zip"
 	"bytes"
+	"compress/flate"
 	"encoding/xml"
+	"hash/crc32"
 	"io"
+	"math"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
+	"runtime"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
+)
+
+var (
+	// canonicalModTime defined the fixed modification time of the zip entries
+	// for writing the spreadsheet in canonical form.
+	canonicalModTime = time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC)
+	// xmlAttrRegexp defined the regular expression for matching the attributes
+	// in the start tag of the XML element.
+	xmlAttrRegexp = regexp.MustCompile(`\s[^\s=/>]+\s*=\s*("[^"]*"|'[^']*')`)
+	// zip64PartSize defined the minimum size in bytes of the part in the zip,
+	// which should be written with the ZIP64 extended information in the
+	// local file header.
+	zip64PartSize int64 = math.MaxUint32
+	// maxCompressWorkers defined the maximum number of the workers for
+	// compressing the worksheets of the stream writers in parallel.
+	maxCompressWorkers = 4
 )
 
 // NewFile provides a function to create new file by default template.
@@ -75,6 +99,9 @@ func (f *File) SaveAs(name string, opts ...Options) error {
 	if _, ok := supportedContentTypes[strings.ToLower(filepath.Ext(f.Path))]; !ok {
 		return ErrWorkbookFileFormat
 	}
+	if err := f.detachSource(filepath.Clean(name)); err != nil {
+		return err
+	}
 	file, err := os.OpenFile(filepath.Clean(name), os.O_WRONLY|os.O_TRUNC|os.O_CREATE, os.ModePerm)
 	if err != nil {
 		return err
@@ -100,6 +127,43 @@ func (f *File) Close() error {
 	for _, stream := range f.streams {
 		_ = stream.rawData.Close()
 	}
+	if f.source != nil {
+		if closeErr := f.source.Close(); err == nil {
+			err = closeErr
+		}
+		f.source = nil
+	}
+	return err
+}
+
+// detachSource provides a function to copy the media parts which have not
+// been extracted from the source file into memory, and close the source file
+// if the spreadsheet will be saved to the source file by given path.
+func (f *File) detachSource(name string) error {
+	if f.source == nil {
+		return nil
+	}
+	fi, err := os.Stat(name)
+	if err != nil {
+		return nil
+	}
+	sourceInfo, err := f.source.Stat()
+	if err != nil || !os.SameFile(fi, sourceInfo) {
+		return err
+	}
+	f.lazyMedia.Range(func(path, file interface{}) bool {
+		var media *zip.File
+		if media, err = newLazyMediaPart(file.(*zip.File)); err != nil {
+			return false
+		}
+		f.lazyMedia.Store(path, media)
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	err = f.source.Close()
+	f.source = nil
 	return err
 }
 
@@ -123,6 +187,14 @@ func (f *File) WriteTo(w io.Writer, opts ...Options) (int64, error) {
 			return 0, err
 		}
 	}
+	if opts := f.takeTagOptions(); opts != nil {
+		cp, err := f.copyWithTags(opts)
+		if err != nil {
+			return 0, err
+		}
+		defer cp.Close()
+		return cp.WriteTo(w)
+	}
 	if f.options != nil && f.options.Password != "" {
 		buf, err := f.WriteToBuffer()
 		if err != nil {
@@ -139,6 +211,14 @@ func (f *File) WriteTo(w io.Writer, opts ...Options) (int64, error) {
 // WriteToBuffer provides a function to get bytes.Buffer from the saved file,
 // and it allocates space in memory. Be careful when the file size is large.
 func (f *File) WriteToBuffer() (*bytes.Buffer, error) {
+	if opts := f.takeTagOptions(); opts != nil {
+		cp, err := f.copyWithTags(opts)
+		if err != nil {
+			return new(bytes.Buffer), err
+		}
+		defer cp.Close()
+		return cp.WriteToBuffer()
+	}
 	buf := new(bytes.Buffer)
 	zw := zip.NewWriter(buf)
 
@@ -173,6 +253,11 @@ func (f *File) writeDirectToWriter(w io.Writer) error {
 
 // writeToZip provides a function to write to zip.Writer
 func (f *File) writeToZip(zw *zip.Writer) error {
+	if f.options != nil && f.options.OptimizeForSize {
+		zw.RegisterCompressor(zip.Deflate, func(out io.Writer) (io.WriteCloser, error) {
+			return flate.NewWriter(out, flate.BestCompression)
+		})
+	}
 	f.calcChainWriter()
 	f.commentsWriter()
 	f.contentTypesWriter()
@@ -180,6 +265,7 @@ func (f *File) writeToZip(zw *zip.Writer) error {
 	f.volatileDepsWriter()
 	f.vmlDrawingWriter()
 	f.workBookWriter()
+	f.docPropsWriter()
 	f.workSheetWriter()
 	f.relsWriter()
 	_ = f.sharedStringsLoader()
@@ -187,54 +273,965 @@ func (f *File) writeToZip(zw *zip.Writer) error {
 	f.styleSheetWriter()
 	f.themeWriter()
 
-	for path, stream := range f.streams {
-		fi, err := zw.Create(path)
-		if err != nil {
-			return err
-		}
-		var from io.Reader
-		if from, err = stream.rawData.Reader(); err != nil {
-			_ = stream.rawData.Close()
-			return err
-		}
-		if _, err = io.Copy(fi, from); err != nil {
-			return err
-		}
+	scrubbed := f.scrubMetadata()
+	if f.options != nil && f.options.Canonical {
+		return f.writeCanonicalZip(zw, scrubbed)
+	}
+	if err := f.writeStreams(zw); err != nil {
+		return err
 	}
 	var (
 		err              error
 		files, tempFiles []string
+		worksheets       = map[string]*xlsxWorksheet{}
 	)
+	remap := f.getOptimizedCellXfs()
+	if f.isLowMemorySave() {
+		f.Sheet.Range(func(path, ws interface{}) bool {
+			if _, ok := f.streams[path.(string)]; !ok && ws != nil {
+				worksheets[path.(string)] = ws.(*xlsxWorksheet)
+				files = append(files, path.(string))
+			}
+			return true
+		})
+	}
 	f.Pkg.Range(func(path, content interface{}) bool {
 		if _, ok := f.streams[path.(string)]; ok {
 			return true
 		}
+		if _, ok := worksheets[path.(string)]; ok {
+			return true
+		}
 		files = append(files, path.(string))
 		return true
 	})
 	sort.Sort(sort.Reverse(sort.StringSlice(files)))
 	for _, path := range files {
-		var fi io.Writer
-		if fi, err = zw.Create(path); err != nil {
-			break
+		if ws, ok := worksheets[path]; ok {
+			if err = f.writeWorksheetPart(zw, path, ws, remap); err != nil {
+				break
+			}
+			continue
 		}
 		content, _ := f.Pkg.Load(path)
-		_, err = fi.Write(content.([]byte))
+		if b, ok := scrubbed[path]; ok {
+			content = b
+		}
+		b, _ := content.([]byte)
+		if err = f.writeZipPart(zw, &zip.FileHeader{Name: path, Method: zip.Deflate},
+			bytes.NewReader(b)); err != nil {
+			break
+		}
 	}
 	f.tempFiles.Range(func(path, content interface{}) bool {
 		if _, ok := f.Pkg.Load(path); ok {
 			return true
 		}
+		if _, ok := worksheets[path.(string)]; ok {
+			return true
+		}
 		tempFiles = append(tempFiles, path.(string))
 		return true
 	})
 	sort.Sort(sort.Reverse(sort.StringSlice(tempFiles)))
 	for _, path := range tempFiles {
-		var fi io.Writer
-		if fi, err = zw.Create(path); err != nil {
+		if err = f.writeZipPart(zw, &zip.FileHeader{Name: path, Method: zip.Deflate},
+			bytes.NewReader(f.readBytes(path))); err != nil {
 			break
 		}
-		_, err = fi.Write(f.readBytes(path))
+	}
+	if err != nil {
+		return err
+	}
+	return f.writeLazyMedia(zw)
+}
+
+// compressionLevel provides a function to get the deflate compression level
+// of the parts, the best compression level will be used when the
+// OptimizeForSize option was enabled.
+func (f *File) compressionLevel() int {
+	if f.options != nil && f.options.OptimizeForSize {
+		return flate.BestCompression
+	}
+	return flate.DefaultCompression
+}
+
+// getOptimizedCellXfs provides a function to get the mapping from the cell
+// formats to the first identical cell format when the OptimizeForSize option
+// was enabled, the cells, rows and columns will be serialized with the mapped
+// cell formats without changing the worksheets.
+func (f *File) getOptimizedCellXfs() map[int]int {
+	if f.options == nil || !f.options.OptimizeForSize || f.Styles == nil || f.Styles.CellXfs == nil {
+		return nil
+	}
+	f.Styles.mu.Lock()
+	defer f.Styles.mu.Unlock()
+	var (
+		seen  = make(map[string]int)
+		remap = make(map[int]int)
+	)
+	for i, xf := range f.Styles.CellXfs.Xf {
+		key, _ := xml.Marshal(xf)
+		if idx, ok := seen[string(key)]; ok {
+			remap[i] = idx
+			continue
+		}
+		seen[string(key)] = i
+	}
+	return remap
+}
+
+// getOptimizedRelationships provides a function to get a copy of the
+// relationships sorted by the relationship ID when the OptimizeForSize option
+// was enabled, otherwise the given relationships will be returned.
+func (f *File) getOptimizedRelationships(rels *xlsxRelationships) *xlsxRelationships {
+	if f.options == nil || !f.options.OptimizeForSize {
+		return rels
+	}
+	rels.mu.Lock()
+	defer rels.mu.Unlock()
+	sorted := &xlsxRelationships{XMLName: rels.XMLName, Relationships: make([]xlsxRelationship, len(rels.Relationships))}
+	copy(sorted.Relationships, rels.Relationships)
+	sort.SliceStable(sorted.Relationships, func(i, j int) bool {
+		idi, _ := strconv.Atoi(strings.TrimPrefix(sorted.Relationships[i].ID, "rId"))
+		idj, _ := strconv.Atoi(strings.TrimPrefix(sorted.Relationships[j].ID, "rId"))
+		return idi < idj
+	})
+	return sorted
+}
+
+// writeLazyMedia provides a function to copy the media parts which have not
+// been extracted from the source package to zip.Writer without decompressing.
+func (f *File) writeLazyMedia(zw *zip.Writer) error {
+	var (
+		err   error
+		media []string
+	)
+	f.lazyMedia.Range(func(path, file interface{}) bool {
+		if _, ok := f.Pkg.Load(path); !ok {
+			media = append(media, path.(string))
+		}
+		return true
+	})
+	sort.Strings(media)
+	for _, path := range media {
+		file, _ := f.lazyMedia.Load(path)
+		if err = copyZipPart(zw, file.(*zip.File)); err != nil {
+			break
+		}
+	}
+	return err
+}
+
+// writeZipPart provides a function to write the part to zip.Writer by given
+// file header and the reader of the part content. If the size of the part
+// reaches the zip64PartSize, the part will be compressed into the system
+// temporary file before writing, so that the checksum and sizes with the
+// ZIP64 extended information could be written in the local file header
+// instead of the data descriptor, the spreadsheet applications refuse to open
+// the package without that.
+func (f *File) writeZipPart(zw *zip.Writer, fh *zip.FileHeader, r io.Reader) error {
+	if sr, ok := r.(interface{ Size() int64 }); !ok || sr.Size() < zip64PartSize {
+		fi, err := zw.CreateHeader(fh)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(fi, r)
+		return err
+	}
+	var part compressedPart
+	part.deflate(fh, r, f.compressionLevel())
+	return part.writeTo(zw)
+}
+
+// copyZipPart provides a function to copy the compressed part from the zip
+// reader to zip.Writer. If the size of the part reaches the zip64PartSize,
+// the sizes will be written in the local file header instead of the data
+// descriptor.
+func copyZipPart(zw *zip.Writer, file *zip.File) error {
+	if file.CompressedSize64 < uint64(zip64PartSize) && file.UncompressedSize64 < uint64(zip64PartSize) {
+		return zw.Copy(file)
+	}
+	r, err := file.OpenRaw()
+	if err != nil {
+		return err
+	}
+	fh := file.FileHeader
+	fh.Flags &^= 0x8
+	fi, err := zw.CreateRaw(&fh)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(fi, r)
+	return err
+}
+
+// writeStreams provides a function to write the worksheets of the stream
+// writers to zip.Writer. If there are multiple stream writers, the worksheets
+// will be compressed into the system temporary files in parallel by a small
+// worker pool, and each of them will be written to the zip by the order of the
+// part name as soon as it is ready, the number of the parts which are being
+// compressed or waiting to be written will not exceed the number of workers.
+func (f *File) writeStreams(zw *zip.Writer) error {
+	if len(f.streams) < 2 {
+		for path, stream := range f.streams {
+			from, err := stream.rawData.Reader()
+			if err != nil {
+				_ = stream.rawData.Close()
+				return err
+			}
+			if err = f.writeZipPart(zw, &zip.FileHeader{Name: path, Method: zip.Deflate}, from); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	paths := make([]string, 0, len(f.streams))
+	for path := range f.streams {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	workers := runtime.NumCPU()
+	if workers > maxCompressWorkers {
+		workers = maxCompressWorkers
+	}
+	var (
+		err   error
+		sem   = make(chan struct{}, workers)
+		parts = make([]compressedPart, len(paths))
+		done  = make([]chan struct{}, len(paths))
+		level = f.compressionLevel()
+	)
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+	go func() {
+		for i, path := range paths {
+			sem <- struct{}{}
+			go func(part *compressedPart, path string, stream *StreamWriter, done chan struct{}) {
+				defer close(done)
+				part.compress(path, stream, level)
+			}(&parts[i], path, f.streams[path], done[i])
+		}
+	}()
+	for i := range parts {
+		<-done[i]
+		if err != nil {
+			parts[i].close()
+		} else {
+			err = parts[i].writeTo(zw)
+		}
+		<-sem
+	}
+	return err
+}
+
+// compressedPart directly maps the deflate compressed content of a part in
+// the zip which stored in the system temporary file, with the file header of
+// the part.
+type compressedPart struct {
+	header *zip.FileHeader
+	tmp    *os.File
+	err    error
+}
+
+// compress provides a function to compress the worksheet of the stream writer
+// with deflate method by given compression level, and calculate the checksum
+// and sizes for the file header of the part.
+func (p *compressedPart) compress(path string, stream *StreamWriter, level int) {
+	from, err := stream.rawData.Reader()
+	if err != nil {
+		_ = stream.rawData.Close()
+		p.err = err
+		return
+	}
+	p.deflate(&zip.FileHeader{Name: path, Method: zip.Deflate}, from, level)
+}
+
+// deflate provides a function to compress the content of the given reader
+// with deflate method into the system temporary file by given compression
+// level, and set the checksum and sizes of the given file header as the file
+// header of the part.
+func (p *compressedPart) deflate(fh *zip.FileHeader, r io.Reader, level int) {
+	if p.tmp, p.err = os.CreateTemp(os.TempDir(), "excelize-"); p.err != nil {
+		return
+	}
+	fw, _ := flate.NewWriter(p.tmp, level)
+	crc := crc32.NewIEEE()
+	size, err := io.Copy(io.MultiWriter(fw, crc), r)
+	if err != nil {
+		p.err = err
+		return
+	}
+	if p.err = fw.Close(); p.err != nil {
+		return
+	}
+	compressedSize, err := p.tmp.Seek(0, io.SeekCurrent)
+	if err != nil {
+		p.err = err
+		return
+	}
+	fh.Method, fh.CRC32 = zip.Deflate, crc.Sum32()
+	fh.CompressedSize64, fh.UncompressedSize64 = uint64(compressedSize), uint64(size)
+	p.header = fh
+}
+
+// writeTo provides a function to write the compressed part to zip.Writer,
+// and remove the system temporary file of the part.
+func (p *compressedPart) writeTo(zw *zip.Writer) error {
+	defer p.close()
+	if p.err != nil {
+		return p.err
+	}
+	fi, err := zw.CreateRaw(p.header)
+	if err != nil {
+		return err
+	}
+	if _, err = p.tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	_, err = io.Copy(fi, p.tmp)
+	return err
+}
+
+// close provides a function to close and remove the system temporary file of
+// the compressed part.
+func (p *compressedPart) close() {
+	if p.tmp != nil {
+		_ = p.tmp.Close()
+		_ = os.Remove(p.tmp.Name())
+		p.tmp = nil
+	}
+}
+
+// writeCanonicalZip provides a function to write all parts of the spreadsheet
+// to zip.Writer in canonical order with the fixed modification time, the
+// content types part and the package relationships part will be written
+// first, and the other parts will be sorted by the part name. The scrubbed
+// parts content will be written instead of the content in the package.
+func (f *File) writeCanonicalZip(zw *zip.Writer, scrubbed map[string][]byte) error {
+	var paths []string
+	for path := range f.streams {
+		paths = append(paths, path)
+	}
+	f.Pkg.Range(func(path, content interface{}) bool {
+		if _, ok := f.streams[path.(string)]; !ok {
+			paths = append(paths, path.(string))
+		}
+		return true
+	})
+	f.tempFiles.Range(func(path, content interface{}) bool {
+		if _, ok := f.Pkg.Load(path); !ok {
+			if _, ok = f.streams[path.(string)]; !ok {
+				paths = append(paths, path.(string))
+			}
+		}
+		return true
+	})
+	f.lazyMedia.Range(func(path, file interface{}) bool {
+		if _, ok := f.Pkg.Load(path); !ok {
+			paths = append(paths, path.(string))
+		}
+		return true
+	})
+	priority := map[string]int{defaultXMLPathContentTypes: 1, "_rels/.rels": 2}
+	sort.Slice(paths, func(i, j int) bool {
+		if pi, pj := priority[paths[i]], priority[paths[j]]; pi != pj {
+			return pi != 0 && (pj == 0 || pi < pj)
+		}
+		return paths[i] < paths[j]
+	})
+	for _, path := range paths {
+		fh := &zip.FileHeader{Name: path, Method: zip.Deflate, Modified: canonicalModTime}
+		if stream, ok := f.streams[path]; ok {
+			from, err := stream.rawData.Reader()
+			if err != nil {
+				_ = stream.rawData.Close()
+				return err
+			}
+			if err = f.writeZipPart(zw, fh, from); err != nil {
+				return err
+			}
+			continue
+		}
+		content, ok := f.Pkg.Load(path)
+		if !ok {
+			content = f.readBytes(path)
+		}
+		if b, ok := scrubbed[path]; ok {
+			content = b
+		}
+		b, _ := content.([]byte)
+		if err := f.writeZipPart(zw, fh, bytes.NewReader(b)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scrubMetadata provides a function to get the content of the parts with
+// metadata scrubbed when the ScrubMetadata option was enabled. The created
+// and modified time of the document core properties will be normalized, the
+// last modified by will be removed, and the relationship IDs of each part
+// will be renumbered in order. The content in the package will not be
+// changed, so that the parts in memory are keep consistent with the
+// relationships.
+func (f *File) scrubMetadata() map[string][]byte {
+	scrubbed := map[string][]byte{}
+	if f.options == nil || !f.options.ScrubMetadata {
+		return scrubbed
+	}
+	if core, err := f.docPropsCoreReader(); err == nil {
+		modTime := canonicalModTime.Format("2006-01-02T15:04:05Z")
+		core.Created = &xlsxDcTerms{Type: "dcterms:W3CDTF", Text: modTime}
+		core.Modified = &xlsxDcTerms{Type: "dcterms:W3CDTF", Text: modTime}
+		core.LastModifiedBy = ""
+		scrubbed[defaultXMLPathDocPropsCore], _ = xml.Marshal(core)
+	}
+	var relsPaths []string
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.HasSuffix(k.(string), ".rels") {
+			relsPaths = append(relsPaths, k.(string))
+		}
+		return true
+	})
+	for _, relsPath := range relsPaths {
+		dir, name := path.Split(relsPath)
+		if !strings.HasSuffix(dir, "_rels/") || name == ".rels" {
+			continue
+		}
+		partPath := path.Join(strings.TrimSuffix(dir, "_rels/"), strings.TrimSuffix(name, ".rels"))
+		if _, ok := f.streams[partPath]; ok {
+			continue
+		}
+		part, ok := f.Pkg.Load(partPath)
+		if !ok {
+			continue
+		}
+		rels, content := new(xlsxRelationships), f.readXML(relsPath)
+		if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content))).
+			Decode(rels); err != nil && err != io.EOF {
+			continue
+		}
+		relsIDs := map[string]string{}
+		for i := range rels.Relationships {
+			rID := "rId" + strconv.Itoa(i+1)
+			relsIDs[rels.Relationships[i].ID], rels.Relationships[i].ID = rID, rID
+		}
+		if len(relsIDs) != len(rels.Relationships) {
+			continue
+		}
+		content, ok = replaceRelationshipIDs(part.([]byte), relsIDs)
+		if !ok {
+			continue
+		}
+		scrubbed[relsPath], _ = xml.Marshal(rels)
+		scrubbed[partPath] = content
+	}
+	return scrubbed
+}
+
+// checkPartName provides a function to check the package part name, the part
+// name should be a relative path in the package without leading slash, and
+// should not be the content types part.
+func checkPartName(name string) error {
+	if name == "" || name == defaultXMLPathContentTypes || strings.HasSuffix(name, "/") ||
+		strings.Contains(name, "\\") || path.Clean("/"+name) != "/"+name {
+		return newInvalidPartNameError(name)
+	}
+	return nil
+}
+
+// SetPart provides a function to add or replace a part in the package by
+// given part name, content, content type and optional relationship settings.
+// The part name is the path of the part in the package without leading slash,
+// for example "customXml/item1.xml". The override content type of the part
+// will be set when the content type is not empty, and the content type is
+// required for a new part unless the default content type of the part
+// extension exists. The part must be referenced by a relationship to be
+// recognized by the spreadsheet applications, set the RelationshipType of the
+// options to add the relationship from the RelationshipSource part to the
+// part when it doesn't exist. On replacing an exist part, the relationships
+// of the part which are no longer referenced by the new content will be
+// removed. Note that the parts managed by excelize, such as worksheets,
+// styles, and so on, will be overwritten by the serialized data on saving the
+// workbook. For example, add a vendor-specific part referenced by the
+// workbook:
+//
+//	err := f.SetPart("vendor/settings.xml", []byte(`<settings/>`),
+//	    "application/vnd.vendor.settings+xml", excelize.PartOptions{
+//	        RelationshipSource: "xl/workbook.xml",
+//	        RelationshipType:   "http://schemas.vendor.com/relationships/settings",
+//	    })
+func (f *File) SetPart(name string, content []byte, contentType string, opts ...PartOptions) error {
+	if err := checkPartName(name); err != nil {
+		return err
+	}
+	var options PartOptions
+	for _, opt := range opts {
+		options = opt
+	}
+	if options.RelationshipType != "" {
+		if err := f.checkRelationshipSource(options.RelationshipSource); err != nil {
+			return err
+		}
+	}
+	_, inPkg := f.Pkg.Load(name)
+	_, inTemp := f.tempFiles.Load(name)
+	exist := inPkg || inTemp || f.hasMedia(name)
+	ct, err := f.contentTypesReader()
+	if err != nil {
+		return err
+	}
+	if err = ct.setPartContentType(name, contentType, exist); err != nil {
+		return err
+	}
+	if exist {
+		if err = f.removeUnusedPartRelationships(name, content); err != nil {
+			return err
+		}
+	}
+	if strings.HasSuffix(name, ".rels") {
+		f.Relationships.Delete(name)
+	}
+	f.Pkg.Store(name, content)
+	if options.RelationshipType == "" {
+		return nil
+	}
+	return f.addPartRelationship(options.RelationshipSource, options.RelationshipType, name)
+}
+
+// setPartContentType provides a function to set the override content type of
+// the part by given part name and content type. The exist override or the
+// default content type of the part extension will be used if the content type
+// is empty, and an error will be returned if both of them don't exist for a
+// new part.
+func (ct *xlsxTypes) setPartContentType(name, contentType string, exist bool) error {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+	if contentType == "" {
+		if exist {
+			return nil
+		}
+		for _, override := range ct.Overrides {
+			if override.PartName == "/"+name {
+				return nil
+			}
+		}
+		ext := strings.TrimPrefix(path.Ext(name), ".")
+		for _, def := range ct.Defaults {
+			if ext != "" && strings.EqualFold(def.Extension, ext) {
+				return nil
+			}
+		}
+		return ErrParameterRequired
+	}
+	for i, override := range ct.Overrides {
+		if override.PartName == "/"+name {
+			ct.Overrides[i].ContentType = contentType
+			return nil
+		}
+	}
+	ct.Overrides = append(ct.Overrides, xlsxOverride{PartName: "/" + name, ContentType: contentType})
+	return nil
+}
+
+// removeUnusedPartRelationships provides a function to remove the
+// relationships of the part which are no longer referenced by the given new
+// content of the part. The relationships part will be removed if there are no
+// relationships left. The parts serialized by excelize will be skipped.
+func (f *File) removeUnusedPartRelationships(name string, content []byte) error {
+	if strings.HasSuffix(name, ".rels") {
+		return nil
+	}
+	_, inSheet := f.Sheet.Load(name)
+	_, inDrawing := f.Drawings.Load(name)
+	if inSheet || inDrawing {
+		return nil
+	}
+	relsPath := getPartRelsPath(name)
+	rels, err := f.relsReader(relsPath)
+	if err != nil || rels == nil {
+		return err
+	}
+	rels.mu.Lock()
+	defer rels.mu.Unlock()
+	rIDs, ok := getRelationshipIDs(content)
+	if !ok {
+		return err
+	}
+	var relationships []xlsxRelationship
+	for _, rel := range rels.Relationships {
+		if rIDs[rel.ID] {
+			relationships = append(relationships, rel)
+		}
+	}
+	rels.Relationships = relationships
+	if len(relationships) == 0 {
+		f.Relationships.Delete(relsPath)
+		f.Pkg.Delete(relsPath)
 	}
 	return err
 }
+
+// getRelationshipIDs provides a function to get the relationship IDs which
+// referenced by the attributes in the relationships namespace, or the legacy
+// VML relid attributes in the given XML content. The false will be returned if
+// the content is not a valid XML document.
+func getRelationshipIDs(content []byte) (map[string]bool, bool) {
+	rIDs := make(map[string]bool)
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	decoder.Strict = false
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return rIDs, true
+		}
+		if err != nil {
+			return rIDs, false
+		}
+		if se, ok := token.(xml.StartElement); ok {
+			for _, attr := range se.Attr {
+				if isRelationshipIDAttr(attr.Name) {
+					rIDs[attr.Value] = true
+				}
+			}
+		}
+	}
+}
+
+// replaceRelationshipIDs provides a function to replace the relationship IDs
+// which referenced by the attributes in the given XML content with the given
+// map of the relationship IDs, the attributes are matched in the same way as
+// the getRelationshipIDs function, and the other content will be kept as is.
+// The false will be returned if the content is not a valid XML document.
+func replaceRelationshipIDs(content []byte, rIDs map[string]string) ([]byte, bool) {
+	var (
+		buf     bytes.Buffer
+		written int64
+		decoder = xml.NewDecoder(bytes.NewReader(content))
+	)
+	decoder.Strict = false
+	for {
+		start := decoder.InputOffset()
+		token, err := decoder.Token()
+		if err == io.EOF {
+			buf.Write(content[written:])
+			return buf.Bytes(), true
+		}
+		if err != nil {
+			return content, false
+		}
+		se, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		tag := content[start:decoder.InputOffset()]
+		attrs := xmlAttrRegexp.FindAllSubmatchIndex(tag, -1)
+		if len(attrs) != len(se.Attr) {
+			continue
+		}
+		for i, attr := range se.Attr {
+			rID, ok := rIDs[attr.Value]
+			if !ok || !isRelationshipIDAttr(attr.Name) {
+				continue
+			}
+			valueStart, valueEnd := start+int64(attrs[i][2])+1, start+int64(attrs[i][3])-1
+			buf.Write(content[written:valueStart])
+			buf.WriteString(rID)
+			written = valueEnd
+		}
+	}
+}
+
+// isRelationshipIDAttr provides a function to check if the given attribute
+// name references to the relationship ID.
+func isRelationshipIDAttr(name xml.Name) bool {
+	switch name.Space {
+	case SourceRelationship.Value, StrictSourceRelationship:
+		return true
+	case NameSpaceOffice.Value:
+		return name.Local == "relid"
+	}
+	return false
+}
+
+// addPartRelationship provides a function to add the relationship from the
+// source part to the given part name with given relationship type if the
+// relationship doesn't exist, the empty source part name represents the
+// package.
+func (f *File) addPartRelationship(source, relType, name string) error {
+	relsPath := getPartRelsPath(source)
+	rels, err := f.relsReader(relsPath)
+	if err != nil {
+		return err
+	}
+	if rels != nil {
+		rels.mu.Lock()
+		for _, rel := range rels.Relationships {
+			if rel.Type == relType && getRelationshipTargetPart(relsPath, rel) == "/"+name {
+				rels.mu.Unlock()
+				return err
+			}
+		}
+		rels.mu.Unlock()
+	}
+	target := name
+	if source != "" {
+		target = relativePartPath(path.Dir(source), name)
+	}
+	f.addRels(relsPath, relType, target, "")
+	return err
+}
+
+// relativePartPath provides a function to get the relative path of the part
+// name from the given directory of the source part, both of them are the
+// slash-separated part names in the package.
+func relativePartPath(dir, name string) string {
+	base := strings.Split(path.Clean(dir), "/")
+	target := strings.Split(path.Clean(name), "/")
+	if dir == "." || dir == "" {
+		base = nil
+	}
+	var i int
+	for i < len(base) && i < len(target)-1 && base[i] == target[i] {
+		i++
+	}
+	return strings.Repeat("../", len(base)-i) + strings.Join(target[i:], "/")
+}
+
+// getRelationshipTargetPart provides a function to get the absolute target
+// part name of the internal relationship in the given relationships part.
+func getRelationshipTargetPart(relsPart string, rel xlsxRelationship) string {
+	if strings.HasPrefix(rel.Target, "/") {
+		return path.Clean(rel.Target)
+	}
+	return path.Join("/", path.Dir(path.Dir(relsPart)), rel.Target)
+}
+
+// GetPart provides a function to get the content of a part in the package by
+// given part name. Note that the content of the parts managed by excelize
+// reflects the latest saved state. For example, get the custom XML part:
+//
+//	content, err := f.GetPart("customXml/item1.xml")
+func (f *File) GetPart(name string) ([]byte, error) {
+	if err := checkPartName(name); err != nil {
+		return nil, err
+	}
+	_, inPkg := f.Pkg.Load(name)
+	_, inTemp := f.tempFiles.Load(name)
+	if !inPkg && !inTemp && !f.hasMedia(name) {
+		return nil, newNoExistPartError(name)
+	}
+	return f.readBytes(name), nil
+}
+
+// DeletePart provides a function to delete a part in the package by given
+// part name. The override content type of the part, the relationships which
+// target the part, and the relationships part of the part will be removed.
+// Use this method with caution, deleting the parts managed by excelize will
+// cause a corrupted workbook. For example, delete the custom XML part:
+//
+//	err := f.DeletePart("customXml/item1.xml")
+func (f *File) DeletePart(name string) error {
+	if err := checkPartName(name); err != nil {
+		return err
+	}
+	_, inPkg := f.Pkg.Load(name)
+	tempFile, inTemp := f.tempFiles.Load(name)
+	if !inPkg && !inTemp && !f.hasMedia(name) {
+		return newNoExistPartError(name)
+	}
+	ct, err := f.contentTypesReader()
+	if err != nil {
+		return err
+	}
+	ct.mu.Lock()
+	for i := 0; i < len(ct.Overrides); i++ {
+		if ct.Overrides[i].PartName == "/"+name {
+			ct.Overrides = append(ct.Overrides[:i], ct.Overrides[i+1:]...)
+			i--
+		}
+	}
+	ct.mu.Unlock()
+	var relsParts []string
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.HasSuffix(k.(string), ".rels") {
+			relsParts = append(relsParts, k.(string))
+		}
+		return true
+	})
+	f.Relationships.Range(func(k, v interface{}) bool {
+		if _, ok := f.Pkg.Load(k); !ok {
+			relsParts = append(relsParts, k.(string))
+		}
+		return true
+	})
+	for _, relsPart := range relsParts {
+		if err = f.deletePartRelationships(relsPart, name); err != nil {
+			return err
+		}
+	}
+	ownRels := getPartRelsPath(name)
+	f.Pkg.Delete(ownRels)
+	f.Relationships.Delete(ownRels)
+	f.Pkg.Delete(name)
+	f.lazyMedia.Delete(name)
+	if inTemp {
+		f.tempFiles.Delete(name)
+		_ = os.Remove(tempFile.(string))
+	}
+	return err
+}
+
+// deletePartRelationships provides a function to delete the internal
+// relationships which target the given part name in the relationships part.
+func (f *File) deletePartRelationships(relsPart, name string) error {
+	rels, err := f.relsReader(relsPart)
+	if err != nil || rels == nil {
+		return err
+	}
+	rels.mu.Lock()
+	defer rels.mu.Unlock()
+	for i := 0; i < len(rels.Relationships); i++ {
+		rel := rels.Relationships[i]
+		if rel.TargetMode == "External" {
+			continue
+		}
+		if getRelationshipTargetPart(relsPart, rel) == "/"+name {
+			rels.Relationships = append(rels.Relationships[:i], rels.Relationships[i+1:]...)
+			i--
+		}
+	}
+	return err
+}
+
+// getPartRelsPath provides a function to get the relationships part path of
+// the given part name, the package relationships part path will be returned
+// if the part name is empty.
+func getPartRelsPath(name string) string {
+	if name == "" {
+		return "_rels/.rels"
+	}
+	return path.Join(path.Dir(name), "_rels", path.Base(name)+".rels")
+}
+
+// checkRelationshipSource provides a function to check the source part name
+// of the relationships, the empty part name represents the package.
+func (f *File) checkRelationshipSource(name string) error {
+	if name == "" {
+		return nil
+	}
+	if err := checkPartName(name); err != nil {
+		return err
+	}
+	_, inPkg := f.Pkg.Load(name)
+	_, inSheet := f.Sheet.Load(name)
+	_, inDrawing := f.Drawings.Load(name)
+	_, inTemp := f.tempFiles.Load(name)
+	if !inPkg && !inSheet && !inDrawing && !inTemp {
+		return newNoExistPartError(name)
+	}
+	return nil
+}
+
+// GetRelationships provides a function to get the relationships from the
+// part by given source part name, the relationships of the package will be
+// returned if the part name is empty. For example, get the relationships of
+// the workbook part:
+//
+//	rels, err := f.GetRelationships("xl/workbook.xml")
+func (f *File) GetRelationships(part string) ([]Relationship, error) {
+	var relationships []Relationship
+	if err := f.checkRelationshipSource(part); err != nil {
+		return relationships, err
+	}
+	rels, err := f.relsReader(getPartRelsPath(part))
+	if err != nil || rels == nil {
+		return relationships, err
+	}
+	rels.mu.Lock()
+	defer rels.mu.Unlock()
+	for _, rel := range rels.Relationships {
+		relationships = append(relationships, Relationship{
+			ID: rel.ID, Type: rel.Type, Target: rel.Target, TargetMode: rel.TargetMode,
+		})
+	}
+	return relationships, err
+}
+
+// checkRelationship provides a function to check the relationship settings.
+func checkRelationship(rel Relationship) error {
+	if rel.Type == "" || rel.Target == "" {
+		return ErrParameterRequired
+	}
+	if rel.TargetMode != "" && rel.TargetMode != "Internal" && rel.TargetMode != "External" {
+		return ErrParameterInvalid
+	}
+	return nil
+}
+
+// AddRelationship provides a function to add a relationship to the part by
+// given source part name and relationship settings, and returns the ID of the
+// new relationship. The relationship will be added to the package if the part
+// name is empty. The ID of the relationship settings will be ignored, and the
+// target of the internal relationship is relative to the folder of the source
+// part. For example, add a relationship from the first worksheet to an
+// external image:
+//
+//	rID, err := f.AddRelationship("xl/worksheets/sheet1.xml", excelize.Relationship{
+//	    Type:       "http://schemas.openxmlformats.org/officeDocument/2006/relationships/image",
+//	    Target:     "https://example.com/image.png",
+//	    TargetMode: "External",
+//	})
+func (f *File) AddRelationship(part string, rel Relationship) (string, error) {
+	if err := f.checkRelationshipSource(part); err != nil {
+		return "", err
+	}
+	if err := checkRelationship(rel); err != nil {
+		return "", err
+	}
+	relsPath := getPartRelsPath(part)
+	if _, err := f.relsReader(relsPath); err != nil {
+		return "", err
+	}
+	rID := f.addRels(relsPath, rel.Type, rel.Target, rel.TargetMode)
+	return "rId" + strconv.Itoa(rID), nil
+}
+
+// SetRelationship provides a function to update an exist relationship of the
+// part by given source part name and relationship settings, the relationship
+// will be matched by the ID. For example, migrate the image target of the
+// drawing part to an external URL:
+//
+//	err := f.SetRelationship("xl/drawings/drawing1.xml", excelize.Relationship{
+//	    ID:         "rId1",
+//	    Type:       "http://schemas.openxmlformats.org/officeDocument/2006/relationships/image",
+//	    Target:     "https://example.com/image.png",
+//	    TargetMode: "External",
+//	})
+func (f *File) SetRelationship(part string, rel Relationship) error {
+	if err := f.checkRelationshipSource(part); err != nil {
+		return err
+	}
+	if err := checkRelationship(rel); err != nil {
+		return err
+	}
+	rels, err := f.relsReader(getPartRelsPath(part))
+	if err != nil {
+		return err
+	}
+	if rels != nil {
+		rels.mu.Lock()
+		defer rels.mu.Unlock()
+		for i := range rels.Relationships {
+			if rels.Relationships[i].ID == rel.ID {
+				rels.Relationships[i] = xlsxRelationship{
+					ID: rel.ID, Type: rel.Type, Target: rel.Target, TargetMode: rel.TargetMode,
+				}
+				return err
+			}
+		}
+	}
+	return newNoExistRelationshipError(rel.ID)
+}
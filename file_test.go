@@ -1,10 +1,16 @@
 package excelize
 
 import (
+	"archive/zip"
 	"bufio"
 	"bytes"
+	"compress/flate"
+	"encoding/xml"
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"testing"
@@ -36,6 +42,32 @@ func BenchmarkWrite(b *testing.B) {
 	}
 }
 
+func BenchmarkWriteOptimizeForSize(b *testing.B) {
+	for _, optimize := range []bool{false, true} {
+		b.Run(fmt.Sprintf("OptimizeForSize=%t", optimize), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				f := NewFile()
+				for col := 1; col <= 20; col++ {
+					for row := 1; row <= 10000; row++ {
+						cell, err := CoordinatesToCellName(col, row)
+						if err != nil {
+							b.Error(err)
+						}
+						if err := f.SetCellValue("Sheet1", cell, row*col); err != nil {
+							b.Error(err)
+						}
+					}
+				}
+				var buf bytes.Buffer
+				if err := f.Write(&buf, Options{OptimizeForSize: optimize}); err != nil {
+					b.Error(err)
+				}
+				b.ReportMetric(float64(buf.Len()), "bytes/file")
+			}
+		})
+	}
+}
+
 func TestWriteTo(t *testing.T) {
 	// Test WriteToBuffer err
 	{
@@ -90,8 +122,643 @@ func TestWriteTo(t *testing.T) {
 	}
 }
 
+func TestWriteCanonical(t *testing.T) {
+	prepare := func(reverse bool) *File {
+		f := NewFile()
+		for _, sheet := range []string{"Sheet2", "Sheet3"} {
+			_, err := f.NewSheet(sheet)
+			assert.NoError(t, err)
+			sw, err := f.NewStreamWriter(sheet)
+			assert.NoError(t, err)
+			assert.NoError(t, sw.SetRow("A1", []interface{}{sheet}))
+			assert.NoError(t, sw.Flush())
+		}
+		assert.NoError(t, f.SetCellValue("Sheet1", "A1", "A1"))
+		attrs := []xml.Attr{
+			{Name: xml.Name{Local: "xmlns"}, Value: NameSpaceSpreadSheet.Value},
+			{Name: xml.Name{Space: "xmlns", Local: "x14ac"}, Value: NameSpaceSpreadSheetX14.Value},
+			{Name: xml.Name{Space: "xmlns", Local: "mc"}, Value: SourceRelationshipCompatibility.Value},
+			{Name: xml.Name{Space: SourceRelationshipCompatibility.Value, Local: "Ignorable"}, Value: "x14ac xr"},
+			{Name: xml.Name{Space: "xmlns", Local: "xr"}, Value: NameSpaceSpreadSheetXR10.Value},
+		}
+		if reverse {
+			for i, j := 0, len(attrs)-1; i < j; i, j = i+1, j-1 {
+				attrs[i], attrs[j] = attrs[j], attrs[i]
+			}
+			attrs[1].Value = "xr x14ac"
+		}
+		f.xmlAttr.Store("xl/worksheets/sheet1.xml", attrs)
+		return f
+	}
+	var output [2]bytes.Buffer
+	for i, reverse := range []bool{false, true} {
+		f := prepare(reverse)
+		assert.NoError(t, f.Write(&output[i], Options{Canonical: true}))
+		assert.NoError(t, f.Close())
+	}
+	assert.Equal(t, output[0].Bytes(), output[1].Bytes())
+	zr, err := zip.NewReader(bytes.NewReader(output[0].Bytes()), int64(output[0].Len()))
+	assert.NoError(t, err)
+	var names []string
+	for _, file := range zr.File {
+		names = append(names, file.Name)
+		assert.True(t, canonicalModTime.Equal(file.Modified))
+	}
+	assert.Equal(t, []string{defaultXMLPathContentTypes, "_rels/.rels"}, names[:2])
+	assert.True(t, sort.StringsAreSorted(names[2:]))
+	assert.Contains(t, names, "xl/worksheets/sheet2.xml")
+	assert.Contains(t, names, "xl/worksheets/sheet3.xml")
+	// Test the root element attributes of the worksheet in canonical order
+	f, err := OpenReader(bytes.NewReader(output[0].Bytes()))
+	assert.NoError(t, err)
+	content, ok := f.Pkg.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Contains(t, string(content.([]byte)), `<worksheet xmlns="`+NameSpaceSpreadSheet.Value+`" xmlns:mc="`+SourceRelationshipCompatibility.Value+`" xmlns:x14ac="`+NameSpaceSpreadSheetX14.Value+`" xmlns:xr="`+NameSpaceSpreadSheetXR10.Value+`" mc:Ignorable="x14ac xr">`)
+	assert.NoError(t, f.Close())
+	// Test write canonical with stream writer error
+	f = NewFile()
+	tmp, err := os.CreateTemp(os.TempDir(), "excelize-")
+	assert.NoError(t, err)
+	assert.NoError(t, tmp.Close())
+	assert.NoError(t, os.Remove(tmp.Name()))
+	f.streams = map[string]*StreamWriter{"s": {rawData: bufferedWriter{tmp: tmp}}}
+	assert.Error(t, f.Write(&bytes.Buffer{}, Options{Canonical: true}))
+	// Test write canonical with file path overflow
+	f = NewFile()
+	f.Pkg.Store(strings.Repeat("s", 1<<16), nil)
+	assert.EqualError(t, f.Write(&bytes.Buffer{}, Options{Canonical: true}), "zip: FileHeader.Name too long")
+	// Test write canonical with temporary file
+	f = NewFile()
+	f.tempFiles.Store("s", "")
+	assert.NoError(t, f.Write(&bytes.Buffer{}, Options{Canonical: true}))
+}
+
+func TestWriteScrubMetadata(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetDocProps(&DocProperties{Creator: "Author", LastModifiedBy: "Editor", Created: "2024-01-02T03:04:05Z"}))
+	assert.NoError(t, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"), nil))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "B1", "https://github.com/xuri/excelize", "External"))
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	sw, err := f.NewStreamWriter("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"A1"}))
+	assert.NoError(t, sw.Flush())
+	// Make the relationship IDs of the worksheet out of order
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	rels, err := f.relsReader("xl/worksheets/_rels/sheet1.xml.rels")
+	assert.NoError(t, err)
+	rels.Relationships[0].ID, ws.Drawing.RID = "rId10", "rId10"
+	rels.Relationships[1].ID, ws.Hyperlinks.Hyperlink[0].RID = "rId5", "rId5"
+	var buf bytes.Buffer
+	assert.NoError(t, f.Write(&buf, Options{ScrubMetadata: true, UpdateModifiedTime: true}))
+	// Test the content in the package will not be changed
+	content, ok := f.Pkg.Load("xl/worksheets/_rels/sheet1.xml.rels")
+	assert.True(t, ok)
+	assert.Contains(t, string(content.([]byte)), `Id="rId10"`)
+	assert.NoError(t, f.Close())
+
+	f, err = OpenReader(bytes.NewReader(buf.Bytes()))
+	assert.NoError(t, err)
+	props, err := f.GetDocProps()
+	assert.NoError(t, err)
+	assert.Equal(t, "Author", props.Creator)
+	assert.Empty(t, props.LastModifiedBy)
+	assert.Equal(t, "1980-01-01T00:00:00Z", props.Created)
+	assert.Equal(t, "1980-01-01T00:00:00Z", props.Modified)
+	rels, err = f.relsReader("xl/worksheets/_rels/sheet1.xml.rels")
+	assert.NoError(t, err)
+	assert.Equal(t, "rId1", rels.Relationships[0].ID)
+	assert.Equal(t, "rId2", rels.Relationships[1].ID)
+	pics, err := f.GetPictures("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 1)
+	link, target, err := f.GetCellHyperLink("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.True(t, link)
+	assert.Equal(t, "https://github.com/xuri/excelize", target)
+	val, err := f.GetCellValue("Sheet2", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1", val)
+	assert.NoError(t, f.Close())
+	// Test write canonical with scrub metadata
+	f = NewFile()
+	buf.Reset()
+	assert.NoError(t, f.Write(&buf, Options{ScrubMetadata: true, Canonical: true}))
+	f, err = OpenReader(bytes.NewReader(buf.Bytes()))
+	assert.NoError(t, err)
+	props, err = f.GetDocProps()
+	assert.NoError(t, err)
+	assert.Equal(t, "1980-01-01T00:00:00Z", props.Created)
+	assert.NoError(t, f.Close())
+	// Test scrub metadata with invalid or duplicate relationships
+	f = NewFile()
+	f.Pkg.Store("xl/worksheets/_rels/sheet1.xml.rels", MacintoshCyrillicCharset)
+	f.Pkg.Store("xl/_rels/workbook.xml.rels", []byte(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Target="a"/><Relationship Id="rId1" Target="b"/></Relationships>`))
+	f.Relationships.Delete("xl/_rels/workbook.xml.rels")
+	f.options = &Options{ScrubMetadata: true}
+	scrubbed := f.scrubMetadata()
+	assert.Len(t, scrubbed, 1)
+	assert.NoError(t, f.Close())
+	// Test scrub metadata with the attributes in the relationships namespace
+	f = NewFile()
+	f.Pkg.Store("xl/custom.xml", []byte(`<root xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" xmlns:o="urn:schemas-microsoft-com:office:office" xmlns:x="urn:custom"><a r:href = 'rId7' id="rId7"/><b o:relid="rId3" x:id="rId3">rId3</b></root>`))
+	f.Pkg.Store("xl/_rels/custom.xml.rels", []byte(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId7" Target="a"/><Relationship Id="rId3" Target="b"/></Relationships>`))
+	f.options = &Options{ScrubMetadata: true}
+	scrubbed = f.scrubMetadata()
+	assert.Equal(t, `<root xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" xmlns:o="urn:schemas-microsoft-com:office:office" xmlns:x="urn:custom"><a r:href = 'rId1' id="rId7"/><b o:relid="rId2" x:id="rId3">rId3</b></root>`, string(scrubbed["xl/custom.xml"]))
+	// Test scrub metadata with invalid part content
+	f.Pkg.Store("xl/custom.xml", []byte(`<root><a></b></root>`))
+	scrubbed = f.scrubMetadata()
+	_, ok = scrubbed["xl/custom.xml"]
+	assert.False(t, ok)
+	_, ok = scrubbed["xl/_rels/custom.xml.rels"]
+	assert.False(t, ok)
+	assert.NoError(t, f.Close())
+}
+
+func TestWriteOptimizeForSize(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	// Add the duplicate cell format
+	f.Styles.CellXfs.Xf = append(f.Styles.CellXfs.Xf, f.Styles.CellXfs.Xf[style])
+	f.Styles.CellXfs.Count = len(f.Styles.CellXfs.Xf)
+	duplicate := f.Styles.CellXfs.Count - 1
+	for _, cell := range []string{"A1", "B2"} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, cell))
+		assert.NoError(t, f.SetCellStyle("Sheet1", cell, cell, duplicate))
+	}
+	assert.NoError(t, f.SetRowStyle("Sheet1", 3, 3, duplicate))
+	assert.NoError(t, f.SetColStyle("Sheet1", "C", duplicate))
+	assert.NoError(t, f.AddPicture("Sheet1", "D1", filepath.Join("test", "images", "excel.png"), nil))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "E1", "https://github.com/xuri/excelize", "External"))
+	// Make the relationship IDs of the worksheet out of order
+	rels, err := f.relsReader("xl/worksheets/_rels/sheet1.xml.rels")
+	assert.NoError(t, err)
+	rels.Relationships[0], rels.Relationships[1] = rels.Relationships[1], rels.Relationships[0]
+	f.Relationships.Store("xl/_rels/nil.rels", nil)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	var buf bytes.Buffer
+	assert.NoError(t, f.Write(&buf, Options{OptimizeForSize: true}))
+	// Test optimize for size without changing the worksheet and relationships
+	assert.Equal(t, duplicate, ws.SheetData.Row[0].C[0].S)
+	assert.Equal(t, duplicate, ws.SheetData.Row[2].S)
+	assert.Equal(t, duplicate, ws.Cols.Col[0].Style)
+	assert.Equal(t, "rId2", rels.Relationships[0].ID)
+	var lowMemoryBuf bytes.Buffer
+	assert.NoError(t, f.Write(&lowMemoryBuf, Options{OptimizeForSize: true, LowMemorySave: true}))
+	assert.NoError(t, f.Close())
+	f, err = OpenReader(bytes.NewReader(lowMemoryBuf.Bytes()))
+	assert.NoError(t, err)
+	styleID, err := f.GetCellStyle("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.Equal(t, style, styleID)
+	assert.NoError(t, f.Close())
+
+	f, err = OpenReader(bytes.NewReader(buf.Bytes()))
+	assert.NoError(t, err)
+	for _, cell := range []string{"A1", "B2", "C4"} {
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, style, styleID)
+	}
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, style, ws.SheetData.Row[2].S)
+	assert.Equal(t, style, ws.Cols.Col[0].Style)
+	rels, err = f.relsReader("xl/worksheets/_rels/sheet1.xml.rels")
+	assert.NoError(t, err)
+	assert.Equal(t, "rId1", rels.Relationships[0].ID)
+	assert.Equal(t, "rId2", rels.Relationships[1].ID)
+	pics, err := f.GetPictures("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 1)
+	assert.NoError(t, f.Close())
+}
+
+func TestWriteZip64Parts(t *testing.T) {
+	// Write all parts with the sizes in the local file header
+	defer func(size int64) { zip64PartSize = size }(zip64PartSize)
+	zip64PartSize = 1
+	assertParts := func(buf []byte) {
+		zr, err := zip.NewReader(bytes.NewReader(buf), int64(len(buf)))
+		assert.NoError(t, err)
+		for _, file := range zr.File {
+			assert.Zero(t, file.Flags&0x8, file.Name)
+		}
+	}
+	f := NewFile()
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"Excelize"}))
+	assert.NoError(t, sw.Flush())
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddPicture("Sheet2", "B2", filepath.Join("test", "images", "excel.png"), nil))
+	var buf bytes.Buffer
+	assert.NoError(t, f.Write(&buf))
+	assertParts(buf.Bytes())
+	assert.NoError(t, f.Close())
+
+	// Test copy the lazy loaded media and write the parts in canonical form
+	for _, opts := range []Options{{}, {Canonical: true}} {
+		f, err = OpenReader(bytes.NewReader(buf.Bytes()))
+		assert.NoError(t, err)
+		var out bytes.Buffer
+		assert.NoError(t, f.Write(&out, opts))
+		assertParts(out.Bytes())
+		assert.NoError(t, f.Close())
+
+		f, err = OpenReader(bytes.NewReader(out.Bytes()))
+		assert.NoError(t, err)
+		val, err := f.GetCellValue("Sheet1", "A1")
+		assert.NoError(t, err)
+		assert.Equal(t, "Excelize", val)
+		pics, err := f.GetPictures("Sheet2", "B2")
+		assert.NoError(t, err)
+		assert.Len(t, pics, 1)
+		assert.NoError(t, f.Close())
+	}
+
+	// Test write the parts with the best compression level on optimize for size
+	f = NewFile()
+	for _, sheet := range []string{"Sheet1", "Sheet2"} {
+		_, err = f.NewSheet(sheet)
+		assert.NoError(t, err)
+		sw, err := f.NewStreamWriter(sheet)
+		assert.NoError(t, err)
+		for row := 1; row <= 100; row++ {
+			assert.NoError(t, sw.SetRow(fmt.Sprintf("A%d", row), []interface{}{row, "Excelize", row * row}))
+		}
+		assert.NoError(t, sw.Flush())
+	}
+	var out bytes.Buffer
+	assert.NoError(t, f.Write(&out, Options{OptimizeForSize: true}))
+	assert.NoError(t, f.Close())
+	zr, err := zip.NewReader(bytes.NewReader(out.Bytes()), int64(out.Len()))
+	assert.NoError(t, err)
+	for _, file := range zr.File {
+		content, err := readFile(file)
+		assert.NoError(t, err)
+		var compressed bytes.Buffer
+		fw, err := flate.NewWriter(&compressed, flate.BestCompression)
+		assert.NoError(t, err)
+		_, err = fw.Write(content)
+		assert.NoError(t, err)
+		assert.NoError(t, fw.Close())
+		assert.Equal(t, uint64(compressed.Len()), file.CompressedSize64, file.Name)
+	}
+
+	// Test write the part with read error
+	tmp, err := os.CreateTemp(os.TempDir(), "excelize-")
+	assert.NoError(t, err)
+	assert.NoError(t, tmp.Close())
+	defer os.Remove(tmp.Name())
+	zw := zip.NewWriter(&buf)
+	assert.Error(t, f.writeZipPart(zw, &zip.FileHeader{Name: "a.xml"}, io.NewSectionReader(tmp, 0, 10)))
+	// Test write the part with the invalid part name
+	name := strings.Repeat("a", 65536)
+	assert.Error(t, f.writeZipPart(zw, &zip.FileHeader{Name: name}, strings.NewReader("a")))
+	zip64PartSize = 2
+	assert.Error(t, f.writeZipPart(zw, &zip.FileHeader{Name: name}, strings.NewReader("a")))
+
+	// Test the compressed part is stored in the temporary file
+	var part compressedPart
+	part.deflate(&zip.FileHeader{Name: "a.xml"}, strings.NewReader(strings.Repeat("a", 1024)), flate.DefaultCompression)
+	assert.NoError(t, part.err)
+	tmpName := part.tmp.Name()
+	fi, err := os.Stat(tmpName)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(fi.Size()), part.header.CompressedSize64)
+	assert.Equal(t, uint64(1024), part.header.UncompressedSize64)
+	buf.Reset()
+	zw = zip.NewWriter(&buf)
+	assert.NoError(t, part.writeTo(zw))
+	assert.NoError(t, zw.Close())
+	_, err = os.Stat(tmpName)
+	assert.True(t, os.IsNotExist(err))
+	zr, err = zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	content, err := readFile(zr.File[0])
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Repeat("a", 1024), string(content))
+}
+
+func TestWriteLowMemorySave(t *testing.T) {
+	readParts := func(buf []byte) map[string][]byte {
+		zr, err := zip.NewReader(bytes.NewReader(buf), int64(len(buf)))
+		assert.NoError(t, err)
+		parts := map[string][]byte{}
+		for _, file := range zr.File {
+			rc, err := file.Open()
+			assert.NoError(t, err)
+			parts[file.Name], err = io.ReadAll(rc)
+			assert.NoError(t, err)
+			assert.NoError(t, rc.Close())
+		}
+		return parts
+	}
+	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Excelize"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "B1", "https://github.com/xuri/excelize", "External"))
+	_, err = f.NewSheet("Sheet3")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet3", "A1", 1))
+	var expected, actual bytes.Buffer
+	assert.NoError(t, f.Write(&expected))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "Book1.xlsx"), Options{LowMemorySave: true})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Excelize"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "B1", "https://github.com/xuri/excelize", "External"))
+	_, err = f.NewSheet("Sheet3")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet3", "A1", 1))
+	assert.NoError(t, f.Write(&actual, Options{LowMemorySave: true}))
+	// Test the worksheets are kept in memory after saving
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Excelize", val)
+	assert.NoError(t, f.Close())
+	assert.Equal(t, readParts(expected.Bytes()), readParts(actual.Bytes()))
+
+	// Test write the worksheet with invalid part name
+	zw := zip.NewWriter(&actual)
+	assert.EqualError(t, f.writeWorksheetPart(zw, strings.Repeat("s", 65536), &xlsxWorksheet{}, nil), "zip: FileHeader.Name too long")
+	f.options.LowMemorySave = true
+	f.Sheet.Store(strings.Repeat("s", 65536), &xlsxWorksheet{})
+	_, err = f.WriteTo(&actual)
+	assert.EqualError(t, err, "zip: FileHeader.Name too long")
+}
+
 func TestClose(t *testing.T) {
 	f := NewFile()
 	f.tempFiles.Store("/d/", "/d/")
 	require.Error(t, f.Close())
 }
+
+func TestPart(t *testing.T) {
+	f := NewFile()
+	const (
+		name, contentType = "customXml/item1.xml", "application/vnd.vendor.item+xml"
+		relType           = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/customXml"
+	)
+	assert.NoError(t, f.SetPart(name, []byte(`<item/>`), contentType))
+	content, err := f.GetPart(name)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(`<item/>`), content)
+	// Test replace the part with another content type
+	assert.NoError(t, f.SetPart(name, []byte(`<item2/>`), "application/xml"))
+	// Test replace the part without content type
+	assert.NoError(t, f.SetPart(name, []byte(`<item3/>`), ""))
+	content, err = f.GetPart(name)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(`<item3/>`), content)
+	ct, err := f.contentTypesReader()
+	assert.NoError(t, err)
+	var overrides []xlsxOverride
+	for _, override := range ct.Overrides {
+		if override.PartName == "/"+name {
+			overrides = append(overrides, override)
+		}
+	}
+	assert.Equal(t, []xlsxOverride{{PartName: "/" + name, ContentType: "application/xml"}}, overrides)
+	// Test get the part managed by excelize
+	content, err = f.GetPart(defaultXMLPathStyles)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, content)
+	// Test delete the part with relationships
+	f.addRels(defaultXMLPathWorkbookRels, relType, "../customXml/item1.xml", "")
+	f.addRels("_rels/.rels", relType, "/customXml/item1.xml", "")
+	f.addRels("_rels/.rels", SourceRelationshipHyperLink, "https://example.com/customXml/item1.xml", "External")
+	assert.NoError(t, f.SetPart("customXml/_rels/item1.xml.rels", []byte(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"/>`), ""))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestPart.xlsx")))
+	assert.NoError(t, f.DeletePart(name))
+	_, err = f.GetPart(name)
+	assert.Equal(t, newNoExistPartError(name), err)
+	_, err = f.GetPart("customXml/_rels/item1.xml.rels")
+	assert.Equal(t, newNoExistPartError("customXml/_rels/item1.xml.rels"), err)
+	for _, relsPart := range []string{defaultXMLPathWorkbookRels, "_rels/.rels"} {
+		rels, err := f.relsReader(relsPart)
+		assert.NoError(t, err)
+		for _, rel := range rels.Relationships {
+			assert.NotEqual(t, relType, rel.Type)
+		}
+	}
+	ct, err = f.contentTypesReader()
+	assert.NoError(t, err)
+	for _, override := range ct.Overrides {
+		assert.NotEqual(t, "/"+name, override.PartName)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDeletePart.xlsx")))
+	// Test delete the part in the system temporary directory
+	tmp, err := os.CreateTemp(os.TempDir(), "excelize-")
+	assert.NoError(t, err)
+	assert.NoError(t, tmp.Close())
+	f.tempFiles.Store("xl/vendor.xml", tmp.Name())
+	assert.NoError(t, f.DeletePart("xl/vendor.xml"))
+	_, err = os.Stat(tmp.Name())
+	assert.True(t, os.IsNotExist(err))
+	// Test set, get and delete part with invalid part name
+	for _, name := range []string{"", "/xl/item.xml", "xl/../item.xml", "xl/", `xl\item.xml`, "./item.xml", defaultXMLPathContentTypes} {
+		assert.Equal(t, newInvalidPartNameError(name), f.SetPart(name, nil, ""))
+		_, err = f.GetPart(name)
+		assert.Equal(t, newInvalidPartNameError(name), err)
+		assert.Equal(t, newInvalidPartNameError(name), f.DeletePart(name))
+	}
+	// Test delete not exist part
+	assert.Equal(t, newNoExistPartError("xl/item.xml"), f.DeletePart("xl/item.xml"))
+	assert.NoError(t, f.Close())
+	// Test set and delete part with unsupported charset content types
+	f = NewFile()
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetPart(name, nil, contentType), "XML syntax error on line 1: invalid UTF-8")
+	f.ContentTypes = nil
+	assert.EqualError(t, f.SetPart(name, nil, ""), "XML syntax error on line 1: invalid UTF-8")
+	f.Pkg.Store(name, nil)
+	f.ContentTypes = nil
+	assert.EqualError(t, f.DeletePart(name), "XML syntax error on line 1: invalid UTF-8")
+	// Test delete part with unsupported charset relationships
+	f = NewFile()
+	f.Relationships.Delete(defaultXMLPathWorkbookRels)
+	f.Pkg.Store(defaultXMLPathWorkbookRels, MacintoshCyrillicCharset)
+	assert.NoError(t, f.SetPart(name, nil, ""))
+	assert.EqualError(t, f.DeletePart(name), "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.SetPart("customXml/item2.xml", nil, contentType, PartOptions{
+		RelationshipSource: defaultXMLPathWorkbook, RelationshipType: relType,
+	}), "XML syntax error on line 1: invalid UTF-8")
+	// Test replace part with unsupported charset relationships of the part
+	f = NewFile()
+	assert.NoError(t, f.SetPart(name, nil, contentType))
+	f.Pkg.Store("customXml/_rels/item1.xml.rels", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetPart(name, nil, ""), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestSetPartRelationships(t *testing.T) {
+	f := NewFile()
+	const (
+		name, contentType = "customXml/item1.xml", "application/vnd.vendor.item+xml"
+		relType           = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/customXml"
+	)
+	// Test set part with the relationship from the workbook
+	opts := PartOptions{RelationshipSource: defaultXMLPathWorkbook, RelationshipType: relType}
+	assert.NoError(t, f.SetPart(name, []byte(`<item/>`), contentType, opts))
+	assert.NoError(t, f.SetPart(name, []byte(`<item/>`), contentType, opts))
+	rels, err := f.GetRelationships(defaultXMLPathWorkbook)
+	assert.NoError(t, err)
+	var targets []string
+	for _, rel := range rels {
+		if rel.Type == relType {
+			targets = append(targets, rel.Target)
+		}
+	}
+	assert.Equal(t, []string{"../customXml/item1.xml"}, targets)
+	// Test set part with the relationship from the package
+	assert.NoError(t, f.SetPart("vendor.xml", []byte(`<vendor/>`), "", PartOptions{RelationshipType: relType}))
+	rels, err = f.GetRelationships("")
+	assert.NoError(t, err)
+	assert.Equal(t, "vendor.xml", rels[len(rels)-1].Target)
+	// Test set new part without content type and default content type
+	assert.Equal(t, ErrParameterRequired, f.SetPart("customXml/item2.vendor", nil, ""))
+	// Test set part with the relationship from not exist part
+	assert.Equal(t, newNoExistPartError("xl/item.xml"), f.SetPart(name, nil, "", PartOptions{RelationshipSource: "xl/item.xml", RelationshipType: relType}))
+	// Test replace part removes the relationships no longer referenced
+	rID, err := f.AddRelationship(name, Relationship{Type: relType, Target: "item2.xml"})
+	assert.NoError(t, err)
+	_, err = f.AddRelationship(name, Relationship{Type: relType, Target: "item3.xml"})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetPart(name, []byte(`<item xmlns:r="`+SourceRelationship.Value+`" r:id="`+rID+`"/>`), ""))
+	rels, err = f.GetRelationships(name)
+	assert.NoError(t, err)
+	assert.Equal(t, []Relationship{{ID: rID, Type: relType, Target: "item2.xml"}}, rels)
+	// Test replace part with the relationship ID in the attribute of other
+	// namespace, or as the prefix of another relationship ID
+	assert.NoError(t, f.SetPart(name, []byte(`<item id="`+rID+`"><ref xmlns:r="`+SourceRelationship.Value+`" r:id="`+rID+`0"/>`+rID+`</item>`), ""))
+	rels, err = f.GetRelationships(name)
+	assert.NoError(t, err)
+	assert.Empty(t, rels)
+	rID, err = f.AddRelationship(name, Relationship{Type: relType, Target: "item2.xml"})
+	assert.NoError(t, err)
+	// Test replace part with non-XML content keeps the relationships
+	assert.NoError(t, f.SetPart(name, []byte("\x00<item"), ""))
+	rels, err = f.GetRelationships(name)
+	assert.NoError(t, err)
+	assert.Equal(t, []Relationship{{ID: rID, Type: relType, Target: "item2.xml"}}, rels)
+	// Test replace part keeps the relationships referenced by legacy VML
+	assert.NoError(t, f.SetPart(name, []byte(`<xml xmlns:o="`+NameSpaceOffice.Value+`"><imagedata o:relid="`+rID+`"/></xml>`), ""))
+	rels, err = f.GetRelationships(name)
+	assert.NoError(t, err)
+	assert.Len(t, rels, 1)
+	assert.NoError(t, f.SetPart(name, []byte(`<item/>`), ""))
+	rels, err = f.GetRelationships(name)
+	assert.NoError(t, err)
+	assert.Empty(t, rels)
+	_, err = f.GetPart("customXml/_rels/item1.xml.rels")
+	assert.Equal(t, newNoExistPartError("customXml/_rels/item1.xml.rels"), err)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetPartRelationships.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
+func TestRelativePartPath(t *testing.T) {
+	for _, c := range []struct{ dir, name, expected string }{
+		{"xl", "xl/worksheets/sheet1.xml", "worksheets/sheet1.xml"},
+		{"xl/worksheets", "xl/media/image1.png", "../media/image1.png"},
+		{"xl/worksheets", "customXml/item1.xml", "../../customXml/item1.xml"},
+		{".", "customXml/item1.xml", "customXml/item1.xml"},
+		{"xl/drawings", "xl/drawings/drawing2.xml", "drawing2.xml"},
+	} {
+		assert.Equal(t, c.expected, relativePartPath(c.dir, c.name), c)
+	}
+}
+
+func TestRelationships(t *testing.T) {
+	f := NewFile()
+	// Test get the relationships of the package and workbook
+	rels, err := f.GetRelationships("")
+	assert.NoError(t, err)
+	assert.Len(t, rels, 3)
+	rels, err = f.GetRelationships(defaultXMLPathWorkbook)
+	assert.NoError(t, err)
+	assert.Contains(t, rels, Relationship{ID: "rId1", Type: SourceRelationshipWorkSheet, Target: "worksheets/sheet1.xml"})
+	// Test get the relationships of the part without relationships
+	rels, err = f.GetRelationships(defaultXMLPathStyles)
+	assert.NoError(t, err)
+	assert.Empty(t, rels)
+	// Test add and update relationships
+	assert.NoError(t, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"), nil))
+	const drawingXML = "xl/drawings/drawing1.xml"
+	rels, err = f.GetRelationships(drawingXML)
+	assert.NoError(t, err)
+	assert.Equal(t, []Relationship{{ID: "rId1", Type: SourceRelationshipImage, Target: "../media/image1.png"}}, rels)
+	rels[0].Target, rels[0].TargetMode = "https://example.com/image.png", "External"
+	assert.NoError(t, f.SetRelationship(drawingXML, rels[0]))
+	rID, err := f.AddRelationship("xl/worksheets/sheet1.xml", Relationship{
+		ID: "rId100", Type: SourceRelationshipHyperLink, Target: "https://example.com", TargetMode: "External",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "rId2", rID)
+	rID, err = f.AddRelationship("", Relationship{Type: SourceRelationshipExtendProperties, Target: "docProps/app.xml"})
+	assert.NoError(t, err)
+	assert.Equal(t, "rId4", rID)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRelationships.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestRelationships.xlsx"))
+	assert.NoError(t, err)
+	rels, err = f.GetRelationships(drawingXML)
+	assert.NoError(t, err)
+	assert.Equal(t, []Relationship{{ID: "rId1", Type: SourceRelationshipImage, Target: "https://example.com/image.png", TargetMode: "External"}}, rels)
+	rels, err = f.GetRelationships("xl/worksheets/sheet1.xml")
+	assert.NoError(t, err)
+	assert.Equal(t, Relationship{ID: "rId2", Type: SourceRelationshipHyperLink, Target: "https://example.com", TargetMode: "External"}, rels[1])
+	// Test add relationship to the part without relationships
+	rID, err = f.AddRelationship(defaultXMLPathStyles, Relationship{Type: SourceRelationshipImage, Target: "../media/image1.png"})
+	assert.NoError(t, err)
+	assert.Equal(t, "rId1", rID)
+	// Test relationships with invalid or not exist part
+	for _, c := range []struct {
+		part string
+		err  error
+	}{
+		{"/xl/workbook.xml", newInvalidPartNameError("/xl/workbook.xml")},
+		{"xl/item.xml", newNoExistPartError("xl/item.xml")},
+	} {
+		_, err = f.GetRelationships(c.part)
+		assert.Equal(t, c.err, err)
+		_, err = f.AddRelationship(c.part, Relationship{})
+		assert.Equal(t, c.err, err)
+		assert.Equal(t, c.err, f.SetRelationship(c.part, Relationship{}))
+	}
+	// Test relationships with invalid settings
+	for _, c := range []struct {
+		rel Relationship
+		err error
+	}{
+		{Relationship{Target: "../media/image1.png"}, ErrParameterRequired},
+		{Relationship{Type: SourceRelationshipImage}, ErrParameterRequired},
+		{Relationship{Type: SourceRelationshipImage, Target: "../media/image1.png", TargetMode: "Mode"}, ErrParameterInvalid},
+	} {
+		_, err = f.AddRelationship(drawingXML, c.rel)
+		assert.Equal(t, c.err, err)
+		assert.Equal(t, c.err, f.SetRelationship(drawingXML, c.rel))
+	}
+	// Test update not exist relationship
+	assert.Equal(t, newNoExistRelationshipError("rId2"), f.SetRelationship(drawingXML, Relationship{ID: "rId2", Type: SourceRelationshipImage, Target: "../media/image1.png"}))
+	assert.Equal(t, newNoExistRelationshipError("rId1"), f.SetRelationship("docProps/app.xml", Relationship{ID: "rId1", Type: SourceRelationshipImage, Target: "../media/image1.png"}))
+	assert.NoError(t, f.Close())
+	// Test relationships with unsupported charset
+	f = NewFile()
+	f.Relationships.Delete(defaultXMLPathWorkbookRels)
+	f.Pkg.Store(defaultXMLPathWorkbookRels, MacintoshCyrillicCharset)
+	_, err = f.GetRelationships(defaultXMLPathWorkbook)
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	_, err = f.AddRelationship(defaultXMLPathWorkbook, Relationship{Type: SourceRelationshipImage, Target: "media/image1.png"})
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.SetRelationship(defaultXMLPathWorkbook, Relationship{Type: SourceRelationshipImage, Target: "media/image1.png"}), "XML syntax error on line 1: invalid UTF-8")
+}
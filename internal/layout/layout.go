@@ -0,0 +1,225 @@
+// Copyright 2016 - 2024 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+
+// Package layout providing the cell layout functions shared by the renderers
+// of the worksheets, such as the image renderer and the PDF exporter,
+// including the merged cells, border styles, colors, alignment and text
+// wrapping of the cells.
+package layout
+
+import (
+	"image/color"
+	"strconv"
+	"strings"
+)
+
+// BorderStyle directly maps the line weight and dash pattern of the cell
+// border style. The weight is the number of the line units, the hair line
+// has zero weight, and the dash pattern is in the line units.
+type BorderStyle struct {
+	Weight int
+	Dash   []int
+	Double bool
+}
+
+// ValueKind is the kind of the cell value for the general horizontal
+// alignment.
+type ValueKind byte
+
+// This section defines the kinds of the cell value.
+const (
+	ValueText ValueKind = iota
+	ValueNumber
+	ValueLogical
+)
+
+// Span directly maps the cell or merged cells in the range by the column and
+// row number of the top-left and bottom-right cells, and the column and row
+// number of the cell which holds the value.
+type Span struct {
+	Col1, Row1, Col2, Row2 int
+	Col, Row               int
+}
+
+var (
+	// BorderStyles defined the line weight and dash pattern of the cell
+	// border styles by the border style index.
+	BorderStyles = map[int]BorderStyle{
+		1:  {Weight: 1},
+		2:  {Weight: 2},
+		3:  {Weight: 1, Dash: []int{3, 1}},
+		4:  {Weight: 1, Dash: []int{1, 1}},
+		5:  {Weight: 3},
+		6:  {Weight: 1, Double: true},
+		7:  {Dash: []int{1, 2}},
+		8:  {Weight: 2, Dash: []int{9, 3}},
+		9:  {Weight: 1, Dash: []int{9, 3, 3, 3}},
+		10: {Weight: 2, Dash: []int{9, 3, 3, 3}},
+		11: {Weight: 1, Dash: []int{9, 3, 3, 3, 3, 3}},
+		12: {Weight: 2, Dash: []int{9, 3, 3, 3, 3, 3}},
+		13: {Weight: 2, Dash: []int{11, 1, 5, 1}},
+	}
+	// mirroredBorders defined the mirrored border types in the right to left
+	// worksheet.
+	mirroredBorders = map[string]string{
+		"left": "right", "right": "left", "diagonalDown": "diagonalUp", "diagonalUp": "diagonalDown",
+	}
+)
+
+// BorderType provides a function to get the border type to be drawn by given
+// border type, the left and right borders, and the diagonal borders will be
+// mirrored in the right to left worksheet.
+func BorderType(typ string, rtl bool) string {
+	if !rtl {
+		return typ
+	}
+	if mirrored, ok := mirroredBorders[typ]; ok {
+		return mirrored
+	}
+	return typ
+}
+
+// ParseColor provides a function to parse the hex color code in 'RRGGBB' or
+// 'AARRGGBB' notation, the false will be returned if the given color code is
+// invalid.
+func ParseColor(hexColor string) (color.RGBA, bool) {
+	hexColor = strings.TrimPrefix(hexColor, "#")
+	if len(hexColor) == 8 {
+		hexColor = hexColor[2:]
+	}
+	if len(hexColor) != 6 {
+		return color.RGBA{}, false
+	}
+	val, err := strconv.ParseUint(hexColor, 16, 32)
+	if err != nil {
+		return color.RGBA{}, false
+	}
+	return color.RGBA{R: uint8(val >> 16), G: uint8(val >> 8), B: uint8(val), A: 0xFF}, true
+}
+
+// FillColor provides a function to get the fill color of the cell by given
+// fill type, pattern and colors of the cell style, the false will be returned
+// if the cell has no fill.
+func FillColor(fillType string, pattern int, colors []string) (color.RGBA, bool) {
+	if len(colors) == 0 || (fillType == "pattern" && pattern == 0) {
+		return color.RGBA{}, false
+	}
+	return ParseColor(colors[0])
+}
+
+// HorizontalAlignment provides a function to get the horizontal alignment of
+// the cell by given horizontal alignment of the cell style and the kind of
+// the cell value. For the general horizontal alignment, the numbers will be
+// aligned right, the boolean and error values will be aligned center, and
+// the text will be aligned left, which will be mirrored in the right to left
+// worksheet.
+func HorizontalAlignment(horizontal string, kind ValueKind, rtl bool) string {
+	if horizontal != "" && horizontal != "general" {
+		return horizontal
+	}
+	start, end := "left", "right"
+	if rtl {
+		start, end = end, start
+	}
+	switch kind {
+	case ValueLogical:
+		return "center"
+	case ValueNumber:
+		return end
+	}
+	return start
+}
+
+// AlignX provides a function to get the horizontal position of the text line
+// in the cell by given horizontal alignment, the left position and width of
+// the cell, the width of the text, the indent and the padding of the cell.
+func AlignX(horizontal string, x, width, textWidth, indent, padding float64) float64 {
+	switch horizontal {
+	case "right":
+		return x + width - padding - textWidth - indent
+	case "center", "centerContinuous", "distributed":
+		return x + (width-textWidth)/2
+	}
+	return x + padding + indent
+}
+
+// AlignY provides a function to get the top position of the text lines in the
+// cell by given vertical alignment, the top position and height of the cell,
+// and the total height of the text lines. The text lines will be kept 1 unit
+// from the top edge and 2 units from the bottom edge of the cell.
+func AlignY(vertical string, y, height, textHeight float64) float64 {
+	switch vertical {
+	case "top":
+		return y + 1
+	case "center", "justify", "distributed":
+		return y + (height-textHeight)/2
+	}
+	return y + height - 2 - textHeight
+}
+
+// WrapText provides a function to split the text into lines which fit the
+// given width by the words, the width of the text is measured by the given
+// function.
+func WrapText(text string, width float64, measure func(string) float64) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		var line string
+		for _, word := range strings.Fields(paragraph) {
+			if line == "" {
+				line = word
+				continue
+			}
+			if measure(line+" "+word) > width {
+				lines = append(lines, line)
+				line = word
+				continue
+			}
+			line += " " + word
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// Spans provides a function to get the cells to be drawn in the range by
+// given range and the merged cells of the worksheet, the merged cells will
+// be clipped by the range and drawn as a single cell with the value of the
+// top-left cell, and the other cells will be drawn in row-major order after
+// the merged cells.
+func Spans(rng Span, mergeCells []Span) []Span {
+	var spans []Span
+	covered := make(map[[2]int]bool)
+	for _, mc := range mergeCells {
+		mc.Col, mc.Row = mc.Col1, mc.Row1
+		if mc.Col1 < rng.Col1 {
+			mc.Col1 = rng.Col1
+		}
+		if mc.Row1 < rng.Row1 {
+			mc.Row1 = rng.Row1
+		}
+		if mc.Col2 > rng.Col2 {
+			mc.Col2 = rng.Col2
+		}
+		if mc.Row2 > rng.Row2 {
+			mc.Row2 = rng.Row2
+		}
+		if mc.Col1 > mc.Col2 || mc.Row1 > mc.Row2 {
+			continue
+		}
+		for col := mc.Col1; col <= mc.Col2; col++ {
+			for row := mc.Row1; row <= mc.Row2; row++ {
+				covered[[2]int{col, row}] = true
+			}
+		}
+		spans = append(spans, mc)
+	}
+	for row := rng.Row1; row <= rng.Row2; row++ {
+		for col := rng.Col1; col <= rng.Col2; col++ {
+			if !covered[[2]int{col, row}] {
+				spans = append(spans, Span{Col1: col, Row1: row, Col2: col, Row2: row, Col: col, Row: row})
+			}
+		}
+	}
+	return spans
+}
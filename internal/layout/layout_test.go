@@ -0,0 +1,75 @@
+package layout
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBorderType(t *testing.T) {
+	assert.Equal(t, "left", BorderType("left", false))
+	assert.Equal(t, "right", BorderType("left", true))
+	assert.Equal(t, "diagonalUp", BorderType("diagonalDown", true))
+	assert.Equal(t, "top", BorderType("top", true))
+}
+
+func TestParseColor(t *testing.T) {
+	clr, ok := ParseColor("#4472C4")
+	assert.True(t, ok)
+	assert.Equal(t, color.RGBA{R: 0x44, G: 0x72, B: 0xC4, A: 0xFF}, clr)
+	clr, ok = ParseColor("FF4472C4")
+	assert.True(t, ok)
+	assert.Equal(t, color.RGBA{R: 0x44, G: 0x72, B: 0xC4, A: 0xFF}, clr)
+	for _, hexColor := range []string{"", "4472C", "GGGGGG"} {
+		_, ok = ParseColor(hexColor)
+		assert.False(t, ok, hexColor)
+	}
+}
+
+func TestFillColor(t *testing.T) {
+	_, ok := FillColor("pattern", 0, []string{"4472C4"})
+	assert.False(t, ok)
+	_, ok = FillColor("pattern", 1, nil)
+	assert.False(t, ok)
+	clr, ok := FillColor("gradient", 0, []string{"4472C4", "FFFFFF"})
+	assert.True(t, ok)
+	assert.Equal(t, color.RGBA{R: 0x44, G: 0x72, B: 0xC4, A: 0xFF}, clr)
+}
+
+func TestHorizontalAlignment(t *testing.T) {
+	assert.Equal(t, "center", HorizontalAlignment("center", ValueNumber, false))
+	assert.Equal(t, "left", HorizontalAlignment("", ValueText, false))
+	assert.Equal(t, "right", HorizontalAlignment("general", ValueNumber, false))
+	assert.Equal(t, "center", HorizontalAlignment("", ValueLogical, true))
+	assert.Equal(t, "right", HorizontalAlignment("", ValueText, true))
+	assert.Equal(t, "left", HorizontalAlignment("", ValueNumber, true))
+}
+
+func TestAlign(t *testing.T) {
+	assert.Equal(t, 12.0, AlignX("left", 10, 100, 20, 0, 2))
+	assert.Equal(t, 83.0, AlignX("right", 10, 100, 20, 5, 2))
+	assert.Equal(t, 50.0, AlignX("center", 10, 100, 20, 5, 2))
+	assert.Equal(t, 11.0, AlignY("top", 10, 40, 20))
+	assert.Equal(t, 20.0, AlignY("center", 10, 40, 20))
+	assert.Equal(t, 28.0, AlignY("", 10, 40, 20))
+}
+
+func TestWrapText(t *testing.T) {
+	measure := func(text string) float64 { return float64(len(text)) }
+	assert.Equal(t, []string{"one two", "three", "four"}, WrapText("one two three\nfour", 8, measure))
+	assert.Equal(t, []string{""}, WrapText("", 8, measure))
+}
+
+func TestSpans(t *testing.T) {
+	spans := Spans(Span{Col1: 2, Row1: 2, Col2: 3, Row2: 3}, []Span{
+		{Col1: 1, Row1: 1, Col2: 2, Row2: 2},
+		{Col1: 5, Row1: 5, Col2: 6, Row2: 6},
+	})
+	assert.Equal(t, []Span{
+		{Col1: 2, Row1: 2, Col2: 2, Row2: 2, Col: 1, Row: 1},
+		{Col1: 3, Row1: 2, Col2: 3, Row2: 2, Col: 3, Row: 2},
+		{Col1: 2, Row1: 3, Col2: 2, Row2: 3, Col: 2, Row: 3},
+		{Col1: 3, Row1: 3, Col2: 3, Row2: 3, Col: 3, Row: 3},
+	}, spans)
+}
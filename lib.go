@@ -22,11 +22,18 @@ import (
 	"math/big"
 	"os"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 )
 
-// ReadZipReader extract spreadsheet with given options.
+// ReadZipReader extract spreadsheet with given options. The media parts in
+// the xl/media folder will not be decompressed on opening the spreadsheet.
+// They will be read from the source file on demand if the spreadsheet was
+// opened by the OpenFile function, otherwise each compressed entry is kept
+// in memory standalone without referencing the source package. The media
+// parts will be decompressed on first access, or copied as is without
+// decompressing on saving the workbook.
 func (f *File) ReadZipReader(r *zip.Reader) (map[string][]byte, int, error) {
 	var (
 		err     error
@@ -57,6 +64,16 @@ func (f *File) ReadZipReader(r *zip.Reader) (map[string][]byte, int, error) {
 				continue
 			}
 		}
+		if strings.HasPrefix(fileName, "xl/media/") && !v.FileInfo().IsDir() {
+			media := v
+			if f.source == nil {
+				if media, err = newLazyMediaPart(v); err != nil {
+					return nil, 0, err
+				}
+			}
+			f.lazyMedia.Store(fileName, media)
+			continue
+		}
 		if strings.HasPrefix(strings.ToLower(fileName), "xl/worksheets/sheet") {
 			worksheets++
 			if fileSize > f.options.UnzipXMLSizeLimit && !v.FileInfo().IsDir() {
@@ -70,12 +87,36 @@ func (f *File) ReadZipReader(r *zip.Reader) (map[string][]byte, int, error) {
 			}
 		}
 		if fileList[fileName], err = readFile(v); err != nil {
+			if f.options.PartialRecovery && strings.HasPrefix(strings.ToLower(fileName), "xl/worksheets/sheet") {
+				delete(fileList, fileName)
+				f.brokenSheets.Store(fileName, ErrBrokenSheet{Part: fileName, Err: err})
+				continue
+			}
 			return nil, 0, err
 		}
 	}
 	return fileList, worksheets, nil
 }
 
+// newLazyMediaPart provides a function to copy the compressed zip entry into
+// a standalone single entry zip archive, so that the lazily loaded media part
+// doesn't keep the whole source package in memory.
+func newLazyMediaPart(file *zip.File) (*zip.File, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	if err := zw.Copy(file); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		return nil, err
+	}
+	return zr.File[0], err
+}
+
 // unzipToTemp unzip the zip entity to the system temporary directory and
 // returned the unzipped file path.
 func (f *File) unzipToTemp(zipFile *zip.File) (string, error) {
@@ -96,6 +137,17 @@ func (f *File) unzipToTemp(zipFile *zip.File) (string, error) {
 	return tmp.Name(), tmp.Close()
 }
 
+// newGUID provides a function to generate a random GUID in the registry
+// format, such as {2E4F4E59-0B52-4E3C-9D12-7B35F6A1C0D8}.
+func newGUID() (string, error) {
+	b, err := randomBytes(16)
+	if err != nil {
+		return "", err
+	}
+	b[6], b[8] = b[6]&0x0f|0x40, b[8]&0x3f|0x80
+	return fmt.Sprintf("{%X-%X-%X-%X-%X}", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), err
+}
+
 // readXML provides a function to read XML content as bytes.
 func (f *File) readXML(name string) []byte {
 	if content, _ := f.Pkg.Load(name); content != nil {
@@ -113,6 +165,9 @@ func (f *File) readBytes(name string) []byte {
 	if len(content) != 0 {
 		return content
 	}
+	if media, ok := f.readMedia(name); ok {
+		return media
+	}
 	file, err := f.readTemp(name)
 	if err != nil {
 		return content
@@ -123,6 +178,36 @@ func (f *File) readBytes(name string) []byte {
 	return content
 }
 
+// readMedia provides a function to read the media part by given part path.
+// The media parts are kept compressed when opening the workbook, and will be
+// extracted into the package parts on first access.
+func (f *File) readMedia(name string) ([]byte, bool) {
+	if content, _ := f.Pkg.Load(name); content != nil {
+		return content.([]byte), true
+	}
+	file, ok := f.lazyMedia.Load(name)
+	if !ok {
+		return nil, false
+	}
+	content, err := readFile(file.(*zip.File))
+	if err != nil {
+		return nil, false
+	}
+	f.Pkg.Store(name, content)
+	f.lazyMedia.Delete(name)
+	return content, true
+}
+
+// hasMedia provides a function to check if the media part exists in the
+// package parts or the source package by given part path.
+func (f *File) hasMedia(name string) bool {
+	if _, ok := f.Pkg.Load(name); ok {
+		return true
+	}
+	_, ok := f.lazyMedia.Load(name)
+	return ok
+}
+
 // readTemp read file from system temporary directory by given path.
 func (f *File) readTemp(name string) (file *os.File, err error) {
 	path, ok := f.tempFiles.Load(name)
@@ -400,6 +485,33 @@ func inCoordinates(a [][]int, x []int) int {
 	return -1
 }
 
+// contiguousRuns provides a function to group the numbers into the sorted
+// runs of consecutive numbers, the duplicate numbers will be ignored, and each
+// run contains the first number and the count of numbers in the run.
+func contiguousRuns(nums []int) [][2]int {
+	sorted := make([]int, len(nums))
+	copy(sorted, nums)
+	sort.Ints(sorted)
+	var runs [][2]int
+	for _, num := range sorted {
+		if last := len(runs) - 1; last >= 0 && runs[last][0]+runs[last][1] >= num {
+			if runs[last][0]+runs[last][1] == num {
+				runs[last][1]++
+			}
+			continue
+		}
+		runs = append(runs, [2]int{num, 1})
+	}
+	return runs
+}
+
+// inRuns returns whether the number is within the sorted runs of consecutive
+// numbers.
+func inRuns(runs [][2]int, num int) bool {
+	i := sort.Search(len(runs), func(i int) bool { return runs[i][0]+runs[i][1] > num })
+	return i < len(runs) && runs[i][0] <= num
+}
+
 // inStrSlice provides a method to check if an element is present in an array,
 // and return the index of its location, otherwise return -1.
 func inStrSlice(a []string, x string, caseSensitive bool) int {
@@ -685,15 +797,129 @@ func getXMLNamespace(space string, attr []xml.Attr) string {
 	return space
 }
 
+// canonicalXMLAttrs provides a function to sort the XML root element
+// attributes in canonical order. The namespace declarations will be placed
+// before other attributes and sorted by the prefix, other attributes will be
+// sorted by the qualified name, and the prefixes in the ignorable attribute
+// value will be sorted.
+func canonicalXMLAttrs(attrs []xml.Attr) []xml.Attr {
+	sorted := make([]xml.Attr, len(attrs))
+	copy(sorted, attrs)
+	key := func(attr xml.Attr) string {
+		if attr.Name.Space == "" && attr.Name.Local == "xmlns" {
+			return "0"
+		}
+		if attr.Name.Space == "xmlns" {
+			return "1" + attr.Name.Local
+		}
+		return "2" + getXMLNamespace(attr.Name.Space, attrs) + ":" + attr.Name.Local
+	}
+	for i, attr := range sorted {
+		if attr.Name.Local == "Ignorable" {
+			prefixes := strings.Fields(attr.Value)
+			sort.Strings(prefixes)
+			sorted[i].Value = strings.Join(prefixes, " ")
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return key(sorted[i]) < key(sorted[j])
+	})
+	return sorted
+}
+
 // replaceNameSpaceBytes provides a function to replace the XML root element
 // attribute by the given component part path and XML content.
 func (f *File) replaceNameSpaceBytes(path string, contentMarshal []byte) []byte {
+	sourceXmlns, targetXmlns := f.nameSpaceBytes(path)
+	return bytesReplace(contentMarshal, sourceXmlns, targetXmlns, -1)
+}
+
+// replaceWriter directly maps a writer which replaces the byte sequences of
+// the content written to the underlying writer, the content is buffered for
+// the longest byte sequence to match across the writes.
+type replaceWriter struct {
+	w      io.Writer
+	pairs  [][2][]byte
+	buf    []byte
+	maxLen int
+}
+
+// newReplaceWriter returns a writer which replaces each pair of the byte
+// sequences of the content written to the given writer.
+func newReplaceWriter(w io.Writer, pairs [][2][]byte) *replaceWriter {
+	rw := &replaceWriter{w: w, pairs: pairs}
+	for _, pair := range pairs {
+		if len(pair[0]) > rw.maxLen {
+			rw.maxLen = len(pair[0])
+		}
+	}
+	return rw
+}
+
+// Write buffers the content, and writes the replaced content which could not
+// be a part of the byte sequences to the underlying writer.
+func (rw *replaceWriter) Write(p []byte) (int, error) {
+	rw.buf = append(rw.buf, p...)
+	if err := rw.replace(false); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Flush writes all of the buffered content to the underlying writer.
+func (rw *replaceWriter) Flush() error {
+	return rw.replace(true)
+}
+
+// replace provides a function to replace the byte sequences in the buffered
+// content and write the replaced content to the underlying writer, the tail
+// of the buffered content will be kept for matching with the next write if
+// flush is false.
+func (rw *replaceWriter) replace(flush bool) error {
+	for {
+		idx, pair := -1, 0
+		for i, p := range rw.pairs {
+			if j := bytes.Index(rw.buf, p[0]); j != -1 && (idx == -1 || j < idx) {
+				idx, pair = j, i
+			}
+		}
+		if idx == -1 {
+			break
+		}
+		if _, err := rw.w.Write(rw.buf[:idx]); err != nil {
+			return err
+		}
+		if _, err := rw.w.Write(rw.pairs[pair][1]); err != nil {
+			return err
+		}
+		rw.buf = rw.buf[idx+len(rw.pairs[pair][0]):]
+	}
+	n := len(rw.buf)
+	if !flush {
+		n -= rw.maxLen - 1
+	}
+	if n > 0 {
+		if _, err := rw.w.Write(rw.buf[:n]); err != nil {
+			return err
+		}
+		rw.buf = rw.buf[n:]
+	}
+	return nil
+}
+
+// nameSpaceBytes provides a function to get the default namespace declaration
+// of the serialized part and the namespace declarations for replacing it by
+// given component part path.
+func (f *File) nameSpaceBytes(path string) ([]byte, []byte) {
 	sourceXmlns := []byte(`xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
 	targetXmlns := []byte(templateNamespaceIDMap)
 	if attrs, ok := f.xmlAttr.Load(path); ok {
+		if f.options != nil && f.options.Canonical {
+			attrs = canonicalXMLAttrs(attrs.([]xml.Attr))
+		}
 		targetXmlns = []byte(genXMLNamespace(attrs.([]xml.Attr)))
 	}
-	return bytesReplace(contentMarshal, sourceXmlns, bytes.ReplaceAll(targetXmlns, []byte(" mc:Ignorable=\"r\""), []byte{}), -1)
+	return sourceXmlns, bytes.ReplaceAll(targetXmlns, []byte(" mc:Ignorable=\"r\""), []byte{})
 }
 
 // addNameSpaces provides a function to add an XML attribute by the given
@@ -233,6 +233,16 @@ func TestSortCoordinates(t *testing.T) {
 	assert.EqualError(t, sortCoordinates(make([]int, 3)), ErrCoordinates.Error())
 }
 
+func TestContiguousRuns(t *testing.T) {
+	assert.Empty(t, contiguousRuns(nil))
+	assert.Equal(t, [][2]int{{2, 3}, {6, 1}, {8, 2}}, contiguousRuns([]int{2, 3, 4, 6, 8, 9}))
+	assert.Equal(t, [][2]int{{2, 3}, {6, 1}}, contiguousRuns([]int{6, 4, 2, 3, 3, 6}))
+	runs := contiguousRuns([]int{2, 3, 4, 6})
+	for num, expected := range map[int]bool{1: false, 2: true, 4: true, 5: false, 6: true, 7: false} {
+		assert.Equal(t, expected, inRuns(runs, num), num)
+	}
+}
+
 func TestInStrSlice(t *testing.T) {
 	assert.EqualValues(t, -1, inStrSlice([]string{}, "", true))
 }
@@ -287,6 +297,45 @@ func TestBytesReplace(t *testing.T) {
 	assert.EqualValues(t, s, bytesReplace(s, []byte{}, []byte{}, 0))
 }
 
+func TestReplaceWriter(t *testing.T) {
+	var buf bytes.Buffer
+	rw := newReplaceWriter(&buf, [][2][]byte{{[]byte("abc"), []byte("x")}, {[]byte("cd"), []byte("abcd")}})
+	for _, c := range []byte("abcdabcccdab") {
+		n, err := rw.Write([]byte{c})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+	}
+	assert.NoError(t, rw.Flush())
+	assert.Equal(t, "xdxcabcdab", buf.String())
+	// Test write with the underlying writer error
+	for _, content := range []string{"abc", "ab", "zzabc"} {
+		rw = newReplaceWriter(&failWriter{}, [][2][]byte{{[]byte("abc"), []byte("x")}})
+		_, err := rw.Write([]byte(content))
+		if err == nil {
+			err = rw.Flush()
+		}
+		assert.Equal(t, io.ErrShortWrite, err)
+	}
+	rw = newReplaceWriter(&failWriter{n: 1}, [][2][]byte{{[]byte("abc"), []byte("x")}})
+	_, err := rw.Write([]byte("zabc"))
+	assert.Equal(t, io.ErrShortWrite, err)
+}
+
+// failWriter directly maps a writer which returns the error after writing the
+// given times.
+type failWriter struct {
+	n int
+}
+
+// Write returns the io.ErrShortWrite error if the writes exceed the limit.
+func (w *failWriter) Write(p []byte) (int, error) {
+	if w.n > 0 {
+		w.n--
+		return len(p), nil
+	}
+	return 0, io.ErrShortWrite
+}
+
 func TestGetRootElement(t *testing.T) {
 	assert.Len(t, getRootElement(xml.NewDecoder(strings.NewReader(""))), 0)
 }
@@ -357,6 +406,31 @@ func TestReadBytes(t *testing.T) {
 	assert.Equal(t, []byte{}, f.readBytes(sheet))
 }
 
+func TestNewLazyMediaPart(t *testing.T) {
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	fi, err := zw.Create("xl/media/image1.png")
+	assert.NoError(t, err)
+	_, err = fi.Write([]byte("image"))
+	assert.NoError(t, err)
+	assert.NoError(t, zw.Close())
+	source := buf.Bytes()
+	zr, err := zip.NewReader(bytes.NewReader(source), int64(len(source)))
+	assert.NoError(t, err)
+	media, err := newLazyMediaPart(zr.File[0])
+	assert.NoError(t, err)
+	// Test the media part doesn't reference the source package
+	for i := range source {
+		source[i] = 0
+	}
+	content, err := readFile(media)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("image"), content)
+	// Test copy the media part with corrupted local file header
+	_, err = newLazyMediaPart(zr.File[0])
+	assert.Equal(t, zip.ErrFormat, err)
+}
+
 func TestUnzipToTemp(t *testing.T) {
 	if ver := runtime.Version(); strings.HasPrefix(ver, "go1.19") || strings.HasPrefix(ver, "go1.2") {
 		t.Skip()
@@ -0,0 +1,229 @@
+// Copyright 2016 - 2024 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.18 or later.
+
+package excelize
+
+import (
+	"strings"
+	"unicode"
+)
+
+// cultureInfo directly maps the locale-specific settings of the culture, the
+// built-in number format codes, the separators, function names, boolean and
+// error values used in the localized formulas.
+type cultureInfo struct {
+	lang                                          string
+	numFmt                                        map[int]string
+	decimalSep, listSep, arrayColSep, arrayRowSep rune
+	functions, booleans, errors                   map[string]string
+}
+
+// cultures defined the locale-specific settings of the supported cultures.
+var cultures = map[CultureName]*cultureInfo{
+	CultureNameZhCN: {lang: "zh-cn"},
+	CultureNameZhTW: {lang: "zh-tw"},
+	CultureNameJaJP: {lang: "ja-jp"},
+	CultureNameKoKR: {lang: "ko-kr"},
+	CultureNameThTH: {lang: "th-th"},
+	CultureNameDeDE: {
+		numFmt: map[int]string{
+			14: "dd.mm.yyyy", 15: "dd. mmm yy", 16: "dd. mmm", 17: "mmm yy", 22: "dd.mm.yyyy hh:mm",
+		},
+		decimalSep: ',', listSep: ';', arrayColSep: '.', arrayRowSep: ';',
+		functions: map[string]string{
+			"ABRUNDEN": "ROUNDDOWN", "ANZAHL": "COUNT", "ANZAHL2": "COUNTA", "ANZAHLLEEREZELLEN": "COUNTBLANK",
+			"AUFRUNDEN": "ROUNDUP", "BEREICH.VERSCHIEBEN": "OFFSET", "DATUM": "DATE", "EDATUM": "EDATE",
+			"ERSETZEN": "REPLACE", "FINDEN": "FIND", "GANZZAHL": "INT", "GLÄTTEN": "TRIM", "GROSS": "UPPER",
+			"HEUTE": "TODAY", "INDIREKT": "INDIRECT", "ISTFEHLER": "ISERROR", "ISTLEER": "ISBLANK",
+			"ISTZAHL": "ISNUMBER", "JAHR": "YEAR", "JETZT": "NOW", "KLEIN": "LOWER", "KÜRZEN": "TRUNC",
+			"LINKS": "LEFT", "LÄNGE": "LEN", "MITTELWERT": "AVERAGE", "MONAT": "MONTH", "MONATSENDE": "EOMONTH",
+			"NETTOARBEITSTAGE": "NETWORKDAYS", "NICHT": "NOT", "ODER": "OR", "POTENZ": "POWER", "RANG": "RANK",
+			"RECHTS": "RIGHT", "REST": "MOD", "RUNDEN": "ROUND", "SPALTE": "COLUMN", "STABW": "STDEV",
+			"SUCHEN": "SEARCH", "SUMME": "SUM", "SUMMENPRODUKT": "SUMPRODUCT", "SUMMEWENN": "SUMIF",
+			"SUMMEWENNS": "SUMIFS", "SVERWEIS": "VLOOKUP", "TAG": "DAY", "TEIL": "MID", "TEILERGEBNIS": "SUBTOTAL",
+			"UND": "AND", "VERGLEICH": "MATCH", "VERKETTEN": "CONCATENATE", "WAHL": "CHOOSE", "WECHSELN": "SUBSTITUTE",
+			"WENN": "IF", "WENNFEHLER": "IFERROR", "WERT": "VALUE", "WOCHENTAG": "WEEKDAY", "WURZEL": "SQRT",
+			"WVERWEIS": "HLOOKUP", "ZEILE": "ROW", "ZUFALLSZAHL": "RAND", "ZÄHLENWENN": "COUNTIF",
+			"ZÄHLENWENNS": "COUNTIFS",
+		},
+		booleans: map[string]string{"WAHR": "TRUE", "FALSCH": "FALSE"},
+		errors: map[string]string{
+			"#BEZUG!": "#REF!", "#NV": "#N/A", "#NULL!": "#NULL!", "#WERT!": "#VALUE!", "#ZAHL!": "#NUM!",
+		},
+	},
+	CultureNameFrFR: {
+		numFmt: map[int]string{
+			14: "dd/mm/yyyy", 15: "dd-mmm-yy", 16: "dd-mmm", 17: "mmm-yy", 22: "dd/mm/yyyy hh:mm",
+		},
+		decimalSep: ',', listSep: ';', arrayColSep: '.', arrayRowSep: ';',
+		functions: map[string]string{
+			"ALEA": "RAND", "ANNEE": "YEAR", "ARRONDI": "ROUND", "ARRONDI.INF": "ROUNDDOWN", "ARRONDI.SUP": "ROUNDUP",
+			"AUJOURDHUI": "TODAY", "CHERCHE": "SEARCH", "CHOISIR": "CHOOSE", "CNUM": "VALUE", "COLONNE": "COLUMN",
+			"CONCATENER": "CONCATENATE", "DECALER": "OFFSET", "DROITE": "RIGHT", "ECARTYPE": "STDEV", "ENT": "INT",
+			"EQUIV": "MATCH", "ESTERREUR": "ISERROR", "ESTNUM": "ISNUMBER", "ESTVIDE": "ISBLANK", "ET": "AND",
+			"FIN.MOIS": "EOMONTH", "GAUCHE": "LEFT", "JOUR": "DAY", "JOURSEM": "WEEKDAY", "LIGNE": "ROW",
+			"MAINTENANT": "NOW", "MAJUSCULE": "UPPER", "MEDIANE": "MEDIAN", "MINUSCULE": "LOWER", "MOIS": "MONTH",
+			"MOIS.DECALER": "EDATE", "MOYENNE": "AVERAGE", "NB": "COUNT", "NB.JOURS.OUVRES": "NETWORKDAYS",
+			"NB.SI": "COUNTIF", "NB.SI.ENS": "COUNTIFS", "NB.VIDE": "COUNTBLANK", "NBCAR": "LEN", "NBVAL": "COUNTA",
+			"NON": "NOT", "OU": "OR", "PUISSANCE": "POWER", "RACINE": "SQRT", "RECHERCHEH": "HLOOKUP",
+			"RECHERCHEV": "VLOOKUP", "REMPLACER": "REPLACE", "SI": "IF", "SIERREUR": "IFERROR", "SOMME": "SUM",
+			"SOMME.SI": "SUMIF", "SOMME.SI.ENS": "SUMIFS", "SOMMEPROD": "SUMPRODUCT", "SOUS.TOTAL": "SUBTOTAL",
+			"STXT": "MID", "SUBSTITUE": "SUBSTITUTE", "SUPPRESPACE": "TRIM", "TEXTE": "TEXT", "TROUVE": "FIND",
+			"TRONQUE": "TRUNC",
+		},
+		booleans: map[string]string{"VRAI": "TRUE", "FAUX": "FALSE"},
+		errors: map[string]string{
+			"#NOM?": "#NAME?", "#NOMBRE!": "#NUM!", "#NUL!": "#NULL!", "#VALEUR!": "#VALUE!",
+		},
+	},
+	CultureNameEsES: {
+		numFmt: map[int]string{
+			14: "dd/mm/yyyy", 15: "dd-mmm-yy", 16: "dd-mmm", 17: "mmm-yy", 22: "dd/mm/yyyy h:mm",
+		},
+		decimalSep: ',', listSep: ';', arrayColSep: '\\', arrayRowSep: ';',
+		functions: map[string]string{
+			"AHORA": "NOW", "ALEATORIO": "RAND", "AÑO": "YEAR", "BUSCARH": "HLOOKUP", "BUSCARV": "VLOOKUP",
+			"COINCIDIR": "MATCH", "COLUMNA": "COLUMN", "CONCATENAR": "CONCATENATE", "CONTAR": "COUNT",
+			"CONTAR.BLANCO": "COUNTBLANK", "CONTAR.SI": "COUNTIF", "CONTAR.SI.CONJUNTO": "COUNTIFS",
+			"CONTARA": "COUNTA", "DERECHA": "RIGHT", "DESREF": "OFFSET", "DESVEST": "STDEV", "DIA": "DAY",
+			"DIAS.LAB": "NETWORKDAYS", "DIASEM": "WEEKDAY", "ELEGIR": "CHOOSE", "ENCONTRAR": "FIND",
+			"ENTERO": "INT", "ESBLANCO": "ISBLANK", "ESERROR": "ISERROR", "ESNUMERO": "ISNUMBER",
+			"ESPACIOS": "TRIM", "EXTRAE": "MID", "FECHA": "DATE", "FECHA.MES": "EDATE", "FILA": "ROW",
+			"FIN.MES": "EOMONTH", "HALLAR": "SEARCH", "HOY": "TODAY", "INDICE": "INDEX", "INDIRECTO": "INDIRECT",
+			"IZQUIERDA": "LEFT", "JERARQUIA": "RANK", "LARGO": "LEN", "MAYUSC": "UPPER", "MEDIANA": "MEDIAN",
+			"MES": "MONTH", "MINUSC": "LOWER", "NO": "NOT", "O": "OR", "POTENCIA": "POWER", "PROMEDIO": "AVERAGE",
+			"RAIZ": "SQRT", "REDONDEAR": "ROUND", "REDONDEAR.MAS": "ROUNDUP", "REDONDEAR.MENOS": "ROUNDDOWN",
+			"REEMPLAZAR": "REPLACE", "RESIDUO": "MOD", "SI": "IF", "SI.ERROR": "IFERROR", "SUBTOTALES": "SUBTOTAL",
+			"SUMA": "SUM", "SUMAPRODUCTO": "SUMPRODUCT", "SUMAR.SI": "SUMIF", "SUMAR.SI.CONJUNTO": "SUMIFS",
+			"SUSTITUIR": "SUBSTITUTE", "TEXTO": "TEXT", "TRUNCAR": "TRUNC", "VALOR": "VALUE", "Y": "AND",
+		},
+		booleans: map[string]string{"VERDADERO": "TRUE", "FALSO": "FALSE"},
+		errors: map[string]string{
+			"#¡DIV/0!": "#DIV/0!", "#¡NULO!": "#NULL!", "#¡NUM!": "#NUM!", "#¡REF!": "#REF!",
+			"#¡VALOR!": "#VALUE!", "#¿NOMBRE?": "#NAME?",
+		},
+	},
+}
+
+// canonicalFormula provides a function to translate the formula written in
+// the localized function names, boolean and error values, and separators of
+// the culture specified by the CultureInfo option to the canonical storage
+// form. The string literals, quoted sheet names and structured references in
+// the formula will keep unchanged.
+func (f *File) canonicalFormula(formula string) string {
+	culture, ok := cultures[f.options.CultureInfo]
+	if !ok || culture.functions == nil {
+		return formula
+	}
+	var (
+		b                strings.Builder
+		runes            = []rune(formula)
+		braces, brackets int
+	)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case brackets > 0 || r == '[':
+			if r == '[' {
+				brackets++
+			} else if r == ']' {
+				brackets--
+			}
+			b.WriteRune(r)
+		case r == '"' || r == '\'':
+			j := i + 1
+			for ; j < len(runes); j++ {
+				if runes[j] == r {
+					if j+1 < len(runes) && runes[j+1] == r {
+						j++
+						continue
+					}
+					break
+				}
+			}
+			if j >= len(runes) {
+				j = len(runes) - 1
+			}
+			b.WriteString(string(runes[i : j+1]))
+			i = j
+		case r == '#':
+			i += culture.writeErrorValue(&b, runes[i:]) - 1
+		case unicode.IsDigit(r):
+			for ; i < len(runes); i++ {
+				if runes[i] == culture.decimalSep && i+1 < len(runes) && unicode.IsDigit(runes[i+1]) {
+					b.WriteRune('.')
+					continue
+				}
+				if !unicode.IsDigit(runes[i]) {
+					break
+				}
+				b.WriteRune(runes[i])
+			}
+			i--
+		case unicode.IsLetter(r) || r == '_':
+			j := i
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '.' || runes[j] == '_') {
+				j++
+			}
+			b.WriteString(culture.canonicalName(string(runes[i:j]), runes[j:]))
+			i = j - 1
+		case r == '{':
+			braces++
+			b.WriteRune(r)
+		case r == '}':
+			braces--
+			b.WriteRune(r)
+		case braces > 0 && r == culture.arrayColSep:
+			b.WriteRune(',')
+		case braces > 0 && r == culture.arrayRowSep:
+			b.WriteRune(';')
+		case braces == 0 && r == culture.listSep:
+			b.WriteRune(',')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// canonicalName provides a function to get the canonical name of the
+// localized function name or boolean value by given name and the remaining
+// characters after the name in the formula.
+func (c *cultureInfo) canonicalName(name string, rest []rune) string {
+	if len(rest) > 0 && rest[0] == '(' {
+		if fn, ok := c.functions[strings.ToUpper(name)]; ok {
+			return fn
+		}
+		return name
+	}
+	if len(rest) > 0 && (rest[0] == '!' || rest[0] == ':') {
+		return name
+	}
+	if val, ok := c.booleans[strings.ToUpper(name)]; ok {
+		return val
+	}
+	return name
+}
+
+// writeErrorValue provides a function to write the canonical error value of
+// the localized error value at the beginning of the given characters, and
+// returns the number of the characters has been consumed.
+func (c *cultureInfo) writeErrorValue(b *strings.Builder, runes []rune) int {
+	text := strings.ToUpper(string(runes))
+	for localized, canonical := range c.errors {
+		if strings.HasPrefix(text, localized) {
+			b.WriteString(canonical)
+			return len([]rune(localized))
+		}
+	}
+	b.WriteRune(runes[0])
+	return 1
+}
@@ -0,0 +1,39 @@
+package excelize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanonicalFormula(t *testing.T) {
+	for culture, cases := range map[CultureName][][2]string{
+		CultureNameDeDE: {
+			{"=SUMME(A1;2,5)", "=SUM(A1,2.5)"},
+			{"=wenn(istzahl(A1);WAHR;falsch)", "=IF(ISNUMBER(A1),TRUE,FALSE)"},
+			{"=ZÄHLENWENN('Sheet;1'!A1:A3;\"a;b\"\"c\")", "=COUNTIF('Sheet;1'!A1:A3,\"a;b\"\"c\")"},
+			{"=SUMME({1,5.2;3.4})", "=SUM({1.5,2;3,4})"},
+			{"=WENNFEHLER(A1;#NV)+#WERT!+#DIV/0!", "=IFERROR(A1,#N/A)+#VALUE!+#DIV/0!"},
+			{"=SUMME(Table1[[#Data];[Wert]])", "=SUM(Table1[[#Data];[Wert]])"},
+			{"=BEREICH.VERSCHIEBEN(WAHR!A1;1;1)+Summe", "=OFFSET(WAHR!A1,1,1)+Summe"},
+			{"=1,5E+3+'Sheet1", "=1.5E+3+'Sheet1"},
+			{"=UNBEKANNT(1;2)", "=UNBEKANNT(1,2)"},
+		},
+		CultureNameFrFR: {
+			{"=SOMME.SI(A1:A3;\">0\")+NB.SI.ENS(B1:B3;VRAI)", "=SUMIF(A1:A3,\">0\")+COUNTIFS(B1:B3,TRUE)"},
+			{"=SI(A1>0,5;#VALEUR!;#NOM?)", "=IF(A1>0.5,#VALUE!,#NAME?)"},
+		},
+		CultureNameEsES: {
+			{"=SI.ERROR(BUSCARV(A1;B1:C3;2;FALSO);#¡REF!)", "=IFERROR(VLOOKUP(A1,B1:C3,2,FALSE),#REF!)"},
+			{"=SUMA({1\\2;3\\4})+AÑO(HOY())", "=SUM({1,2;3,4})+YEAR(TODAY())"},
+		},
+		CultureNameEnUS: {{"=SUM(A1;B1)", "=SUM(A1;B1)"}},
+		CultureNameZhCN: {{"=SUMME(A1;B1)", "=SUMME(A1;B1)"}},
+	} {
+		f := NewFile(Options{CultureInfo: culture})
+		for _, c := range cases {
+			assert.Equal(t, c[1], f.canonicalFormula(c[0]), c[0])
+		}
+		assert.NoError(t, f.Close())
+	}
+}
@@ -11,7 +11,11 @@
 
 package excelize
 
-import "strings"
+import (
+	"strings"
+
+	"github.com/mohae/deepcopy"
+)
 
 // Rect gets merged cell rectangle coordinates sequence.
 func (mc *xlsxMergeCell) Rect() ([]int, error) {
@@ -26,6 +30,19 @@ func (mc *xlsxMergeCell) Rect() ([]int, error) {
 	return mc.rect, err
 }
 
+// MergeCellOverlapType is the type of the handling for the merged cell which
+// overlaps with the existing merged cells.
+type MergeCellOverlapType byte
+
+// This section defines the currently supported merged cell overlap handling
+// types enumeration.
+const (
+	MergeCellOverlapDefault MergeCellOverlapType = iota
+	MergeCellOverlapError
+	MergeCellOverlapAbsorb
+	MergeCellOverlapSplit
+)
+
 // MergeCell provides a function to merge cells by given range reference and
 // sheet name. Merging cells only keeps the upper-left cell value, and
 // discards the other values. For example create a merged cell of D3:E9 on
@@ -49,23 +66,49 @@ func (mc *xlsxMergeCell) Rect() ([]int, error) {
 //	|                        |
 //	|A8(x3,y4)      C8(x4,y4)|
 //	+------------------------+
-func (f *File) MergeCell(sheet, topLeftCell, bottomRightCell string) error {
+//
+// Set the Overlap field of the optional options to MergeCellOverlapError for
+// returning the ErrRangeOverlap error on overlapping, or set it to
+// MergeCellOverlapAbsorb for absorbing the intersecting merged cells into the
+// new merged cell immediately, the new merged cell will be expanded to the
+// bounding range.
+//
+// Set the Overlap field to MergeCellOverlapSplit for splitting the
+// intersecting merged cells, the parts of the existing merged cells outside
+// the new merged cell will be kept as the merged cells. For example, merge
+// cells B5:C8 and absorb the intersecting merged cells:
+//
+//	err := f.MergeCell("Sheet1", "B5", "C8", excelize.MergeCellOptions{
+//	    Overlap: excelize.MergeCellOverlapAbsorb,
+//	})
+func (f *File) MergeCell(sheet, topLeftCell, bottomRightCell string, opts ...MergeCellOptions) error {
 	rect, err := rangeRefToCoordinates(topLeftCell + ":" + bottomRightCell)
 	if err != nil {
 		return err
 	}
 	// Correct the range reference, such correct C1:B3 to B1:C3.
 	_ = sortCoordinates(rect)
-
-	topLeftCell, _ = CoordinatesToCellName(rect[0], rect[1])
-	bottomRightCell, _ = CoordinatesToCellName(rect[2], rect[3])
-
+	var options MergeCellOptions
+	for _, opt := range opts {
+		options = opt
+	}
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
 	}
 	ws.mu.Lock()
 	defer ws.mu.Unlock()
+	var mergeCells []*xlsxMergeCell
+	if ws.MergeCells != nil {
+		mergeCells = ws.MergeCells.Cells
+		if options.Overlap != MergeCellOverlapDefault {
+			if mergeCells, rect, err = resolveMergeCellOverlap(mergeCells, rect, options.Overlap); err != nil {
+				return err
+			}
+		}
+	}
+	topLeftCell, _ = CoordinatesToCellName(rect[0], rect[1])
+	bottomRightCell, _ = CoordinatesToCellName(rect[2], rect[3])
 	for col := rect[0]; col <= rect[2]; col++ {
 		for row := rect[1]; row <= rect[3]; row++ {
 			if col == rect[0] && row == rect[1] {
@@ -78,22 +121,107 @@ func (f *File) MergeCell(sheet, topLeftCell, bottomRightCell string) error {
 		}
 	}
 	ref := topLeftCell + ":" + bottomRightCell
-	if ws.MergeCells != nil {
-		ws.MergeCells.Cells = append(ws.MergeCells.Cells, &xlsxMergeCell{Ref: ref, rect: rect})
-	} else {
-		ws.MergeCells = &xlsxMergeCells{Cells: []*xlsxMergeCell{{Ref: ref, rect: rect}}}
+	mergeCells = append(mergeCells, &xlsxMergeCell{Ref: ref, rect: rect})
+	if ws.MergeCells == nil {
+		ws.MergeCells = &xlsxMergeCells{}
 	}
-	ws.MergeCells.Count = len(ws.MergeCells.Cells)
+	ws.MergeCells.Cells, ws.MergeCells.Count = mergeCells, len(mergeCells)
 	return err
 }
 
+// resolveMergeCellOverlap provides a function to handle the existing merged
+// cells which overlap with the given range coordinates by given handling
+// type, returns the merged cells should be kept and the range coordinates of
+// the new merged cell.
+func resolveMergeCellOverlap(cells []*xlsxMergeCell, rect []int, overlap MergeCellOverlapType) ([]*xlsxMergeCell, []int, error) {
+	var kept, intersecting []*xlsxMergeCell
+	for _, mergeCell := range cells {
+		if mergeCell == nil {
+			continue
+		}
+		rect2, err := mergeCell.Rect()
+		if err != nil || !isOverlap(rect, sortedRect(rect2)) {
+			kept = append(kept, mergeCell)
+			continue
+		}
+		if overlap == MergeCellOverlapError {
+			ref, _ := coordinatesToRangeRef(rect)
+			return nil, rect, ErrRangeOverlap{Ref: ref, OverlapRef: mergeCell.Ref}
+		}
+		intersecting = append(intersecting, mergeCell)
+	}
+	if len(intersecting) == 0 {
+		return kept, rect, nil
+	}
+	if overlap == MergeCellOverlapAbsorb {
+		for _, mergeCell := range intersecting {
+			rect2, _ := mergeCell.Rect()
+			rect2 = sortedRect(rect2)
+			for i := 0; i < 2; i++ {
+				if rect2[i] < rect[i] {
+					rect[i] = rect2[i]
+				}
+				if rect2[i+2] > rect[i+2] {
+					rect[i+2] = rect2[i+2]
+				}
+			}
+		}
+		// The expanded range may overlap with other merged cells.
+		return resolveMergeCellOverlap(kept, rect, overlap)
+	}
+	for _, mergeCell := range intersecting {
+		rect2, _ := mergeCell.Rect()
+		for _, part := range splitRect(sortedRect(rect2), rect) {
+			ref, _ := coordinatesToRangeRef(part)
+			kept = append(kept, &xlsxMergeCell{Ref: ref, rect: part})
+		}
+	}
+	return kept, rect, nil
+}
+
+// sortedRect returns a sorted copy of the given range coordinates.
+func sortedRect(rect []int) []int {
+	sorted := append([]int{}, rect...)
+	_ = sortCoordinates(sorted)
+	return sorted
+}
+
+// splitRect provides a function to split the range coordinates by removing
+// the intersection with the given cutting range coordinates, returns the
+// parts which contain more than one cell.
+func splitRect(rect, cut []int) [][]int {
+	top, bottom := rect[1], rect[3]
+	if cut[1] > top {
+		top = cut[1]
+	}
+	if cut[3] < bottom {
+		bottom = cut[3]
+	}
+	var parts [][]int
+	for _, part := range [][]int{
+		{rect[0], rect[1], rect[2], cut[1] - 1},
+		{rect[0], cut[3] + 1, rect[2], rect[3]},
+		{rect[0], top, cut[0] - 1, bottom},
+		{cut[2] + 1, top, rect[2], bottom},
+	} {
+		if part[0] <= part[2] && part[1] <= part[3] && (part[0] != part[2] || part[1] != part[3]) {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
 // UnmergeCell provides a function to unmerge a given range reference.
 // For example unmerge range reference D3:E9 on Sheet1:
 //
 //	err := f.UnmergeCell("Sheet1", "D3", "E9")
 //
-// Attention: overlapped range will also be unmerged.
-func (f *File) UnmergeCell(sheet, topLeftCell, bottomRightCell string) error {
+// Attention: overlapped range will also be unmerged. Set the FillValue field
+// of the optional options to fill all cells of the unmerged range with the
+// value of the top-left cell, for example:
+//
+//	err := f.UnmergeCell("Sheet1", "D3", "E9", excelize.UnmergeCellOptions{FillValue: true})
+func (f *File) UnmergeCell(sheet, topLeftCell, bottomRightCell string, opts ...UnmergeCellOptions) error {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
@@ -107,14 +235,42 @@ func (f *File) UnmergeCell(sheet, topLeftCell, bottomRightCell string) error {
 
 	// Correct the range reference, such correct C1:B3 to B1:C3.
 	_ = sortCoordinates(rect1)
+	return f.unmergeCells(ws, sheet, rect1, opts...)
+}
 
+// UnmergeAll provides a function to unmerge all merged cells in the worksheet
+// by given worksheet name. Set the FillValue field of the optional options to
+// fill all cells of each unmerged range with the value of the top-left cell,
+// which is useful for normalizing the spreadsheets for ingestion. For example,
+// unmerge all merged cells on Sheet1 and fill the values:
+//
+//	err := f.UnmergeAll("Sheet1", excelize.UnmergeCellOptions{FillValue: true})
+func (f *File) UnmergeAll(sheet string, opts ...UnmergeCellOptions) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	return f.unmergeCells(ws, sheet, nil, opts...)
+}
+
+// unmergeCells provides a function to unmerge the merged cells which overlap
+// with the given range coordinates, all merged cells will be unmerged if the
+// range coordinates is nil.
+func (f *File) unmergeCells(ws *xlsxWorksheet, sheet string, rect1 []int, opts ...UnmergeCellOptions) error {
 	// return nil since no MergeCells in the sheet
 	if ws.MergeCells == nil {
 		return nil
 	}
-	if err = f.mergeOverlapCells(ws); err != nil {
+	if err := f.mergeOverlapCells(ws); err != nil {
 		return err
 	}
+	var options UnmergeCellOptions
+	for _, opt := range opts {
+		options = opt
+	}
+	var unmerged [][]int
 	i := 0
 	for _, mergeCell := range ws.MergeCells.Cells {
 		if mergeCell == nil {
@@ -124,8 +280,14 @@ func (f *File) UnmergeCell(sheet, topLeftCell, bottomRightCell string) error {
 		if !strings.Contains(mergedCellsRef, ":") {
 			mergedCellsRef += ":" + mergedCellsRef
 		}
-		rect2, _ := rangeRefToCoordinates(mergedCellsRef)
-		if isOverlap(rect1, rect2) {
+		rect2, err := rangeRefToCoordinates(mergedCellsRef)
+		if err == nil {
+			_ = sortCoordinates(rect2)
+		}
+		if rect1 == nil || (err == nil && isOverlap(rect1, rect2)) {
+			if err == nil {
+				unmerged = append(unmerged, rect2)
+			}
 			continue
 		}
 		ws.MergeCells.Cells[i] = mergeCell
@@ -136,9 +298,87 @@ func (f *File) UnmergeCell(sheet, topLeftCell, bottomRightCell string) error {
 	if ws.MergeCells.Count == 0 {
 		ws.MergeCells = nil
 	}
+	if options.FillValue {
+		for _, rect := range unmerged {
+			if err := f.fillMergedValue(ws, sheet, rect); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fillMergedValue provides a function to fill all cells of the unmerged range
+// with the value of the top-left cell by given worksheet, worksheet name and
+// range coordinates. The formula of the top-left cell will not be copied, and
+// the cached result of the formula will be used as the value.
+func (f *File) fillMergedValue(ws *xlsxWorksheet, sheet string, rect []int) error {
+	ws.prepareSheetXML(rect[0], rect[1])
+	topLeft := ws.SheetData.Row[rect[1]-1].C[rect[0]-1]
+	if topLeft.F != nil && topLeft.T == "str" {
+		topLeft.setInlineStr(topLeft.V)
+	}
+	for row := rect[1]; row <= rect[3]; row++ {
+		for col := rect[0]; col <= rect[2]; col++ {
+			if col == rect[0] && row == rect[1] {
+				continue
+			}
+			ws.prepareSheetXML(col, row)
+			c := &ws.SheetData.Row[row-1].C[col-1]
+			if err := f.removeFormula(c, ws, sheet); err != nil {
+				return err
+			}
+			c.T, c.V, c.IS, c.XMLSpace = topLeft.T, topLeft.V, nil, topLeft.XMLSpace
+			if topLeft.IS != nil {
+				c.IS = deepcopy.Copy(topLeft.IS).(*xlsxSI)
+			}
+		}
+	}
 	return nil
 }
 
+// GetMergeCellAt provides a function to get the merged cell which contains
+// the cell by given worksheet name and cell reference, the returned merged
+// cell will be nil if the cell is not in any merged cell. For example, get the
+// merged cell which contains the cell D5 on Sheet1:
+//
+//	mergeCell, err := f.GetMergeCellAt("Sheet1", "D5")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if mergeCell != nil {
+//	    fmt.Println(mergeCell.GetStartAxis(), mergeCell.GetEndAxis(), mergeCell.GetCellValue())
+//	}
+func (f *File) GetMergeCellAt(sheet, cell string) (MergeCell, error) {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return nil, err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return nil, err
+	}
+	if ws.MergeCells == nil {
+		return nil, err
+	}
+	if err = f.mergeOverlapCells(ws); err != nil {
+		return nil, err
+	}
+	for _, mergeCell := range ws.MergeCells.Cells {
+		rect, err := mergeCell.Rect()
+		if err != nil {
+			return nil, err
+		}
+		_ = sortCoordinates(rect)
+		if cellInRange([]int{col, row}, rect) {
+			val, err := f.GetCellValue(sheet, strings.Split(mergeCell.Ref, ":")[0])
+			return MergeCell{mergeCell.Ref, val}, err
+		}
+	}
+	return nil, err
+}
+
 // GetMergeCells provides a function to get all merged cells from a worksheet
 // currently.
 func (f *File) GetMergeCells(sheet string) ([]MergeCell, error) {
@@ -92,6 +92,7 @@ func TestMergeCell(t *testing.T) {
 func TestMergeCellOverlap(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.MergeCell("Sheet1", "A1", "C2"))
+	assert.Equal(t, ErrRangeOverlap{Ref: "B2:D3", OverlapRef: "A1:C2"}, f.MergeCell("Sheet1", "B2", "D3", MergeCellOptions{Overlap: MergeCellOverlapError}))
 	assert.NoError(t, f.MergeCell("Sheet1", "B2", "D3"))
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestMergeCellOverlap.xlsx")))
 
@@ -106,6 +107,39 @@ func TestMergeCellOverlap(t *testing.T) {
 	assert.Equal(t, "D3", mc[0].GetEndAxis())
 	assert.Equal(t, "", mc[0].GetCellValue())
 	assert.NoError(t, f.Close())
+
+	// Test merge cells which crossing the existing merged cell
+	f = NewFile()
+	assert.NoError(t, f.MergeCell("Sheet1", "A2", "E2"))
+	assert.Equal(t, ErrRangeOverlap{Ref: "C1:C3", OverlapRef: "A2:E2"}, f.MergeCell("Sheet1", "C1", "C3", MergeCellOptions{Overlap: MergeCellOverlapError}))
+	// Test merge cells with absorbing the intersecting merged cells recursively
+	assert.NoError(t, f.MergeCell("Sheet1", "E3", "F4"))
+	assert.NoError(t, f.MergeCell("Sheet1", "A5", "A6"))
+	assert.NoError(t, f.MergeCell("Sheet1", "C1", "C3", MergeCellOptions{Overlap: MergeCellOverlapAbsorb}))
+	mc, err = f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []MergeCell{{"A5:A6", ""}, {"A1:F4", ""}}, mc)
+	assert.NoError(t, f.MergeCell("Sheet1", "H3", "A1", MergeCellOptions{Overlap: MergeCellOverlapAbsorb}))
+	mc, err = f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []MergeCell{{"A5:A6", ""}, {"A1:H4", ""}}, mc)
+	// Test merge cells with splitting the intersecting merged cells
+	f = NewFile()
+	assert.NoError(t, f.MergeCell("Sheet1", "A1", "E5"))
+	assert.NoError(t, f.MergeCell("Sheet1", "G1", "G2"))
+	assert.NoError(t, f.MergeCell("Sheet1", "B2", "C3", MergeCellOptions{Overlap: MergeCellOverlapSplit}))
+	mc, err = f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []MergeCell{{"G1:G2", ""}, {"A1:E1", ""}, {"A4:E5", ""}, {"A2:A3", ""}, {"D2:E3", ""}, {"B2:C3", ""}}, mc)
+	assert.NoError(t, f.MergeCell("Sheet1", "E1", "G3", MergeCellOptions{Overlap: MergeCellOverlapSplit}))
+	mc, err = f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []MergeCell{{"A4:E5", ""}, {"A2:A3", ""}, {"B2:C3", ""}, {"A1:D1", ""}, {"D2:D3", ""}, {"E1:G3", ""}}, mc)
+	// Test merge cells with invalid existing merged cell reference
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).MergeCells = &xlsxMergeCells{Cells: []*xlsxMergeCell{{Ref: "A:A"}}}
+	assert.NoError(t, f.MergeCell("Sheet1", "A1", "B2"))
 }
 
 func TestGetMergeCells(t *testing.T) {
@@ -209,6 +243,96 @@ func TestUnmergeCell(t *testing.T) {
 	assert.EqualError(t, f.UnmergeCell("Sheet1", "A2", "B3"), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
 }
 
+func TestGetMergeCellAt(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", "merged"))
+	assert.NoError(t, f.MergeCell("Sheet1", "C3", "B2"))
+	// Test get merged cell without merged cells
+	mergeCell, err := f.GetMergeCellAt("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Nil(t, mergeCell)
+	assert.NoError(t, f.MergeCell("Sheet1", "E5", "F6"))
+	for _, cell := range []string{"B2", "C2", "B3", "C3"} {
+		mergeCell, err = f.GetMergeCellAt("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, MergeCell{"B2:C3", "merged"}, mergeCell)
+	}
+	mergeCell, err = f.GetMergeCellAt("Sheet1", "D4")
+	assert.NoError(t, err)
+	assert.Nil(t, mergeCell)
+	// Test get merged cell with invalid cell reference
+	_, err = f.GetMergeCellAt("Sheet1", "A")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	// Test get merged cell on not exists worksheet
+	_, err = f.GetMergeCellAt("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get merged cell with invalid merged cell reference
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).MergeCells = &xlsxMergeCells{Cells: []*xlsxMergeCell{{Ref: "A:A"}}}
+	_, err = f.GetMergeCellAt("Sheet1", "A1")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+}
+
+func TestUnmergeAll(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "text"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "D1", 100))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D4", "D1*2"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", "discarded"))
+	assert.NoError(t, f.MergeCell("Sheet1", "A1", "B2"))
+	assert.NoError(t, f.MergeCell("Sheet1", "D1", "E1"))
+	assert.NoError(t, f.MergeCell("Sheet1", "D4", "D5"))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[3].C[3].setStr("200")
+	// Test unmerge the merged cells with filling values
+	assert.NoError(t, f.UnmergeCell("Sheet1", "B2", "B2", UnmergeCellOptions{FillValue: true}))
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 2)
+	for _, cell := range []string{"A1", "B1", "A2", "B2"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, "text", val)
+	}
+	assert.NoError(t, f.UnmergeAll("Sheet1", UnmergeCellOptions{FillValue: true}))
+	mergeCells, err = f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, mergeCells)
+	for cell, expected := range map[string]string{"D1": "100", "E1": "100", "D4": "200", "D5": "200"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val)
+	}
+	formula, err := f.GetCellFormula("Sheet1", "D5")
+	assert.NoError(t, err)
+	assert.Empty(t, formula)
+	// Test unmerge all merged cells without filling values
+	assert.NoError(t, f.MergeCell("Sheet1", "G1", "H2"))
+	assert.NoError(t, f.UnmergeAll("Sheet1"))
+	val, err := f.GetCellValue("Sheet1", "H2")
+	assert.NoError(t, err)
+	assert.Empty(t, val)
+	// Test unmerge all merged cells without merged cells
+	assert.NoError(t, f.UnmergeAll("Sheet1"))
+	// Test unmerge all merged cells on not exists worksheet
+	assert.EqualError(t, f.UnmergeAll("SheetN"), "sheet SheetN does not exist")
+	// Test unmerge all merged cells with invalid merged cell reference
+	ws.(*xlsxWorksheet).MergeCells = &xlsxMergeCells{Cells: []*xlsxMergeCell{{Ref: "A:A"}}}
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.UnmergeAll("Sheet1"))
+	// Test unmerge all merged cells with filling values and unsupported charset
+	f = NewFile()
+	assert.NoError(t, f.MergeCell("Sheet1", "A1", "A2"))
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).prepareSheetXML(1, 2)
+	ws.(*xlsxWorksheet).SheetData.Row[1].C[0].F = &xlsxF{Content: "B1"}
+	f.CalcChain = nil
+	f.Pkg.Store(defaultXMLPathCalcChain, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.UnmergeAll("Sheet1", UnmergeCellOptions{FillValue: true}), "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestFlatMergedCells(t *testing.T) {
 	ws := &xlsxWorksheet{MergeCells: &xlsxMergeCells{Cells: []*xlsxMergeCell{{Ref: ""}}}}
 	assert.EqualError(t, flatMergedCells(ws, [][]*xlsxMergeCell{}), "cannot convert cell \"\" to coordinates: invalid cell name \"\"")
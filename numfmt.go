@@ -39,7 +39,7 @@ type numberFormat struct {
 	t                                                                        time.Time
 	sectionIdx                                                               int
 	date1904, isNumeric, hours, seconds, useMillisecond, useGannen           bool
-	number                                                                   float64
+	number, fraction                                                         float64
 	ap, localCode, result, value, valueSectionType                           string
 	switchArgument, currencyString                                           string
 	fracHolder, fracPadding, intHolder, intPadding, expBaseLen               int
@@ -57,6 +57,13 @@ const (
 	CultureNameUnknown CultureName = iota
 	CultureNameEnUS
 	CultureNameZhCN
+	CultureNameZhTW
+	CultureNameJaJP
+	CultureNameKoKR
+	CultureNameThTH
+	CultureNameDeDE
+	CultureNameFrFR
+	CultureNameEsES
 )
 
 var (
@@ -4742,21 +4749,27 @@ func (f *File) extractNumFmtDecimal(fmtCode string) int {
 	return -1
 }
 
-// langNumFmtFuncZhCN returns number format code by given date and time pattern
-// for country code zh-cn.
-func (f *File) langNumFmtFuncZhCN(numFmtID int) string {
+// langNumFmtFunc returns number format code by given date and time pattern
+// for the country code, such as zh-cn.
+func (f *File) langNumFmtFunc(lang string, numFmtID int) string {
 	if numFmtID == 30 && f.options.ShortDatePattern != "" {
 		return f.options.ShortDatePattern
 	}
 	if (32 <= numFmtID && numFmtID <= 33) && f.options.LongTimePattern != "" {
 		return f.options.LongTimePattern
 	}
-	return langNumFmt["zh-cn"][numFmtID]
+	return langNumFmt[lang][numFmtID]
 }
 
 // getBuiltInNumFmtCode convert number format index to number format code with
 // specified locale and language.
 func (f *File) getBuiltInNumFmtCode(numFmtID int) (string, bool) {
+	culture, ok := cultures[f.options.CultureInfo]
+	if ok {
+		if fmtCode, ok := culture.numFmt[numFmtID]; ok {
+			return fmtCode, true
+		}
+	}
 	if fmtCode, ok := builtInNumFmt[numFmtID]; ok {
 		return fmtCode, true
 	}
@@ -4764,8 +4777,8 @@ func (f *File) getBuiltInNumFmtCode(numFmtID int) (string, bool) {
 		if f.options.CultureInfo == CultureNameEnUS {
 			return f.langNumFmtFuncEnUS(numFmtID), true
 		}
-		if f.options.CultureInfo == CultureNameZhCN {
-			return f.langNumFmtFuncZhCN(numFmtID), true
+		if ok && culture.lang != "" {
+			return f.langNumFmtFunc(culture.lang, numFmtID), true
 		}
 	}
 	return "", false
@@ -4897,8 +4910,7 @@ func (nf *numberFormat) printNumberLiteral(text string) string {
 			}
 		}
 		if token.TType == nfp.TokenTypeFraction {
-			_, frac = math.Modf(nf.number)
-			frac, useFraction = math.Abs(frac), true
+			frac, useFraction = nf.fraction, true
 		}
 		if useFraction {
 			result += nf.fractionHandler(frac, token)
@@ -5019,22 +5031,19 @@ func (nf *numberFormat) numberHandler() string {
 			return nf.printNumberLiteral(nf.printBigNumber(decimal, fracLen))
 		}
 	}
+	if nf.useScientificNotation {
+		return nf.scientificHandler()
+	}
 	paddingLen := intLen + fracLen
 	if fracLen > 0 {
 		paddingLen++
 	}
 	fmtCode := fmt.Sprintf("%%0%d.%df%s", paddingLen, fracLen, strings.Repeat("%%", nf.percent))
-	if nf.useScientificNotation {
-		if nf.expBaseLen != 2 {
-			return nf.value
-		}
-		fmtCode = fmt.Sprintf("%%.%dE%s", fracLen, strings.Repeat("%%", nf.percent))
-	}
 	if nf.percent > 0 {
 		num *= math.Pow(100, float64(nf.percent))
 	}
 	if nf.useFraction {
-		num = math.Floor(math.Abs(num))
+		return nf.fractionNumberHandler(fmtCode)
 	}
 	if result = fmt.Sprintf(fmtCode, math.Abs(num)); nf.useCommaSep {
 		result = printCommaSep(result)
@@ -5042,6 +5051,130 @@ func (nf *numberFormat) numberHandler() string {
 	return nf.printNumberLiteral(result)
 }
 
+// fractionNumberHandler handling the integer part of the fraction number
+// format expression for positive and negative numeric. The number will be
+// displayed as an improper fraction if there is no integer part placeholder
+// before the fraction, and the integer part will be omitted if it is zero and
+// the placeholder is a digit placeholder (#).
+func (nf *numberFormat) fractionNumberHandler(fmtCode string) string {
+	var (
+		denominator                            nfp.Token
+		useIntPart, useFraction, useZeroHolder bool
+	)
+	for _, token := range nf.section[nf.sectionIdx].Items {
+		if token.TType == nfp.TokenTypeFraction {
+			useFraction = true
+			continue
+		}
+		if useFraction && (token.TType == nfp.TokenTypeDigitalPlaceHolder || token.TType == nfp.TokenTypeDenominator) {
+			denominator = token
+			break
+		}
+		if token.TType == nfp.TokenTypeHashPlaceHolder || token.TType == nfp.TokenTypeZeroPlaceHolder {
+			useIntPart = true
+			useZeroHolder = useZeroHolder || token.TType == nfp.TokenTypeZeroPlaceHolder
+		}
+	}
+	whole, frac := math.Modf(math.Abs(nf.number))
+	if !useIntPart {
+		whole, frac = 0, math.Abs(nf.number)
+	}
+	nf.fraction = frac
+	rat := nf.fractionHandler(frac, denominator)
+	if parts := strings.Split(rat, "/"); useIntPart && len(parts) == 2 && parts[0] == parts[1] {
+		whole, nf.fraction, rat = whole+1, 0, ""
+	}
+	result := fmt.Sprintf(fmtCode, whole)
+	if whole == 0 && useIntPart && !useZeroHolder && strings.TrimSpace(rat) != "" && !strings.HasPrefix(rat, "0/") {
+		result = ""
+	}
+	if nf.useCommaSep {
+		result = printCommaSep(result)
+	}
+	return nf.printNumberLiteral(result)
+}
+
+// scientificHandler handling scientific and engineering notation number
+// format expression for positive and negative numeric. The exponent will be
+// a multiple of the number of the integer part placeholders if there is a
+// digit placeholder (#) in the integer part, such as ##0.0E+0.
+func (nf *numberFormat) scientificHandler() string {
+	var (
+		intDigits, intZeros, fracZeros, fracDigits, expDigits int
+		usePointer, useExp, useHash                           bool
+		expToken                                              string
+	)
+	for _, token := range nf.section[nf.sectionIdx].Items {
+		switch token.TType {
+		case nfp.TokenTypeDecimalPoint:
+			usePointer = true
+		case nfp.TokenTypeExponential:
+			useExp, expToken = true, token.TValue
+		case nfp.TokenTypeHashPlaceHolder, nfp.TokenTypeZeroPlaceHolder:
+			isZero := token.TType == nfp.TokenTypeZeroPlaceHolder
+			switch {
+			case useExp:
+				expDigits += len(token.TValue)
+			case usePointer:
+				if fracDigits += len(token.TValue); isZero {
+					fracZeros = fracDigits
+				}
+			default:
+				if intDigits += len(token.TValue); isZero {
+					intZeros += len(token.TValue)
+				} else {
+					useHash = true
+				}
+			}
+		}
+	}
+	if intDigits == 0 || expDigits == 0 {
+		return nf.value
+	}
+	num, exp, step := math.Abs(nf.number)*math.Pow(100, float64(nf.percent)), 0, 1
+	if useHash {
+		step = intDigits
+	}
+	if num != 0 {
+		exp = int(math.Floor(math.Log10(num)))
+		if useHash {
+			exp = int(math.Floor(float64(exp)/float64(step))) * step
+		} else {
+			exp -= intDigits - 1
+		}
+	}
+	mantissa := strconv.FormatFloat(num/math.Pow10(exp), 'f', fracDigits, 64)
+	if m, _ := strconv.ParseFloat(mantissa, 64); m >= math.Pow10(intDigits) {
+		exp += step
+		mantissa = strconv.FormatFloat(num/math.Pow10(exp), 'f', fracDigits, 64)
+	}
+	if parts := strings.Split(mantissa, "."); len(parts) == 2 && fracDigits > fracZeros {
+		decimal := strings.TrimRight(parts[1], "0")
+		if len(decimal) < fracZeros {
+			decimal += strings.Repeat("0", fracZeros-len(decimal))
+		}
+		mantissa = parts[0] + "." + decimal
+	}
+	if intPart := strings.Split(mantissa, ".")[0]; len(intPart) < intZeros {
+		mantissa = strings.Repeat("0", intZeros-len(intPart)) + mantissa
+	}
+	sign := ""
+	if exp < 0 {
+		sign = "-"
+	} else if strings.Contains(expToken, "+") {
+		sign = "+"
+	}
+	expText := strconv.Itoa(int(math.Abs(float64(exp))))
+	if len(expText) < expDigits {
+		expText = strings.Repeat("0", expDigits-len(expText)) + expText
+	}
+	result := mantissa + expToken[:1] + sign + expText
+	if nf.percent > 0 {
+		result += strings.Repeat("%", nf.percent)
+	}
+	return nf.printNumberLiteral(result)
+}
+
 // dateTimeHandler handling data and time number format expression for a
 // positive numeric.
 func (nf *numberFormat) dateTimeHandler() string {
@@ -7110,7 +7243,7 @@ func (nf *numberFormat) negativeHandler() (result string) {
 
 // zeroHandler will be handling zero selection for a number format expression.
 func (nf *numberFormat) zeroHandler() string {
-	return nf.value
+	return nf.positiveHandler()
 }
 
 // textHandler will be handling text selection for a number format expression.
@@ -7153,5 +7286,10 @@ func (nf *numberFormat) getValueSectionType(value string) (float64, string) {
 		}
 		return number, nfp.TokenSectionNegative
 	}
-	return number, nfp.TokenSectionZero
+	for _, sec := range nf.section {
+		if sec.Type == nfp.TokenSectionZero {
+			return number, nfp.TokenSectionZero
+		}
+	}
+	return number, nfp.TokenSectionPositive
 }
@@ -69,7 +69,7 @@ func TestNumFmt(t *testing.T) {
 		{"0.97952546296296295", "h:m", "23:30"},
 		{"43528", "mmmm", "March"},
 		{"43528", "dddd", "Monday"},
-		{"0", ";;;", "0"},
+		{"0", ";;;", ""},
 		{"43528", "[$-409]MM/DD/YYYY", "03/04/2019"},
 		{"43528", "[$-409]MM/DD/YYYY am/pm", "03/04/2019 AM"},
 		{"43528", "[$-111]MM/DD/YYYY", "43528"},
@@ -3562,7 +3562,21 @@ func TestNumFmt(t *testing.T) {
 		{"123.4567", "#\\ ?/1000", "123 457/1000"},
 		{"1234.5678", "[$$-409]#,##0.00", "$1,234.57"},
 		// Unsupported number format
-		{"37947.7500001", "0.00000000E+000", "37947.7500001"},
+		{"37947.7500001", "0.00000000E+000", "3.79477500E+004"},
+		{"1.5", "# ?/?", "1 1/2"},
+		{"-2.75", "# ?/?", "-2 3/4"},
+		{"3.25", "?/?", "13/4"},
+		{"0.333333", "# ??/??", " 1/3"},
+		{"0.99", "# ?/?", "1    "},
+		{"12345", "##0.0E+0", "12.3E+3"},
+		{"0.00012345", "##0.0E+0", "123.5E-6"},
+		{"1234567", "##0.0E+0", "1.2E+6"},
+		{"999999", "##0.0E+0", "1.0E+6"},
+		{"12345", "00.00E+00", "12.35E+03"},
+		{"0.5", "0.00E+00%", "5.00E+01%"},
+		{"0", "0.00", "0.00"},
+		{"0", "0;-0;\"zero\";@", "zero"},
+		{"0", "0.0;-0.0;0.000", "0.000"},
 		{"123", "[$x.-unknown]#,##0.00", "123"},
 		{"123", "[$x.-unknown]MM/DD/YYYY", "123"},
 		{"123", "[DBNum4][$-804]yyyy\"年\"m\"月\";@", "123"},
@@ -3603,6 +3617,7 @@ func TestNumFmt(t *testing.T) {
 			{"1234.5678", "\"¥\"#,##0.00_);\\(\"¥\"#,##0.00\\)", "1234.5678"},
 			{"1234.5678", "0_);[Red]\\(0\\)", "1234.5678"},
 			{"1234.5678", "\"text\"@", "text1234.5678"},
+			{"text", "0;-0;0;\"T:\"@", "T:text"},
 		} {
 			result := format(item[0], item[1], false, cellType, nil)
 			assert.Equal(t, item[2], result, item)
@@ -3613,3 +3628,32 @@ func TestNumFmt(t *testing.T) {
 	assert.Equal(t, ErrUnsupportedNumberFormat, err)
 	assert.False(t, changeNumFmtCode)
 }
+
+func TestGetBuiltInNumFmtCode(t *testing.T) {
+	for culture, expected := range map[CultureName][2]string{
+		CultureNameUnknown: {"mm-dd-yy", ""},
+		CultureNameDeDE:    {"dd.mm.yyyy", ""},
+		CultureNameFrFR:    {"dd/mm/yyyy", ""},
+		CultureNameJaJP:    {"mm-dd-yy", "yyyy\"年\"m\"月\"d\"日\""},
+		CultureNameZhTW:    {"mm-dd-yy", "yyyy\"年\"m\"月\"d\"日\""},
+	} {
+		f := NewFile(Options{CultureInfo: culture})
+		fmtCode, ok := f.getBuiltInNumFmtCode(14)
+		assert.True(t, ok)
+		assert.Equal(t, expected[0], fmtCode)
+		fmtCode, ok = f.getBuiltInNumFmtCode(31)
+		assert.Equal(t, expected[1] != "", ok)
+		assert.Equal(t, expected[1], fmtCode)
+		assert.NoError(t, f.Close())
+	}
+	// Test format the date with the localized short date pattern
+	f := NewFile(Options{CultureInfo: CultureNameDeDE})
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 45162))
+	style, err := f.NewStyle(&Style{NumFmt: 14})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", style))
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "24.08.2023", val)
+	assert.NoError(t, f.Close())
+}
@@ -0,0 +1,170 @@
+// Copyright 2016 - 2024 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Font style index of the standard fonts in the document.
+const (
+	fontRegular = iota
+	fontBold
+	fontItalic
+	fontBoldItalic
+)
+
+var (
+	// fontNames defined the names of the standard Type 1 fonts used in the
+	// document by font style index.
+	fontNames = []string{"Helvetica", "Helvetica-Bold", "Helvetica-Oblique", "Helvetica-BoldOblique"}
+	// helveticaWidths defined the glyph widths of the Helvetica font for the
+	// printable ASCII characters in 1/1000 text space units.
+	helveticaWidths = []int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	}
+	// helveticaBoldWidths defined the glyph widths of the Helvetica-Bold font
+	// for the printable ASCII characters in 1/1000 text space units.
+	helveticaBoldWidths = []int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	}
+)
+
+// document directly maps the objects of the PDF document, the object number
+// 1 is the catalog, 2 is the page tree, and the fonts are started from 3.
+type document struct {
+	objects [][]byte
+	pages   []int
+}
+
+// newDocument provides a function to create an empty PDF document with the
+// standard fonts.
+func newDocument() *document {
+	doc := &document{objects: make([][]byte, 2)}
+	for _, name := range fontNames {
+		doc.addObject([]byte("<< /Type /Font /Subtype /Type1 /BaseFont /" + name + " /Encoding /WinAnsiEncoding >>"))
+	}
+	return doc
+}
+
+// addObject provides a function to add an object to the document and returns
+// the object number.
+func (doc *document) addObject(obj []byte) int {
+	doc.objects = append(doc.objects, obj)
+	return len(doc.objects)
+}
+
+// addPage provides a function to add a page with the given size in points
+// and the content stream to the document.
+func (doc *document) addPage(width, height float64, content []byte) error {
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	if _, err := zw.Write(content); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	stream := fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n", buf.Len())
+	contentID := doc.addObject(append(append([]byte(stream), buf.Bytes()...), []byte("\nendstream")...))
+	var fonts strings.Builder
+	for i := range fontNames {
+		fmt.Fprintf(&fonts, " /F%d %d 0 R", i+1, i+3)
+	}
+	doc.pages = append(doc.pages, doc.addObject([]byte(fmt.Sprintf(
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font <<%s >> >> /Contents %d 0 R >>",
+		formatNumber(width), formatNumber(height), fonts.String(), contentID))))
+	return nil
+}
+
+// writeTo provides a function to write the document to the given writer.
+func (doc *document) writeTo(w io.Writer) error {
+	kids := make([]string, len(doc.pages))
+	for i, page := range doc.pages {
+		kids[i] = strconv.Itoa(page) + " 0 R"
+	}
+	doc.objects[0] = []byte("<< /Type /Catalog /Pages 2 0 R >>")
+	doc.objects[1] = []byte(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(doc.pages)))
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n%\xE2\xE3\xCF\xD3\n")
+	offsets := make([]int, len(doc.objects))
+	for i, obj := range doc.objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n", i+1)
+		buf.Write(obj)
+		buf.WriteString("\nendobj\n")
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(doc.objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(doc.objects)+1, xref)
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// textWidth provides a function to measure the width of the text in points
+// by given font style index and font size.
+func textWidth(text string, font int, size float64) float64 {
+	widths := helveticaWidths
+	if font == fontBold || font == fontBoldItalic {
+		widths = helveticaBoldWidths
+	}
+	var width int
+	for _, r := range text {
+		if r >= 32 && r < 127 {
+			width += widths[r-32]
+			continue
+		}
+		width += 556
+	}
+	return float64(width) * size / 1000
+}
+
+// encodeText provides a function to encode the text as a PDF literal string
+// in WinAnsiEncoding, the characters which are not supported by the encoding
+// will be replaced by question marks.
+func encodeText(text string) string {
+	var b strings.Builder
+	b.WriteByte('(')
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= 32 && r < 127:
+			b.WriteRune(r)
+		case r >= 160 && r < 256:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	b.WriteByte(')')
+	return b.String()
+}
+
+// formatNumber provides a function to format the number in the content
+// stream with at most 3 decimal places.
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(math.Round(n*1000)/1000, 'f', -1, 64)
+}
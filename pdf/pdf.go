@@ -0,0 +1,795 @@
+// Copyright 2016 - 2024 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+
+// Package pdf providing a function to export the worksheets of the
+// spreadsheet created or opened by excelize to a PDF document. The page
+// setup, print areas, headers and footers, scaling, and a subset of the cell
+// styles including fonts, fills, borders, alignment and merged cells are
+// supported. The text will be rendered by the standard Helvetica fonts, and
+// only the characters in the WinAnsiEncoding are supported.
+package pdf
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+	"github.com/xuri/excelize/v2/internal/layout"
+)
+
+// Options define the options for exporting the worksheets to a PDF document.
+//
+// Sheets specifies the names of the worksheets to be exported in order, all
+// visible worksheets will be exported if it's empty.
+//
+// GridLines specifies if print the gridlines of the cells without fill.
+type Options struct {
+	Sheets    []string
+	GridLines bool
+}
+
+// area directly maps the range of a print area and the size of the columns
+// and rows in points.
+type area struct {
+	coordinates [4]int
+	colWidths   []float64
+	rowHeights  []float64
+}
+
+// page directly maps the columns and rows of the print area in a page, the
+// columns and rows are the index of the area with inclusive start and
+// exclusive end.
+type page struct {
+	area       *area
+	cols, rows [2]int
+}
+
+// exporter directly maps the settings for exporting a worksheet.
+type exporter struct {
+	f                       *excelize.File
+	opts                    *Options
+	doc                     *document
+	sheet                   string
+	rtl                     bool
+	styles                  map[int]*excelize.Style
+	pageWidth, pageHeight   float64
+	margins                 excelize.PageLayoutMarginsOptions
+	scale, offsetX, offsetY float64
+}
+
+var (
+	// paperSizes defined the width and height in points of the supported
+	// paper sizes by paper size index.
+	paperSizes = map[int][2]float64{
+		1:  {612, 792},
+		2:  {612, 792},
+		3:  {792, 1224},
+		4:  {1224, 792},
+		5:  {612, 1008},
+		6:  {396, 612},
+		7:  {522, 756},
+		8:  {mm(297), mm(420)},
+		9:  {mm(210), mm(297)},
+		10: {mm(210), mm(297)},
+		11: {mm(148), mm(210)},
+		12: {mm(257), mm(364)},
+		13: {mm(182), mm(257)},
+		14: {612, 936},
+		66: {mm(420), mm(594)},
+		70: {mm(105), mm(148)},
+	}
+	// paperUnits defined the points per unit of the custom paper size.
+	paperUnits = map[string]float64{"mm": 72 / 25.4, "cm": 72 / 2.54, "in": 72, "pt": 1, "pc": 12, "pi": 12}
+)
+
+// SaveAsPDF provides a function to export the worksheets of the spreadsheet
+// to a PDF document, and write the document to the given writer. Each
+// worksheet will be paginated by the page layout, page margins and print areas
+// of the worksheet, and the headers and footers will be printed on each page.
+// For example, export all visible worksheets of the spreadsheet to a PDF
+// file:
+//
+//	out, err := os.Create("Book1.pdf")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	defer out.Close()
+//	if err := pdf.SaveAsPDF(f, out, &pdf.Options{GridLines: true}); err != nil {
+//	    fmt.Println(err)
+//	}
+func SaveAsPDF(f *excelize.File, w io.Writer, opts *Options) error {
+	if opts == nil {
+		opts = &Options{}
+	}
+	sheets := opts.Sheets
+	if len(sheets) == 0 {
+		for _, sheet := range f.GetSheetList() {
+			if _, err := f.GetSheetDimension(sheet); errors.As(err, &excelize.ErrNotWorksheet{}) {
+				continue
+			}
+			if visible, err := f.GetSheetVisible(sheet); err != nil || !visible {
+				continue
+			}
+			sheets = append(sheets, sheet)
+		}
+	}
+	doc := newDocument()
+	for _, sheet := range sheets {
+		e := &exporter{f: f, opts: opts, doc: doc, sheet: sheet, styles: make(map[int]*excelize.Style)}
+		if err := e.export(); err != nil {
+			return err
+		}
+	}
+	return doc.writeTo(w)
+}
+
+// mm provides a function to convert millimeters to points.
+func mm(n float64) float64 {
+	return n * 72 / 25.4
+}
+
+// parsePaperLength provides a function to parse the length of the custom
+// paper size with a unit of measurement to points.
+func parsePaperLength(length string) (float64, bool) {
+	if len(length) < 3 {
+		return 0, false
+	}
+	unit, ok := paperUnits[length[len(length)-2:]]
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.ParseFloat(length[:len(length)-2], 64)
+	return n * unit, err == nil && n > 0
+}
+
+// export provides a function to export the worksheet to the pages of the
+// document.
+func (e *exporter) export() error {
+	layout, err := e.f.GetPageLayout(e.sheet)
+	if err != nil {
+		return err
+	}
+	if e.margins, err = e.f.GetPageMargins(e.sheet); err != nil {
+		return err
+	}
+	headerFooter, err := e.f.GetHeaderFooter(e.sheet)
+	if err != nil {
+		return err
+	}
+	props, err := e.f.GetSheetProps(e.sheet)
+	if err != nil {
+		return err
+	}
+	view, err := e.f.GetSheetView(e.sheet, 0)
+	if err != nil {
+		return err
+	}
+	e.rtl = *view.RightToLeft
+	e.setPageSize(layout)
+	areas, err := e.areas()
+	if err != nil {
+		return err
+	}
+	e.scale = float64(*layout.AdjustTo) / 100
+	if props.FitToPage != nil && *props.FitToPage {
+		e.scale = e.fitToPage(areas, layout)
+	}
+	var pages []page
+	for _, a := range areas {
+		colGroups := paginate(a.colWidths, e.contentWidth()/e.scale)
+		rowGroups := paginate(a.rowHeights, e.contentHeight()/e.scale)
+		for _, cols := range colGroups {
+			for _, rows := range rowGroups {
+				pages = append(pages, page{area: a, cols: cols, rows: rows})
+			}
+		}
+	}
+	for i, p := range pages {
+		var content strings.Builder
+		if err = e.drawPage(&content, p); err != nil {
+			return err
+		}
+		e.drawHeaderFooter(&content, headerFooter, i, len(pages), int(*layout.FirstPageNumber))
+		if err = e.doc.addPage(e.pageWidth, e.pageHeight, []byte(content.String())); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// setPageSize provides a function to set the page size in points by given
+// page layout settings.
+func (e *exporter) setPageSize(layout excelize.PageLayoutOptions) {
+	size, ok := paperSizes[*layout.Size]
+	if !ok {
+		size = paperSizes[1]
+	}
+	if layout.PaperWidth != nil && layout.PaperHeight != nil {
+		width, okWidth := parsePaperLength(*layout.PaperWidth)
+		height, okHeight := parsePaperLength(*layout.PaperHeight)
+		if okWidth && okHeight {
+			size = [2]float64{width, height}
+		}
+	}
+	e.pageWidth, e.pageHeight = size[0], size[1]
+	if *layout.Orientation == "landscape" {
+		e.pageWidth, e.pageHeight = size[1], size[0]
+	}
+}
+
+// contentWidth provides a function to get the width of the printable area of
+// the page in points.
+func (e *exporter) contentWidth() float64 {
+	return e.pageWidth - (*e.margins.Left+*e.margins.Right)*72
+}
+
+// contentHeight provides a function to get the height of the printable area
+// of the page in points.
+func (e *exporter) contentHeight() float64 {
+	return e.pageHeight - (*e.margins.Top+*e.margins.Bottom)*72
+}
+
+// fitToPage provides a function to calculate the scale for fitting the print
+// areas into the number of pages wide and tall specified by the page layout.
+func (e *exporter) fitToPage(areas []*area, layout excelize.PageLayoutOptions) float64 {
+	scale, fitWidth, fitHeight := 1.0, 1, 1
+	if layout.FitToWidth != nil {
+		fitWidth = *layout.FitToWidth
+	}
+	if layout.FitToHeight != nil {
+		fitHeight = *layout.FitToHeight
+	}
+	for _, a := range areas {
+		var width, height float64
+		for _, w := range a.colWidths {
+			width += w
+		}
+		for _, h := range a.rowHeights {
+			height += h
+		}
+		if s := float64(fitWidth) * e.contentWidth() / width; fitWidth > 0 && width > 0 && s < scale {
+			scale = s
+		}
+		if s := float64(fitHeight) * e.contentHeight() / height; fitHeight > 0 && height > 0 && s < scale {
+			scale = s
+		}
+	}
+	if scale < 0.1 {
+		scale = 0.1
+	}
+	return scale
+}
+
+// areas provides a function to get the print areas of the worksheet, the used
+// range of the worksheet will be printed if the print area is not specified.
+func (e *exporter) areas() ([]*area, error) {
+	var refs []string
+	for _, dn := range e.f.GetDefinedName() {
+		if dn.Name == "_xlnm.Print_Area" && dn.Scope == e.sheet {
+			for _, ref := range strings.Split(dn.RefersTo, ",") {
+				refs = append(refs, ref[strings.LastIndex(ref, "!")+1:])
+			}
+		}
+	}
+	if len(refs) == 0 {
+		rows, err := e.f.GetRows(e.sheet)
+		if err != nil {
+			return nil, err
+		}
+		maxCol := 1
+		for _, row := range rows {
+			if len(row) > maxCol {
+				maxCol = len(row)
+			}
+		}
+		cell, _ := excelize.CoordinatesToCellName(maxCol, len(rows)+1)
+		if len(rows) > 0 {
+			cell, _ = excelize.CoordinatesToCellName(maxCol, len(rows))
+		}
+		refs = append(refs, "A1:"+cell)
+	}
+	var areas []*area
+	for _, ref := range refs {
+		a, err := e.newArea(strings.ReplaceAll(ref, "$", ""))
+		if err != nil {
+			return areas, err
+		}
+		areas = append(areas, a)
+	}
+	return areas, nil
+}
+
+// newArea provides a function to create the print area by given range
+// reference, the size of the hidden columns and rows will be zero.
+func (e *exporter) newArea(ref string) (*area, error) {
+	a := &area{}
+	cells := strings.Split(ref, ":")
+	if len(cells) == 1 {
+		cells = append(cells, cells[0])
+	}
+	for i, cell := range cells[:2] {
+		col, row, err := excelize.CellNameToCoordinates(cell)
+		if err != nil {
+			return a, err
+		}
+		a.coordinates[i*2], a.coordinates[i*2+1] = col, row
+	}
+	if a.coordinates[0] > a.coordinates[2] {
+		a.coordinates[0], a.coordinates[2] = a.coordinates[2], a.coordinates[0]
+	}
+	if a.coordinates[1] > a.coordinates[3] {
+		a.coordinates[1], a.coordinates[3] = a.coordinates[3], a.coordinates[1]
+	}
+	hiddenCols, err := e.f.GetHiddenCols(e.sheet)
+	if err != nil {
+		return a, err
+	}
+	hiddenRows, err := e.f.GetHiddenRows(e.sheet)
+	if err != nil {
+		return a, err
+	}
+	hidden := make(map[string]bool)
+	for _, col := range hiddenCols {
+		hidden[col] = true
+	}
+	for _, row := range hiddenRows {
+		hidden[strconv.Itoa(row)] = true
+	}
+	for col := a.coordinates[0]; col <= a.coordinates[2]; col++ {
+		name, _ := excelize.ColumnNumberToName(col)
+		width, err := e.f.GetColWidth(e.sheet, name)
+		if err != nil {
+			return a, err
+		}
+		if hidden[name] {
+			width = 0
+		}
+		a.colWidths = append(a.colWidths, width*7*0.75)
+	}
+	for row := a.coordinates[1]; row <= a.coordinates[3]; row++ {
+		height, err := e.f.GetRowHeight(e.sheet, row)
+		if err != nil {
+			return a, err
+		}
+		if hidden[strconv.Itoa(row)] {
+			height = 0
+		}
+		a.rowHeights = append(a.rowHeights, height)
+	}
+	return a, err
+}
+
+// paginate provides a function to split the columns or rows into pages by
+// given sizes and the available size of a page, each page contains one
+// column or row at least.
+func paginate(sizes []float64, available float64) [][2]int {
+	var (
+		groups [][2]int
+		start  int
+		used   float64
+	)
+	for i, size := range sizes {
+		if i > start && used+size > available {
+			groups = append(groups, [2]int{start, i})
+			start, used = i, 0
+		}
+		used += size
+	}
+	return append(groups, [2]int{start, len(sizes)})
+}
+
+// drawPage provides a function to draw the cells in the page to the content
+// stream.
+func (e *exporter) drawPage(content *strings.Builder, p page) error {
+	colX := []float64{0}
+	for _, w := range p.area.colWidths[p.cols[0]:p.cols[1]] {
+		colX = append(colX, colX[len(colX)-1]+w)
+	}
+	rowY := []float64{0}
+	for _, h := range p.area.rowHeights[p.rows[0]:p.rows[1]] {
+		rowY = append(rowY, rowY[len(rowY)-1]+h)
+	}
+	e.offsetX, e.offsetY = 0, 0
+	if e.margins.Horizontally != nil && *e.margins.Horizontally {
+		e.offsetX = (e.contentWidth() - colX[len(colX)-1]*e.scale) / 2
+	} else if e.rtl {
+		e.offsetX = e.contentWidth() - colX[len(colX)-1]*e.scale
+	}
+	if e.margins.Vertically != nil && *e.margins.Vertically {
+		e.offsetY = (e.contentHeight() - rowY[len(rowY)-1]*e.scale) / 2
+	}
+	cells, err := e.cells(p, colX, rowY)
+	if err != nil {
+		return err
+	}
+	if e.rtl {
+		for i, c := range cells {
+			cells[i].x = colX[len(colX)-1] - c.x - c.width
+		}
+	}
+	for _, layer := range []func(*strings.Builder, cell, *excelize.Style) error{
+		e.drawFill, e.drawGridlines, e.drawBorders, e.drawText,
+	} {
+		for _, c := range cells {
+			style, err := e.cellStyle(c.cell)
+			if err != nil {
+				return err
+			}
+			if err = layer(content, c, style); err != nil {
+				return err
+			}
+		}
+	}
+	return err
+}
+
+// cell directly maps the rectangle in points of a cell or merged cells in the
+// page, and the cell reference of the value.
+type cell struct {
+	x, y, width, height float64
+	cell                string
+}
+
+// cells provides a function to get the cells to be drawn in the page, the
+// merged cells in the page will be drawn as a single cell.
+func (e *exporter) cells(p page, colX, rowY []float64) ([]cell, error) {
+	var cells []cell
+	col0, row0 := p.area.coordinates[0]+p.cols[0], p.area.coordinates[1]+p.rows[0]
+	col1, row1 := col0+p.cols[1]-p.cols[0]-1, row0+p.rows[1]-p.rows[0]-1
+	mergeCells, err := e.f.GetMergeCells(e.sheet)
+	if err != nil {
+		return cells, err
+	}
+	var merged []layout.Span
+	for _, mc := range mergeCells {
+		x1, y1, err := excelize.CellNameToCoordinates(mc.GetStartAxis())
+		if err != nil {
+			return cells, err
+		}
+		x2, y2, err := excelize.CellNameToCoordinates(mc.GetEndAxis())
+		if err != nil {
+			return cells, err
+		}
+		merged = append(merged, layout.Span{Col1: x1, Row1: y1, Col2: x2, Row2: y2})
+	}
+	for _, span := range layout.Spans(layout.Span{Col1: col0, Row1: row0, Col2: col1, Row2: row1}, merged) {
+		ref, _ := excelize.CoordinatesToCellName(span.Col, span.Row)
+		cells = append(cells, cell{
+			x: colX[span.Col1-col0], y: rowY[span.Row1-row0],
+			width:  colX[span.Col2-col0+1] - colX[span.Col1-col0],
+			height: rowY[span.Row2-row0+1] - rowY[span.Row1-row0],
+			cell:   ref,
+		})
+	}
+	return cells, err
+}
+
+// cellStyle provides a function to get the style definition of the cell
+// without changing the worksheet, the style definitions will be cached by the
+// style index.
+func (e *exporter) cellStyle(cell string) (*excelize.Style, error) {
+	details, err := e.f.GetCellStyleDetails(e.sheet, cell)
+	if err != nil {
+		return nil, err
+	}
+	styleID := details.StyleID
+	if style, ok := e.styles[styleID]; ok {
+		return style, err
+	}
+	style, err := e.f.GetStyle(styleID)
+	if err != nil {
+		return nil, err
+	}
+	e.styles[styleID] = style
+	return style, err
+}
+
+// x provides a function to convert the horizontal position in the page to
+// the PDF user space.
+func (e *exporter) x(x float64) string {
+	return formatNumber(*e.margins.Left*72 + e.offsetX + x*e.scale)
+}
+
+// y provides a function to convert the vertical position in the page from
+// top to the PDF user space from bottom.
+func (e *exporter) y(y float64) string {
+	return formatNumber(e.pageHeight - *e.margins.Top*72 - e.offsetY - y*e.scale)
+}
+
+// length provides a function to convert the length in the page to the PDF
+// user space.
+func (e *exporter) length(n float64) string {
+	return formatNumber(n * e.scale)
+}
+
+// rect provides a function to get the rectangle operands of the cell.
+func (e *exporter) rect(c cell) string {
+	return fmt.Sprintf("%s %s %s %s re", e.x(c.x), e.y(c.y+c.height), e.length(c.width), e.length(c.height))
+}
+
+// drawFill provides a function to draw the fill of the cell.
+func (e *exporter) drawFill(content *strings.Builder, c cell, style *excelize.Style) error {
+	if clr, ok := fillColor(style); ok && c.width > 0 && c.height > 0 {
+		fmt.Fprintf(content, "%s rg %s f\n", clr, e.rect(c))
+	}
+	return nil
+}
+
+// drawGridlines provides a function to draw the gridlines of the cell without
+// fill.
+func (e *exporter) drawGridlines(content *strings.Builder, c cell, style *excelize.Style) error {
+	if _, ok := fillColor(style); ok || !e.opts.GridLines || c.width == 0 || c.height == 0 {
+		return nil
+	}
+	fmt.Fprintf(content, "0.83 G 0.25 w [] 0 d %s S\n", e.rect(c))
+	return nil
+}
+
+// drawBorders provides a function to draw the borders of the cell.
+func (e *exporter) drawBorders(content *strings.Builder, c cell, style *excelize.Style) error {
+	if c.width == 0 || c.height == 0 {
+		return nil
+	}
+	for _, border := range style.Border {
+		bs, ok := layout.BorderStyles[border.Style]
+		if !ok {
+			continue
+		}
+		clr := parseColor(border.Color, "0 0 0")
+		offsets := []float64{0}
+		if bs.Double {
+			offsets = []float64{-0.75, 0.75}
+		}
+		width, dash := float64(bs.Weight)*0.5, formatDash(bs.Dash)
+		if bs.Weight == 0 {
+			width = 0.25
+		}
+		for _, o := range offsets {
+			var x1, y1, x2, y2 float64
+			switch layout.BorderType(border.Type, e.rtl) {
+			case "left":
+				x1, y1, x2, y2 = c.x+o, c.y, c.x+o, c.y+c.height
+			case "right":
+				x1, y1, x2, y2 = c.x+c.width+o, c.y, c.x+c.width+o, c.y+c.height
+			case "top":
+				x1, y1, x2, y2 = c.x, c.y+o, c.x+c.width, c.y+o
+			case "bottom":
+				x1, y1, x2, y2 = c.x, c.y+c.height+o, c.x+c.width, c.y+c.height+o
+			case "diagonalDown":
+				x1, y1, x2, y2 = c.x, c.y, c.x+c.width, c.y+c.height
+			case "diagonalUp":
+				x1, y1, x2, y2 = c.x, c.y+c.height, c.x+c.width, c.y
+			default:
+				continue
+			}
+			fmt.Fprintf(content, "%s RG %s w %s 0 d %s %s m %s %s l S\n",
+				clr, e.length(width), dash, e.x(x1), e.y(y1), e.x(x2), e.y(y2))
+		}
+	}
+	return nil
+}
+
+// drawText provides a function to draw the formatted value of the cell with
+// the font and alignment of the cell style.
+func (e *exporter) drawText(content *strings.Builder, c cell, style *excelize.Style) error {
+	if c.width == 0 || c.height == 0 {
+		return nil
+	}
+	val, err := e.f.GetCellValue(e.sheet, c.cell)
+	if err != nil || val == "" {
+		return err
+	}
+	kind, err := e.valueKind(c.cell)
+	if err != nil {
+		return err
+	}
+	var (
+		horizontal, vertical string
+		indent               float64
+		wrapText             bool
+		font                 = fontRegular
+		size                 = 11.0
+		clr                  = "0 0 0"
+	)
+	if style.Alignment != nil {
+		horizontal, vertical = style.Alignment.Horizontal, style.Alignment.Vertical
+		indent, wrapText = float64(style.Alignment.Indent)*6.75, style.Alignment.WrapText
+	}
+	horizontal = layout.HorizontalAlignment(horizontal, kind, e.rtl)
+	if style.Font != nil {
+		if style.Font.Size > 0 {
+			size = style.Font.Size
+		}
+		if style.Font.Bold {
+			font |= fontBold
+		}
+		if style.Font.Italic {
+			font |= fontItalic
+		}
+		clr = parseColor(e.f.GetBaseColor(style.Font.Color, style.Font.ColorIndexed, style.Font.ColorTheme), clr)
+	}
+	lines := []string{strings.ReplaceAll(val, "\n", " ")}
+	if wrapText {
+		lines = layout.WrapText(val, c.width-3-indent, func(text string) float64 {
+			return textWidth(text, font, size)
+		})
+	}
+	lineHeight := size * 1.2
+	baseline := layout.AlignY(vertical, c.y, c.height, lineHeight*float64(len(lines))) + size
+	fmt.Fprintf(content, "q %s W n %s rg %s RG\n", e.rect(c), clr, clr)
+	for _, line := range lines {
+		width := textWidth(line, font, size)
+		x := layout.AlignX(horizontal, c.x, c.width, width, indent, 2)
+		fmt.Fprintf(content, "BT /F%d %s Tf %s %s Td %s Tj ET\n", font+1, e.length(size), e.x(x), e.y(baseline), encodeText(line))
+		if style.Font != nil && style.Font.Underline != "" {
+			fmt.Fprintf(content, "%s w [] 0 d %s %s m %s %s l S\n", e.length(size*0.05),
+				e.x(x), e.y(baseline+size*0.1), e.x(x+width), e.y(baseline+size*0.1))
+		}
+		if style.Font != nil && style.Font.Strike {
+			fmt.Fprintf(content, "%s w [] 0 d %s %s m %s %s l S\n", e.length(size*0.05),
+				e.x(x), e.y(baseline-size*0.3), e.x(x+width), e.y(baseline-size*0.3))
+		}
+		baseline += lineHeight
+	}
+	content.WriteString("Q\n")
+	return err
+}
+
+// valueKind provides a function to get the kind of the cell value for the
+// general horizontal alignment by given cell reference.
+func (e *exporter) valueKind(cell string) (layout.ValueKind, error) {
+	cellType, err := e.f.GetCellType(e.sheet, cell)
+	if err != nil {
+		return layout.ValueText, err
+	}
+	switch cellType {
+	case excelize.CellTypeBool, excelize.CellTypeError:
+		return layout.ValueLogical, err
+	case excelize.CellTypeSharedString, excelize.CellTypeInlineString:
+		return layout.ValueText, err
+	}
+	raw, err := e.f.GetCellValue(e.sheet, cell, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return layout.ValueText, err
+	}
+	if _, err := strconv.ParseFloat(raw, 64); err == nil {
+		return layout.ValueNumber, nil
+	}
+	return layout.ValueText, err
+}
+
+// drawHeaderFooter provides a function to draw the header and footer of the
+// page by given page index, total pages and the first page number.
+func (e *exporter) drawHeaderFooter(content *strings.Builder, opts *excelize.HeaderFooterOptions, idx, total, first int) {
+	if opts == nil {
+		return
+	}
+	header, footer := opts.OddHeader, opts.OddFooter
+	if opts.DifferentOddEven && (first+idx)%2 == 0 {
+		header, footer = opts.EvenHeader, opts.EvenFooter
+	}
+	if opts.DifferentFirst && idx == 0 {
+		header, footer = opts.FirstHeader, opts.FirstFooter
+	}
+	for i, text := range []string{header, footer} {
+		y := e.pageHeight - *e.margins.Header*72 - 11
+		if i == 1 {
+			y = *e.margins.Footer*72 + 2
+		}
+		for section, text := range e.parseHeaderFooter(text, first+idx, total) {
+			if text == "" {
+				continue
+			}
+			width := textWidth(text, fontRegular, 11)
+			x := *e.margins.Left * 72
+			switch section {
+			case 1:
+				x = (e.pageWidth - width) / 2
+			case 2:
+				x = e.pageWidth - *e.margins.Right*72 - width
+			}
+			fmt.Fprintf(content, "0 0 0 rg BT /F1 11 Tf %s %s Td %s Tj ET\n", formatNumber(x), formatNumber(y), encodeText(text))
+		}
+	}
+}
+
+// parseHeaderFooter provides a function to parse the header or footer format
+// codes into the left, center and right sections of text by given page number
+// and number of pages. The font formatting codes will be ignored.
+func (e *exporter) parseHeaderFooter(text string, pageNum, pages int) [3]string {
+	var (
+		sections [3]strings.Builder
+		section  = 1
+		runes    = []rune(text)
+		now      = time.Now()
+	)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '&' || i == len(runes)-1 {
+			sections[section].WriteRune(runes[i])
+			continue
+		}
+		i++
+		switch runes[i] {
+		case 'L':
+			section = 0
+		case 'C':
+			section = 1
+		case 'R':
+			section = 2
+		case 'P':
+			sections[section].WriteString(strconv.Itoa(pageNum))
+		case 'N':
+			sections[section].WriteString(strconv.Itoa(pages))
+		case 'D':
+			sections[section].WriteString(now.Format("1/2/2006"))
+		case 'T':
+			sections[section].WriteString(now.Format("3:04 PM"))
+		case 'A':
+			sections[section].WriteString(e.sheet)
+		case 'F':
+			sections[section].WriteString(filepath.Base(e.f.Path))
+		case 'Z':
+			sections[section].WriteString(filepath.Dir(e.f.Path) + string(filepath.Separator))
+		case '&':
+			sections[section].WriteRune('&')
+		case '"':
+			for i+1 < len(runes) && runes[i+1] != '"' {
+				i++
+			}
+			i++
+		case 'K':
+			i += 6
+		default:
+			for i+1 < len(runes) && runes[i] >= '0' && runes[i] <= '9' && runes[i+1] >= '0' && runes[i+1] <= '9' {
+				i++
+			}
+		}
+	}
+	return [3]string{sections[0].String(), sections[1].String(), sections[2].String()}
+}
+
+// fillColor provides a function to get the fill color operands of the cell
+// by given style definition.
+func fillColor(style *excelize.Style) (string, bool) {
+	clr, ok := layout.FillColor(style.Fill.Type, style.Fill.Pattern, style.Fill.Color)
+	if !ok {
+		return "", false
+	}
+	return colorOperands(clr), true
+}
+
+// parseColor provides a function to parse the hex color code in 'RRGGBB' or
+// 'AARRGGBB' notation to the color operands, the default color operands will
+// be returned if the given color code is invalid.
+func parseColor(hexColor, def string) string {
+	clr, ok := layout.ParseColor(hexColor)
+	if !ok {
+		return def
+	}
+	return colorOperands(clr)
+}
+
+// colorOperands provides a function to get the color operands by given
+// color.
+func colorOperands(clr color.RGBA) string {
+	return fmt.Sprintf("%s %s %s", formatNumber(float64(clr.R)/255),
+		formatNumber(float64(clr.G)/255), formatNumber(float64(clr.B)/255))
+}
+
+// formatDash provides a function to format the dash pattern operand by given
+// dash pattern.
+func formatDash(dash []int) string {
+	parts := make([]string, len(dash))
+	for i, n := range dash {
+		parts[i] = strconv.Itoa(n)
+	}
+	return "[" + strings.Join(parts, " ") + "]"
+}
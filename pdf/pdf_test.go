@@ -0,0 +1,213 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
+)
+
+// pageContents provides a function to extract the decompressed content
+// streams of the pages in the PDF document.
+func pageContents(t *testing.T, doc []byte) []string {
+	var contents []string
+	for _, match := range regexp.MustCompile(`(?s)/FlateDecode >>\nstream\n(.*?)\nendstream`).FindAllSubmatch(doc, -1) {
+		zr, err := zlib.NewReader(bytes.NewReader(match[1]))
+		assert.NoError(t, err)
+		content, err := io.ReadAll(zr)
+		assert.NoError(t, err)
+		contents = append(contents, string(content))
+	}
+	return contents
+}
+
+func prepareTestBook(t *testing.T) *excelize.File {
+	f := excelize.NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Name", "Amount", "Paid", "Note (a\\b)", "Hidden"}))
+	for row := 2; row <= 100; row++ {
+		cell, err := excelize.CoordinatesToCellName(1, row)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &[]interface{}{"Item", row * 10, row%2 == 0, "Café"}))
+	}
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", 1234.5))
+	header, err := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Bold: true, Italic: true, Size: 12, Color: "FFFFFF", Underline: "single", Strike: true},
+		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"4472C4"}},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center"},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "D1", header))
+	var borders []excelize.Border
+	for i, typ := range []string{"left", "right", "top", "bottom", "diagonalUp", "diagonalDown"} {
+		borders = append(borders, excelize.Border{Type: typ, Color: "FF0000", Style: i*2 + 1})
+	}
+	bordered, err := f.NewStyle(&excelize.Style{Border: borders, NumFmt: 4})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B2", "B3", bordered))
+	wrapped, err := f.NewStyle(&excelize.Style{Alignment: &excelize.Alignment{WrapText: true, Vertical: "top", Indent: 1}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A3", "A long description\nwith line break"))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A3", "A3", wrapped))
+	assert.NoError(t, f.MergeCell("Sheet1", "C2", "D3"))
+	assert.NoError(t, f.SetColVisible("Sheet1", "E", false))
+	assert.NoError(t, f.SetRowVisible("Sheet1", 4, false))
+	assert.NoError(t, f.SetHeaderFooter("Sheet1", &excelize.HeaderFooterOptions{
+		OddHeader: `&L&"Arial,Bold"&12&KFF0000&A&CReport &&&RPage &P of &N`,
+		OddFooter: "&L&F&C&D &T&R&Z",
+	}))
+	return f
+}
+
+func TestSaveAsPDF(t *testing.T) {
+	f := prepareTestBook(t)
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetVisible("Sheet2", false))
+	assert.NoError(t, f.AddChartSheet("Chart1", &excelize.Chart{
+		Type:   excelize.Col,
+		Series: []excelize.ChartSeries{{Name: "Sheet1!$A$1", Categories: "Sheet1!$A$2:$A$5", Values: "Sheet1!$B$2:$B$5"}},
+	}))
+	var buf bytes.Buffer
+	assert.NoError(t, SaveAsPDF(f, &buf, nil))
+	assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF-1.4")))
+	assert.True(t, bytes.HasSuffix(buf.Bytes(), []byte("%%EOF\n")))
+	assert.Contains(t, buf.String(), "/MediaBox [0 0 612 792]")
+	contents := pageContents(t, buf.Bytes())
+	assert.Len(t, contents, 3)
+	assert.Contains(t, contents[0], "(Name) Tj")
+	assert.Contains(t, contents[0], "(Note \\(a\\\\b\\)) Tj")
+	assert.Contains(t, contents[0], "(Caf\\351) Tj")
+	assert.Contains(t, contents[0], "(1,234.50) Tj")
+	assert.Contains(t, contents[0], "(description) Tj")
+	assert.NotContains(t, contents[0], "(Hidden) Tj")
+	assert.NotContains(t, contents[0], "(40) Tj")
+	assert.Contains(t, contents[0], "(TRUE) Tj")
+	assert.Contains(t, contents[0], "[3 1] 0 d")
+	assert.Contains(t, contents[0], "(Sheet1) Tj")
+	assert.Contains(t, contents[0], "(Report &) Tj")
+	assert.Contains(t, contents[0], "(Page 1 of 3) Tj")
+	assert.Contains(t, contents[2], "(Page 3 of 3) Tj")
+	assert.NoError(t, f.Close())
+}
+
+func TestSaveAsPDFReadOnly(t *testing.T) {
+	f := prepareTestBook(t)
+	assert.NoError(t, f.SetCellValue("Sheet1", "H120", "Excelize"))
+	before, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, SaveAsPDF(f, io.Discard, nil))
+	after, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.Equal(t, before.Bytes(), after.Bytes())
+	assert.NoError(t, f.Close())
+}
+
+func TestSaveAsPDFPageSetup(t *testing.T) {
+	f := prepareTestBook(t)
+	// Test export with fit to page and custom paper size in landscape
+	assert.NoError(t, f.SetSheetProps("Sheet1", &excelize.SheetPropsOptions{FitToPage: boolPtr(true)}))
+	assert.NoError(t, f.SetPageLayout("Sheet1", &excelize.PageLayoutOptions{
+		Orientation: stringPtr("landscape"), PaperWidth: stringPtr("8.5in"), PaperHeight: stringPtr("110mm"),
+		FitToHeight: intPtr(1), FitToWidth: intPtr(1), FirstPageNumber: uintPtr(5),
+	}))
+	assert.NoError(t, f.SetPageMargins("Sheet1", &excelize.PageLayoutMarginsOptions{
+		Horizontally: boolPtr(true), Vertically: boolPtr(true),
+	}))
+	assert.NoError(t, f.SetHeaderFooter("Sheet1", &excelize.HeaderFooterOptions{
+		DifferentFirst: true, DifferentOddEven: true,
+		OddHeader: "odd &P", EvenHeader: "even &P", FirstHeader: "first &P",
+	}))
+	var buf bytes.Buffer
+	assert.NoError(t, SaveAsPDF(f, &buf, &Options{Sheets: []string{"Sheet1"}, GridLines: true}))
+	assert.Contains(t, buf.String(), "/MediaBox [0 0 311.811 612]")
+	contents := pageContents(t, buf.Bytes())
+	assert.Len(t, contents, 1)
+	assert.Contains(t, contents[0], "(first 5) Tj")
+	assert.Contains(t, contents[0], "0.83 G")
+
+	assert.NoError(t, f.Close())
+
+	// Test export with print areas, scaling and paper size
+	f = prepareTestBook(t)
+	assert.NoError(t, f.SetPageLayout("Sheet1", &excelize.PageLayoutOptions{Size: intPtr(9), AdjustTo: uintPtr(50), FirstPageNumber: uintPtr(5)}))
+	assert.NoError(t, f.SetHeaderFooter("Sheet1", &excelize.HeaderFooterOptions{
+		DifferentFirst: true, DifferentOddEven: true,
+		OddHeader: "odd &P", EvenHeader: "even &P", FirstHeader: "first &P",
+	}))
+	assert.NoError(t, f.SetDefinedName(&excelize.DefinedName{
+		Name: "_xlnm.Print_Area", RefersTo: "Sheet1!$A$1:$B$2,Sheet1!$C$3:$C$2,Sheet1!$E$5", Scope: "Sheet1",
+	}))
+	buf.Reset()
+	assert.NoError(t, SaveAsPDF(f, &buf, &Options{Sheets: []string{"Sheet1"}}))
+	assert.Contains(t, buf.String(), "/MediaBox [0 0 595.276 841.89]")
+	contents = pageContents(t, buf.Bytes())
+	assert.Len(t, contents, 3)
+	assert.Contains(t, contents[0], "(first 5) Tj")
+	assert.Contains(t, contents[1], "(even 6) Tj")
+	assert.Contains(t, contents[2], "(odd 7) Tj")
+	assert.Contains(t, contents[0], "/F4 6 Tf")
+	assert.NoError(t, f.Close())
+
+	// Test parse custom paper size with invalid unit
+	_, ok := parsePaperLength("1x")
+	assert.False(t, ok)
+	_, ok = parsePaperLength("1")
+	assert.False(t, ok)
+}
+
+func TestSaveAsPDFRightToLeft(t *testing.T) {
+	f := excelize.NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Name", 100}))
+	style, err := f.NewStyle(&excelize.Style{Border: []excelize.Border{{Type: "left", Color: "FF0000", Style: 1}}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", style))
+	assert.NoError(t, f.SetSheetRightToLeft("Sheet1", true))
+	var buf bytes.Buffer
+	assert.NoError(t, SaveAsPDF(f, &buf, nil))
+	contents := pageContents(t, buf.Bytes())
+	assert.Len(t, contents, 1)
+	// The column A is placed at the right margin, the text is aligned right
+	// and the left border is drawn at the right edge of the cell
+	assert.Contains(t, contents[0], "1 0 0 RG 0.5 w [] 0 d 561.6 738 m 561.6 723 l S")
+	assert.Regexp(t, `BT /F1 11 Tf 5[0-9.]+ [0-9.]+ Td \(Name\) Tj ET`, contents[0])
+	assert.Regexp(t, `BT /F1 11 Tf 4[0-9.]+ [0-9.]+ Td \(100\) Tj ET`, contents[0])
+	assert.NoError(t, f.Close())
+}
+
+func TestSaveAsPDFErrors(t *testing.T) {
+	f := prepareTestBook(t)
+	// Test export not exists worksheet
+	assert.EqualError(t, SaveAsPDF(f, io.Discard, &Options{Sheets: []string{"SheetN"}}), "sheet SheetN does not exist")
+	// Test export with invalid print area
+	assert.NoError(t, f.SetDefinedName(&excelize.DefinedName{Name: "_xlnm.Print_Area", RefersTo: "Sheet1!A", Scope: "Sheet1"}))
+	assert.Error(t, SaveAsPDF(f, io.Discard, nil))
+	// Test export with writer error
+	assert.NoError(t, f.DeleteDefinedName(&excelize.DefinedName{Name: "_xlnm.Print_Area", Scope: "Sheet1"}))
+	assert.EqualError(t, SaveAsPDF(f, errWriter{}, nil), "write error")
+	assert.NoError(t, f.Close())
+}
+
+func TestParseHeaderFooter(t *testing.T) {
+	e := &exporter{f: excelize.NewFile(), sheet: "Sheet1"}
+	e.f.Path = "Book1.xlsx"
+	sections := e.parseHeaderFooter("&LLeft &F&C&12Center&R&\"Arial\"Right &N&", 2, 3)
+	assert.Equal(t, "Left Book1.xlsx", sections[0])
+	assert.Equal(t, "Center", sections[1])
+	assert.Equal(t, "Right 3&", sections[2])
+	assert.True(t, strings.HasSuffix(e.parseHeaderFooter("&Z", 1, 1)[1], "/"))
+}
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) { return 0, errors.New("write error") }
+
+func boolPtr(b bool) *bool       { return &b }
+func intPtr(i int) *int          { return &i }
+func stringPtr(s string) *string { return &s }
+func uintPtr(u uint) *uint       { return &u }
@@ -12,6 +12,7 @@
 package excelize
 
 import (
+	"archive/zip"
 	"bytes"
 	"encoding/xml"
 	"image"
@@ -35,6 +36,13 @@ const (
 	PictureInsertTypeDISPIMG
 )
 
+// pictureRef defines the picture and the media part path of the picture
+// content.
+type pictureRef struct {
+	pic   Picture
+	media string
+}
+
 // parseGraphicOptions provides a function to parse the format settings of
 // the picture with default value.
 func parseGraphicOptions(opts *GraphicOptions) *GraphicOptions {
@@ -128,6 +136,13 @@ func parseGraphicOptions(opts *GraphicOptions) *GraphicOptions {
 // The optional parameter "AltText" is used to add alternative text to a graph
 // object.
 //
+// The optional parameter "AltTextTitle" is used to add the title of the
+// alternative text to a graph object.
+//
+// The optional parameter "Decorative" indicates whether the graph object is
+// marked as decorative, the decorative objects will be ignored by
+// accessibility tools such as screen readers.
+//
 // The optional parameter "PrintObject" indicates whether the graph object is
 // printed when the worksheet is printed, the default value of that is 'true'.
 //
@@ -165,6 +180,42 @@ func parseGraphicOptions(opts *GraphicOptions) *GraphicOptions {
 // cells), "twoCell" (Move and size with cells), and "absolute" (Don't move or
 // size with cells). If you don't set this parameter, the default positioning
 // is to move and size with cells.
+//
+// The optional parameter "Unit" specifies the unit of the "PositionX",
+// "PositionY", "Width" and "Height", the supported units are "emu" (English
+// Metric Unit), "cm", "mm", "in" and "pt", the default unit is "emu".
+//
+// The optional parameter "Absolute" specifies whether position the graph
+// object by the absolute coordinates from the top-left corner of the
+// worksheet, instead of the cell reference and offsets. The graph object will
+// be anchored on the cell which contains the top-left corner of that.
+//
+// The optional parameters "PositionX" and "PositionY" specify the horizontal
+// and vertical coordinates of the graph object when "Absolute" is enabled.
+//
+// The optional parameters "Width" and "Height" specify the target size of the
+// graph object, which override the "AutoFit", "ScaleX" and "ScaleY", if only
+// one of them is specified, the other one will be calculated according to the
+// aspect ratio of the image. For example, insert a picture at 2 cm right and
+// 3 cm below the top-left corner of the worksheet, which width is 5 cm:
+//
+//	err := f.AddPicture("Sheet1", "A1", "image.png", &excelize.GraphicOptions{
+//	    Unit:      "cm",
+//	    Absolute:  true,
+//	    PositionX: 2,
+//	    PositionY: 3,
+//	    Width:     5,
+//	})
+//
+// The optional parameter "LinkedRange" specifies the range reference which the
+// picture content mirrors, such as "Sheet1!A1:C5", to create a linked picture
+// like the camera tool does. The given image will be used as the snapshot of
+// the range, and the spreadsheet application will refresh the picture with
+// the current appearance of the range. For example:
+//
+//	err := f.AddPicture("Sheet2", "B2", "snapshot.png", &excelize.GraphicOptions{
+//	    LinkedRange: "Sheet1!A1:C5",
+//	})
 func (f *File) AddPicture(sheet, cell, name string, opts *GraphicOptions) error {
 	var err error
 	// Check picture exists first.
@@ -313,6 +364,93 @@ func (f *File) addSheetPicture(sheet string, rID int) error {
 	return err
 }
 
+// getPictureAnchor provides a function to get the starting and ending anchor
+// of the picture by given worksheet name, cell reference, image config and
+// format sets.
+func (f *File) getPictureAnchor(sheet, cell string, col, row int, img image.Config, opts *GraphicOptions) (*xlsxFrom, *xlsxTo, error) {
+	if opts.hasAbsoluteAnchor() {
+		return f.getGraphicAnchorEMUs(sheet, col, row, img.Width, img.Height, opts)
+	}
+	var err error
+	width, height := img.Width, img.Height
+	if opts.AutoFit {
+		if width, height, col, row, err = f.drawingResize(sheet, cell, float64(width), float64(height), opts); err != nil {
+			return nil, nil, err
+		}
+	} else {
+		width = int(float64(width) * opts.ScaleX)
+		height = int(float64(height) * opts.ScaleY)
+	}
+	colStart, rowStart, colEnd, rowEnd, x2, y2 := f.positionObjectPixels(sheet, col, row, opts.OffsetX, opts.OffsetY, width, height)
+	from := xlsxFrom{}
+	from.Col = colStart
+	from.ColOff = opts.OffsetX * EMU
+	from.Row = rowStart
+	from.RowOff = opts.OffsetY * EMU
+	to := xlsxTo{}
+	to.Col = colEnd
+	to.ColOff = x2 * EMU
+	to.Row = rowEnd
+	to.RowOff = y2 * EMU
+	return &from, &to, err
+}
+
+// hasAbsoluteAnchor provides a function to check if the graph object should
+// be positioned by the absolute coordinates or sized by the physical units.
+func (opts *GraphicOptions) hasAbsoluteAnchor() bool {
+	return opts.Absolute || opts.Width != 0 || opts.Height != 0
+}
+
+// getGraphicAnchorEMUs provides a function to get the starting and ending
+// anchor of the graph object which positioned by the absolute coordinates or
+// sized by the physical units by given worksheet name, coordinates, the
+// original width and height of the graph object in pixels and format sets,
+// all the calculations are in EMUs to avoid the precision loss on converting
+// to pixels.
+func (f *File) getGraphicAnchorEMUs(sheet string, col, row, imgWidth, imgHeight int, opts *GraphicOptions) (*xlsxFrom, *xlsxTo, error) {
+	unit, ok := supportedGraphicUnits[strings.ToLower(opts.Unit)]
+	if !ok || opts.Width < 0 || opts.Height < 0 {
+		return nil, nil, ErrParameterInvalid
+	}
+	colWidth := func(col int) int { return f.getColWidth(sheet, col) * EMU }
+	rowHeight := func(row int) int { return f.getRowHeight(sheet, row) * EMU }
+	x, y := int(opts.PositionX*unit), int(opts.PositionY*unit)
+	if !opts.Absolute {
+		x, y = opts.OffsetX*EMU, opts.OffsetY*EMU
+		for c := 1; c < col; c++ {
+			x += colWidth(c)
+		}
+		for r := 1; r < row; r++ {
+			y += rowHeight(r)
+		}
+	}
+	width, height := int(float64(imgWidth*EMU)*opts.ScaleX), int(float64(imgHeight*EMU)*opts.ScaleY)
+	if opts.Width > 0 {
+		width = int(opts.Width * unit)
+	}
+	if opts.Height > 0 {
+		height = int(opts.Height * unit)
+	}
+	if opts.Width > 0 && opts.Height == 0 && imgWidth > 0 {
+		height = int(float64(width) * float64(imgHeight) / float64(imgWidth))
+	}
+	if opts.Height > 0 && opts.Width == 0 && imgHeight > 0 {
+		width = int(float64(height) * float64(imgWidth) / float64(imgHeight))
+	}
+	locate := func(pos, limit int, size func(int) int) (idx, off int) {
+		for idx = 0; idx < limit-1 && pos >= size(idx+1); idx++ {
+			pos -= size(idx + 1)
+		}
+		return idx, pos
+	}
+	from, to := xlsxFrom{}, xlsxTo{}
+	from.Col, from.ColOff = locate(x, MaxColumns, colWidth)
+	from.Row, from.RowOff = locate(y, TotalRows, rowHeight)
+	to.Col, to.ColOff = locate(x+width, MaxColumns, colWidth)
+	to.Row, to.RowOff = locate(y+height, TotalRows, rowHeight)
+	return &from, &to, nil
+}
+
 // countDrawings provides a function to get drawing files count storage in the
 // folder xl/drawings.
 func (f *File) countDrawings() int {
@@ -343,38 +481,35 @@ func (f *File) addDrawingPicture(sheet, drawingXML, cell, ext string, rID, hyper
 	if opts.Positioning != "" && inStrSlice(supportedPositioning, opts.Positioning, true) == -1 {
 		return ErrParameterInvalid
 	}
-	width, height := img.Width, img.Height
-	if opts.AutoFit {
-		if width, height, col, row, err = f.drawingResize(sheet, cell, float64(width), float64(height), opts); err != nil {
-			return err
-		}
-	} else {
-		width = int(float64(width) * opts.ScaleX)
-		height = int(float64(height) * opts.ScaleY)
+	from, to, err := f.getPictureAnchor(sheet, cell, col, row, img, opts)
+	if err != nil {
+		return err
 	}
-	colStart, rowStart, colEnd, rowEnd, x2, y2 := f.positionObjectPixels(sheet, col, row, opts.OffsetX, opts.OffsetY, width, height)
 	content, cNvPrID, err := f.drawingParser(drawingXML)
 	if err != nil {
 		return err
 	}
 	twoCellAnchor := xdrCellAnchor{}
 	twoCellAnchor.EditAs = opts.Positioning
-	from := xlsxFrom{}
-	from.Col = colStart
-	from.ColOff = opts.OffsetX * EMU
-	from.Row = rowStart
-	from.RowOff = opts.OffsetY * EMU
-	to := xlsxTo{}
-	to.Col = colEnd
-	to.ColOff = x2 * EMU
-	to.Row = rowEnd
-	to.RowOff = y2 * EMU
-	twoCellAnchor.From = &from
-	twoCellAnchor.To = &to
+	twoCellAnchor.From = from
+	twoCellAnchor.To = to
 	pic := xlsxPic{}
 	pic.NvPicPr.CNvPicPr.PicLocks.NoChangeAspect = opts.LockAspectRatio
+	if opts.LinkedRange != "" {
+		cellRange, err := parseLinkedRange(opts.LinkedRange)
+		if err != nil {
+			return err
+		}
+		pic.NvPicPr.CNvPicPr.ExtLst = &xlsxCNvPicPrExtLst{Ext: []xlsxCNvPicPrExt{{
+			URI: ExtURICameraTool,
+			CameraTool: &xlsxCameraTool{
+				XMLNSA14:  NameSpaceDrawingMLA14.Value,
+				CellRange: cellRange,
+			},
+		}}}
+	}
 	pic.NvPicPr.CNvPr.ID = cNvPrID
-	pic.NvPicPr.CNvPr.Descr = opts.AltText
+	pic.NvPicPr.CNvPr.setAltText(opts)
 	pic.NvPicPr.CNvPr.Name = "Picture " + strconv.Itoa(cNvPrID)
 	if hyperlinkRID != 0 {
 		pic.NvPicPr.CNvPr.HlinkClick = &xlsxHlinkClick{
@@ -411,6 +546,55 @@ func (f *File) addDrawingPicture(sheet, drawingXML, cell, ext string, rID, hyper
 	return err
 }
 
+// parseLinkedRange provides a function to convert the range reference of the
+// linked picture to the absolute range reference with optional worksheet
+// name, for example, convert "Sheet1!A1:C5" to "Sheet1!$A$1:$C$5".
+func parseLinkedRange(ref string) (string, error) {
+	var sheet string
+	if i := strings.LastIndex(ref, "!"); i != -1 {
+		sheet, ref = ref[:i], ref[i+1:]
+	}
+	ref = strings.ReplaceAll(ref, "$", "")
+	if !strings.Contains(ref, ":") {
+		ref += ":" + ref
+	}
+	coordinates, err := rangeRefToCoordinates(ref)
+	if err != nil {
+		return "", err
+	}
+	_ = sortCoordinates(coordinates)
+	if ref, err = coordinatesToRangeRef(coordinates, true); err != nil || sheet == "" {
+		return ref, err
+	}
+	return sheet + "!" + ref, err
+}
+
+// getLinkedRange returns the range reference of the linked picture created by
+// the camera tool.
+func (c *xlsxCNvPicPr) getLinkedRange() string {
+	if c.ExtLst != nil {
+		for _, ext := range c.ExtLst.Ext {
+			if ext.URI == ExtURICameraTool && ext.CameraTool != nil {
+				return ext.CameraTool.CellRange
+			}
+		}
+	}
+	return ""
+}
+
+// getLinkedRange returns the range reference of the decoded linked picture
+// created by the camera tool.
+func (c *decodeCNvPicPr) getLinkedRange() string {
+	if c.ExtLst != nil {
+		for _, ext := range c.ExtLst.Ext {
+			if ext.URI == ExtURICameraTool && ext.CameraTool != nil {
+				return ext.CameraTool.CellRange
+			}
+		}
+	}
+	return ""
+}
+
 // countMedia provides a function to get media files count storage in the
 // folder xl/media/image.
 func (f *File) countMedia() int {
@@ -421,6 +605,12 @@ func (f *File) countMedia() int {
 		}
 		return true
 	})
+	f.lazyMedia.Range(func(k, v interface{}) bool {
+		if _, ok := f.Pkg.Load(k); !ok && strings.Contains(k.(string), "xl/media/image") {
+			count++
+		}
+		return true
+	})
 	return count
 }
 
@@ -443,6 +633,20 @@ func (f *File) addMedia(file []byte, ext string) string {
 	if name != "" {
 		return name
 	}
+	f.lazyMedia.Range(func(k, v interface{}) bool {
+		if !strings.HasPrefix(k.(string), "xl/media/image") ||
+			v.(*zip.File).UncompressedSize64 != uint64(len(file)) {
+			return true
+		}
+		if existing, ok := f.readMedia(k.(string)); ok && bytes.Equal(file, existing) {
+			name = k.(string)
+			return false
+		}
+		return true
+	})
+	if name != "" {
+		return name
+	}
 	media := "xl/media/image" + strconv.Itoa(count+1) + ext
 	f.Pkg.Store(media, file)
 	return media
@@ -474,6 +678,68 @@ func (f *File) addMedia(file []byte, ext string) string {
 //	    }
 //	}
 func (f *File) GetPictures(sheet, cell string) ([]Picture, error) {
+	refs, err := f.getPictureRefs(sheet, cell)
+	if err != nil {
+		return nil, err
+	}
+	var pics []Picture
+	for _, ref := range refs {
+		if content, ok := f.readMedia(ref.media); ok {
+			ref.pic.File = content
+			pics = append(pics, ref.pic)
+		}
+	}
+	return pics, err
+}
+
+// GetPictureInfo provides a function to get the meta info of the pictures
+// embed in spreadsheet by given worksheet and cell name, without reading the
+// image contents into memory. The width and height of the picture in pixels
+// will be zero if the decoder of the image format was not registered. This
+// function is concurrency safe. For example:
+//
+//	infos, err := f.GetPictureInfo("Sheet1", "A2")
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	for _, info := range infos {
+//	    fmt.Println(info.Extension, info.Width, info.Height, info.Size)
+//	}
+func (f *File) GetPictureInfo(sheet, cell string) ([]PictureInfo, error) {
+	refs, err := f.getPictureRefs(sheet, cell)
+	if err != nil {
+		return nil, err
+	}
+	var infos []PictureInfo
+	for _, ref := range refs {
+		info := PictureInfo{Extension: ref.pic.Extension, Format: ref.pic.Format, InsertType: ref.pic.InsertType}
+		decodeConfig := func(r io.Reader) {
+			if cfg, _, err := image.DecodeConfig(r); err == nil {
+				info.Width, info.Height = cfg.Width, cfg.Height
+			}
+		}
+		if content, _ := f.Pkg.Load(ref.media); content != nil {
+			info.Size = int64(len(content.([]byte)))
+			decodeConfig(bytes.NewReader(content.([]byte)))
+		} else if file, ok := f.lazyMedia.Load(ref.media); ok {
+			info.Size = int64(file.(*zip.File).UncompressedSize64)
+			rc, err := file.(*zip.File).Open()
+			if err != nil {
+				return infos, err
+			}
+			decodeConfig(rc)
+			_ = rc.Close()
+		} else {
+			continue
+		}
+		infos = append(infos, info)
+	}
+	return infos, err
+}
+
+// getPictureRefs provides a function to get the pictures and the media part
+// paths of the pictures by given worksheet and cell name.
+func (f *File) getPictureRefs(sheet, cell string) ([]pictureRef, error) {
 	col, row, err := CellNameToCoordinates(cell)
 	if err != nil {
 		return nil, err
@@ -579,7 +845,9 @@ func (f *File) DeletePicture(sheet, cell string) error {
 	f.Relationships.Range(checkPicRef)
 	f.Pkg.Range(checkPicRef)
 	if !used {
-		f.Pkg.Delete(strings.Replace(rels.Target, "../", "xl/", -1))
+		media := strings.Replace(rels.Target, "../", "xl/", -1)
+		f.Pkg.Delete(media)
+		f.lazyMedia.Delete(media)
 	}
 	f.deleteDrawingRels(drawingRels, rID)
 	return err
@@ -587,7 +855,7 @@ func (f *File) DeletePicture(sheet, cell string) error {
 
 // getPicture provides a function to get picture base name and raw content
 // embed in spreadsheet by given coordinates and drawing relationships.
-func (f *File) getPicture(row, col int, drawingXML, drawingRelationships string) (pics []Picture, err error) {
+func (f *File) getPicture(row, col int, drawingXML, drawingRelationships string) (pics []pictureRef, err error) {
 	var wsDr *xlsxWsDr
 	if wsDr, _, err = f.drawingParser(drawingXML); err != nil {
 		return
@@ -599,19 +867,21 @@ func (f *File) getPicture(row, col int, drawingXML, drawingRelationships string)
 	cb := func(a *xdrCellAnchor, r *xlsxRelationship) {
 		pic := Picture{Extension: filepath.Ext(r.Target), Format: &GraphicOptions{}, InsertType: PictureInsertTypePlaceOverCells}
 		target, _ := filepath.Abs("/xl/drawings/" + r.Target)
-		if buffer, _ := f.Pkg.Load(strings.TrimPrefix(target, "/")); buffer != nil {
-			pic.File = buffer.([]byte)
+		if media := strings.TrimPrefix(target, "/"); f.hasMedia(media) {
 			pic.Format.AltText = a.Pic.NvPicPr.CNvPr.Descr
-			pics = append(pics, pic)
+			pic.Format.AltTextTitle, pic.Format.Decorative = a.Pic.NvPicPr.CNvPr.Title, a.Pic.NvPicPr.CNvPr.isDecorative()
+			pic.Format.LinkedRange = a.Pic.NvPicPr.CNvPicPr.getLinkedRange()
+			pics = append(pics, pictureRef{pic: pic, media: media})
 		}
 	}
 	cb2 := func(a *decodeCellAnchor, r *xlsxRelationship) {
 		pic := Picture{Extension: filepath.Ext(r.Target), Format: &GraphicOptions{}, InsertType: PictureInsertTypePlaceOverCells}
 		target, _ := filepath.Abs("/xl/drawings/" + r.Target)
-		if buffer, _ := f.Pkg.Load(strings.TrimPrefix(target, "/")); buffer != nil {
-			pic.File = buffer.([]byte)
+		if media := strings.TrimPrefix(target, "/"); f.hasMedia(media) {
 			pic.Format.AltText = a.Pic.NvPicPr.CNvPr.Descr
-			pics = append(pics, pic)
+			pic.Format.AltTextTitle, pic.Format.Decorative = a.Pic.NvPicPr.CNvPr.Title, a.Pic.NvPicPr.CNvPr.isDecorative()
+			pic.Format.LinkedRange = a.Pic.NvPicPr.CNvPicPr.getLinkedRange()
+			pics = append(pics, pictureRef{pic: pic, media: media})
 		}
 	}
 	for _, anchor := range wsDr.TwoCellAnchor {
@@ -759,7 +1029,7 @@ func (f *File) getPictureCells(drawingXML, drawingRelationships string) ([]strin
 	cond2 := func(from *decodeFrom) bool { return true }
 	cb := func(a *xdrCellAnchor, r *xlsxRelationship) {
 		target, _ := filepath.Abs("/xl/drawings/" + r.Target)
-		if _, ok := f.Pkg.Load(strings.TrimPrefix(target, "/")); ok {
+		if f.hasMedia(strings.TrimPrefix(target, "/")) {
 			if cell, err := CoordinatesToCellName(a.From.Col+1, a.From.Row+1); err == nil && inStrSlice(cells, cell, true) == -1 {
 				cells = append(cells, cell)
 			}
@@ -767,7 +1037,7 @@ func (f *File) getPictureCells(drawingXML, drawingRelationships string) ([]strin
 	}
 	cb2 := func(a *decodeCellAnchor, r *xlsxRelationship) {
 		target, _ := filepath.Abs("/xl/drawings/" + r.Target)
-		if _, ok := f.Pkg.Load(strings.TrimPrefix(target, "/")); ok {
+		if f.hasMedia(strings.TrimPrefix(target, "/")) {
 			if cell, err := CoordinatesToCellName(a.From.Col+1, a.From.Row+1); err == nil && inStrSlice(cells, cell, true) == -1 {
 				cells = append(cells, cell)
 			}
@@ -910,7 +1180,7 @@ func (f *File) getImageCellRel(c *xlsxC, pic *Picture) (*xlsxRelationship, error
 // getCellImages provides a function to get the cell images and
 // the Kingsoft WPS Office embedded cell images by given worksheet name and cell
 // reference.
-func (f *File) getCellImages(sheet, cell string) ([]Picture, error) {
+func (f *File) getCellImages(sheet, cell string) ([]pictureRef, error) {
 	pics, err := f.getDispImages(sheet, cell)
 	if err != nil {
 		return pics, err
@@ -922,9 +1192,8 @@ func (f *File) getCellImages(sheet, cell string) ([]Picture, error) {
 			return "", true, err
 		}
 		pic.Extension = filepath.Ext(r.Target)
-		if buffer, _ := f.Pkg.Load(strings.TrimPrefix(strings.ReplaceAll(r.Target, "..", "xl"), "/")); buffer != nil {
-			pic.File = buffer.([]byte)
-			pics = append(pics, pic)
+		if media := strings.TrimPrefix(strings.ReplaceAll(r.Target, "..", "xl"), "/"); f.hasMedia(media) {
+			pics = append(pics, pictureRef{pic: pic, media: media})
 		}
 		return "", true, nil
 	})
@@ -933,7 +1202,7 @@ func (f *File) getCellImages(sheet, cell string) ([]Picture, error) {
 
 // getDispImages provides a function to get the Kingsoft WPS Office embedded
 // cell images by given worksheet name and cell reference.
-func (f *File) getDispImages(sheet, cell string) ([]Picture, error) {
+func (f *File) getDispImages(sheet, cell string) ([]pictureRef, error) {
 	formula, err := f.GetCellFormula(sheet, cell)
 	if err != nil {
 		return nil, err
@@ -953,16 +1222,16 @@ func (f *File) getDispImages(sheet, cell string) ([]Picture, error) {
 	if rels == nil {
 		return nil, err
 	}
-	var pics []Picture
+	var pics []pictureRef
 	for _, cellImg := range cellImages.CellImage {
 		if cellImg.Pic.NvPicPr.CNvPr.Name == imgID {
 			for _, r := range rels.Relationships {
 				if r.ID == cellImg.Pic.BlipFill.Blip.Embed {
 					pic := Picture{Extension: filepath.Ext(r.Target), Format: &GraphicOptions{}, InsertType: PictureInsertTypeDISPIMG}
-					if buffer, _ := f.Pkg.Load("xl/" + r.Target); buffer != nil {
-						pic.File = buffer.([]byte)
+					if media := "xl/" + r.Target; f.hasMedia(media) {
 						pic.Format.AltText = cellImg.Pic.NvPicPr.CNvPr.Descr
-						pics = append(pics, pic)
+						pic.Format.AltTextTitle, pic.Format.Decorative = cellImg.Pic.NvPicPr.CNvPr.Title, cellImg.Pic.NvPicPr.CNvPr.isDecorative()
+						pics = append(pics, pictureRef{pic: pic, media: media})
 					}
 				}
 			}
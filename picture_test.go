@@ -1,6 +1,7 @@
 package excelize
 
 import (
+	"bytes"
 	"fmt"
 	"image"
 	_ "image/gif"
@@ -270,6 +271,225 @@ func TestGetPicture(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestAddPictureAltText(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"),
+		&GraphicOptions{AltText: "Excel logo", AltTextTitle: "Logo"}))
+	assert.NoError(t, f.AddPicture("Sheet1", "F1", filepath.Join("test", "images", "excel.png"),
+		&GraphicOptions{Decorative: true}))
+	for cell, expected := range map[string]GraphicOptions{
+		"A1": {AltText: "Excel logo", AltTextTitle: "Logo"},
+		"F1": {Decorative: true},
+	} {
+		pics, err := f.GetPictures("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Len(t, pics, 1)
+		assert.Equal(t, expected, *pics[0].Format)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddPictureAltText.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test get alternative text of the pictures from the saved workbook
+	f, err := OpenFile(filepath.Join("test", "TestAddPictureAltText.xlsx"))
+	assert.NoError(t, err)
+	for cell, expected := range map[string]GraphicOptions{
+		"A1": {AltText: "Excel logo", AltTextTitle: "Logo"},
+		"F1": {Decorative: true},
+	} {
+		pics, err := f.GetPictures("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Len(t, pics, 1)
+		assert.Equal(t, expected, *pics[0].Format)
+	}
+	assert.NoError(t, f.Close())
+}
+
+func TestAddPictureLinkedRange(t *testing.T) {
+	f := NewFile()
+	for cell, linkedRange := range map[string]string{"A1": "Sheet1!C5:A1", "F1": "$B$2"} {
+		assert.NoError(t, f.AddPicture("Sheet1", cell, filepath.Join("test", "images", "excel.png"),
+			&GraphicOptions{LinkedRange: linkedRange}))
+	}
+	expected := map[string]GraphicOptions{
+		"A1": {LinkedRange: "Sheet1!$A$1:$C$5"},
+		"F1": {LinkedRange: "$B$2:$B$2"},
+	}
+	for cell, opts := range expected {
+		pics, err := f.GetPictures("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Len(t, pics, 1)
+		assert.Equal(t, opts, *pics[0].Format)
+	}
+	// Test add linked picture with invalid range reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")),
+		f.AddPicture("Sheet1", "K1", filepath.Join("test", "images", "excel.png"), &GraphicOptions{LinkedRange: "Sheet1!A"}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddPictureLinkedRange.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test get linked range of the pictures from the saved workbook
+	f, err := OpenFile(filepath.Join("test", "TestAddPictureLinkedRange.xlsx"))
+	assert.NoError(t, err)
+	for cell, opts := range expected {
+		pics, err := f.GetPictures("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Len(t, pics, 1)
+		assert.Equal(t, opts, *pics[0].Format)
+	}
+	assert.NoError(t, f.Close())
+}
+
+func TestGetPictureInfo(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"), nil))
+	file, err := os.ReadFile(filepath.Join("test", "images", "excel.png"))
+	assert.NoError(t, err)
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(file))
+	assert.NoError(t, err)
+	infos, err := f.GetPictureInfo("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, []PictureInfo{{
+		Extension: ".png", Format: &GraphicOptions{}, InsertType: PictureInsertTypePlaceOverCells,
+		Width: cfg.Width, Height: cfg.Height, Size: 13233,
+	}}, infos)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetPictureInfo.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test get picture info with the lazily loaded media part
+	f, err = OpenFile(filepath.Join("test", "TestGetPictureInfo.xlsx"))
+	assert.NoError(t, err)
+	_, ok := f.Pkg.Load("xl/media/image1.png")
+	assert.False(t, ok)
+	_, ok = f.lazyMedia.Load("xl/media/image1.png")
+	assert.True(t, ok)
+	infos, err = f.GetPictureInfo("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Len(t, infos, 1)
+	assert.Equal(t, ".png", infos[0].Extension)
+	assert.Equal(t, int64(13233), infos[0].Size)
+	assert.Equal(t, cfg.Width, infos[0].Width)
+	assert.Equal(t, 1, f.countMedia())
+	_, ok = f.Pkg.Load("xl/media/image1.png")
+	assert.False(t, ok)
+	// Test get pictures will extract the media part
+	pics, err := f.GetPictures("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Len(t, pics[0].File, 13233)
+	_, ok = f.lazyMedia.Load("xl/media/image1.png")
+	assert.False(t, ok)
+	infos, err = f.GetPictureInfo("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(13233), infos[0].Size)
+	assert.NoError(t, f.Close())
+
+	// Test add the duplicate picture with the lazily loaded media part
+	f, err = OpenFile(filepath.Join("test", "TestGetPictureInfo.xlsx"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddPicture("Sheet1", "B1", filepath.Join("test", "images", "excel.jpg"), nil))
+	assert.NoError(t, f.AddPicture("Sheet1", "C1", filepath.Join("test", "images", "excel.png"), nil))
+	assert.Equal(t, 2, f.countMedia())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetPictureInfo2.xlsx")))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestGetPictureInfo2.xlsx"), Options{Canonical: true})
+	assert.NoError(t, err)
+	for _, cell := range []string{"A1", "C1"} {
+		pics, err = f.GetPictures("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Len(t, pics[0].File, 13233)
+	}
+	// Test delete picture with the lazily loaded media part
+	assert.NoError(t, f.DeletePicture("Sheet1", "B1"))
+	_, ok = f.lazyMedia.Load("xl/media/image2.jpg")
+	assert.False(t, ok)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetPictureInfo3.xlsx")))
+	assert.NoError(t, f.Close())
+	assert.Nil(t, f.source)
+
+	// Test save the workbook to the source file with the media parts read on
+	// demand from the source file
+	f, err = OpenFile(filepath.Join("test", "TestGetPictureInfo2.xlsx"))
+	assert.NoError(t, err)
+	assert.NotNil(t, f.source)
+	assert.NoError(t, f.Save())
+	assert.Nil(t, f.source)
+	_, ok = f.lazyMedia.Load("xl/media/image1.png")
+	assert.True(t, ok)
+	pics, err = f.GetPictures("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Len(t, pics[0].File, 13233)
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestGetPictureInfo2.xlsx"))
+	assert.NoError(t, err)
+	for _, cell := range []string{"A1", "B1", "C1"} {
+		pics, err = f.GetPictures("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Len(t, pics, 1, cell)
+	}
+	assert.NoError(t, f.Close())
+
+	// Test get picture info with invalid cell reference
+	_, err = f.GetPictureInfo("Sheet1", "A")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	// Test get picture info on not exists worksheet
+	_, err = f.GetPictureInfo("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+}
+
+func TestAddPicturePhysicalUnits(t *testing.T) {
+	f := NewFile()
+	file, err := os.ReadFile(filepath.Join("test", "images", "excel.png"))
+	assert.NoError(t, err)
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(file))
+	assert.NoError(t, err)
+	colWidth, rowHeight := f.getColWidth("Sheet1", 1)*EMU, f.getRowHeight("Sheet1", 1)*EMU
+	getAnchor := func(idx int) *xdrCellAnchor {
+		drawing, ok := f.Drawings.Load("xl/drawings/drawing1.xml")
+		assert.True(t, ok)
+		return drawing.(*xlsxWsDr).TwoCellAnchor[idx]
+	}
+	// Test add picture by absolute position with the width in centimeters
+	assert.NoError(t, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"),
+		&GraphicOptions{Unit: "cm", Absolute: true, PositionX: 2, PositionY: 3, Width: 5}))
+	x, y, width := 720000, 1080000, 1800000
+	height := int(float64(width) * float64(cfg.Height) / float64(cfg.Width))
+	anchor := getAnchor(0)
+	assert.Equal(t, &xlsxFrom{Col: x / colWidth, ColOff: x % colWidth, Row: y / rowHeight, RowOff: y % rowHeight}, anchor.From)
+	assert.Equal(t, &xlsxTo{Col: (x + width) / colWidth, ColOff: (x + width) % colWidth, Row: (y + height) / rowHeight, RowOff: (y + height) % rowHeight}, anchor.To)
+	cell, err := CoordinatesToCellName(x/colWidth+1, y/rowHeight+1)
+	assert.NoError(t, err)
+	pics, err := f.GetPictures("Sheet1", cell)
+	assert.NoError(t, err)
+	assert.Len(t, pics, 1)
+	// Test add picture on the cell with the height in inches
+	assert.NoError(t, f.AddPicture("Sheet1", "C2", filepath.Join("test", "images", "excel.png"),
+		&GraphicOptions{Unit: "in", OffsetX: 10, OffsetY: 5, Height: 1}))
+	x, y, height = 2*colWidth+10*EMU, rowHeight+5*EMU, 914400
+	width = int(float64(height) * float64(cfg.Width) / float64(cfg.Height))
+	anchor = getAnchor(1)
+	assert.Equal(t, &xlsxFrom{Col: 2, ColOff: 10 * EMU, Row: 1, RowOff: 5 * EMU}, anchor.From)
+	assert.Equal(t, &xlsxTo{Col: (x + width) / colWidth, ColOff: (x + width) % colWidth, Row: (y + height) / rowHeight, RowOff: (y + height) % rowHeight}, anchor.To)
+	// Test add picture with the width and height in EMUs
+	assert.NoError(t, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"),
+		&GraphicOptions{Absolute: true, Width: float64(colWidth), Height: float64(rowHeight)}))
+	anchor = getAnchor(2)
+	assert.Equal(t, &xlsxFrom{}, anchor.From)
+	assert.Equal(t, &xlsxTo{Col: 1, Row: 1}, anchor.To)
+	// Test add picture with the original size by absolute position
+	assert.NoError(t, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"),
+		&GraphicOptions{Unit: "mm", Absolute: true, PositionX: 10, ScaleX: 0.5}))
+	anchor = getAnchor(3)
+	x, width, height = 360000, cfg.Width*EMU/2, cfg.Height*EMU
+	assert.Equal(t, &xlsxFrom{Col: x / colWidth, ColOff: x % colWidth}, anchor.From)
+	assert.Equal(t, &xlsxTo{Col: (x + width) / colWidth, ColOff: (x + width) % colWidth, Row: height / rowHeight, RowOff: height % rowHeight}, anchor.To)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddPicturePhysicalUnits.xlsx")))
+	// Test add picture with unsupported unit
+	assert.Equal(t, ErrParameterInvalid, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"),
+		&GraphicOptions{Unit: "px", Width: 1}))
+	// Test add picture with negative size
+	assert.Equal(t, ErrParameterInvalid, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"),
+		&GraphicOptions{Absolute: true, Height: -1}))
+	assert.NoError(t, f.Close())
+}
+
 func TestAddDrawingPicture(t *testing.T) {
 	// Test addDrawingPicture with illegal cell reference
 	f := NewFile()
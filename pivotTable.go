@@ -14,6 +14,7 @@ package excelize
 import (
 	"bytes"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
 	"path/filepath"
@@ -189,7 +190,7 @@ func (f *File) parseFormatPivotTableSet(opts *PivotTableOptions) (*xlsxWorksheet
 	}
 	pivotTableSheetName, _, err := f.adjustRange(opts.PivotTableRange)
 	if err != nil {
-		return nil, "", newPivotTableRangeError(err.Error())
+		return nil, "", newPivotTableRangeError(err)
 	}
 	if len(opts.Name) > MaxFieldLength {
 		return nil, "", ErrNameLength
@@ -200,7 +201,7 @@ func (f *File) parseFormatPivotTableSet(opts *PivotTableOptions) (*xlsxWorksheet
 	}
 	dataSheetName, _, err := f.adjustRange(opts.pivotDataRange)
 	if err != nil {
-		return nil, "", newPivotTableDataRangeError(err.Error())
+		return nil, "", newPivotTableDataRangeError(err)
 	}
 	dataSheet, err := f.workSheetReader(dataSheetName)
 	if err != nil {
@@ -252,7 +253,7 @@ func (f *File) getTableFieldsOrder(opts *PivotTableOptions) ([]string, error) {
 	}
 	dataSheet, coordinates, err := f.adjustRange(opts.pivotDataRange)
 	if err != nil {
-		return order, newPivotTableDataRangeError(err.Error())
+		return order, newPivotTableDataRangeError(err)
 	}
 	for col := coordinates[0]; col <= coordinates[2]; col++ {
 		coordinate, _ := CoordinatesToCellName(col, coordinates[1])
@@ -270,7 +271,7 @@ func (f *File) addPivotCache(opts *PivotTableOptions) error {
 	// validate data range
 	dataSheet, coordinates, err := f.adjustRange(opts.pivotDataRange)
 	if err != nil {
-		return newPivotTableDataRangeError(err.Error())
+		return newPivotTableDataRangeError(err)
 	}
 	// data range has been checked
 	order, _ := f.getTableFieldsOrder(opts)
@@ -312,7 +313,7 @@ func (f *File) addPivotTable(cacheID, pivotTableID int, opts *PivotTableOptions)
 	// validate pivot table range
 	_, coordinates, err := f.adjustRange(opts.PivotTableRange)
 	if err != nil {
-		return newPivotTableRangeError(err.Error())
+		return newPivotTableRangeError(err)
 	}
 
 	topLeftCell, _ := CoordinatesToCellName(coordinates[0], coordinates[1])
@@ -747,7 +748,7 @@ func (f *File) GetPivotTables(sheet string) ([]PivotTableOptions, error) {
 // named reference (defined name or table name), and set pivot table data range.
 func (f *File) getPivotTableDataRange(opts *PivotTableOptions) error {
 	if opts.DataRange == "" {
-		return newPivotTableDataRangeError(ErrParameterRequired.Error())
+		return newPivotTableDataRangeError(ErrParameterRequired)
 	}
 	if opts.pivotDataRange != "" {
 		return nil
@@ -758,8 +759,7 @@ func (f *File) getPivotTableDataRange(opts *PivotTableOptions) error {
 	}
 	for _, sheetName := range f.GetSheetList() {
 		tables, err := f.GetTables(sheetName)
-		e := ErrSheetNotExist{sheetName}
-		if err != nil && err.Error() != newNotWorksheetError(sheetName).Error() && err.Error() != e.Error() {
+		if err != nil && !errors.Is(err, ErrNotWorksheet{SheetName: sheetName}) && !errors.Is(err, ErrSheetNotExist{SheetName: sheetName}) {
 			return err
 		}
 		for _, table := range tables {
@@ -776,7 +776,7 @@ func (f *File) getPivotTableDataRange(opts *PivotTableOptions) error {
 			return nil
 		}
 	}
-	return newPivotTableDataRangeError(ErrParameterInvalid.Error())
+	return newPivotTableDataRangeError(ErrParameterInvalid)
 }
 
 // getPivotTable provides a function to get a pivot table definition by given
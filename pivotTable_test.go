@@ -182,7 +182,7 @@ func TestPivotTable(t *testing.T) {
 		Name:            strings.Repeat("c", MaxFieldLength+1),
 	}))
 	// Test invalid data range
-	assert.Equal(t, newPivotTableDataRangeError("parameter is invalid"), f.AddPivotTable(&PivotTableOptions{
+	assert.Equal(t, newPivotTableDataRangeError(ErrParameterInvalid), f.AddPivotTable(&PivotTableOptions{
 		DataRange:       "Sheet1!A1:A1",
 		PivotTableRange: "Sheet1!U34:O2",
 		Rows:            []PivotTableField{{Data: "Month", DefaultSubtotal: true}, {Data: "Year"}},
@@ -190,7 +190,7 @@ func TestPivotTable(t *testing.T) {
 		Data:            []PivotTableField{{Data: "Sales"}},
 	}))
 	// Test the data range of the worksheet that is not declared
-	assert.Equal(t, newPivotTableDataRangeError("parameter is invalid"), f.AddPivotTable(&PivotTableOptions{
+	assert.Equal(t, newPivotTableDataRangeError(ErrParameterInvalid), f.AddPivotTable(&PivotTableOptions{
 		DataRange:       "A1:E31",
 		PivotTableRange: "Sheet1!U34:O2",
 		Rows:            []PivotTableField{{Data: "Month", DefaultSubtotal: true}, {Data: "Year"}},
@@ -206,7 +206,7 @@ func TestPivotTable(t *testing.T) {
 		Data:            []PivotTableField{{Data: "Sales"}},
 	}))
 	// Test the pivot table range of the worksheet that is not declared
-	assert.Equal(t, newPivotTableRangeError("parameter is invalid"), f.AddPivotTable(&PivotTableOptions{
+	assert.Equal(t, newPivotTableRangeError(ErrParameterInvalid), f.AddPivotTable(&PivotTableOptions{
 		DataRange:       "Sheet1!A1:E31",
 		PivotTableRange: "U34:O2",
 		Rows:            []PivotTableField{{Data: "Month", DefaultSubtotal: true}, {Data: "Year"}},
@@ -230,7 +230,7 @@ func TestPivotTable(t *testing.T) {
 		Data:            []PivotTableField{{Data: "Sales"}},
 	}))
 	// Test invalid row number in data range
-	assert.Equal(t, newPivotTableDataRangeError(newCellNameToCoordinatesError("A0", newInvalidCellNameError("A0")).Error()), f.AddPivotTable(&PivotTableOptions{
+	assert.Equal(t, newPivotTableDataRangeError(newCellNameToCoordinatesError("A0", newInvalidCellNameError("A0"))), f.AddPivotTable(&PivotTableOptions{
 		DataRange:       "Sheet1!A0:E31",
 		PivotTableRange: "Sheet1!U34:O2",
 		Rows:            []PivotTableField{{Data: "Month", DefaultSubtotal: true}, {Data: "Year"}},
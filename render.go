@@ -0,0 +1,480 @@
+// Copyright 2016 - 2024 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.18 or later.
+
+package excelize
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/xuri/excelize/v2/internal/layout"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/gobolditalic"
+	"golang.org/x/image/font/gofont/goitalic"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// renderCell directly maps the pixel rectangle of a cell or merged cells, and
+// the cell reference of the value in the rendering.
+type renderCell struct {
+	rect image.Rectangle
+	cell string
+}
+
+// rangeRenderer directly maps the settings for rendering the range of the
+// worksheet.
+type rangeRenderer struct {
+	f      *File
+	sheet  string
+	ws     *xlsxWorksheet
+	rtl    bool
+	styles map[int]*Style
+	faces  map[renderFaceKey]font.Face
+}
+
+// renderFaceKey directly maps the cache key of the font face in the
+// rendering.
+type renderFaceKey struct {
+	size  float64
+	index int
+}
+
+var (
+	// renderFonts defined the fonts for rendering the regular, bold, italic
+	// and bold italic text.
+	renderFonts     [4]*sfnt.Font
+	renderFontsErr  error
+	renderFontsOnce sync.Once
+	// renderGridColor defined the color of the gridlines in the rendering.
+	renderGridColor = color.RGBA{R: 0xD4, G: 0xD4, B: 0xD4, A: 0xFF}
+)
+
+// RenderRange provides a function to render the cells in the range of the
+// worksheet to an image by given worksheet name and range reference. The
+// fonts, fills, borders, merged cells, alignment and formatted values of the
+// cells will be rasterized, which could be used for generating the previews or
+// thumbnails of the tables. Note that the text will be rendered by the Go
+// fonts instead of the fonts specified in the cell styles, and the hidden rows
+// and columns will not be rendered. The number of cells in the range is
+// limited by MaxRenderCells, and the width multiplied by the height of the
+// image in pixels is limited by MaxRenderPixels. For example, render the range
+// A1:D10 of the worksheet named Sheet1 to a PNG image:
+//
+//	img, err := f.RenderRange("Sheet1", "A1:D10")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	out, err := os.Create("preview.png")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	defer out.Close()
+//	if err := png.Encode(out, img); err != nil {
+//	    fmt.Println(err)
+//	}
+func (f *File) RenderRange(sheet, rangeRef string) (image.Image, error) {
+	if !strings.Contains(rangeRef, ":") {
+		rangeRef += ":" + rangeRef
+	}
+	coordinates, err := rangeRefToCoordinates(rangeRef)
+	if err != nil {
+		return nil, err
+	}
+	_ = sortCoordinates(coordinates)
+	if (coordinates[2]-coordinates[0]+1)*(coordinates[3]-coordinates[1]+1) > MaxRenderCells {
+		return nil, ErrRenderCells
+	}
+	if renderFontsOnce.Do(loadRenderFonts); renderFontsErr != nil {
+		return nil, renderFontsErr
+	}
+	r := &rangeRenderer{f: f, sheet: sheet, styles: make(map[int]*Style), faces: make(map[renderFaceKey]font.Face)}
+	if r.rtl, err = f.isSheetRightToLeft(sheet); err != nil {
+		return nil, err
+	}
+	if r.ws, err = f.workSheetReader(sheet); err != nil {
+		return nil, err
+	}
+	colX, rowY, err := r.layout(coordinates)
+	if err != nil {
+		return nil, err
+	}
+	if int64(colX[len(colX)-1])*int64(rowY[len(rowY)-1]) > MaxRenderPixels {
+		return nil, ErrRenderPixels
+	}
+	cells, err := r.cells(coordinates, colX, rowY)
+	if err != nil {
+		return nil, err
+	}
+	if r.rtl {
+		width := colX[len(colX)-1]
+		for i, c := range cells {
+			cells[i].rect.Min.X, cells[i].rect.Max.X = width-c.rect.Max.X, width-c.rect.Min.X
+		}
+	}
+	img := image.NewRGBA(image.Rect(0, 0, colX[len(colX)-1], rowY[len(rowY)-1]))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+	for _, layer := range []func(*image.RGBA, renderCell, *Style) error{
+		r.drawFill, r.drawGridlines, r.drawBorders, r.drawText,
+	} {
+		for _, c := range cells {
+			style, err := r.cellStyle(c.cell)
+			if err != nil {
+				return nil, err
+			}
+			if err = layer(img, c, style); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return img, nil
+}
+
+// loadRenderFonts provides a function to parse the fonts for rendering text.
+func loadRenderFonts() {
+	for i, ttf := range [][]byte{goregular.TTF, gobold.TTF, goitalic.TTF, gobolditalic.TTF} {
+		if renderFonts[i], renderFontsErr = opentype.Parse(ttf); renderFontsErr != nil {
+			return
+		}
+	}
+}
+
+// layout provides a function to calculate the pixel offsets of the columns
+// and rows in the range by given coordinates.
+func (r *rangeRenderer) layout(coordinates []int) ([]int, []int, error) {
+	colX := make([]int, coordinates[2]-coordinates[0]+2)
+	rowY := make([]int, coordinates[3]-coordinates[1]+2)
+	for col := coordinates[0]; col <= coordinates[2]; col++ {
+		name, _ := ColumnNumberToName(col)
+		visible, err := r.f.GetColVisible(r.sheet, name)
+		if err != nil {
+			return colX, rowY, err
+		}
+		var width int
+		if visible {
+			width = r.f.getColWidth(r.sheet, col)
+		}
+		colX[col-coordinates[0]+1] = colX[col-coordinates[0]] + width
+	}
+	ws, err := r.f.workSheetReader(r.sheet)
+	if err != nil {
+		return colX, rowY, err
+	}
+	for row := coordinates[1]; row <= coordinates[3]; row++ {
+		ht, err := r.f.GetRowHeight(r.sheet, row)
+		if err != nil {
+			return colX, rowY, err
+		}
+		height := int(convertRowHeightToPixels(ht))
+		ws.mu.Lock()
+		if row <= len(ws.SheetData.Row) && ws.SheetData.Row[row-1].Hidden {
+			height = 0
+		}
+		ws.mu.Unlock()
+		rowY[row-coordinates[1]+1] = rowY[row-coordinates[1]] + height
+	}
+	return colX, rowY, err
+}
+
+// cells provides a function to get the cells to be rendered in the range, the
+// merged cells in the range will be rendered as a single cell.
+func (r *rangeRenderer) cells(coordinates, colX, rowY []int) ([]renderCell, error) {
+	var cells []renderCell
+	mergeCells, err := r.f.GetMergeCells(r.sheet)
+	if err != nil {
+		return cells, err
+	}
+	var merged []layout.Span
+	for _, mergeCell := range mergeCells {
+		ref := mergeCell[0]
+		if !strings.Contains(ref, ":") {
+			ref += ":" + ref
+		}
+		rng, err := rangeRefToCoordinates(ref)
+		if err != nil {
+			return cells, err
+		}
+		_ = sortCoordinates(rng)
+		merged = append(merged, layout.Span{Col1: rng[0], Row1: rng[1], Col2: rng[2], Row2: rng[3]})
+	}
+	for _, span := range layout.Spans(layout.Span{
+		Col1: coordinates[0], Row1: coordinates[1], Col2: coordinates[2], Row2: coordinates[3],
+	}, merged) {
+		cell, _ := CoordinatesToCellName(span.Col, span.Row)
+		cells = append(cells, renderCell{rect: image.Rect(
+			colX[span.Col1-coordinates[0]], rowY[span.Row1-coordinates[1]],
+			colX[span.Col2-coordinates[0]+1], rowY[span.Row2-coordinates[1]+1],
+		), cell: cell})
+	}
+	return cells, err
+}
+
+// cellStyle provides a function to get the style definition of the cell
+// without changing the worksheet, the style definitions will be cached by the
+// style index.
+func (r *rangeRenderer) cellStyle(cell string) (*Style, error) {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return nil, err
+	}
+	r.ws.mu.Lock()
+	styleID, _ := r.ws.getCellStyleSource(col, row)
+	r.ws.mu.Unlock()
+	if style, ok := r.styles[styleID]; ok {
+		return style, err
+	}
+	style, err := r.f.GetStyle(styleID)
+	if err != nil {
+		return nil, err
+	}
+	r.styles[styleID] = style
+	return style, err
+}
+
+// drawFill provides a function to draw the fill of the cell.
+func (r *rangeRenderer) drawFill(img *image.RGBA, c renderCell, style *Style) error {
+	if clr, ok := layout.FillColor(style.Fill.Type, style.Fill.Pattern, style.Fill.Color); ok {
+		draw.Draw(img, c.rect, image.NewUniform(clr), image.Point{}, draw.Src)
+	}
+	return nil
+}
+
+// drawGridlines provides a function to draw the gridlines on the right and
+// bottom edge of the cell without fill.
+func (r *rangeRenderer) drawGridlines(img *image.RGBA, c renderCell, style *Style) error {
+	if _, ok := layout.FillColor(style.Fill.Type, style.Fill.Pattern, style.Fill.Color); ok || c.rect.Empty() {
+		return nil
+	}
+	drawRenderLine(img, c.rect.Max.X-1, c.rect.Min.Y, c.rect.Max.X-1, c.rect.Max.Y-1, nil, renderGridColor)
+	drawRenderLine(img, c.rect.Min.X, c.rect.Max.Y-1, c.rect.Max.X-1, c.rect.Max.Y-1, nil, renderGridColor)
+	return nil
+}
+
+// drawBorders provides a function to draw the borders of the cell.
+func (r *rangeRenderer) drawBorders(img *image.RGBA, c renderCell, style *Style) error {
+	if c.rect.Empty() {
+		return nil
+	}
+	minX, minY, maxX, maxY := c.rect.Min.X, c.rect.Min.Y, c.rect.Max.X-1, c.rect.Max.Y-1
+	for _, border := range style.Border {
+		bs, ok := layout.BorderStyles[border.Style]
+		if !ok {
+			continue
+		}
+		clr := parseRenderColor(border.Color, color.RGBA{A: 0xFF})
+		width := bs.Weight
+		if width < 1 {
+			width = 1
+		}
+		for i := 0; i < width; i++ {
+			offsets := []int{i}
+			if bs.Double {
+				offsets = []int{0, 2}
+			}
+			for _, o := range offsets {
+				switch layout.BorderType(border.Type, r.rtl) {
+				case "left":
+					drawRenderLine(img, minX+o, minY, minX+o, maxY, bs.Dash, clr)
+				case "right":
+					drawRenderLine(img, maxX-o, minY, maxX-o, maxY, bs.Dash, clr)
+				case "top":
+					drawRenderLine(img, minX, minY+o, maxX, minY+o, bs.Dash, clr)
+				case "bottom":
+					drawRenderLine(img, minX, maxY-o, maxX, maxY-o, bs.Dash, clr)
+				case "diagonalDown":
+					drawRenderLine(img, minX+o, minY, maxX, maxY-o, bs.Dash, clr)
+				case "diagonalUp":
+					drawRenderLine(img, minX+o, maxY, maxX, minY+o, bs.Dash, clr)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// drawText provides a function to draw the formatted value of the cell with
+// the font and alignment of the cell style.
+func (r *rangeRenderer) drawText(img *image.RGBA, c renderCell, style *Style) error {
+	if c.rect.Dx() < 3 || c.rect.Dy() < 3 {
+		return nil
+	}
+	val, err := r.f.GetCellValue(r.sheet, c.cell)
+	if err != nil || val == "" {
+		return err
+	}
+	kind, err := r.valueKind(c.cell)
+	if err != nil {
+		return err
+	}
+	var horizontal, vertical string
+	var indent int
+	var wrapText bool
+	if style.Alignment != nil {
+		horizontal, vertical = style.Alignment.Horizontal, style.Alignment.Vertical
+		indent, wrapText = style.Alignment.Indent*9, style.Alignment.WrapText
+	}
+	horizontal = layout.HorizontalAlignment(horizontal, kind, r.rtl)
+	face, err := r.fontFace(style.Font)
+	if err != nil {
+		return err
+	}
+	var (
+		clip       = img.SubImage(c.rect.Inset(1)).(*image.RGBA)
+		metrics    = face.Metrics()
+		ascent     = metrics.Ascent.Ceil()
+		lineHeight = metrics.Height.Ceil()
+		lines      = []string{strings.ReplaceAll(val, "\n", " ")}
+		clr        = color.RGBA{A: 0xFF}
+	)
+	if wrapText {
+		lines = layout.WrapText(val, float64(c.rect.Dx()-4-indent), func(text string) float64 {
+			return float64(font.MeasureString(face, text).Ceil())
+		})
+	}
+	if style.Font != nil {
+		clr = parseRenderColor(r.f.GetBaseColor(style.Font.Color, style.Font.ColorIndexed, style.Font.ColorTheme), clr)
+	}
+	y := int(layout.AlignY(vertical, float64(c.rect.Min.Y), float64(c.rect.Dy()), float64(lineHeight*len(lines))))
+	for _, line := range lines {
+		width := font.MeasureString(face, line).Ceil()
+		x := int(layout.AlignX(horizontal, float64(c.rect.Min.X), float64(c.rect.Dx()-1), float64(width), float64(indent), 2))
+		d := &font.Drawer{Dst: clip, Src: image.NewUniform(clr), Face: face, Dot: fixed.P(x, y+ascent)}
+		d.DrawString(line)
+		if style.Font != nil && style.Font.Underline != "" {
+			drawRenderLine(clip, x, y+ascent+1, x+width, y+ascent+1, nil, clr)
+			if strings.HasPrefix(style.Font.Underline, "double") {
+				drawRenderLine(clip, x, y+ascent+3, x+width, y+ascent+3, nil, clr)
+			}
+		}
+		if style.Font != nil && style.Font.Strike {
+			drawRenderLine(clip, x, y+ascent-ascent/3, x+width, y+ascent-ascent/3, nil, clr)
+		}
+		y += lineHeight
+	}
+	return err
+}
+
+// valueKind provides a function to get the kind of the cell value for the
+// general horizontal alignment by given cell reference.
+func (r *rangeRenderer) valueKind(cell string) (layout.ValueKind, error) {
+	cellType, err := r.f.GetCellType(r.sheet, cell)
+	if err != nil {
+		return layout.ValueText, err
+	}
+	switch cellType {
+	case CellTypeBool, CellTypeError:
+		return layout.ValueLogical, err
+	case CellTypeSharedString, CellTypeInlineString:
+		return layout.ValueText, err
+	}
+	raw, err := r.f.GetCellValue(r.sheet, cell, Options{RawCellValue: true})
+	if err != nil {
+		return layout.ValueText, err
+	}
+	if _, err := strconv.ParseFloat(raw, 64); err == nil {
+		return layout.ValueNumber, nil
+	}
+	return layout.ValueText, err
+}
+
+// fontFace provides a function to get the font face by given font settings,
+// the font faces will be cached by the font size and font style.
+func (r *rangeRenderer) fontFace(fnt *Font) (font.Face, error) {
+	key := renderFaceKey{size: 11}
+	if fnt != nil {
+		if fnt.Size > 0 {
+			key.size = fnt.Size
+		}
+		if fnt.Bold {
+			key.index |= 1
+		}
+		if fnt.Italic {
+			key.index |= 2
+		}
+	}
+	if face, ok := r.faces[key]; ok {
+		return face, nil
+	}
+	face, err := opentype.NewFace(renderFonts[key.index], &opentype.FaceOptions{
+		Size: key.size, DPI: 96, Hinting: font.HintingFull,
+	})
+	if err != nil {
+		return nil, err
+	}
+	r.faces[key] = face
+	return face, err
+}
+
+// parseRenderColor provides a function to parse the hex color code in
+// 'RRGGBB' or 'AARRGGBB' notation, the default color will be returned if the
+// given color code is invalid.
+func parseRenderColor(hexColor string, def color.RGBA) color.RGBA {
+	if clr, ok := layout.ParseColor(hexColor); ok {
+		return clr
+	}
+	return def
+}
+
+// drawRenderLine provides a function to draw the line between two points with
+// the given dash pattern and color on the image.
+func drawRenderLine(img *image.RGBA, x0, y0, x1, y1 int, dash []int, clr color.Color) {
+	dx, dy, sx, sy := x1-x0, y1-y0, 1, 1
+	if dx < 0 {
+		dx, sx = -dx, -1
+	}
+	if dy > 0 {
+		dy = -dy
+	} else {
+		sy = -1
+	}
+	var period int
+	for _, n := range dash {
+		period += n
+	}
+	for i, e := 0, dx+dy; ; i++ {
+		if period == 0 || renderDashOn(dash, i%period) {
+			img.Set(x0, y0, clr)
+		}
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * e
+		if e2 >= dy {
+			e += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			e += dx
+			y0 += sy
+		}
+	}
+}
+
+// renderDashOn provides a function to check if the given position of the
+// line should be drawn by the dash pattern.
+func renderDashOn(dash []int, pos int) bool {
+	for i, n := range dash {
+		if pos < n {
+			return i%2 == 0
+		}
+		pos -= n
+	}
+	return false
+}
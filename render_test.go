@@ -0,0 +1,117 @@
+package excelize
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderRange(t *testing.T) {
+	f := NewFile()
+	for cell, value := range map[string]interface{}{
+		"A1": "Name", "B1": "Amount", "C1": "Paid",
+		"A2": "Apple", "B2": 1234.5, "C2": true,
+		"A3": "Orange with a long wrapped description", "B3": 42, "C3": false,
+		"A5": "Merged",
+	} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+	}
+	header, err := f.NewStyle(&Style{
+		Font:      &Font{Bold: true, Italic: true, Size: 14, Color: "FFFFFF", Underline: "double", Strike: true},
+		Fill:      Fill{Type: "pattern", Pattern: 1, Color: []string{"4472C4"}},
+		Alignment: &Alignment{Horizontal: "center", Vertical: "center"},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "C1", header))
+	var borders []Border
+	for i, typ := range []string{"left", "right", "top", "bottom", "diagonalUp", "diagonalDown"} {
+		borders = append(borders, Border{Type: typ, Color: "FF0000", Style: i*2 + 1})
+	}
+	bordered, err := f.NewStyle(&Style{Border: borders, NumFmt: 4})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B2", "B3", bordered))
+	wrapped, err := f.NewStyle(&Style{Alignment: &Alignment{WrapText: true, Vertical: "top", Indent: 1}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A3", "A3", wrapped))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 3, 45))
+	assert.NoError(t, f.SetColWidth("Sheet1", "D", "D", 20))
+	assert.NoError(t, f.SetColVisible("Sheet1", "D", false))
+	assert.NoError(t, f.SetRowVisible("Sheet1", 4, false))
+	assert.NoError(t, f.MergeCell("Sheet1", "A5", "D6"))
+	right, err := f.NewStyle(&Style{Alignment: &Alignment{Horizontal: "right"}, Fill: Fill{Type: "gradient", Color: []string{"FFFF00", "FF0000"}}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A5", "A5", right))
+
+	img, err := f.RenderRange("Sheet1", "E6:A1")
+	assert.NoError(t, err)
+	colWidth, rowHeight := int(defaultColWidthPixels), int(convertRowHeightToPixels(defaultRowHeight))
+	assert.Equal(t, image.Rect(0, 0, colWidth*4, rowHeight*4+int(convertRowHeightToPixels(45))), img.Bounds())
+	// Test the fill of the header and merged cells
+	assert.Equal(t, color.RGBA{R: 0x44, G: 0x72, B: 0xC4, A: 0xFF}, img.At(colWidth/2, 2))
+	assert.Equal(t, color.RGBA{R: 0xFF, G: 0xFF, A: 0xFF}, img.At(colWidth*2, img.Bounds().Dy()-2))
+	// Test the border and gridlines
+	assert.Equal(t, color.RGBA{R: 0xFF, A: 0xFF}, img.At(colWidth, rowHeight+rowHeight/2))
+	assert.Equal(t, renderGridColor, img.At(colWidth/2, rowHeight*2-1))
+	assert.NoError(t, png.Encode(new(bytes.Buffer), img))
+
+	// Test render range in the right to left worksheet
+	assert.NoError(t, f.SetSheetRightToLeft("Sheet1", true))
+	img, err = f.RenderRange("Sheet1", "A1:E6")
+	assert.NoError(t, err)
+	width := img.Bounds().Dx()
+	assert.Equal(t, color.RGBA{R: 0x44, G: 0x72, B: 0xC4, A: 0xFF}, img.At(width-colWidth/2, 2))
+	assert.Equal(t, color.RGBA{R: 0xFF, A: 0xFF}, img.At(width-colWidth-1, rowHeight+rowHeight/2))
+	assert.Equal(t, renderGridColor, img.At(width-colWidth/2, rowHeight*2-1))
+	assert.NoError(t, f.SetSheetRightToLeft("Sheet1", false))
+
+	// Test render single cell
+	img, err = f.RenderRange("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, colWidth, rowHeight), img.Bounds())
+	// Test render range exceeds the cells limit
+	_, err = f.RenderRange("Sheet1", "A1:XFD1048576")
+	assert.Equal(t, ErrRenderCells, err)
+	// Test render range exceeds the pixels limit
+	assert.NoError(t, f.SetColWidth("Sheet1", "A", "Z", MaxColumnWidth))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 1, MaxRowHeight))
+	_, err = f.RenderRange("Sheet1", "A1:Z100")
+	assert.Equal(t, ErrRenderPixels, err)
+	// Test render range with invalid range reference
+	_, err = f.RenderRange("Sheet1", "A:B")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	// Test render range on not exists worksheet
+	_, err = f.RenderRange("SheetN", "A1:B2")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test render range with invalid merged cell reference
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).MergeCells.Cells[0].Ref = "A"
+	_, err = f.RenderRange("Sheet1", "A1:B2")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	assert.NoError(t, f.Close())
+}
+
+func TestRenderRangeReadOnly(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", "Excelize"))
+	style, err := f.NewStyle(&Style{Fill: Fill{Type: "pattern", Pattern: 1, Color: []string{"FFFF00"}}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetRowStyle("Sheet1", 4, 4, style))
+	assert.NoError(t, f.SetColStyle("Sheet1", "D", style))
+	before, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	img, err := f.RenderRange("Sheet1", "A1:Z2000")
+	assert.NoError(t, err)
+	// Test the row and column styles are rendered
+	colWidth, rowHeight := int(defaultColWidthPixels), int(convertRowHeightToPixels(defaultRowHeight))
+	assert.Equal(t, color.RGBA{R: 0xFF, G: 0xFF, A: 0xFF}, img.At(colWidth/2, rowHeight*3+rowHeight/2))
+	assert.Equal(t, color.RGBA{R: 0xFF, G: 0xFF, A: 0xFF}, img.At(colWidth*3+colWidth/2, rowHeight*10))
+	after, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.Equal(t, before.Bytes(), after.Bytes())
+	assert.NoError(t, f.Close())
+}
@@ -0,0 +1,417 @@
+// Copyright 2016 - 2024 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.18 or later.
+
+package excelize
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// reviewLayerName is the hidden worksheet scoped defined name which
+	// records the cell ranges of the review layer highlights.
+	reviewLayerName = "_ReviewLayer"
+	// reviewNotesName is the hidden worksheet scoped defined name which
+	// records the cells of the review layer notes.
+	reviewNotesName = "_ReviewNotes"
+	// reviewPersonName is the display name of the author of the review layer
+	// notes.
+	reviewPersonName = "Excelize"
+	// threadedCommentPlaceholder is the text of the legacy comment which
+	// represents the threaded comment for the spreadsheet applications that
+	// don't support threaded comments.
+	threadedCommentPlaceholder = "[Threaded comment]\n\nYour version of Excel allows you to read this threaded comment; however, any edits to it will get removed if the file is opened in a newer version of Excel. Learn more: https://go.microsoft.com/fwlink/?linkid=870924\n\nComment:\n    "
+)
+
+// reviewLayerNameExp defined the pattern of the hidden defined names of the
+// review layer, the references exceeding the length limit of the defined name
+// will be recorded in the defined names with the numeric suffix.
+var reviewLayerNameExp = regexp.MustCompile(`^(_ReviewLayer|_ReviewNotes)(\d*)$`)
+
+// HighlightCells provides a function to highlight cells by given worksheet
+// name, range reference, fill color and an optional note. The highlight is
+// applied by a conditional format with a dedicated differential format, so
+// the direct formatting of the cells will not be changed, and the highlights
+// with the same fill color share the differential format. The note will be
+// added as a threaded comment on the top-left cell of the range if it is not
+// empty. The default fill color is yellow "FFFF00". The highlights and notes
+// are recorded as a review layer of the worksheet, which persists in the
+// workbook and could be stripped by the RemoveHighlights function. For
+// example, highlight the cells Sheet1!A2:C5 with orange color and a note:
+//
+//	err := f.HighlightCells("Sheet1", "A2:C5", "FFC000", "Check the amounts")
+func (f *File) HighlightCells(sheet, rangeRef, color, note string) error {
+	sheetID, err := f.GetSheetIndex(sheet)
+	if err != nil {
+		return err
+	}
+	if sheetID == -1 {
+		return ErrSheetNotExist{sheet}
+	}
+	SQRef, cell, err := prepareConditionalFormatRange(rangeRef)
+	if err != nil {
+		return err
+	}
+	if color == "" {
+		color = "FFFF00"
+	}
+	style, err := f.getReviewDxfID(color)
+	if err != nil {
+		return err
+	}
+	if err = f.SetConditionalFormat(sheet, SQRef, []ConditionalFormatOptions{
+		{Type: "formula", Criteria: "TRUE", Format: &style},
+	}); err != nil {
+		return err
+	}
+	f.addReviewLayerRefs(sheetID, reviewLayerName, strings.Split(SQRef, " "))
+	if note == "" {
+		return err
+	}
+	if err = f.addReviewNote(sheet, cell, note); err != nil {
+		return err
+	}
+	f.addReviewLayerRefs(sheetID, reviewNotesName, []string{cell})
+	return err
+}
+
+// RemoveHighlights provides a function to strip the review layer of the
+// worksheet by given worksheet name, which removes the highlights and the
+// notes added by the HighlightCells function. The other conditional formats
+// and comments in the worksheet will be kept. For example, remove the
+// highlights and notes in Sheet1:
+//
+//	err := f.RemoveHighlights("Sheet1")
+func (f *File) RemoveHighlights(sheet string) error {
+	sheetID, err := f.GetSheetIndex(sheet)
+	if err != nil {
+		return err
+	}
+	if sheetID == -1 {
+		return ErrSheetNotExist{sheet}
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if layer := f.getReviewLayerRefs(sheetID, reviewLayerName); len(layer) > 0 {
+		areas := make(map[string]struct{}, len(layer))
+		for _, ref := range layer {
+			areas[ref] = struct{}{}
+		}
+		var conditionalFormatting []*xlsxConditionalFormatting
+		for _, cf := range ws.ConditionalFormatting {
+			if !isReviewHighlight(cf, areas) {
+				conditionalFormatting = append(conditionalFormatting, cf)
+			}
+		}
+		ws.ConditionalFormatting = conditionalFormatting
+	}
+	for _, cell := range f.getReviewLayerRefs(sheetID, reviewNotesName) {
+		if err = f.deleteReviewNote(sheet, cell); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// isReviewHighlight returns whether the conditional format is a highlight of
+// the review layer by given conditional format and the recorded cell ranges.
+func isReviewHighlight(cf *xlsxConditionalFormatting, areas map[string]struct{}) bool {
+	if len(cf.CfRule) != 1 || cf.CfRule[0].Type != "expression" ||
+		len(cf.CfRule[0].Formula) != 1 || cf.CfRule[0].Formula[0] != "TRUE" {
+		return false
+	}
+	for _, ref := range strings.Split(cf.SQRef, " ") {
+		if _, ok := areas[ref]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// getReviewDxfID provides a function to get the differential format ID of the
+// review layer highlight by given fill color, the exist identical
+// differential format will be reused.
+func (f *File) getReviewDxfID(color string) (int, error) {
+	style := &Style{Fill: Fill{Type: "pattern", Color: []string{color}, Pattern: 1}}
+	fs, err := parseFormatStyleSet(style)
+	if err != nil {
+		return 0, err
+	}
+	s, err := f.stylesReader()
+	if err != nil {
+		return 0, err
+	}
+	dxf, _ := xml.Marshal(xlsxDxf{Fill: newFills(fs, false)})
+	if s.Dxfs != nil {
+		for idx, d := range s.Dxfs.Dxfs {
+			if output, _ := xml.Marshal(d); bytes.Equal(output, dxf) {
+				return idx, err
+			}
+		}
+	}
+	return f.NewConditionalStyle(style)
+}
+
+// isReviewLayerName returns whether the defined name is the hidden defined
+// name of the review layer by given defined name, review layer name and
+// sheet index, and returns the numeric suffix of the defined name.
+func isReviewLayerName(dn xlsxDefinedName, name string, sheetID int) (bool, int) {
+	matches := reviewLayerNameExp.FindStringSubmatch(dn.Name)
+	if matches == nil || matches[1] != name || dn.LocalSheetID == nil || *dn.LocalSheetID != sheetID {
+		return false, 0
+	}
+	suffix, _ := strconv.Atoi(matches[2])
+	return true, suffix
+}
+
+// getReviewLayerRefs provides a function to get the recorded cell references
+// of the review layer by given sheet index and hidden defined name, and
+// delete the defined names.
+func (f *File) getReviewLayerRefs(sheetID int, name string) []string {
+	wb, _ := f.workbookReader()
+	if wb.DefinedNames == nil {
+		return nil
+	}
+	var (
+		refs         []string
+		definedNames []xlsxDefinedName
+	)
+	for _, dn := range wb.DefinedNames.DefinedName {
+		if ok, _ := isReviewLayerName(dn, name, sheetID); !ok {
+			definedNames = append(definedNames, dn)
+			continue
+		}
+		for _, ref := range strings.Split(dn.Data, ",") {
+			if i := strings.LastIndex(ref, "!"); i != -1 {
+				refs = append(refs, strings.ReplaceAll(ref[i+1:], "$", ""))
+			}
+		}
+	}
+	if wb.DefinedNames.DefinedName = definedNames; len(definedNames) == 0 {
+		wb.DefinedNames = nil
+	}
+	return refs
+}
+
+// addReviewLayerRefs provides a function to record the cell references into
+// the review layer by given sheet index, hidden defined name and cell
+// references. The references will be recorded in a new defined name with the
+// numeric suffix if the formula of the defined name exceeds the length limit.
+func (f *File) addReviewLayerRefs(sheetID int, name string, refs []string) {
+	wb, _ := f.workbookReader()
+	sheetName := "'" + strings.ReplaceAll(f.GetSheetName(sheetID), "'", "''") + "'!"
+	if wb.DefinedNames == nil {
+		wb.DefinedNames = &xlsxDefinedNames{}
+	}
+	for _, ref := range refs {
+		var cells []string
+		for _, cell := range strings.Split(ref, ":") {
+			col, row, _ := CellNameToCoordinates(cell)
+			cellName, _ := CoordinatesToCellName(col, row, true)
+			cells = append(cells, cellName)
+		}
+		formula := sheetName + strings.Join(cells, ":")
+		last, suffix := -1, 0
+		for idx, dn := range wb.DefinedNames.DefinedName {
+			if ok, n := isReviewLayerName(dn, name, sheetID); ok && n >= suffix {
+				last, suffix = idx, n
+			}
+		}
+		if last != -1 && len(wb.DefinedNames.DefinedName[last].Data)+len(formula) < MaxFieldLength {
+			wb.DefinedNames.DefinedName[last].Data += "," + formula
+			continue
+		}
+		definedName := name
+		if last != -1 {
+			definedName += strconv.Itoa(suffix + 1)
+		}
+		wb.DefinedNames.DefinedName = append(wb.DefinedNames.DefinedName, xlsxDefinedName{
+			Name: definedName, Hidden: true, LocalSheetID: intPtr(sheetID), Data: formula,
+		})
+	}
+}
+
+// addReviewNote provides a function to add the note of the review layer as a
+// threaded comment by given worksheet name, cell reference and note, the
+// legacy comment will be added as the placeholder of the threaded comment.
+func (f *File) addReviewNote(sheet, cell, note string) error {
+	personID, err := f.getReviewPersonID()
+	if err != nil {
+		return err
+	}
+	threadedCommentsXML, err := f.getThreadedCommentsPart(sheet, true)
+	if err != nil {
+		return err
+	}
+	threadedComments, err := f.threadedCommentsReader(threadedCommentsXML)
+	if err != nil {
+		return err
+	}
+	id, err := newGUID()
+	if err != nil {
+		return err
+	}
+	if err = f.AddComment(sheet, Comment{Cell: cell, Author: "tc=" + id, Text: threadedCommentPlaceholder + note}); err != nil {
+		return err
+	}
+	threadedComments.ThreadedComment = append(threadedComments.ThreadedComment, xlsxThreadedComment{
+		Ref: cell, DT: time.Now().UTC().Format("2006-01-02T15:04:05.00"), PersonID: personID, ID: id, Text: note,
+	})
+	output, _ := xml.Marshal(threadedComments)
+	f.saveFileList(threadedCommentsXML, output)
+	return err
+}
+
+// deleteReviewNote provides a function to delete the note of the review
+// layer by given worksheet name and cell reference, the threaded comments
+// part will be deleted if there are no threaded comments left.
+func (f *File) deleteReviewNote(sheet, cell string) error {
+	threadedCommentsXML, err := f.getThreadedCommentsPart(sheet, false)
+	if err != nil {
+		return err
+	}
+	if threadedCommentsXML != "" {
+		threadedComments, err := f.threadedCommentsReader(threadedCommentsXML)
+		if err != nil {
+			return err
+		}
+		var comments []xlsxThreadedComment
+		for _, comment := range threadedComments.ThreadedComment {
+			if comment.Ref != cell {
+				comments = append(comments, comment)
+			}
+		}
+		if len(comments) == 0 {
+			if err = f.DeletePart(threadedCommentsXML); err != nil {
+				return err
+			}
+		} else {
+			threadedComments.ThreadedComment = comments
+			output, _ := xml.Marshal(threadedComments)
+			f.saveFileList(threadedCommentsXML, output)
+		}
+	}
+	return f.DeleteComment(sheet, cell)
+}
+
+// threadedCommentsReader provides a function to get the threaded comments by
+// given threaded comments part path.
+func (f *File) threadedCommentsReader(path string) (*xlsxThreadedComments, error) {
+	var threadedComments xlsxThreadedComments
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(path)))).
+		Decode(&threadedComments); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return &threadedComments, nil
+}
+
+// getThreadedCommentsPart provides a function to get the threaded comments
+// part path of the worksheet by given worksheet name, the threaded comments
+// part will be created if it doesn't exist and the create parameter is true.
+func (f *File) getThreadedCommentsPart(sheet string, create bool) (string, error) {
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	rels, err := f.relsReader(getRelsPath(sheetXMLPath))
+	if err != nil {
+		return "", err
+	}
+	if rels != nil {
+		rels.mu.Lock()
+		for _, rel := range rels.Relationships {
+			if rel.Type == SourceRelationshipThreadedComment {
+				rels.mu.Unlock()
+				return getRelsPartPath(sheetXMLPath, rel.Target), err
+			}
+		}
+		rels.mu.Unlock()
+	}
+	if !create {
+		return "", err
+	}
+	var name string
+	for idx := 1; ; idx++ {
+		name = fmt.Sprintf("xl/threadedComments/threadedComment%d.xml", idx)
+		if _, ok := f.Pkg.Load(name); !ok {
+			break
+		}
+	}
+	return name, f.addReviewPart(sheetXMLPath, SourceRelationshipThreadedComment, name, ContentTypeThreadedComments)
+}
+
+// getReviewPersonID provides a function to get the person ID of the author of
+// the review layer notes, the persons part and the person will be created if
+// they don't exist.
+func (f *File) getReviewPersonID() (string, error) {
+	wbPath := f.getWorkbookPath()
+	rels, err := f.relsReader(f.getWorkbookRelsPath())
+	if err != nil {
+		return "", err
+	}
+	var personsXML string
+	if rels != nil {
+		rels.mu.Lock()
+		for _, rel := range rels.Relationships {
+			if rel.Type == SourceRelationshipPerson {
+				personsXML = getRelsPartPath(wbPath, rel.Target)
+			}
+		}
+		rels.mu.Unlock()
+	}
+	var persons xlsxPersonList
+	if personsXML == "" {
+		personsXML = path.Join(path.Dir(wbPath), "persons/person.xml")
+		if err = f.addReviewPart(wbPath, SourceRelationshipPerson, personsXML, ContentTypePerson); err != nil {
+			return "", err
+		}
+	}
+	if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(personsXML)))).
+		Decode(&persons); err != nil && err != io.EOF {
+		return "", err
+	}
+	for _, person := range persons.Person {
+		if person.DisplayName == reviewPersonName {
+			return person.ID, nil
+		}
+	}
+	id, err := newGUID()
+	if err != nil {
+		return "", err
+	}
+	persons.Person = append(persons.Person, xlsxPerson{
+		DisplayName: reviewPersonName, ID: id, UserID: reviewPersonName, ProviderID: "None",
+	})
+	output, _ := xml.Marshal(persons)
+	f.saveFileList(personsXML, output)
+	return id, err
+}
+
+// addReviewPart provides a function to add the relationship from the source
+// part and the content type of the part by given source part path,
+// relationship type, part path and content type.
+func (f *File) addReviewPart(source, relType, name, contentType string) error {
+	if err := f.addPartRelationship(source, relType, name); err != nil {
+		return err
+	}
+	ct, err := f.contentTypesReader()
+	if err != nil {
+		return err
+	}
+	return ct.setPartContentType(name, contentType, false)
+}
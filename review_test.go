@@ -0,0 +1,167 @@
+package excelize
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHighlightCells(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A10", []ConditionalFormatOptions{
+		{Type: "formula", Criteria: "TRUE"},
+	}))
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "H1", Text: "Excelize"}))
+	assert.NoError(t, f.HighlightCells("Sheet1", "A2:C5", "FFC000", "Check the amounts"))
+	assert.NoError(t, f.HighlightCells("Sheet1", "E1:E2,G3", "", ""))
+	formats, err := f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, formats, 3)
+	assert.Len(t, formats["A2:C5"], 1)
+	assert.Equal(t, "TRUE", formats["A2:C5"][0].Criteria)
+	style, err := f.GetConditionalStyle(*formats["A2:C5"][0].Format)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"FFC000"}, style.Fill.Color)
+	style, err = f.GetConditionalStyle(*formats["E1:E2 G3"][0].Format)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"FFFF00"}, style.Fill.Color)
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 2)
+	assert.Equal(t, "A2", comments[1].Cell)
+	assert.Equal(t, threadedCommentPlaceholder+"Check the amounts", comments[1].Text)
+	assert.True(t, strings.HasPrefix(comments[1].Author, "tc={"))
+	threadedComments, err := f.threadedCommentsReader("xl/threadedComments/threadedComment1.xml")
+	assert.NoError(t, err)
+	assert.Len(t, threadedComments.ThreadedComment, 1)
+	assert.Equal(t, "A2", threadedComments.ThreadedComment[0].Ref)
+	assert.Equal(t, "Check the amounts", threadedComments.ThreadedComment[0].Text)
+	assert.Equal(t, "tc="+threadedComments.ThreadedComment[0].ID, comments[1].Author)
+	personID, err := f.getReviewPersonID()
+	assert.NoError(t, err)
+	assert.Equal(t, personID, threadedComments.ThreadedComment[0].PersonID)
+	assert.Empty(t, f.GetDefinedName()[0].Comment)
+	assert.Equal(t, "'Sheet1'!$A$2:$C$5,'Sheet1'!$E$1:$E$2,'Sheet1'!$G$3", f.GetDefinedName()[0].RefersTo)
+	assert.Equal(t, "'Sheet1'!$A$2", f.GetDefinedName()[1].RefersTo)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestHighlightCells.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test remove the review layer after reopening the workbook
+	f, err = OpenFile(filepath.Join("test", "TestHighlightCells.xlsx"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.RemoveHighlights("Sheet1"))
+	formats, err = f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, formats, 1)
+	assert.Len(t, formats["A1:A10"], 1)
+	comments, err = f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "H1", comments[0].Cell)
+	assert.Empty(t, f.GetDefinedName())
+	_, ok := f.Pkg.Load("xl/threadedComments/threadedComment1.xml")
+	assert.False(t, ok)
+	// Test remove the review layer without highlights
+	assert.NoError(t, f.RemoveHighlights("Sheet1"))
+	assert.NoError(t, f.Close())
+
+	// Test the differential format is reused by the highlights
+	f = NewFile()
+	assert.NoError(t, f.HighlightCells("Sheet1", "A1", "", "Excelize"))
+	styles, err := f.stylesReader()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, styles.Dxfs.Count)
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, f.RemoveHighlights("Sheet1"))
+		assert.NoError(t, f.HighlightCells("Sheet1", "A1", "", "Excelize"))
+		assert.NoError(t, f.HighlightCells("Sheet1", "B1", "", ""))
+	}
+	assert.Equal(t, 1, styles.Dxfs.Count)
+	assert.Len(t, styles.Dxfs.Dxfs, 1)
+	// Test remove one of the notes in the threaded comments part
+	assert.NoError(t, f.HighlightCells("Sheet1", "C1", "", "Excelize"))
+	assert.NoError(t, f.deleteReviewNote("Sheet1", "A1"))
+	threadedComments, err = f.threadedCommentsReader("xl/threadedComments/threadedComment1.xml")
+	assert.NoError(t, err)
+	assert.Len(t, threadedComments.ThreadedComment, 1)
+	assert.Equal(t, "C1", threadedComments.ThreadedComment[0].Ref)
+	assert.NoError(t, f.Close())
+
+	// Test highlight cells exceeds the length limit of the defined name
+	f = NewFile()
+	for row := 1; row <= 100; row++ {
+		assert.NoError(t, f.HighlightCells("Sheet1", fmt.Sprintf("A%d:B%d", row, row), "", ""))
+	}
+	definedNames := f.GetDefinedName()
+	assert.Greater(t, len(definedNames), 1)
+	for idx, dn := range definedNames {
+		assert.LessOrEqual(t, len(dn.RefersTo), MaxFieldLength)
+		if idx > 0 {
+			assert.Equal(t, fmt.Sprintf("%s%d", reviewLayerName, idx), dn.Name)
+		}
+	}
+	assert.NoError(t, f.RemoveHighlights("Sheet1"))
+	formats, err = f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, formats)
+	assert.Empty(t, f.GetDefinedName())
+	assert.NoError(t, f.Close())
+
+	f = NewFile()
+	// Test highlight cells with invalid sheet name
+	assert.EqualError(t, f.HighlightCells("Sheet:1", "A1", "", ""), ErrSheetNameInvalid.Error())
+	assert.EqualError(t, f.RemoveHighlights("Sheet:1"), ErrSheetNameInvalid.Error())
+	// Test highlight cells on not exists worksheet
+	assert.EqualError(t, f.HighlightCells("SheetN", "A1", "", ""), "sheet SheetN does not exist")
+	assert.EqualError(t, f.RemoveHighlights("SheetN"), "sheet SheetN does not exist")
+	// Test highlight cells with invalid range reference
+	assert.Equal(t, ErrParameterRequired, f.HighlightCells("Sheet1", "", "", ""))
+	assert.Equal(t, ErrParameterInvalid, f.HighlightCells("Sheet1", "A1:B2:C3", "", ""))
+	// Test remove highlights with unsupported charset worksheet
+	assert.NoError(t, f.HighlightCells("Sheet1", "A1", "", "Excelize"))
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.RemoveHighlights("Sheet1"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+
+	// Test highlight cells with unsupported charset styles
+	f = NewFile()
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.HighlightCells("Sheet1", "A1", "", ""), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+
+	// Test highlight cells with unsupported charset workbook relationships
+	f = NewFile()
+	f.Relationships.Delete(defaultXMLPathWorkbookRels)
+	f.Pkg.Store(defaultXMLPathWorkbookRels, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.HighlightCells("Sheet1", "A1", "", "Excelize"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+
+	// Test highlight cells with unsupported charset persons
+	f = NewFile()
+	assert.NoError(t, f.HighlightCells("Sheet1", "A1", "", "Excelize"))
+	f.Pkg.Store("xl/persons/person.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.HighlightCells("Sheet1", "A2", "", "Excelize"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+
+	// Test highlight cells with unsupported charset worksheet relationships
+	f = NewFile()
+	assert.NoError(t, f.HighlightCells("Sheet1", "A1", "", "Excelize"))
+	f.Relationships.Delete("xl/worksheets/_rels/sheet1.xml.rels")
+	f.Pkg.Store("xl/worksheets/_rels/sheet1.xml.rels", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.HighlightCells("Sheet1", "A2", "", "Excelize"), "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.RemoveHighlights("Sheet1"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+
+	// Test highlight cells with unsupported charset threaded comments
+	f = NewFile()
+	assert.NoError(t, f.HighlightCells("Sheet1", "A1", "", "Excelize"))
+	f.Pkg.Store("xl/threadedComments/threadedComment1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.HighlightCells("Sheet1", "A2", "", "Excelize"), "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.RemoveHighlights("Sheet1"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
@@ -58,13 +58,35 @@ var duplicateHelperFunc = [3]func(*File, *xlsxWorksheet, string, int, int) error
 //	    }
 //	    fmt.Println()
 //	}
+//
+// The options can be specified per call, for example, get the raw value of the
+// cells in columns B to D of the first 100 visible rows:
+//
+//	rows, err := f.GetRows("Sheet1", excelize.Options{
+//	    RawCellValue:   true,
+//	    SkipHiddenRows: true,
+//	    MaxRows:        100,
+//	    ColumnRange:    "B:D",
+//	})
 func (f *File) GetRows(sheet string, opts ...Options) ([][]string, error) {
+	rowsOpts := getRowsOptions(opts...)
+	if rowsOpts.ColumnRange != "" {
+		if _, _, err := f.parseColRange(rowsOpts.ColumnRange); err != nil {
+			return nil, err
+		}
+	}
 	rows, err := f.Rows(sheet)
 	if err != nil {
 		return nil, err
 	}
 	results, cur, maxVal := make([][]string, 0, 64), 0, 0
 	for rows.Next() {
+		if rowsOpts.MaxRows > 0 && cur >= rowsOpts.MaxRows {
+			break
+		}
+		if rowsOpts.SkipHiddenRows && rows.curRow == rows.seekRow && rows.GetRowOpts().Hidden {
+			continue
+		}
 		cur++
 		row, err := rows.Columns(opts...)
 		if err != nil {
@@ -81,22 +103,43 @@ func (f *File) GetRows(sheet string, opts ...Options) ([][]string, error) {
 	return results[:maxVal], rows.Close()
 }
 
+// getRowsOptions provides a function to get the options which only take
+// effect on reading rows, such as SkipHiddenRows, MaxRows and ColumnRange.
+// These options should be specified per call, and the options of the
+// workbook will be ignored.
+func getRowsOptions(opts ...Options) Options {
+	var options Options
+	for _, opt := range opts {
+		options = opt
+	}
+	return options
+}
+
 // Rows defines an iterator to a sheet.
 type Rows struct {
 	err                     error
 	curRow, seekRow         int
 	needClose, rawCellValue bool
-	sheet                   string
+	sheet, sheetName        string
 	f                       *File
+	options                 *Options
 	tempFile                *os.File
 	sst                     *xlsxSST
 	decoder                 *xml.Decoder
 	token                   xml.Token
 	curRowOpts, seekRowOpts RowOpts
+	reverse                 bool
+	src                     io.ReaderAt
+	offset, end             int64
+	rowTag                  []byte
+	rowNums                 map[int64]int
 }
 
 // Next will return true if it finds the next row element.
 func (rows *Rows) Next() bool {
+	if rows.reverse {
+		return rows.prev()
+	}
 	rows.seekRow++
 	if rows.curRow >= rows.seekRow {
 		rows.curRowOpts = rows.seekRowOpts
@@ -126,6 +169,250 @@ func (rows *Rows) Next() bool {
 	}
 }
 
+// prev provides a function to move the reverse rows iterator to the previous
+// row, and it will return true if the row exists.
+func (rows *Rows) prev() bool {
+	if rows.seekRow == 0 {
+		if !rows.locatePrevRow() {
+			return false
+		}
+		rows.seekRow, rows.curRowOpts = rows.curRow, rows.seekRowOpts
+		return true
+	}
+	if rows.curRow == rows.seekRow && !rows.locatePrevRow() {
+		rows.curRow = 0
+	}
+	if rows.curRow >= rows.seekRow {
+		rows.seekRow = rows.curRow + 1
+	}
+	if rows.seekRow--; rows.seekRow < 1 {
+		return false
+	}
+	rows.curRowOpts = RowOpts{Height: defaultRowHeight}
+	if rows.curRow == rows.seekRow {
+		rows.curRowOpts = rows.seekRowOpts
+	}
+	return true
+}
+
+// locatePrevRow provides a function to locate the previous row element before
+// the offset of the reverse rows iterator, and it will return true if the row
+// element exists.
+func (rows *Rows) locatePrevRow() bool {
+	if rows.offset <= 0 {
+		return false
+	}
+	start, err := lastIndexAt(rows.src, rows.offset, rows.rowTag, func(next byte) bool {
+		return next == ' ' || next == '>' || next == '/' || next == '\t' || next == '\r' || next == '\n'
+	})
+	if err != nil {
+		rows.err = err
+	}
+	if start == -1 {
+		rows.offset = 0
+		return false
+	}
+	rows.decoder = rows.f.xmlNewDecoder(io.NewSectionReader(rows.src, start, rows.offset-start))
+	token, _ := rows.decoder.Token()
+	xmlElement, ok := token.(xml.StartElement)
+	if !ok {
+		rows.offset = 0
+		return false
+	}
+	rowNum, _ := attrValToInt("r", xmlElement.Attr)
+	if rowNum == 0 {
+		if rows.rowNums == nil {
+			if rows.err = rows.indexRows(); rows.err != nil {
+				rows.offset = 0
+				return false
+			}
+		}
+		rowNum = rows.rowNums[start]
+	}
+	rows.curRow, rows.token, rows.offset = rowNum, token, start
+	rows.seekRowOpts = extractRowOpts(xmlElement.Attr)
+	return true
+}
+
+// indexRows provides a function to build the index of the row numbers by the
+// offsets of the row elements without row number attribute for the reverse
+// rows iterator, which scans the worksheet only once.
+func (rows *Rows) indexRows() error {
+	var row int
+	rows.rowNums = make(map[int64]int)
+	decoder := rows.f.xmlNewDecoder(io.NewSectionReader(rows.src, 0, rows.end))
+	for {
+		offset := decoder.InputOffset()
+		token, err := decoder.Token()
+		if err == io.EOF || (err != nil && decoder.InputOffset() >= rows.end) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if xmlElement, ok := token.(xml.StartElement); ok && xmlElement.Name.Local == "row" {
+			row++
+			if rowNum, _ := attrValToInt("r", xmlElement.Attr); rowNum != 0 {
+				row = rowNum
+			} else {
+				rows.rowNums[offset] = row
+			}
+			if err = decoder.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// locateSheetDataEnd provides a function to locate the end element of the
+// sheet data for the reverse rows iterator by given size of the worksheet, and
+// get the row element tag with the same namespace prefix of the sheet data.
+func (rows *Rows) locateSheetDataEnd(size int64) error {
+	rows.rowTag = []byte("<row")
+	end, err := lastIndexAt(rows.src, size, []byte("sheetData>"), nil)
+	if end == -1 || err != nil {
+		return err
+	}
+	lo := end - 64
+	if lo < 0 {
+		lo = 0
+	}
+	buf := make([]byte, end-lo)
+	if _, err = rows.src.ReadAt(buf, lo); err != nil {
+		return err
+	}
+	idx := bytes.LastIndex(buf, []byte("</"))
+	if idx == -1 {
+		return err
+	}
+	prefix := buf[idx+2:]
+	if bytes.ContainsAny(prefix, " \t\r\n<>/\"'") || (len(prefix) > 0 && prefix[len(prefix)-1] != ':') {
+		return err
+	}
+	rows.end, rows.rowTag = lo+int64(idx), append(append([]byte("<"), prefix...), "row"...)
+	return err
+}
+
+// lastIndexAt provides a function to find the offset of the last instance of
+// the separator before the end offset in the given reader, and the byte after
+// the separator must be valid by the given function if it is not nil. It
+// returns -1 if the separator is not present.
+func lastIndexAt(r io.ReaderAt, end int64, sep []byte, valid func(next byte) bool) (int64, error) {
+	buf := make([]byte, StreamChunkSize/16)
+	for end > 0 {
+		lo := end - int64(len(buf))
+		if lo < 0 {
+			lo = 0
+		}
+		chunk := buf[:end-lo]
+		if n, err := r.ReadAt(chunk, lo); err != nil && !(err == io.EOF && n == len(chunk)) {
+			return -1, err
+		}
+		for limit := len(chunk); limit > 0; {
+			idx := bytes.LastIndex(chunk[:limit], sep)
+			if idx == -1 {
+				break
+			}
+			if valid == nil || (idx+len(sep) < len(chunk) && valid(chunk[idx+len(sep)])) {
+				return lo + int64(idx), nil
+			}
+			limit = idx
+		}
+		if lo == 0 {
+			break
+		}
+		end = lo + int64(len(sep))
+	}
+	return -1, nil
+}
+
+// Seek moves the rows iterator to the given row number, and the next call of
+// the 'Next' function will move to that row. This function allows skipping
+// the rows without parsing the cells of them, for example, get the cell
+// values of the rows start from the row 1000000:
+//
+//	if err = rows.Seek(1000000); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for rows.Next() {
+//	    row, err := rows.Columns()
+//	    if err != nil {
+//	        fmt.Println(err)
+//	    }
+//	    fmt.Println(rows.CurrentRow(), row)
+//	}
+//
+// For the reverse rows iterator, the next call of the 'Next' function will
+// move to the given row, and then move to the previous rows.
+func (rows *Rows) Seek(row int) error {
+	if row < 1 || row > TotalRows {
+		return newInvalidRowNumberError(row)
+	}
+	if rows.reverse {
+		return rows.seekReverse(row)
+	}
+	if row <= rows.seekRow {
+		if err := rows.Close(); err != nil {
+			return err
+		}
+		needClose, decoder, tempFile, err := rows.f.xmlDecoder(rows.sheet)
+		if err != nil {
+			return err
+		}
+		rows.needClose, rows.decoder, rows.tempFile = needClose, decoder, tempFile
+		rows.curRow, rows.seekRow, rows.token = 0, 0, nil
+	}
+	for rows.curRow < row {
+		token, _ := rows.decoder.Token()
+		if token == nil {
+			break
+		}
+		if xmlElement, ok := token.(xml.EndElement); ok && xmlElement.Name.Local == "sheetData" {
+			break
+		}
+		xmlElement, ok := token.(xml.StartElement)
+		if !ok || xmlElement.Name.Local != "row" {
+			continue
+		}
+		rows.curRow++
+		if rowNum, _ := attrValToInt("r", xmlElement.Attr); rowNum != 0 {
+			rows.curRow = rowNum
+		}
+		if rows.curRow >= row {
+			rows.token, rows.seekRowOpts = token, extractRowOpts(xmlElement.Attr)
+			break
+		}
+		if err := rows.decoder.Skip(); err != nil {
+			return err
+		}
+	}
+	rows.seekRow = row - 1
+	return nil
+}
+
+// seekReverse provides a function to move the reverse rows iterator to the
+// given row number.
+func (rows *Rows) seekReverse(row int) error {
+	rows.offset, rows.curRow, rows.token = rows.end, 0, nil
+	for {
+		if !rows.locatePrevRow() {
+			rows.curRow = 0
+			break
+		}
+		if rows.curRow <= row {
+			break
+		}
+	}
+	rows.seekRow = row + 1
+	return rows.err
+}
+
+// CurrentRow returns the row number of the current row of the rows iterator.
+func (rows *Rows) CurrentRow() int {
+	return rows.seekRow
+}
+
 // GetRowOpts will return the RowOpts of the current row.
 func (rows *Rows) GetRowOpts() RowOpts {
 	return rows.curRowOpts
@@ -149,12 +436,18 @@ func (rows *Rows) Close() error {
 // data as a stream, returns each cell in a row as is, and will not skip empty
 // rows in the tail of the worksheet.
 func (rows *Rows) Columns(opts ...Options) ([]string, error) {
-	if rows.curRow > rows.seekRow {
+	if rows.curRow > rows.seekRow || (rows.reverse && rows.curRow != rows.seekRow) {
 		return nil, nil
 	}
 	var rowIterator rowXMLIterator
 	var token xml.Token
-	rows.rawCellValue = rows.f.getOptions(opts...).RawCellValue
+	options := rows.f.getOptions(opts...)
+	rows.rawCellValue, rows.options = options.RawCellValue, options
+	if colRange := getRowsOptions(opts...).ColumnRange; colRange != "" {
+		if rowIterator.minCol, rowIterator.maxCol, rowIterator.err = rows.f.parseColRange(colRange); rowIterator.err != nil {
+			return rowIterator.cells, rowIterator.err
+		}
+	}
 	if rows.sst, rowIterator.err = rows.f.sharedStringsReader(); rowIterator.err != nil {
 		return rowIterator.cells, rowIterator.err
 	}
@@ -223,6 +516,7 @@ type rowXMLIterator struct {
 	err              error
 	inElement        string
 	cellCol, cellRow int
+	minCol, maxCol   int
 	cells            []string
 }
 
@@ -230,15 +524,35 @@ type rowXMLIterator struct {
 func (rows *Rows) rowXMLHandler(rowIterator *rowXMLIterator, xmlElement *xml.StartElement, raw bool) {
 	if rowIterator.inElement == "c" {
 		rowIterator.cellCol++
+		for _, attr := range xmlElement.Attr {
+			if attr.Name.Local == "r" && attr.Value != "" {
+				if rowIterator.cellCol, _, rowIterator.err = CellNameToCoordinates(attr.Value); rowIterator.err != nil {
+					return
+				}
+			}
+		}
+		blank := rowIterator.cellCol - len(rowIterator.cells)
+		if rowIterator.maxCol > 0 {
+			if rowIterator.cellCol < rowIterator.minCol || rowIterator.cellCol > rowIterator.maxCol {
+				rowIterator.err = rows.decoder.Skip()
+				return
+			}
+			blank -= rowIterator.minCol - 1
+		}
 		colCell := xlsxC{}
 		_ = rows.decoder.DecodeElement(&colCell, xmlElement)
-		if colCell.R != "" {
-			if rowIterator.cellCol, _, rowIterator.err = CellNameToCoordinates(colCell.R); rowIterator.err != nil {
+		cached := colCell.V
+		val, _ := colCell.getValueFrom(rows.f, rows.sst, raw)
+		if colCell.F != nil {
+			colCell.V = cached
+			if colCell.R, rowIterator.err = CoordinatesToCellName(rowIterator.cellCol, rows.curRow); rowIterator.err != nil {
+				return
+			}
+			if val, rowIterator.err = rows.f.getFormulaValue(rows.sheetName, &colCell, val, rows.options); rowIterator.err != nil {
 				return
 			}
 		}
-		blank := rowIterator.cellCol - len(rowIterator.cells)
-		if val, _ := colCell.getValueFrom(rows.f, rows.sst, raw); val != "" || colCell.F != nil {
+		if val != "" || colCell.F != nil {
 			rowIterator.cells = append(appendSpace(blank, rowIterator.cells), val)
 		}
 	}
@@ -283,11 +597,170 @@ func (f *File) Rows(sheet string) (*Rows, error) {
 		f.saveFileList(name, f.replaceNameSpaceBytes(name, output))
 	}
 	var err error
-	rows := Rows{f: f, sheet: name}
+	rows := Rows{f: f, sheet: name, sheetName: sheet}
 	rows.needClose, rows.decoder, rows.tempFile, err = f.xmlDecoder(name)
 	return &rows, err
 }
 
+// ReverseRows returns a reverse rows iterator, used for streaming reading data
+// for a worksheet from the last row to the first row, which allows getting the
+// last rows of a worksheet with a large data without parsing the earlier rows.
+// This function is concurrency safe. For example, get the last 10 rows of the
+// worksheet:
+//
+//	rows, err := f.ReverseRows("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for i := 0; i < 10 && rows.Next(); i++ {
+//	    row, err := rows.Columns()
+//	    if err != nil {
+//	        fmt.Println(err)
+//	    }
+//	    fmt.Println(rows.CurrentRow(), row)
+//	}
+//	if err = rows.Close(); err != nil {
+//	    fmt.Println(err)
+//	}
+func (f *File) ReverseRows(sheet string) (*Rows, error) {
+	rows, err := f.Rows(sheet)
+	if err != nil {
+		return rows, err
+	}
+	content := f.readXML(rows.sheet)
+	rows.reverse, rows.src = true, bytes.NewReader(content)
+	size := int64(len(content))
+	if rows.tempFile != nil {
+		fi, err := rows.tempFile.Stat()
+		if err != nil {
+			return rows, err
+		}
+		rows.src, size = rows.tempFile, fi.Size()
+	}
+	err = rows.locateSheetDataEnd(size)
+	rows.offset = rows.end
+	return rows, err
+}
+
+// Cells defines an iterator to the non-empty cells of a worksheet.
+type Cells struct {
+	err          error
+	col, row     int
+	rawCellValue bool
+	value        string
+	f            *File
+	options      *Options
+	sst          *xlsxSST
+	rows         *Rows
+}
+
+// Cells returns a cells iterator, used for streaming reading the non-empty
+// cells of a worksheet in document order, which avoids expanding the empty
+// rows and columns for a sparse worksheet. The cells which have value or
+// formula will be returned. This function is concurrency safe. For example:
+//
+//	cells, err := f.Cells("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for cells.Next() {
+//	    col, row := cells.Coordinates()
+//	    fmt.Println(col, row, cells.Value())
+//	}
+//	if err = cells.Error(); err != nil {
+//	    fmt.Println(err)
+//	}
+//	if err = cells.Close(); err != nil {
+//	    fmt.Println(err)
+//	}
+func (f *File) Cells(sheet string, opts ...Options) (*Cells, error) {
+	rows, err := f.Rows(sheet)
+	if err != nil {
+		return nil, err
+	}
+	options := f.getOptions(opts...)
+	cells := Cells{f: f, rows: rows, rawCellValue: options.RawCellValue, options: options}
+	cells.sst, err = f.sharedStringsReader()
+	return &cells, err
+}
+
+// Next will return true if it finds the next non-empty cell.
+func (cells *Cells) Next() bool {
+	if cells.err != nil {
+		return false
+	}
+	for {
+		token, _ := cells.rows.decoder.Token()
+		if token == nil {
+			return false
+		}
+		switch xmlElement := token.(type) {
+		case xml.StartElement:
+			switch xmlElement.Name.Local {
+			case "row":
+				cells.row++
+				if rowNum, _ := attrValToInt("r", xmlElement.Attr); rowNum != 0 {
+					cells.row = rowNum
+				}
+				cells.col = 0
+			case "c":
+				cells.col++
+				colCell := xlsxC{}
+				if cells.err = cells.rows.decoder.DecodeElement(&colCell, &xmlElement); cells.err != nil {
+					return false
+				}
+				if colCell.R != "" {
+					if cells.col, _, cells.err = CellNameToCoordinates(colCell.R); cells.err != nil {
+						return false
+					}
+				}
+				cached := colCell.V
+				val, _ := colCell.getValueFrom(cells.f, cells.sst, cells.rawCellValue)
+				if colCell.F != nil {
+					colCell.V = cached
+					if colCell.R, cells.err = CoordinatesToCellName(cells.col, cells.row); cells.err != nil {
+						return false
+					}
+					if val, cells.err = cells.f.getFormulaValue(cells.rows.sheetName, &colCell, val, cells.options); cells.err != nil {
+						return false
+					}
+				}
+				if val != "" || colCell.F != nil {
+					cells.value = val
+					return true
+				}
+			}
+		case xml.EndElement:
+			if xmlElement.Name.Local == "sheetData" {
+				return false
+			}
+		}
+	}
+}
+
+// Coordinates returns the column and row number of the current cell.
+func (cells *Cells) Coordinates() (int, int) {
+	return cells.col, cells.row
+}
+
+// Value returns the value of the current cell.
+func (cells *Cells) Value() string {
+	return cells.value
+}
+
+// Error will return the error when the error occurs.
+func (cells *Cells) Error() error {
+	return cells.err
+}
+
+// Close closes the open worksheet XML file in the system temporary
+// directory.
+func (cells *Cells) Close() error {
+	return cells.rows.Close()
+}
+
 // getFromStringItem build shared string item offset list from system temporary
 // file at one time, and return value by given to string index.
 func (f *File) getFromStringItem(index int) string {
@@ -497,6 +970,73 @@ func (f *File) SetRowVisible(sheet string, row int, visible bool) error {
 	return nil
 }
 
+// SetRowsVisible provides a function to set visible of multiple ranges of rows
+// by given worksheet name, row ranges and visibility in one pass. The row
+// ranges will be validated before updating any rows. For example, hide rows 2
+// to 100 and rows 200 to 300 in Sheet1:
+//
+//	err := f.SetRowsVisible("Sheet1", []excelize.RowRange{
+//	    {Start: 2, End: 100}, {Start: 200, End: 300},
+//	}, false)
+func (f *File) SetRowsVisible(sheet string, ranges []RowRange, visible bool) error {
+	var maxRow int
+	rowRanges := make([]RowRange, len(ranges))
+	for i, rng := range ranges {
+		if rng.Start > rng.End {
+			rng.Start, rng.End = rng.End, rng.Start
+		}
+		if rng.Start < 1 {
+			return newInvalidRowNumberError(rng.Start)
+		}
+		if rng.End > TotalRows {
+			return ErrMaxRows
+		}
+		if rng.End > maxRow {
+			maxRow = rng.End
+		}
+		rowRanges[i] = rng
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || maxRow == 0 {
+		return err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if visible && maxRow > len(ws.SheetData.Row) {
+		maxRow = len(ws.SheetData.Row)
+	}
+	if maxRow == 0 {
+		return err
+	}
+	ws.prepareSheetXML(0, maxRow)
+	for _, rng := range rowRanges {
+		for row := rng.Start; row <= rng.End && row <= maxRow; row++ {
+			ws.SheetData.Row[row-1].Hidden = !visible
+		}
+	}
+	return err
+}
+
+// GetHiddenRows provides a function to get the Excel row number of all hidden
+// rows by given worksheet name. For example, get hidden rows in Sheet1:
+//
+//	rows, err := f.GetHiddenRows("Sheet1")
+func (f *File) GetHiddenRows(sheet string) ([]int, error) {
+	var rows []int
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return rows, err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	for _, row := range ws.SheetData.Row {
+		if row.Hidden {
+			rows = append(rows, row.R)
+		}
+	}
+	return rows, err
+}
+
 // GetRowVisible provides a function to get visible of a single row by given
 // worksheet name and Excel row number. For example, get visible state of row
 // 2 in Sheet1:
@@ -567,27 +1107,86 @@ func (f *File) GetRowOutlineLevel(sheet string, row int) (uint8, error) {
 // worksheet, it will cause a file error when you open it. The excelize only
 // partially updates these references currently.
 func (f *File) RemoveRow(sheet string, row int) error {
+	return f.RemoveRows(sheet, row, 1)
+}
+
+// RemoveRows provides a function to remove the given number of contiguous
+// rows start from the given Excel row number in one pass. For example, remove
+// 3 rows start from row 2 (row 2, 3 and 4) in Sheet1:
+//
+//	err := f.RemoveRows("Sheet1", 2, 3)
+//
+// Use this method with caution, which will affect changes in references such
+// as formulas, charts, and so on. If there is any referenced value of the
+// worksheet, it will cause a file error when you open it. The excelize only
+// partially updates these references currently.
+func (f *File) RemoveRows(sheet string, row, n int) error {
 	if row < 1 {
 		return newInvalidRowNumberError(row)
 	}
+	if row > TotalRows || n > TotalRows {
+		return ErrMaxRows
+	}
+	if n < 1 {
+		return ErrParameterInvalid
+	}
+	return f.removeRows(sheet, [][2]int{{row, n}})
+}
+
+// RemoveRowSet provides a function to remove the set of rows by given
+// worksheet name and the Excel row numbers, the row numbers don't need to be
+// contiguous or sorted, and the duplicate row numbers will be ignored. All row
+// numbers will be validated before removing, so either all of the rows will be
+// removed or none of them. For example, remove row 2, 5 and 6 in Sheet1:
+//
+//	err := f.RemoveRowSet("Sheet1", []int{2, 5, 6})
+//
+// Use this method with caution, which will affect changes in references such
+// as formulas, charts, and so on. If there is any referenced value of the
+// worksheet, it will cause a file error when you open it. The excelize only
+// partially updates these references currently.
+func (f *File) RemoveRowSet(sheet string, rowNums []int) error {
+	if len(rowNums) == 0 {
+		return ErrParameterInvalid
+	}
+	nums := make([]int, 0, len(rowNums))
+	for _, row := range rowNums {
+		if row < 1 {
+			return newInvalidRowNumberError(row)
+		}
+		if row > TotalRows {
+			return ErrMaxRows
+		}
+		nums = append(nums, row)
+	}
+	return f.removeRows(sheet, contiguousRuns(nums))
+}
 
+// removeRows provides a function to remove the rows by given worksheet name
+// and the sorted runs of the contiguous rows. The cells of all rows will be
+// removed in one pass, and the references will be adjusted from the last run
+// to the first run, so that the row numbers of the previous runs are not
+// affected by the adjustment.
+func (f *File) removeRows(sheet string, runs [][2]int) error {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
 	}
-	if row > len(ws.SheetData.Row) {
-		return f.adjustHelper(sheet, rows, row, -1)
-	}
 	keep := 0
 	for rowIdx := 0; rowIdx < len(ws.SheetData.Row); rowIdx++ {
 		v := &ws.SheetData.Row[rowIdx]
-		if v.R != row {
+		if !inRuns(runs, v.R) {
 			ws.SheetData.Row[keep] = *v
 			keep++
 		}
 	}
 	ws.SheetData.Row = ws.SheetData.Row[:keep]
-	return f.adjustHelper(sheet, rows, row, -1)
+	for i := len(runs) - 1; i >= 0; i-- {
+		if err = f.adjustHelper(sheet, rows, runs[i][0], -runs[i][1]); err != nil {
+			return err
+		}
+	}
+	return err
 }
 
 // InsertRows provides a function to insert new rows after the given Excel row
@@ -613,6 +1212,135 @@ func (f *File) InsertRows(sheet string, row, n int) error {
 	return f.adjustHelper(sheet, rows, row, n)
 }
 
+// AppendRows provides a function to append rows of values after the last used
+// row of the worksheet. If a table spans the last used row, the new rows will
+// be written from the first column of the table, inserted before the totals
+// row if the table has one, and the table range will be expanded to include
+// the appended rows. The cell styles of the previous data row will be copied
+// to the appended rows. For example, append two rows in Sheet1:
+//
+//	err := f.AppendRows("Sheet1", [][]interface{}{
+//	    {"Apple", 12, 3.5},
+//	    {"Orange", 8, 2.25},
+//	})
+func (f *File) AppendRows(sheet string, values [][]interface{}) error {
+	if len(values) == 0 {
+		return nil
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	ws.mu.Lock()
+	var lastRow int
+	for _, row := range ws.SheetData.Row {
+		for _, c := range row.C {
+			if row.R > lastRow && (c.V != "" || c.F != nil || c.IS != nil) {
+				lastRow = row.R
+				break
+			}
+		}
+	}
+	ws.mu.Unlock()
+	if lastRow+len(values) > TotalRows {
+		return ErrMaxRows
+	}
+	tables, err := f.GetTables(sheet)
+	if err != nil {
+		return err
+	}
+	startCol, startRow, firstDataRow := 1, lastRow+1, 1
+	for _, table := range tables {
+		content, ok := f.Pkg.Load(table.tableXML)
+		if !ok {
+			continue
+		}
+		var t xlsxTable
+		if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content.([]byte)))).
+			Decode(&t); err != nil && err != io.EOF {
+			return err
+		}
+		coordinates, err := rangeRefToCoordinates(t.Ref)
+		if err != nil {
+			return err
+		}
+		_ = sortCoordinates(coordinates)
+		if lastRow < coordinates[1] || lastRow > coordinates[3] {
+			continue
+		}
+		startCol, firstDataRow = coordinates[0], coordinates[1]
+		if t.HeaderRowCount == nil || *t.HeaderRowCount > 0 {
+			firstDataRow++
+		}
+		if totalsRowCount := t.TotalsRowCount; totalsRowCount > 0 {
+			startRow = coordinates[3] - totalsRowCount + 1
+			if err = f.InsertRows(sheet, startRow, len(values)); err != nil {
+				return err
+			}
+			// The totals row count was reset on adjusting the table
+			// range, restore it for the moved totals row.
+			if content, ok = f.Pkg.Load(table.tableXML); ok {
+				t = xlsxTable{}
+				if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content.([]byte)))).
+					Decode(&t); err != nil && err != io.EOF {
+					return err
+				}
+			}
+			t.TotalsRowCount = totalsRowCount
+		} else if coordinates[3] < startRow+len(values)-1 {
+			coordinates[3] = startRow + len(values) - 1
+			t.Ref, _ = coordinatesToRangeRef(coordinates)
+			if t.AutoFilter != nil {
+				t.AutoFilter.Ref = t.Ref
+			}
+		}
+		output, _ := xml.Marshal(t)
+		f.saveFileList(table.tableXML, output)
+		break
+	}
+	for i := range values {
+		cell, err := CoordinatesToCellName(startCol, startRow+i)
+		if err != nil {
+			return err
+		}
+		if err = f.SetSheetRow(sheet, cell, &values[i]); err != nil {
+			return err
+		}
+	}
+	if prevRow := startRow - 1; prevRow >= firstDataRow {
+		f.copyRowCellStyles(ws, prevRow, startRow, len(values))
+	}
+	return nil
+}
+
+// copyRowCellStyles provides a function to copy the cell styles of the given
+// row to the n rows starting from the given target row, the cells which
+// already have styles will be kept.
+func (f *File) copyRowCellStyles(ws *xlsxWorksheet, row, target, n int) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	styles := map[int]int{}
+	for _, r := range ws.SheetData.Row {
+		if r.R != row {
+			continue
+		}
+		for _, c := range r.C {
+			if col, _, err := CellNameToCoordinates(c.R); err == nil && c.S != 0 {
+				styles[col] = c.S
+			}
+		}
+		break
+	}
+	for col, styleID := range styles {
+		for r := target; r < target+n; r++ {
+			ws.prepareSheetXML(col, r)
+			if c := &ws.SheetData.Row[r-1].C[col-1]; c.S == 0 {
+				c.S = styleID
+			}
+		}
+	}
+}
+
 // DuplicateRow inserts a copy of specified row (by its Excel row number) below
 //
 //	err := f.DuplicateRow("Sheet1", 2)
@@ -681,7 +1409,7 @@ func (f *File) DuplicateRowTo(sheet string, row, row2 int) error {
 	if idx2 != -1 {
 		ws.SheetData.Row[idx2] = rowCopy
 	} else {
-		ws.SheetData.Row = append(ws.SheetData.Row, rowCopy)
+		ws.SheetData.Row, ws.rowIndex = append(ws.SheetData.Row, rowCopy), nil
 	}
 	for _, fn := range duplicateHelperFunc {
 		if err := fn(f, ws, sheet, row, row2); err != nil {
@@ -5,6 +5,8 @@ import (
 	"encoding/xml"
 	"fmt"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -21,6 +23,87 @@ func TestGetRows(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestGetRowsWithOptions(t *testing.T) {
+	f := NewFile()
+	for r, row := range [][]interface{}{
+		{"A1", "B1", 1.5, "D1"},
+		{"A2", "B2", 2.5},
+		nil,
+		{"A4", nil, 4.5, "D4", "E4"},
+		{"A5", "B5"},
+	} {
+		if row != nil {
+			assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", r+1), &row))
+		}
+	}
+	style, err := f.NewStyle(&Style{NumFmt: 2})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "C1", "C4", style))
+	assert.NoError(t, f.SetRowVisible("Sheet1", 2, false))
+	assert.NoError(t, f.SetRowVisible("Sheet1", 3, false))
+	for _, c := range []struct {
+		opts     Options
+		expected [][]string
+	}{
+		{Options{}, [][]string{{"A1", "B1", "1.50", "D1"}, {"A2", "B2", "2.50"}, nil, {"A4", "", "4.50", "D4", "E4"}, {"A5", "B5"}}},
+		{Options{RawCellValue: true}, [][]string{{"A1", "B1", "1.5", "D1"}, {"A2", "B2", "2.5"}, nil, {"A4", "", "4.5", "D4", "E4"}, {"A5", "B5"}}},
+		{Options{SkipHiddenRows: true}, [][]string{{"A1", "B1", "1.50", "D1"}, {"A4", "", "4.50", "D4", "E4"}, {"A5", "B5"}}},
+		{Options{MaxRows: 2}, [][]string{{"A1", "B1", "1.50", "D1"}, {"A2", "B2", "2.50"}}},
+		{Options{MaxRows: 2, SkipHiddenRows: true}, [][]string{{"A1", "B1", "1.50", "D1"}, {"A4", "", "4.50", "D4", "E4"}}},
+		{Options{ColumnRange: "D:B"}, [][]string{{"B1", "1.50", "D1"}, {"B2", "2.50"}, nil, {"", "4.50", "D4"}, {"B5"}}},
+		{Options{ColumnRange: "D", RawCellValue: true}, [][]string{{"D1"}, nil, nil, {"D4"}}},
+		{Options{ColumnRange: "E:F", MaxRows: 3}, [][]string{}},
+	} {
+		rows, err := f.GetRows("Sheet1", c.opts)
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, rows, c.opts)
+	}
+	// Test the options of reading rows will be ignored for the workbook
+	f.options = &Options{SkipHiddenRows: true, MaxRows: 1, ColumnRange: "D"}
+	results, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"A1", "B1", "1.50", "D1"}, {"A2", "B2", "2.50"}, nil, {"A4", "", "4.50", "D4", "E4"}, {"A5", "B5"}}, results)
+	f.options = &Options{}
+	// Test rows iterator with columns range
+	rows, err := f.Rows("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, rows.Next())
+	row, err := rows.Columns(Options{ColumnRange: "C:D"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1.50", "D1"}, row)
+	// Test rows iterator with invalid columns range
+	assert.True(t, rows.Next())
+	_, err = rows.Columns(Options{ColumnRange: "-"})
+	assert.Equal(t, newInvalidColumnNameError("-"), err)
+	assert.NoError(t, rows.Close())
+	// Test get rows with invalid columns range
+	_, err = f.GetRows("Sheet1", Options{ColumnRange: "A:-"})
+	assert.Equal(t, newInvalidColumnNameError("-"), err)
+	assert.NoError(t, f.Close())
+
+	// Test get rows skip the child elements of the cells outside the columns range
+	f = NewFile()
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(fmt.Sprintf(`<worksheet xmlns="%s"><sheetData><row r="1"><c r="A1" t="inlineStr"><is><t>A1</t></is><extLst><c r="C1" t="inlineStr"><is><t>C1</t></is></c></extLst></c><c r="B1" t="inlineStr"><is><t>B1</t></is></c></row></sheetData></worksheet>`, NameSpaceSpreadSheet.Value)))
+	f.checked.Delete("xl/worksheets/sheet1.xml")
+	rows, err = f.Rows("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, rows.Next())
+	row, err = rows.Columns(Options{ColumnRange: "B:C"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"B1"}, row)
+	assert.NoError(t, rows.Close())
+	// Test get rows with unclosed cell outside the columns range
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(fmt.Sprintf(`<worksheet xmlns="%s"><sheetData><row r="1"><c r="A1"><v>1</v>`, NameSpaceSpreadSheet.Value)))
+	rows, err = f.Rows("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, rows.Next())
+	_, err = rows.Columns(Options{ColumnRange: "B:C"})
+	assert.EqualError(t, err, "XML syntax error on line 1: unexpected EOF")
+	assert.NoError(t, rows.Close())
+	assert.NoError(t, f.Close())
+}
+
 func TestRows(t *testing.T) {
 	const sheet2 = "Sheet2"
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
@@ -112,6 +195,241 @@ func TestRowsIterator(t *testing.T) {
 	assert.Equal(t, expectedNumRow, rowCount)
 }
 
+func TestRowsSeek(t *testing.T) {
+	for _, opts := range []Options{{}, {UnzipXMLSizeLimit: 128}} {
+		f, err := OpenFile(filepath.Join("test", "Book1.xlsx"), opts)
+		assert.NoError(t, err)
+		expected, err := f.GetRows("Sheet1")
+		assert.NoError(t, err)
+		rows, err := f.Rows("Sheet1")
+		assert.NoError(t, err)
+		for _, row := range []int{5, 6, 2, 15, 1} {
+			assert.NoError(t, rows.Seek(row))
+			assert.True(t, rows.Next())
+			assert.Equal(t, row, rows.CurrentRow())
+			cols, err := rows.Columns()
+			assert.NoError(t, err)
+			assert.Equal(t, expected[row-1], cols)
+			assert.True(t, rows.Next())
+			cols, err = rows.Columns()
+			assert.NoError(t, err)
+			assert.Equal(t, expected[row], cols)
+		}
+		// Test seek without getting the columns of the current row
+		assert.NoError(t, rows.Seek(3))
+		assert.True(t, rows.Next())
+		assert.NoError(t, rows.Seek(4))
+		assert.True(t, rows.Next())
+		cols, err := rows.Columns()
+		assert.NoError(t, err)
+		assert.Equal(t, expected[3], cols)
+		// Test seek beyond the last row
+		assert.NoError(t, rows.Seek(len(expected)+1))
+		assert.False(t, rows.Next())
+		// Test seek with invalid row number
+		assert.Equal(t, newInvalidRowNumberError(0), rows.Seek(0))
+		assert.Equal(t, newInvalidRowNumberError(TotalRows+1), rows.Seek(TotalRows+1))
+		assert.NoError(t, rows.Close())
+		assert.NoError(t, f.Close())
+	}
+
+	// Test seek to the empty rows
+	f := NewFile()
+	for _, cell := range []string{"A2", "B5"} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, cell))
+	}
+	rows, err := f.Rows("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, rows.Seek(3))
+	var results [][]string
+	for rows.Next() {
+		cols, err := rows.Columns()
+		assert.NoError(t, err)
+		results = append(results, cols)
+	}
+	assert.Equal(t, [][]string{nil, nil, {"", "B5"}}, results)
+	assert.NoError(t, rows.Close())
+	assert.NoError(t, f.Close())
+}
+
+func TestReverseRows(t *testing.T) {
+	for _, opts := range []Options{{}, {UnzipXMLSizeLimit: 128}} {
+		f, err := OpenFile(filepath.Join("test", "Book1.xlsx"), opts)
+		assert.NoError(t, err)
+		for _, sheet := range []string{"Sheet1", "Sheet2"} {
+			expected, err := f.GetRows(sheet)
+			assert.NoError(t, err)
+			rows, err := f.ReverseRows(sheet)
+			assert.NoError(t, err)
+			results := make([][]string, len(expected))
+			for rows.Next() {
+				cols, err := rows.Columns()
+				assert.NoError(t, err)
+				if row := rows.CurrentRow(); row <= len(results) {
+					results[row-1] = cols
+				}
+			}
+			assert.NoError(t, rows.Error())
+			assert.Equal(t, expected, results)
+			// Test seek the reverse rows iterator
+			assert.NoError(t, rows.Seek(3))
+			for row := 3; row > 0; row-- {
+				assert.True(t, rows.Next())
+				assert.Equal(t, row, rows.CurrentRow())
+				cols, err := rows.Columns()
+				assert.NoError(t, err)
+				assert.Equal(t, expected[row-1], cols)
+			}
+			assert.False(t, rows.Next())
+			assert.NoError(t, rows.Close())
+		}
+		assert.NoError(t, f.Close())
+	}
+
+	// Test get reverse rows with invalid sheet name
+	f := NewFile()
+	_, err := f.ReverseRows("Sheet:1")
+	assert.Equal(t, ErrSheetNameInvalid, err)
+
+	// Test get reverse rows with sparse rows and rows without row number
+	for _, cell := range []string{"A2", "B5"} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, cell))
+	}
+	rows, err := f.ReverseRows("Sheet1")
+	assert.NoError(t, err)
+	var results [][]string
+	for rows.Next() {
+		assert.Equal(t, 5-len(results), rows.CurrentRow())
+		cols, err := rows.Columns()
+		assert.NoError(t, err)
+		results = append(results, cols)
+	}
+	assert.Equal(t, [][]string{{"", "B5"}, nil, nil, {"A2"}, nil}, results)
+	assert.NoError(t, rows.Seek(10))
+	assert.True(t, rows.Next())
+	assert.Equal(t, 10, rows.CurrentRow())
+
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(`<worksheet><sheetData><row><c t="inlineStr"><is><t>A1</t></is></c></row><row><c t="inlineStr"><is><t>A2</t></is></c></row></sheetData></worksheet>`))
+	rows, err = f.ReverseRows("Sheet1")
+	assert.NoError(t, err)
+	results = nil
+	for rows.Next() {
+		cols, err := rows.Columns()
+		assert.NoError(t, err)
+		results = append(results, cols)
+	}
+	assert.Equal(t, [][]string{{"A2"}, {"A1"}}, results)
+
+	// Test get reverse rows with namespace prefixed elements
+	var sheetData strings.Builder
+	for row := 1; row <= 1000; row++ {
+		if row%100 == 0 {
+			fmt.Fprintf(&sheetData, `<x:row r="%d"><x:c r="B%d"><x:v>%d</x:v></x:c></x:row>`, row, row, row)
+			continue
+		}
+		fmt.Fprintf(&sheetData, `<x:row><x:c t="inlineStr"><x:is><x:t>%d</x:t></x:is></x:c></x:row>`, row)
+	}
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(fmt.Sprintf(`<x:worksheet xmlns:x="%s"><x:sheetData>%s</x:sheetData></x:worksheet>`, NameSpaceSpreadSheet.Value, sheetData.String())))
+	rows, err = f.ReverseRows("Sheet1")
+	assert.NoError(t, err)
+	for row := 1000; rows.Next(); row-- {
+		assert.Equal(t, row, rows.CurrentRow())
+		cols, err := rows.Columns()
+		assert.NoError(t, err)
+		if row%100 == 0 {
+			assert.Equal(t, []string{"", strconv.Itoa(row)}, cols)
+			continue
+		}
+		assert.Equal(t, []string{strconv.Itoa(row)}, cols)
+	}
+	assert.NoError(t, rows.Error())
+	assert.Len(t, rows.rowNums, 990)
+
+	// Test get reverse rows with invalid sheet data end element
+	for _, content := range []string{
+		`<worksheet><sheetData><row r="1"/></worksheet>`,
+		`<worksheet><c></c><sheetData><row r="1"/></worksheet>`,
+	} {
+		f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(content))
+		rows, err = f.ReverseRows("Sheet1")
+		assert.NoError(t, err)
+		assert.False(t, rows.Next())
+	}
+
+	// Test get reverse rows with invalid row element
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(`<worksheet><sheetData><row><c></x></row><row></row></sheetData></worksheet>`))
+	rows, err = f.ReverseRows("Sheet1")
+	assert.NoError(t, err)
+	assert.False(t, rows.Next())
+	assert.EqualError(t, rows.Error(), "XML syntax error on line 1: element <c> closed by </x>")
+
+	// Test get reverse rows without rows
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(`<worksheet><sheetData/></worksheet>`))
+	rows, err = f.ReverseRows("Sheet1")
+	assert.NoError(t, err)
+	assert.False(t, rows.Next())
+	assert.NoError(t, f.Close())
+}
+
+func TestCells(t *testing.T) {
+	f := NewFile()
+	expected := map[string]string{"B2": "B2", "A100": "100", "C1000": "TRUE", "D10000": "D10000"}
+	for cell, value := range map[string]interface{}{"B2": "B2", "A100": 100, "C1000": true, "D10000": "D10000"} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+	}
+	assert.NoError(t, f.SetCellFormula("Sheet1", "E5", "SUM(A1:A2)"))
+	expected["E5"] = ""
+	assert.NoError(t, f.SetCellValue("Sheet1", "F5", ""))
+	cells, err := f.Cells("Sheet1")
+	assert.NoError(t, err)
+	results := map[string]string{}
+	var refs []string
+	for cells.Next() {
+		cell, err := CoordinatesToCellName(cells.Coordinates())
+		assert.NoError(t, err)
+		results[cell] = cells.Value()
+		refs = append(refs, cell)
+	}
+	assert.NoError(t, cells.Error())
+	assert.NoError(t, cells.Close())
+	assert.Equal(t, expected, results)
+	assert.Equal(t, []string{"B2", "E5", "A100", "C1000", "D10000"}, refs)
+
+	// Test get cells with raw cell value
+	style, err := f.NewStyle(&Style{NumFmt: 10})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A100", "A100", style))
+	cells, err = f.Cells("Sheet1", Options{RawCellValue: true})
+	assert.NoError(t, err)
+	for cells.Next() {
+		if col, row := cells.Coordinates(); col == 1 && row == 100 {
+			assert.Equal(t, "100", cells.Value())
+		}
+	}
+	assert.NoError(t, cells.Close())
+
+	// Test get cells with invalid sheet name
+	_, err = f.Cells("Sheet:1")
+	assert.Equal(t, ErrSheetNameInvalid, err)
+
+	// Test get cells with invalid cell reference
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(`<worksheet><sheetData><row><c r="A" t="str"><v>1</v></c></row></sheetData></worksheet>`))
+	cells, err = f.Cells("Sheet1")
+	assert.NoError(t, err)
+	assert.False(t, cells.Next())
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), cells.Error())
+	assert.False(t, cells.Next())
+
+	// Test get cells with unsupported charset shared strings table
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	_, err = f.Cells("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestRowsGetRowOpts(t *testing.T) {
 	sheetName := "Sheet2"
 	expectedRowStyleID1 := RowOpts{Height: 17.0, Hidden: false, StyleID: 1}
@@ -368,6 +686,99 @@ func TestRemoveRow(t *testing.T) {
 	assert.EqualError(t, f.RemoveRow("Sheet:1", 1), ErrSheetNameInvalid.Error())
 }
 
+func TestSetRowsVisible(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetRowsVisible("Sheet1", []RowRange{{Start: 2, End: 4}, {Start: 10, End: 8}}, false))
+	rows, err := f.GetHiddenRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 3, 4, 8, 9, 10}, rows)
+	assert.NoError(t, f.SetRowsVisible("Sheet1", []RowRange{{Start: 3, End: 8}, {Start: 20, End: 30}}, true))
+	rows, err = f.GetHiddenRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 9, 10}, rows)
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Len(t, ws.(*xlsxWorksheet).SheetData.Row, 10)
+	// Test set rows visible without row ranges
+	assert.NoError(t, f.SetRowsVisible("Sheet1", nil, false))
+	// Test set rows visible with invalid row ranges, and no rows will be changed
+	assert.Equal(t, newInvalidRowNumberError(0), f.SetRowsVisible("Sheet1", []RowRange{{Start: 2, End: 3}, {Start: 0, End: 1}}, true))
+	assert.Equal(t, ErrMaxRows, f.SetRowsVisible("Sheet1", []RowRange{{Start: 2, End: 3}, {Start: 1, End: TotalRows + 1}}, true))
+	rows, err = f.GetHiddenRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 9, 10}, rows)
+	// Test set rows visible and get hidden rows on not exist worksheet
+	assert.EqualError(t, f.SetRowsVisible("SheetN", []RowRange{{Start: 1, End: 1}}, false), "sheet SheetN does not exist")
+	_, err = f.GetHiddenRows("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetRowsVisible.xlsx")))
+}
+
+func TestRemoveRows(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, fillCells(f, "Sheet1", 5, 10))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "F10", "SUM(A8:A10)"))
+	assert.NoError(t, f.MergeCell("Sheet1", "B2", "C8"))
+	assert.NoError(t, f.MergeCell("Sheet1", "D3", "E4"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A4", "https://github.com/xuri/excelize", "External"))
+	// Test remove rows 3 to 5 in one pass
+	assert.NoError(t, f.RemoveRows("Sheet1", 3, 3))
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, rows, 7)
+	assert.Equal(t, []string{"A1", "A2", "A6", "A7", "A8", "A9", "A10"}, []string{rows[0][0], rows[1][0], rows[2][0], rows[3][0], rows[4][0], rows[5][0], rows[6][0]})
+	formula, err := f.GetCellFormula("Sheet1", "F7")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(A5:A7)", formula)
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	assert.Equal(t, "B2:C5", mergeCells[0][0])
+	links, target, err := f.GetCellHyperLink("Sheet1", "A4")
+	assert.NoError(t, err)
+	assert.False(t, links)
+	assert.Empty(t, target)
+	// Test remove rows with invalid arguments
+	assert.Equal(t, newInvalidRowNumberError(0), f.RemoveRows("Sheet1", 0, 1))
+	assert.Equal(t, ErrMaxRows, f.RemoveRows("Sheet1", TotalRows+1, 1))
+	assert.Equal(t, ErrParameterInvalid, f.RemoveRows("Sheet1", 1, 0))
+	// Test remove rows on not exist worksheet
+	assert.EqualError(t, f.RemoveRows("SheetN", 1, 1), "sheet SheetN does not exist")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRemoveRows.xlsx")))
+}
+
+func TestRemoveRowSet(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, fillCells(f, "Sheet1", 5, 10))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "F10", "SUM(A8:A10)"))
+	assert.NoError(t, f.MergeCell("Sheet1", "B3", "C6"))
+	// Test remove the non-contiguous rows 2, 5 and 6
+	assert.NoError(t, f.RemoveRowSet("Sheet1", []int{6, 2, 5, 5}))
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	var heads []string
+	for _, row := range rows {
+		heads = append(heads, row[0])
+	}
+	assert.Equal(t, []string{"A1", "A3", "A4", "A7", "A8", "A9", "A10"}, heads)
+	formula, err := f.GetCellFormula("Sheet1", "F7")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(A5:A7)", formula)
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	assert.Equal(t, "B2:C3", mergeCells[0][0])
+	// Test remove rows set with invalid arguments
+	assert.Equal(t, ErrParameterInvalid, f.RemoveRowSet("Sheet1", nil))
+	assert.Equal(t, newInvalidRowNumberError(0), f.RemoveRowSet("Sheet1", []int{1, 0}))
+	assert.Equal(t, ErrMaxRows, f.RemoveRowSet("Sheet1", []int{1, TotalRows + 1}))
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, rows, 7)
+	assert.EqualError(t, f.RemoveRowSet("SheetN", []int{1}), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
 func TestInsertRows(t *testing.T) {
 	f := NewFile()
 	sheet1 := f.GetSheetName(0)
@@ -1189,3 +1600,76 @@ func trimSliceSpace(s []string) []string {
 	}
 	return s
 }
+
+func TestAppendRows(t *testing.T) {
+	f := NewFile()
+	// Test append rows on an empty worksheet
+	assert.NoError(t, f.AppendRows("Sheet1", [][]interface{}{{"A", 1}, {"B", 2}}))
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"A", "1"}, {"B", "2"}}, rows)
+	// Test append rows with copying cell styles of the previous row
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B2", "B2", style))
+	assert.NoError(t, f.AppendRows("Sheet1", [][]interface{}{{"C", 3}}))
+	styleID, err := f.GetCellStyle("Sheet1", "B3")
+	assert.NoError(t, err)
+	assert.Equal(t, style, styleID)
+	// Test append empty rows
+	assert.NoError(t, f.AppendRows("Sheet1", nil))
+	// Test append rows on not exists worksheet
+	assert.EqualError(t, f.AppendRows("SheetN", [][]interface{}{{1}}), "sheet SheetN does not exist")
+	// Test append rows with exceeding the maximum rows
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1048576", 1))
+	assert.Equal(t, ErrMaxRows, f.AppendRows("Sheet1", [][]interface{}{{1}}))
+
+	// Test append rows into a table without totals row
+	f = NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "B1", &[]interface{}{"Name", "Qty"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "B2", &[]interface{}{"Apple", 1}))
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "B1:C2", Name: "Table1"}))
+	assert.NoError(t, f.AppendRows("Sheet1", [][]interface{}{{"Orange", 2}, {"Pear", 3}}))
+	tables, err := f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "B1:C4", tables[0].Range)
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"", "Pear", "3"}, rows[3])
+
+	// Test append rows into a table with totals row
+	tableXML := "xl/tables/table1.xml"
+	assert.NoError(t, f.SetSheetRow("Sheet1", "B5", &[]interface{}{"Total", 6}))
+	style, err = f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "C4", "C4", style))
+	content, ok := f.Pkg.Load(tableXML)
+	assert.True(t, ok)
+	var tbl xlsxTable
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &tbl))
+	tbl.Ref, tbl.AutoFilter, tbl.TotalsRowCount = "B1:C5", nil, 1
+	output, err := xml.Marshal(tbl)
+	assert.NoError(t, err)
+	f.Pkg.Store(tableXML, output)
+	assert.NoError(t, f.AppendRows("Sheet1", [][]interface{}{{"Grape", 4}}))
+	tables, err = f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "B1:C6", tables[0].Range)
+	content, ok = f.Pkg.Load(tableXML)
+	assert.True(t, ok)
+	tbl = xlsxTable{}
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &tbl))
+	assert.Equal(t, 1, tbl.TotalsRowCount)
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"", "Grape", "4"}, rows[4])
+	assert.Equal(t, []string{"", "Total", "6"}, rows[5])
+	styleID, err = f.GetCellStyle("Sheet1", "C5")
+	assert.NoError(t, err)
+	assert.Equal(t, style, styleID)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAppendRows.xlsx")))
+
+	// Test append rows with unsupported charset table
+	f.Pkg.Store(tableXML, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AppendRows("Sheet1", [][]interface{}{{1}}), "XML syntax error on line 1: invalid UTF-8")
+}
@@ -0,0 +1,495 @@
+// Copyright 2016 - 2024 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.18 or later.
+
+package excelize
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// autoMacroName defined the pattern of the defined names which run the macros
+// automatically on opening, closing, activating or deactivating the workbook.
+var autoMacroName = regexp.MustCompile(`(?i)^auto_(open|close|activate|deactivate)`)
+
+// Sanitize provides a function to neutralize or remove the untrusted content
+// in the workbook, so an uploaded workbook can be safely re-served to other
+// users. The optional settings specify the categories of the content to be
+// sanitized, all categories will be sanitized if the settings are nil. The
+// categories that can be sanitized are:
+//
+//	 Category        | Description
+//	-----------------+-----------------------------------------------------------
+//	 Formulas        | The formulas of the cells will be replaced with the
+//	                 | cached values, and the text values which start with the
+//	                 | "=", "+", "-" or "@" characters will be formatted with the
+//	                 | quote prefix to keep them as text.
+//	                 |
+//	 ExternalLinks   | The external workbook references parts, the defined
+//	                 | names which reference to external workbook or path, the
+//	                 | data connections and the query tables which refresh
+//	                 | data by the data connections.
+//	                 |
+//	 Macros          | The VBA project, the digital signatures of it, the
+//	                 | Excel 4.0 macro sheets and the defined names of the
+//	                 | macros, such as Auto_Open, the workbook will be saved as
+//	                 | macro-free workbook.
+//	                 |
+//	 EmbeddedObjects | The OLE objects, embedded packages, ActiveX controls
+//	                 | and the header and footer VML drawings of the
+//	                 | worksheets.
+//	                 |
+//	 RemoteImages    | The pictures in the worksheets which reference to the
+//	                 | external images.
+//
+// Note that the formulas which reference to external workbooks will be broken
+// if removing external links without sanitizing formulas. For example,
+// sanitize the formulas and remove the macros of the workbook:
+//
+//	err := f.Sanitize(&excelize.SanitizeOptions{
+//	    Formulas: true,
+//	    Macros:   true,
+//	})
+func (f *File) Sanitize(opts *SanitizeOptions) error {
+	if opts == nil {
+		opts = &SanitizeOptions{
+			Formulas: true, ExternalLinks: true, Macros: true,
+			EmbeddedObjects: true, RemoteImages: true,
+		}
+	}
+	if opts.Macros {
+		if err := f.removeMacros(); err != nil {
+			return err
+		}
+	}
+	if opts.ExternalLinks {
+		if err := f.removeExternalLinks(); err != nil {
+			return err
+		}
+	}
+	for _, sheet := range f.GetSheetList() {
+		ws, err := f.workSheetReader(sheet)
+		if err != nil {
+			if errors.Is(err, ErrNotWorksheet{SheetName: sheet}) {
+				continue
+			}
+			return err
+		}
+		if opts.ExternalLinks {
+			if err = f.removeQueryTables(sheet, ws); err != nil {
+				return err
+			}
+		}
+		if opts.Formulas {
+			if err = f.neutralizeFormulas(ws); err != nil {
+				return err
+			}
+		}
+		if opts.EmbeddedObjects {
+			f.removeEmbeddedObjects(sheet, ws)
+		}
+		if opts.RemoteImages {
+			if err = f.removeRemoteImages(sheet, ws); err != nil {
+				return err
+			}
+		}
+	}
+	if opts.Formulas {
+		return f.removeCalcChain()
+	}
+	return nil
+}
+
+// neutralizeFormulas provides a function to replace the formulas of the cells
+// with the cached values, and format the formula-like text values with the
+// quote prefix by given worksheet.
+func (f *File) neutralizeFormulas(ws *xlsxWorksheet) error {
+	sst, err := f.sharedStringsReader()
+	if err != nil {
+		return err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	for r := range ws.SheetData.Row {
+		for i := range ws.SheetData.Row[r].C {
+			c := &ws.SheetData.Row[r].C[i]
+			c.F = nil
+			if c.T != "s" && c.T != "str" && c.T != "inlineStr" {
+				continue
+			}
+			val, _ := c.getValueFrom(f, sst, true)
+			if val == "" || !strings.ContainsRune("=+-@", rune(val[0])) {
+				continue
+			}
+			if c.S, err = f.getQuotePrefixStyleID(c.S); err != nil {
+				return err
+			}
+		}
+	}
+	return err
+}
+
+// removeMacros provides a function to remove the VBA project, the digital
+// signatures of it and the Excel 4.0 macro sheets in the workbook.
+func (f *File) removeMacros() error {
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	rels, err := f.relsReader(f.getWorkbookRelsPath())
+	if err != nil || rels == nil {
+		return err
+	}
+	var macroSheets, macroSheetPaths []string
+	for _, sheet := range wb.Sheets.Sheet {
+		for _, rel := range rels.Relationships {
+			if rel.ID == sheet.ID && (rel.Type == SourceRelationshipMacroSheet || rel.Type == SourceRelationshipIntlMacroSheet) {
+				macroSheets = append(macroSheets, sheet.Name)
+				macroSheetPaths = append(macroSheetPaths, f.getWorksheetPath(rel.Target))
+			}
+		}
+	}
+	for i, sheet := range macroSheets {
+		if err = f.DeleteSheet(sheet); err != nil {
+			return err
+		}
+		if err = f.deletePart(macroSheetPaths[i], false); err != nil {
+			return err
+		}
+	}
+	rels.mu.Lock()
+	var relationships []xlsxRelationship
+	var vbaProjects []string
+	for _, rel := range rels.Relationships {
+		if rel.Type == SourceRelationshipVBAProject {
+			vbaProjects = append(vbaProjects, getRelsPartPath(f.getWorkbookPath(), rel.Target))
+			continue
+		}
+		relationships = append(relationships, rel)
+	}
+	rels.Relationships = relationships
+	rels.mu.Unlock()
+	for _, vbaProject := range vbaProjects {
+		if err = f.deletePart(vbaProject, true); err != nil {
+			return err
+		}
+	}
+	if wb.DefinedNames != nil {
+		var definedNames []xlsxDefinedName
+		for _, dn := range wb.DefinedNames.DefinedName {
+			if !autoMacroName.MatchString(dn.Name) && !dn.Function && !dn.VbProcedure && !dn.Xlm {
+				definedNames = append(definedNames, dn)
+			}
+		}
+		if wb.DefinedNames.DefinedName = definedNames; len(definedNames) == 0 {
+			wb.DefinedNames = nil
+		}
+	}
+	content, err := f.contentTypesReader()
+	if err != nil {
+		return err
+	}
+	content.mu.Lock()
+	defer content.mu.Unlock()
+	for idx, o := range content.Overrides {
+		if o.PartName == "/"+f.getWorkbookPath() {
+			switch o.ContentType {
+			case ContentTypeMacro, ContentTypeAddinMacro:
+				content.Overrides[idx].ContentType = ContentTypeSheetML
+			case ContentTypeTemplateMacro:
+				content.Overrides[idx].ContentType = ContentTypeTemplate
+			}
+		}
+	}
+	return err
+}
+
+// removeExternalLinks provides a function to remove the external workbook
+// references parts and the defined names which reference to external
+// workbook or path.
+func (f *File) removeExternalLinks() error {
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	if wb.ExternalReferences != nil {
+		rels, err := f.relsReader(f.getWorkbookRelsPath())
+		if err != nil {
+			return err
+		}
+		var externalLinks []string
+		if rels != nil {
+			rels.mu.Lock()
+			var relationships []xlsxRelationship
+			for _, rel := range rels.Relationships {
+				if rel.Type == SourceRelationshipExternalLink {
+					externalLinks = append(externalLinks, getRelsPartPath(f.getWorkbookPath(), rel.Target))
+					continue
+				}
+				relationships = append(relationships, rel)
+			}
+			rels.Relationships = relationships
+			rels.mu.Unlock()
+		}
+		for _, externalLink := range externalLinks {
+			if err = f.deletePart(externalLink, false); err != nil {
+				return err
+			}
+		}
+		wb.ExternalReferences = nil
+	}
+	if err = f.removeDataConnections(); err != nil {
+		return err
+	}
+	return f.removeExternalDefinedNames()
+}
+
+// removeRelationships provides a function to remove the relationships which
+// match the given function by given relationships part path and source part
+// path, and returns the internal target parts of the removed relationships.
+func (f *File) removeRelationships(relsPath, source string, fn func(rel xlsxRelationship) bool) ([]string, error) {
+	rels, err := f.relsReader(relsPath)
+	if err != nil || rels == nil {
+		return nil, err
+	}
+	rels.mu.Lock()
+	defer rels.mu.Unlock()
+	var (
+		relationships []xlsxRelationship
+		parts         []string
+	)
+	for _, rel := range rels.Relationships {
+		if fn(rel) {
+			if rel.TargetMode != "External" {
+				parts = append(parts, getRelsPartPath(source, rel.Target))
+			}
+			continue
+		}
+		relationships = append(relationships, rel)
+	}
+	rels.Relationships = relationships
+	return parts, err
+}
+
+// removeDataConnections provides a function to remove the data connections
+// part of the workbook.
+func (f *File) removeDataConnections() error {
+	connections, err := f.removeRelationships(f.getWorkbookRelsPath(), f.getWorkbookPath(), func(rel xlsxRelationship) bool {
+		return rel.Type == SourceRelationshipConnections
+	})
+	if err != nil {
+		return err
+	}
+	for _, connection := range connections {
+		if err = f.deletePart(connection, false); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// removeQueryTables provides a function to remove the query tables which
+// refresh data by the data connections by given worksheet name and worksheet,
+// the tables of the query tables will be converted to the worksheet tables.
+func (f *File) removeQueryTables(sheet string, ws *xlsxWorksheet) error {
+	isQueryTable := func(rel xlsxRelationship) bool {
+		return rel.Type == SourceRelationshipQueryTable
+	}
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	queryTables, err := f.removeRelationships(getRelsPath(sheetXMLPath), sheetXMLPath, isQueryTable)
+	if err != nil {
+		return err
+	}
+	if ws.TableParts != nil {
+		for _, tbl := range ws.TableParts.TableParts {
+			tableXML := getRelsPartPath(sheetXMLPath, f.getSheetRelationshipsTargetByID(sheet, tbl.RID))
+			parts, err := f.removeRelationships(getRelsPath(tableXML), tableXML, isQueryTable)
+			if err != nil {
+				return err
+			}
+			queryTables = append(queryTables, parts...)
+			content, ok := f.Pkg.Load(tableXML)
+			if !ok {
+				continue
+			}
+			t := xlsxTable{}
+			if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content.([]byte)))).
+				Decode(&t); err != nil && err != io.EOF {
+				return err
+			}
+			if t.TableType != "queryTable" && t.ConnectionID == 0 {
+				continue
+			}
+			t.TableType, t.ConnectionID = "", 0
+			if t.TableColumns != nil {
+				for i := range t.TableColumns.TableColumn {
+					t.TableColumns.TableColumn[i].QueryTableFieldID = 0
+				}
+			}
+			table, _ := xml.Marshal(t)
+			f.saveFileList(tableXML, table)
+		}
+	}
+	for _, queryTable := range queryTables {
+		if err = f.deletePart(queryTable, false); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// removeEmbeddedObjects provides a function to remove the OLE objects,
+// embedded packages, ActiveX controls and the header and footer VML drawing by
+// given worksheet name and worksheet.
+func (f *File) removeEmbeddedObjects(sheet string, ws *xlsxWorksheet) {
+	ws.mu.Lock()
+	legacyDrawingHF := ws.LegacyDrawingHF
+	ws.OleObjects, ws.Controls, ws.LegacyDrawingHF = nil, nil, nil
+	ws.mu.Unlock()
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	relsPath := getRelsPath(sheetXMLPath)
+	rels, _ := f.relsReader(relsPath)
+	if rels == nil {
+		return
+	}
+	rels.mu.Lock()
+	var relationships []xlsxRelationship
+	var parts []string
+	for _, rel := range rels.Relationships {
+		if rel.Type == SourceRelationshipOLEObject || rel.Type == SourceRelationshipPackage || rel.Type == SourceRelationshipControl {
+			if rel.TargetMode != "External" {
+				parts = append(parts, getRelsPartPath(sheetXMLPath, rel.Target))
+			}
+			continue
+		}
+		relationships = append(relationships, rel)
+	}
+	rels.Relationships = relationships
+	rels.mu.Unlock()
+	for _, part := range parts {
+		_ = f.deletePart(part, true)
+	}
+	if legacyDrawingHF == nil {
+		return
+	}
+	vmlDrawings, _ := f.removeRelationships(relsPath, sheetXMLPath, func(rel xlsxRelationship) bool {
+		return rel.ID == legacyDrawingHF.RID
+	})
+	for _, vmlDrawing := range vmlDrawings {
+		_ = f.deletePart(vmlDrawing, false)
+	}
+}
+
+// removeRemoteImages provides a function to remove the pictures which
+// reference to the external images by given worksheet name and worksheet.
+func (f *File) removeRemoteImages(sheet string, ws *xlsxWorksheet) error {
+	if ws.Drawing == nil {
+		return nil
+	}
+	drawingXML := strings.ReplaceAll(f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID), "..", "xl")
+	drawingRels := getRelsPath(drawingXML)
+	rels, _ := f.relsReader(drawingRels)
+	if rels == nil {
+		return nil
+	}
+	remoteImages := map[string]bool{}
+	rels.mu.Lock()
+	var relationships []xlsxRelationship
+	for _, rel := range rels.Relationships {
+		if rel.Type == SourceRelationshipImage && rel.TargetMode == "External" {
+			remoteImages[rel.ID] = true
+			continue
+		}
+		relationships = append(relationships, rel)
+	}
+	rels.Relationships = relationships
+	rels.mu.Unlock()
+	if len(remoteImages) == 0 {
+		return nil
+	}
+	wsDr, _, err := f.drawingParser(drawingXML)
+	if err != nil {
+		return err
+	}
+	wsDr.mu.Lock()
+	defer wsDr.mu.Unlock()
+	isRemoteImage := func(anchor *xdrCellAnchor) bool {
+		var deCellAnchor decodeCellAnchor
+		_ = f.xmlNewDecoder(strings.NewReader("<decodeCellAnchor>" + anchor.GraphicFrame + "</decodeCellAnchor>")).Decode(&deCellAnchor)
+		return deCellAnchor.Pic != nil && remoteImages[deCellAnchor.Pic.BlipFill.Blip.Link]
+	}
+	for _, anchors := range []*[]*xdrCellAnchor{&wsDr.AbsoluteAnchor, &wsDr.OneCellAnchor, &wsDr.TwoCellAnchor} {
+		var cellAnchors []*xdrCellAnchor
+		for _, anchor := range *anchors {
+			if !isRemoteImage(anchor) {
+				cellAnchors = append(cellAnchors, anchor)
+			}
+		}
+		*anchors = cellAnchors
+	}
+	return err
+}
+
+// deletePart provides a function to delete the part, the relationships part
+// of it and the content type override of it in the package by given part
+// path. The internal target parts of the relationships will be deleted if the
+// targets parameter is true, such as the binary part of the ActiveX control.
+func (f *File) deletePart(path string, targets bool) error {
+	relsPath := getRelsPath(path)
+	if rels, _ := f.relsReader(relsPath); rels != nil && targets {
+		for _, rel := range rels.Relationships {
+			if rel.TargetMode != "External" {
+				if err := f.deletePart(getRelsPartPath(path, rel.Target), false); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	for _, p := range []string{path, relsPath} {
+		f.Pkg.Delete(p)
+	}
+	f.Relationships.Delete(relsPath)
+	content, err := f.contentTypesReader()
+	if err != nil {
+		return err
+	}
+	content.mu.Lock()
+	defer content.mu.Unlock()
+	var overrides []xlsxOverride
+	for _, o := range content.Overrides {
+		if o.PartName != "/"+path {
+			overrides = append(overrides, o)
+		}
+	}
+	content.Overrides = overrides
+	return err
+}
+
+// getRelsPath returns the path of the relationships part by given part path,
+// such as "xl/worksheets/_rels/sheet1.xml.rels" for the part
+// "xl/worksheets/sheet1.xml".
+func getRelsPath(path string) string {
+	return filepath.ToSlash(filepath.Join(filepath.Dir(path), "_rels", filepath.Base(path)+".rels"))
+}
+
+// getRelsPartPath returns the path of the target part in the package by given
+// source part path and the relationship target.
+func getRelsPartPath(path, target string) string {
+	if strings.HasPrefix(target, "/") {
+		return strings.TrimPrefix(target, "/")
+	}
+	return filepath.ToSlash(filepath.Clean(filepath.Join(filepath.Dir(path), target)))
+}
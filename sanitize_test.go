@@ -0,0 +1,335 @@
+package excelize
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitize(t *testing.T) {
+	f, err := prepareSanitizeWorkbook()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Sanitize(nil))
+	// Test neutralize formulas
+	for cell, expected := range map[string]string{"A1": "4", "A2": "=1+1", "B1": "=cmd|' /C calc'!A0", "B2": "Excelize"} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Empty(t, formula, cell)
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	s, err := f.stylesReader()
+	assert.NoError(t, err)
+	for cell, quotePrefix := range map[string]bool{"A1": false, "A2": true, "B1": true, "B2": false} {
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		xf := s.CellXfs.Xf[styleID]
+		assert.Equal(t, quotePrefix, xf.QuotePrefix != nil && *xf.QuotePrefix, cell)
+	}
+	assert.Nil(t, f.CalcChain)
+	// Test remove macros, external links and embedded objects
+	for _, part := range []string{
+		defaultXMLPathCalcChain, "xl/vbaProject.bin", "xl/_rels/vbaProject.bin.rels",
+		"xl/vbaProjectSignature.bin", "xl/macrosheets/sheet1.xml",
+		"xl/externalLinks/externalLink1.xml", "xl/externalLinks/_rels/externalLink1.xml.rels",
+		"xl/embeddings/oleObject1.bin", "xl/embeddings/Document.docx",
+		"xl/activeX/activeX1.xml", "xl/activeX/_rels/activeX1.xml.rels", "xl/activeX/activeX1.bin",
+		"xl/connections.xml", "xl/queryTables/queryTable1.xml",
+		"xl/drawings/vmlDrawingHF1.vml", "xl/drawings/_rels/vmlDrawingHF1.vml.rels",
+	} {
+		_, ok := f.Pkg.Load(part)
+		assert.False(t, ok, part)
+	}
+	assert.Equal(t, []string{"Sheet1", "Sheet2"}, f.GetSheetList())
+	assert.Empty(t, f.GetDefinedName())
+	wb, err := f.workbookReader()
+	assert.NoError(t, err)
+	assert.Nil(t, wb.ExternalReferences)
+	rels, err := f.relsReader(defaultXMLPathWorkbookRels)
+	assert.NoError(t, err)
+	for _, rel := range rels.Relationships {
+		assert.NotContains(t, []string{SourceRelationshipVBAProject, SourceRelationshipMacroSheet, SourceRelationshipExternalLink, SourceRelationshipConnections}, rel.Type)
+	}
+	content, err := f.contentTypesReader()
+	assert.NoError(t, err)
+	for _, o := range content.Overrides {
+		assert.NotContains(t, []string{"/xl/macrosheets/sheet1.xml", "/xl/externalLinks/externalLink1.xml", "/xl/activeX/activeX1.xml", "/xl/connections.xml", "/xl/queryTables/queryTable1.xml"}, o.PartName)
+		if o.PartName == "/xl/workbook.xml" {
+			assert.Equal(t, ContentTypeSheetML, o.ContentType)
+		}
+	}
+	ws, err := f.workSheetReader("Sheet2")
+	assert.NoError(t, err)
+	assert.Nil(t, ws.OleObjects)
+	assert.Nil(t, ws.Controls)
+	assert.Nil(t, ws.LegacyDrawingHF)
+	rels, err = f.relsReader("xl/worksheets/_rels/sheet2.xml.rels")
+	assert.NoError(t, err)
+	assert.Len(t, rels.Relationships, 2)
+	for _, rel := range rels.Relationships {
+		assert.Contains(t, []string{SourceRelationshipDrawingML, SourceRelationshipTable}, rel.Type)
+	}
+	// Test convert the query table to the worksheet table
+	tables, err := f.GetTables("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, tables, 1)
+	table, ok := f.Pkg.Load("xl/tables/table1.xml")
+	assert.True(t, ok)
+	assert.NotContains(t, string(table.([]byte)), "queryTable")
+	assert.NotContains(t, string(table.([]byte)), "connectionId")
+	rels, err = f.relsReader("xl/tables/_rels/table1.xml.rels")
+	assert.NoError(t, err)
+	assert.Empty(t, rels.Relationships)
+	// Test remove remote images
+	rels, err = f.relsReader("xl/drawings/_rels/drawing1.xml.rels")
+	assert.NoError(t, err)
+	assert.Len(t, rels.Relationships, 1)
+	assert.Empty(t, rels.Relationships[0].TargetMode)
+	pics, err := f.GetPictures("Sheet2", "A1")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 1)
+	wsDr, _, err := f.drawingParser("xl/drawings/drawing1.xml")
+	assert.NoError(t, err)
+	assert.Len(t, wsDr.TwoCellAnchor, 1)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSanitize.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestSanitize.xlsx"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Sheet1", "Sheet2"}, f.GetSheetList())
+	// Test sanitize the workbook without untrusted content
+	assert.NoError(t, f.Sanitize(nil))
+	assert.NoError(t, f.Close())
+
+	// Test sanitize the workbook partially
+	f, err = prepareSanitizeWorkbook()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Sanitize(&SanitizeOptions{RemoteImages: true}))
+	formula, err := f.GetCellFormula("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "[1]Sheet1!A1*2", formula)
+	_, ok = f.Pkg.Load("xl/vbaProject.bin")
+	assert.True(t, ok)
+	assert.NoError(t, f.Close())
+
+	// Test remove macros with the defined names of the macros
+	f, err = prepareSanitizeWorkbook()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Sanitize(&SanitizeOptions{Macros: true}))
+	definedNames := f.GetDefinedName()
+	assert.Len(t, definedNames, 1)
+	assert.Equal(t, "External", definedNames[0].Name)
+	_, ok = f.Pkg.Load("xl/connections.xml")
+	assert.True(t, ok)
+	assert.NoError(t, f.Close())
+
+	for _, opts := range []*SanitizeOptions{{ExternalLinks: true}, {Macros: true}} {
+		// Test sanitize the workbook with unsupported charset workbook
+		f = NewFile()
+		f.WorkBook = nil
+		f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+		assert.EqualError(t, f.Sanitize(opts), "XML syntax error on line 1: invalid UTF-8")
+		assert.NoError(t, f.Close())
+	}
+	// Test sanitize the workbook with unsupported charset worksheet
+	f = NewFile()
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	f.checked.Delete("xl/worksheets/sheet1.xml")
+	assert.EqualError(t, f.Sanitize(nil), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+	// Test sanitize the workbook with unsupported charset shared strings table
+	f = NewFile()
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.Sanitize(nil), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+	// Test sanitize the workbook with unsupported charset styles
+	f = NewFile()
+	assert.NoError(t, f.SetCellStr("Sheet1", "A1", "=1"))
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.Sanitize(nil), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+	// Test sanitize the workbook with unsupported charset drawing
+	f, err = prepareSanitizeWorkbook()
+	assert.NoError(t, err)
+	f.Drawings.Delete("xl/drawings/drawing1.xml")
+	f.Pkg.Store("xl/drawings/drawing1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.Sanitize(nil), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+	// Test sanitize the workbook with unsupported charset content types
+	for _, opts := range []*SanitizeOptions{{Formulas: true}, {ExternalLinks: true}} {
+		f, err = prepareSanitizeWorkbook()
+		assert.NoError(t, err)
+		f.ContentTypes = nil
+		f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+		assert.EqualError(t, f.Sanitize(opts), "XML syntax error on line 1: invalid UTF-8")
+		assert.NoError(t, f.Close())
+	}
+	// Test sanitize the workbook with unsupported charset workbook relationships
+	for _, opts := range []*SanitizeOptions{{ExternalLinks: true}, {Macros: true}} {
+		f, err = prepareSanitizeWorkbook()
+		assert.NoError(t, err)
+		f.Relationships.Delete(defaultXMLPathWorkbookRels)
+		f.Pkg.Store(defaultXMLPathWorkbookRels, MacintoshCyrillicCharset)
+		assert.EqualError(t, f.Sanitize(opts), "XML syntax error on line 1: invalid UTF-8")
+		assert.NoError(t, f.Close())
+	}
+	// Test remove data connections with unsupported charset workbook relationships
+	f = NewFile()
+	f.Relationships.Delete(defaultXMLPathWorkbookRels)
+	f.Pkg.Store(defaultXMLPathWorkbookRels, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.Sanitize(&SanitizeOptions{ExternalLinks: true}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+	// Test remove query tables with unsupported charset parts
+	for _, part := range []string{"xl/worksheets/_rels/sheet2.xml.rels", "xl/tables/_rels/table1.xml.rels", "xl/tables/table1.xml"} {
+		f, err = prepareSanitizeWorkbook()
+		assert.NoError(t, err)
+		f.Relationships.Delete(part)
+		f.Pkg.Store(part, MacintoshCyrillicCharset)
+		assert.EqualError(t, f.Sanitize(&SanitizeOptions{ExternalLinks: true}), "XML syntax error on line 1: invalid UTF-8", part)
+		assert.NoError(t, f.Close())
+	}
+}
+
+// prepareSanitizeWorkbook provides a function to prepare a workbook which
+// contains formulas, external links, macros, embedded objects and remote
+// images for testing.
+func prepareSanitizeWorkbook() (*File, error) {
+	f := NewFile()
+	if _, err := f.NewSheet("Sheet2"); err != nil {
+		return f, err
+	}
+	for cell, formula := range map[string]string{"A1": "[1]Sheet1!A1*2", "A2": "\"=1+1\""} {
+		if err := f.SetCellFormula("Sheet1", cell, formula); err != nil {
+			return f, err
+		}
+	}
+	ws, err := f.workSheetReader("Sheet1")
+	if err != nil {
+		return f, err
+	}
+	ws.SheetData.Row[0].C[0].V = "4"
+	ws.SheetData.Row[1].C[0].T, ws.SheetData.Row[1].C[0].V = "str", "=1+1"
+	if err = f.SetSheetCol("Sheet1", "B1", &[]interface{}{"=cmd|' /C calc'!A0", "Excelize"}); err != nil {
+		return f, err
+	}
+	f.CalcChain = &xlsxCalcChain{C: []xlsxCalcChainC{{R: "A1", I: 1}, {R: "A2"}}}
+	// Prepare macros
+	file, err := os.ReadFile(filepath.Join("test", "vbaProject.bin"))
+	if err != nil {
+		return f, err
+	}
+	if err = f.AddVBAProject(file); err != nil {
+		return f, err
+	}
+	if err = f.setContentTypePartProjectExtensions(ContentTypeMacro); err != nil {
+		return f, err
+	}
+	f.Pkg.Store("xl/_rels/vbaProject.bin.rels", []byte(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.microsoft.com/office/2006/relationships/vbaProjectSignature" Target="vbaProjectSignature.bin"/></Relationships>`))
+	f.Pkg.Store("xl/vbaProjectSignature.bin", []byte("signature"))
+	wb, err := f.workbookReader()
+	if err != nil {
+		return f, err
+	}
+	wb.Sheets.Sheet = append(wb.Sheets.Sheet, xlsxSheet{Name: "Macro1", SheetID: 3, ID: "rId20"})
+	f.Pkg.Store("xl/macrosheets/sheet1.xml", []byte(`<xm:macrosheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:xm="http://schemas.microsoft.com/office/excel/2006/main"><sheetData/></xm:macrosheet>`))
+	// Prepare external links
+	wb.ExternalReferences = &xlsxExternalReferences{ExternalReference: []xlsxExternalReference{{RID: "rId21"}}}
+	rels, err := f.relsReader(defaultXMLPathWorkbookRels)
+	if err != nil {
+		return f, err
+	}
+	rels.Relationships = append(rels.Relationships,
+		xlsxRelationship{ID: "rId20", Type: SourceRelationshipMacroSheet, Target: "macrosheets/sheet1.xml"},
+		xlsxRelationship{ID: "rId21", Type: SourceRelationshipExternalLink, Target: "externalLinks/externalLink1.xml"},
+	)
+	f.Pkg.Store("xl/externalLinks/externalLink1.xml", []byte(`<externalLink xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"/>`))
+	f.Pkg.Store("xl/externalLinks/_rels/externalLink1.xml.rels", []byte(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/externalLinkPath" Target="Book2.xlsx" TargetMode="External"/></Relationships>`))
+	if err = f.SetDefinedName(&DefinedName{Name: "External", RefersTo: "[1]Sheet1!$A$1"}); err != nil {
+		return f, err
+	}
+	wb.DefinedNames.DefinedName = append(wb.DefinedNames.DefinedName,
+		xlsxDefinedName{Name: "Auto_Open", Data: "Macro1!$A$1"},
+		xlsxDefinedName{Name: "Macro", Function: true, VbProcedure: true, Data: "Macro1!$A$2"},
+	)
+	// Prepare data connections
+	rels.Relationships = append(rels.Relationships,
+		xlsxRelationship{ID: "rId22", Type: SourceRelationshipConnections, Target: "connections.xml"})
+	f.Pkg.Store("xl/connections.xml", []byte(`<connections xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><connection id="1" name="Query" type="5" refreshedVersion="8"><dbPr connection="Provider=SQLOLEDB" command="SELECT 1"/></connection></connections>`))
+	content, err := f.contentTypesReader()
+	if err != nil {
+		return f, err
+	}
+	content.Overrides = append(content.Overrides,
+		xlsxOverride{PartName: "/xl/macrosheets/sheet1.xml", ContentType: "application/vnd.ms-excel.macrosheet+xml"},
+		xlsxOverride{PartName: "/xl/externalLinks/externalLink1.xml", ContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.externalLink+xml"},
+		xlsxOverride{PartName: "/xl/activeX/activeX1.xml", ContentType: "application/vnd.ms-office.activeX+xml"},
+		xlsxOverride{PartName: "/xl/connections.xml", ContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.connections+xml"},
+		xlsxOverride{PartName: "/xl/queryTables/queryTable1.xml", ContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.queryTable+xml"},
+	)
+	// Prepare embedded objects and remote images
+	if err = f.AddPicture("Sheet2", "A1", filepath.Join("test", "images", "excel.png"), nil); err != nil {
+		return f, err
+	}
+	if ws, err = f.workSheetReader("Sheet2"); err != nil {
+		return f, err
+	}
+	ws.OleObjects = &xlsxInnerXML{Content: `<oleObject progId="Package" shapeId="1025" r:id="rId10"/><oleObject progId="Word.Document.12" shapeId="1026" r:id="rId12"/>`}
+	ws.Controls = &xlsxInnerXML{Content: `<control shapeId="1027" r:id="rId11" name="CommandButton1"/>`}
+	ws.LegacyDrawingHF = &xlsxLegacyDrawingHF{RID: "rId14"}
+	if rels, err = f.relsReader("xl/worksheets/_rels/sheet2.xml.rels"); err != nil {
+		return f, err
+	}
+	rels.Relationships = append(rels.Relationships,
+		xlsxRelationship{ID: "rId10", Type: SourceRelationshipOLEObject, Target: "../embeddings/oleObject1.bin"},
+		xlsxRelationship{ID: "rId11", Type: SourceRelationshipControl, Target: "../activeX/activeX1.xml"},
+		xlsxRelationship{ID: "rId12", Type: SourceRelationshipPackage, Target: "/xl/embeddings/Document.docx"},
+		xlsxRelationship{ID: "rId13", Type: SourceRelationshipOLEObject, Target: "file:///C:/Object.bin", TargetMode: "External"},
+		xlsxRelationship{ID: "rId14", Type: SourceRelationshipDrawingVML, Target: "../drawings/vmlDrawingHF1.vml"},
+	)
+	// Prepare query table
+	if err = f.AddTable("Sheet2", &Table{Range: "H1:I3"}); err != nil {
+		return f, err
+	}
+	for part, data := range map[string]string{
+		"xl/embeddings/oleObject1.bin":             "oleObject",
+		"xl/embeddings/Document.docx":              "package",
+		"xl/activeX/activeX1.xml":                  `<ax:ocx xmlns:ax="http://schemas.microsoft.com/office/2006/activeX" ax:classid="{D7053240-CE69-11CD-A777-00DD01143C57}" ax:persistence="persistStreamInit" r:id="rId1" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"/>`,
+		"xl/activeX/_rels/activeX1.xml.rels":       `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.microsoft.com/office/2006/relationships/activeXControlBinary" Target="activeX1.bin"/></Relationships>`,
+		"xl/activeX/activeX1.bin":                  "activeX",
+		"xl/drawings/vmlDrawingHF1.vml":            `<xml xmlns:v="urn:schemas-microsoft-com:vml"><v:shape id="LH"><v:imagedata o:relid="rId1"/></v:shape></xml>`,
+		"xl/drawings/_rels/vmlDrawingHF1.vml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/image" Target="https://example.com/image.png" TargetMode="External"/></Relationships>`,
+		"xl/tables/_rels/table1.xml.rels":          `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/queryTable" Target="../queryTables/queryTable1.xml"/></Relationships>`,
+		"xl/queryTables/queryTable1.xml":           `<queryTable xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" name="Query" connectionId="1"/>`,
+	} {
+		f.Pkg.Store(part, []byte(data))
+	}
+	table, _ := f.Pkg.Load("xl/tables/table1.xml")
+	f.Pkg.Store("xl/tables/table1.xml", bytes.Replace(table.([]byte), []byte(`ref="H1:I3"`), []byte(`ref="H1:I3" tableType="queryTable" connectionId="1"`), 1))
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		return f, err
+	}
+	if err = f.Close(); err != nil {
+		return f, err
+	}
+	// Append a picture which reference to the external image
+	if f, err = OpenReader(buf); err != nil {
+		return f, err
+	}
+	drawing, _ := f.Pkg.Load("xl/drawings/drawing1.xml")
+	f.Pkg.Store("xl/drawings/drawing1.xml", bytes.ReplaceAll(drawing.([]byte), []byte("</xdr:wsDr>"),
+		[]byte(`<xdr:twoCellAnchor editAs="oneCell"><xdr:from><xdr:col>3</xdr:col><xdr:colOff>0</xdr:colOff><xdr:row>0</xdr:row><xdr:rowOff>0</xdr:rowOff></xdr:from><xdr:to><xdr:col>5</xdr:col><xdr:colOff>0</xdr:colOff><xdr:row>5</xdr:row><xdr:rowOff>0</xdr:rowOff></xdr:to><xdr:pic><xdr:nvPicPr><xdr:cNvPr id="3" name="Picture 2"/><xdr:cNvPicPr/></xdr:nvPicPr><xdr:blipFill><a:blip r:link="rId9"/><a:stretch><a:fillRect/></a:stretch></xdr:blipFill><xdr:spPr><a:prstGeom prst="rect"><a:avLst/></a:prstGeom></xdr:spPr></xdr:pic><xdr:clientData/></xdr:twoCellAnchor></xdr:wsDr>`)))
+	if rels, err = f.relsReader("xl/drawings/_rels/drawing1.xml.rels"); err != nil {
+		return f, err
+	}
+	rels.Relationships = append(rels.Relationships,
+		xlsxRelationship{ID: "rId9", Type: SourceRelationshipImage, Target: "https://example.com/image.png", TargetMode: "External"})
+	return f, err
+}
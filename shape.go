@@ -12,6 +12,7 @@
 package excelize
 
 import (
+	"path/filepath"
 	"strconv"
 	"strings"
 )
@@ -78,6 +79,10 @@ func parseShapeOptions(opts *Shape) (*Shape, error) {
 //	    },
 //	)
 //
+// The optional parameters "Unit", "Absolute", "PositionX", "PositionY",
+// "Width" and "Height" of the "Format" specify the absolute position and the
+// physical size of the shape in the same way as the AddPicture function.
+//
 // The following shows the type of shape supported by excelize:
 //
 //	accentBorderCallout1 (Callout 1 with Border and Accent Shape)
@@ -288,6 +293,26 @@ func parseShapeOptions(opts *Shape) (*Shape, error) {
 //	wavy
 //	wavyHeavy
 //	wavyDbl
+//
+// The optional parameter "AltText", "AltTextTitle" and "Decorative" in the
+// "Format" specifies the alternative text description, the alternative text
+// title and whether the shape is marked as decorative for accessibility tools.
+//
+// The optional parameter "Hyperlink" in the "Format" specifies the hyperlink
+// of the shape, and the "HyperlinkType" defines two types of hyperlink
+// "External" for website or "Location" for moving to one of the cells in this
+// workbook. When the "HyperlinkType" is "Location", coordinates need to start
+// with "#". For example, add a shape which links to the cell D8 in Sheet2:
+//
+//	err := f.AddShape("Sheet1", &excelize.Shape{
+//	    Cell:      "G6",
+//	    Type:      "rect",
+//	    Paragraph: []excelize.RichTextRun{{Text: "Go to Sheet2"}},
+//	    Format: excelize.GraphicOptions{
+//	        Hyperlink:     "#Sheet2!D8",
+//	        HyperlinkType: "Location",
+//	    },
+//	})
 func (f *File) AddShape(sheet string, opts *Shape) error {
 	options, err := parseShapeOptions(opts)
 	if err != nil {
@@ -329,27 +354,32 @@ func (f *File) twoCellAnchorShape(sheet, drawingXML, cell string, width, height
 	if err != nil {
 		return nil, nil, 0, err
 	}
-	w := int(float64(width) * format.ScaleX)
-	h := int(float64(height) * format.ScaleY)
-	colStart, rowStart, colEnd, rowEnd, x2, y2 := f.positionObjectPixels(sheet, fromCol, fromRow, format.OffsetX, format.OffsetY, w, h)
+	from, to := &xlsxFrom{}, &xlsxTo{}
+	if format.hasAbsoluteAnchor() {
+		if from, to, err = f.getGraphicAnchorEMUs(sheet, fromCol, fromRow, int(width), int(height), &format); err != nil {
+			return nil, nil, 0, err
+		}
+	} else {
+		w := int(float64(width) * format.ScaleX)
+		h := int(float64(height) * format.ScaleY)
+		colStart, rowStart, colEnd, rowEnd, x2, y2 := f.positionObjectPixels(sheet, fromCol, fromRow, format.OffsetX, format.OffsetY, w, h)
+		from.Col = colStart
+		from.ColOff = format.OffsetX * EMU
+		from.Row = rowStart
+		from.RowOff = format.OffsetY * EMU
+		to.Col = colEnd
+		to.ColOff = x2 * EMU
+		to.Row = rowEnd
+		to.RowOff = y2 * EMU
+	}
 	content, cNvPrID, err := f.drawingParser(drawingXML)
 	if err != nil {
 		return content, nil, cNvPrID, err
 	}
 	twoCellAnchor := xdrCellAnchor{}
 	twoCellAnchor.EditAs = format.Positioning
-	from := xlsxFrom{}
-	from.Col = colStart
-	from.ColOff = format.OffsetX * EMU
-	from.Row = rowStart
-	from.RowOff = format.OffsetY * EMU
-	to := xlsxTo{}
-	to.Col = colEnd
-	to.ColOff = x2 * EMU
-	to.Row = rowEnd
-	to.RowOff = y2 * EMU
-	twoCellAnchor.From = &from
-	twoCellAnchor.To = &to
+	twoCellAnchor.From = from
+	twoCellAnchor.To = to
 	return content, &twoCellAnchor, cNvPrID, err
 }
 
@@ -402,6 +432,19 @@ func (f *File) addDrawingShape(sheet, drawingXML, cell string, opts *Shape) erro
 			},
 		},
 	}
+	shape.NvSpPr.CNvPr.setAltText(&opts.Format)
+	if opts.Format.Hyperlink != "" && opts.Format.HyperlinkType != "" {
+		var hyperlinkType string
+		if opts.Format.HyperlinkType == "External" {
+			hyperlinkType = opts.Format.HyperlinkType
+		}
+		drawingRels := "xl/drawings/_rels/" + filepath.Base(drawingXML) + ".rels"
+		rID := f.addRels(drawingRels, SourceRelationshipHyperLink, opts.Format.Hyperlink, hyperlinkType)
+		shape.NvSpPr.CNvPr.HlinkClick = &xlsxHlinkClick{
+			R:   SourceRelationship.Value,
+			RID: "rId" + strconv.Itoa(rID),
+		}
+	}
 	if *opts.Line.Width != 1 {
 		shape.SpPr.Ln = xlsxLineProperties{
 			W: f.ptToEMUs(*opts.Line.Width),
@@ -459,7 +502,7 @@ func (f *File) addDrawingShape(sheet, drawingXML, cell string, opts *Shape) erro
 		srgbClr := strings.ReplaceAll(strings.ToUpper(font.Color), "#", "")
 		if len(srgbClr) == 6 {
 			paragraph.R.RPr.SolidFill = &aSolidFill{
-				SrgbClr: &attrValString{
+				SrgbClr: &aSrgbClr{
 					Val: stringPtr(srgbClr),
 				},
 			}
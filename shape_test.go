@@ -113,3 +113,76 @@ func TestAddDrawingShape(t *testing.T) {
 		},
 	), "XML syntax error on line 1: invalid UTF-8")
 }
+
+func TestAddShapePhysicalUnits(t *testing.T) {
+	f := NewFile()
+	colWidth, rowHeight := f.getColWidth("Sheet1", 1)*EMU, f.getRowHeight("Sheet1", 1)*EMU
+	// Test add shape on the cell with the height in inches
+	assert.NoError(t, f.AddShape("Sheet1", &Shape{
+		Cell: "C2", Type: "rect", Width: 100, Height: 50,
+		Format: GraphicOptions{Unit: "in", OffsetX: 10, OffsetY: 5, Height: 1},
+	}))
+	drawing, ok := f.Drawings.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	anchor := drawing.(*xlsxWsDr).TwoCellAnchor[0]
+	x, y, width, height := 2*colWidth+10*EMU, rowHeight+5*EMU, 1828800, 914400
+	assert.Equal(t, &xlsxFrom{Col: 2, ColOff: 10 * EMU, Row: 1, RowOff: 5 * EMU}, anchor.From)
+	assert.Equal(t, &xlsxTo{Col: (x + width) / colWidth, ColOff: (x + width) % colWidth, Row: (y + height) / rowHeight, RowOff: (y + height) % rowHeight}, anchor.To)
+	// Test add shape with negative size
+	assert.Equal(t, ErrParameterInvalid, f.AddShape("Sheet1", &Shape{Cell: "A1", Type: "rect", Format: GraphicOptions{Absolute: true, Width: -1}}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddShapePhysicalUnits.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
+func TestAddShapeHyperlink(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddShape("Sheet1", &Shape{
+		Cell: "A1", Type: "rect", Paragraph: []RichTextRun{{Text: "Excelize"}},
+		Format: GraphicOptions{Hyperlink: "https://github.com/xuri/excelize", HyperlinkType: "External"},
+	}))
+	assert.NoError(t, f.AddShape("Sheet1", &Shape{
+		Cell: "D1", Type: "rect", Paragraph: []RichTextRun{{Text: "Sheet1!D8"}},
+		Format: GraphicOptions{Hyperlink: "#Sheet1!D8", HyperlinkType: "Location"},
+	}))
+	assert.NoError(t, f.AddShape("Sheet1", &Shape{Cell: "G1", Type: "rect", Format: GraphicOptions{Hyperlink: "#Sheet1!D8"}}))
+	drawing, ok := f.Drawings.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	rels, err := f.relsReader("xl/drawings/_rels/drawing1.xml.rels")
+	assert.NoError(t, err)
+	assert.Len(t, rels.Relationships, 2)
+	for idx, expected := range []xlsxRelationship{
+		{ID: "rId1", Type: SourceRelationshipHyperLink, Target: "https://github.com/xuri/excelize", TargetMode: "External"},
+		{ID: "rId2", Type: SourceRelationshipHyperLink, Target: "#Sheet1!D8"},
+	} {
+		assert.Equal(t, expected, rels.Relationships[idx])
+		assert.Equal(t, &xlsxHlinkClick{R: SourceRelationship.Value, RID: expected.ID},
+			drawing.(*xlsxWsDr).TwoCellAnchor[idx].Sp.NvSpPr.CNvPr.HlinkClick)
+	}
+	assert.Nil(t, drawing.(*xlsxWsDr).TwoCellAnchor[2].Sp.NvSpPr.CNvPr.HlinkClick)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddShapeHyperlink.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
+func TestAddShapeAltText(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddShape("Sheet1", &Shape{
+		Cell: "A1", Type: "rect", Paragraph: []RichTextRun{{Text: "Excelize"}},
+		Format: GraphicOptions{AltText: "Rectangle", AltTextTitle: "Shape", Decorative: true},
+	}))
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{
+		Type:   Line,
+		Series: []ChartSeries{{Name: "Sheet1!$A$1", Values: "Sheet1!$B$1:$D$1"}},
+		Format: GraphicOptions{AltText: "Line chart", AltTextTitle: "Chart"},
+	}))
+	drawing, ok := f.Drawings.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	wsDr := drawing.(*xlsxWsDr)
+	cNvPr := wsDr.TwoCellAnchor[0].Sp.NvSpPr.CNvPr
+	assert.Equal(t, "Rectangle", cNvPr.Descr)
+	assert.Equal(t, "Shape", cNvPr.Title)
+	assert.True(t, cNvPr.isDecorative())
+	assert.Contains(t, wsDr.TwoCellAnchor[1].GraphicFrame, `descr="Line chart" title="Chart"`)
+	assert.NotContains(t, wsDr.TwoCellAnchor[1].GraphicFrame, ExtURIDecorative)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddShapeAltText.xlsx")))
+	assert.NoError(t, f.Close())
+}
@@ -12,8 +12,10 @@
 package excelize
 
 import (
+	"archive/zip"
 	"bytes"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -28,6 +30,7 @@ import (
 	"unicode/utf8"
 
 	"github.com/mohae/deepcopy"
+	"golang.org/x/text/encoding/unicode"
 )
 
 // NewSheet provides the function to create a new sheet by given a worksheet
@@ -140,6 +143,7 @@ func (f *File) workSheetWriter() {
 		arr     []byte
 		buffer  = bytes.NewBuffer(arr)
 		encoder = xml.NewEncoder(buffer)
+		remap   = f.getOptimizedCellXfs()
 	)
 	f.Sheet.Range(func(p, ws interface{}) bool {
 		if ws != nil {
@@ -150,19 +154,29 @@ func (f *File) workSheetWriter() {
 			if sheet.Cols != nil && len(sheet.Cols.Col) > 0 {
 				f.mergeExpandedCols(sheet)
 			}
-			sheet.SheetData.Row = trimRow(&sheet.SheetData)
+			sheet.SheetData.Row, sheet.rowIndex = trimRow(&sheet.SheetData), nil
+			if f.options != nil && f.options.RowSpans {
+				sheet.setRowSpans()
+			}
+			if f.options != nil && f.options.AutoDimension {
+				_ = sheet.updateDimension()
+			}
 			if sheet.SheetPr != nil || sheet.Drawing != nil || sheet.Hyperlinks != nil || sheet.Picture != nil || sheet.TableParts != nil {
 				f.addNameSpaces(p.(string), SourceRelationship)
 			}
-			if sheet.DecodeAlternateContent != nil {
-				sheet.AlternateContent = &xlsxAlternateContent{
-					Content: sheet.DecodeAlternateContent.Content,
+			for _, content := range sheet.DecodeAlternateContent {
+				sheet.AlternateContent = append(sheet.AlternateContent, &xlsxAlternateContent{
+					Content: content.Content,
 					XMLNSMC: SourceRelationshipCompatibility.Value,
-				}
+				})
 			}
 			sheet.DecodeAlternateContent = nil
+			if f.isLowMemorySave() {
+				// The worksheet will be serialized on writing the zip
+				return true
+			}
 			// reusing buffer
-			_ = encoder.Encode(sheet)
+			_ = sheet.encode(encoder, remap)
 			f.saveFileList(p.(string), replaceRelationshipsBytes(f.replaceNameSpaceBytes(p.(string), buffer.Bytes())))
 			_, ok := f.checked.Load(p.(string))
 			if ok {
@@ -175,6 +189,72 @@ func (f *File) workSheetWriter() {
 	})
 }
 
+// isLowMemorySave returns whether the loaded worksheets should be serialized
+// directly into the zip on saving.
+func (f *File) isLowMemorySave() bool {
+	return f.options != nil && f.options.LowMemorySave && !f.options.Canonical && !f.options.ScrubMetadata
+}
+
+// encode provides a function to serialize the worksheet by given encoder and
+// the mapping of the cell formats. The cells, rows and columns will be
+// serialized with copies which reference the mapped cell formats, and the
+// worksheet will be restored after serializing.
+func (ws *xlsxWorksheet) encode(encoder *xml.Encoder, remap map[int]int) error {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if len(remap) == 0 {
+		return encoder.Encode(ws)
+	}
+	sheetData, cols := ws.SheetData, ws.Cols
+	defer func() { ws.SheetData, ws.Cols = sheetData, cols }()
+	ws.SheetData.Row = make([]xlsxRow, len(sheetData.Row))
+	for rowIdx, row := range sheetData.Row {
+		if idx, ok := remap[row.S]; ok {
+			row.S = idx
+		}
+		row.C = make([]xlsxC, len(row.C))
+		copy(row.C, sheetData.Row[rowIdx].C)
+		for colIdx := range row.C {
+			if idx, ok := remap[row.C[colIdx].S]; ok {
+				row.C[colIdx].S = idx
+			}
+		}
+		ws.SheetData.Row[rowIdx] = row
+	}
+	if cols != nil {
+		ws.Cols = &xlsxCols{Col: make([]xlsxCol, len(cols.Col))}
+		copy(ws.Cols.Col, cols.Col)
+		for colIdx := range ws.Cols.Col {
+			if idx, ok := remap[ws.Cols.Col[colIdx].Style]; ok {
+				ws.Cols.Col[colIdx].Style = idx
+			}
+		}
+	}
+	return encoder.Encode(ws)
+}
+
+// writeWorksheetPart provides a function to serialize the worksheet directly
+// into the zip by given part path, worksheet and the mapping of the cell
+// formats, the namespace declarations will be replaced while writing.
+func (f *File) writeWorksheetPart(zw *zip.Writer, path string, ws *xlsxWorksheet, remap map[int]int) error {
+	fi, err := zw.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err = io.WriteString(fi, xml.Header); err != nil {
+		return err
+	}
+	sourceXmlns, targetXmlns := f.nameSpaceBytes(path)
+	rw := newReplaceWriter(fi, [][2][]byte{
+		{sourceXmlns, targetXmlns},
+		{relationshipsXMLNSBytes, []byte("r")},
+	})
+	if err = ws.encode(xml.NewEncoder(rw), remap); err != nil {
+		return err
+	}
+	return rw.Flush()
+}
+
 // trimRow provides a function to trim empty rows.
 func trimRow(sheetData *xlsxSheetData) []xlsxRow {
 	var (
@@ -248,7 +328,7 @@ func (f *File) setSheet(index int, name string) {
 func (f *File) relsWriter() {
 	f.Relationships.Range(func(path, rel interface{}) bool {
 		if rel != nil {
-			output, _ := xml.Marshal(rel.(*xlsxRelationships))
+			output, _ := xml.Marshal(f.getOptimizedRelationships(rel.(*xlsxRelationships)))
 			if strings.HasPrefix(path.(string), "xl/worksheets/sheet/rels/sheet") {
 				output = f.replaceNameSpaceBytes(path.(string), output)
 			}
@@ -258,13 +338,15 @@ func (f *File) relsWriter() {
 	})
 }
 
+// relationshipsXMLNSBytes defined the relationships namespace declaration
+// and prefix generated by the XML encoder.
+var relationshipsXMLNSBytes = []byte(`xmlns:relationships="http://schemas.openxmlformats.org/officeDocument/2006/relationships" relationships`)
+
 // replaceRelationshipsBytes; Some tools that read spreadsheet files have very
 // strict requirements about the structure of the input XML. This function is
 // a horrible hack to fix that after the XML marshalling is completed.
 func replaceRelationshipsBytes(content []byte) []byte {
-	sourceXmlns := []byte(`xmlns:relationships="http://schemas.openxmlformats.org/officeDocument/2006/relationships" relationships`)
-	targetXmlns := []byte("r")
-	return bytesReplace(content, sourceXmlns, targetXmlns, -1)
+	return bytesReplace(content, relationshipsXMLNSBytes, []byte("r"), -1)
 }
 
 // SetActiveSheet provides a function to set the default active sheet of the
@@ -351,10 +433,14 @@ func (f *File) getActiveSheetID() int {
 }
 
 // SetSheetName provides a function to set the worksheet name by given source and
-// target worksheet names. Maximum 31 characters are allowed in sheet title and
-// this function only changes the name of the sheet and will not update the
-// sheet name in the formula or reference associated with the cell. So there
-// may be problem formula error or reference missing.
+// target worksheet names. Maximum 31 characters are allowed in sheet title.
+// The references which embed the source sheet name in the cell formulas,
+// defined names, chart series, conditional formats, data validations and
+// hyperlinks will be updated to the target name, and the target name will be
+// enclosed in single quotation marks if it includes spaces or
+// non-alphabetical characters. For example, rename Sheet1 to "Sales Data":
+//
+//	err := f.SetSheetName("Sheet1", "Sales Data")
 func (f *File) SetSheetName(source, target string) error {
 	var err error
 	if err = checkSheetName(source); err != nil {
@@ -366,19 +452,166 @@ func (f *File) SetSheetName(source, target string) error {
 	if target == source {
 		return err
 	}
-	wb, _ := f.workbookReader()
+	idx, _ := f.GetSheetIndex(source)
+	if idx == -1 {
+		return err
+	}
+	return f.renameSheets(map[string]string{f.GetSheetName(idx): target})
+}
+
+// RenameSheets provides a function to rename multiple sheets in one pass by
+// given source and target sheet names map. All the target names will be
+// validated before renaming, an error will be returned if any source sheet
+// doesn't exist, any target name is invalid or conflicts with another sheet
+// name, and the workbook will not be changed in this case. Swapping the names
+// of sheets is supported. The references which embed the old sheet names will
+// be updated to the new names in the same way as the SetSheetName function.
+// For example, rename the Sheet1 to "Summary" and Sheet2 to "Data":
+//
+//	err := f.RenameSheets(map[string]string{
+//	    "Sheet1": "Summary",
+//	    "Sheet2": "Data",
+//	})
+func (f *File) RenameSheets(names map[string]string) error {
+	renames, sources, targets := map[string]string{}, map[string]bool{}, map[string]bool{}
+	sheets := f.GetSheetList()
+	for source, target := range names {
+		if err := checkSheetName(source); err != nil {
+			return err
+		}
+		if err := checkSheetName(target); err != nil {
+			return err
+		}
+		idx, _ := f.GetSheetIndex(source)
+		if idx == -1 {
+			return ErrSheetNotExist{source}
+		}
+		if targets[strings.ToLower(target)] {
+			return ErrExistsSheet
+		}
+		sources[strings.ToLower(source)], targets[strings.ToLower(target)] = true, true
+		if sheets[idx] != target {
+			renames[sheets[idx]] = target
+		}
+	}
+	for _, sheet := range sheets {
+		if name := strings.ToLower(sheet); targets[name] && !sources[name] {
+			return ErrExistsSheet
+		}
+	}
+	if len(renames) == 0 {
+		return nil
+	}
+	return f.renameSheets(renames)
+}
+
+// renameSheets provides a function to rename the sheets by given source and
+// target sheet names map, and update the sheet names in the defined names,
+// chart series formulas and the formulas and references of the worksheets.
+func (f *File) renameSheets(names map[string]string) error {
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	paths := map[string]string{}
+	for source := range names {
+		paths[source], _ = f.getSheetXMLPath(source)
+		for name := range f.sheetMap {
+			if strings.EqualFold(name, source) {
+				delete(f.sheetMap, name)
+			}
+		}
+	}
 	for k, v := range wb.Sheets.Sheet {
-		if v.Name == source {
+		if target, ok := names[v.Name]; ok {
 			wb.Sheets.Sheet[k].Name = target
-			f.sheetMap[target] = f.sheetMap[source]
-			delete(f.sheetMap, source)
+			f.sheetMap[target] = paths[v.Name]
+		}
+	}
+	return f.adjustSheetReferences(wb, names)
+}
+
+// adjustSheetReferences provides a function to update the sheet names in the
+// defined names, chart series formulas and the formulas and references of the
+// worksheets by given source and target sheet names map. The references to
+// the sheet with an empty target name will be replaced with the #REF! error.
+func (f *File) adjustSheetReferences(wb *xlsxWorkbook, names map[string]string) error {
+	if wb.DefinedNames != nil {
+		for i, dn := range wb.DefinedNames.DefinedName {
+			if data := adjustFormulaSheetNames(dn.Data, names); data != dn.Data {
+				wb.DefinedNames.DefinedName[i].Data = data
+				continue
+			}
+			wb.DefinedNames.DefinedName[i].Data = adjustRangeSheetName(dn.Data, names)
+		}
+	}
+	for _, sheet := range f.GetSheetList() {
+		ws, err := f.workSheetReader(sheet)
+		if err != nil {
+			if _, ok := err.(ErrNotWorksheet); ok {
+				continue
+			}
+			return err
 		}
+		ws.adjustSheetNames(names)
+	}
+	f.adjustChartSheetNames(names)
+	return nil
+}
+
+// MoveSheet provides a function to move the sheet to the given position in the
+// sheet tabs by given sheet name and zero-based target index. The scope of the
+// local defined names and the active sheet will be kept after moving. For
+// example, move the sheet named "Summary" to the first position:
+//
+//	err := f.MoveSheet("Summary", 0)
+func (f *File) MoveSheet(sheet string, index int) error {
+	from, err := f.GetSheetIndex(sheet)
+	if err != nil {
+		return err
 	}
-	if wb.DefinedNames == nil {
+	if from == -1 {
+		return ErrSheetNotExist{sheet}
+	}
+	wb, _ := f.workbookReader()
+	if index < 0 || index >= len(wb.Sheets.Sheet) {
+		return ErrSheetIdx
+	}
+	if from == index {
 		return err
 	}
-	for i, dn := range wb.DefinedNames.DefinedName {
-		wb.DefinedNames.DefinedName[i].Data = adjustRangeSheetName(dn.Data, source, target)
+	moved := wb.Sheets.Sheet[from]
+	wb.Sheets.Sheet = append(wb.Sheets.Sheet[:from], wb.Sheets.Sheet[from+1:]...)
+	wb.Sheets.Sheet = append(wb.Sheets.Sheet[:index], append([]xlsxSheet{moved}, wb.Sheets.Sheet[index:]...)...)
+	newIndex := make([]int, len(wb.Sheets.Sheet))
+	for i := range newIndex {
+		switch {
+		case i == from:
+			newIndex[i] = index
+		case from < i && i <= index:
+			newIndex[i] = i - 1
+		case index <= i && i < from:
+			newIndex[i] = i + 1
+		default:
+			newIndex[i] = i
+		}
+	}
+	if wb.DefinedNames != nil {
+		for i, dn := range wb.DefinedNames.DefinedName {
+			if dn.LocalSheetID != nil && *dn.LocalSheetID >= 0 && *dn.LocalSheetID < len(newIndex) {
+				wb.DefinedNames.DefinedName[i].LocalSheetID = intPtr(newIndex[*dn.LocalSheetID])
+			}
+		}
+	}
+	if wb.BookViews != nil {
+		for i, view := range wb.BookViews.WorkBookView {
+			if view.ActiveTab >= 0 && view.ActiveTab < len(newIndex) {
+				wb.BookViews.WorkBookView[i].ActiveTab = newIndex[view.ActiveTab]
+			}
+			if view.FirstSheet >= 0 && view.FirstSheet < len(newIndex) {
+				wb.BookViews.WorkBookView[i].FirstSheet = newIndex[view.FirstSheet]
+			}
+		}
 	}
 	return err
 }
@@ -486,6 +719,9 @@ func (f *File) getSheetMap() (map[string]string, error) {
 				if _, ok := f.tempFiles.Load(sheetXMLPath); ok {
 					maps[v.Name] = sheetXMLPath
 				}
+				if _, ok := f.brokenSheets.Load(sheetXMLPath); ok {
+					maps[v.Name] = sheetXMLPath
+				}
 			}
 		}
 	}
@@ -549,19 +785,189 @@ func (f *File) setSheetBackground(sheet, extension string, file []byte) error {
 	return f.setContentTypePartImageExtensions()
 }
 
+// SheetDependencyType is the type of the reference to a sheet.
+type SheetDependencyType byte
+
+// Sheet dependency types enumeration.
+const (
+	SheetDependencyFormula SheetDependencyType = iota
+	SheetDependencyDefinedName
+	SheetDependencyChart
+	SheetDependencyPivotTable
+)
+
+// GetSheetDependencies provides a function to get the references to the sheet
+// from the other parts of the workbook by given sheet name, which includes the
+// cell formulas on the other worksheets, the defined names, the chart series
+// and the data source of the pivot tables. These references will be broken
+// after deleting the sheet. For the shared formulas, only the master cell of
+// the shared formula will be returned. For example, check if Sheet2 could be
+// deleted safely:
+//
+//	deps, err := f.GetSheetDependencies("Sheet2")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, dep := range deps {
+//	    fmt.Println(dep.Type, dep.Sheet, dep.Cell, dep.Name, dep.RefersTo)
+//	}
+func (f *File) GetSheetDependencies(sheet string) ([]SheetDependency, error) {
+	var deps []SheetDependency
+	idx, err := f.GetSheetIndex(sheet)
+	if err != nil {
+		return deps, err
+	}
+	if idx == -1 {
+		return deps, ErrSheetNotExist{sheet}
+	}
+	sheet = f.GetSheetName(idx)
+	for _, dn := range f.GetDefinedName() {
+		if dn.Scope != sheet && isFormulaReferSheet(dn.RefersTo, sheet) {
+			deps = append(deps, SheetDependency{Type: SheetDependencyDefinedName, Sheet: dn.Scope, Name: dn.Name, RefersTo: dn.RefersTo})
+		}
+	}
+	for _, name := range f.GetSheetList() {
+		if name == sheet {
+			continue
+		}
+		charts, err := f.getSheetChartPaths(name)
+		if err != nil {
+			return deps, err
+		}
+		for _, chartXML := range charts {
+			for _, matches := range chartFormulaRegexp.FindAllStringSubmatch(string(f.readXML(chartXML)), -1) {
+				if formula := chartFormulaUnescaper.Replace(matches[2]); isFormulaReferSheet(formula, sheet) {
+					deps = append(deps, SheetDependency{Type: SheetDependencyChart, Sheet: name, RefersTo: formula})
+				}
+			}
+		}
+		ws, err := f.workSheetReader(name)
+		if err != nil {
+			if _, ok := err.(ErrNotWorksheet); ok {
+				continue
+			}
+			return deps, err
+		}
+		for _, row := range ws.SheetData.Row {
+			for _, c := range row.C {
+				if c.F != nil && c.F.Content != "" && isFormulaReferSheet(c.F.Content, sheet) {
+					deps = append(deps, SheetDependency{Type: SheetDependencyFormula, Sheet: name, Cell: c.R, RefersTo: c.F.Content})
+				}
+			}
+		}
+		pivotTables, err := f.GetPivotTables(name)
+		if err != nil {
+			return deps, err
+		}
+		for _, pivotTable := range pivotTables {
+			pc, err := f.pivotCacheReader(pivotTable.pivotCacheXML)
+			if err != nil {
+				return deps, err
+			}
+			dataRange := pivotTable.DataRange
+			if src := pc.CacheSource.WorksheetSource; src != nil && src.Name == "" {
+				dataRange = escapeSheetName(src.Sheet) + "!" + src.Ref
+			}
+			if isFormulaReferSheet(dataRange, sheet) || (pivotTable.namedDataRange && isFormulaReferSheet(pivotTable.pivotDataRange, sheet)) {
+				deps = append(deps, SheetDependency{Type: SheetDependencyPivotTable, Sheet: name, Name: pivotTable.Name, RefersTo: dataRange})
+			}
+		}
+	}
+	return deps, nil
+}
+
+// getSheetChartPaths provides a function to get the part paths of the charts
+// in the worksheet or chart sheet by given sheet name.
+func (f *File) getSheetChartPaths(sheet string) ([]string, error) {
+	var charts []string
+	if sheetXMLPath, _ := f.getSheetXMLPath(sheet); strings.HasPrefix(sheetXMLPath, "xl/chartsheets") {
+		cs, _, err := f.chartSheetReader(sheet)
+		if err != nil {
+			return charts, err
+		}
+		if chartXML := f.getChartSheetChartPath(sheet, cs); chartXML != "" {
+			charts = append(charts, chartXML)
+		}
+		return charts, err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		if _, ok := err.(ErrNotWorksheet); ok {
+			err = nil
+		}
+		return charts, err
+	}
+	if ws.Drawing == nil {
+		return charts, err
+	}
+	drawingXML := strings.ReplaceAll(f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID), "..", "xl")
+	drawingRels, err := f.relsReader("xl/drawings/_rels/" + path.Base(drawingXML) + ".rels")
+	if err != nil || drawingRels == nil {
+		return charts, err
+	}
+	drawingRels.mu.Lock()
+	defer drawingRels.mu.Unlock()
+	for _, rel := range drawingRels.Relationships {
+		if rel.Type == SourceRelationshipChart {
+			charts = append(charts, strings.TrimPrefix(strings.ReplaceAll(rel.Target, "..", "xl"), "/"))
+		}
+	}
+	return charts, err
+}
+
 // DeleteSheet provides a function to delete worksheet in a workbook by given
 // worksheet name. Use this method with caution, which will affect changes in
 // references such as formulas, charts, and so on. If there is any referenced
 // value of the deleted worksheet, it will cause a file error when you open
-// it. This function will be invalid when only one worksheet is left.
-func (f *File) DeleteSheet(sheet string) error {
+// it. This function will be invalid when only one worksheet is left. The
+// optional settings for handling the references to the deleted sheet are:
+//
+// CheckDependencies specifies if refuse to delete the sheet which is
+// referenced by the other parts of the workbook, the ErrSheetDependencies
+// error will be returned with the dependencies in this case.
+//
+// Cascade specifies if replace the references to the deleted sheet in the
+// formulas, defined names, chart series, conditional formats, data
+// validations and hyperlinks with the #REF! error, in the same way as the
+// spreadsheet application. The pivot tables based on the deleted sheet will
+// keep their cached data. This setting will be ignored if the
+// CheckDependencies was true.
+//
+// For example, delete Sheet2 only if there is no reference to it:
+//
+//	err := f.DeleteSheet("Sheet2", excelize.DeleteSheetOptions{
+//	    CheckDependencies: true,
+//	})
+func (f *File) DeleteSheet(sheet string, opts ...DeleteSheetOptions) error {
 	if err := checkSheetName(sheet); err != nil {
 		return err
 	}
-	if idx, _ := f.GetSheetIndex(sheet); f.SheetCount == 1 || idx == -1 {
+	idx, _ := f.GetSheetIndex(sheet)
+	if f.SheetCount == 1 || idx == -1 {
 		return nil
 	}
-
+	for _, o := range opts {
+		if o.CheckDependencies {
+			deps, err := f.GetSheetDependencies(sheet)
+			if err != nil {
+				return err
+			}
+			if len(deps) > 0 {
+				return ErrSheetDependencies{SheetName: sheet, Dependencies: deps}
+			}
+			continue
+		}
+		if o.Cascade {
+			wb, err := f.workbookReader()
+			if err != nil {
+				return err
+			}
+			if err = f.adjustSheetReferences(wb, map[string]string{f.GetSheetName(idx): ""}); err != nil {
+				return err
+			}
+		}
+	}
 	wb, _ := f.workbookReader()
 	wbRels, _ := f.relsReader(f.getWorkbookRelsPath())
 	activeSheetName := f.GetSheetName(f.GetActiveSheetIndex())
@@ -593,6 +999,8 @@ func (f *File) DeleteSheet(sheet string) error {
 		f.Relationships.Delete(rels)
 		f.Sheet.Delete(sheetXML)
 		f.xmlAttr.Delete(sheetXML)
+		f.brokenSheets.Delete(sheetXML)
+		f.tags.Delete(sheetXML)
 		f.SheetCount--
 	}
 	index, err := f.GetSheetIndex(activeSheetName)
@@ -681,6 +1089,26 @@ func (f *File) getSheetRelationshipsTargetByID(sheet, rID string) string {
 	return ""
 }
 
+// getSheetRelationshipsTargetByType provides a function to get Target
+// attribute value in xl/worksheets/_rels/sheet%d.xml.rels by given worksheet
+// name, relationship index and relationship type.
+func (f *File) getSheetRelationshipsTargetByType(sheet, rID, relType string) string {
+	name, _ := f.getSheetXMLPath(sheet)
+	rels := "xl/worksheets/_rels/" + strings.TrimPrefix(name, "xl/worksheets/") + ".rels"
+	sheetRels, _ := f.relsReader(rels)
+	if sheetRels == nil {
+		return ""
+	}
+	sheetRels.mu.Lock()
+	defer sheetRels.mu.Unlock()
+	for _, v := range sheetRels.Relationships {
+		if v.ID == rID && v.Type == relType {
+			return v.Target
+		}
+	}
+	return ""
+}
+
 // CopySheet provides a function to duplicate a worksheet by gave source and
 // target worksheet index. Note that currently doesn't support duplicate
 // workbooks that contain tables, charts or pictures. For Example:
@@ -728,59 +1156,121 @@ func (f *File) copySheet(from, to int) error {
 	return err
 }
 
-// getSheetState returns sheet visible enumeration by given hidden status.
-func getSheetState(visible bool, veryHidden []bool) string {
-	state := "hidden"
-	if !visible && len(veryHidden) > 0 && veryHidden[0] {
-		state = "veryHidden"
-	}
-	return state
+// SheetState is the type of sheet visible state.
+type SheetState byte
+
+// Sheet visible states enumeration.
+const (
+	SheetStateVisible SheetState = iota
+	SheetStateHidden
+	SheetStateVeryHidden
+)
+
+// sheetStates mapping the sheet visible state enumeration and the value of
+// the state attribute.
+var sheetStates = map[SheetState]string{
+	SheetStateVisible:    "visible",
+	SheetStateHidden:     "hidden",
+	SheetStateVeryHidden: "veryHidden",
 }
 
 // SetSheetVisible provides a function to set worksheet visible by given
-// worksheet name. A workbook must contain at least one visible worksheet. If
-// the given worksheet has been activated, this setting will be invalidated.
-// The third optional veryHidden parameter only works when visible was false.
+// worksheet name. A workbook must contain at least one visible worksheet, an
+// error will be returned if hide the last visible sheet. If the given
+// worksheet has been activated, this setting will be invalidated. The third
+// optional veryHidden parameter only works when visible was false. Use the
+// SetSheetState function to set the sheet visible state directly.
 //
 // For example, hide Sheet1:
 //
 //	err := f.SetSheetVisible("Sheet1", false)
 func (f *File) SetSheetVisible(sheet string, visible bool, veryHidden ...bool) error {
+	state := SheetStateVisible
+	if !visible {
+		if state = SheetStateHidden; len(veryHidden) > 0 && veryHidden[0] {
+			state = SheetStateVeryHidden
+		}
+	}
+	return f.SetSheetState(sheet, state)
+}
+
+// SetSheetState provides a function to set the visible state of the sheet by
+// given sheet name and state. The sheet with SheetStateVeryHidden state can't
+// be unhidden from the user interface of the spreadsheet application. A
+// workbook must contain at least one visible sheet, the ErrLastVisibleSheet
+// error will be returned if hide the last visible sheet. If the given
+// worksheet has been activated, this setting will be invalidated. For example,
+// set the Sheet2 to very hidden:
+//
+//	err := f.SetSheetState("Sheet2", excelize.SheetStateVeryHidden)
+func (f *File) SetSheetState(sheet string, state SheetState) error {
 	if err := checkSheetName(sheet); err != nil {
 		return err
 	}
+	value, ok := sheetStates[state]
+	if !ok {
+		return ErrParameterInvalid
+	}
 	wb, err := f.workbookReader()
 	if err != nil {
 		return err
 	}
-	if visible {
-		for k, v := range wb.Sheets.Sheet {
-			if strings.EqualFold(v.Name, sheet) {
-				wb.Sheets.Sheet[k].State = ""
-			}
+	idx, visibleCount := -1, 0
+	for k, v := range wb.Sheets.Sheet {
+		if strings.EqualFold(v.Name, sheet) {
+			idx = k
+			continue
 		}
+		if v.State == "" || v.State == sheetStates[SheetStateVisible] {
+			visibleCount++
+		}
+	}
+	if idx == -1 {
+		return ErrSheetNotExist{sheet}
+	}
+	if state == SheetStateVisible {
+		wb.Sheets.Sheet[idx].State = ""
 		return err
 	}
-	count, state := 0, getSheetState(visible, veryHidden)
-	for _, v := range wb.Sheets.Sheet {
-		if v.State != state {
-			count++
-		}
+	if visibleCount == 0 {
+		return ErrLastVisibleSheet
 	}
-	for k, v := range wb.Sheets.Sheet {
-		ws, err := f.workSheetReader(v.Name)
-		if err != nil {
+	ws, err := f.workSheetReader(wb.Sheets.Sheet[idx].Name)
+	if err != nil {
+		if _, ok := err.(ErrNotWorksheet); !ok {
 			return err
 		}
-		tabSelected := false
-		if len(ws.SheetViews.SheetView) > 0 {
-			tabSelected = ws.SheetViews.SheetView[0].TabSelected
-		}
-		if strings.EqualFold(v.Name, sheet) && count > 1 && !tabSelected {
-			wb.Sheets.Sheet[k].State = state
+	}
+	if ws != nil && ws.SheetViews != nil && len(ws.SheetViews.SheetView) > 0 && ws.SheetViews.SheetView[0].TabSelected {
+		return nil
+	}
+	wb.Sheets.Sheet[idx].State = value
+	return nil
+}
+
+// GetSheetState provides a function to get the visible state of the sheet by
+// given sheet name. For example, get the visible state of Sheet2:
+//
+//	state, err := f.GetSheetState("Sheet2")
+func (f *File) GetSheetState(sheet string) (SheetState, error) {
+	if err := checkSheetName(sheet); err != nil {
+		return SheetStateVisible, err
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return SheetStateVisible, err
+	}
+	for _, v := range wb.Sheets.Sheet {
+		if strings.EqualFold(v.Name, sheet) {
+			for state, value := range sheetStates {
+				if v.State == value {
+					return state, err
+				}
+			}
+			return SheetStateVisible, err
 		}
 	}
-	return err
+	return SheetStateVisible, ErrSheetNotExist{sheet}
 }
 
 // setPanes set create freeze panes and split panes by given options.
@@ -1410,39 +1900,316 @@ func (f *File) UnprotectSheet(sheet string, password ...string) error {
 	return err
 }
 
-// checkSheetName check whether there are illegal characters in the sheet name.
-// 1. Confirm that the sheet name is not empty
-// 2. Make sure to enter a name with no more than 31 characters
-// 3. Make sure the first or last character of the name cannot be a single quote
-// 4. Verify that the following characters are not included in the name :\/?*[]
-func checkSheetName(name string) error {
-	if name == "" {
-		return ErrSheetNameBlank
+// AddAllowEditRange provides a function to add a range which allowed to be
+// edited by users when the worksheet is protected, that corresponding to the
+// "Allow Edit Ranges" in Excel. The title of the range is required and must
+// be unique in the worksheet, multiple cell ranges are separated by space.
+// Set an empty password to edit the range without password, otherwise the
+// password will be saved by SHA-512 hash. For example, allow edit range
+// A1:B2 and D1:D5 in Sheet1 with password:
+//
+//	err := f.AddAllowEditRange("Sheet1", "Range1", "A1:B2 D1:D5", "password")
+func (f *File) AddAllowEditRange(sheet, title, ref, password string) error {
+	if title == "" || ref == "" {
+		return ErrParameterRequired
 	}
-	if utf8.RuneCountInString(name) > MaxSheetNameLength {
-		return ErrSheetNameLength
+	sqref, err := checkAllowEditRangeRef(ref)
+	if err != nil {
+		return err
 	}
-	if strings.HasPrefix(name, "'") || strings.HasSuffix(name, "'") {
-		return ErrSheetNameSingleQuote
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
 	}
-	if strings.ContainsAny(name, ":\\/?*[]") {
-		return ErrSheetNameInvalid
+	if ws.ProtectedRanges == nil {
+		ws.ProtectedRanges = &xlsxProtectedRanges{}
 	}
-	return nil
+	for _, rng := range ws.ProtectedRanges.ProtectedRange {
+		if strings.EqualFold(rng.Name, title) {
+			return ErrExistsAllowEditRange
+		}
+	}
+	protectedRange := &xlsxProtectedRange{Sqref: sqref, Name: title}
+	if password != "" {
+		hashValue, saltValue, err := genISOPasswdHash(password, "SHA-512", "", int(sheetProtectionSpinCount))
+		if err != nil {
+			return err
+		}
+		protectedRange.AlgorithmName = "SHA-512"
+		protectedRange.HashValue = hashValue
+		protectedRange.SaltValue = saltValue
+		protectedRange.SpinCount = int(sheetProtectionSpinCount)
+	}
+	ws.ProtectedRanges.ProtectedRange = append(ws.ProtectedRanges.ProtectedRange, protectedRange)
+	return err
 }
 
-// SetPageLayout provides a function to sets worksheet page layout.
-//
-// The following shows the paper size sorted by excelize index number:
-//
-//	 Index | Paper Size
-//	-------+-----------------------------------------------
-//	   1   | Letter paper (8.5 in. by 11 in.)
-//	   2   | Letter small paper (8.5 in. by 11 in.)
-//	   3   | Tabloid paper (11 in. by 17 in.)
-//	   4   | Ledger paper (17 in. by 11 in.)
-//	   5   | Legal paper (8.5 in. by 14 in.)
-//	   6   | Statement paper (5.5 in. by 8.5 in.)
+// checkAllowEditRangeRef provides a function to check the cell reference of
+// allow edit range, and returns the reference without absolute symbols.
+func checkAllowEditRangeRef(ref string) (string, error) {
+	var refs []string
+	for _, cellRef := range strings.Fields(strings.ReplaceAll(ref, "$", "")) {
+		cells := strings.Split(cellRef, ":")
+		if len(cells) > 2 {
+			return "", ErrParameterInvalid
+		}
+		for _, cell := range cells {
+			if _, _, err := CellNameToCoordinates(cell); err != nil {
+				return "", err
+			}
+		}
+		refs = append(refs, cellRef)
+	}
+	return strings.Join(refs, " "), nil
+}
+
+// GetAllowEditRanges provides a function to get all ranges which allowed to
+// be edited when the worksheet is protected by given worksheet name.
+func (f *File) GetAllowEditRanges(sheet string) ([]AllowEditRange, error) {
+	var ranges []AllowEditRange
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.ProtectedRanges == nil {
+		return ranges, err
+	}
+	for _, rng := range ws.ProtectedRanges.ProtectedRange {
+		ranges = append(ranges, AllowEditRange{
+			Title:       rng.Name,
+			Range:       rng.Sqref,
+			HasPassword: rng.Password != "" || rng.HashValue != "",
+		})
+	}
+	return ranges, err
+}
+
+// DeleteAllowEditRange provides a function to delete the range which allowed
+// to be edited when the worksheet is protected by given worksheet name and
+// range title.
+func (f *File) DeleteAllowEditRange(sheet, title string) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if ws.ProtectedRanges != nil {
+		for i, rng := range ws.ProtectedRanges.ProtectedRange {
+			if !strings.EqualFold(rng.Name, title) {
+				continue
+			}
+			ws.ProtectedRanges.ProtectedRange = append(ws.ProtectedRanges.ProtectedRange[:i], ws.ProtectedRanges.ProtectedRange[i+1:]...)
+			if len(ws.ProtectedRanges.ProtectedRange) == 0 {
+				ws.ProtectedRanges = nil
+			}
+			return err
+		}
+	}
+	return newNoExistAllowEditRangeError(title)
+}
+
+// AddScenario provides a function to add a what-if analysis scenario to the
+// worksheet by given worksheet name and scenario settings, that corresponding
+// to the "Scenario Manager" in Excel. The name of the scenario is required and
+// must be unique in the worksheet, and the scenario must contain 1 to 32
+// changing cells. For example, add the best and worst cases for the growth
+// rate and the cost in Sheet1:
+//
+//	err := f.AddScenario("Sheet1", &excelize.Scenario{
+//	    Name: "Best Case",
+//	    Cells: []excelize.ScenarioCell{
+//	        {Cell: "B1", Value: "0.15"},
+//	        {Cell: "B2", Value: "1000"},
+//	    },
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.AddScenario("Sheet1", &excelize.Scenario{
+//	    Name:    "Worst Case",
+//	    Comment: "Low growth with high cost",
+//	    Locked:  true,
+//	    Cells: []excelize.ScenarioCell{
+//	        {Cell: "B1", Value: "0.02"},
+//	        {Cell: "B2", Value: "1800"},
+//	    },
+//	})
+func (f *File) AddScenario(sheet string, scenario *Scenario) error {
+	if scenario == nil || scenario.Name == "" {
+		return ErrParameterRequired
+	}
+	if utf8.RuneCountInString(scenario.Name) > MaxFieldLength {
+		return ErrNameLength
+	}
+	if len(scenario.Cells) == 0 || len(scenario.Cells) > MaxScenarioCells {
+		return ErrScenarioCells
+	}
+	item := &xlsxScenario{
+		Name:    scenario.Name,
+		Locked:  scenario.Locked,
+		Hidden:  scenario.Hidden,
+		Count:   len(scenario.Cells),
+		User:    scenario.User,
+		Comment: scenario.Comment,
+	}
+	for _, c := range scenario.Cells {
+		col, row, err := CellNameToCoordinates(strings.ReplaceAll(c.Cell, "$", ""))
+		if err != nil {
+			return err
+		}
+		cell, _ := CoordinatesToCellName(col, row)
+		item.InputCells = append(item.InputCells, &xlsxInputCells{R: cell, Val: c.Value})
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if ws.Scenarios == nil {
+		ws.Scenarios = &xlsxScenarios{}
+	}
+	for _, s := range ws.Scenarios.Scenario {
+		if strings.EqualFold(s.Name, scenario.Name) {
+			return ErrExistsScenario
+		}
+	}
+	ws.Scenarios.Scenario = append(ws.Scenarios.Scenario, item)
+	ws.Scenarios.adjustSqref()
+	return err
+}
+
+// adjustSqref provides a function to update the reference sequence of the
+// changing cells in all scenarios of the worksheet.
+func (s *xlsxScenarios) adjustSqref() {
+	var refs []string
+	for _, scenario := range s.Scenario {
+		for _, c := range scenario.InputCells {
+			if inStrSlice(refs, c.R, true) == -1 {
+				refs = append(refs, c.R)
+			}
+		}
+	}
+	s.Sqref = strings.Join(refs, " ")
+}
+
+// GetScenarios provides a function to get all what-if analysis scenarios of
+// the worksheet by given worksheet name.
+func (f *File) GetScenarios(sheet string) ([]Scenario, error) {
+	var scenarios []Scenario
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.Scenarios == nil {
+		return scenarios, err
+	}
+	for _, s := range ws.Scenarios.Scenario {
+		scenario := Scenario{
+			Name:    s.Name,
+			Comment: s.Comment,
+			User:    s.User,
+			Locked:  s.Locked,
+			Hidden:  s.Hidden,
+		}
+		for _, c := range s.InputCells {
+			scenario.Cells = append(scenario.Cells, ScenarioCell{Cell: c.R, Value: c.Val})
+		}
+		scenarios = append(scenarios, scenario)
+	}
+	return scenarios, err
+}
+
+// ShowScenario provides a function to apply the input values of the
+// what-if analysis scenario to the changing cells by given worksheet name and
+// scenario name, the numeric input values will be set as numbers, and the
+// others will be set as strings. For example, show the scenario named
+// "Best Case" in Sheet1:
+//
+//	err := f.ShowScenario("Sheet1", "Best Case")
+func (f *File) ShowScenario(sheet, name string) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if ws.Scenarios != nil {
+		for i, s := range ws.Scenarios.Scenario {
+			if !strings.EqualFold(s.Name, name) {
+				continue
+			}
+			for _, c := range s.InputCells {
+				if _, err = strconv.ParseFloat(c.Val, 64); err == nil {
+					err = f.SetCellDefault(sheet, c.R, c.Val)
+				} else {
+					err = f.SetCellStr(sheet, c.R, c.Val)
+				}
+				if err != nil {
+					return err
+				}
+			}
+			ws.Scenarios.Current, ws.Scenarios.Show = intPtr(i), intPtr(i)
+			return err
+		}
+	}
+	return newNoExistScenarioError(name)
+}
+
+// DeleteScenario provides a function to delete the what-if analysis scenario
+// by given worksheet name and scenario name.
+func (f *File) DeleteScenario(sheet, name string) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if ws.Scenarios != nil {
+		for i, s := range ws.Scenarios.Scenario {
+			if !strings.EqualFold(s.Name, name) {
+				continue
+			}
+			ws.Scenarios.Scenario = append(ws.Scenarios.Scenario[:i], ws.Scenarios.Scenario[i+1:]...)
+			if len(ws.Scenarios.Scenario) == 0 {
+				ws.Scenarios = nil
+				return err
+			}
+			for _, idx := range []**int{&ws.Scenarios.Current, &ws.Scenarios.Show} {
+				if *idx != nil && **idx == i {
+					*idx = nil
+				} else if *idx != nil && **idx > i {
+					*idx = intPtr(**idx - 1)
+				}
+			}
+			ws.Scenarios.adjustSqref()
+			return err
+		}
+	}
+	return newNoExistScenarioError(name)
+}
+
+// checkSheetName check whether there are illegal characters in the sheet name.
+// 1. Confirm that the sheet name is not empty
+// 2. Make sure to enter a name with no more than 31 characters
+// 3. Make sure the first or last character of the name cannot be a single quote
+// 4. Verify that the following characters are not included in the name :\/?*[]
+func checkSheetName(name string) error {
+	if name == "" {
+		return ErrSheetNameBlank
+	}
+	if utf8.RuneCountInString(name) > MaxSheetNameLength {
+		return ErrSheetNameLength
+	}
+	if strings.HasPrefix(name, "'") || strings.HasSuffix(name, "'") {
+		return ErrSheetNameSingleQuote
+	}
+	if strings.ContainsAny(name, ":\\/?*[]") {
+		return ErrSheetNameInvalid
+	}
+	return nil
+}
+
+// SetPageLayout provides a function to sets worksheet page layout.
+//
+// The following shows the paper size sorted by excelize index number:
+//
+//	 Index | Paper Size
+//	-------+-----------------------------------------------
+//	   1   | Letter paper (8.5 in. by 11 in.)
+//	   2   | Letter small paper (8.5 in. by 11 in.)
+//	   3   | Tabloid paper (11 in. by 17 in.)
+//	   4   | Ledger paper (17 in. by 11 in.)
+//	   5   | Legal paper (8.5 in. by 14 in.)
+//	   6   | Statement paper (5.5 in. by 8.5 in.)
 //	   7   | Executive paper (7.25 in. by 10.5 in.)
 //	   8   | A3 paper (297 mm by 420 mm)
 //	   9   | A4 paper (210 mm by 297 mm)
@@ -1583,27 +2350,65 @@ func (ws *xlsxWorksheet) setPageSetUp(opts *PageLayoutOptions) {
 		ws.newPageSetUp()
 		ws.PageSetUp.Orientation = *opts.Orientation
 	}
-	if opts.FirstPageNumber != nil && *opts.FirstPageNumber > 0 {
+	if opts.FirstPageNumber != nil {
 		ws.newPageSetUp()
-		ws.PageSetUp.FirstPageNumber = strconv.Itoa(int(*opts.FirstPageNumber))
-		ws.PageSetUp.UseFirstPageNumber = true
+		ws.PageSetUp.FirstPageNumber, ws.PageSetUp.UseFirstPageNumber = "", false
+		if *opts.FirstPageNumber > 0 {
+			ws.PageSetUp.FirstPageNumber = strconv.Itoa(int(*opts.FirstPageNumber))
+			ws.PageSetUp.UseFirstPageNumber = true
+		}
 	}
 	if opts.AdjustTo != nil && 10 <= *opts.AdjustTo && *opts.AdjustTo <= 400 {
 		ws.newPageSetUp()
 		ws.PageSetUp.Scale = int(*opts.AdjustTo)
+		ws.setFitToPage(false)
 	}
 	if opts.FitToHeight != nil {
 		ws.newPageSetUp()
 		ws.PageSetUp.FitToHeight = opts.FitToHeight
+		ws.setFitToPage(true)
 	}
 	if opts.FitToWidth != nil {
 		ws.newPageSetUp()
 		ws.PageSetUp.FitToWidth = opts.FitToWidth
+		ws.setFitToPage(true)
+	}
+	if opts.PaperWidth != nil && opts.PaperHeight != nil &&
+		isValidPaperDimension(*opts.PaperWidth) && isValidPaperDimension(*opts.PaperHeight) {
+		ws.newPageSetUp()
+		ws.PageSetUp.PaperWidth = *opts.PaperWidth
+		ws.PageSetUp.PaperHeight = *opts.PaperHeight
 	}
 	if opts.BlackAndWhite != nil {
 		ws.newPageSetUp()
 		ws.PageSetUp.BlackAndWhite = *opts.BlackAndWhite
 	}
+	if opts.Draft != nil {
+		ws.newPageSetUp()
+		ws.PageSetUp.Draft = *opts.Draft
+	}
+	if opts.CellComments != nil && inStrSlice([]string{"none", "atEnd", "asDisplayed"}, *opts.CellComments, true) != -1 {
+		ws.newPageSetUp()
+		ws.PageSetUp.CellComments = *opts.CellComments
+	}
+	if opts.Errors != nil && inStrSlice([]string{"displayed", "blank", "dash", "NA"}, *opts.Errors, true) != -1 {
+		ws.newPageSetUp()
+		ws.PageSetUp.Errors = *opts.Errors
+	}
+}
+
+// setFitToPage provides a function to set the fit to page option of the
+// worksheet, the print scaling and fit to pages settings are mutually
+// exclusive.
+func (ws *xlsxWorksheet) setFitToPage(fitToPage bool) {
+	if !fitToPage && (ws.SheetPr == nil || ws.SheetPr.PageSetUpPr == nil) {
+		return
+	}
+	ws.prepareSheetPr()
+	if ws.SheetPr.PageSetUpPr == nil {
+		ws.SheetPr.PageSetUpPr = new(xlsxPageSetUpPr)
+	}
+	ws.SheetPr.PageSetUpPr.FitToPage = fitToPage
 }
 
 // GetPageLayout provides a function to gets worksheet page layout.
@@ -1618,28 +2423,144 @@ func (f *File) GetPageLayout(sheet string) (PageLayoutOptions, error) {
 	if err != nil {
 		return opts, err
 	}
-	if ws.PageSetUp != nil {
-		if ws.PageSetUp.PaperSize != nil {
-			opts.Size = ws.PageSetUp.PaperSize
+	return getPageLayout(ws.PageSetUp), err
+}
+
+// SetPrinterSettings provides a function to attach the printer settings binary
+// part for the worksheet by given worksheet name and the content of the
+// printer settings binary part, which usually copied from the workbook saved
+// by the spreadsheet application with the specified printer. The existing
+// printer settings binary part of the worksheet will be replaced, and set an
+// empty content to remove it. For example:
+//
+//	data, err := os.ReadFile("printerSettings1.bin")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err := f.SetPrinterSettings("Sheet1", data); err != nil {
+//	    fmt.Println(err)
+//	}
+func (f *File) SetPrinterSettings(sheet string, data []byte) error {
+	if len(data) == 0 {
+		return f.removePrinterSettings(sheet)
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	ws.newPageSetUp()
+	if ws.PageSetUp.RID != "" {
+		if target := f.getPrinterSettingsPath(sheet, ws.PageSetUp.RID); target != "" {
+			f.Pkg.Store(target, data)
+			return err
+		}
+	}
+	printerSettingsID := f.getPrinterSettingsID()
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/worksheets/") + ".rels"
+	rID := f.addRels(sheetRels, SourceRelationshipPrinterSettings, "../printerSettings/printerSettings"+strconv.Itoa(printerSettingsID)+".bin", "")
+	f.addSheetNameSpace(sheet, SourceRelationship)
+	ws.PageSetUp.RID = "rId" + strconv.Itoa(rID)
+	f.Pkg.Store("xl/printerSettings/printerSettings"+strconv.Itoa(printerSettingsID)+".bin", data)
+	return f.addContentTypePart(printerSettingsID, "printerSettings")
+}
+
+// GetPrinterSettings provides a function to get the content of the printer
+// settings binary part of the worksheet by given worksheet name. This function
+// returns empty content if the worksheet has no printer settings.
+func (f *File) GetPrinterSettings(sheet string) ([]byte, error) {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.PageSetUp == nil || ws.PageSetUp.RID == "" {
+		return nil, err
+	}
+	if content, ok := f.Pkg.Load(f.getPrinterSettingsPath(sheet, ws.PageSetUp.RID)); ok {
+		return content.([]byte), err
+	}
+	return nil, err
+}
+
+// getPrinterSettingsPath provides a function to get the package path of the
+// printer settings binary part by given worksheet name and relationship ID.
+func (f *File) getPrinterSettingsPath(sheet, rID string) string {
+	target := f.getSheetRelationshipsTargetByID(sheet, rID)
+	if target == "" {
+		return target
+	}
+	if !strings.HasPrefix(target, "/") {
+		target = "xl" + strings.TrimPrefix(target, "..")
+	}
+	return strings.TrimPrefix(target, "/")
+}
+
+// getPrinterSettingsID provides a function to get the first unused ID of the
+// printer settings binary parts in the folder xl/printerSettings, so that the
+// existing part will not be overwritten after a part has been removed.
+func (f *File) getPrinterSettingsID() int {
+	printerSettingsID := 1
+	for {
+		if _, ok := f.Pkg.Load("xl/printerSettings/printerSettings" + strconv.Itoa(printerSettingsID) + ".bin"); !ok {
+			return printerSettingsID
 		}
-		if ws.PageSetUp.Orientation != "" {
-			opts.Orientation = stringPtr(ws.PageSetUp.Orientation)
+		printerSettingsID++
+	}
+}
+
+// isValidPaperDimension provides a function to check whether the custom paper
+// width or height is a positive number followed by a unit of measurement,
+// such as "210mm" or "8.5in".
+func isValidPaperDimension(val string) bool {
+	for _, unit := range []string{"mm", "cm", "in", "pt", "pc", "pi"} {
+		if strings.HasSuffix(val, unit) {
+			n, err := strconv.ParseFloat(strings.TrimSuffix(val, unit), 64)
+			return err == nil && n > 0
 		}
-		if num, _ := strconv.Atoi(ws.PageSetUp.FirstPageNumber); num != 0 {
+	}
+	return false
+}
+
+// getPageLayout provides a function to get page layout settings by given page
+// setup settings of the worksheet or chart sheet.
+func getPageLayout(pageSetUp *xlsxPageSetUp) PageLayoutOptions {
+	opts := PageLayoutOptions{
+		Size:            intPtr(0),
+		Orientation:     stringPtr("portrait"),
+		FirstPageNumber: uintPtr(1),
+		AdjustTo:        uintPtr(100),
+	}
+	if pageSetUp != nil {
+		if pageSetUp.PaperSize != nil {
+			opts.Size = pageSetUp.PaperSize
+		}
+		if pageSetUp.Orientation != "" {
+			opts.Orientation = stringPtr(pageSetUp.Orientation)
+		}
+		if pageSetUp.PaperWidth != "" && pageSetUp.PaperHeight != "" {
+			opts.PaperWidth = stringPtr(pageSetUp.PaperWidth)
+			opts.PaperHeight = stringPtr(pageSetUp.PaperHeight)
+		}
+		if num, _ := strconv.Atoi(pageSetUp.FirstPageNumber); num != 0 {
 			opts.FirstPageNumber = uintPtr(uint(num))
 		}
-		if ws.PageSetUp.Scale >= 10 && ws.PageSetUp.Scale <= 400 {
-			opts.AdjustTo = uintPtr(uint(ws.PageSetUp.Scale))
+		if pageSetUp.Scale >= 10 && pageSetUp.Scale <= 400 {
+			opts.AdjustTo = uintPtr(uint(pageSetUp.Scale))
+		}
+		if pageSetUp.FitToHeight != nil {
+			opts.FitToHeight = pageSetUp.FitToHeight
+		}
+		if pageSetUp.FitToWidth != nil {
+			opts.FitToWidth = pageSetUp.FitToWidth
 		}
-		if ws.PageSetUp.FitToHeight != nil {
-			opts.FitToHeight = ws.PageSetUp.FitToHeight
+		opts.BlackAndWhite = boolPtr(pageSetUp.BlackAndWhite)
+		opts.Draft = boolPtr(pageSetUp.Draft)
+		if pageSetUp.CellComments != "" {
+			opts.CellComments = stringPtr(pageSetUp.CellComments)
 		}
-		if ws.PageSetUp.FitToWidth != nil {
-			opts.FitToWidth = ws.PageSetUp.FitToWidth
+		if pageSetUp.Errors != "" {
+			opts.Errors = stringPtr(pageSetUp.Errors)
 		}
-		opts.BlackAndWhite = boolPtr(ws.PageSetUp.BlackAndWhite)
 	}
-	return opts, err
+	return opts
 }
 
 // SetDefinedName provides a function to set the defined names of the workbook
@@ -1746,6 +2667,109 @@ func (f *File) GetDefinedName() []DefinedName {
 	return definedNames
 }
 
+// GenerateTOCSheet provides a function to create a table of contents
+// worksheet by given worksheet name and options, which lists the hyperlinks
+// to every worksheet in the workbook in the order of the sheet tabs. The
+// worksheet with the given name should not exist in the workbook. The
+// optional settings of the table of contents are:
+//
+// Title specifies the text in the first row of the table of contents
+// worksheet, the default value is "Table of Contents".
+//
+// IncludeHidden specifies if list the hidden worksheets, the hidden worksheets
+// will be skipped by default.
+//
+// IncludeDefinedNames specifies if list the hyperlinks to the defined names
+// after the worksheets, the built-in defined names such as "_xlnm.Print_Area"
+// will be skipped.
+//
+// Chart sheets and dialog sheets will be skipped because the hyperlink can't
+// navigate to them. For example, create a table of contents worksheet named
+// "Contents" with the defined names:
+//
+//	err := f.GenerateTOCSheet("Contents", &excelize.TOCOptions{
+//	    IncludeDefinedNames: true,
+//	})
+func (f *File) GenerateTOCSheet(name string, opts *TOCOptions) error {
+	if opts == nil {
+		opts = &TOCOptions{}
+	}
+	if opts.Title == "" {
+		opts.Title = "Table of Contents"
+	}
+	if err := checkSheetName(name); err != nil {
+		return err
+	}
+	if idx, _ := f.GetSheetIndex(name); idx != -1 {
+		return ErrExistsSheet
+	}
+	var sheets []string
+	for _, sheet := range f.GetSheetList() {
+		if _, err := f.workSheetReader(sheet); err != nil {
+			if _, ok := err.(ErrNotWorksheet); ok {
+				continue
+			}
+			return err
+		}
+		if visible, _ := f.GetSheetVisible(sheet); visible || opts.IncludeHidden {
+			sheets = append(sheets, sheet)
+		}
+	}
+	if _, err := f.NewSheet(name); err != nil {
+		return err
+	}
+	titleStyle, err := f.NewStyle(&Style{Font: &Font{Bold: true, Size: 14}})
+	if err != nil {
+		return err
+	}
+	linkStyle, err := f.NewStyle(&Style{Font: &Font{Color: "0563C1", Underline: "single"}})
+	if err != nil {
+		return err
+	}
+	if err = f.SetCellStr(name, "A1", opts.Title); err != nil {
+		return err
+	}
+	if err = f.SetCellStyle(name, "A1", "A1", titleStyle); err != nil {
+		return err
+	}
+	row := 2
+	addLink := func(text, location string) error {
+		cell, err := CoordinatesToCellName(1, row)
+		if err != nil {
+			return err
+		}
+		row++
+		if err = f.SetCellStr(name, cell, text); err != nil {
+			return err
+		}
+		if err = f.SetCellHyperLink(name, cell, location, "Location"); err != nil {
+			return err
+		}
+		return f.SetCellStyle(name, cell, cell, linkStyle)
+	}
+	for _, sheet := range sheets {
+		if err = addLink(sheet, escapeSheetName(sheet)+"!A1"); err != nil {
+			return err
+		}
+	}
+	if opts.IncludeDefinedNames {
+		row++
+		for _, dn := range f.GetDefinedName() {
+			if strings.HasPrefix(dn.Name, "_xlnm.") {
+				continue
+			}
+			location := dn.Name
+			if dn.Scope != "Workbook" {
+				location = escapeSheetName(dn.Scope) + "!" + dn.Name
+			}
+			if err = addLink(dn.Name, location); err != nil {
+				return err
+			}
+		}
+	}
+	return f.SetColWidth(name, "A", "A", 30)
+}
+
 // GroupSheets provides a function to group worksheets by given worksheets
 // name. Group worksheets must contain an active worksheet.
 func (f *File) GroupSheets(sheets []string) error {
@@ -1959,6 +2983,7 @@ func (ws *xlsxWorksheet) prepareSheetXML(col int, row int) {
 		for rowIdx := rowCount; rowIdx < row; rowIdx++ {
 			ws.SheetData.Row = append(ws.SheetData.Row, xlsxRow{R: rowIdx + 1, CustomHeight: customHeight, Ht: ht, C: make([]xlsxC, 0, sizeHint)})
 		}
+		ws.rowIndex = nil
 	}
 	rowData := &ws.SheetData.Row[row-1]
 	fillColumns(rowData, col, row)
@@ -2019,15 +3044,520 @@ func (f *File) SetSheetDimension(sheet string, rangeRef string) error {
 	return err
 }
 
+// UpdateDimension provides the method to recalculate the used range of the
+// worksheet by given worksheet name from the cells which contain value,
+// formula or style. The used range will be set to "A1" if the worksheet
+// doesn't contain any cells. For example, recalculate the used range of the
+// worksheet named Sheet1:
+//
+//	err := f.UpdateDimension("Sheet1")
+func (f *File) UpdateDimension(sheet string) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	return ws.updateDimension()
+}
+
+// updateDimension provides a function to recalculate the used range of the
+// worksheet from the cells.
+func (ws *xlsxWorksheet) updateDimension() error {
+	coordinates := []int{0, 0, 0, 0}
+	for _, row := range ws.SheetData.Row {
+		for _, c := range row.C {
+			if !c.hasValue() {
+				continue
+			}
+			col, r, err := CellNameToCoordinates(c.R)
+			if err != nil {
+				return err
+			}
+			if coordinates[0] == 0 || col < coordinates[0] {
+				coordinates[0] = col
+			}
+			if coordinates[1] == 0 || r < coordinates[1] {
+				coordinates[1] = r
+			}
+			if col > coordinates[2] {
+				coordinates[2] = col
+			}
+			if r > coordinates[3] {
+				coordinates[3] = r
+			}
+		}
+	}
+	if coordinates[0] == 0 {
+		ws.Dimension = &xlsxDimension{Ref: "A1"}
+		return nil
+	}
+	ref, err := coordinatesToRangeRef(coordinates)
+	if coordinates[0] == coordinates[2] && coordinates[1] == coordinates[3] {
+		ref, err = CoordinatesToCellName(coordinates[0], coordinates[1])
+	}
+	ws.Dimension = &xlsxDimension{Ref: ref}
+	return err
+}
+
+// setRowSpans provides a function to set the spans attribute of the rows in
+// the worksheet, the spans specifies the range of non-empty columns for the
+// block of 16 rows to which the row belongs, which is an optimization hint for
+// the spreadsheet applications to load the worksheet faster.
+func (ws *xlsxWorksheet) setRowSpans() {
+	spans := make(map[int][]int)
+	for _, row := range ws.SheetData.Row {
+		for _, c := range row.C {
+			col, _, err := CellNameToCoordinates(c.R)
+			if err != nil {
+				continue
+			}
+			block := (row.R - 1) / 16
+			if span, ok := spans[block]; !ok {
+				spans[block] = []int{col, col}
+			} else if col < span[0] {
+				span[0] = col
+			} else if col > span[1] {
+				span[1] = col
+			}
+		}
+	}
+	for rowIdx := range ws.SheetData.Row {
+		row := &ws.SheetData.Row[rowIdx]
+		row.Spans = ""
+		if span, ok := spans[(row.R-1)/16]; ok {
+			row.Spans = fmt.Sprintf("%d:%d", span[0], span[1])
+		}
+	}
+}
+
+// TrimSheet provides the method to remove the trailing empty rows and cells
+// of the worksheet by given worksheet name, and recalculate the used range of
+// the worksheet. The rows and cells without value and formula in the tail of
+// the worksheet will be removed even if they have style or row properties,
+// this is useful for fixing the worksheet with an inflated used range. The
+// cell formats at the end of the cell formats table which were only used by
+// the removed rows and cells will be removed from the workbook, the style
+// index of the other cell formats will not be changed. For example, trim the
+// worksheet named Sheet1:
+//
+//	err := f.TrimSheet("Sheet1")
+func (f *File) TrimSheet(sheet string) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	ws.mu.Lock()
+	styleIDs, lastRow := make(map[int]bool), 0
+	for rowIdx := range ws.SheetData.Row {
+		row := &ws.SheetData.Row[rowIdx]
+		lastCell := 0
+		for cellIdx := range row.C {
+			if row.C[cellIdx].hasData() {
+				lastCell = cellIdx + 1
+			}
+		}
+		for _, c := range row.C[lastCell:] {
+			styleIDs[c.S] = true
+		}
+		if row.C = row.C[:lastCell]; lastCell > 0 {
+			lastRow = rowIdx + 1
+		}
+	}
+	for _, row := range ws.SheetData.Row[lastRow:] {
+		styleIDs[row.S] = true
+	}
+	ws.SheetData.Row, ws.rowIndex = ws.SheetData.Row[:lastRow], nil
+	err = ws.updateDimension()
+	ws.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return f.removeUnusedCellXfs(styleIDs)
+}
+
+// removeUnusedCellXfs provides a function to remove the trailing cell formats
+// by given style indexes which are not used by any worksheet in the workbook.
+// Only the cell formats at the end of the cell formats table will be removed,
+// so the index of the other cell formats will never be changed, and nothing
+// will be removed if there are stream writers in the workbook, since the
+// style indexes of the written rows are unknown.
+func (f *File) removeUnusedCellXfs(styleIDs map[int]bool) error {
+	delete(styleIDs, 0)
+	if len(styleIDs) == 0 || len(f.streams) > 0 {
+		return nil
+	}
+	for _, sheet := range f.GetSheetList() {
+		ws, err := f.workSheetReader(sheet)
+		if err != nil {
+			if errors.Is(err, ErrNotWorksheet{SheetName: sheet}) {
+				continue
+			}
+			return err
+		}
+		ws.mu.Lock()
+		ws.rangeStyleIDs(func(styleID *int) { delete(styleIDs, *styleID) })
+		ws.mu.Unlock()
+	}
+	if len(styleIDs) == 0 {
+		return nil
+	}
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	f.mu.Unlock()
+	if err != nil || s.CellXfs == nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	count := len(s.CellXfs.Xf)
+	for count > 1 && styleIDs[count-1] {
+		count--
+	}
+	s.CellXfs.Xf, s.CellXfs.Count = s.CellXfs.Xf[:count], count
+	for def, styleID := range s.styleIDs {
+		if styleID >= count {
+			delete(s.styleIDs, def)
+		}
+	}
+	for styleID, quoteID := range s.quoteIDs {
+		if styleID >= count || quoteID >= count {
+			delete(s.quoteIDs, styleID)
+		}
+	}
+	return err
+}
+
+// rangeStyleIDs provides a function to call the given function with the
+// pointer of each style index of the cells, rows and columns in the worksheet.
+func (ws *xlsxWorksheet) rangeStyleIDs(fn func(styleID *int)) {
+	for rowIdx := range ws.SheetData.Row {
+		row := &ws.SheetData.Row[rowIdx]
+		fn(&row.S)
+		for cellIdx := range row.C {
+			fn(&row.C[cellIdx].S)
+		}
+	}
+	if ws.Cols != nil {
+		for colIdx := range ws.Cols.Col {
+			fn(&ws.Cols.Col[colIdx].Style)
+		}
+	}
+}
+
 // GetSheetDimension provides the method to get the used range of the worksheet.
+// The used range will be recalculated from the cells if the AutoDimension
+// option has been set.
 func (f *File) GetSheetDimension(sheet string) (string, error) {
 	var ref string
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return ref, err
 	}
+	if f.options != nil && f.options.AutoDimension {
+		ws.mu.Lock()
+		err = ws.updateDimension()
+		ws.mu.Unlock()
+	}
 	if ws.Dimension != nil {
 		ref = ws.Dimension.Ref
 	}
 	return ref, err
 }
+
+// GetUsedRange provides a function to get the actual used range of the
+// worksheet by given worksheet name, which is computed by scanning the cells
+// which contain value or formula, instead of the dimension stored in the
+// worksheet. The empty string will be returned if the worksheet doesn't
+// contain any used cells. The cells with style, merged cells and the cells
+// covered by the drawing objects could be counted by the optional settings.
+// For example, get the used range of Sheet1 including the merged cells:
+//
+//	ref, err := f.GetUsedRange("Sheet1", excelize.UsedRangeOptions{
+//	    IncludeMergeCells: true,
+//	})
+func (f *File) GetUsedRange(sheet string, opts ...UsedRangeOptions) (string, error) {
+	var options UsedRangeOptions
+	for _, opt := range opts {
+		options = opt
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return "", err
+	}
+	coordinates := []int{0, 0, 0, 0}
+	extend := func(col, row int) {
+		if coordinates[0] == 0 || col < coordinates[0] {
+			coordinates[0] = col
+		}
+		if coordinates[1] == 0 || row < coordinates[1] {
+			coordinates[1] = row
+		}
+		if col > coordinates[2] {
+			coordinates[2] = col
+		}
+		if row > coordinates[3] {
+			coordinates[3] = row
+		}
+	}
+	ws.mu.Lock()
+	for _, row := range ws.SheetData.Row {
+		for _, c := range row.C {
+			if c.V == "" && c.F == nil && c.IS == nil && (!options.IncludeStyles || c.S == 0) {
+				continue
+			}
+			col, r, err := CellNameToCoordinates(c.R)
+			if err != nil {
+				ws.mu.Unlock()
+				return "", err
+			}
+			extend(col, r)
+		}
+	}
+	if options.IncludeMergeCells && ws.MergeCells != nil {
+		for _, mc := range ws.MergeCells.Cells {
+			if mc == nil {
+				continue
+			}
+			rect, err := rangeRefToCoordinates(mc.Ref)
+			if err != nil {
+				ws.mu.Unlock()
+				return "", err
+			}
+			extend(rect[0], rect[1])
+			extend(rect[2], rect[3])
+		}
+	}
+	ws.mu.Unlock()
+	if options.IncludeDrawings && ws.Drawing != nil {
+		if err = f.extendDrawingsUsedRange(sheet, ws.Drawing.RID, extend); err != nil {
+			return "", err
+		}
+	}
+	if coordinates[0] == 0 {
+		return "", err
+	}
+	if coordinates[0] == coordinates[2] && coordinates[1] == coordinates[3] {
+		return CoordinatesToCellName(coordinates[0], coordinates[1])
+	}
+	return coordinatesToRangeRef(coordinates)
+}
+
+// extendDrawingsUsedRange provides a function to extend the used range by the
+// cells covered by the drawing objects of the worksheet by given worksheet
+// name, drawing relationship ID and the callback function.
+func (f *File) extendDrawingsUsedRange(sheet, rID string, extend func(col, row int)) error {
+	target := f.getSheetRelationshipsTargetByID(sheet, rID)
+	drawingXML := strings.TrimPrefix(strings.ReplaceAll(target, "..", "xl"), "/")
+	wsDr, _, err := f.drawingParser(drawingXML)
+	if err != nil {
+		return err
+	}
+	wsDr.mu.Lock()
+	defer wsDr.mu.Unlock()
+	extendAnchor := func(fromCol, fromRow int, to *decodeTo) {
+		extend(fromCol+1, fromRow+1)
+		if to == nil {
+			return
+		}
+		toCol, toRow := to.Col+1, to.Row+1
+		if to.ColOff == 0 && to.Col > fromCol {
+			toCol--
+		}
+		if to.RowOff == 0 && to.Row > fromRow {
+			toRow--
+		}
+		extend(toCol, toRow)
+	}
+	for _, anchors := range [][]*xdrCellAnchor{wsDr.TwoCellAnchor, wsDr.OneCellAnchor} {
+		for _, anchor := range anchors {
+			if anchor.From != nil {
+				var to *decodeTo
+				if anchor.To != nil {
+					to = &decodeTo{Col: anchor.To.Col, ColOff: anchor.To.ColOff, Row: anchor.To.Row, RowOff: anchor.To.RowOff}
+				}
+				extendAnchor(anchor.From.Col, anchor.From.Row, to)
+				continue
+			}
+			deCellAnchor := new(decodeCellAnchor)
+			_ = f.xmlNewDecoder(strings.NewReader("<decodeCellAnchor>" + anchor.GraphicFrame + "</decodeCellAnchor>")).Decode(deCellAnchor)
+			if deCellAnchor.From != nil {
+				extendAnchor(deCellAnchor.From.Col, deCellAnchor.From.Row, deCellAnchor.To)
+			}
+		}
+	}
+	return err
+}
+
+// AddCellWatch provides a function to add a cell into the Watch Window of the
+// spreadsheet application by given worksheet name and cell reference, the
+// watched cells will be kept when inserting or deleting rows or columns. For
+// example, watch the cell Sheet1!B5:
+//
+//	err := f.AddCellWatch("Sheet1", "B5")
+func (f *File) AddCellWatch(sheet, cell string) error {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	cell, _ = CoordinatesToCellName(col, row)
+	if ws.CellWatches == nil {
+		ws.CellWatches = &xlsxCellWatches{}
+	}
+	for _, watch := range ws.CellWatches.CellWatch {
+		if watch.R == cell {
+			return err
+		}
+	}
+	ws.CellWatches.CellWatch = append(ws.CellWatches.CellWatch, xlsxCellWatch{R: cell})
+	return err
+}
+
+// GetCellWatches provides a function to get the watched cells in the Watch
+// Window of the spreadsheet application by given worksheet name.
+func (f *File) GetCellWatches(sheet string) ([]string, error) {
+	var cells []string
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.CellWatches == nil {
+		return cells, err
+	}
+	for _, watch := range ws.CellWatches.CellWatch {
+		cells = append(cells, watch.R)
+	}
+	return cells, err
+}
+
+// DeleteCellWatch provides a function to remove a cell from the Watch Window
+// of the spreadsheet application by given worksheet name and cell reference.
+// For example, stop watching the cell Sheet1!B5:
+//
+//	err := f.DeleteCellWatch("Sheet1", "B5")
+func (f *File) DeleteCellWatch(sheet, cell string) error {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.CellWatches == nil {
+		return err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	cell, _ = CoordinatesToCellName(col, row)
+	for i, watch := range ws.CellWatches.CellWatch {
+		if watch.R == cell {
+			ws.CellWatches.CellWatch = append(ws.CellWatches.CellWatch[:i], ws.CellWatches.CellWatch[i+1:]...)
+			break
+		}
+	}
+	if len(ws.CellWatches.CellWatch) == 0 {
+		ws.CellWatches = nil
+	}
+	return err
+}
+
+// SetSheetCustomProp provides a function to set a custom property of the
+// worksheet by given worksheet name, property name and value. The value of
+// the property will be stored in a custom property part of the workbook, and
+// the existing property with the same name will be updated. For example, set
+// the custom property "DataSource" of Sheet1:
+//
+//	err := f.SetSheetCustomProp("Sheet1", "DataSource", "Sales")
+func (f *File) SetSheetCustomProp(sheet, name, value string) error {
+	if name == "" {
+		return ErrParameterRequired
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	data, _ := unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM).NewEncoder().Bytes([]byte(value))
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.CustomProperties == nil {
+		ws.CustomProperties = &xlsxCustomProperties{}
+	}
+	idx := -1
+	for i, prop := range ws.CustomProperties.CustomPr {
+		if prop.Name == name {
+			if target := f.getCustomPropertyPath(sheet, prop.RID); target != "" {
+				f.Pkg.Store(target, data)
+				return err
+			}
+			idx = i
+		}
+	}
+	customPropertyID := f.countCustomProperties() + 1
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/worksheets/") + ".rels"
+	rID := f.addRels(sheetRels, SourceRelationshipCustomProperty, "../customProperty"+strconv.Itoa(customPropertyID)+".bin", "")
+	f.addSheetNameSpace(sheet, SourceRelationship)
+	if idx == -1 {
+		idx = len(ws.CustomProperties.CustomPr)
+		ws.CustomProperties.CustomPr = append(ws.CustomProperties.CustomPr, xlsxCustomProperty{Name: name})
+	}
+	ws.CustomProperties.CustomPr[idx].RID = "rId" + strconv.Itoa(rID)
+	f.Pkg.Store("xl/customProperty"+strconv.Itoa(customPropertyID)+".bin", data)
+	return f.addContentTypePart(customPropertyID, "customProperty")
+}
+
+// GetSheetCustomProps provides a function to get the custom properties of the
+// worksheet by given worksheet name. For example, get the custom properties
+// of Sheet1:
+//
+//	props, err := f.GetSheetCustomProps("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, prop := range props {
+//	    fmt.Println(prop.Name, prop.Value)
+//	}
+func (f *File) GetSheetCustomProps(sheet string) ([]SheetCustomProperty, error) {
+	var props []SheetCustomProperty
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.CustomProperties == nil {
+		return props, err
+	}
+	for _, prop := range ws.CustomProperties.CustomPr {
+		var value string
+		if content, ok := f.Pkg.Load(f.getCustomPropertyPath(sheet, prop.RID)); ok {
+			data, _ := unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM).NewDecoder().Bytes(content.([]byte))
+			value = string(data)
+		}
+		props = append(props, SheetCustomProperty{Name: prop.Name, Value: value})
+	}
+	return props, err
+}
+
+// getCustomPropertyPath provides a function to get the package path of the
+// custom property part by given worksheet name and relationship ID.
+func (f *File) getCustomPropertyPath(sheet, rID string) string {
+	target := f.getSheetRelationshipsTargetByID(sheet, rID)
+	if target == "" {
+		return target
+	}
+	if !strings.HasPrefix(target, "/") {
+		target = "xl" + strings.TrimPrefix(target, "..")
+	}
+	return strings.TrimPrefix(target, "/")
+}
+
+// countCustomProperties provides a function to get custom property parts
+// count storage in the folder xl.
+func (f *File) countCustomProperties() int {
+	count := 0
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.HasPrefix(k.(string), "xl/customProperty") {
+			count++
+		}
+		return true
+	})
+	return count
+}
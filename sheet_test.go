@@ -210,17 +210,147 @@ func TestSetPageLayout(t *testing.T) {
 		FitToHeight:     intPtr(2),
 		FitToWidth:      intPtr(2),
 		BlackAndWhite:   boolPtr(true),
+		Draft:           boolPtr(true),
+		CellComments:    stringPtr("atEnd"),
+		Errors:          stringPtr("NA"),
 	}
 	assert.NoError(t, f.SetPageLayout("Sheet1", &expected))
 	opts, err := f.GetPageLayout("Sheet1")
 	assert.NoError(t, err)
 	assert.Equal(t, expected, opts)
+	// Test the fit to pages settings override the print scaling settings
+	props, err := f.GetSheetProps("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, *props.FitToPage)
+	// Test set print scaling will disable the fit to page option
+	assert.NoError(t, f.SetPageLayout("Sheet1", &PageLayoutOptions{AdjustTo: uintPtr(80)}))
+	props, err = f.GetSheetProps("Sheet1")
+	assert.NoError(t, err)
+	assert.False(t, *props.FitToPage)
+	// Test set fit to pages will enable the fit to page option
+	assert.NoError(t, f.SetPageLayout("Sheet1", &PageLayoutOptions{FitToWidth: intPtr(1)}))
+	props, err = f.GetSheetProps("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, *props.FitToPage)
+	// Test set page layout with invalid cell comments and errors print mode
+	assert.NoError(t, f.SetPageLayout("Sheet1", &PageLayoutOptions{CellComments: stringPtr("unknown"), Errors: stringPtr("unknown")}))
+	// Test set first page number to automatic
+	assert.NoError(t, f.SetPageLayout("Sheet1", &PageLayoutOptions{FirstPageNumber: uintPtr(0)}))
+	opts, err = f.GetPageLayout("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), *opts.FirstPageNumber)
+	assert.Equal(t, "atEnd", *opts.CellComments)
+	assert.Equal(t, "NA", *opts.Errors)
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.False(t, ws.(*xlsxWorksheet).PageSetUp.UseFirstPageNumber)
+	// Test set print scaling without fit to page option
+	f = NewFile()
+	assert.NoError(t, f.SetPageLayout("Sheet1", &PageLayoutOptions{AdjustTo: uintPtr(80)}))
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Nil(t, ws.(*xlsxWorksheet).SheetPr)
 	// Test set page layout on not exists worksheet
 	assert.EqualError(t, f.SetPageLayout("SheetN", nil), "sheet SheetN does not exist")
 	// Test set page layout with invalid sheet name
 	assert.EqualError(t, f.SetPageLayout("Sheet:1", nil), ErrSheetNameInvalid.Error())
 }
 
+func TestSetPageLayoutPaperSize(t *testing.T) {
+	f := NewFile()
+	expected := PageLayoutOptions{
+		Size:            intPtr(0),
+		PaperWidth:      stringPtr("101.6mm"),
+		PaperHeight:     stringPtr("4in"),
+		Orientation:     stringPtr("portrait"),
+		FirstPageNumber: uintPtr(1),
+		AdjustTo:        uintPtr(100),
+		BlackAndWhite:   boolPtr(false),
+		Draft:           boolPtr(false),
+	}
+	assert.NoError(t, f.SetPageLayout("Sheet1", &PageLayoutOptions{PaperWidth: expected.PaperWidth, PaperHeight: expected.PaperHeight}))
+	opts, err := f.GetPageLayout("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, opts)
+	// Test set page layout with invalid or partial custom paper size
+	for _, size := range [][]string{{"100", "4in"}, {"0mm", "4in"}, {"100mm", "-4in"}, {"Amm", "4in"}} {
+		assert.NoError(t, f.SetPageLayout("Sheet1", &PageLayoutOptions{PaperWidth: stringPtr(size[0]), PaperHeight: stringPtr(size[1])}))
+	}
+	assert.NoError(t, f.SetPageLayout("Sheet1", &PageLayoutOptions{PaperWidth: stringPtr("50mm")}))
+	opts, err = f.GetPageLayout("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, opts)
+}
+
+func TestPrinterSettings(t *testing.T) {
+	f := NewFile()
+	data, err := f.GetPrinterSettings("Sheet1")
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetPrinterSettings("Sheet1", []byte{1, 2, 3}))
+	assert.NoError(t, f.SetPrinterSettings("Sheet2", []byte{4, 5, 6}))
+	// Test replace the existing printer settings
+	assert.NoError(t, f.SetPrinterSettings("Sheet1", []byte{7, 8, 9}))
+	assert.Equal(t, 3, f.getPrinterSettingsID())
+	data, err = f.GetPrinterSettings("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{7, 8, 9}, data)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestPrinterSettings.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestPrinterSettings.xlsx"))
+	assert.NoError(t, err)
+	data, err = f.GetPrinterSettings("Sheet2")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{4, 5, 6}, data)
+	content, err := f.contentTypesReader()
+	assert.NoError(t, err)
+	assert.Contains(t, content.Overrides, xlsxOverride{PartName: "/xl/printerSettings/printerSettings2.bin", ContentType: ContentTypeSpreadSheetMLPrinterSettings})
+	// Test remove the printer settings
+	assert.NoError(t, f.SetPrinterSettings("Sheet2", nil))
+	data, err = f.GetPrinterSettings("Sheet2")
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+	_, ok := f.Pkg.Load("xl/printerSettings/printerSettings2.bin")
+	assert.False(t, ok)
+	assert.NotContains(t, content.Overrides, xlsxOverride{PartName: "/xl/printerSettings/printerSettings2.bin", ContentType: ContentTypeSpreadSheetMLPrinterSettings})
+	// Test set printer settings after the printer settings part of another
+	// worksheet has been removed
+	_, err = f.NewSheet("Sheet3")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetPrinterSettings("Sheet3", []byte{10, 11}))
+	assert.NoError(t, f.SetPrinterSettings("Sheet2", []byte{12, 13}))
+	for sheet, expected := range map[string][]byte{"Sheet1": {7, 8, 9}, "Sheet2": {12, 13}, "Sheet3": {10, 11}} {
+		data, err = f.GetPrinterSettings(sheet)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, data, sheet)
+	}
+	// Test get printer settings with missing binary part
+	f.Pkg.Delete("xl/printerSettings/printerSettings1.bin")
+	data, err = f.GetPrinterSettings("Sheet1")
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+	// Test set printer settings with relationship which target not exist
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).PageSetUp.RID = "rId100"
+	assert.NoError(t, f.SetPrinterSettings("Sheet1", []byte{1}))
+	assert.NotEqual(t, "rId100", ws.(*xlsxWorksheet).PageSetUp.RID)
+	// Test printer settings on not exist worksheet
+	assert.EqualError(t, f.SetPrinterSettings("SheetN", []byte{1}), "sheet SheetN does not exist")
+	_, err = f.GetPrinterSettings("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test set printer settings with unsupported charset content types
+	_, err = f.NewSheet("Sheet4")
+	assert.NoError(t, err)
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetPrinterSettings("Sheet4", []byte{1}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestGetPageLayout(t *testing.T) {
 	f := NewFile()
 	// Test get page layout on not exists worksheet
@@ -335,6 +465,60 @@ func TestDefinedName(t *testing.T) {
 		"XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestGenerateTOCSheet(t *testing.T) {
+	f := NewFile()
+	for _, sheet := range []string{"Sales 2024", "Hidden"} {
+		_, err := f.NewSheet(sheet)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, f.SetSheetVisible("Hidden", false))
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Name: "Sheet1!$A$1", Categories: "Sheet1!$A$2:$A$5", Values: "Sheet1!$B$2:$B$5"}},
+	}))
+	for _, dn := range []*DefinedName{
+		{Name: "Amount", RefersTo: "Sheet1!$A$1"},
+		{Name: "Local", RefersTo: "'Sales 2024'!$A$1", Scope: "Sales 2024"},
+		{Name: "_xlnm.Print_Area", RefersTo: "Sheet1!$A$1:$B$2", Scope: "Sheet1"},
+	} {
+		assert.NoError(t, f.SetDefinedName(dn))
+	}
+	assert.NoError(t, f.GenerateTOCSheet("Contents", &TOCOptions{IncludeDefinedNames: true}))
+	rows, err := f.GetRows("Contents")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"Table of Contents"}, {"Sheet1"}, {"Sales 2024"}, nil, {"Amount"}, {"Local"}}, rows)
+	for cell, expected := range map[string]string{"A2": "Sheet1!A1", "A3": "'Sales 2024'!A1", "A5": "Amount", "A6": "'Sales 2024'!Local"} {
+		link, target, err := f.GetCellHyperLink("Contents", cell)
+		assert.NoError(t, err)
+		assert.True(t, link)
+		assert.Equal(t, expected, target)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGenerateTOCSheet.xlsx")))
+	// Test generate table of contents with hidden worksheets and custom title
+	assert.NoError(t, f.GenerateTOCSheet("Index", &TOCOptions{Title: "Index", IncludeHidden: true}))
+	rows, err = f.GetRows("Index")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"Index"}, {"Sheet1"}, {"Sales 2024"}, {"Hidden"}, {"Contents"}}, rows)
+	// Test generate table of contents with exists worksheet name
+	assert.Equal(t, ErrExistsSheet, f.GenerateTOCSheet("contents", nil))
+	// Test generate table of contents with invalid worksheet name
+	assert.Equal(t, ErrSheetNameInvalid, f.GenerateTOCSheet("Sheet:1", nil))
+	assert.NoError(t, f.Close())
+	// Test generate table of contents with unsupported charset worksheet
+	f = NewFile()
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	f.checked = sync.Map{}
+	assert.EqualError(t, f.GenerateTOCSheet("Contents", nil), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+	// Test generate table of contents with unsupported charset style sheet
+	f = NewFile()
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.GenerateTOCSheet("Contents", nil), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestGroupSheets(t *testing.T) {
 	f := NewFile()
 	sheets := []string{"Sheet2", "Sheet3"}
@@ -460,6 +644,95 @@ func TestSetActiveSheet(t *testing.T) {
 	f.SetActiveSheet(idx)
 }
 
+func TestRenameSheets(t *testing.T) {
+	f := NewFile()
+	for _, sheet := range []string{"Sheet2", "Sheet3"} {
+		_, err := f.NewSheet(sheet)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, f.SetCellFormula("Sheet3", "A1", "SUM(Sheet1!A1:B2,'Sheet2'!A1)+Sheet3!A2&\"Sheet1!A1\""))
+	assert.NoError(t, f.SetCellFormula("Sheet3", "A2", "Sheet1:Sheet2!A1"))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Amount", RefersTo: "Sheet1!$A$1:$B$2"}))
+	assert.NoError(t, f.AddChart("Sheet3", "C1", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Name: "Sheet1!$A$1", Categories: "Sheet2!$A$2:$A$5", Values: "Sheet1!$B$2:$B$5"}},
+	}))
+	// Test swap the names of the sheets and rename with name need be quoted
+	assert.NoError(t, f.RenameSheets(map[string]string{"Sheet1": "My Data", "sheet2": "Sheet3", "Sheet3": "Sheet2"}))
+	assert.Equal(t, []string{"My Data", "Sheet3", "Sheet2"}, f.GetSheetList())
+	formula, err := f.GetCellFormula("Sheet2", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM('My Data'!A1:B2,Sheet3!A1)+Sheet2!A2&\"Sheet1!A1\"", formula)
+	formula, err = f.GetCellFormula("Sheet2", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, "'My Data:Sheet3'!A1", formula)
+	assert.Equal(t, "'My Data'!$A$1:$B$2", f.GetDefinedName()[0].RefersTo)
+	content, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	for _, formula := range []string{"'My Data'!$A$1", "Sheet3!$A$2:$A$5", "'My Data'!$B$2:$B$5"} {
+		assert.Contains(t, string(content.([]byte)), "<f>"+formula+"</f>")
+	}
+	assert.NoError(t, f.SetCellValue("My Data", "A1", 1))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRenameSheets.xlsx")))
+	// Test rename sheets with the same name
+	assert.NoError(t, f.RenameSheets(map[string]string{"My Data": "My Data"}))
+	// Test rename sheets with invalid sheet names
+	assert.Equal(t, ErrSheetNameInvalid, f.RenameSheets(map[string]string{"Sheet:1": "Sheet1"}))
+	assert.Equal(t, ErrSheetNameInvalid, f.RenameSheets(map[string]string{"Sheet2": "Sheet:1"}))
+	// Test rename not exists sheet
+	assert.EqualError(t, f.RenameSheets(map[string]string{"SheetN": "Sheet1"}), "sheet SheetN does not exist")
+	// Test rename sheets with conflict target names
+	assert.Equal(t, ErrExistsSheet, f.RenameSheets(map[string]string{"Sheet2": "Sheet3"}))
+	assert.Equal(t, ErrExistsSheet, f.RenameSheets(map[string]string{"Sheet2": "Sheet4", "Sheet3": "sheet4"}))
+	assert.Equal(t, []string{"My Data", "Sheet3", "Sheet2"}, f.GetSheetList())
+	assert.NoError(t, f.Close())
+	// Test rename sheets with unsupported charset worksheet
+	f = NewFile()
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	f.Sheet.Delete("xl/worksheets/sheet2.xml")
+	f.Pkg.Store("xl/worksheets/sheet2.xml", MacintoshCyrillicCharset)
+	f.checked = sync.Map{}
+	assert.EqualError(t, f.RenameSheets(map[string]string{"Sheet1": "Sheet3"}), "XML syntax error on line 1: invalid UTF-8")
+	// Test rename sheets with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.renameSheets(map[string]string{"Sheet1": "Sheet3"}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestMoveSheet(t *testing.T) {
+	f := NewFile()
+	for _, sheet := range []string{"Sheet2", "Sheet3", "Sheet4"} {
+		_, err := f.NewSheet(sheet)
+		assert.NoError(t, err)
+	}
+	f.SetActiveSheet(1)
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Local", RefersTo: "Sheet2!$A$1", Scope: "Sheet2"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Local", RefersTo: "Sheet4!$A$1", Scope: "Sheet4"}))
+	// Test move the sheet forward and backward
+	assert.NoError(t, f.MoveSheet("Sheet4", 0))
+	assert.Equal(t, []string{"Sheet4", "Sheet1", "Sheet2", "Sheet3"}, f.GetSheetList())
+	assert.NoError(t, f.MoveSheet("sheet1", 3))
+	assert.Equal(t, []string{"Sheet4", "Sheet2", "Sheet3", "Sheet1"}, f.GetSheetList())
+	assert.Equal(t, "Sheet2", f.GetSheetName(f.GetActiveSheetIndex()))
+	for _, dn := range f.GetDefinedName() {
+		assert.Equal(t, dn.Scope+"!$A$1", dn.RefersTo)
+	}
+	// Test move the sheet to the same position
+	assert.NoError(t, f.MoveSheet("Sheet2", 1))
+	assert.Equal(t, []string{"Sheet4", "Sheet2", "Sheet3", "Sheet1"}, f.GetSheetList())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestMoveSheet.xlsx")))
+	// Test move sheet with invalid index
+	assert.Equal(t, ErrSheetIdx, f.MoveSheet("Sheet2", -1))
+	assert.Equal(t, ErrSheetIdx, f.MoveSheet("Sheet2", 4))
+	// Test move not exists sheet
+	assert.EqualError(t, f.MoveSheet("SheetN", 0), "sheet SheetN does not exist")
+	// Test move sheet with invalid sheet name
+	assert.Equal(t, ErrSheetNameInvalid, f.MoveSheet("Sheet:1", 0))
+	assert.NoError(t, f.Close())
+}
+
 func TestSetSheetName(t *testing.T) {
 	f := NewFile()
 	// Test set worksheet with the same name
@@ -486,9 +759,48 @@ func TestSetSheetName(t *testing.T) {
 		RefersTo: "Sheet1!$A$1:'Sheet1'!A1:Sheet1!$A$1,Sheet1!A1:Sheet3!A1,Sheet3!A1",
 	}))
 	assert.NoError(t, f.SetSheetName("Sheet1", "Sheet2"))
-	for i, expected := range []string{"'Sheet2'!$A$1:$A$2", "$B$2", "$A1$2:A2", "Sheet2!$A$1:'Sheet2'!A1:Sheet2!$A$1,Sheet2!A1:Sheet3!A1,Sheet3!A1"} {
+	for i, expected := range []string{"Sheet2!$A$1:$A$2", "$B$2", "$A1$2:A2", "Sheet2!$A$1:'Sheet2'!A1:Sheet2!$A$1,Sheet2!A1:Sheet3!A1,Sheet3!A1"} {
 		assert.Equal(t, expected, f.WorkBook.DefinedNames.DefinedName[i].Data)
 	}
+	assert.NoError(t, f.Close())
+
+	// Test set worksheet name will update the references in the formulas,
+	// conditional formats, data validations and hyperlinks
+	f = NewFile()
+	_, err := f.NewSheet("Report")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellFormula("Report", "A1", "Sheet1!A1*2"))
+	format, err := f.NewConditionalStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetConditionalFormat("Report", "A1", []ConditionalFormatOptions{
+		{Type: "formula", Criteria: "Sheet1!$A$1>0", Format: &format},
+	}))
+	dv := NewDataValidation(true)
+	dv.Sqref = "B1"
+	dv.SetSqrefDropList("Sheet1!$A$1:$A$3")
+	assert.NoError(t, f.AddDataValidation("Report", dv))
+	assert.NoError(t, f.SetCellHyperLink("Report", "C1", "Sheet1!A1", "Location"))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet2.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).ExtLst = &xlsxExtLst{Ext: "<ext><xm:f>Sheet1!$A$1&amp;Sheet2!A1</xm:f><xm:sqref>A1</xm:sqref><xm:f>Report!A1</xm:f></ext>"}
+	assert.NoError(t, f.SetSheetName("Sheet1", "Sales Data"))
+	formula, err := f.GetCellFormula("Report", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "'Sales Data'!A1*2", formula)
+	opts, err := f.GetConditionalFormats("Report")
+	assert.NoError(t, err)
+	assert.Equal(t, "'Sales Data'!$A$1>0", opts["A1"][0].Criteria)
+	dvs, err := f.GetDataValidations("Report")
+	assert.NoError(t, err)
+	assert.Equal(t, "'Sales Data'!$A$1:$A$3", dvs[0].Formula1)
+	_, target, err := f.GetCellHyperLink("Report", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, "'Sales Data'!A1", target)
+	assert.Equal(t, "<ext><xm:f>'Sales Data'!$A$1&amp;Sheet2!A1</xm:f><xm:sqref>A1</xm:sqref><xm:f>Report!A1</xm:f></ext>", ws.(*xlsxWorksheet).ExtLst.Ext)
+	// Test set not exists worksheet name
+	assert.NoError(t, f.SetSheetName("SheetN", "Sheet3"))
+	assert.Equal(t, []string{"Sales Data", "Report"}, f.GetSheetList())
+	assert.NoError(t, f.Close())
 }
 
 func TestWorksheetWriter(t *testing.T) {
@@ -547,6 +859,128 @@ func TestDeleteSheet(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDeleteSheet2.xlsx")))
 }
 
+func TestGetSheetDependencies(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Data")
+	assert.NoError(t, err)
+	_, err = f.NewSheet("Sheet3")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetRow("Data", "A1", &[]interface{}{"Month", "Year", "Sales"}))
+	assert.NoError(t, f.SetSheetRow("Data", "A2", &[]interface{}{"Jan", 2024, 10}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "'Data'!C2*2"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A2", "SUM(Sheet3!A1:A2)"))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Sales", RefersTo: "'Data'!$C$2"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Local", RefersTo: "'Data'!$A$1", Scope: "Data"}))
+	assert.NoError(t, f.AddChart("Sheet3", "E1", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Name: "'Data'!$C$1", Categories: "'Data'!$A$2", Values: "'Data'!$C$2"}},
+	}))
+	assert.NoError(t, f.AddPivotTable(&PivotTableOptions{
+		Name:            "PivotTable1",
+		DataRange:       "Data!A1:C2",
+		PivotTableRange: "Sheet3!H1:J5",
+		Rows:            []PivotTableField{{Data: "Month"}},
+		Data:            []PivotTableField{{Data: "Sales"}},
+	}))
+	deps, err := f.GetSheetDependencies("data")
+	assert.NoError(t, err)
+	assert.Equal(t, []SheetDependency{
+		{Type: SheetDependencyDefinedName, Sheet: "Workbook", Name: "Sales", RefersTo: "'Data'!$C$2"},
+		{Type: SheetDependencyFormula, Sheet: "Sheet1", Cell: "A1", RefersTo: "'Data'!C2*2"},
+		{Type: SheetDependencyChart, Sheet: "Sheet3", RefersTo: "'Data'!$C$1"},
+		{Type: SheetDependencyChart, Sheet: "Sheet3", RefersTo: "'Data'!$A$2"},
+		{Type: SheetDependencyChart, Sheet: "Sheet3", RefersTo: "'Data'!$C$2"},
+		{Type: SheetDependencyPivotTable, Sheet: "Sheet3", Name: "PivotTable1", RefersTo: "Data!A1:C2"},
+	}, deps)
+	deps, err = f.GetSheetDependencies("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, deps)
+	// Test get sheet dependencies with not exist worksheet
+	_, err = f.GetSheetDependencies("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get sheet dependencies with invalid sheet name
+	_, err = f.GetSheetDependencies("Sheet:1")
+	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
+
+	// Test delete sheet with checking dependencies
+	err = f.DeleteSheet("Data", DeleteSheetOptions{CheckDependencies: true, Cascade: true})
+	assert.EqualError(t, err, "sheet Data is referenced by 6 dependencies")
+	assert.IsType(t, ErrSheetDependencies{}, err)
+	assert.Len(t, err.(ErrSheetDependencies).Dependencies, 6)
+	assert.Equal(t, 3, f.SheetCount)
+	// Test delete sheet without dependencies with checking dependencies
+	_, err = f.NewSheet("Sheet4")
+	assert.NoError(t, err)
+	assert.NoError(t, f.DeleteSheet("Sheet4", DeleteSheetOptions{CheckDependencies: true}))
+
+	// Test delete sheet with cascading the references
+	assert.NoError(t, f.DeleteSheet("Data", DeleteSheetOptions{Cascade: true}))
+	formula, err := f.GetCellFormula("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "#REF!C2*2", formula)
+	formula, err = f.GetCellFormula("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(Sheet3!A1:A2)", formula)
+	definedNames := f.GetDefinedName()
+	assert.Len(t, definedNames, 1)
+	assert.Equal(t, "#REF!$C$2", definedNames[0].RefersTo)
+	charts, err := f.getSheetChartPaths("Sheet3")
+	assert.NoError(t, err)
+	assert.Len(t, charts, 1)
+	assert.Contains(t, string(f.readXML(charts[0])), "<f>#REF!$C$2</f>")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetSheetDependencies.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test get sheet dependencies with unsupported charset worksheet
+	f = NewFile()
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	f.Sheet.Delete("xl/worksheets/sheet2.xml")
+	f.Pkg.Store("xl/worksheets/sheet2.xml", MacintoshCyrillicCharset)
+	f.checked = sync.Map{}
+	_, err = f.GetSheetDependencies("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.DeleteSheet("Sheet1", DeleteSheetOptions{CheckDependencies: true}), "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.DeleteSheet("Sheet1", DeleteSheetOptions{Cascade: true}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestGetSheetChartPaths(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Name: "Sheet1!$A$1", Categories: "Sheet1!$A$2", Values: "Sheet1!$B$2"}},
+	}))
+	charts, err := f.getSheetChartPaths("Chart1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"xl/charts/chart1.xml"}, charts)
+	deps, err := f.GetSheetDependencies("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, deps, 3)
+	assert.Equal(t, "Chart1", deps[0].Sheet)
+	charts, err = f.getSheetChartPaths("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, charts)
+	// Test get sheet chart paths with unsupported charset drawing relationships
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Name: "Sheet1!$A$1", Categories: "Sheet1!$A$2", Values: "Sheet1!$B$2"}},
+	}))
+	f.Relationships.Delete("xl/drawings/_rels/drawing2.xml.rels")
+	f.Pkg.Store("xl/drawings/_rels/drawing2.xml.rels", MacintoshCyrillicCharset)
+	_, err = f.getSheetChartPaths("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get sheet chart paths with unsupported charset chart sheet
+	chartSheetXML, ok := f.getSheetXMLPath("Chart1")
+	assert.True(t, ok)
+	f.Pkg.Store(chartSheetXML, MacintoshCyrillicCharset)
+	_, err = f.getSheetChartPaths("Chart1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	_, err = f.GetSheetDependencies("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestDeleteAndAdjustDefinedNames(t *testing.T) {
 	deleteAndAdjustDefinedNames(nil, 0)
 	deleteAndAdjustDefinedNames(&xlsxWorkbook{}, 0)
@@ -565,13 +999,69 @@ func TestSetSheetVisible(t *testing.T) {
 	// Test set sheet visible with invalid sheet name
 	assert.EqualError(t, f.SetSheetVisible("Sheet:1", false), ErrSheetNameInvalid.Error())
 	f.WorkBook.Sheets.Sheet[0].Name = "SheetN"
-	assert.EqualError(t, f.SetSheetVisible("Sheet1", false), "sheet SheetN does not exist")
+	assert.EqualError(t, f.SetSheetVisible("Sheet1", false), "sheet Sheet1 does not exist")
 	// Test set sheet visible with unsupported charset workbook
 	f.WorkBook = nil
 	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
 	assert.EqualError(t, f.SetSheetVisible("Sheet1", false), "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestSetSheetState(t *testing.T) {
+	f := NewFile()
+	for _, sheet := range []string{"Sheet2", "Sheet3"} {
+		_, err := f.NewSheet(sheet)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Name: "Sheet1!$A$1", Categories: "Sheet1!$A$2:$A$5", Values: "Sheet1!$B$2:$B$5"}},
+	}))
+	assert.NoError(t, f.SetSheetState("Sheet2", SheetStateHidden))
+	assert.NoError(t, f.SetSheetVisible("Sheet3", false, true))
+	assert.NoError(t, f.SetSheetState("Chart1", SheetStateVeryHidden))
+	for sheet, expected := range map[string]SheetState{
+		"Sheet1": SheetStateVisible, "Sheet2": SheetStateHidden, "sheet3": SheetStateVeryHidden, "Chart1": SheetStateVeryHidden,
+	} {
+		state, err := f.GetSheetState(sheet)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, state, sheet)
+	}
+	visible, err := f.GetSheetVisible("Sheet3")
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	// Test hide the active sheet will be invalidated
+	assert.NoError(t, f.SetSheetState("Sheet1", SheetStateVisible))
+	f.SetActiveSheet(0)
+	assert.NoError(t, f.SetSheetState("Sheet3", SheetStateVisible))
+	assert.NoError(t, f.SetSheetState("Sheet1", SheetStateHidden))
+	state, err := f.GetSheetState("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, SheetStateVisible, state)
+	// Test hide the last visible sheet
+	assert.NoError(t, f.SetSheetState("Sheet3", SheetStateHidden))
+	assert.Equal(t, ErrLastVisibleSheet, f.SetSheetState("Sheet1", SheetStateVeryHidden))
+	// Test set sheet state with invalid state
+	assert.Equal(t, ErrParameterInvalid, f.SetSheetState("Sheet1", SheetStateVeryHidden+1))
+	// Test set and get sheet state with invalid sheet name
+	assert.Equal(t, ErrSheetNameInvalid, f.SetSheetState("Sheet:1", SheetStateHidden))
+	_, err = f.GetSheetState("Sheet:1")
+	assert.Equal(t, ErrSheetNameInvalid, err)
+	// Test get sheet state on not exists sheet
+	_, err = f.GetSheetState("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test set sheet state with unsupported charset worksheet
+	f.Sheet.Delete("xl/worksheets/sheet2.xml")
+	f.Pkg.Store("xl/worksheets/sheet2.xml", MacintoshCyrillicCharset)
+	f.checked = sync.Map{}
+	assert.EqualError(t, f.SetSheetState("Sheet2", SheetStateHidden), "XML syntax error on line 1: invalid UTF-8")
+	// Test get sheet state with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	_, err = f.GetSheetState("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestGetSheetVisible(t *testing.T) {
 	f := NewFile()
 	// Test get sheet visible with invalid sheet name
@@ -761,3 +1251,486 @@ func TestSheetDimension(t *testing.T) {
 	assert.Empty(t, dimension)
 	assert.EqualError(t, err, "sheet SheetN does not exist")
 }
+
+func TestUpdateDimension(t *testing.T) {
+	f := NewFile()
+	// Test update dimension on the empty worksheet
+	assert.NoError(t, f.SetSheetDimension("Sheet1", "A1:Z100"))
+	assert.NoError(t, f.UpdateDimension("Sheet1"))
+	dimension, err := f.GetSheetDimension("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1", dimension)
+	// Test update dimension with single cell
+	assert.NoError(t, f.SetCellValue("Sheet1", "C3", 1))
+	assert.NoError(t, f.UpdateDimension("Sheet1"))
+	dimension, err = f.GetSheetDimension("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "C3", dimension)
+	// Test update dimension with formula and styled cells
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B5", "C3+1"))
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "E2", "E2", style))
+	assert.NoError(t, f.UpdateDimension("Sheet1"))
+	dimension, err = f.GetSheetDimension("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "B2:E5", dimension)
+	// Test update dimension with not exist worksheet
+	assert.EqualError(t, f.UpdateDimension("SheetN"), "sheet SheetN does not exist")
+	// Test update dimension with invalid cell reference
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[2].C[2].R = "-"
+	assert.Equal(t, newCellNameToCoordinatesError("-", newInvalidCellNameError("-")), f.UpdateDimension("Sheet1"))
+}
+
+func TestTrimSheet(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"FFFF00"}, Pattern: 1}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", "B2"))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A2", "A2", style))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C4", "B2"))
+	// Add styled empty cells and rows in the tail of the worksheet
+	assert.NoError(t, f.SetCellStyle("Sheet1", "D2", "Z1000", style))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 2000, 30))
+	assert.NoError(t, f.SetSheetDimension("Sheet1", "A1:Z2000"))
+	assert.NoError(t, f.TrimSheet("Sheet1"))
+	dimension, err := f.GetSheetDimension("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A2:C4", dimension)
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	rows := ws.(*xlsxWorksheet).SheetData.Row
+	assert.Len(t, rows, 4)
+	assert.Len(t, rows[1].C, 2)
+	assert.Len(t, rows[2].C, 0)
+	assert.Len(t, rows[3].C, 3)
+	// Test the styles of cells inside the used range are preserved
+	styleID, err := f.GetCellStyle("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, style, styleID)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestTrimSheet.xlsx")))
+	// Test trim sheet with not exist worksheet
+	assert.EqualError(t, f.TrimSheet("SheetN"), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+
+	// Test remove the trailing cell formats which only used by the trimmed cells
+	f = NewFile()
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Name: "Sheet1!$A$1", Categories: "Sheet1!$A$2:$A$5", Values: "Sheet1!$B$2:$B$5"}},
+	}))
+	var styles []int
+	for _, color := range []string{"0000FF", "FF0000", "00FF00", "FFFF00"} {
+		style, err := f.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{color}, Pattern: 1}})
+		assert.NoError(t, err)
+		styles = append(styles, style)
+	}
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", "B2"))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B2", "B2", styles[1]))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "X50", "X50", styles[0]))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "Y60", "Y60", styles[2]))
+	assert.NoError(t, f.SetRowStyle("Sheet1", 70, 70, styles[3]))
+	assert.NoError(t, f.SetCellStyle("Sheet2", "A1", "A1", styles[1]))
+	assert.NoError(t, f.SetColStyle("Sheet2", "C", styles[1]))
+	cellXfs := len(f.Styles.CellXfs.Xf)
+	assert.NoError(t, f.TrimSheet("Sheet1"))
+	// The unused cell format which is not at the end will be kept
+	assert.Len(t, f.Styles.CellXfs.Xf, cellXfs-2)
+	for _, c := range []struct {
+		sheet, cell string
+		styleID     int
+		color       string
+	}{
+		{"Sheet1", "B2", styles[1], "FF0000"},
+		{"Sheet2", "A1", styles[1], "FF0000"},
+		{"Sheet2", "C1", styles[1], "FF0000"},
+	} {
+		styleID, err := f.GetCellStyle(c.sheet, c.cell)
+		assert.NoError(t, err)
+		assert.Equal(t, c.styleID, styleID)
+		style, err := f.GetStyle(styleID)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{c.color}, style.Fill.Color)
+	}
+	// Test create style after removing the trailing cell formats
+	style, err = f.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"00FF00"}, Pattern: 1}})
+	assert.NoError(t, err)
+	assert.Equal(t, styles[2], style)
+	// Test trim sheet with the cell formats still in use
+	cellXfs = len(f.Styles.CellXfs.Xf)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "Z80", "Z80", styles[1]))
+	assert.NoError(t, f.TrimSheet("Sheet1"))
+	assert.Len(t, f.Styles.CellXfs.Xf, cellXfs)
+	// Test trim sheet with stream writer in the workbook
+	assert.NoError(t, f.SetCellStyle("Sheet1", "Z80", "Z80", style))
+	_, err = f.NewStreamWriter("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.TrimSheet("Sheet1"))
+	assert.Len(t, f.Styles.CellXfs.Xf, cellXfs)
+	f.streams = nil
+	// Test trim sheet with unsupported charset worksheet and style sheet
+	assert.NoError(t, f.SetCellStyle("Sheet1", "Z80", "Z80", style))
+	f.Sheet.Delete("xl/worksheets/sheet2.xml")
+	f.Pkg.Store("xl/worksheets/sheet2.xml", MacintoshCyrillicCharset)
+	f.checked.Delete("xl/worksheets/sheet2.xml")
+	assert.EqualError(t, f.TrimSheet("Sheet1"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.DeleteSheet("Sheet2"))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "Z80", "Z80", style))
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.TrimSheet("Sheet1"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestRowSpansAndAutoDimension(t *testing.T) {
+	f := NewFile(Options{RowSpans: true, AutoDimension: true})
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", 1))
+	assert.NoError(t, f.SetCellValue("Sheet1", "D3", 2))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C18", 3))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 20, 30))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 40, 30))
+	// Test get the used range without saving
+	dimension, err := f.GetSheetDimension("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "B2:D18", dimension)
+	assert.NoError(t, f.SetCellValue("Sheet1", "E1", 4))
+	dimension, err = f.GetSheetDimension("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "B1:E18", dimension)
+	// Test write spans of the rows on saving
+	_, err = f.WriteToBuffer()
+	assert.NoError(t, err)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	spans := map[int]string{}
+	for _, row := range ws.SheetData.Row {
+		spans[row.R] = row.Spans
+	}
+	for r, expected := range map[int]string{1: "2:5", 2: "2:5", 3: "2:5", 18: "3:3", 20: "3:3", 40: ""} {
+		assert.Equal(t, expected, spans[r])
+	}
+	assert.Equal(t, "B1:E18", ws.Dimension.Ref)
+	// Test get the used range with not exist worksheet
+	_, err = f.GetSheetDimension("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get the used range with invalid cell reference
+	ws.SheetData.Row[0].C[0].R = "-"
+	_, err = f.GetSheetDimension("Sheet1")
+	assert.Equal(t, newCellNameToCoordinatesError("-", newInvalidCellNameError("-")), err)
+	ws.setRowSpans()
+	assert.Equal(t, "2:4", ws.SheetData.Row[0].Spans)
+	assert.NoError(t, f.Close())
+}
+
+func TestGetUsedRange(t *testing.T) {
+	f := NewFile()
+	ref, err := f.GetUsedRange("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, ref)
+	assert.NoError(t, f.SetCellValue("Sheet1", "C3", 1))
+	ref, err = f.GetUsedRange("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "C3", ref)
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B5", "C3*2"))
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "F1", "F1", style))
+	assert.NoError(t, f.MergeCell("Sheet1", "A10", "B12"))
+	assert.NoError(t, f.AddPicture("Sheet1", "H20", filepath.Join("test", "images", "excel.png"), nil))
+	for _, c := range []struct {
+		opts     UsedRangeOptions
+		expected string
+	}{
+		{expected: "B3:C5"},
+		{opts: UsedRangeOptions{IncludeStyles: true}, expected: "B1:F5"},
+		{opts: UsedRangeOptions{IncludeMergeCells: true}, expected: "A3:C12"},
+		{opts: UsedRangeOptions{IncludeDrawings: true}, expected: "B3:K27"},
+	} {
+		ref, err = f.GetUsedRange("Sheet1", c.opts)
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, ref)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetUsedRange.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test get the used range with the drawing objects from the saved workbook
+	f, err = OpenFile(filepath.Join("test", "TestGetUsedRange.xlsx"))
+	assert.NoError(t, err)
+	ref, err = f.GetUsedRange("Sheet1", UsedRangeOptions{IncludeDrawings: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "B3:K27", ref)
+	// Test get the used range with unsupported charset drawing
+	f.Drawings.Delete("xl/drawings/drawing1.xml")
+	f.Pkg.Store("xl/drawings/drawing1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetUsedRange("Sheet1", UsedRangeOptions{IncludeDrawings: true})
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get the used range with invalid merged cell reference
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).MergeCells.Cells[0].Ref = "A1:B"
+	_, err = f.GetUsedRange("Sheet1", UsedRangeOptions{IncludeMergeCells: true})
+	assert.Equal(t, newCellNameToCoordinatesError("B", newInvalidCellNameError("B")), err)
+	// Test get the used range with invalid cell reference
+	ws.(*xlsxWorksheet).SheetData.Row[2].C[2].R = "-"
+	_, err = f.GetUsedRange("Sheet1")
+	assert.Equal(t, newCellNameToCoordinatesError("-", newInvalidCellNameError("-")), err)
+	// Test get the used range with not exist worksheet
+	_, err = f.GetUsedRange("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
+func TestAllowEditRange(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddAllowEditRange("Sheet1", "Range1", "$A$1:$B$2 D1:D5", "password"))
+	assert.NoError(t, f.AddAllowEditRange("Sheet1", "Range2", "F1", ""))
+	assert.NoError(t, f.ProtectSheet("Sheet1", &SheetProtectionOptions{Password: "password"}))
+	ranges, err := f.GetAllowEditRanges("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []AllowEditRange{
+		{Title: "Range1", Range: "A1:B2 D1:D5", HasPassword: true},
+		{Title: "Range2", Range: "F1"},
+	}, ranges)
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	protectedRange := ws.(*xlsxWorksheet).ProtectedRanges.ProtectedRange[0]
+	assert.Equal(t, "SHA-512", protectedRange.AlgorithmName)
+	hashValue, _, err := genISOPasswdHash("password", protectedRange.AlgorithmName, protectedRange.SaltValue, protectedRange.SpinCount)
+	assert.NoError(t, err)
+	assert.Equal(t, protectedRange.HashValue, hashValue)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAllowEditRange.xlsx")))
+	// Test add allow edit range with duplicate title
+	assert.Equal(t, ErrExistsAllowEditRange, f.AddAllowEditRange("Sheet1", "range1", "H1", ""))
+	// Test add allow edit range with invalid parameters
+	assert.Equal(t, ErrParameterRequired, f.AddAllowEditRange("Sheet1", "", "H1", ""))
+	assert.Equal(t, ErrParameterRequired, f.AddAllowEditRange("Sheet1", "Range3", "", ""))
+	assert.Equal(t, ErrParameterInvalid, f.AddAllowEditRange("Sheet1", "Range3", "A1:B2:C3", ""))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.AddAllowEditRange("Sheet1", "Range3", "A:B", ""))
+	// Test delete allow edit range
+	assert.NoError(t, f.DeleteAllowEditRange("Sheet1", "Range1"))
+	assert.Equal(t, newNoExistAllowEditRangeError("Range1"), f.DeleteAllowEditRange("Sheet1", "Range1"))
+	assert.NoError(t, f.DeleteAllowEditRange("Sheet1", "Range2"))
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Nil(t, ws.(*xlsxWorksheet).ProtectedRanges)
+	ranges, err = f.GetAllowEditRanges("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, ranges)
+	// Test allow edit range on not exist worksheet
+	assert.EqualError(t, f.AddAllowEditRange("SheetN", "Range1", "A1", ""), "sheet SheetN does not exist")
+	_, err = f.GetAllowEditRanges("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.EqualError(t, f.DeleteAllowEditRange("SheetN", "Range1"), "sheet SheetN does not exist")
+	// Test read allow edit ranges from the workbook
+	f, err = OpenFile(filepath.Join("test", "TestAllowEditRange.xlsx"))
+	assert.NoError(t, err)
+	ranges, err = f.GetAllowEditRanges("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, ranges, 2)
+	assert.NoError(t, f.Close())
+}
+
+func TestScenario(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Growth", 0.05}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"Cost", 1200}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B3", "B2*(1+B1)"))
+	assert.NoError(t, f.AddScenario("Sheet1", &Scenario{
+		Name: "Best Case", Cells: []ScenarioCell{{Cell: "$B$1", Value: "0.15"}, {Cell: "B2", Value: "1000"}},
+	}))
+	assert.NoError(t, f.AddScenario("Sheet1", &Scenario{
+		Name: "Worst Case", Comment: "Low growth with high cost", User: "Excelize", Locked: true, Hidden: true,
+		Cells: []ScenarioCell{{Cell: "B1", Value: "0.02"}, {Cell: "B2", Value: "1800"}, {Cell: "A1", Value: "Rate"}},
+	}))
+	expected := []Scenario{
+		{Name: "Best Case", Cells: []ScenarioCell{{Cell: "B1", Value: "0.15"}, {Cell: "B2", Value: "1000"}}},
+		{
+			Name: "Worst Case", Comment: "Low growth with high cost", User: "Excelize", Locked: true, Hidden: true,
+			Cells: []ScenarioCell{{Cell: "B1", Value: "0.02"}, {Cell: "B2", Value: "1800"}, {Cell: "A1", Value: "Rate"}},
+		},
+	}
+	scenarios, err := f.GetScenarios("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, scenarios)
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, "B1 B2 A1", ws.(*xlsxWorksheet).Scenarios.Sqref)
+	// Test show scenario
+	assert.NoError(t, f.ShowScenario("Sheet1", "worst case"))
+	result, err := f.CalcCellValue("Sheet1", "B3")
+	assert.NoError(t, err)
+	assert.Equal(t, "1836", result)
+	cellType, err := f.GetCellType("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeSharedString, cellType)
+	assert.Equal(t, 1, *ws.(*xlsxWorksheet).Scenarios.Current)
+	assert.Equal(t, 1, *ws.(*xlsxWorksheet).Scenarios.Show)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestScenario.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test read scenarios from the workbook
+	f, err = OpenFile(filepath.Join("test", "TestScenario.xlsx"))
+	assert.NoError(t, err)
+	scenarios, err = f.GetScenarios("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, scenarios)
+	// Test add scenario with duplicate name
+	assert.Equal(t, ErrExistsScenario, f.AddScenario("Sheet1", &Scenario{Name: "best case", Cells: []ScenarioCell{{Cell: "B1"}}}))
+	// Test add scenario with invalid parameters
+	assert.Equal(t, ErrParameterRequired, f.AddScenario("Sheet1", nil))
+	assert.Equal(t, ErrParameterRequired, f.AddScenario("Sheet1", &Scenario{}))
+	assert.Equal(t, ErrNameLength, f.AddScenario("Sheet1", &Scenario{Name: strings.Repeat("c", MaxFieldLength+1)}))
+	assert.Equal(t, ErrScenarioCells, f.AddScenario("Sheet1", &Scenario{Name: "Base Case"}))
+	assert.Equal(t, ErrScenarioCells, f.AddScenario("Sheet1", &Scenario{Name: "Base Case", Cells: make([]ScenarioCell, MaxScenarioCells+1)}))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.AddScenario("Sheet1", &Scenario{Name: "Base Case", Cells: []ScenarioCell{{Cell: "A"}}}))
+	// Test delete scenario
+	assert.NoError(t, f.AddScenario("Sheet1", &Scenario{Name: "Base Case", Cells: []ScenarioCell{{Cell: "C1", Value: "1"}}}))
+	assert.NoError(t, f.ShowScenario("Sheet1", "Base Case"))
+	assert.NoError(t, f.DeleteScenario("Sheet1", "Best Case"))
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, 1, *ws.(*xlsxWorksheet).Scenarios.Current)
+	assert.NoError(t, f.DeleteScenario("Sheet1", "Base Case"))
+	assert.Nil(t, ws.(*xlsxWorksheet).Scenarios.Current)
+	assert.Nil(t, ws.(*xlsxWorksheet).Scenarios.Show)
+	assert.Equal(t, "B1 B2 A1", ws.(*xlsxWorksheet).Scenarios.Sqref)
+	assert.Equal(t, newNoExistScenarioError("Base Case"), f.DeleteScenario("Sheet1", "Base Case"))
+	assert.Equal(t, newNoExistScenarioError("Base Case"), f.ShowScenario("Sheet1", "Base Case"))
+	assert.NoError(t, f.DeleteScenario("Sheet1", "Worst Case"))
+	assert.Nil(t, ws.(*xlsxWorksheet).Scenarios)
+	scenarios, err = f.GetScenarios("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, scenarios)
+	assert.EqualError(t, f.DeleteScenario("Sheet1", "Worst Case"), "scenario Worst Case does not exist")
+	// Test show scenario with invalid cell reference
+	ws.(*xlsxWorksheet).Scenarios = &xlsxScenarios{Scenario: []*xlsxScenario{{Name: "Invalid", InputCells: []*xlsxInputCells{{R: "A"}}}}}
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.ShowScenario("Sheet1", "Invalid"))
+	// Test scenario on not exist worksheet
+	assert.EqualError(t, f.AddScenario("SheetN", &Scenario{Name: "Base Case", Cells: []ScenarioCell{{Cell: "A1"}}}), "sheet SheetN does not exist")
+	_, err = f.GetScenarios("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.EqualError(t, f.ShowScenario("SheetN", "Base Case"), "sheet SheetN does not exist")
+	assert.EqualError(t, f.DeleteScenario("SheetN", "Base Case"), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
+func TestCellWatches(t *testing.T) {
+	f := NewFile()
+	cells, err := f.GetCellWatches("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, cells)
+	assert.NoError(t, f.AddCellWatch("Sheet1", "B5"))
+	assert.NoError(t, f.AddCellWatch("Sheet1", "$D$2"))
+	assert.NoError(t, f.AddCellWatch("Sheet1", "B5"))
+	cells, err = f.GetCellWatches("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"B5", "D2"}, cells)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCellWatches.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestCellWatches.xlsx"))
+	assert.NoError(t, err)
+	cells, err = f.GetCellWatches("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"B5", "D2"}, cells)
+	assert.NoError(t, f.DeleteCellWatch("Sheet1", "B5"))
+	assert.NoError(t, f.DeleteCellWatch("Sheet1", "A1"))
+	cells, err = f.GetCellWatches("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"D2"}, cells)
+	assert.NoError(t, f.DeleteCellWatch("Sheet1", "D2"))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Nil(t, ws.(*xlsxWorksheet).CellWatches)
+	assert.NoError(t, f.DeleteCellWatch("Sheet1", "D2"))
+	// Test cell watches with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.AddCellWatch("Sheet1", "A"))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.DeleteCellWatch("Sheet1", "A"))
+	// Test cell watches with not exist worksheet
+	assert.EqualError(t, f.AddCellWatch("SheetN", "A1"), "sheet SheetN does not exist")
+	_, err = f.GetCellWatches("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.EqualError(t, f.DeleteCellWatch("SheetN", "A1"), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+
+	// Test preserve the cell watches and custom properties of the worksheet
+	f = NewFile()
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(xml.Header+`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheetData/><customProperties><customPr name="Landmark" r:id="rId1"/></customProperties><cellWatches><cellWatch r="C3"/></cellWatches></worksheet>`))
+	f.checked = sync.Map{}
+	cells, err = f.GetCellWatches("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"C3"}, cells)
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	sheet, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []xlsxCustomProperty{{Name: "Landmark", RID: "rId1"}}, sheet.CustomProperties.CustomPr)
+	assert.NoError(t, f.Close())
+}
+
+func TestSheetCustomProps(t *testing.T) {
+	f := NewFile()
+	props, err := f.GetSheetCustomProps("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, props)
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetCustomProp("Sheet1", "DataSource", "Sales"))
+	assert.NoError(t, f.SetSheetCustomProp("Sheet1", "Owner", "Excelize 文档"))
+	assert.NoError(t, f.SetSheetCustomProp("Sheet2", "DataSource", "Finance"))
+	// Test update the existing custom property
+	assert.NoError(t, f.SetSheetCustomProp("Sheet1", "DataSource", "Orders"))
+	assert.Equal(t, 3, f.countCustomProperties())
+	data, ok := f.Pkg.Load("xl/customProperty1.bin")
+	assert.True(t, ok)
+	assert.Equal(t, []byte{'O', 0, 'r', 0, 'd', 0, 'e', 0, 'r', 0, 's', 0}, data)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSheetCustomProps.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestSheetCustomProps.xlsx"))
+	assert.NoError(t, err)
+	props, err = f.GetSheetCustomProps("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []SheetCustomProperty{{Name: "DataSource", Value: "Orders"}, {Name: "Owner", Value: "Excelize 文档"}}, props)
+	props, err = f.GetSheetCustomProps("Sheet2")
+	assert.NoError(t, err)
+	assert.Equal(t, []SheetCustomProperty{{Name: "DataSource", Value: "Finance"}}, props)
+	content, err := f.contentTypesReader()
+	assert.NoError(t, err)
+	assert.Contains(t, content.Overrides, xlsxOverride{PartName: "/xl/customProperty3.bin", ContentType: ContentTypeSpreadSheetMLCustomProperty})
+	// Test get custom properties with missing custom property part
+	f.Pkg.Delete("xl/customProperty3.bin")
+	props, err = f.GetSheetCustomProps("Sheet2")
+	assert.NoError(t, err)
+	assert.Equal(t, []SheetCustomProperty{{Name: "DataSource"}}, props)
+	// Test set custom property with relationship which target not exist
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).CustomProperties.CustomPr[1].RID = "rId100"
+	assert.NoError(t, f.SetSheetCustomProp("Sheet1", "Owner", "Excelize"))
+	assert.NotEqual(t, "rId100", ws.(*xlsxWorksheet).CustomProperties.CustomPr[1].RID)
+	props, err = f.GetSheetCustomProps("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []SheetCustomProperty{{Name: "DataSource", Value: "Orders"}, {Name: "Owner", Value: "Excelize"}}, props)
+	// Test set custom property without name
+	assert.Equal(t, ErrParameterRequired, f.SetSheetCustomProp("Sheet1", "", "Excelize"))
+	// Test custom properties on not exist worksheet
+	assert.EqualError(t, f.SetSheetCustomProp("SheetN", "DataSource", "Sales"), "sheet SheetN does not exist")
+	_, err = f.GetSheetCustomProps("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+
+	// Test set custom property with unsupported charset content types
+	f = NewFile()
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetSheetCustomProp("Sheet1", "DataSource", "Sales"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
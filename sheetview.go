@@ -11,6 +11,11 @@
 
 package excelize
 
+import (
+	"fmt"
+	"strings"
+)
+
 // getSheetView returns the SheetView object
 func (f *File) getSheetView(sheet string, viewIndex int) (*xlsxSheetView, error) {
 	ws, err := f.workSheetReader(sheet)
@@ -127,3 +132,337 @@ func (f *File) GetSheetView(sheet string, viewIndex int) (ViewOptions, error) {
 	}
 	return opts, err
 }
+
+// SetCustomSheetView provides a function to add or update the custom sheet
+// view of the worksheet by given worksheet name and custom view options. The
+// custom view of the workbook will be created if it does not exist, and the
+// unspecified settings of an existing custom sheet view will be kept. For
+// example, add a custom view named "Summary" for Sheet1, which hides the grid
+// lines, zoom to 80 percent, and saves the current auto filter settings of the
+// worksheet:
+//
+//	disable, enable, zoomScale := false, true, 80
+//	err := f.SetCustomSheetView("Sheet1", &excelize.CustomSheetViewOptions{
+//	    Name:          "Summary",
+//	    ShowGridLines: &disable,
+//	    ZoomScale:     &zoomScale,
+//	    IncludeFilter: &enable,
+//	})
+func (f *File) SetCustomSheetView(sheet string, opts *CustomSheetViewOptions) error {
+	if opts == nil || opts.Name == "" {
+		return ErrParameterRequired
+	}
+	if opts.State != nil {
+		if _, ok := sheetStates[*opts.State]; !ok {
+			return ErrParameterInvalid
+		}
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	guid, err := f.getCustomWorkbookViewGUID(opts.Name, true)
+	if err != nil {
+		return err
+	}
+	if ws.CustomSheetViews == nil {
+		ws.CustomSheetViews = &xlsxCustomSheetViews{}
+	}
+	var view *xlsxCustomSheetView
+	for _, v := range ws.CustomSheetViews.CustomSheetView {
+		if strings.EqualFold(v.GUID, guid) {
+			view = v
+			break
+		}
+	}
+	if view == nil {
+		view = &xlsxCustomSheetView{GUID: guid}
+		ws.CustomSheetViews.CustomSheetView = append(ws.CustomSheetViews.CustomSheetView, view)
+	}
+	view.setCustomSheetView(ws, opts)
+	return err
+}
+
+// setCustomSheetView set custom sheet view by given options.
+func (view *xlsxCustomSheetView) setCustomSheetView(ws *xlsxWorksheet, opts *CustomSheetViewOptions) {
+	for _, field := range []struct {
+		value  *bool
+		target **bool
+	}{
+		{opts.ShowGridLines, &view.ShowGridLines},
+		{opts.ShowRowColHeaders, &view.ShowRowCol},
+		{opts.ShowOutlineSymbols, &view.OutlineSymbols},
+		{opts.ShowZeros, &view.ZeroValues},
+		{opts.ShowRuler, &view.ShowRuler},
+	} {
+		if field.value != nil {
+			*field.target = boolPtr(*field.value)
+		}
+	}
+	for _, field := range []struct {
+		value  *bool
+		target *bool
+	}{
+		{opts.ShowFormulas, &view.ShowFormulas},
+		{opts.ShowPageBreaks, &view.ShowPageBreaks},
+		{opts.HiddenRows, &view.HiddenRows},
+		{opts.HiddenColumns, &view.HiddenColumns},
+		{opts.FitToPage, &view.FitToPage},
+		{opts.PrintArea, &view.PrintArea},
+	} {
+		if field.value != nil {
+			*field.target = *field.value
+		}
+	}
+	if opts.IncludeFilter != nil {
+		view.AutoFilter, view.Filter, view.ShowAutoFilter = nil, false, false
+		if *opts.IncludeFilter && ws.AutoFilter != nil {
+			filter := *ws.AutoFilter
+			filter.FilterColumn = append([]*xlsxFilterColumn(nil), ws.AutoFilter.FilterColumn...)
+			view.AutoFilter, view.Filter, view.ShowAutoFilter = &filter, true, true
+		}
+	}
+	if opts.State != nil {
+		if view.State = sheetStates[*opts.State]; *opts.State == SheetStateVisible {
+			view.State = ""
+		}
+	}
+	if opts.TopLeftCell != nil {
+		view.TopLeftCell = *opts.TopLeftCell
+	}
+	if opts.View != nil {
+		if inStrSlice([]string{"normal", "pageLayout", "pageBreakPreview"}, *opts.View, true) != -1 {
+			view.View = *opts.View
+		}
+	}
+	if opts.ZoomScale != nil && *opts.ZoomScale >= 10 && *opts.ZoomScale <= 400 {
+		view.Scale = *opts.ZoomScale
+	}
+}
+
+// GetCustomSheetViews provides a function to get the custom sheet views of the
+// worksheet by given worksheet name. The name of the custom sheet view will be
+// empty if the corresponding custom view of the workbook does not exist.
+func (f *File) GetCustomSheetViews(sheet string) ([]CustomSheetViewOptions, error) {
+	var views []CustomSheetViewOptions
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return views, err
+	}
+	if ws.CustomSheetViews == nil {
+		return views, err
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return views, err
+	}
+	for _, v := range ws.CustomSheetViews.CustomSheetView {
+		state := SheetStateVisible
+		opts := CustomSheetViewOptions{
+			ShowGridLines:      boolPtr(true),
+			ShowRowColHeaders:  boolPtr(true),
+			ShowFormulas:       boolPtr(v.ShowFormulas),
+			ShowZeros:          boolPtr(true),
+			ShowOutlineSymbols: boolPtr(true),
+			ShowPageBreaks:     boolPtr(v.ShowPageBreaks),
+			ShowRuler:          boolPtr(true),
+			HiddenRows:         boolPtr(v.HiddenRows),
+			HiddenColumns:      boolPtr(v.HiddenColumns),
+			FitToPage:          boolPtr(v.FitToPage),
+			PrintArea:          boolPtr(v.PrintArea),
+			IncludeFilter:      boolPtr(v.AutoFilter != nil),
+			State:              &state,
+			TopLeftCell:        stringPtr(v.TopLeftCell),
+			View:               stringPtr("normal"),
+			ZoomScale:          intPtr(100),
+		}
+		if wb.CustomWorkbookViews != nil {
+			for _, view := range wb.CustomWorkbookViews.CustomWorkbookView {
+				if view.GUID != nil && view.Name != nil && strings.EqualFold(*view.GUID, v.GUID) {
+					opts.Name = *view.Name
+				}
+			}
+		}
+		for _, field := range []struct {
+			value  *bool
+			target **bool
+		}{
+			{v.ShowGridLines, &opts.ShowGridLines},
+			{v.ShowRowCol, &opts.ShowRowColHeaders},
+			{v.ZeroValues, &opts.ShowZeros},
+			{v.OutlineSymbols, &opts.ShowOutlineSymbols},
+			{v.ShowRuler, &opts.ShowRuler},
+		} {
+			if field.value != nil {
+				*field.target = boolPtr(*field.value)
+			}
+		}
+		for sheetState, value := range sheetStates {
+			if v.State == value {
+				state = sheetState
+			}
+		}
+		if v.View != "" {
+			opts.View = stringPtr(v.View)
+		}
+		if v.Scale >= 10 && v.Scale <= 400 {
+			opts.ZoomScale = intPtr(v.Scale)
+		}
+		views = append(views, opts)
+	}
+	return views, err
+}
+
+// DeleteCustomSheetView provides a function to delete the custom sheet view of
+// the worksheet by given worksheet name and custom view name. The custom view
+// of the workbook will be deleted if there is no custom sheet view of the
+// other worksheets belongs to it.
+func (f *File) DeleteCustomSheetView(sheet, name string) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	guid, err := f.getCustomWorkbookViewGUID(name, false)
+	if err != nil || guid == "" || ws.CustomSheetViews == nil {
+		return err
+	}
+	views := ws.CustomSheetViews.CustomSheetView[:0]
+	for _, v := range ws.CustomSheetViews.CustomSheetView {
+		if !strings.EqualFold(v.GUID, guid) {
+			views = append(views, v)
+		}
+	}
+	if ws.CustomSheetViews.CustomSheetView = views; len(views) == 0 {
+		ws.CustomSheetViews = nil
+	}
+	for _, name := range f.GetSheetList() {
+		ws, err := f.workSheetReader(name)
+		if err != nil {
+			if _, ok := err.(ErrNotWorksheet); ok {
+				continue
+			}
+			return err
+		}
+		if ws.CustomSheetViews == nil {
+			continue
+		}
+		for _, v := range ws.CustomSheetViews.CustomSheetView {
+			if strings.EqualFold(v.GUID, guid) {
+				return err
+			}
+		}
+	}
+	wb, _ := f.workbookReader()
+	workbookViews := wb.CustomWorkbookViews.CustomWorkbookView[:0]
+	for _, v := range wb.CustomWorkbookViews.CustomWorkbookView {
+		if v.GUID == nil || !strings.EqualFold(*v.GUID, guid) {
+			workbookViews = append(workbookViews, v)
+		}
+	}
+	if wb.CustomWorkbookViews.CustomWorkbookView = workbookViews; len(workbookViews) == 0 {
+		wb.CustomWorkbookViews = nil
+	}
+	return nil
+}
+
+// getCustomWorkbookViewGUID provides a function to get the GUID of the custom
+// workbook view by given custom view name, the custom workbook view will be
+// created if it does not exist and the create parameter is true.
+func (f *File) getCustomWorkbookViewGUID(name string, create bool) (string, error) {
+	wb, err := f.workbookReader()
+	if err != nil {
+		return "", err
+	}
+	guids := map[string]struct{}{}
+	if wb.CustomWorkbookViews != nil {
+		for _, v := range wb.CustomWorkbookViews.CustomWorkbookView {
+			if v.GUID == nil {
+				continue
+			}
+			if v.Name != nil && strings.EqualFold(*v.Name, name) {
+				return *v.GUID, err
+			}
+			guids[strings.ToUpper(*v.GUID)] = struct{}{}
+		}
+	}
+	if !create {
+		return "", err
+	}
+	var guid string
+	for i := len(guids) + 1; ; i++ {
+		guid = fmt.Sprintf("{00000000-0000-0000-0000-%012X}", i)
+		if _, ok := guids[guid]; !ok {
+			break
+		}
+	}
+	view := xlsxCustomWorkbookView{
+		GUID:          stringPtr(guid),
+		Name:          stringPtr(name),
+		ActiveSheetID: intPtr(f.getActiveSheetID()),
+		WindowWidth:   intPtr(1920),
+		WindowHeight:  intPtr(1080),
+	}
+	if wb.BookViews != nil && len(wb.BookViews.WorkBookView) > 0 {
+		if bookView := wb.BookViews.WorkBookView[0]; bookView.WindowWidth > 0 && bookView.WindowHeight > 0 {
+			view.WindowWidth, view.WindowHeight = intPtr(bookView.WindowWidth), intPtr(bookView.WindowHeight)
+		}
+	}
+	if wb.CustomWorkbookViews == nil {
+		wb.CustomWorkbookViews = &xlsxCustomWorkbookViews{}
+	}
+	wb.CustomWorkbookViews.CustomWorkbookView = append(wb.CustomWorkbookViews.CustomWorkbookView, view)
+	return guid, err
+}
+
+// SetSheetRightToLeft provides a function to set the worksheet displayed from
+// right to left by given worksheet name, it will be applied to all views of
+// the worksheet. In a right to left worksheet, the column A will be displayed
+// at the right side of the window, and the general horizontal alignment of
+// the text will be right aligned. The column widths, row heights and the
+// anchors of the drawing objects are stored in the logical order, so they
+// keep unchanged and will be mirrored by the spreadsheet application. For
+// example, set the worksheet named Sheet1 displayed from right to left for
+// Arabic or Hebrew reports:
+//
+//	err := f.SetSheetRightToLeft("Sheet1", true)
+func (f *File) SetSheetRightToLeft(sheet string, rtl bool) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if ws.SheetViews == nil || len(ws.SheetViews.SheetView) == 0 {
+		ws.SheetViews = &xlsxSheetViews{SheetView: []xlsxSheetView{{WorkbookViewID: 0}}}
+	}
+	for i := range ws.SheetViews.SheetView {
+		ws.SheetViews.SheetView[i].RightToLeft = rtl
+	}
+	return err
+}
+
+// SetWorkbookRightToLeft provides a function to set all worksheets in the
+// workbook displayed from right to left, the chart sheets will be skipped.
+// For example:
+//
+//	err := f.SetWorkbookRightToLeft(true)
+func (f *File) SetWorkbookRightToLeft(rtl bool) error {
+	for _, sheet := range f.GetSheetList() {
+		err := f.SetSheetRightToLeft(sheet, rtl)
+		if _, ok := err.(ErrNotWorksheet); ok {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isSheetRightToLeft provides a function to check if the first view of the
+// worksheet is displayed from right to left by given worksheet name.
+func (f *File) isSheetRightToLeft(sheet string) (bool, error) {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.SheetViews == nil || len(ws.SheetViews.SheetView) == 0 {
+		return false, err
+	}
+	return ws.SheetViews.SheetView[0].RightToLeft, err
+}
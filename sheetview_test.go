@@ -1,6 +1,8 @@
 package excelize
 
 import (
+	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -48,3 +50,165 @@ func TestGetView(t *testing.T) {
 	_, err = f.GetSheetView("SheetN", 0)
 	assert.EqualError(t, err, "sheet SheetN does not exist")
 }
+
+func TestSetSheetRightToLeft(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Name: "Sheet1!$A$1", Categories: "Sheet1!$A$2:$A$3", Values: "Sheet1!$B$2:$B$3"}},
+	}))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetViews = nil
+	assert.NoError(t, f.SetSheetRightToLeft("Sheet1", true))
+	rtl, err := f.isSheetRightToLeft("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, rtl)
+	assert.NoError(t, f.SetWorkbookRightToLeft(true))
+	for _, sheet := range []string{"Sheet1", "Sheet2"} {
+		opts, err := f.GetSheetView(sheet, 0)
+		assert.NoError(t, err)
+		assert.True(t, *opts.RightToLeft)
+	}
+	assert.NoError(t, f.SetWorkbookRightToLeft(false))
+	rtl, err = f.isSheetRightToLeft("Sheet2")
+	assert.NoError(t, err)
+	assert.False(t, rtl)
+	// Test set right to left on not exists worksheet
+	assert.EqualError(t, f.SetSheetRightToLeft("SheetN", true), "sheet SheetN does not exist")
+	_, err = f.isSheetRightToLeft("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test set right to left with unsupported charset worksheet
+	f.Sheet.Delete("xl/worksheets/sheet2.xml")
+	f.Pkg.Store("xl/worksheets/sheet2.xml", MacintoshCyrillicCharset)
+	f.checked = sync.Map{}
+	assert.EqualError(t, f.SetWorkbookRightToLeft(true), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestCustomSheetView(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Name", "Amount"}))
+	assert.NoError(t, f.AutoFilter("Sheet1", "A1:B3", []AutoFilterOptions{{Column: "B", Expression: "x > 10"}}))
+	state := SheetStateHidden
+	expected := CustomSheetViewOptions{
+		Name:               "Summary",
+		ShowGridLines:      boolPtr(false),
+		ShowRowColHeaders:  boolPtr(false),
+		ShowFormulas:       boolPtr(true),
+		ShowZeros:          boolPtr(false),
+		ShowOutlineSymbols: boolPtr(false),
+		ShowPageBreaks:     boolPtr(true),
+		ShowRuler:          boolPtr(false),
+		HiddenRows:         boolPtr(true),
+		HiddenColumns:      boolPtr(true),
+		FitToPage:          boolPtr(true),
+		PrintArea:          boolPtr(true),
+		IncludeFilter:      boolPtr(true),
+		State:              &state,
+		TopLeftCell:        stringPtr("B2"),
+		View:               stringPtr("pageLayout"),
+		ZoomScale:          intPtr(80),
+	}
+	assert.NoError(t, f.SetCustomSheetView("Sheet1", &expected))
+	assert.NoError(t, f.SetCustomSheetView("Sheet2", &CustomSheetViewOptions{Name: "summary"}))
+	assert.NoError(t, f.SetCustomSheetView("Sheet1", &CustomSheetViewOptions{Name: "Detail", ZoomScale: intPtr(500), View: stringPtr("unknown")}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCustomSheetView.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test get custom sheet views after reopen the workbook
+	f, err = OpenFile(filepath.Join("test", "TestCustomSheetView.xlsx"))
+	assert.NoError(t, err)
+	views, err := f.GetCustomSheetViews("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, views, 2)
+	assert.Equal(t, expected, views[0])
+	assert.Equal(t, "Detail", views[1].Name)
+	assert.Equal(t, 100, *views[1].ZoomScale)
+	assert.Equal(t, "normal", *views[1].View)
+	assert.True(t, *views[1].ShowGridLines)
+	assert.False(t, *views[1].IncludeFilter)
+	assert.Equal(t, SheetStateVisible, *views[1].State)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "{00000000-0000-0000-0000-000000000001}", ws.CustomSheetViews.CustomSheetView[0].GUID)
+	assert.Len(t, ws.CustomSheetViews.CustomSheetView[0].AutoFilter.FilterColumn, 1)
+	views, err = f.GetCustomSheetViews("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, views, 1)
+	assert.Equal(t, "Summary", views[0].Name)
+	// Test update the custom sheet view
+	assert.NoError(t, f.SetCustomSheetView("Sheet1", &CustomSheetViewOptions{
+		Name: "Summary", ShowGridLines: boolPtr(true), IncludeFilter: boolPtr(false), State: &[]SheetState{SheetStateVisible}[0],
+	}))
+	views, err = f.GetCustomSheetViews("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, *views[0].ShowGridLines)
+	assert.False(t, *views[0].IncludeFilter)
+	assert.False(t, *views[0].ShowZeros)
+	assert.Equal(t, SheetStateVisible, *views[0].State)
+	// Test get custom sheet views without custom sheet views
+	_, err = f.NewSheet("Sheet3")
+	assert.NoError(t, err)
+	views, err = f.GetCustomSheetViews("Sheet3")
+	assert.NoError(t, err)
+	assert.Empty(t, views)
+
+	// Test delete custom sheet view
+	assert.NoError(t, f.DeleteCustomSheetView("Sheet1", "Summary"))
+	assert.NoError(t, f.DeleteCustomSheetView("Sheet3", "Summary"))
+	assert.NoError(t, f.DeleteCustomSheetView("Sheet1", "Unknown"))
+	wb, err := f.workbookReader()
+	assert.NoError(t, err)
+	assert.Len(t, wb.CustomWorkbookViews.CustomWorkbookView, 2)
+	assert.NoError(t, f.DeleteCustomSheetView("Sheet2", "Summary"))
+	assert.Len(t, wb.CustomWorkbookViews.CustomWorkbookView, 1)
+	assert.NoError(t, f.DeleteCustomSheetView("Sheet1", "Detail"))
+	assert.Nil(t, wb.CustomWorkbookViews)
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Nil(t, ws.CustomSheetViews)
+	// Test set custom sheet view with the GUID which has been used
+	wb.CustomWorkbookViews = &xlsxCustomWorkbookViews{CustomWorkbookView: []xlsxCustomWorkbookView{{}, {GUID: stringPtr("{00000000-0000-0000-0000-000000000002}")}}}
+	assert.NoError(t, f.SetCustomSheetView("Sheet1", &CustomSheetViewOptions{Name: "View"}))
+	assert.Equal(t, "{00000000-0000-0000-0000-000000000003}", ws.CustomSheetViews.CustomSheetView[0].GUID)
+	// Test custom sheet views with invalid options
+	assert.Equal(t, ErrParameterRequired, f.SetCustomSheetView("Sheet1", nil))
+	assert.Equal(t, ErrParameterRequired, f.SetCustomSheetView("Sheet1", &CustomSheetViewOptions{}))
+	state = SheetState(10)
+	assert.Equal(t, ErrParameterInvalid, f.SetCustomSheetView("Sheet1", &CustomSheetViewOptions{Name: "View", State: &state}))
+	// Test custom sheet views with not exist worksheet
+	assert.EqualError(t, f.SetCustomSheetView("SheetN", &CustomSheetViewOptions{Name: "View"}), "sheet SheetN does not exist")
+	_, err = f.GetCustomSheetViews("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.EqualError(t, f.DeleteCustomSheetView("SheetN", "View"), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+
+	// Test custom sheet views with unsupported charset workbook
+	f = NewFile()
+	assert.NoError(t, f.SetCustomSheetView("Sheet1", &CustomSheetViewOptions{Name: "View"}))
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCustomSheetView("Sheet1", &CustomSheetViewOptions{Name: "View"}), "XML syntax error on line 1: invalid UTF-8")
+	f.WorkBook = nil
+	_, err = f.GetCustomSheetViews("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	f.WorkBook = nil
+	assert.EqualError(t, f.DeleteCustomSheetView("Sheet1", "View"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+
+	// Test delete custom sheet view with unsupported charset worksheet
+	f = NewFile()
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCustomSheetView("Sheet1", &CustomSheetViewOptions{Name: "View"}))
+	f.Sheet.Delete("xl/worksheets/sheet2.xml")
+	f.Pkg.Store("xl/worksheets/sheet2.xml", MacintoshCyrillicCharset)
+	f.checked = sync.Map{}
+	assert.EqualError(t, f.DeleteCustomSheetView("Sheet1", "View"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
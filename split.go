@@ -0,0 +1,174 @@
+// Copyright 2016 - 2024 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.18 or later.
+
+package excelize
+
+import (
+	"strconv"
+
+	"github.com/mohae/deepcopy"
+)
+
+// SplitByColumn provides a function to split the worksheet into workbooks by
+// given worksheet name, column name and optional settings, one workbook will
+// be created for each distinct formatted value of the cells in the column,
+// and the workbooks are returned by the cell values. Each workbook contains a
+// worksheet with the same name, which includes the header rows and the rows
+// with the cell value of the column. The styles, column widths, row heights,
+// the sheet view and the merged cells of the header rows will be preserved,
+// and the formulas of the cells will be replaced with the cached values,
+// since the rows are rearranged in the new worksheets. The first row is the
+// header row by default, the HeaderRows of the settings specifies the number
+// of header rows, set it to 0 if the worksheet has no header rows. For
+// example, split the worksheet Sheet1 by the customers in column B, and save
+// each workbook as a separate file:
+//
+//	books, err := f.SplitByColumn("Sheet1", "B")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for customer, book := range books {
+//	    if err := book.SaveAs(customer + ".xlsx"); err != nil {
+//	        fmt.Println(err)
+//	    }
+//	}
+func (f *File) SplitByColumn(sheet, column string, opts ...SplitOptions) (map[string]*File, error) {
+	col, err := ColumnNameToNumber(column)
+	if err != nil {
+		return nil, err
+	}
+	headerRows := 1
+	for _, opt := range opts {
+		if opt.HeaderRows != nil {
+			headerRows = *opt.HeaderRows
+		}
+	}
+	if headerRows < 0 || headerRows > TotalRows {
+		return nil, ErrParameterInvalid
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return nil, err
+	}
+	if err = f.sharedStringsLoader(); err != nil {
+		return nil, err
+	}
+	sst, err := f.sharedStringsReader()
+	if err != nil {
+		return nil, err
+	}
+	var header []xlsxRow
+	groups, books := map[string][]xlsxRow{}, map[string]*File{}
+	var values []string
+	for _, row := range ws.SheetData.Row {
+		if row.R <= headerRows {
+			header = append(header, row)
+			continue
+		}
+		cell, _ := CoordinatesToCellName(col, row.R)
+		value, err := f.GetCellValue(sheet, cell)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := groups[value]; !ok {
+			values = append(values, value)
+		}
+		groups[value] = append(groups[value], row)
+	}
+	for _, value := range values {
+		book, err := f.newSplitWorkbook(sheet, ws, sst, headerRows, header, groups[value])
+		if err != nil {
+			return nil, err
+		}
+		books[value] = book
+	}
+	return books, err
+}
+
+// newSplitWorkbook provides a function to create a workbook for the split
+// worksheet by given worksheet name, source worksheet, shared strings table,
+// number of header rows, the header rows and the data rows to be copied.
+func (f *File) newSplitWorkbook(sheet string, ws *xlsxWorksheet, sst *xlsxSST, headerRows int, header, data []xlsxRow) (*File, error) {
+	styleSheet, err := f.stylesReader()
+	if err != nil {
+		return nil, err
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return nil, err
+	}
+	book := NewFile()
+	book.Styles = deepcopy.Copy(styleSheet).(*xlsxStyleSheet)
+	if f.Theme != nil {
+		book.Theme = deepcopy.Copy(f.Theme).(*decodeTheme)
+	}
+	if wb.WorkbookPr != nil && wb.WorkbookPr.Date1904 {
+		book.WorkBook.WorkbookPr.Date1904 = true
+	}
+	bookSST, _ := book.sharedStringsReader()
+	sstIndex := map[string]string{}
+	bookWs, _ := book.workSheetReader("Sheet1")
+	bookWs.SheetPr = deepcopy.Copy(ws.SheetPr).(*xlsxSheetPr)
+	bookWs.SheetFormatPr = deepcopy.Copy(ws.SheetFormatPr).(*xlsxSheetFormatPr)
+	bookWs.SheetViews = deepcopy.Copy(ws.SheetViews).(*xlsxSheetViews)
+	bookWs.Cols = deepcopy.Copy(ws.Cols).(*xlsxCols)
+	if ws.MergeCells != nil {
+		for _, mergeCell := range ws.MergeCells.Cells {
+			rect, err := rangeRefToCoordinates(mergeCell.Ref)
+			if err != nil {
+				return nil, err
+			}
+			if rect[3] <= headerRows {
+				if bookWs.MergeCells == nil {
+					bookWs.MergeCells = &xlsxMergeCells{}
+				}
+				bookWs.MergeCells.Cells = append(bookWs.MergeCells.Cells, &xlsxMergeCell{Ref: mergeCell.Ref})
+			}
+		}
+	}
+	rows := deepcopy.Copy(append(append(make([]xlsxRow, 0, len(header)+len(data)), header...), data...)).([]xlsxRow)
+	for idx := range data {
+		rows[len(header)+idx].R = headerRows + idx + 1
+	}
+	bookWs.SheetData.Row = rows
+	for r := range bookWs.SheetData.Row {
+		row := &bookWs.SheetData.Row[r]
+		for i := range row.C {
+			c := &row.C[i]
+			col, _, err := CellNameToCoordinates(c.R)
+			if err != nil {
+				return nil, err
+			}
+			c.R, _ = CoordinatesToCellName(col, row.R)
+			c.F = nil
+			if c.T != "s" {
+				continue
+			}
+			if idx, ok := sstIndex[c.V]; ok {
+				c.V = idx
+				continue
+			}
+			var si xlsxSI
+			if i, err := strconv.Atoi(c.V); err == nil && i >= 0 && i < len(sst.SI) {
+				si = deepcopy.Copy(sst.SI[i]).(xlsxSI)
+			}
+			bookSST.SI = append(bookSST.SI, si)
+			bookSST.Count, bookSST.UniqueCount = len(bookSST.SI), len(bookSST.SI)
+			sstIndex[c.V] = strconv.Itoa(len(bookSST.SI) - 1)
+			c.V = sstIndex[c.V]
+		}
+	}
+	if sheet != "Sheet1" {
+		err = book.SetSheetName("Sheet1", sheet)
+	}
+	return book, err
+}
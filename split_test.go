@@ -0,0 +1,122 @@
+package excelize
+
+import (
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitByColumn(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetName("Sheet1", "Orders"))
+	assert.NoError(t, f.SetSheetRow("Orders", "A1", &[]interface{}{"Order Report"}))
+	assert.NoError(t, f.MergeCell("Orders", "A1", "C1"))
+	assert.NoError(t, f.SetSheetRow("Orders", "A2", &[]interface{}{"ID", "Customer", "Amount"}))
+	for idx, row := range [][]interface{}{
+		{1, "Contoso", 100}, {2, "Fabrikam", 200}, {3, "Contoso", 300}, {4, nil, 400},
+	} {
+		assert.NoError(t, f.SetSheetRow("Orders", "A"+strconv.Itoa(idx+3), &row))
+	}
+	assert.NoError(t, f.SetCellRichText("Orders", "B4", []RichTextRun{{Text: "Fab"}, {Text: "rikam", Font: &Font{Bold: true}}}))
+	assert.NoError(t, f.SetCellFormula("Orders", "C5", "=C3*3"))
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetRowStyle("Orders", 2, 2, style))
+	assert.NoError(t, f.SetColWidth("Orders", "B", "B", 24))
+	assert.NoError(t, f.SetRowHeight("Orders", 5, 30))
+	assert.NoError(t, f.SetPanes("Orders", &Panes{Freeze: true, YSplit: 2, TopLeftCell: "A3", ActivePane: "bottomLeft"}))
+	books, err := f.SplitByColumn("Orders", "B", SplitOptions{HeaderRows: intPtr(2)})
+	assert.NoError(t, err)
+	assert.Len(t, books, 3)
+	for value, expected := range map[string][][]string{
+		"Contoso":  {{"Order Report"}, {"ID", "Customer", "Amount"}, {"1", "Contoso", "100"}, {"3", "Contoso", "300"}},
+		"Fabrikam": {{"Order Report"}, {"ID", "Customer", "Amount"}, {"2", "Fabrikam", "200"}},
+		"":         {{"Order Report"}, {"ID", "Customer", "Amount"}, {"4", "", "400"}},
+	} {
+		book := books[value]
+		assert.Equal(t, []string{"Orders"}, book.GetSheetList())
+		rows, err := book.GetRows("Orders")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, rows, value)
+		width, err := book.GetColWidth("Orders", "B")
+		assert.NoError(t, err)
+		assert.Equal(t, 24.0, width)
+		styleID, err := book.GetCellStyle("Orders", "B2")
+		assert.NoError(t, err)
+		assert.Equal(t, style, styleID)
+		cellStyle, err := book.GetStyle(styleID)
+		assert.NoError(t, err)
+		assert.True(t, cellStyle.Font.Bold)
+		mergeCells, err := book.GetMergeCells("Orders")
+		assert.NoError(t, err)
+		assert.Len(t, mergeCells, 1)
+		panes, err := book.GetPanes("Orders")
+		assert.NoError(t, err)
+		assert.True(t, panes.Freeze)
+	}
+	// Test the formulas are replaced with the cached values
+	formula, err := books["Contoso"].GetCellFormula("Orders", "C4")
+	assert.NoError(t, err)
+	assert.Empty(t, formula)
+	height, err := books["Contoso"].GetRowHeight("Orders", 4)
+	assert.NoError(t, err)
+	assert.Equal(t, 30.0, height)
+	// Test the rich text cells are preserved
+	runs, err := books["Fabrikam"].GetCellRichText("Orders", "B3")
+	assert.NoError(t, err)
+	assert.Len(t, runs, 2)
+	assert.NoError(t, books["Contoso"].SaveAs(filepath.Join("test", "TestSplitByColumn.xlsx")))
+	for _, book := range books {
+		assert.NoError(t, book.Close())
+	}
+	// Test split the worksheet without header rows
+	books, err = f.SplitByColumn("Orders", "B", SplitOptions{HeaderRows: intPtr(0)})
+	assert.NoError(t, err)
+	assert.Len(t, books, 5)
+	// Test split the worksheet with invalid parameters
+	_, err = f.SplitByColumn("Orders", "*")
+	assert.EqualError(t, err, newInvalidColumnNameError("*").Error())
+	_, err = f.SplitByColumn("Orders", "B", SplitOptions{HeaderRows: intPtr(-1)})
+	assert.Equal(t, ErrParameterInvalid, err)
+	// Test split the worksheet on not exists worksheet
+	_, err = f.SplitByColumn("SheetN", "B")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test split the worksheet with invalid merged cell reference
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).MergeCells.Cells[0].Ref = "A1"
+	_, err = f.SplitByColumn("Orders", "B")
+	assert.Equal(t, ErrParameterInvalid, err)
+	ws.(*xlsxWorksheet).MergeCells.Cells[0].Ref = "A1:C1"
+	// Test split the worksheet with invalid cell reference
+	ws.(*xlsxWorksheet).SheetData.Row[2].C[0].R = "A"
+	_, err = f.SplitByColumn("Orders", "C")
+	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	assert.NoError(t, f.Close())
+
+	// Test split the worksheet with unsupported charset shared strings table
+	f = NewFile()
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	_, err = f.SplitByColumn("Sheet1", "A")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+	// Test split the worksheet with unsupported charset styles
+	f = NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", 1))
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	_, err = f.SplitByColumn("Sheet1", "A")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+	// Test split the worksheet with unsupported charset workbook
+	f = NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", 1))
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	_, err = f.SplitByColumn("Sheet1", "A")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
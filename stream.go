@@ -18,6 +18,7 @@ import (
 	"io"
 	"os"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -29,13 +30,34 @@ type StreamWriter struct {
 	Sheet           string
 	SheetID         int
 	sheetWritten    bool
-	cols            strings.Builder
+	colOpts         []streamColOpts
+	cols            []xlsxCol
 	worksheet       *xlsxWorksheet
 	rawData         bufferedWriter
 	rows            int
 	mergeCellsCount int
 	mergeCells      strings.Builder
 	tableParts      string
+	rowBufferSize   int
+	rowBuffer       map[int][]byte
+	bufferedRows    []int
+	table           *Table
+	autoFilter      *streamAutoFilter
+}
+
+// streamColOpts directly maps the width or style settings of the columns for
+// the stream writer in the order of settings.
+type streamColOpts struct {
+	min, max int
+	width    *float64
+	style    *int
+}
+
+// streamAutoFilter directly maps the auto filter of the stream writer, which
+// range will be determined when calling Flush.
+type streamAutoFilter struct {
+	coordinates []int
+	opts        []AutoFilterOptions
 }
 
 // NewStreamWriter returns stream writer struct by given worksheet name used for
@@ -132,10 +154,12 @@ func (f *File) NewStreamWriter(sheet string) (*StreamWriter, error) {
 	}
 
 	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	f.mu.Lock()
 	if f.streams == nil {
 		f.streams = make(map[string]*StreamWriter)
 	}
 	f.streams[sheetXMLPath] = sw
+	f.mu.Unlock()
 
 	_, _ = sw.rawData.WriteString(xml.Header + `<worksheet` + templateNamespaceIDMap)
 	bulkAppendFields(&sw.rawData, sw.worksheet, 2, 3)
@@ -164,7 +188,15 @@ func (f *File) NewStreamWriter(sheet string) (*StreamWriter, error) {
 // header cells must contain strings and must be unique.
 //
 // Currently, only one table is allowed for a StreamWriter. AddTable must be
-// called after the rows are written but before Flush.
+// called before Flush. If the range of the table only contains the header
+// row, such as A1:D1, the table will be created when calling Flush and its
+// range will be extended to the last row written by the stream writer, so
+// that the table can be added without knowing the final number of rows:
+//
+//	err := sw.AddTable(&excelize.Table{Range: "A1:D1"})
+//
+// Otherwise, the header row of the table must be written before calling
+// AddTable.
 //
 // See File.AddTable for details on the table format.
 func (sw *StreamWriter) AddTable(table *Table) error {
@@ -177,7 +209,24 @@ func (sw *StreamWriter) AddTable(table *Table) error {
 		return err
 	}
 	_ = sortCoordinates(coordinates)
+	// Defer the table creation until flush if only the header row is given.
+	if coordinates[1] == coordinates[3] {
+		if _, err = coordinatesToRangeRef(coordinates); err != nil {
+			return err
+		}
+		table := *options
+		sw.table = &table
+		return err
+	}
+	if err = sw.writeBufferedRows(0); err != nil {
+		return err
+	}
+	return sw.addTable(options, coordinates)
+}
 
+// addTable provides a function to create the table part for the stream
+// writer by given table options and sorted coordinates of the table range.
+func (sw *StreamWriter) addTable(options *Table, coordinates []int) error {
 	// Correct the minimum number of rows, the table at least two lines.
 	if coordinates[1] == coordinates[3] {
 		coordinates[3]++
@@ -202,6 +251,8 @@ func (sw *StreamWriter) AddTable(table *Table) error {
 		}
 	}
 
+	sw.file.mu.Lock()
+	defer sw.file.mu.Unlock()
 	tableID := sw.file.countTables() + 1
 
 	name := options.Name
@@ -385,26 +436,59 @@ func parseRowOpts(opts ...RowOpts) *RowOpts {
 //
 // As a special case, if Cell is used as a value, then the Cell.StyleID will be
 // applied to that cell.
+//
+// The rows must be written in ascending order of row numbers, unless the row
+// buffer was set by the SetRowBuffer function, which allows the rows to be
+// written slightly out of order.
 func (sw *StreamWriter) SetRow(cell string, values []interface{}, opts ...RowOpts) error {
 	col, row, err := CellNameToCoordinates(cell)
 	if err != nil {
 		return err
 	}
-	if row <= sw.rows {
+	if sw.rowBuffer == nil {
+		if row <= sw.rows {
+			return newStreamSetRowError(row)
+		}
+		sw.rows = row
+	} else if _, ok := sw.rowBuffer[row]; ok {
 		return newStreamSetRowError(row)
+	} else if row <= sw.rows {
+		return newStreamRowBufferExceededError(row, sw.rows)
 	}
-	sw.rows = row
 	sw.writeSheetData()
 	options := parseRowOpts(opts...)
 	attrs, err := options.marshalAttrs()
 	if err != nil {
 		return err
 	}
-	_, _ = sw.rawData.WriteString(`<row r="`)
-	_, _ = sw.rawData.WriteString(strconv.Itoa(row))
-	_, _ = sw.rawData.WriteString(`"`)
-	_, _ = sw.rawData.WriteString(attrs.String())
-	_, _ = sw.rawData.WriteString(`>`)
+	if sw.rowBuffer == nil {
+		err = sw.writeRow(&sw.rawData, col, row, values, options, attrs.String())
+		if err == nil {
+			err = sw.rawData.Sync()
+		}
+		return err
+	}
+	var buf bufferedWriter
+	err = sw.writeRow(&buf, col, row, values, options, attrs.String())
+	sw.rowBuffer[row] = buf.buf.Bytes()
+	idx := sort.SearchInts(sw.bufferedRows, row)
+	sw.bufferedRows = append(sw.bufferedRows, 0)
+	copy(sw.bufferedRows[idx+1:], sw.bufferedRows[idx:])
+	sw.bufferedRows[idx] = row
+	if syncErr := sw.writeBufferedRows(sw.rowBufferSize); err == nil {
+		err = syncErr
+	}
+	return err
+}
+
+// writeRow provides a function to write a row with the values to the given
+// buffered writer.
+func (sw *StreamWriter) writeRow(w *bufferedWriter, col, row int, values []interface{}, options *RowOpts, attrs string) error {
+	_, _ = w.WriteString(`<row r="`)
+	_, _ = w.WriteString(strconv.Itoa(row))
+	_, _ = w.WriteString(`"`)
+	_, _ = w.WriteString(attrs)
+	_, _ = w.WriteString(`>`)
 	for i, val := range values {
 		if val == nil {
 			continue
@@ -423,13 +507,59 @@ func (sw *StreamWriter) SetRow(cell string, values []interface{}, opts ...RowOpt
 			val = v.Value
 			setCellFormula(&c, v.Formula)
 		}
+		if c.S == 0 {
+			c.S = sw.getColStyle(col + i)
+		}
 		if err = sw.setCellValFunc(&c, val); err != nil {
-			_, _ = sw.rawData.WriteString(`</row>`)
+			_, _ = w.WriteString(`</row>`)
 			return err
 		}
-		writeCell(&sw.rawData, c)
+		writeCell(w, c)
+	}
+	_, _ = w.WriteString(`</row>`)
+	return nil
+}
+
+// SetRowBuffer provides a function to set the size of the row buffer for the
+// StreamWriter, which allows the rows to be written out of order within the
+// given number of rows, such as the rows produced by multiple goroutines. The
+// buffered rows will be written in ascending order of row numbers when the
+// buffer is full or calling the 'Flush' function. An error will be returned
+// if writing a row which is less than the rows that have been written out of
+// the buffer. Set the size as 0 to disable the row buffer. For example, allow
+// the rows to be written out of order within 100 rows:
+//
+//	err := sw.SetRowBuffer(100)
+func (sw *StreamWriter) SetRowBuffer(size int) error {
+	if size < 0 {
+		return ErrParameterInvalid
+	}
+	if err := sw.writeBufferedRows(size); err != nil {
+		return err
+	}
+	if sw.rowBufferSize = size; size == 0 {
+		sw.rowBuffer, sw.bufferedRows = nil, nil
+		return nil
+	}
+	if sw.rowBuffer == nil {
+		sw.rowBuffer = make(map[int][]byte)
 	}
-	_, _ = sw.rawData.WriteString(`</row>`)
+	return nil
+}
+
+// writeBufferedRows provides a function to write the buffered rows in
+// ascending order of row numbers until the number of the buffered rows is
+// not more than the given size.
+func (sw *StreamWriter) writeBufferedRows(size int) error {
+	if len(sw.bufferedRows) <= size {
+		return nil
+	}
+	for _, row := range sw.bufferedRows[:len(sw.bufferedRows)-size] {
+		_, _ = sw.rawData.Write(sw.rowBuffer[row])
+		delete(sw.rowBuffer, row)
+		sw.rows = row
+	}
+	sw.bufferedRows = append(sw.bufferedRows[:0], sw.bufferedRows[len(sw.bufferedRows)-size:]...)
 	return sw.rawData.Sync()
 }
 
@@ -453,16 +583,113 @@ func (sw *StreamWriter) SetColWidth(minVal, maxVal int, width float64) error {
 		minVal, maxVal = maxVal, minVal
 	}
 
-	sw.cols.WriteString(`<col min="`)
-	sw.cols.WriteString(strconv.Itoa(minVal))
-	sw.cols.WriteString(`" max="`)
-	sw.cols.WriteString(strconv.Itoa(maxVal))
-	sw.cols.WriteString(`" width="`)
-	sw.cols.WriteString(strconv.FormatFloat(width, 'f', -1, 64))
-	sw.cols.WriteString(`" customWidth="1"/>`)
+	sw.colOpts = append(sw.colOpts, streamColOpts{min: minVal, max: maxVal, width: float64Ptr(width)})
 	return nil
 }
 
+// SetColStyle provides a function to set the style of a single column or
+// multiple columns for the StreamWriter, the style will be applied to the
+// cells of the subsequently streamed rows in these columns, which have no
+// style specified by the cell or row options. Note that you must call the
+// 'SetColStyle' function before the 'SetRow' function. For example set the
+// style of column B:C:
+//
+//	err := sw.SetColStyle(2, 3, styleID)
+func (sw *StreamWriter) SetColStyle(minVal, maxVal, styleID int) error {
+	if sw.sheetWritten {
+		return ErrStreamSetColStyle
+	}
+	if minVal < MinColumns || minVal > MaxColumns || maxVal < MinColumns || maxVal > MaxColumns {
+		return ErrColumnNumber
+	}
+	if minVal > maxVal {
+		minVal, maxVal = maxVal, minVal
+	}
+	sw.file.mu.Lock()
+	s, err := sw.file.stylesReader()
+	sw.file.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	if styleID < 0 || s.CellXfs == nil || len(s.CellXfs.Xf) <= styleID {
+		s.mu.Unlock()
+		return newInvalidStyleID(styleID)
+	}
+	s.mu.Unlock()
+	sw.colOpts = append(sw.colOpts, streamColOpts{min: minVal, max: maxVal, style: intPtr(styleID)})
+	return err
+}
+
+// SetDefaultRowHeight provides a function to set the default height of rows
+// in points for the StreamWriter. Note that you must call the
+// 'SetDefaultRowHeight' function before the 'SetRow' function. For example,
+// set the default row height as 20 points:
+//
+//	err := sw.SetDefaultRowHeight(20)
+func (sw *StreamWriter) SetDefaultRowHeight(height float64) error {
+	if sw.sheetWritten {
+		return ErrStreamSetDefaultRowHeight
+	}
+	if height > MaxRowHeight {
+		return ErrMaxRowHeight
+	}
+	if height <= 0 {
+		return ErrParameterInvalid
+	}
+	if sw.worksheet.SheetFormatPr == nil {
+		sw.worksheet.SheetFormatPr = &xlsxSheetFormatPr{}
+	}
+	sw.worksheet.SheetFormatPr.DefaultRowHeight = height
+	sw.worksheet.SheetFormatPr.CustomHeight = true
+	return nil
+}
+
+// getColStyle provides a function to get the style of the column by given
+// column number, which was set by the SetColStyle function.
+func (sw *StreamWriter) getColStyle(col int) int {
+	idx := sort.Search(len(sw.cols), func(i int) bool { return sw.cols[i].Max >= col })
+	if idx < len(sw.cols) && sw.cols[idx].Min <= col {
+		return sw.cols[idx].Style
+	}
+	return 0
+}
+
+// flatColOpts provides a function to flatten the column settings of the
+// stream writer into the sorted and non-overlapping columns, the later
+// settings of the width or style will override the earlier settings.
+func (sw *StreamWriter) flatColOpts() {
+	var bounds []int
+	for _, opts := range sw.colOpts {
+		bounds = append(bounds, opts.min, opts.max+1)
+	}
+	sort.Ints(bounds)
+	width := defaultColWidth
+	if sw.worksheet.SheetFormatPr != nil && sw.worksheet.SheetFormatPr.DefaultColWidth > 0 {
+		width = sw.worksheet.SheetFormatPr.DefaultColWidth
+	}
+	for i := 0; i+1 < len(bounds); i++ {
+		if bounds[i] == bounds[i+1] {
+			continue
+		}
+		col, covered := xlsxCol{Min: bounds[i], Max: bounds[i+1] - 1, Width: float64Ptr(width)}, false
+		for _, opts := range sw.colOpts {
+			if opts.min > col.Min || col.Max > opts.max {
+				continue
+			}
+			if covered = true; opts.width != nil {
+				col.Width, col.CustomWidth = opts.width, true
+			}
+			if opts.style != nil {
+				col.Style = *opts.style
+			}
+		}
+		if covered {
+			sw.cols = append(sw.cols, col)
+		}
+	}
+}
+
 // InsertPageBreak creates a page break to determine where the printed page ends
 // and where begins the next one by a given cell reference, the content before
 // the page break will be printed on one page and after the page break on
@@ -498,6 +725,59 @@ func (sw *StreamWriter) MergeCell(topLeftCell, bottomRightCell string) error {
 	return nil
 }
 
+// AutoFilter provides the method to add auto filter for the StreamWriter by
+// given range reference and settings. The auto filter will be applied when
+// calling Flush, and if the range only contains the header row, such as
+// A1:D1, the range will be extended to the last row written by the stream
+// writer. For example, add auto filter with the header row A1:D1:
+//
+//	err := sw.AutoFilter("A1:D1", []excelize.AutoFilterOptions{})
+//
+// See File.AutoFilter for details on the filter settings.
+func (sw *StreamWriter) AutoFilter(rangeRef string, opts []AutoFilterOptions) error {
+	coordinates, err := rangeRefToCoordinates(rangeRef)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	if _, err = coordinatesToRangeRef(coordinates); err != nil {
+		return err
+	}
+	sw.autoFilter = &streamAutoFilter{coordinates: coordinates, opts: opts}
+	return err
+}
+
+// flushDeferred provides a function to create the table and apply the auto
+// filter which ranges will be determined by the last row written by the
+// stream writer.
+func (sw *StreamWriter) flushDeferred() error {
+	if sw.table != nil {
+		coordinates, _ := rangeRefToCoordinates(sw.table.Range)
+		_ = sortCoordinates(coordinates)
+		if coordinates[3] < sw.rows {
+			coordinates[3] = sw.rows
+		}
+		if err := sw.addTable(sw.table, coordinates); err != nil {
+			return err
+		}
+		sw.table = nil
+	}
+	if sw.autoFilter != nil {
+		coordinates := sw.autoFilter.coordinates
+		if coordinates[1] == coordinates[3] && coordinates[3] < sw.rows {
+			coordinates[3] = sw.rows
+		}
+		ref, _ := coordinatesToRangeRef(coordinates)
+		sw.file.mu.Lock()
+		defer sw.file.mu.Unlock()
+		if err := sw.file.AutoFilter(sw.Sheet, ref, sw.autoFilter.opts); err != nil {
+			return err
+		}
+		sw.autoFilter = nil
+	}
+	return nil
+}
+
 // setCellFormula provides a function to set formula of a cell.
 func setCellFormula(c *xlsxC, formula string) {
 	if formula != "" {
@@ -534,14 +814,18 @@ func (sw *StreamWriter) setCellValFunc(c *xlsxC, val interface{}) error {
 		c.T, c.V = setCellFloat(val, -1, 64)
 	case string:
 		c.setCellValue(val)
+		c.S, err = sw.file.prepareQuotePrefixStyle(c.S, val)
 	case []byte:
 		c.setCellValue(string(val))
+		c.S, err = sw.file.prepareQuotePrefixStyle(c.S, string(val))
 	case time.Duration:
 		c.T, c.V = setCellDuration(val)
 	case time.Time:
 		err = sw.setCellTime(c, val)
 	case bool:
 		c.T, c.V = setCellBool(val)
+	case CellErrorType:
+		c.T, c.V, err = setCellError(val)
 	case nil:
 		return err
 	case []RichTextRun:
@@ -644,19 +928,28 @@ func writeCell(buf *bufferedWriter, c xlsxC) {
 func (sw *StreamWriter) writeSheetData() {
 	if !sw.sheetWritten {
 		bulkAppendFields(&sw.rawData, sw.worksheet, 4, 5)
-		if sw.cols.Len() > 0 {
-			_, _ = sw.rawData.WriteString("<cols>")
-			_, _ = sw.rawData.WriteString(sw.cols.String())
-			_, _ = sw.rawData.WriteString("</cols>")
+		sw.flatColOpts()
+		if len(sw.cols) > 0 {
+			cols, _ := xml.Marshal(xlsxCols{Col: sw.cols})
+			_, _ = sw.rawData.Write(cols)
 		}
 		_, _ = sw.rawData.WriteString(`<sheetData>`)
 		sw.sheetWritten = true
 	}
 }
 
-// Flush ending the streaming writing process.
+// Flush ending the streaming writing process. The stream writers of different
+// worksheets can be written and flushed concurrently in separate goroutines,
+// and the worksheets of these stream writers will be compressed in parallel
+// when saving the workbook.
 func (sw *StreamWriter) Flush() error {
 	sw.writeSheetData()
+	if err := sw.writeBufferedRows(0); err != nil {
+		return err
+	}
+	if err := sw.flushDeferred(); err != nil {
+		return err
+	}
 	_, _ = sw.rawData.WriteString(`</sheetData>`)
 	bulkAppendFields(&sw.rawData, sw.worksheet, 8, 15)
 	mergeCells := strings.Builder{}
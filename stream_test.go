@@ -3,10 +3,13 @@ package excelize
 import (
 	"encoding/xml"
 	"fmt"
+	"io"
 	"math/rand"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -165,6 +168,119 @@ func TestStreamSetColWidth(t *testing.T) {
 	assert.Equal(t, ErrStreamSetColWidth, streamWriter.SetColWidth(2, 3, 20))
 }
 
+func TestStreamSetColStyle(t *testing.T) {
+	f := NewFile()
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	colStyle, err := f.NewStyle(&Style{NumFmt: 10})
+	assert.NoError(t, err)
+	cellStyle, err := f.NewStyle(&Style{NumFmt: 9})
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetColWidth(1, 3, 20))
+	assert.NoError(t, sw.SetColStyle(3, 2, colStyle))
+	assert.NoError(t, sw.SetColWidth(3, 3, 30))
+	assert.NoError(t, sw.SetDefaultRowHeight(25))
+	assert.Equal(t, ErrColumnNumber, sw.SetColStyle(0, 3, colStyle))
+	assert.Equal(t, ErrColumnNumber, sw.SetColStyle(MaxColumns+1, 3, colStyle))
+	assert.Equal(t, newInvalidStyleID(10), sw.SetColStyle(1, 3, 10))
+	assert.Equal(t, ErrMaxRowHeight, sw.SetDefaultRowHeight(MaxRowHeight+1))
+	assert.Equal(t, ErrParameterInvalid, sw.SetDefaultRowHeight(0))
+	assert.NoError(t, sw.SetRow("A1", []interface{}{1, 2, Cell{StyleID: cellStyle, Value: 3}, 4}))
+	assert.NoError(t, sw.SetRow("A2", []interface{}{1, 2, 3}, RowOpts{StyleID: cellStyle}))
+	assert.Equal(t, ErrStreamSetColStyle, sw.SetColStyle(2, 3, colStyle))
+	assert.Equal(t, ErrStreamSetDefaultRowHeight, sw.SetDefaultRowHeight(20))
+	assert.NoError(t, sw.Flush())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStreamSetColStyle.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestStreamSetColStyle.xlsx"))
+	assert.NoError(t, err)
+	for col, expected := range map[string]float64{"A": 20, "B": 20, "C": 30, "D": defaultColWidth} {
+		width, err := f.GetColWidth("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, width)
+	}
+	for col, expected := range map[string]int{"A": 0, "B": colStyle, "C": colStyle, "D": 0} {
+		styleID, err := f.GetColStyle("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, styleID)
+	}
+	for cell, expected := range map[string]int{"A1": 0, "B1": colStyle, "C1": cellStyle, "D1": 0, "B2": cellStyle} {
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, styleID, cell)
+	}
+	ht, err := f.GetRowHeight("Sheet1", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 25.0, ht)
+	assert.NoError(t, f.Close())
+
+	// Test set column style with unsupported charset style sheet
+	f = NewFile()
+	sw, err = f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, sw.SetColStyle(1, 1, 0), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestStreamSetRowBuffer(t *testing.T) {
+	f := NewFile()
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, ErrParameterInvalid, sw.SetRowBuffer(-1))
+	assert.NoError(t, sw.SetRowBuffer(3))
+	for _, row := range []int{2, 1, 4, 3, 6, 5} {
+		cell, _ := CoordinatesToCellName(1, row)
+		assert.NoError(t, sw.SetRow(cell, []interface{}{row}))
+	}
+	// Test write row which has been buffered
+	assert.Equal(t, newStreamSetRowError(5), sw.SetRow("A5", []interface{}{5}))
+	// Test write row which exceeds the row buffer
+	assert.NoError(t, sw.SetRow("A9", []interface{}{9}))
+	assert.NoError(t, sw.SetRow("A8", []interface{}{8}))
+	assert.Equal(t, newStreamRowBufferExceededError(3, 5), sw.SetRow("A3", []interface{}{3}))
+	// Test shrink the row buffer
+	assert.NoError(t, sw.SetRowBuffer(1))
+	assert.Equal(t, newStreamRowBufferExceededError(7, 8), sw.SetRow("A7", []interface{}{7}))
+	// Test disable the row buffer
+	assert.NoError(t, sw.SetRowBuffer(0))
+	assert.Equal(t, newStreamSetRowError(9), sw.SetRow("A9", []interface{}{9}))
+	assert.NoError(t, sw.SetRow("A10", []interface{}{10}))
+	assert.NoError(t, sw.Flush())
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"1"}, {"2"}, {"3"}, {"4"}, {"5"}, {"6"}, nil, {"8"}, {"9"}, {"10"}}, rows)
+	assert.NoError(t, f.Close())
+
+	// Test flush the buffered rows with the table
+	f = NewFile()
+	sw, err = f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRowBuffer(10))
+	assert.NoError(t, sw.SetRow("A2", []interface{}{1, 2}))
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"A", "B"}))
+	assert.NoError(t, sw.AddTable(&Table{Range: "A1:B2"}))
+	assert.Equal(t, newStreamRowBufferExceededError(1, 2), sw.SetRow("A1", nil))
+	assert.NoError(t, sw.Flush())
+	tables, err := f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1:B2", tables[0].Range)
+	assert.NoError(t, f.Close())
+
+	// Test write row with invalid value in the row buffer
+	f = NewFile()
+	sw, err = f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRowBuffer(1))
+	assert.Equal(t, ErrMaxRowHeight, sw.SetRow("A1", nil, RowOpts{Height: MaxRowHeight + 1}))
+	assert.Equal(t, ErrCellCharsLength, sw.SetRow("A2", []interface{}{[]RichTextRun{{Text: strings.Repeat("s", TotalCellChars+1)}}}))
+	assert.NoError(t, sw.SetRow("A1", nil))
+	assert.NoError(t, sw.Flush())
+	assert.NoError(t, f.Close())
+}
+
 func TestStreamSetPanes(t *testing.T) {
 	file, paneOpts := NewFile(), &Panes{
 		Freeze:      true,
@@ -230,6 +346,67 @@ func TestStreamTable(t *testing.T) {
 	assert.EqualError(t, streamWriter.AddTable(&Table{Range: "A1:C2"}), "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestStreamTableDeferred(t *testing.T) {
+	f := NewFile()
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	// Test add table and auto filter with the header row before writing rows
+	assert.NoError(t, sw.AddTable(&Table{Range: "A1:C1", Name: "Table1"}))
+	assert.NoError(t, sw.AutoFilter("E1:F1", []AutoFilterOptions{{Column: "F", Expression: "x > 50"}}))
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"A", "B", "C", nil, "E", "F"}))
+	for r := 2; r <= 100; r++ {
+		cell, _ := CoordinatesToCellName(1, r)
+		assert.NoError(t, sw.SetRow(cell, []interface{}{r, r, r, nil, r, r}))
+	}
+	assert.NoError(t, sw.Flush())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStreamTableDeferred.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestStreamTableDeferred.xlsx"))
+	assert.NoError(t, err)
+	tables, err := f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, tables, 1)
+	assert.Equal(t, "A1:C100", tables[0].Range)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "$E$1:$F$100", ws.AutoFilter.Ref)
+	assert.Len(t, ws.AutoFilter.FilterColumn, 1)
+	assert.NoError(t, f.Close())
+
+	// Test add table with the header row only without writing data rows
+	f = NewFile()
+	sw, err = f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"A", "B"}))
+	assert.NoError(t, sw.AddTable(&Table{Range: "B1:A1"}))
+	assert.NoError(t, sw.Flush())
+	tables, err = f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1:B2", tables[0].Range)
+	assert.NoError(t, f.Close())
+
+	f = NewFile()
+	sw, err = f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	// Test add table and auto filter with invalid range reference
+	assert.Equal(t, newCellNameToCoordinatesError("B", newInvalidCellNameError("B")), sw.AutoFilter("A1:B", nil))
+	assert.Equal(t, ErrColumnNumber, sw.AutoFilter("A1:XFE1", nil))
+	assert.Equal(t, ErrColumnNumber, sw.AddTable(&Table{Range: "A1:XFE1"}))
+	// Test flush with the deferred table without header row
+	assert.NoError(t, sw.AddTable(&Table{Range: "A1:B1"}))
+	assert.EqualError(t, sw.Flush(), "XML syntax error on line 2: unexpected EOF")
+	assert.NoError(t, f.Close())
+
+	// Test flush with the deferred auto filter with invalid column
+	f = NewFile()
+	sw, err = f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.AutoFilter("A1:B1", []AutoFilterOptions{{Column: "C", Expression: "x > 50"}}))
+	assert.Equal(t, newInvalidAutoFilterColumnError("C"), sw.Flush())
+	assert.NoError(t, f.Close())
+}
+
 func TestStreamMergeCells(t *testing.T) {
 	file := NewFile()
 	defer func() {
@@ -312,6 +489,22 @@ func TestStreamSetRowNilValues(t *testing.T) {
 	assert.NotEqual(t, ws.SheetData.Row[0].C[0].XMLName.Local, "c")
 }
 
+func TestStreamSetRowWithError(t *testing.T) {
+	file := NewFile()
+	defer func() {
+		assert.NoError(t, file.Close())
+	}()
+	streamWriter, err := file.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, streamWriter.SetRow("A1", []interface{}{CellErrorNA, CellErrorDiv0}))
+	// Test set row with invalid error value type
+	assert.Equal(t, ErrParameterInvalid, streamWriter.SetRow("A2", []interface{}{CellErrorNone}))
+	assert.NoError(t, streamWriter.Flush())
+	errType, err := file.GetCellError("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, CellErrorDiv0, errType)
+}
+
 func TestStreamSetRowWithStyle(t *testing.T) {
 	file := NewFile()
 	defer func() {
@@ -398,3 +591,85 @@ func TestStreamWriterOutlineLevel(t *testing.T) {
 	}
 	assert.NoError(t, file.Close())
 }
+
+func TestStreamWriterConcurrency(t *testing.T) {
+	f := NewFile()
+	sheets := []string{"Sheet1", "Sheet2", "Sheet3", "Sheet4", "Sheet5", "Sheet6"}
+	streamWriters := make([]*StreamWriter, len(sheets))
+	for i, sheet := range sheets {
+		if i > 0 {
+			_, err := f.NewSheet(sheet)
+			assert.NoError(t, err)
+		}
+		sw, err := f.NewStreamWriter(sheet)
+		assert.NoError(t, err)
+		streamWriters[i] = sw
+	}
+	var wg sync.WaitGroup
+	for i, sw := range streamWriters {
+		wg.Add(1)
+		go func(i int, sw *StreamWriter) {
+			defer wg.Done()
+			for rowID := 1; rowID <= 100; rowID++ {
+				cell, _ := CoordinatesToCellName(1, rowID)
+				assert.NoError(t, sw.SetRow(cell, []interface{}{i, rowID, "Data"}))
+			}
+			assert.NoError(t, sw.AddTable(&Table{Range: "A1:C100"}))
+			assert.NoError(t, sw.Flush())
+		}(i, sw)
+	}
+	wg.Wait()
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStreamWriterConcurrency.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err := OpenFile(filepath.Join("test", "TestStreamWriterConcurrency.xlsx"))
+	assert.NoError(t, err)
+	for i, sheet := range sheets {
+		rows, err := f.GetRows(sheet)
+		assert.NoError(t, err)
+		assert.Len(t, rows, 100)
+		assert.Equal(t, []string{strconv.Itoa(i), "100", "Data"}, rows[99])
+		tables, err := f.GetTables(sheet)
+		assert.NoError(t, err)
+		assert.Len(t, tables, 1)
+	}
+	assert.NoError(t, f.Close())
+
+	// Test write stream parts with closed temporary file, the parts exceed the
+	// number of workers
+	f = NewFile()
+	for i, sheet := range sheets {
+		if i > 0 {
+			_, err := f.NewSheet(sheet)
+			assert.NoError(t, err)
+		}
+		sw, err := f.NewStreamWriter(sheet)
+		assert.NoError(t, err)
+		assert.NoError(t, sw.Flush())
+		if i != 1 {
+			continue
+		}
+		sw.rawData.tmp, err = os.CreateTemp(os.TempDir(), "excelize-")
+		assert.NoError(t, err)
+		assert.NoError(t, sw.rawData.tmp.Close())
+	}
+	assert.Error(t, f.Write(io.Discard))
+	assert.NoError(t, f.Close())
+}
+
+func TestStreamWriterNoFormulaConversion(t *testing.T) {
+	f := NewFile(Options{NoFormulaConversion: true})
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"=1+1", []byte("@SUM(1)"), "Excelize"}))
+	assert.NoError(t, sw.Flush())
+	s, err := f.stylesReader()
+	assert.NoError(t, err)
+	for cell, quotePrefix := range map[string]bool{"A1": true, "B1": true, "C1": false} {
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		xf := s.CellXfs.Xf[styleID]
+		assert.Equal(t, quotePrefix, xf.QuotePrefix != nil && *xf.QuotePrefix, cell)
+	}
+	assert.NoError(t, f.Close())
+}
@@ -21,6 +21,9 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
+
+	"github.com/mohae/deepcopy"
 )
 
 // stylesReader provides a function to get the pointer to the structure after
@@ -957,23 +960,35 @@ func parseFormatStyleSet(style *Style) (*Style, error) {
 // or GetConditionalStyle function, the DecimalPlaces only doesn't nil if a
 // number format code has the same decimal places in the positive part negative
 // part, or only the positive part.
+//
+// When the StyleRegistry option is specified, the style definitions will be
+// resolved through the registry, and the identical style will be returned
+// directly without parsing and searching the existing styles again.
 func (f *File) NewStyle(style *Style) (int, error) {
-	var (
-		fs                                  *Style
-		font                                *xlsxFont
-		err                                 error
-		cellXfsID, fontID, borderID, fillID int
-	)
 	if style == nil {
-		return cellXfsID, err
+		return 0, nil
 	}
-	fs, err = parseFormatStyleSet(style)
+	if f.options != nil && f.options.StyleRegistry != nil {
+		return f.newRegisteredStyle(f.options.StyleRegistry, style)
+	}
+	fs, err := parseFormatStyleSet(style)
 	if err != nil {
-		return cellXfsID, err
+		return 0, err
 	}
 	if fs.DecimalPlaces != nil && (*fs.DecimalPlaces < 0 || *fs.DecimalPlaces > 30) {
 		fs.DecimalPlaces = intPtr(2)
 	}
+	return f.newStyle(fs)
+}
+
+// newStyle provides a function to create the style for cells by given parsed
+// style options, and returns style index.
+func (f *File) newStyle(fs *Style) (int, error) {
+	var (
+		font                                *xlsxFont
+		err                                 error
+		cellXfsID, fontID, borderID, fillID int
+	)
 	f.mu.Lock()
 	s, err := f.stylesReader()
 	if err != nil {
@@ -1026,6 +1041,178 @@ func (f *File) NewStyle(style *Style) (int, error) {
 	return setCellXfs(s, fontID, numFmtID, fillID, borderID, applyAlignment, applyProtection, alignment, protection)
 }
 
+// StyleRegistry directly maps the shared style definitions, which can be used
+// across multiple workbooks by the StyleRegistry option. The identical style
+// settings will be resolved to the same definition, so that the style
+// settings only need to be validated once in a generation service. A style
+// registry is concurrency safe. For example:
+//
+//	registry := excelize.NewStyleRegistry()
+//	for i := 0; i < 10; i++ {
+//	    f := excelize.NewFile(excelize.Options{StyleRegistry: registry})
+//	    style, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+//	    if err != nil {
+//	        fmt.Println(err)
+//	        return
+//	    }
+//	    // ...
+//	}
+type StyleRegistry struct {
+	mu     sync.RWMutex
+	styles map[string]*styleDefinition
+}
+
+// styleDefinition directly maps the parsed style settings stored in the style
+// registry.
+type styleDefinition struct {
+	style *Style
+}
+
+// NewStyleRegistry provides a function to create an empty style registry.
+func NewStyleRegistry() *StyleRegistry {
+	return &StyleRegistry{styles: make(map[string]*styleDefinition)}
+}
+
+// Len provides a function to get the number of the style definitions in the
+// style registry.
+func (r *StyleRegistry) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.styles)
+}
+
+// register provides a function to get the style definition by given style
+// settings, the style settings will be parsed and stored in the registry if
+// it doesn't exist.
+func (r *StyleRegistry) register(style *Style) (*styleDefinition, error) {
+	key := styleKey(style)
+	r.mu.RLock()
+	def, ok := r.styles[key]
+	r.mu.RUnlock()
+	if ok {
+		return def, nil
+	}
+	fs, err := parseFormatStyleSet(deepcopy.Copy(style).(*Style))
+	if err != nil {
+		return nil, err
+	}
+	if fs.DecimalPlaces != nil && (*fs.DecimalPlaces < 0 || *fs.DecimalPlaces > 30) {
+		fs.DecimalPlaces = intPtr(2)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if def, ok = r.styles[key]; !ok {
+		def = &styleDefinition{style: fs}
+		r.styles[key] = def
+	}
+	return def, nil
+}
+
+// newRegisteredStyle provides a function to create the style for cells by
+// given style registry and style settings, and returns style index.
+func (f *File) newRegisteredStyle(r *StyleRegistry, style *Style) (int, error) {
+	def, err := r.register(style)
+	if err != nil {
+		return 0, err
+	}
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	f.mu.Unlock()
+	if err != nil {
+		return 0, err
+	}
+	s.mu.Lock()
+	styleID, ok := s.styleIDs[def]
+	s.mu.Unlock()
+	if ok {
+		return styleID, nil
+	}
+	if styleID, err = f.newStyle(def.style); err != nil {
+		return styleID, err
+	}
+	s.mu.Lock()
+	if s.styleIDs == nil {
+		s.styleIDs = make(map[*styleDefinition]int)
+	}
+	s.styleIDs[def] = styleID
+	s.mu.Unlock()
+	return styleID, err
+}
+
+// styleKey provides a function to generate the unique key of the given style
+// settings without marshaling. Every field of the style settings must be
+// written into the key, which is checked by the TestStyleKey test.
+func styleKey(style *Style) string {
+	var b strings.Builder
+	writeInt := func(n int) { b.WriteString(strconv.Itoa(n)); b.WriteByte('|') }
+	writeStr := func(s string) { b.WriteString(strconv.Quote(s)); b.WriteByte('|') }
+	writeBool := func(v bool) { b.WriteString(strconv.FormatBool(v)); b.WriteByte('|') }
+	writeFloat := func(v float64) { b.WriteString(strconv.FormatFloat(v, 'g', -1, 64)); b.WriteByte('|') }
+	b.WriteString("B")
+	for _, border := range style.Border {
+		writeStr(border.Type)
+		writeStr(border.Color)
+		writeInt(border.Style)
+	}
+	b.WriteString("F")
+	writeStr(style.Fill.Type)
+	writeInt(style.Fill.Pattern)
+	for _, color := range style.Fill.Color {
+		writeStr(color)
+	}
+	writeInt(style.Fill.Shading)
+	writeInt(style.Fill.Transparency)
+	if font := style.Font; font != nil {
+		b.WriteString("T")
+		writeBool(font.Bold)
+		writeBool(font.Italic)
+		writeStr(font.Underline)
+		writeStr(font.Family)
+		writeFloat(font.Size)
+		writeBool(font.Strike)
+		writeStr(font.Color)
+		writeInt(font.ColorIndexed)
+		if font.ColorTheme != nil {
+			writeInt(*font.ColorTheme)
+		} else {
+			b.WriteByte('|')
+		}
+		writeFloat(font.ColorTint)
+		writeStr(font.VertAlign)
+	}
+	if a := style.Alignment; a != nil {
+		b.WriteString("A")
+		writeStr(a.Horizontal)
+		writeInt(a.Indent)
+		writeBool(a.JustifyLastLine)
+		writeInt(int(a.ReadingOrder))
+		writeInt(a.RelativeIndent)
+		writeBool(a.ShrinkToFit)
+		writeInt(a.TextRotation)
+		writeStr(a.Vertical)
+		writeBool(a.WrapText)
+	}
+	if p := style.Protection; p != nil {
+		b.WriteString("P")
+		writeBool(p.Hidden)
+		writeBool(p.Locked)
+	}
+	b.WriteString("N")
+	writeInt(style.NumFmt)
+	if style.DecimalPlaces != nil {
+		writeInt(*style.DecimalPlaces)
+	} else {
+		b.WriteByte('|')
+	}
+	if style.CustomNumFmt != nil {
+		writeStr(*style.CustomNumFmt)
+	} else {
+		b.WriteByte('|')
+	}
+	writeBool(style.NegRed)
+	return b.String()
+}
+
 var (
 	// styleBorders list all types of the cell border style.
 	styleBorders = []string{
@@ -2185,6 +2372,171 @@ func setCellXfs(style *xlsxStyleSheet, fontID, numFmtID, fillID, borderID int, a
 	return style.CellXfs.Count - 1, nil
 }
 
+// featurePropertyBagReader provides a function to get the pointer to the
+// structure after deserialization of
+// xl/featurePropertyBag/featurePropertyBag.xml.
+func (f *File) featurePropertyBagReader() (*xlsxFeaturePropertyBags, error) {
+	var bags xlsxFeaturePropertyBags
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(defaultXMLPathFeaturePropertyBag)))).
+		Decode(&bags); err != nil && err != io.EOF {
+		return &bags, err
+	}
+	return &bags, nil
+}
+
+// getBagID returns the referenced property bag index by given key, returns -1
+// if the key doesn't exist in the property bag.
+func (bag *xlsxFeaturePropertyBag) getBagID(key string) int {
+	for _, ID := range bag.BagID {
+		if ID.K == key {
+			return ID.Val
+		}
+	}
+	return -1
+}
+
+// addCheckboxFeaturePropertyBag provides a function to get the index of the
+// cell formatting complements which specifies the in-cell checkbox. The
+// property bags for the checkbox will be created if not exist.
+func (f *File) addCheckboxFeaturePropertyBag() (int, error) {
+	bags, err := f.featurePropertyBagReader()
+	if err != nil {
+		return -1, err
+	}
+	getBag := func(ID int, bagType string) *xlsxFeaturePropertyBag {
+		if ID >= 0 && ID < len(bags.Bag) && bags.Bag[ID].Type == bagType {
+			return &bags.Bag[ID]
+		}
+		return nil
+	}
+	complements := -1
+	for i, bag := range bags.Bag {
+		if bag.Type == "XFComplements" && bag.A != nil {
+			complements = i
+			for idx, ID := range bag.A.BagID {
+				if complement := getBag(ID.Val, "XFComplement"); complement != nil {
+					if controls := getBag(complement.getBagID("XFControls"), "XFControls"); controls != nil &&
+						getBag(controls.getBagID("CellControl"), "Checkbox") != nil {
+						return idx, err
+					}
+				}
+			}
+		}
+	}
+	if complements == -1 {
+		complements = len(bags.Bag)
+		bags.Bag = append(bags.Bag, xlsxFeaturePropertyBag{
+			Type: "XFComplements", ExtRef: "XFComplementsMapperExtRef",
+			A: &xlsxFeaturePropertyBagsArray{K: "MappedFeaturePropertyBags"},
+		})
+	}
+	checkbox := len(bags.Bag)
+	bags.Bag = append(bags.Bag,
+		xlsxFeaturePropertyBag{Type: "Checkbox"},
+		xlsxFeaturePropertyBag{Type: "XFControls", BagID: []xlsxFeaturePropertyBagID{{K: "CellControl", Val: checkbox}}},
+		xlsxFeaturePropertyBag{Type: "XFComplement", BagID: []xlsxFeaturePropertyBagID{{K: "XFControls", Val: checkbox + 1}}},
+	)
+	mapped := bags.Bag[complements].A
+	mapped.BagID = append(mapped.BagID, xlsxFeaturePropertyBagID{Val: checkbox + 2})
+	output, _ := xml.Marshal(bags)
+	if _, ok := f.Pkg.Load(defaultXMLPathFeaturePropertyBag); !ok {
+		f.addRels(f.getWorkbookRelsPath(), SourceRelationshipFeaturePropertyBag, strings.TrimPrefix(defaultXMLPathFeaturePropertyBag, "xl/"), "")
+		if err = f.addContentTypePart(0, "featurePropertyBag"); err != nil {
+			return -1, err
+		}
+	}
+	f.saveFileList(defaultXMLPathFeaturePropertyBag, output)
+	return len(mapped.BagID) - 1, err
+}
+
+// getCheckboxStyleID provides a function to get the cell formatting index
+// which displays the boolean cell value as the in-cell checkbox based on the
+// given cell formatting.
+func (f *File) getCheckboxStyleID(styleID int) (int, error) {
+	s, err := f.stylesReader()
+	if err != nil {
+		return styleID, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if styleID < 0 || s.CellXfs == nil || len(s.CellXfs.Xf) <= styleID {
+		return styleID, newInvalidStyleID(styleID)
+	}
+	if xf := s.CellXfs.Xf[styleID]; xf.ExtLst != nil && strings.Contains(xf.ExtLst.Ext, ExtURIFeaturePropertyBag) {
+		return styleID, err
+	}
+	idx, err := f.addCheckboxFeaturePropertyBag()
+	if err != nil {
+		return styleID, err
+	}
+	xf := deepcopy.Copy(s.CellXfs.Xf[styleID]).(xlsxXf)
+	xf.ExtLst = &xlsxExtLst{Ext: fmt.Sprintf(`<ext uri="%s" xmlns:%s="%s"><xfpb:xfComplement i="%d"/></ext>`,
+		ExtURIFeaturePropertyBag, NameSpaceFeaturePropertyBag.Name.Local, NameSpaceFeaturePropertyBag.Value, idx)}
+	for i, x := range s.CellXfs.Xf {
+		if reflect.DeepEqual(x, xf) {
+			return i, err
+		}
+	}
+	if len(s.CellXfs.Xf) == MaxCellStyles {
+		return styleID, ErrCellStyles
+	}
+	s.CellXfs.Xf = append(s.CellXfs.Xf, xf)
+	s.CellXfs.Count = len(s.CellXfs.Xf)
+	return s.CellXfs.Count - 1, err
+}
+
+// prepareQuotePrefixStyle provides a function to get the cell formatting
+// index with the quote prefix for the text value which starts with the
+// formula characters by given cell formatting index and text value when the
+// NoFormulaConversion option is enabled, otherwise the given index will be
+// returned.
+func (f *File) prepareQuotePrefixStyle(styleID int, value string) (int, error) {
+	if f.options == nil || !f.options.NoFormulaConversion || value == "" || !strings.ContainsRune("=+-@", rune(value[0])) {
+		return styleID, nil
+	}
+	return f.getQuotePrefixStyleID(styleID)
+}
+
+// getQuotePrefixStyleID provides a function to get the cell formatting index
+// which keeps the text value as text with the quote prefix based on the given
+// cell formatting. The mapping from the given cell formatting index to the
+// quote prefix one will be cached in the style sheet.
+func (f *File) getQuotePrefixStyleID(styleID int) (int, error) {
+	s, err := f.stylesReader()
+	if err != nil {
+		return styleID, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if styleID < 0 || s.CellXfs == nil || len(s.CellXfs.Xf) <= styleID {
+		return styleID, newInvalidStyleID(styleID)
+	}
+	if xf := s.CellXfs.Xf[styleID]; xf.QuotePrefix != nil && *xf.QuotePrefix {
+		return styleID, err
+	}
+	if quoteID, ok := s.quoteIDs[styleID]; ok {
+		return quoteID, err
+	}
+	if s.quoteIDs == nil {
+		s.quoteIDs = make(map[int]int)
+	}
+	xf := deepcopy.Copy(s.CellXfs.Xf[styleID]).(xlsxXf)
+	xf.QuotePrefix = boolPtr(true)
+	for i, x := range s.CellXfs.Xf {
+		if reflect.DeepEqual(x, xf) {
+			s.quoteIDs[styleID] = i
+			return i, err
+		}
+	}
+	if len(s.CellXfs.Xf) == MaxCellStyles {
+		return styleID, ErrCellStyles
+	}
+	s.CellXfs.Xf = append(s.CellXfs.Xf, xf)
+	s.CellXfs.Count = len(s.CellXfs.Xf)
+	s.quoteIDs[styleID] = s.CellXfs.Count - 1
+	return s.CellXfs.Count - 1, err
+}
+
 // GetCellStyle provides a function to get cell style index by given worksheet
 // name and cell reference.
 func (f *File) GetCellStyle(sheet, cell string) (int, error) {
@@ -2202,6 +2554,140 @@ func (f *File) GetCellStyle(sheet, cell string) (int, error) {
 	return ws.prepareCellStyle(col, row, ws.SheetData.Row[row-1].C[col-1].S), err
 }
 
+// StyleSource is the type of the source of the cell style attributes.
+type StyleSource byte
+
+// Cell style sources enumeration.
+const (
+	StyleSourceDefault StyleSource = iota
+	StyleSourceCell
+	StyleSourceRow
+	StyleSourceColumn
+	StyleSourceNamedStyle
+)
+
+// GetCellStyleDetails provides a function to get the resolved style settings
+// of the cell by given worksheet name and cell reference. The result includes
+// the style index which takes effect on the cell, whether the style index
+// comes from the cell, row or column formatting, the name of the named cell
+// style, the number format code, and the source of each style attribute. The
+// attributes which are not applied by the cell formatting will be resolved
+// from the named cell style. For example, get the style details of cell A1 on
+// Sheet1:
+//
+//	details, err := f.GetCellStyleDetails("Sheet1", "A1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	fmt.Println(details.NamedStyle, details.NumFmtCode, details.Sources.Font)
+func (f *File) GetCellStyleDetails(sheet, cell string) (*CellStyleDetails, error) {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return nil, err
+	}
+	s, err := f.stylesReader()
+	f.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	ws.mu.Lock()
+	styleID, source := ws.getCellStyleSource(col, row)
+	ws.mu.Unlock()
+	if s.CellXfs == nil || len(s.CellXfs.Xf) <= styleID {
+		return nil, newInvalidStyleID(styleID)
+	}
+	xf := s.CellXfs.Xf[styleID]
+	details := &CellStyleDetails{StyleID: styleID, Source: source, Style: &Style{}}
+	var namedXf *xlsxXf
+	if xf.XfID != nil && s.CellStyleXfs != nil && *xf.XfID < len(s.CellStyleXfs.Xf) {
+		namedXf = &xlsxXf{}
+		*namedXf = s.CellStyleXfs.Xf[*xf.XfID]
+		namedXf.ApplyNumberFormat, namedXf.ApplyFont, namedXf.ApplyFill = nil, nil, nil
+		namedXf.ApplyBorder, namedXf.ApplyAlignment, namedXf.ApplyProtection = nil, nil, nil
+		if s.CellStyles != nil {
+			for _, cellStyle := range s.CellStyles.CellStyle {
+				if cellStyle.XfID == *xf.XfID {
+					details.NamedStyle = cellStyle.Name
+					break
+				}
+			}
+		}
+	}
+	resolve := func(apply *bool, src *StyleSource) xlsxXf {
+		if *src = source; apply != nil && !*apply && namedXf != nil {
+			*src = StyleSourceNamedStyle
+			return *namedXf
+		}
+		return xf
+	}
+	if x := resolve(xf.ApplyFill, &details.Sources.Fill); extractStyleCondFuncs["fill"](x, s) {
+		f.extractFills(s.Fills.Fill[*x.FillID], s, details.Style)
+	}
+	if x := resolve(xf.ApplyBorder, &details.Sources.Border); extractStyleCondFuncs["border"](x, s) {
+		f.extractBorders(s.Borders.Border[*x.BorderID], s, details.Style)
+	}
+	if x := resolve(xf.ApplyFont, &details.Sources.Font); extractStyleCondFuncs["font"](x, s) {
+		f.extractFont(s.Fonts.Font[*x.FontID], s, details.Style)
+	}
+	if x := resolve(xf.ApplyAlignment, &details.Sources.Alignment); extractStyleCondFuncs["alignment"](x, s) {
+		f.extractAlignment(x.Alignment, s, details.Style)
+	}
+	if x := resolve(xf.ApplyProtection, &details.Sources.Protection); extractStyleCondFuncs["protection"](x, s) {
+		f.extractProtection(x.Protection, s, details.Style)
+	}
+	if x := resolve(xf.ApplyNumberFormat, &details.Sources.NumFmt); x.NumFmtID != nil {
+		f.extractNumFmt(x.NumFmtID, s, details.Style)
+		if details.NumFmtCode, _ = f.getBuiltInNumFmtCode(*x.NumFmtID); details.NumFmtCode == "" {
+			details.NumFmtCode, _ = s.getCustomNumFmtCode(*x.NumFmtID)
+		}
+	}
+	return details, err
+}
+
+// getCellStyleSource provides a function to get the style index which takes
+// effect on the cell and the source of it by given column and row number, the
+// style of the cell takes precedence over the row style and column style.
+func (ws *xlsxWorksheet) getCellStyleSource(col, row int) (int, StyleSource) {
+	rows := ws.SheetData.Row
+	if row <= len(rows) && rows[row-1].R == row {
+		rows = rows[row-1 : row]
+	}
+	for _, r := range rows {
+		if r.R != row {
+			continue
+		}
+		cells := r.C
+		if col <= len(cells) && cells[col-1].R != "" {
+			if cellCol, _, err := CellNameToCoordinates(cells[col-1].R); err == nil && cellCol == col {
+				cells = cells[col-1 : col]
+			}
+		}
+		for _, c := range cells {
+			if cellCol, _, err := CellNameToCoordinates(c.R); err == nil && cellCol == col && c.S != 0 {
+				return c.S, StyleSourceCell
+			}
+		}
+		if r.S != 0 {
+			return r.S, StyleSourceRow
+		}
+	}
+	if ws.Cols != nil {
+		for _, c := range ws.Cols.Col {
+			if c.Min <= col && col <= c.Max && c.Style != 0 {
+				return c.Style, StyleSourceColumn
+			}
+		}
+	}
+	return 0, StyleSourceDefault
+}
+
 // SetCellStyle provides a function to add style attribute for cells by given
 // worksheet name, range reference and style ID. This function is concurrency
 // safe. Note that diagonalDown and diagonalUp type border should be use same
@@ -2361,6 +2847,106 @@ func (f *File) SetCellStyle(sheet, topLeftCell, bottomRightCell string, styleID
 	return err
 }
 
+// SetRangeBorders provides a function to set the outline, inside horizontal
+// and inside vertical borders of the range by given worksheet name, range
+// reference and border settings, which is similar to the borders of the
+// spreadsheet application. The outline border will be applied to the edges of
+// the range, and the inside borders will be applied to the edges between the
+// cells inside the range. The borders which are nil will not be changed, and
+// the border with zero style will remove the border of the cells. The other
+// style settings of the cells will be kept, and the cells with the identical
+// style and border settings will share the same style. For example, set the
+// thin outline and dashed inside horizontal borders for the range A1:C5 on
+// Sheet1:
+//
+//	err := f.SetRangeBorders("Sheet1", "A1:C5", &excelize.RangeBorderOptions{
+//	    Outline:          &excelize.Border{Color: "000000", Style: 1},
+//	    InsideHorizontal: &excelize.Border{Color: "000000", Style: 3},
+//	})
+func (f *File) SetRangeBorders(sheet, rangeRef string, opts *RangeBorderOptions) error {
+	if opts == nil {
+		return ErrParameterInvalid
+	}
+	coordinates, err := rangeRefToCoordinates(rangeRef)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	type rangeBorderKey struct {
+		styleID int
+		borders [4]*Border
+	}
+	styles := map[rangeBorderKey]int{}
+	for row := coordinates[1]; row <= coordinates[3]; row++ {
+		for col := coordinates[0]; col <= coordinates[2]; col++ {
+			key := rangeBorderKey{borders: [4]*Border{opts.InsideVertical, opts.InsideVertical, opts.InsideHorizontal, opts.InsideHorizontal}}
+			if col == coordinates[0] {
+				key.borders[0] = opts.Outline
+			}
+			if col == coordinates[2] {
+				key.borders[1] = opts.Outline
+			}
+			if row == coordinates[1] {
+				key.borders[2] = opts.Outline
+			}
+			if row == coordinates[3] {
+				key.borders[3] = opts.Outline
+			}
+			if key.borders == [4]*Border{} {
+				continue
+			}
+			cell, _ := CoordinatesToCellName(col, row)
+			if key.styleID, err = f.GetCellStyle(sheet, cell); err != nil {
+				return err
+			}
+			styleID, ok := styles[key]
+			if !ok {
+				if styleID, err = f.setBorders(key.styleID, key.borders); err != nil {
+					return err
+				}
+				styles[key] = styleID
+			}
+			if err = f.SetCellStyle(sheet, cell, cell, styleID); err != nil {
+				return err
+			}
+		}
+	}
+	return err
+}
+
+// setBorders provides a function to create the style which is based on the
+// given style index, and replaced the left, right, top and bottom borders by
+// given borders, the borders which are nil will not be changed.
+func (f *File) setBorders(styleID int, borders [4]*Border) (int, error) {
+	style, err := f.GetStyle(styleID)
+	if err != nil {
+		return styleID, err
+	}
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	f.mu.Unlock()
+	if err != nil {
+		return styleID, err
+	}
+	// Keep the default font for the style without applied font
+	if xf := s.CellXfs.Xf[styleID]; xf.ApplyFont == nil && (xf.FontID == nil || *xf.FontID == 0) {
+		style.Font = nil
+	}
+	var border []Border
+	for _, b := range style.Border {
+		if idx := inStrSlice(styleBorderTypes[:4], b.Type, true); idx == -1 || borders[idx] == nil {
+			border = append(border, b)
+		}
+	}
+	for idx, b := range borders {
+		if b != nil && b.Style != 0 {
+			border = append(border, Border{Type: styleBorderTypes[idx], Color: b.Color, Style: b.Style})
+		}
+	}
+	style.Border = border
+	return f.NewStyle(style)
+}
+
 // SetConditionalFormat provides a function to create conditional formatting
 // rule for cell value. Conditional formatting is a feature of Excel which
 // allows you to apply a format to a cell or a range of cells based on certain
@@ -2773,7 +3359,14 @@ func (f *File) SetCellStyle(sheet, topLeftCell, bottomRightCell string, styleID
 // formatting rule when more than one rule is applied to a cell or a range of
 // cells. When this parameter is set then subsequent rules are not evaluated
 // if the current rule is true.
-func (f *File) SetConditionalFormat(sheet, rangeRef string, opts []ConditionalFormatOptions) error {
+//
+// Set the ErrorOnOverlap field of the optional overlap options to true for
+// returning the ErrRangeOverlap error if the range overlaps with the ranges of
+// the existing conditional formats, for example:
+//
+//	err := f.SetConditionalFormat("Sheet1", "A1:A10", format,
+//	    excelize.RangeOverlapOptions{ErrorOnOverlap: true})
+func (f *File) SetConditionalFormat(sheet, rangeRef string, opts []ConditionalFormatOptions, overlapOpts ...RangeOverlapOptions) error {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
@@ -2782,6 +3375,15 @@ func (f *File) SetConditionalFormat(sheet, rangeRef string, opts []ConditionalFo
 	if err != nil {
 		return err
 	}
+	if getRangeOverlapOptions(overlapOpts).ErrorOnOverlap {
+		var sqrefs []string
+		for _, cf := range ws.ConditionalFormatting {
+			sqrefs = append(sqrefs, cf.SQRef)
+		}
+		if err = checkSqrefOverlap(sqrefs, SQRef); err != nil {
+			return err
+		}
+	}
 	// Create a pseudo GUID for each unique rule.
 	var rules int
 	for _, cf := range ws.ConditionalFormatting {
@@ -3504,3 +4106,33 @@ func ThemeColor(baseColor string, tint float64) string {
 	br, bg, bb := HSLToRGB(h, s, l)
 	return fmt.Sprintf("FF%02X%02X%02X", br, bg, bb)
 }
+
+// MirrorAlignment provides a function to get the mirrored alignment of the
+// given alignment settings for switching the cells between the left to right
+// and right to left worksheets. The left and right horizontal alignment will
+// be exchanged, and the left-to-right and right-to-left reading order will be
+// exchanged, the other settings keep unchanged. For example, create a style
+// with the mirrored alignment for a right to left worksheet:
+//
+//	style, err := f.NewStyle(&excelize.Style{
+//	    Alignment: excelize.MirrorAlignment(&excelize.Alignment{Horizontal: "left"}),
+//	})
+func MirrorAlignment(alignment *Alignment) *Alignment {
+	if alignment == nil {
+		return nil
+	}
+	mirrored := *alignment
+	switch alignment.Horizontal {
+	case "left":
+		mirrored.Horizontal = "right"
+	case "right":
+		mirrored.Horizontal = "left"
+	}
+	switch alignment.ReadingOrder {
+	case 1:
+		mirrored.ReadingOrder = 2
+	case 2:
+		mirrored.ReadingOrder = 1
+	}
+	return &mirrored
+}
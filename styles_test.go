@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -239,6 +240,23 @@ func TestSetConditionalFormat(t *testing.T) {
 		assert.Equal(t, expected, priorities)
 		assert.NoError(t, f.Close())
 	})
+
+	t.Run("range_overlap", func(t *testing.T) {
+		f := NewFile()
+		format := []ConditionalFormatOptions{{Type: "formula", Criteria: "TRUE"}}
+		assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:B5", format))
+		// Test set conditional format on the overlapped range without checking
+		assert.NoError(t, f.SetConditionalFormat("Sheet1", "B5:C6", format))
+		// Test set conditional format with checking the overlapped range
+		overlapOpts := RangeOverlapOptions{ErrorOnOverlap: true}
+		assert.Equal(t, ErrRangeOverlap{Ref: "A5:A5", OverlapRef: "A1:B5"},
+			f.SetConditionalFormat("Sheet1", "D1 A5", format, overlapOpts))
+		assert.NoError(t, f.SetConditionalFormat("Sheet1", "D1:D6,E1", format, overlapOpts))
+		formats, err := f.GetConditionalFormats("Sheet1")
+		assert.NoError(t, err)
+		assert.Len(t, formats, 3)
+		assert.NoError(t, f.Close())
+	})
 }
 
 func TestGetConditionalFormats(t *testing.T) {
@@ -759,3 +777,325 @@ func TestGetStyle(t *testing.T) {
 	assert.Nil(t, style)
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
+
+func TestGetCellStyleDetails(t *testing.T) {
+	f := NewFile()
+	cellStyle, err := f.NewStyle(&Style{Font: &Font{Bold: true}, NumFmt: 14})
+	assert.NoError(t, err)
+	rowStyle, err := f.NewStyle(&Style{CustomNumFmt: stringPtr("0.000")})
+	assert.NoError(t, err)
+	colStyle, err := f.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"FFFF00"}, Pattern: 1}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", cellStyle))
+	assert.NoError(t, f.SetRowStyle("Sheet1", 3, 3, rowStyle))
+	assert.NoError(t, f.SetColStyle("Sheet1", "D", colStyle))
+	for _, c := range []struct {
+		cell       string
+		styleID    int
+		source     StyleSource
+		numFmtCode string
+	}{
+		{"A1", cellStyle, StyleSourceCell, "mm-dd-yy"},
+		{"B3", rowStyle, StyleSourceRow, "0.000"},
+		{"D3", colStyle, StyleSourceCell, "general"},
+		{"D5", colStyle, StyleSourceColumn, "general"},
+		{"F6", 0, StyleSourceDefault, "general"},
+	} {
+		details, err := f.GetCellStyleDetails("Sheet1", c.cell)
+		assert.NoError(t, err)
+		assert.Equal(t, c.styleID, details.StyleID, c.cell)
+		assert.Equal(t, c.source, details.Source, c.cell)
+		assert.Equal(t, c.numFmtCode, details.NumFmtCode, c.cell)
+		assert.Equal(t, "Normal", details.NamedStyle, c.cell)
+		// The alignment is not applied by the cell formatting without alignment
+		alignment := StyleSourceNamedStyle
+		if c.styleID == 0 {
+			alignment = c.source
+		}
+		assert.Equal(t, StyleSources{
+			NumFmt: c.source, Font: c.source, Fill: c.source,
+			Border: c.source, Alignment: alignment, Protection: c.source,
+		}, details.Sources, c.cell)
+		style, err := f.GetStyle(c.styleID)
+		assert.NoError(t, err)
+		assert.Equal(t, style, details.Style, c.cell)
+	}
+
+	// Test get cell style details with the attributes of the named cell style
+	s, err := f.stylesReader()
+	assert.NoError(t, err)
+	s.CellStyleXfs.Xf = append(s.CellStyleXfs.Xf, xlsxXf{NumFmtID: intPtr(10), FontID: s.CellXfs.Xf[cellStyle].FontID})
+	s.CellStyles.CellStyle = append(s.CellStyles.CellStyle, &xlsxCellStyle{Name: "Heading", XfID: 1})
+	s.CellXfs.Xf = append(s.CellXfs.Xf, xlsxXf{
+		NumFmtID: intPtr(0), FontID: intPtr(0), FillID: s.CellXfs.Xf[colStyle].FillID, BorderID: intPtr(0), XfID: intPtr(1),
+		ApplyNumberFormat: boolPtr(false), ApplyFont: boolPtr(false), ApplyFill: boolPtr(true),
+	})
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A2", "A2", len(s.CellXfs.Xf)-1))
+	details, err := f.GetCellStyleDetails("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, len(s.CellXfs.Xf)-1, details.StyleID)
+	assert.Equal(t, "Heading", details.NamedStyle)
+	assert.Equal(t, "0.00%", details.NumFmtCode)
+	assert.True(t, details.Style.Font.Bold)
+	assert.Equal(t, []string{"FFFF00"}, details.Style.Fill.Color)
+	assert.Equal(t, StyleSources{
+		NumFmt: StyleSourceNamedStyle, Font: StyleSourceNamedStyle, Fill: StyleSourceCell,
+		Border: StyleSourceCell, Alignment: StyleSourceCell, Protection: StyleSourceCell,
+	}, details.Sources)
+
+	// Test get cell style details with invalid cell reference
+	_, err = f.GetCellStyleDetails("Sheet1", "A")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	// Test get cell style details with invalid sheet name
+	_, err = f.GetCellStyleDetails("Sheet:1", "A1")
+	assert.Equal(t, ErrSheetNameInvalid, err)
+	// Test get cell style details on not exists worksheet
+	_, err = f.GetCellStyleDetails("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get cell style details with invalid style ID
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[0].S = 100
+	_, err = f.GetCellStyleDetails("Sheet1", "A1")
+	assert.Equal(t, newInvalidStyleID(100), err)
+	// Test get cell style details with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	_, err = f.GetCellStyleDetails("Sheet1", "A1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestSetRangeBorders(t *testing.T) {
+	f := NewFile()
+	fillStyle, err := f.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"FFFF00"}, Pattern: 1}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B2", "B2", fillStyle))
+	assert.NoError(t, f.SetRangeBorders("Sheet1", "D4:B2", &RangeBorderOptions{
+		Outline:          &Border{Color: "000000", Style: 2},
+		InsideHorizontal: &Border{Color: "FF0000", Style: 1},
+		InsideVertical:   &Border{Color: "0000FF", Style: 3},
+	}))
+	getBorders := func(cell string) (*Style, map[string]int) {
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		style, err := f.GetStyle(styleID)
+		assert.NoError(t, err)
+		borders := map[string]int{}
+		for _, b := range style.Border {
+			borders[b.Type] = b.Style
+		}
+		return style, borders
+	}
+	style, borders := getBorders("B2")
+	assert.Equal(t, map[string]int{"left": 2, "right": 3, "top": 2, "bottom": 1}, borders)
+	assert.Equal(t, []string{"FFFF00"}, style.Fill.Color)
+	_, borders = getBorders("C3")
+	assert.Equal(t, map[string]int{"left": 3, "right": 3, "top": 1, "bottom": 1}, borders)
+	_, borders = getBorders("D4")
+	assert.Equal(t, map[string]int{"left": 3, "right": 2, "top": 1, "bottom": 2}, borders)
+	_, borders = getBorders("A1")
+	assert.Empty(t, borders)
+	// Test the cells with identical settings share the same style
+	assert.NoError(t, f.SetRangeBorders("Sheet1", "H1:K4", &RangeBorderOptions{
+		Outline:          &Border{Color: "000000", Style: 2},
+		InsideHorizontal: &Border{Color: "FF0000", Style: 1},
+		InsideVertical:   &Border{Color: "0000FF", Style: 3},
+	}))
+	styleID1, err := f.GetCellStyle("Sheet1", "C3")
+	assert.NoError(t, err)
+	for _, cell := range []string{"I2", "J2", "I3", "J3"} {
+		styleID2, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, styleID1, styleID2)
+	}
+	// Test set inside borders for a single cell range
+	assert.NoError(t, f.SetRangeBorders("Sheet1", "E3:E3", &RangeBorderOptions{
+		InsideHorizontal: &Border{Color: "FF0000", Style: 1},
+		InsideVertical:   &Border{Color: "0000FF", Style: 3},
+	}))
+	styleID2, err := f.GetCellStyle("Sheet1", "E3")
+	assert.NoError(t, err)
+	assert.Zero(t, styleID2)
+	// Test remove the outline borders of the range
+	assert.NoError(t, f.SetRangeBorders("Sheet1", "B2:D4", &RangeBorderOptions{Outline: &Border{}}))
+	style, borders = getBorders("B2")
+	assert.Equal(t, map[string]int{"right": 3, "bottom": 1}, borders)
+	assert.Equal(t, []string{"FFFF00"}, style.Fill.Color)
+	_, borders = getBorders("C3")
+	assert.Equal(t, map[string]int{"left": 3, "right": 3, "top": 1, "bottom": 1}, borders)
+	// Test set range borders with invalid options
+	assert.Equal(t, ErrParameterInvalid, f.SetRangeBorders("Sheet1", "A1:B2", nil))
+	// Test set range borders with invalid range reference
+	assert.Equal(t, ErrParameterInvalid, f.SetRangeBorders("Sheet1", "A1", &RangeBorderOptions{}))
+	// Test set range borders with invalid sheet name
+	assert.Equal(t, ErrSheetNameInvalid, f.SetRangeBorders("Sheet:1", "A1:B2", &RangeBorderOptions{Outline: &Border{Style: 1}}))
+	// Test set range borders on not exists worksheet
+	assert.EqualError(t, f.SetRangeBorders("SheetN", "A1:B2", &RangeBorderOptions{Outline: &Border{Style: 1}}), "sheet SheetN does not exist")
+	// Test set range borders with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetRangeBorders("Sheet1", "A1:B2", &RangeBorderOptions{Outline: &Border{Style: 1}}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestStyleRegistry(t *testing.T) {
+	registry := NewStyleRegistry()
+	theme, decimal, numFmt := 1, 40, "0.00"
+	styles := []*Style{
+		{Font: &Font{Bold: true, Color: "777777", ColorTheme: &theme}},
+		{Border: []Border{{Type: "left", Color: "0000FF", Style: 3}}, Fill: Fill{Type: "pattern", Color: []string{"E0EBF5"}, Pattern: 1}},
+		{Alignment: &Alignment{Horizontal: "center", WrapText: true}, Protection: &Protection{Locked: true}},
+		{NumFmt: 164, DecimalPlaces: &decimal, CustomNumFmt: &numFmt, NegRed: true},
+	}
+	var expected []int
+	for i := 0; i < 3; i++ {
+		f := NewFile(Options{StyleRegistry: registry})
+		for j, style := range styles {
+			styleID, err := f.NewStyle(style)
+			assert.NoError(t, err)
+			if i == 0 {
+				expected = append(expected, styleID)
+				continue
+			}
+			assert.Equal(t, expected[j], styleID)
+		}
+		// Test create the identical style with a new style settings
+		styleID, err := f.NewStyle(&Style{Font: &Font{Bold: true, Color: "777777", ColorTheme: &theme}})
+		assert.NoError(t, err)
+		assert.Equal(t, expected[0], styleID)
+		style, err := f.GetStyle(styleID)
+		assert.NoError(t, err)
+		assert.True(t, style.Font.Bold)
+		assert.NoError(t, f.Close())
+	}
+	assert.Equal(t, len(styles), registry.Len())
+	// Test the registry is not affected by changing the given style settings
+	styles[0].Font.Bold = false
+	f := NewFile(Options{StyleRegistry: registry})
+	styleID, err := f.NewStyle(styles[0])
+	assert.NoError(t, err)
+	style, err := f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.False(t, style.Font.Bold)
+	assert.Equal(t, len(styles)+1, registry.Len())
+	// Test create style with nil style settings
+	styleID, err = f.NewStyle(nil)
+	assert.NoError(t, err)
+	assert.Zero(t, styleID)
+	// Test create style with invalid style settings
+	_, err = f.NewStyle(&Style{Font: &Font{Size: MaxFontSize + 1}})
+	assert.Equal(t, ErrFontSize, err)
+	assert.Equal(t, len(styles)+1, registry.Len())
+	// Test create style with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	_, err = f.NewStyle(&Style{NumFmt: 1})
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestStyleKey(t *testing.T) {
+	newStyle := func() *Style {
+		return &Style{
+			Border:        []Border{{}},
+			Fill:          Fill{Color: []string{""}},
+			Font:          &Font{ColorTheme: intPtr(0)},
+			Alignment:     &Alignment{},
+			Protection:    &Protection{},
+			DecimalPlaces: intPtr(0),
+			CustomNumFmt:  stringPtr(""),
+		}
+	}
+	// walk calls the given function with the path and value of each field
+	// of the style settings which is not a struct, pointer or slice
+	var walk func(path string, v reflect.Value, fn func(path string, v reflect.Value))
+	walk = func(path string, v reflect.Value, fn func(path string, v reflect.Value)) {
+		switch v.Kind() {
+		case reflect.Ptr:
+			walk(path, v.Elem(), fn)
+		case reflect.Slice:
+			walk(path+"[0]", v.Index(0), fn)
+		case reflect.Struct:
+			for i := 0; i < v.NumField(); i++ {
+				walk(path+"."+v.Type().Field(i).Name, v.Field(i), fn)
+			}
+		default:
+			fn(path, v)
+		}
+	}
+	var paths []string
+	walk("Style", reflect.ValueOf(newStyle()), func(path string, v reflect.Value) {
+		paths = append(paths, path)
+	})
+	// Test the key of the style settings covers every field of the style
+	// settings, the key must be changed by changing any field
+	base, keys := styleKey(newStyle()), map[string]string{}
+	for _, path := range paths {
+		style := newStyle()
+		walk("Style", reflect.ValueOf(style), func(p string, v reflect.Value) {
+			if p != path {
+				return
+			}
+			switch v.Kind() {
+			case reflect.Bool:
+				v.SetBool(true)
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				v.SetInt(1)
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+				v.SetUint(1)
+			case reflect.Float32, reflect.Float64:
+				v.SetFloat(1.5)
+			case reflect.String:
+				v.SetString("1")
+			default:
+				t.Errorf("unsupported field type %s of %s in the style key", v.Kind(), path)
+			}
+		})
+		key := styleKey(style)
+		assert.NotEqual(t, base, key, path)
+		assert.NotContains(t, keys, key, path)
+		keys[key] = path
+	}
+	// Test the key of the style settings with nil and empty values
+	assert.NotEqual(t, styleKey(&Style{}), styleKey(&Style{Font: &Font{}}))
+	assert.NotEqual(t, styleKey(&Style{}), styleKey(&Style{DecimalPlaces: intPtr(0)}))
+	assert.NotEqual(t, styleKey(&Style{}), styleKey(&Style{CustomNumFmt: stringPtr("")}))
+	assert.NotEqual(t, styleKey(&Style{Border: []Border{{Type: "left"}, {}}}), styleKey(&Style{Border: []Border{{}, {Type: "left"}}}))
+}
+
+func BenchmarkNewStyle(b *testing.B) {
+	style := &Style{
+		Border:    []Border{{Type: "left", Color: "0000FF", Style: 3}, {Type: "top", Color: "00FF00", Style: 4}},
+		Fill:      Fill{Type: "pattern", Color: []string{"E0EBF5"}, Pattern: 1},
+		Font:      &Font{Bold: true, Italic: true, Family: "Times New Roman", Size: 12, Color: "777777"},
+		Alignment: &Alignment{Horizontal: "center", WrapText: true},
+	}
+	b.Run("Default", func(b *testing.B) {
+		f := NewFile()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := f.NewStyle(style); err != nil {
+				b.Error(err)
+			}
+		}
+	})
+	b.Run("StyleRegistry", func(b *testing.B) {
+		f := NewFile(Options{StyleRegistry: NewStyleRegistry()})
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := f.NewStyle(style); err != nil {
+				b.Error(err)
+			}
+		}
+	})
+}
+
+func TestMirrorAlignment(t *testing.T) {
+	assert.Nil(t, MirrorAlignment(nil))
+	alignment := &Alignment{Horizontal: "left", ReadingOrder: 1, WrapText: true}
+	assert.Equal(t, &Alignment{Horizontal: "right", ReadingOrder: 2, WrapText: true}, MirrorAlignment(alignment))
+	assert.Equal(t, &Alignment{Horizontal: "left", ReadingOrder: 1, WrapText: true}, alignment)
+	assert.Equal(t, &Alignment{Horizontal: "left", ReadingOrder: 1}, MirrorAlignment(&Alignment{Horizontal: "right", ReadingOrder: 2}))
+	assert.Equal(t, &Alignment{Horizontal: "center"}, MirrorAlignment(&Alignment{Horizontal: "center"}))
+}
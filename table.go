@@ -201,6 +201,180 @@ func (f *File) DeleteTable(name string) error {
 	return newNoExistTableError(name)
 }
 
+// ExpandTableOrPivotSource provides a function to expand the range of the
+// table or the source range of the pivot table by given table or pivot table
+// name, the range will be grown to include the contiguous non-empty rows
+// appended below it. The pivot cache will be refreshed when the workbook is
+// opened by the spreadsheet application. Note that the table with the totals
+// row is not supported. For example, append the data below the source range
+// of the pivot table named "PivotTable1" and expand the source range:
+//
+//	if err := f.SetSheetRow("Sheet1", "A32", &[]interface{}{"Jul", 2020, "North", 100}); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err := f.ExpandTableOrPivotSource("PivotTable1"); err != nil {
+//	    fmt.Println(err)
+//	}
+func (f *File) ExpandTableOrPivotSource(name string) error {
+	for _, sheet := range f.GetSheetList() {
+		tables, err := f.GetTables(sheet)
+		if err != nil {
+			if _, ok := err.(ErrNotWorksheet); ok {
+				continue
+			}
+			return err
+		}
+		for _, table := range tables {
+			if table.Name == name {
+				return f.expandTable(sheet, table)
+			}
+		}
+	}
+	for _, sheet := range f.GetSheetList() {
+		pivotTables, err := f.GetPivotTables(sheet)
+		if err != nil {
+			return err
+		}
+		for _, pivotTable := range pivotTables {
+			if pivotTable.Name == name {
+				return f.expandPivotSource(pivotTable)
+			}
+		}
+	}
+	return newNoExistTableError(name)
+}
+
+// expandTable provides a function to expand the range of the table by given
+// worksheet name and table, the pivot caches based on the table will be
+// refreshed on load if the range has been changed.
+func (f *File) expandTable(sheet string, table Table) error {
+	content, ok := f.Pkg.Load(table.tableXML)
+	if !ok {
+		return newNoExistTableError(table.Name)
+	}
+	var t xlsxTable
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content.([]byte)))).
+		Decode(&t); err != nil && err != io.EOF {
+		return err
+	}
+	if t.TotalsRowCount > 0 {
+		return ErrParameterInvalid
+	}
+	coordinates, err := rangeRefToCoordinates(t.Ref)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	lastRow, err := f.getContiguousLastRow(sheet, coordinates)
+	if err != nil || lastRow == coordinates[3] {
+		return err
+	}
+	coordinates[3] = lastRow
+	t.Ref, _ = coordinatesToRangeRef(coordinates)
+	if t.AutoFilter != nil {
+		t.AutoFilter.Ref = t.Ref
+	}
+	output, _ := xml.Marshal(t)
+	f.saveFileList(table.tableXML, output)
+	var pivotCaches []string
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.HasPrefix(k.(string), "xl/pivotCache/pivotCacheDefinition") {
+			pivotCaches = append(pivotCaches, k.(string))
+		}
+		return true
+	})
+	for _, pivotCacheXML := range pivotCaches {
+		pc, err := f.pivotCacheReader(pivotCacheXML)
+		if err != nil {
+			return err
+		}
+		if pc.CacheSource != nil && pc.CacheSource.WorksheetSource != nil && pc.CacheSource.WorksheetSource.Name == t.Name {
+			pc.RefreshOnLoad = true
+			pivotCache, _ := xml.Marshal(pc)
+			f.saveFileList(pivotCacheXML, pivotCache)
+		}
+	}
+	return nil
+}
+
+// expandPivotSource provides a function to expand the source range of the
+// pivot table by given pivot table options.
+func (f *File) expandPivotSource(opts PivotTableOptions) error {
+	pc, err := f.pivotCacheReader(opts.pivotCacheXML)
+	if err != nil {
+		return err
+	}
+	if pc.CacheSource == nil || pc.CacheSource.WorksheetSource == nil {
+		return ErrParameterInvalid
+	}
+	source := pc.CacheSource.WorksheetSource
+	if source.Name != "" {
+		for _, sheet := range f.GetSheetList() {
+			tables, _ := f.GetTables(sheet)
+			for _, table := range tables {
+				if table.Name == source.Name {
+					return f.expandTable(sheet, table)
+				}
+			}
+		}
+		return ErrParameterInvalid
+	}
+	coordinates, err := rangeRefToCoordinates(source.Ref)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	lastRow, err := f.getContiguousLastRow(source.Sheet, coordinates)
+	if err != nil || lastRow == coordinates[3] {
+		return err
+	}
+	coordinates[3] = lastRow
+	source.Ref, _ = coordinatesToRangeRef(coordinates)
+	pc.RefreshOnLoad = true
+	pivotCache, _ := xml.Marshal(pc)
+	f.saveFileList(opts.pivotCacheXML, pivotCache)
+	return nil
+}
+
+// getContiguousLastRow provides a function to get the last row number of the
+// contiguous non-empty rows below the range by given worksheet name and range
+// coordinates, the row is non-empty if any cell in the columns of the range
+// contains value or formula.
+func (f *File) getContiguousLastRow(sheet string, coordinates []int) (int, error) {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return coordinates[3], err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	lastRow := coordinates[3]
+	for _, row := range ws.SheetData.Row {
+		if row.R <= lastRow {
+			continue
+		}
+		if row.R != lastRow+1 {
+			break
+		}
+		var nonEmpty bool
+		for _, c := range row.C {
+			col, _, err := CellNameToCoordinates(c.R)
+			if err != nil {
+				return coordinates[3], err
+			}
+			if col >= coordinates[0] && col <= coordinates[2] && (c.V != "" || c.F != nil || c.IS != nil) {
+				nonEmpty = true
+				break
+			}
+		}
+		if !nonEmpty {
+			break
+		}
+		lastRow = row.R
+	}
+	return lastRow, err
+}
+
 // countTables provides a function to get table files count storage in the
 // folder xl/tables.
 func (f *File) countTables() int {
@@ -403,8 +577,15 @@ func (f *File) addTable(sheet, tableXML string, x1, y1, x2, y2, i int, opts *Tab
 //
 // It isn't sufficient to just specify the filter condition. You must also
 // hide any rows that don't match the filter condition. Rows are hidden using
-// the SetRowVisible function. Excelize can't filter rows automatically since
-// this isn't part of the file format.
+// the SetRowVisible function, or by the ReapplyAutoFilter function which
+// evaluates the stored filter criteria against the current cell values.
+//
+// HideButton specifies if hide the filter drop-down button of the column, for
+// example, hide the filter button of column C without setting any criteria:
+//
+//	err := f.AutoFilter("Sheet1", "A1:D4", []excelize.AutoFilterOptions{
+//	    {Column: "C", HideButton: true},
+//	})
 //
 // Setting a filter criteria for a column:
 //
@@ -519,7 +700,7 @@ func (f *File) autoFilter(sheet, ref string, columns, col int, opts []AutoFilter
 	}
 	ws.AutoFilter = filter
 	for _, opt := range opts {
-		if opt.Column == "" || opt.Expression == "" {
+		if opt.Column == "" || (opt.Expression == "" && !opt.HideButton) {
 			continue
 		}
 		fsCol, err := ColumnNameToNumber(opt.Column)
@@ -531,6 +712,13 @@ func (f *File) autoFilter(sheet, ref string, columns, col int, opts []AutoFilter
 			return newInvalidAutoFilterColumnError(opt.Column)
 		}
 		fc := &xlsxFilterColumn{ColID: offset}
+		if opt.HideButton {
+			fc.HiddenButton, fc.ShowButton = true, boolPtr(false)
+		}
+		if opt.Expression == "" {
+			filter.FilterColumn = append(filter.FilterColumn, fc)
+			continue
+		}
 		token := expressionFormat.FindAllString(opt.Expression, -1)
 		if len(token) != 3 && len(token) != 7 {
 			return newInvalidAutoFilterExpError(opt.Expression)
@@ -546,6 +734,157 @@ func (f *File) autoFilter(sheet, ref string, columns, col int, opts []AutoFilter
 	return nil
 }
 
+// ReapplyAutoFilter provides a function to re-evaluate the filter criteria of
+// the auto filter in a worksheet by given worksheet name against the current
+// cell values, and update the hidden state of the rows in the auto filter
+// range. The cell values of the filter columns will be read in one traversal
+// of the worksheet, and each row will be evaluated once. Rows which match all
+// filter criteria will be visible, other rows will be hidden. The criteria of filters and custom filters are supported, the
+// color, dynamic, icon and top 10 filters are not evaluated and the rows will
+// not be hidden by them. For example, reapply auto filter on Sheet1:
+//
+//	err := f.ReapplyAutoFilter("Sheet1")
+func (f *File) ReapplyAutoFilter(sheet string) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if ws.AutoFilter == nil {
+		return err
+	}
+	coordinates, err := rangeRefToCoordinates(ws.AutoFilter.Ref)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	filterColumns := ws.AutoFilter.FilterColumn
+	var cells []string
+	for row := coordinates[1] + 1; row <= coordinates[3]; row++ {
+		for _, fc := range filterColumns {
+			cell, err := CoordinatesToCellName(coordinates[0]+fc.ColID, row)
+			if err != nil {
+				return err
+			}
+			cells = append(cells, cell)
+		}
+	}
+	values, err := f.GetCellValues(sheet, cells)
+	if err != nil {
+		return err
+	}
+	for row := coordinates[1] + 1; row <= coordinates[3]; row++ {
+		visible, offset := true, (row-coordinates[1]-1)*len(filterColumns)
+		for i, fc := range filterColumns {
+			if !fc.match(values[offset+i]) {
+				visible = false
+				break
+			}
+		}
+		if err = f.SetRowVisible(sheet, row, visible); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// match provides a function to check if the given cell value matches the
+// criteria of the filter column.
+func (fc *xlsxFilterColumn) match(val string) bool {
+	if fc.Filters != nil {
+		isBlank := strings.TrimSpace(val) == ""
+		for _, filter := range fc.Filters.Filter {
+			// The "blanks" filter value was written for matching blank cells
+			if isBlank && filter.Val == "blanks" || strings.EqualFold(filter.Val, val) {
+				return true
+			}
+		}
+		return isBlank && fc.Filters.Blank
+	}
+	if fc.CustomFilters == nil || len(fc.CustomFilters.CustomFilter) == 0 {
+		return true
+	}
+	for _, cf := range fc.CustomFilters.CustomFilter {
+		if matched := cf.match(val); matched != fc.CustomFilters.And {
+			return matched
+		}
+	}
+	return fc.CustomFilters.And
+}
+
+// match provides a function to check if the given cell value matches the
+// custom filter criteria.
+func (cf *xlsxCustomFilter) match(val string) bool {
+	if cf.Val == " " {
+		// The single space value represents blank cells.
+		isBlank := strings.TrimSpace(val) == ""
+		if cf.Operator == "notEqual" {
+			return !isBlank
+		}
+		return isBlank
+	}
+	cmp := strings.Compare(strings.ToLower(val), strings.ToLower(cf.Val))
+	if a, err := strconv.ParseFloat(val, 64); err == nil {
+		if b, err := strconv.ParseFloat(cf.Val, 64); err == nil {
+			cmp = 0
+			if a < b {
+				cmp = -1
+			} else if a > b {
+				cmp = 1
+			}
+		}
+	}
+	if matchFormat.MatchString(cf.Val) && (cf.Operator == "" || cf.Operator == "equal" || cf.Operator == "notEqual") {
+		matched := filterWildcardToRegexp(cf.Val).MatchString(val)
+		if cf.Operator == "notEqual" {
+			return !matched
+		}
+		return matched
+	}
+	switch cf.Operator {
+	case "lessThan":
+		return cmp < 0
+	case "lessThanOrEqual":
+		return cmp <= 0
+	case "greaterThan":
+		return cmp > 0
+	case "greaterThanOrEqual":
+		return cmp >= 0
+	case "notEqual":
+		return cmp != 0
+	default:
+		return cmp == 0
+	}
+}
+
+// filterWildcardToRegexp provides a function to convert the filter criteria
+// with Excel wildcard characters into a case-insensitive regular expression.
+// The '*' matches any characters, '?' matches any single character, and the
+// '~' escapes the next wildcard character.
+func filterWildcardToRegexp(val string) *regexp.Regexp {
+	var (
+		expr   strings.Builder
+		escape bool
+	)
+	for _, r := range val {
+		if escape {
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+			escape = false
+			continue
+		}
+		switch r {
+		case '~':
+			escape = true
+		case '*':
+			expr.WriteString(".*")
+		case '?':
+			expr.WriteString(".")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	return regexp.MustCompile("(?is)^" + expr.String() + "$")
+}
+
 // writeAutoFilter provides a function to check for single or double custom
 // filters as default filters and handle them accordingly.
 func (f *File) writeAutoFilter(fc *xlsxFilterColumn, exp []int, tokens []string) {
@@ -1,6 +1,7 @@
 package excelize
 
 import (
+	"bytes"
 	"fmt"
 	"path/filepath"
 	"strings"
@@ -137,6 +138,85 @@ func TestDeleteTable(t *testing.T) {
 	assert.Equal(t, "Values", val)
 }
 
+func TestExpandTableOrPivotSource(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Data")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetRow("Data", "A1", &[]interface{}{"Month", "Region", "Sales"}))
+	assert.NoError(t, f.SetSheetRow("Data", "G1", &[]interface{}{"Month", "Region", "Sales"}))
+	for row := 2; row <= 4; row++ {
+		assert.NoError(t, f.SetSheetRow("Data", fmt.Sprintf("A%d", row), &[]interface{}{"Jan", "East", row}))
+		assert.NoError(t, f.SetSheetRow("Data", fmt.Sprintf("G%d", row), &[]interface{}{"Jan", "East", row}))
+	}
+	assert.NoError(t, f.AddTable("Data", &Table{Range: "G1:I4", Name: "Sales"}))
+	assert.NoError(t, f.AddPivotTable(&PivotTableOptions{
+		DataRange:       "Data!A1:C4",
+		PivotTableRange: "Sheet1!A1:D10",
+		Rows:            []PivotTableField{{Data: "Month"}},
+		Data:            []PivotTableField{{Data: "Sales"}},
+		Name:            "PivotTable1",
+	}))
+	assert.NoError(t, f.AddPivotTable(&PivotTableOptions{
+		DataRange:       "Sales",
+		PivotTableRange: "Sheet1!F1:I10",
+		Rows:            []PivotTableField{{Data: "Month"}},
+		Data:            []PivotTableField{{Data: "Sales"}},
+		Name:            "PivotTable2",
+	}))
+	// Test expand without appended rows
+	assert.NoError(t, f.ExpandTableOrPivotSource("PivotTable1"))
+	// Test expand the source range of the pivot table
+	for row := 5; row <= 6; row++ {
+		assert.NoError(t, f.SetSheetRow("Data", fmt.Sprintf("A%d", row), &[]interface{}{"Feb", "West", row}))
+		assert.NoError(t, f.SetSheetRow("Data", fmt.Sprintf("I%d", row), &[]interface{}{row}))
+	}
+	assert.NoError(t, f.SetCellValue("Data", "B8", "not contiguous"))
+	assert.NoError(t, f.SetCellValue("Data", "D7", "outside columns"))
+	assert.NoError(t, f.ExpandTableOrPivotSource("PivotTable1"))
+	pivotTables, err := f.GetPivotTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, pivotTables, 2)
+	pc, err := f.pivotCacheReader(pivotTables[0].pivotCacheXML)
+	assert.NoError(t, err)
+	assert.Equal(t, "A1:C6", pc.CacheSource.WorksheetSource.Ref)
+	assert.True(t, pc.RefreshOnLoad)
+	// Test expand the table which is the source of the pivot table
+	assert.NoError(t, f.ExpandTableOrPivotSource("PivotTable2"))
+	tables, err := f.GetTables("Data")
+	assert.NoError(t, err)
+	assert.Equal(t, "G1:I6", tables[0].Range)
+	pc, err = f.pivotCacheReader(pivotTables[1].pivotCacheXML)
+	assert.NoError(t, err)
+	assert.True(t, pc.RefreshOnLoad)
+	assert.NoError(t, f.SetSheetRow("Data", "G7", &[]interface{}{"Mar"}))
+	assert.NoError(t, f.ExpandTableOrPivotSource("Sales"))
+	tables, err = f.GetTables("Data")
+	assert.NoError(t, err)
+	assert.Equal(t, "G1:I7", tables[0].Range)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestExpandTableOrPivotSource.xlsx")))
+	// Test expand not exist table or pivot table
+	assert.Equal(t, newNoExistTableError("X"), f.ExpandTableOrPivotSource("X"))
+	// Test expand the table with totals row
+	content, ok := f.Pkg.Load(tables[0].tableXML)
+	assert.True(t, ok)
+	f.Pkg.Store(tables[0].tableXML, bytes.Replace(content.([]byte), []byte(`<table `), []byte(`<table totalsRowCount="1" `), 1))
+	assert.Equal(t, ErrParameterInvalid, f.ExpandTableOrPivotSource("Sales"))
+	// Test expand the table with invalid range reference
+	f.Pkg.Store(tables[0].tableXML, []byte(`<table name="Sales" ref="A:B"></table>`))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.ExpandTableOrPivotSource("Sales"))
+	// Test expand the table with unsupported charset
+	f.Pkg.Store(tables[0].tableXML, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.ExpandTableOrPivotSource("PivotTable2"), "XML syntax error on line 1: invalid UTF-8")
+	f.Pkg.Delete(tables[0].tableXML)
+	assert.Equal(t, newPivotTableDataRangeError(ErrParameterInvalid), f.ExpandTableOrPivotSource("PivotTable2"))
+	// Test expand the pivot table with the source of not exist table
+	assert.Equal(t, ErrParameterInvalid, f.expandPivotSource(pivotTables[1]))
+	// Test expand the pivot table with unsupported charset
+	f.Pkg.Store(pivotTables[0].pivotCacheXML, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.ExpandTableOrPivotSource("PivotTable1"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestSetTableColumns(t *testing.T) {
 	f := NewFile()
 	assert.Equal(t, newCoordinatesToCellNameError(1, 0), f.setTableColumns("Sheet1", true, 1, 0, 1, nil))
@@ -215,6 +295,75 @@ func TestAutoFilterError(t *testing.T) {
 	}}))
 }
 
+func TestReapplyAutoFilter(t *testing.T) {
+	f := NewFile()
+	for r, row := range [][]interface{}{
+		{"Name", "Score", "Note"},
+		{"Apple", 10, "x"},
+		{"banana", 25, ""},
+		{"Cherry", 40, "y"},
+		{"apricot", 55, "z"},
+		{"Date~*", 5, ""},
+	} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", r+1), &row))
+	}
+	for _, c := range []struct {
+		opts    []AutoFilterOptions
+		visible []bool
+	}{
+		{[]AutoFilterOptions{{Column: "A", Expression: "x == a*"}}, []bool{true, false, false, true, false}},
+		{[]AutoFilterOptions{{Column: "A", Expression: "x != a*"}}, []bool{false, true, true, false, true}},
+		{[]AutoFilterOptions{{Column: "A", Expression: "x == ?anana"}}, []bool{false, true, false, false, false}},
+		{[]AutoFilterOptions{{Column: "A", Expression: "x == *~~~*"}}, []bool{false, false, false, false, true}},
+		{[]AutoFilterOptions{{Column: "B", Expression: "x > 10 and x <= 40"}}, []bool{false, true, true, false, false}},
+		{[]AutoFilterOptions{{Column: "B", Expression: "x < 10 or x >= 55"}}, []bool{false, false, false, true, true}},
+		{[]AutoFilterOptions{{Column: "B", Expression: "x == 25 or x == 40"}}, []bool{false, true, true, false, false}},
+		{[]AutoFilterOptions{{Column: "A", Expression: "x == cherry"}, {Column: "B", Expression: "x != 40"}}, []bool{false, false, false, false, false}},
+		{[]AutoFilterOptions{{Column: "A", Expression: "x > b"}}, []bool{false, true, true, false, true}},
+		{[]AutoFilterOptions{{Column: "C", Expression: "x == NonBlanks"}}, []bool{true, false, true, true, false}},
+		{[]AutoFilterOptions{{Column: "C", Expression: "x != NonBlanks"}}, []bool{false, true, false, false, true}},
+		{[]AutoFilterOptions{{Column: "C", HideButton: true}}, []bool{true, true, true, true, true}},
+	} {
+		assert.NoError(t, f.AutoFilter("Sheet1", "A1:C6", c.opts))
+		assert.NoError(t, f.ReapplyAutoFilter("Sheet1"))
+		for i, expected := range c.visible {
+			visible, err := f.GetRowVisible("Sheet1", i+2)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, visible, c.opts)
+		}
+	}
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, []*xlsxFilterColumn{{ColID: 2, HiddenButton: true, ShowButton: boolPtr(false)}}, ws.(*xlsxWorksheet).AutoFilter.FilterColumn)
+	// Test hide filter button with criteria
+	assert.NoError(t, f.AutoFilter("Sheet1", "A1:C6", []AutoFilterOptions{{Column: "A", Expression: "x == Apple", HideButton: true}}))
+	assert.NoError(t, f.ReapplyAutoFilter("Sheet1"))
+	visible, err := f.GetRowVisible("Sheet1", 3)
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestReapplyAutoFilter.xlsx")))
+	// Test reapply auto filter on the worksheet without auto filter
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.ReapplyAutoFilter("Sheet2"))
+	// Test reapply auto filter with not exist worksheet
+	assert.EqualError(t, f.ReapplyAutoFilter("SheetN"), "sheet SheetN does not exist")
+	// Test reapply auto filter with invalid range reference
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.(*xlsxWorksheet).AutoFilter.Ref = "A:C6"
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.ReapplyAutoFilter("Sheet1"))
+	ws.(*xlsxWorksheet).AutoFilter.Ref = "XFD1:XFD2"
+	ws.(*xlsxWorksheet).AutoFilter.FilterColumn = []*xlsxFilterColumn{{ColID: 1}}
+	assert.Equal(t, ErrColumnNumber, f.ReapplyAutoFilter("Sheet1"))
+	// Test reapply auto filter with unsupported charset shared strings table
+	ws.(*xlsxWorksheet).AutoFilter.Ref = "A1:C6"
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.ReapplyAutoFilter("Sheet1"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestParseFilterTokens(t *testing.T) {
 	f := NewFile()
 	// Test with unknown operator
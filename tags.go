@@ -0,0 +1,268 @@
+// Copyright 2016 - 2024 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.18 or later.
+
+package excelize
+
+import (
+	"archive/zip"
+	"bytes"
+)
+
+// rangeTag directly maps the tag of the consecutive rows or columns in the
+// worksheet.
+type rangeTag struct {
+	name       string
+	dir        adjustDirection
+	start, end int
+}
+
+// TagRows provides a function to tag the consecutive rows by given worksheet
+// name, tag name, start and end row number. The tags are kept in memory only
+// and will not be saved in the workbook, the tagged rows will be adjusted
+// when inserting or deleting rows, and could be removed or hidden by the
+// ApplyTags function before saving the workbook. For example, tag the rows 5
+// to 8 in Sheet1 as internal data:
+//
+//	err := f.TagRows("Sheet1", "internal", 5, 8)
+func (f *File) TagRows(sheet, tag string, start, end int) error {
+	if start > end {
+		start, end = end, start
+	}
+	if start < 1 {
+		return newInvalidRowNumberError(start)
+	}
+	if end > TotalRows {
+		return ErrMaxRows
+	}
+	return f.addTag(sheet, rangeTag{name: tag, dir: rows, start: start, end: end})
+}
+
+// TagCols provides a function to tag the consecutive columns by given
+// worksheet name, tag name, start and end column name. The tags are kept in
+// memory only and will not be saved in the workbook, the tagged columns will
+// be adjusted when inserting or deleting columns, and could be removed or
+// hidden by the ApplyTags function before saving the workbook. For example,
+// tag the columns D to F in Sheet1 as internal data:
+//
+//	err := f.TagCols("Sheet1", "internal", "D", "F")
+func (f *File) TagCols(sheet, tag, start, end string) error {
+	startCol, err := ColumnNameToNumber(start)
+	if err != nil {
+		return err
+	}
+	endCol, err := ColumnNameToNumber(end)
+	if err != nil {
+		return err
+	}
+	if startCol > endCol {
+		startCol, endCol = endCol, startCol
+	}
+	return f.addTag(sheet, rangeTag{name: tag, dir: columns, start: startCol, end: endCol})
+}
+
+// ApplyTags provides a function to remove or hide the tagged rows and columns
+// by given tag settings when saving the workbook next time, which eases
+// exporting different views of the workbook for multi-audience from one
+// in-memory model. The tagged rows and columns of all tags will be removed if
+// the settings are nil. The tags are applied on a copy of the workbook at save
+// time, so the in-memory model is kept unchanged, and the settings only take
+// effect on the next save. For example, save the internal view of the
+// workbook first, then save the customer view without the rows and columns
+// tagged as internal data:
+//
+//	if err := f.SaveAs("Internal.xlsx"); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err := f.ApplyTags(&excelize.TagOptions{Tags: []string{"internal"}}); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err := f.SaveAs("Customer.xlsx"); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//
+// Use this method with caution when removing the tagged rows and columns,
+// which will affect changes in references such as formulas, charts, and so on
+// in the saved workbook.
+func (f *File) ApplyTags(opts *TagOptions) error {
+	if opts == nil {
+		opts = &TagOptions{}
+	}
+	f.mu.Lock()
+	f.tagOptions = opts
+	f.mu.Unlock()
+	return nil
+}
+
+// takeTagOptions provides a function to get and reset the pending settings of
+// applying tags for the next save.
+func (f *File) takeTagOptions() *TagOptions {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	opts := f.tagOptions
+	f.tagOptions = nil
+	return opts
+}
+
+// copyWithTags provides a function to create a copy of the workbook by
+// writing and reading it back, and apply the tags on the copy by given tag
+// settings, which keeps the in-memory model of the workbook unchanged.
+func (f *File) copyWithTags(opts *TagOptions) (*File, error) {
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	if err := f.writeToZip(zw); err != nil {
+		_ = zw.Close()
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	var options Options
+	if f.options != nil {
+		options = *f.options
+	}
+	options.Password = ""
+	cp, err := OpenReader(buf, options)
+	if err != nil {
+		return nil, err
+	}
+	cp.CharsetReader = f.CharsetReader
+	f.tags.Range(func(k, v interface{}) bool {
+		cp.tags.Store(k, v)
+		return true
+	})
+	if err = cp.applyTags(opts); err != nil {
+		_ = cp.Close()
+		return nil, err
+	}
+	cp.options = f.options
+	return cp, nil
+}
+
+// applyTags provides a function to remove or hide the tagged rows and columns
+// in the workbook by given tag settings.
+func (f *File) applyTags(opts *TagOptions) error {
+	isApplied := func(tag rangeTag) bool {
+		return len(opts.Tags) == 0 || inStrSlice(opts.Tags, tag.name, true) != -1
+	}
+	for _, sheet := range f.GetSheetList() {
+		sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+		if opts.Hide {
+			var rowRanges []RowRange
+			for _, tag := range f.getTags(sheetXMLPath) {
+				if !isApplied(tag) {
+					continue
+				}
+				if tag.dir == rows {
+					rowRanges = append(rowRanges, RowRange{Start: tag.start, End: tag.end})
+					continue
+				}
+				if err := f.hideTaggedCols(sheet, tag); err != nil {
+					return err
+				}
+			}
+			if len(rowRanges) > 0 {
+				if err := f.SetRowsVisible(sheet, rowRanges, false); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		// The tagged ranges will be adjusted after removing each range, so
+		// find the next range which should be removed from the latest tags.
+		for {
+			idx := -1
+			tags := f.getTags(sheetXMLPath)
+			for i, tag := range tags {
+				if isApplied(tag) {
+					idx = i
+					break
+				}
+			}
+			if idx == -1 {
+				break
+			}
+			if err := f.removeTaggedRange(sheet, tags[idx]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// addTag provides a function to add the tag of the consecutive rows or
+// columns by given worksheet name and tag.
+func (f *File) addTag(sheet string, tag rangeTag) error {
+	if tag.name == "" {
+		return ErrParameterRequired
+	}
+	if _, err := f.workSheetReader(sheet); err != nil {
+		return err
+	}
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	f.tags.Store(sheetXMLPath, append(f.getTags(sheetXMLPath), tag))
+	return nil
+}
+
+// getTags provides a function to get the tags of the worksheet by given
+// worksheet XML path.
+func (f *File) getTags(sheetXMLPath string) []rangeTag {
+	if tags, ok := f.tags.Load(sheetXMLPath); ok {
+		return tags.([]rangeTag)
+	}
+	return nil
+}
+
+// hideTaggedCols provides a function to hide the tagged columns by given
+// worksheet name and tag.
+func (f *File) hideTaggedCols(sheet string, tag rangeTag) error {
+	start, _ := ColumnNumberToName(tag.start)
+	end, _ := ColumnNumberToName(tag.end)
+	return f.SetColVisible(sheet, start+":"+end, false)
+}
+
+// removeTaggedRange provides a function to remove the tagged rows or columns
+// by given worksheet name and tag.
+func (f *File) removeTaggedRange(sheet string, tag rangeTag) error {
+	if tag.dir == rows {
+		return f.RemoveRows(sheet, tag.start, tag.end-tag.start+1)
+	}
+	col, _ := ColumnNumberToName(tag.start)
+	return f.RemoveCols(sheet, col, tag.end-tag.start+1)
+}
+
+// adjustTags provides a function to update the tagged rows or columns when
+// inserting or deleting rows or columns.
+func (f *File) adjustTags(ws *xlsxWorksheet, sheet string, dir adjustDirection, num, offset, sheetID int) error {
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	tags := f.getTags(sheetXMLPath)
+	if len(tags) == 0 {
+		return nil
+	}
+	var rangeTags []rangeTag
+	for _, tag := range tags {
+		if tag.dir == dir {
+			start, end := adjustNumber(tag.start, num, offset), adjustNumber(tag.end, num, offset)
+			if isDeletedNumber(tag.start, num, offset) {
+				start = num
+			}
+			if end < start {
+				continue
+			}
+			tag.start, tag.end = start, end
+		}
+		rangeTags = append(rangeTags, tag)
+	}
+	f.tags.Store(sheetXMLPath, rangeTags)
+	return nil
+}
@@ -0,0 +1,121 @@
+package excelize
+
+import (
+	"bytes"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyTags(t *testing.T) {
+	f := NewFile()
+	for row := 1; row <= 10; row++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", "A"+strconv.Itoa(row), &[]interface{}{row, row * 10, row * 100, row * 1000}))
+	}
+	assert.NoError(t, f.TagRows("Sheet1", "internal", 3, 2))
+	assert.NoError(t, f.TagRows("Sheet1", "internal", 8, 8))
+	assert.NoError(t, f.TagRows("Sheet1", "draft", 6, 7))
+	assert.NoError(t, f.TagCols("Sheet1", "internal", "C", "B"))
+	// Test adjust the tags when inserting and removing rows
+	assert.NoError(t, f.InsertRows("Sheet1", 1, 1))
+	assert.NoError(t, f.RemoveRow("Sheet1", 1))
+	assert.Equal(t, []rangeTag{
+		{name: "internal", dir: rows, start: 2, end: 3},
+		{name: "internal", dir: rows, start: 8, end: 8},
+		{name: "draft", dir: rows, start: 6, end: 7},
+		{name: "internal", dir: columns, start: 2, end: 3},
+	}, f.getTags("xl/worksheets/sheet1.xml"))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestApplyTagsInternal.xlsx")))
+	// Test hide the tagged rows and columns
+	assert.NoError(t, f.ApplyTags(&TagOptions{Tags: []string{"draft", "internal"}, Hide: true}))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	cp, err := OpenReader(buf)
+	assert.NoError(t, err)
+	for row, visible := range map[int]bool{5: true, 6: false, 7: false, 8: false, 9: true} {
+		rowVisible, err := cp.GetRowVisible("Sheet1", row)
+		assert.NoError(t, err)
+		assert.Equal(t, visible, rowVisible, row)
+	}
+	colVisible, err := cp.GetColVisible("Sheet1", "B")
+	assert.NoError(t, err)
+	assert.False(t, colVisible)
+	assert.NoError(t, cp.Close())
+	// Test the in-memory model is unchanged after applying tags
+	rowVisible, err := f.GetRowVisible("Sheet1", 6)
+	assert.NoError(t, err)
+	assert.True(t, rowVisible)
+	colVisible, err = f.GetColVisible("Sheet1", "B")
+	assert.NoError(t, err)
+	assert.True(t, colVisible)
+	assert.Len(t, f.getTags("xl/worksheets/sheet1.xml"), 4)
+
+	// Test remove the tagged rows and columns
+	assert.NoError(t, f.ApplyTags(&TagOptions{Tags: []string{"internal"}}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestApplyTagsCustomer.xlsx")))
+	cp, err = OpenFile(filepath.Join("test", "TestApplyTagsCustomer.xlsx"))
+	assert.NoError(t, err)
+	rows, err := cp.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"1", "1000"}, {"4", "4000"}, {"5", "5000"}, {"6", "6000"}, {"7", "7000"}, {"9", "9000"}, {"10", "10000"}}, rows)
+	assert.NoError(t, cp.Close())
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, rows, 10)
+	assert.Len(t, f.getTags("xl/worksheets/sheet1.xml"), 4)
+	// Test the tag settings only take effect on the next save
+	buf, err = f.WriteToBuffer()
+	assert.NoError(t, err)
+	cp, err = OpenReader(buf)
+	assert.NoError(t, err)
+	rows, err = cp.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, rows, 10)
+	assert.NoError(t, cp.Close())
+	// Test remove the tagged rows of all tags with password protected workbook
+	assert.NoError(t, f.ApplyTags(nil))
+	buf = new(bytes.Buffer)
+	assert.NoError(t, f.Write(buf, Options{Password: "password"}))
+	cp, err = OpenReader(buf, Options{Password: "password"})
+	assert.NoError(t, err)
+	rows, err = cp.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, rows, 5)
+	assert.NoError(t, cp.Close())
+	f.options.Password = ""
+	// Test delete the tags with the worksheet
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.TagRows("Sheet2", "internal", 1, 1))
+	assert.NoError(t, f.DeleteSheet("Sheet2"))
+	assert.Empty(t, f.getTags("xl/worksheets/sheet2.xml"))
+	assert.NoError(t, f.Close())
+
+	f = NewFile()
+	// Test tag rows and columns with invalid parameters
+	assert.Equal(t, ErrParameterRequired, f.TagRows("Sheet1", "", 1, 1))
+	assert.EqualError(t, f.TagRows("Sheet1", "internal", 0, 1), newInvalidRowNumberError(0).Error())
+	assert.Equal(t, ErrMaxRows, f.TagRows("Sheet1", "internal", 1, TotalRows+1))
+	assert.EqualError(t, f.TagCols("Sheet1", "internal", "*", "A"), newInvalidColumnNameError("*").Error())
+	assert.EqualError(t, f.TagCols("Sheet1", "internal", "A", "*"), newInvalidColumnNameError("*").Error())
+	// Test tag rows and columns on not exists worksheet
+	assert.EqualError(t, f.TagRows("SheetN", "internal", 1, 1), "sheet SheetN does not exist")
+	assert.EqualError(t, f.TagCols("SheetN", "internal", "A", "A"), "sheet SheetN does not exist")
+	// Test apply tags with unsupported charset worksheet
+	for _, opts := range []*TagOptions{{Hide: true}, {Hide: true, Tags: []string{"cols"}}, nil} {
+		f = NewFile()
+		assert.NoError(t, f.TagRows("Sheet1", "rows", 1, 1))
+		assert.NoError(t, f.TagCols("Sheet1", "cols", "A", "A"))
+		f.Sheet.Delete("xl/worksheets/sheet1.xml")
+		f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+		f.checked.Delete("xl/worksheets/sheet1.xml")
+		assert.NoError(t, f.ApplyTags(opts))
+		_, err := f.WriteToBuffer()
+		assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+		assert.NoError(t, f.ApplyTags(opts))
+		assert.EqualError(t, f.Write(new(bytes.Buffer)), "XML syntax error on line 1: invalid UTF-8")
+		assert.NoError(t, f.Close())
+	}
+}
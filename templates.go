@@ -21,17 +21,22 @@ import "encoding/xml"
 var (
 	NameSpaceDocumentPropertiesVariantTypes = xml.Attr{Name: xml.Name{Local: "vt", Space: "xmlns"}, Value: "http://schemas.openxmlformats.org/officeDocument/2006/docPropsVTypes"}
 	NameSpaceDrawing2016SVG                 = xml.Attr{Name: xml.Name{Local: "asvg", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/drawing/2016/SVG/main"}
+	NameSpaceDrawing2017Decorative          = xml.Attr{Name: xml.Name{Local: "adec", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/drawing/2017/decorative"}
 	NameSpaceDrawingML                      = xml.Attr{Name: xml.Name{Local: "a", Space: "xmlns"}, Value: "http://schemas.openxmlformats.org/drawingml/2006/main"}
 	NameSpaceDrawingMLA14                   = xml.Attr{Name: xml.Name{Local: "a14", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/drawing/2010/main"}
 	NameSpaceDrawingMLChart                 = xml.Attr{Name: xml.Name{Local: "c", Space: "xmlns"}, Value: "http://schemas.openxmlformats.org/drawingml/2006/chart"}
 	NameSpaceDrawingMLSlicer                = xml.Attr{Name: xml.Name{Local: "sle", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/drawing/2010/slicer"}
 	NameSpaceDrawingMLSlicerX15             = xml.Attr{Name: xml.Name{Local: "sle15", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/drawing/2012/slicer"}
 	NameSpaceDrawingMLSpreadSheet           = xml.Attr{Name: xml.Name{Local: "xdr", Space: "xmlns"}, Value: "http://schemas.openxmlformats.org/drawingml/2006/spreadsheetDrawing"}
+	NameSpaceFeaturePropertyBag             = xml.Attr{Name: xml.Name{Local: "xfpb", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/spreadsheetml/2022/featurepropertybag"}
 	NameSpaceMacExcel2008Main               = xml.Attr{Name: xml.Name{Local: "mx", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/mac/excel/2008/main"}
+	NameSpaceOffice                         = xml.Attr{Name: xml.Name{Local: "o", Space: "xmlns"}, Value: "urn:schemas-microsoft-com:office:office"}
 	NameSpaceSpreadSheet                    = xml.Attr{Name: xml.Name{Local: "xmlns"}, Value: "http://schemas.openxmlformats.org/spreadsheetml/2006/main"}
 	NameSpaceSpreadSheetExcel2006Main       = xml.Attr{Name: xml.Name{Local: "xne", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/excel/2006/main"}
 	NameSpaceSpreadSheetX14                 = xml.Attr{Name: xml.Name{Local: "x14", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/spreadsheetml/2009/9/main"}
 	NameSpaceSpreadSheetX15                 = xml.Attr{Name: xml.Name{Local: "x15", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/spreadsheetml/2010/11/main"}
+	NameSpaceSpreadSheetX15AC               = xml.Attr{Name: xml.Name{Local: "x15ac", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/spreadsheetml/2010/11/ac"}
+	NameSpaceSpreadSheetXDA                 = xml.Attr{Name: xml.Name{Local: "xda", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/spreadsheetml/2017/dynamicarray"}
 	NameSpaceSpreadSheetXR10                = xml.Attr{Name: xml.Name{Local: "xr10", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/spreadsheetml/2016/revision10"}
 	SourceRelationship                      = xml.Attr{Name: xml.Name{Local: "r", Space: "xmlns"}, Value: "http://schemas.openxmlformats.org/officeDocument/2006/relationships"}
 	SourceRelationshipChart20070802         = xml.Attr{Name: xml.Name{Local: "c14", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/drawing/2007/8/2/chart"}
@@ -45,20 +50,27 @@ const (
 	ContentTypeAddinMacro                         = "application/vnd.ms-excel.addin.macroEnabled.main+xml"
 	ContentTypeDrawing                            = "application/vnd.openxmlformats-officedocument.drawing+xml"
 	ContentTypeDrawingML                          = "application/vnd.openxmlformats-officedocument.drawingml.chart+xml"
+	ContentTypeFeaturePropertyBag                 = "application/vnd.ms-excel.featurepropertybag+xml"
 	ContentTypeMacro                              = "application/vnd.ms-excel.sheet.macroEnabled.main+xml"
+	ContentTypePerson                             = "application/vnd.ms-excel.person+xml"
 	ContentTypeRelationships                      = "application/vnd.openxmlformats-package.relationships+xml"
 	ContentTypeSheetML                            = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"
 	ContentTypeSlicer                             = "application/vnd.ms-excel.slicer+xml"
 	ContentTypeSlicerCache                        = "application/vnd.ms-excel.slicerCache+xml"
+	ContentTypeSpreadSheetMLCalcChain             = "application/vnd.openxmlformats-officedocument.spreadsheetml.calcChain+xml"
 	ContentTypeSpreadSheetMLChartsheet            = "application/vnd.openxmlformats-officedocument.spreadsheetml.chartsheet+xml"
 	ContentTypeSpreadSheetMLComments              = "application/vnd.openxmlformats-officedocument.spreadsheetml.comments+xml"
+	ContentTypeSpreadSheetMLCustomProperty        = "application/vnd.openxmlformats-officedocument.spreadsheetml.customProperty"
+	ContentTypeSpreadSheetMLMetadata              = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheetMetadata+xml"
 	ContentTypeSpreadSheetMLPivotCacheDefinition  = "application/vnd.openxmlformats-officedocument.spreadsheetml.pivotCacheDefinition+xml"
+	ContentTypeSpreadSheetMLPrinterSettings       = "application/vnd.openxmlformats-officedocument.spreadsheetml.printerSettings"
 	ContentTypeSpreadSheetMLPivotTable            = "application/vnd.openxmlformats-officedocument.spreadsheetml.pivotTable+xml"
 	ContentTypeSpreadSheetMLSharedStrings         = "application/vnd.openxmlformats-officedocument.spreadsheetml.sharedStrings+xml"
 	ContentTypeSpreadSheetMLTable                 = "application/vnd.openxmlformats-officedocument.spreadsheetml.table+xml"
 	ContentTypeSpreadSheetMLWorksheet             = "application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"
 	ContentTypeTemplate                           = "application/vnd.openxmlformats-officedocument.spreadsheetml.template.main+xml"
 	ContentTypeTemplateMacro                      = "application/vnd.ms-excel.template.macroEnabled.main+xml"
+	ContentTypeThreadedComments                   = "application/vnd.ms-excel.threadedcomments+xml"
 	ContentTypeVBA                                = "application/vnd.ms-office.vbaProject"
 	ContentTypeVML                                = "application/vnd.openxmlformats-officedocument.vmlDrawing"
 	NameSpaceDrawingMLMain                        = "http://schemas.openxmlformats.org/drawingml/2006/main"
@@ -66,25 +78,45 @@ const (
 	NameSpaceDublinCoreMetadataInitiative         = "http://purl.org/dc/dcmitype/"
 	NameSpaceDublinCoreTerms                      = "http://purl.org/dc/terms/"
 	NameSpaceExtendedProperties                   = "http://schemas.openxmlformats.org/officeDocument/2006/extended-properties"
+	NameSpaceThreadedComments                     = "http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments"
 	NameSpaceXML                                  = "http://www.w3.org/XML/1998/namespace"
 	NameSpaceXMLSchemaInstance                    = "http://www.w3.org/2001/XMLSchema-instance"
+	SourceRelationshipCalcChain                   = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/calcChain"
 	SourceRelationshipChart                       = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/chart"
 	SourceRelationshipChartsheet                  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/chartsheet"
 	SourceRelationshipComments                    = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/comments"
+	SourceRelationshipConnections                 = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/connections"
+	SourceRelationshipControl                     = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/control"
+	SourceRelationshipCustomProperty              = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/customProperty"
 	SourceRelationshipDialogsheet                 = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/dialogsheet"
 	SourceRelationshipDrawingML                   = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/drawing"
 	SourceRelationshipDrawingVML                  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/vmlDrawing"
 	SourceRelationshipExtendProperties            = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/extended-properties"
+	SourceRelationshipExternalLink                = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/externalLink"
+	SourceRelationshipFeaturePropertyBag          = "http://schemas.microsoft.com/office/2022/11/relationships/FeaturePropertyBag"
 	SourceRelationshipHyperLink                   = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink"
 	SourceRelationshipImage                       = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/image"
+	SourceRelationshipIntlMacroSheet              = "http://schemas.microsoft.com/office/2006/relationships/xlIntlMacrosheet"
+	SourceRelationshipMacroSheet                  = "http://schemas.microsoft.com/office/2006/relationships/xlMacrosheet"
 	SourceRelationshipOfficeDocument              = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument"
+	SourceRelationshipOLEObject                   = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/oleObject"
+	SourceRelationshipPackage                     = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/package"
+	SourceRelationshipMetadata                    = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/sheetMetadata"
+	SourceRelationshipPerson                      = "http://schemas.microsoft.com/office/2017/10/relationships/person"
 	SourceRelationshipPivotCache                  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/pivotCacheDefinition"
 	SourceRelationshipPivotTable                  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/pivotTable"
+	SourceRelationshipPrinterSettings             = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/printerSettings"
+	SourceRelationshipQueryTable                  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/queryTable"
 	SourceRelationshipSharedStrings               = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/sharedStrings"
 	SourceRelationshipSlicer                      = "http://schemas.microsoft.com/office/2007/relationships/slicer"
 	SourceRelationshipSlicerCache                 = "http://schemas.microsoft.com/office/2007/relationships/slicerCache"
 	SourceRelationshipTable                       = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/table"
+	SourceRelationshipThreadedComment             = "http://schemas.microsoft.com/office/2017/10/relationships/threadedComment"
+	SourceRelationshipThumbnail                   = "http://schemas.openxmlformats.org/package/2006/relationships/metadata/thumbnail"
 	SourceRelationshipVBAProject                  = "http://schemas.microsoft.com/office/2006/relationships/vbaProject"
+	SourceRelationshipVBAProjectSignature         = "http://schemas.microsoft.com/office/2006/relationships/vbaProjectSignature"
+	SourceRelationshipVBAProjectSignatureAgile    = "http://schemas.microsoft.com/office/2014/relationships/vbaProjectSignatureAgile"
+	SourceRelationshipVBAProjectSignatureV3       = "http://schemas.microsoft.com/office/2020/07/relationships/vbaProjectSignatureV3"
 	SourceRelationshipWorkSheet                   = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet"
 	StrictNameSpaceDocumentPropertiesVariantTypes = "http://purl.oclc.org/ooxml/officeDocument/docPropsVTypes"
 	StrictNameSpaceDrawingMLMain                  = "http://purl.oclc.org/ooxml/drawingml/main"
@@ -100,12 +132,16 @@ const (
 	// ([ISO/IEC29500-1:2016] section 18.2.10) of the workbook and worksheet
 	// elements extended by the addition of new child ext elements.
 	ExtURICalcFeatures                   = "{B58B0392-4F1F-4190-BB64-5DF3571DCE5F}"
+	ExtURICameraTool                     = "{84589F7E-364E-4C9E-8A38-B11213B215E9}"
 	ExtURIConditionalFormattingRuleID    = "{B025F937-C7B1-47D3-B67F-A62EFF666E3E}"
 	ExtURIConditionalFormattings         = "{78C0D931-6437-407d-A8EE-F0AAD7539E65}"
 	ExtURIDataModel                      = "{FCE2AD5D-F65C-4FA6-A056-5C36A1767C68}"
 	ExtURIDataValidations                = "{CCE6A557-97BC-4b89-ADB6-D9C93CAAB3DF}"
+	ExtURIDecorative                     = "{C183D7F6-B498-43B3-948B-1728B52AA6E4}"
 	ExtURIDrawingBlip                    = "{28A0092B-C50C-407E-A947-70E740481C1C}"
+	ExtURIDynamicArrayProperties         = "{bdbb8cdc-fa1e-496e-a857-3c3f30c029c3}"
 	ExtURIExternalLinkPr                 = "{FCE6A71B-6B00-49CD-AB44-F6B1AE7CDE65}"
+	ExtURIFeaturePropertyBag             = "{C7286773-470A-42A8-94C5-96B5CB345126}"
 	ExtURIIgnoredErrors                  = "{01252117-D84E-4E92-8308-4BE1C098FCBB}"
 	ExtURIMacExcelMX                     = "{64002731-A6B0-56B0-2670-7721B7C09600}"
 	ExtURIModelTimeGroupings             = "{9835A34E-60A6-4A7C-AAB8-D5F71C897F49}"
@@ -171,7 +207,11 @@ const (
 	MaxFormControlValue  = 30000
 	MaxFontFamilyLength  = 31
 	MaxFontSize          = 409
+	MaxFormulaLength     = 8192
+	MaxRenderCells       = 1 << 20
+	MaxRenderPixels      = 1 << 26
 	MaxRowHeight         = 409
+	MaxScenarioCells     = 32
 	MaxSheetNameLength   = 31
 	MinColumns           = 1
 	MinFontSize          = 1
@@ -197,6 +237,16 @@ const (
 	defaultShapeLineWidth       = 1
 )
 
+// This section defines the IDs of the primary, secondary and series axes of
+// the charts.
+const (
+	primaryCatAxID   = 100000000
+	primaryValAxID   = 100000001
+	secondaryCatAxID = 100000003
+	secondaryValAxID = 100000004
+	seriesAxID       = 100000005
+)
+
 // ColorMappingType is the type of color transformation.
 type ColorMappingType byte
 
@@ -274,6 +324,7 @@ const (
 	defaultXMLPathContentTypes            = "[Content_Types].xml"
 	defaultXMLPathDocPropsApp             = "docProps/app.xml"
 	defaultXMLPathDocPropsCore            = "docProps/core.xml"
+	defaultXMLPathFeaturePropertyBag      = "xl/featurePropertyBag/featurePropertyBag.xml"
 	defaultXMLPathSharedStrings           = "xl/sharedStrings.xml"
 	defaultXMLPathStyles                  = "xl/styles.xml"
 	defaultXMLPathTheme                   = "xl/theme/theme1.xml"
@@ -472,6 +523,22 @@ var supportedImageTypes = map[string]string{
 	".tif": ".tiff", ".tiff": ".tiff", ".wmf": ".wmf", ".wmz": ".wmz",
 }
 
+// activeXControlTypes defined the type name and programmatic identifier of the
+// Microsoft Forms 2.0 ActiveX controls by class ID.
+var activeXControlTypes = map[string][]string{
+	"{4C599241-6926-101B-9992-00000B65C6F9}": {"Image", "Forms.Image.1"},
+	"{79176FB0-B7F2-11CE-97EF-00AA006D2776}": {"SpinButton", "Forms.SpinButton.1"},
+	"{8BD21D10-EC42-11CE-9E0D-00AA006002F3}": {"TextBox", "Forms.TextBox.1"},
+	"{8BD21D20-EC42-11CE-9E0D-00AA006002F3}": {"ListBox", "Forms.ListBox.1"},
+	"{8BD21D30-EC42-11CE-9E0D-00AA006002F3}": {"ComboBox", "Forms.ComboBox.1"},
+	"{8BD21D40-EC42-11CE-9E0D-00AA006002F3}": {"CheckBox", "Forms.CheckBox.1"},
+	"{8BD21D50-EC42-11CE-9E0D-00AA006002F3}": {"OptionButton", "Forms.OptionButton.1"},
+	"{8BD21D60-EC42-11CE-9E0D-00AA006002F3}": {"ToggleButton", "Forms.ToggleButton.1"},
+	"{978C9E23-D4B0-11CE-BF2D-00AA003F40D0}": {"Label", "Forms.Label.1"},
+	"{D7053240-CE69-11CD-A777-00DD01143C57}": {"CommandButton", "Forms.CommandButton.1"},
+	"{DFD181E0-5E2F-11CE-A449-00AA004A803D}": {"ScrollBar", "Forms.ScrollBar.1"},
+}
+
 // supportedContentTypes defined supported file format types.
 var supportedContentTypes = map[string]string{
 	".xlam": ContentTypeAddinMacro,
@@ -491,9 +558,45 @@ var supportedDrawingUnderlineTypes = []string{
 	"wavyDbl",
 }
 
+// supportedDrawingPatternTypes defined the preset pattern types in drawing
+// markup language for the fill pattern index 2-18 of the chart elements.
+var supportedDrawingPatternTypes = map[int]string{
+	2: "pct50", 3: "pct75", 4: "pct25", 5: "dkHorz", 6: "dkVert", 7: "dkDnDiag",
+	8: "dkUpDiag", 9: "smGrid", 10: "trellis", 11: "ltHorz", 12: "ltVert",
+	13: "ltDnDiag", 14: "ltUpDiag", 15: "lgGrid", 16: "smCheck", 17: "pct10",
+	18: "pct5",
+}
+
+// supportedChartTickMarkTypes defined supported tick mark types of the chart
+// axis.
+var supportedChartTickMarkTypes = []string{"none", "in", "out", "cross"}
+
+// supportedChartDashTypes defined supported preset dash types of the chart
+// line.
+var supportedChartDashTypes = []string{
+	"solid", "dot", "dash", "lgDash", "dashDot", "lgDashDot", "lgDashDotDot",
+	"sysDash", "sysDot", "sysDashDot", "sysDashDotDot",
+}
+
+// supportedChartTimeUnits defined supported time units of the chart date axis.
+var supportedChartTimeUnits = []string{"days", "months", "years"}
+
+// supportedChartDisplayUnits defined supported built-in display units of the
+// chart value axis.
+var supportedChartDisplayUnits = []string{
+	"hundreds", "thousands", "tenThousands", "hundredThousands", "millions",
+	"tenMillions", "hundredMillions", "billions", "trillions",
+}
+
 // supportedPositioning defined supported positioning types.
 var supportedPositioning = []string{"absolute", "oneCell", "twoCell"}
 
+// supportedGraphicUnits defined supported units of the graph object position
+// and size, and the number of EMUs per unit.
+var supportedGraphicUnits = map[string]float64{
+	"": 1, "emu": 1, "cm": 360000, "mm": 36000, "in": 914400, "pt": 12700,
+}
+
 // builtInDefinedNames defined built-in defined names are built with a _xlnm prefix.
 var builtInDefinedNames = []string{"_xlnm.Print_Area", "_xlnm.Print_Titles", "_xlnm.Criteria", "_xlnm._FilterDatabase", "_xlnm.Extract", "_xlnm.Consolidate_Area", "_xlnm.Database", "_xlnm.Sheet_Title"}
 
@@ -110,6 +110,24 @@ func (f *File) getSheetComments(sheetFile string) string {
 //	    Height: 40,
 //	    Width:  180,
 //	})
+//
+// The format of the comments box could be specified by the Fill, Line, Font
+// and Shadow fields. The Fill sets the fill color of the box, specify two
+// colors for a gradient fill. The Line sets the border color and width in
+// points. The Font sets the default font of the comment text, and the Shadow
+// sets whether to show the shadow of the box. For example, add a comment with
+// blue fill, dark blue border, white Arial font and without shadow in
+// Sheet1!C3:
+//
+//	err := f.AddComment("Sheet1", excelize.Comment{
+//	    Cell:   "C3",
+//	    Author: "Excelize",
+//	    Text:   "This is a comment.",
+//	    Fill:   excelize.Fill{Color: []string{"4472C4"}},
+//	    Line:   excelize.ShapeLine{Color: "1F3864", Width: &width},
+//	    Font:   &excelize.Font{Family: "Arial", Color: "FFFFFF"},
+//	    Shadow: &disable,
+//	})
 func (f *File) AddComment(sheet string, opts Comment) error {
 	return f.addVMLObject(vmlOptions{
 		sheet: sheet, Comment: opts,
@@ -169,13 +187,10 @@ func (f *File) DeleteComment(sheet, cell string) error {
 	return f.deleteFormControl(sheetRelationshipsDrawingVML, cell, true)
 }
 
-// deleteFormControl provides the method to delete shape from
-// xl/drawings/vmlDrawing%d.xml by giving path, cell and shape type.
-func (f *File) deleteFormControl(sheetRelationshipsDrawingVML, cell string, isComment bool) error {
-	col, row, err := CellNameToCoordinates(cell)
-	if err != nil {
-		return err
-	}
+// vmlDrawingReader provides a function to get the pointer to the structure of
+// the VML drawing by given relationships target, the exist VML shapes will be
+// loaded from xl/drawings/vmlDrawing%d.vml on the first access.
+func (f *File) vmlDrawingReader(sheetRelationshipsDrawingVML string) (string, *vmlDrawing, error) {
 	vmlID, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(sheetRelationshipsDrawingVML, "../drawings/vmlDrawing"), ".vml"))
 	drawingVML := strings.ReplaceAll(sheetRelationshipsDrawingVML, "..", "xl")
 	vml := f.VMLDrawing[drawingVML]
@@ -196,7 +211,7 @@ func (f *File) deleteFormControl(sheetRelationshipsDrawingVML, cell string, isCo
 		// Load exist VML shapes from xl/drawings/vmlDrawing%d.vml
 		d, err := f.decodeVMLDrawingReader(drawingVML)
 		if err != nil {
-			return err
+			return drawingVML, nil, err
 		}
 		if d != nil {
 			vml.ShapeType.ID = d.ShapeType.ID
@@ -204,22 +219,45 @@ func (f *File) deleteFormControl(sheetRelationshipsDrawingVML, cell string, isCo
 			vml.ShapeType.Spt = d.ShapeType.Spt
 			vml.ShapeType.Path = d.ShapeType.Path
 			for _, v := range d.Shape {
-				s := xlsxShape{
-					ID:          v.ID,
-					Type:        v.Type,
-					Style:       v.Style,
-					Button:      v.Button,
-					Filled:      v.Filled,
-					FillColor:   v.FillColor,
-					InsetMode:   v.InsetMode,
-					Stroked:     v.Stroked,
-					StrokeColor: v.StrokeColor,
-					Val:         v.Val,
-				}
-				vml.Shape = append(vml.Shape, s)
+				vml.Shape = append(vml.Shape, v.toShape())
 			}
 		}
 	}
+	return drawingVML, vml, nil
+}
+
+// toShape provides a function to convert the decoded VML shape to the
+// structure for re-serialization.
+func (v decodeShape) toShape() xlsxShape {
+	return xlsxShape{
+		ID:           v.ID,
+		Spid:         v.Spid,
+		Type:         v.Type,
+		Style:        v.Style,
+		Alt:          v.Alt,
+		Title:        v.Title,
+		Button:       v.Button,
+		Filled:       v.Filled,
+		FillColor:    v.FillColor,
+		InsetMode:    v.InsetMode,
+		Stroked:      v.Stroked,
+		StrokeColor:  v.StrokeColor,
+		StrokeWeight: v.StrokeWeight,
+		Val:          v.Val,
+	}
+}
+
+// deleteFormControl provides the method to delete shape from
+// xl/drawings/vmlDrawing%d.xml by giving path, cell and shape type.
+func (f *File) deleteFormControl(sheetRelationshipsDrawingVML, cell string, isComment bool) error {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return err
+	}
+	drawingVML, vml, err := f.vmlDrawingReader(sheetRelationshipsDrawingVML)
+	if err != nil {
+		return err
+	}
 	cond := func(objectType string) bool {
 		if isComment {
 			return objectType == "Note"
@@ -280,6 +318,16 @@ func (f *File) addComment(commentsXML string, opts vmlOptions) error {
 		}
 		cmt.Text.T = stringPtr(opts.Comment.Text)
 		chars += len(opts.Comment.Text)
+		if opts.Comment.Font != nil {
+			cmt.Text.T = nil
+			cmt.Text.R = append(cmt.Text.R, xlsxR{
+				RPr: newRpr(opts.Comment.Font),
+				T: &xlsxT{Val: opts.Comment.Text, Space: xml.Attr{
+					Name:  xml.Name{Space: NameSpaceXML, Local: "space"},
+					Value: "preserve",
+				}},
+			})
+		}
 	}
 	for _, run := range opts.Comment.Paragraph {
 		if chars == TotalCellChars {
@@ -305,6 +353,8 @@ func (f *File) addComment(commentsXML string, opts vmlOptions) error {
 		}
 		if run.Font != nil {
 			r.RPr = newRpr(run.Font)
+		} else if opts.Comment.Font != nil {
+			r.RPr = newRpr(opts.Comment.Font)
 		}
 		cmt.Text.R = append(cmt.Text.R, r)
 	}
@@ -362,7 +412,12 @@ func (f *File) commentsWriter() {
 // by given worksheet name and form control options. Supported form control
 // type: button, check box, group box, label, option button, scroll bar and
 // spinner. If set macro for the form control, the workbook extension should be
-// XLSM or XLTM. Scroll value must be between 0 and 30000.
+// XLSM or XLTM. Scroll value must be between 0 and 30000. The optional Name and
+// AltText specifies the name and alternative text of the form control which
+// used by accessibility tools, and the "AltTextTitle" in the "Format" specifies
+// the title of the alternative text. Note that form controls do not support
+// being marked as decorative, and the absolute position and physical size
+// settings in the "Format", such as "Absolute", "Width" and "Height".
 //
 // Example 1, add button form control with macro, rich-text, custom button size,
 // print property on Sheet1!A2, and let the button do not move or size with
@@ -792,6 +847,9 @@ func (f *File) addFormCtrlShape(preset formCtrlPreset, col, row int, anchor stri
 			FirstButton: preset.firstButton,
 		},
 	}
+	if opts.Format.hasAbsoluteAnchor() {
+		return &sp, ErrParameterInvalid
+	}
 	if opts.Format.PrintObject != nil && !*opts.Format.PrintObject {
 		sp.ClientData.PrintObject = "False"
 	}
@@ -810,6 +868,9 @@ func (f *File) addFormCtrlShape(preset formCtrlPreset, col, row int, anchor stri
 	if !opts.formCtrl {
 		return &sp, nil
 	}
+	if len(opts.Macro) > MaxFieldLength {
+		return &sp, newFieldLengthError("Macro")
+	}
 	sp.TextBox.Div.Font = formCtrlText(opts)
 	sp.ClientData.FmlaMacro = opts.Macro
 	if (opts.Type == FormControlCheckBox || opts.Type == FormControlOptionButton) && opts.Checked {
@@ -865,19 +926,7 @@ func (f *File) addDrawingVML(dataID int, drawingVML string, opts *vmlOptions) er
 			vml.ShapeType.Spt = d.ShapeType.Spt
 			vml.ShapeType.Path = d.ShapeType.Path
 			for _, v := range d.Shape {
-				s := xlsxShape{
-					ID:          v.ID,
-					Type:        v.Type,
-					Style:       v.Style,
-					Button:      v.Button,
-					Filled:      v.Filled,
-					FillColor:   v.FillColor,
-					InsetMode:   v.InsetMode,
-					Stroked:     v.Stroked,
-					StrokeColor: v.StrokeColor,
-					Val:         v.Val,
-				}
-				vml.Shape = append(vml.Shape, s)
+				vml.Shape = append(vml.Shape, v.toShape())
 			}
 		}
 	}
@@ -885,7 +934,6 @@ func (f *File) addDrawingVML(dataID int, drawingVML string, opts *vmlOptions) er
 	if err != nil {
 		return err
 	}
-	s, _ := xml.Marshal(sp)
 	shape := xlsxShape{
 		ID:          "_x0000_s1025",
 		Type:        fmt.Sprintf("#_x0000_t%d", vmlID),
@@ -895,13 +943,70 @@ func (f *File) addDrawingVML(dataID int, drawingVML string, opts *vmlOptions) er
 		FillColor:   preset.fillColor,
 		Stroked:     preset.stroked,
 		StrokeColor: preset.strokeColor,
-		Val:         string(s[13 : len(s)-14]),
+	}
+	if !opts.formCtrl {
+		shape.setCommentFormat(sp, &opts.Comment)
+	}
+	s, _ := xml.Marshal(sp)
+	shape.Val = string(s[13 : len(s)-14])
+	if opts.formCtrl {
+		shape.Alt, shape.Title = opts.AltText, opts.Format.AltTextTitle
+		if opts.Name != "" {
+			shape.ID, shape.Spid = strings.ReplaceAll(opts.Name, " ", "_x0020_"), vml.newShapeID()
+			for _, sp := range vml.Shape {
+				if sp.ID == shape.ID {
+					return ErrExistsFormControlName
+				}
+			}
+		}
 	}
 	vml.Shape = append(vml.Shape, shape)
 	f.VMLDrawing[drawingVML] = vml
 	return err
 }
 
+// newShapeID provides a function to generate the unique shape ID which not
+// used by the exist shapes in the VML drawing.
+func (vml *vmlDrawing) newShapeID() string {
+	id := 1025
+	for _, sp := range vml.Shape {
+		for _, shapeID := range []string{sp.ID, sp.Spid} {
+			if n, err := strconv.Atoi(strings.TrimPrefix(shapeID, "_x0000_s")); err == nil && n >= id {
+				id = n + 1
+			}
+		}
+	}
+	return fmt.Sprintf("_x0000_s%d", id)
+}
+
+// setCommentFormat provides a function to set the fill, border and shadow of
+// the comment box by given VML shape and comment options.
+func (shape *xlsxShape) setCommentFormat(sp *encodeShape, opts *Comment) {
+	vmlColor := func(color string) string {
+		return "#" + strings.TrimPrefix(color, "#")
+	}
+	if len(opts.Fill.Color) > 0 && opts.Fill.Color[0] != "" {
+		shape.FillColor, sp.Fill = vmlColor(opts.Fill.Color[0]), nil
+		if len(opts.Fill.Color) > 1 && opts.Fill.Color[1] != "" {
+			sp.Fill = &vFill{
+				Color2: vmlColor(opts.Fill.Color[1]),
+				Angle:  -180,
+				Type:   "gradient",
+				Fill:   &oFill{Ext: "view", Type: "gradientUnscaled"},
+			}
+		}
+	}
+	if opts.Line.Color != "" {
+		shape.StrokeColor = vmlColor(opts.Line.Color)
+	}
+	if opts.Line.Width != nil && *opts.Line.Width > 0 {
+		shape.StrokeWeight = strconv.FormatFloat(*opts.Line.Width, 'f', -1, 64) + "pt"
+	}
+	if opts.Shadow != nil && !*opts.Shadow {
+		sp.Shadow = nil
+	}
+}
+
 // GetFormControls retrieves all form controls in a worksheet by a given
 // worksheet name. Note that, this function does not support getting the width
 // and height of the form controls currently.
@@ -924,11 +1029,12 @@ func (f *File) GetFormControls(sheet string) ([]FormControl, error) {
 		if err != nil {
 			return formControls, err
 		}
-		for _, sp := range d.Shape {
+		for _, v := range d.Shape {
+			sp := v.toShape()
 			if sp.Type != "#_x0000_t201" {
 				continue
 			}
-			formControl, err := extractFormControl(sp.Val)
+			formControl, err := sp.extractFormControl()
 			if err != nil {
 				return formControls, err
 			}
@@ -943,7 +1049,7 @@ func (f *File) GetFormControls(sheet string) ([]FormControl, error) {
 		if sp.Type != "#_x0000_t201" {
 			continue
 		}
-		formControl, err := extractFormControl(sp.Val)
+		formControl, err := sp.extractFormControl()
 		if err != nil {
 			return formControls, err
 		}
@@ -955,6 +1061,235 @@ func (f *File) GetFormControls(sheet string) ([]FormControl, error) {
 	return formControls, err
 }
 
+// GetFormControlByName provides the method to get the form control in a
+// worksheet by given worksheet name and form control name. For example, get
+// the form control named "Button 1" on Sheet1:
+//
+//	formControl, err := f.GetFormControlByName("Sheet1", "Button 1")
+func (f *File) GetFormControlByName(sheet, name string) (FormControl, error) {
+	formControls, err := f.GetFormControls(sheet)
+	if err != nil {
+		return FormControl{}, err
+	}
+	for _, formControl := range formControls {
+		if formControl.Name == name {
+			return formControl, err
+		}
+	}
+	return FormControl{}, newNoExistFormControlError(name)
+}
+
+// SetFormControlMacro provides the method to assign the macro to the exist
+// form control in a worksheet by given worksheet name, cell reference and
+// macro name, without changing the position and other properties of the form
+// control. Passing an empty macro name will remove the assigned macro. For
+// example, assign the macro "Button1_Click" to the form control in
+// Sheet1!$A$1:
+//
+//	err := f.SetFormControlMacro("Sheet1", "A1", "Button1_Click")
+func (f *File) SetFormControlMacro(sheet, cell, macro string) error {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return err
+	}
+	if len(macro) > MaxFieldLength {
+		return newFieldLengthError("Macro")
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if ws.LegacyDrawing == nil {
+		return newNoExistFormControlError(cell)
+	}
+	drawingVML, vml, err := f.vmlDrawingReader(f.getSheetRelationshipsTargetByID(sheet, ws.LegacyDrawing.RID))
+	if err != nil {
+		return err
+	}
+	var ok bool
+	for i, sp := range vml.Shape {
+		formControl, err := sp.extractFormControl()
+		if err != nil || formControl.Type == FormControlNote || formControl.Cell == "" {
+			continue
+		}
+		if c, r, _ := CellNameToCoordinates(formControl.Cell); c != col || r != row {
+			continue
+		}
+		val, err := setShapeMacro(sp.Val, macro)
+		if err != nil {
+			continue
+		}
+		vml.Shape[i].Val, ok = val, true
+	}
+	if !ok {
+		return newNoExistFormControlError(cell)
+	}
+	f.VMLDrawing[drawingVML] = vml
+	return err
+}
+
+// setShapeMacro provides a function to set the macro in the client data of
+// the VML shape by given inner XML content of the shape and macro name, and
+// returns the new inner XML content of the shape.
+func setShapeMacro(val, macro string) (string, error) {
+	var content decodeVMLShapeContent
+	if err := xml.Unmarshal([]byte("<shape>"+val+"</shape>"), &content); err != nil {
+		return val, err
+	}
+	var buf bytes.Buffer
+	for _, el := range content.Elements {
+		if el.XMLName.Local == "ClientData" {
+			var clientData bytes.Buffer
+			for _, child := range el.Elements {
+				if child.XMLName.Local != "FmlaMacro" {
+					child.writeTo(&clientData)
+				}
+			}
+			if macro != "" {
+				var fmlaMacro bytes.Buffer
+				_ = xml.EscapeText(&fmlaMacro, []byte(macro))
+				decodeVMLElement{
+					XMLName: xml.Name{Space: el.XMLName.Space, Local: "FmlaMacro"},
+					Content: fmlaMacro.String(),
+				}.writeTo(&clientData)
+			}
+			el.Content = clientData.String()
+		}
+		el.writeTo(&buf)
+	}
+	return buf.String(), nil
+}
+
+// writeTo provides a function to serialize the element in the VML shape with
+// the namespace prefix of the element and attributes kept as is.
+func (el decodeVMLElement) writeTo(buf *bytes.Buffer) {
+	qualifiedName := func(name xml.Name) string {
+		if name.Space == "" {
+			return name.Local
+		}
+		return name.Space + ":" + name.Local
+	}
+	name := qualifiedName(el.XMLName)
+	buf.WriteString("<" + name)
+	for _, attr := range el.Attr {
+		buf.WriteString(" " + qualifiedName(attr.Name) + "=\"")
+		_ = xml.EscapeText(buf, []byte(attr.Value))
+		buf.WriteString("\"")
+	}
+	if el.Content == "" {
+		buf.WriteString("/>")
+		return
+	}
+	buf.WriteString(">" + el.Content + "</" + name + ">")
+}
+
+// GetActiveXControls retrieves all ActiveX controls in a worksheet by a given
+// worksheet name, including the name, type, programmatic identifier, OLE class
+// ID, the top-left cell of the control anchor, linked cell and list fill
+// range. The ActiveX control parts in the workbook will be preserved when
+// saving the spreadsheet. For example, get the ActiveX controls on Sheet1:
+//
+//	controls, err := f.GetActiveXControls("Sheet1")
+func (f *File) GetActiveXControls(sheet string) ([]ActiveXControl, error) {
+	var controls []ActiveXControl
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return controls, err
+	}
+	var content strings.Builder
+	if ws.Controls != nil {
+		content.WriteString(ws.Controls.Content)
+	}
+	for _, ac := range ws.AlternateContent {
+		content.WriteString(ac.Content)
+	}
+	for _, ac := range ws.DecodeAlternateContent {
+		content.WriteString(ac.Content)
+	}
+	ctrls, err := extractControls(content.String())
+	if err != nil {
+		return controls, err
+	}
+	for _, ctrl := range ctrls {
+		target := f.getSheetRelationshipsTargetByType(sheet, ctrl.RID, SourceRelationshipControl)
+		if target == "" {
+			continue
+		}
+		control := ActiveXControl{Name: ctrl.Name}
+		if ctrl.ControlPr != nil {
+			control.LinkedCell = ctrl.ControlPr.LinkedCell
+			control.ListFillRange = ctrl.ControlPr.ListFillRange
+			if ctrl.ControlPr.Anchor != nil {
+				from := ctrl.ControlPr.Anchor.From
+				if control.Cell, err = CoordinatesToCellName(from.Col+1, from.Row+1); err != nil {
+					return controls, err
+				}
+			}
+		}
+		var ocx decodeActiveXControl
+		if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(strings.ReplaceAll(target, "..", "xl"))))).
+			Decode(&ocx); err != nil && err != io.EOF {
+			return controls, err
+		}
+		control.ClassID = strings.ToUpper(ocx.ClassID)
+		if types, ok := activeXControlTypes[control.ClassID]; ok {
+			control.Type, control.ProgID = types[0], types[1]
+		}
+		controls = append(controls, control)
+	}
+	return controls, nil
+}
+
+// extractControls provides a function to extract the control elements from
+// the given worksheet controls content. The controls in the choice and
+// fallback of alternate content with the same relationship ID will be merged.
+func extractControls(content string) ([]decodeControl, error) {
+	var (
+		controls []decodeControl
+		idx      = map[string]int{}
+		d        = xml.NewDecoder(strings.NewReader("<controls>" + content + "</controls>"))
+	)
+	for {
+		token, err := d.Token()
+		if err == io.EOF {
+			return controls, nil
+		}
+		if err != nil {
+			return controls, err
+		}
+		se, ok := token.(xml.StartElement)
+		if !ok || se.Name.Local != "control" {
+			continue
+		}
+		var ctrl decodeControl
+		if err = d.DecodeElement(&ctrl, &se); err != nil {
+			return controls, err
+		}
+		if i, ok := idx[ctrl.RID]; ok {
+			if controls[i].ControlPr == nil {
+				controls[i].ControlPr = ctrl.ControlPr
+			}
+			continue
+		}
+		idx[ctrl.RID] = len(controls)
+		controls = append(controls, ctrl)
+	}
+}
+
+// extractFormControl provides a function to extract form control for a
+// worksheet by given VML shape, including the name and alternative text.
+func (sp xlsxShape) extractFormControl() (FormControl, error) {
+	formControl, err := extractFormControl(sp.Val)
+	if err != nil {
+		return formControl, err
+	}
+	formControl.AltText, formControl.Format.AltTextTitle = sp.Alt, sp.Title
+	if sp.Spid != "" {
+		formControl.Name = strings.ReplaceAll(sp.ID, "_x0020_", " ")
+	}
+	return formControl, err
+}
+
 // extractFormControl provides a function to extract form controls for a
 // worksheets by given client data.
 func extractFormControl(clientData string) (FormControl, error) {
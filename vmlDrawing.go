@@ -42,17 +42,21 @@ type xlsxIDmap struct {
 
 // xlsxShape directly maps the shape element.
 type xlsxShape struct {
-	XMLName     xml.Name `xml:"v:shape"`
-	ID          string   `xml:"id,attr"`
-	Type        string   `xml:"type,attr"`
-	Style       string   `xml:"style,attr"`
-	Button      string   `xml:"o:button,attr,omitempty"`
-	Filled      string   `xml:"filled,attr,omitempty"`
-	FillColor   string   `xml:"fillcolor,attr,omitempty"`
-	InsetMode   string   `xml:"urn:schemas-microsoft-com:office:office insetmode,attr,omitempty"`
-	Stroked     string   `xml:"stroked,attr,omitempty"`
-	StrokeColor string   `xml:"strokecolor,attr,omitempty"`
-	Val         string   `xml:",innerxml"`
+	XMLName      xml.Name `xml:"v:shape"`
+	ID           string   `xml:"id,attr"`
+	Spid         string   `xml:"o:spid,attr,omitempty"`
+	Type         string   `xml:"type,attr"`
+	Style        string   `xml:"style,attr"`
+	Alt          string   `xml:"alt,attr,omitempty"`
+	Title        string   `xml:"title,attr,omitempty"`
+	Button       string   `xml:"o:button,attr,omitempty"`
+	Filled       string   `xml:"filled,attr,omitempty"`
+	FillColor    string   `xml:"fillcolor,attr,omitempty"`
+	InsetMode    string   `xml:"urn:schemas-microsoft-com:office:office insetmode,attr,omitempty"`
+	Stroked      string   `xml:"stroked,attr,omitempty"`
+	StrokeColor  string   `xml:"strokecolor,attr,omitempty"`
+	StrokeWeight string   `xml:"strokeweight,attr,omitempty"`
+	Val          string   `xml:",innerxml"`
 }
 
 // xlsxShapeType directly maps the shapetype element.
@@ -170,16 +174,20 @@ type decodeShapeType struct {
 
 // decodeShape defines the structure used to parse the particular shape element.
 type decodeShape struct {
-	ID          string `xml:"id,attr"`
-	Type        string `xml:"type,attr"`
-	Style       string `xml:"style,attr"`
-	Button      string `xml:"button,attr,omitempty"`
-	Filled      string `xml:"filled,attr,omitempty"`
-	FillColor   string `xml:"fillcolor,attr,omitempty"`
-	InsetMode   string `xml:"urn:schemas-microsoft-com:office:office insetmode,attr,omitempty"`
-	Stroked     string `xml:"stroked,attr,omitempty"`
-	StrokeColor string `xml:"strokecolor,attr,omitempty"`
-	Val         string `xml:",innerxml"`
+	ID           string `xml:"id,attr"`
+	Spid         string `xml:"urn:schemas-microsoft-com:office:office spid,attr,omitempty"`
+	Type         string `xml:"type,attr"`
+	Style        string `xml:"style,attr"`
+	Alt          string `xml:"alt,attr,omitempty"`
+	Title        string `xml:"title,attr,omitempty"`
+	Button       string `xml:"button,attr,omitempty"`
+	Filled       string `xml:"filled,attr,omitempty"`
+	FillColor    string `xml:"fillcolor,attr,omitempty"`
+	InsetMode    string `xml:"urn:schemas-microsoft-com:office:office insetmode,attr,omitempty"`
+	Stroked      string `xml:"stroked,attr,omitempty"`
+	StrokeColor  string `xml:"strokecolor,attr,omitempty"`
+	StrokeWeight string `xml:"strokeweight,attr,omitempty"`
+	Val          string `xml:",innerxml"`
 }
 
 // decodeShapeVal defines the structure used to parse the sub-element of the
@@ -248,6 +256,21 @@ type decodeVMLClientData struct {
 	Horiz      *string
 }
 
+// decodeVMLShapeContent defines the structure used to parse the child
+// elements of the VML shape, which keeps the elements for re-serialization.
+type decodeVMLShapeContent struct {
+	Elements []decodeVMLElement `xml:",any"`
+}
+
+// decodeVMLElement defines the structure used to parse the element in the VML
+// shape with the attributes, the raw inner content and the child elements.
+type decodeVMLElement struct {
+	XMLName  xml.Name
+	Attr     []xml.Attr         `xml:",any,attr"`
+	Content  string             `xml:",innerxml"`
+	Elements []decodeVMLElement `xml:",any"`
+}
+
 // encodeShape defines the structure used to re-serialization shape element.
 type encodeShape struct {
 	Fill       *vFill       `xml:"v:fill"`
@@ -285,6 +308,8 @@ type vmlOptions struct {
 // FormControl directly maps the form controls information.
 type FormControl struct {
 	Cell         string
+	Name         string
+	AltText      string
 	Macro        string
 	Width        uint
 	Height       uint
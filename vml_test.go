@@ -17,6 +17,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -128,6 +129,58 @@ func TestDeleteComment(t *testing.T) {
 	assert.NoError(t, f.DeleteComment("Sheet1", "A1"))
 }
 
+func TestAddCommentFormat(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", Comment{
+		Cell:   "A1",
+		Author: "Excelize",
+		Text:   "Excelize: This is a comment.",
+		Fill:   Fill{Color: []string{"4472C4"}},
+		Line:   ShapeLine{Color: "#1F3864", Width: float64Ptr(1.5)},
+		Font:   &Font{Family: "Arial", Color: "FFFFFF"},
+		Shadow: boolPtr(false),
+	}))
+	assert.NoError(t, f.AddComment("Sheet1", Comment{
+		Cell:      "C3",
+		Author:    "Excelize",
+		Paragraph: []RichTextRun{{Text: "Excelize: ", Font: &Font{Bold: true}}, {Text: "This is a comment."}},
+		Fill:      Fill{Color: []string{"#E2EFDA", "FFFFFF"}},
+		Font:      &Font{Family: "Arial"},
+	}))
+	vml := f.VMLDrawing["xl/drawings/vmlDrawing1.vml"]
+	assert.NotNil(t, vml)
+	assert.Len(t, vml.Shape, 2)
+	assert.Equal(t, "#4472C4", vml.Shape[0].FillColor)
+	assert.Equal(t, "#1F3864", vml.Shape[0].StrokeColor)
+	assert.Equal(t, "1.5pt", vml.Shape[0].StrokeWeight)
+	assert.NotContains(t, vml.Shape[0].Val, "v:fill")
+	assert.NotContains(t, vml.Shape[0].Val, "v:shadow")
+	assert.Equal(t, "#E2EFDA", vml.Shape[1].FillColor)
+	assert.Contains(t, vml.Shape[1].Val, `color2="#FFFFFF"`)
+	assert.Contains(t, vml.Shape[1].Val, "v:shadow")
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 2)
+	assert.Equal(t, "Excelize: This is a comment.", comments[0].Paragraph[0].Text)
+	assert.Equal(t, "Arial", comments[0].Paragraph[0].Font.Family)
+	assert.Equal(t, "FFFFFF", comments[0].Paragraph[0].Font.Color)
+	assert.True(t, comments[1].Paragraph[0].Font.Bold)
+	assert.Equal(t, "Arial", comments[1].Paragraph[1].Font.Family)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddCommentFormat.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test keep the format of the exist comments on adding comment
+	f, err = OpenFile(filepath.Join("test", "TestAddCommentFormat.xlsx"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "E5", Text: "Excelize"}))
+	vml = f.VMLDrawing["xl/drawings/vmlDrawing1.vml"]
+	assert.NotNil(t, vml)
+	assert.Len(t, vml.Shape, 3)
+	assert.Equal(t, "1.5pt", vml.Shape[0].StrokeWeight)
+	assert.Empty(t, vml.Shape[2].StrokeWeight)
+	assert.NoError(t, f.Close())
+}
+
 func TestDecodeVMLDrawingReader(t *testing.T) {
 	f := NewFile()
 	path := "xl/drawings/vmlDrawing1.xml"
@@ -275,6 +328,11 @@ func TestFormControl(t *testing.T) {
 		Cell: "A1", Type: FormControlButton,
 		Format: GraphicOptions{Positioning: "x"},
 	}), ErrParameterInvalid)
+	// Test add form control with unsupported absolute position and size
+	assert.Equal(t, ErrParameterInvalid, f.AddFormControl("Sheet1", FormControl{
+		Cell: "A1", Type: FormControlButton,
+		Format: GraphicOptions{Absolute: true, Width: 1},
+	}))
 	// Test add spin form control with illegal cell link reference
 	assert.Equal(t, f.AddFormControl("Sheet1", FormControl{
 		Cell: "C5", Type: FormControlSpinButton, CellLink: "*",
@@ -412,3 +470,138 @@ func TestExtractFormControl(t *testing.T) {
 	_, err := extractFormControl(string(MacintoshCyrillicCharset))
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
+
+func TestGetActiveXControls(t *testing.T) {
+	f := NewFile()
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.checked = sync.Map{}
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(xml.Header+`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" xmlns:mc="http://schemas.openxmlformats.org/markup-compatibility/2006" xmlns:xdr="http://schemas.openxmlformats.org/drawingml/2006/spreadsheetDrawing"><sheetData/><mc:AlternateContent><mc:Choice Requires="x14"><oleObjects/></mc:Choice></mc:AlternateContent><mc:AlternateContent><mc:Choice Requires="x14"><controls><mc:AlternateContent><mc:Choice Requires="x14"><control shapeId="1025" r:id="rId2" name="CheckBox1"><controlPr defaultSize="0" autoLine="0" linkedCell="A1" r:id="rId3"><anchor moveWithCells="1"><from><xdr:col>1</xdr:col><xdr:colOff>0</xdr:colOff><xdr:row>2</xdr:row><xdr:rowOff>0</xdr:rowOff></from><to><xdr:col>3</xdr:col><xdr:colOff>0</xdr:colOff><xdr:row>4</xdr:row><xdr:rowOff>0</xdr:rowOff></to></anchor></controlPr></control></mc:Choice><mc:Fallback><control shapeId="1025" r:id="rId2" name="CheckBox1"/></mc:Fallback></mc:AlternateContent><control shapeId="1026" r:id="rId4" name="Button 2"/></controls></mc:Choice></mc:AlternateContent></worksheet>`))
+	f.Pkg.Store("xl/worksheets/_rels/sheet1.xml.rels", []byte(xml.Header+`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/control" Target="../activeX/activeX1.xml"/><Relationship Id="rId4" Type="http://schemas.microsoft.com/office/2007/relationships/ctrlProp" Target="../ctrlProps/ctrlProp1.xml"/></Relationships>`))
+	f.Pkg.Store("xl/activeX/activeX1.xml", []byte(xml.Header+`<ax:ocx ax:classid="{8BD21D40-EC42-11CE-9E0D-00AA006002F3}" ax:persistence="persistStorage" r:id="rId1" xmlns:ax="http://schemas.microsoft.com/office/2006/activeX" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"/>`))
+	expected := []ActiveXControl{{
+		Cell: "B3", Name: "CheckBox1", Type: "CheckBox", ProgID: "Forms.CheckBox.1",
+		ClassID: "{8BD21D40-EC42-11CE-9E0D-00AA006002F3}", LinkedCell: "A1",
+	}}
+	controls, err := f.GetActiveXControls("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, controls)
+	// Test preserve the ActiveX controls and OLE objects after saving
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", true))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetActiveXControls.xlsm")))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestGetActiveXControls.xlsm"))
+	assert.NoError(t, err)
+	controls, err = f.GetActiveXControls("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, controls)
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Len(t, ws.(*xlsxWorksheet).DecodeAlternateContent, 2)
+	// Test get ActiveX controls with not exist worksheet
+	_, err = f.GetActiveXControls("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get ActiveX controls with invalid controls content
+	ws.(*xlsxWorksheet).Controls = &xlsxInnerXML{Content: "<control"}
+	_, err = f.GetActiveXControls("Sheet1")
+	assert.Error(t, err)
+	// Test get ActiveX controls with invalid anchor
+	ws.(*xlsxWorksheet).Controls = &xlsxInnerXML{Content: `<control r:id="rId5"><controlPr><anchor><from><col>-1</col></from></anchor></controlPr></control>`}
+	ws.(*xlsxWorksheet).DecodeAlternateContent = nil
+	f.Relationships.Delete("xl/worksheets/_rels/sheet1.xml.rels")
+	f.Pkg.Store("xl/worksheets/_rels/sheet1.xml.rels", []byte(xml.Header+`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId5" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/control" Target="../activeX/activeX1.xml"/></Relationships>`))
+	_, err = f.GetActiveXControls("Sheet1")
+	assert.Equal(t, newCoordinatesToCellNameError(0, 1), err)
+	// Test get ActiveX controls with unsupported charset ActiveX part
+	ws.(*xlsxWorksheet).Controls = &xlsxInnerXML{Content: `<control r:id="rId5"/>`}
+	f.Pkg.Store("xl/activeX/activeX1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetActiveXControls("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestFormControlMacroAndName(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddFormControl("Sheet1", FormControl{
+		Cell: "A1", Type: FormControlButton, Macro: "Button1_Click",
+		Name: "Button 1", AltText: "Submit the form", Text: "Submit",
+		Format: GraphicOptions{AltTextTitle: "Submit"},
+	}))
+	assert.NoError(t, f.AddFormControl("Sheet1", FormControl{
+		Cell: "C1", Type: FormControlCheckBox, Text: "Check Box 1",
+	}))
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "E1", Text: "Comment"}))
+	// Test get form control by name
+	formControl, err := f.GetFormControlByName("Sheet1", "Button 1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1", formControl.Cell)
+	assert.Equal(t, "Button1_Click", formControl.Macro)
+	assert.Equal(t, "Submit the form", formControl.AltText)
+	_, err = f.GetFormControlByName("Sheet1", "Button 2")
+	assert.EqualError(t, err, "form control Button 2 does not exist")
+	_, err = f.GetFormControlByName("SheetN", "Button 1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test retarget the macro of the exist form controls
+	assert.NoError(t, f.SetFormControlMacro("Sheet1", "A1", "'Book & Co.xlsm'!Module1.Submit"))
+	assert.NoError(t, f.SetFormControlMacro("Sheet1", "C1", "CheckBox1_Click"))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestFormControlMacroAndName.xlsm")))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestFormControlMacroAndName.xlsm"))
+	assert.NoError(t, err)
+	formControls, err := f.GetFormControls("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, formControls, 2)
+	assert.Equal(t, "Button 1", formControls[0].Name)
+	assert.Equal(t, "Submit the form", formControls[0].AltText)
+	assert.Equal(t, "Submit", formControls[0].Format.AltTextTitle)
+	assert.Equal(t, "'Book & Co.xlsm'!Module1.Submit", formControls[0].Macro)
+	assert.Equal(t, "CheckBox1_Click", formControls[1].Macro)
+	// Test remove the macro of the form control
+	assert.NoError(t, f.SetFormControlMacro("Sheet1", "C1", ""))
+	formControls, err = f.GetFormControls("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, formControls[1].Macro)
+	// Test set macro on the cell without form control
+	assert.EqualError(t, f.SetFormControlMacro("Sheet1", "E1", "Macro1"), "form control E1 does not exist")
+	// Test set macro with invalid arguments
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetFormControlMacro("Sheet1", "A", "Macro1"))
+	assert.EqualError(t, f.SetFormControlMacro("Sheet1", "A1", strings.Repeat("c", MaxFieldLength+1)), "field Macro must be less than or equal to 255 characters")
+	for _, macro := range []string{"Macro1", "Module1.Submit&Close", "[0]!Macro1", "Book1.xlsm!Macro1", "Модуль1.Макрос1"} {
+		assert.NoError(t, f.SetFormControlMacro("Sheet1", "A1", macro), macro)
+		formControl, err = f.GetFormControlByName("Sheet1", "Button 1")
+		assert.NoError(t, err)
+		assert.Equal(t, macro, formControl.Macro)
+		assert.Equal(t, "Submit", formControl.Text)
+	}
+	// Test add form control with the exist name
+	assert.Equal(t, ErrExistsFormControlName, f.AddFormControl("Sheet1", FormControl{
+		Cell: "A3", Type: FormControlButton, Name: "Button 1",
+	}))
+	// Test add form controls with unique shape IDs
+	assert.NoError(t, f.AddFormControl("Sheet1", FormControl{
+		Cell: "A5", Type: FormControlButton, Name: "Button 2",
+	}))
+	vml := f.VMLDrawing["xl/drawings/vmlDrawing1.vml"]
+	assert.Equal(t, "_x0000_s1025", vml.Shape[0].Spid)
+	assert.Equal(t, "_x0000_s1026", vml.Shape[len(vml.Shape)-1].Spid)
+	// Test set macro with invalid VML shape content
+	_, err = setShapeMacro("<x:ClientData>", "Macro1")
+	assert.Error(t, err)
+	val, err := setShapeMacro(`<v:textbox style="a"><div>Text</div></v:textbox><x:ClientData ObjectType="Button"><x:Anchor>0, 0, 0, 0, 1, 0, 1, 0</x:Anchor><x:FmlaMacro>Old</x:FmlaMacro><x:AutoFill>False</x:AutoFill></x:ClientData>`, "New&Macro")
+	assert.NoError(t, err)
+	assert.Equal(t, `<v:textbox style="a"><div>Text</div></v:textbox><x:ClientData ObjectType="Button"><x:Anchor>0, 0, 0, 0, 1, 0, 1, 0</x:Anchor><x:AutoFill>False</x:AutoFill><x:FmlaMacro>New&amp;Macro</x:FmlaMacro></x:ClientData>`, val)
+	assert.EqualError(t, f.AddFormControl("Sheet1", FormControl{
+		Cell: "A3", Type: FormControlButton, Macro: strings.Repeat("c", MaxFieldLength+1),
+	}), "field Macro must be less than or equal to 255 characters")
+	assert.EqualError(t, f.SetFormControlMacro("SheetN", "A1", "Macro1"), "sheet SheetN does not exist")
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.EqualError(t, f.SetFormControlMacro("Sheet2", "A1", "Macro1"), "form control A1 does not exist")
+	assert.NoError(t, f.Close())
+	// Test set macro with unsupported charset VML drawing
+	f = NewFile()
+	assert.NoError(t, f.AddFormControl("Sheet1", FormControl{Cell: "A1", Type: FormControlButton}))
+	f.VMLDrawing["xl/drawings/vmlDrawing1.vml"] = nil
+	f.Pkg.Store("xl/drawings/vmlDrawing1.vml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetFormControlMacro("Sheet1", "A1", "Macro1"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
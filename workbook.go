@@ -14,13 +14,28 @@ package excelize
 import (
 	"bytes"
 	"encoding/xml"
+	"errors"
 	"io"
 	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
+	"unicode/utf8"
+
+	"github.com/xuri/efp"
 )
 
-// SetWorkbookProps provides a function to sets workbook properties.
+// SetWorkbookProps provides a function to sets workbook properties. The
+// optional field AbsPath specified the absolute path of the location where the
+// workbook was last saved, set it with an empty string to remove the path
+// information from the workbook. For example, remove the absolute path and
+// filter the personal information of the workbook:
+//
+//	enable, absPath := true, ""
+//	err := f.SetWorkbookProps(&excelize.WorkbookPropsOptions{
+//	    FilterPrivacy: &enable,
+//	    AbsPath:       &absPath,
+//	})
 func (f *File) SetWorkbookProps(opts *WorkbookPropsOptions) error {
 	wb, err := f.workbookReader()
 	if err != nil {
@@ -41,9 +56,61 @@ func (f *File) SetWorkbookProps(opts *WorkbookPropsOptions) error {
 	if opts.CodeName != nil {
 		wb.WorkbookPr.CodeName = *opts.CodeName
 	}
+	if opts.DefaultThemeVersion != nil {
+		wb.WorkbookPr.DefaultThemeVersion = ""
+		if *opts.DefaultThemeVersion > 0 {
+			wb.WorkbookPr.DefaultThemeVersion = strconv.Itoa(*opts.DefaultThemeVersion)
+		}
+	}
+	if opts.AbsPath != nil {
+		wb.setAbsPath(*opts.AbsPath)
+	}
 	return nil
 }
 
+// getAlternateContent provides a function to get the inner XML content of the
+// AlternateContent element of the workbook.
+func (wb *xlsxWorkbook) getAlternateContent() string {
+	if wb.DecodeAlternateContent != nil {
+		return wb.DecodeAlternateContent.Content
+	}
+	if wb.AlternateContent != nil {
+		return wb.AlternateContent.Content
+	}
+	return ""
+}
+
+// getAbsPath provides a function to get the absolute path of the location
+// where the workbook was last saved, returns false if which not exists.
+func (wb *xlsxWorkbook) getAbsPath() (string, bool) {
+	var decode decodeWorkbookAlternateContent
+	content := wb.getAlternateContent()
+	if content == "" {
+		return "", false
+	}
+	if err := xml.Unmarshal([]byte("<AlternateContent>"+content+"</AlternateContent>"), &decode); err != nil ||
+		decode.Choice.AbsPath == nil {
+		return "", false
+	}
+	return decode.Choice.AbsPath.URL, true
+}
+
+// setAbsPath provides a function to set the absolute path of the location
+// where the workbook was last saved, the absolute path will be removed if
+// given an empty path.
+func (wb *xlsxWorkbook) setAbsPath(absPath string) {
+	if _, ok := wb.getAbsPath(); !ok && wb.getAlternateContent() != "" {
+		return
+	}
+	wb.AlternateContent, wb.DecodeAlternateContent = nil, nil
+	if absPath == "" {
+		return
+	}
+	path, _ := xml.Marshal(xlsxAbsPath{XMLNSX15AC: NameSpaceSpreadSheetX15AC.Value, URL: absPath})
+	choice, _ := xml.Marshal(xlsxChoice{Requires: "x15", Content: string(path)})
+	wb.DecodeAlternateContent = &xlsxInnerXML{Content: string(choice)}
+}
+
 // GetWorkbookProps provides a function to gets workbook properties.
 func (f *File) GetWorkbookProps() (WorkbookPropsOptions, error) {
 	var opts WorkbookPropsOptions
@@ -55,10 +122,124 @@ func (f *File) GetWorkbookProps() (WorkbookPropsOptions, error) {
 		opts.Date1904 = boolPtr(wb.WorkbookPr.Date1904)
 		opts.FilterPrivacy = boolPtr(wb.WorkbookPr.FilterPrivacy)
 		opts.CodeName = stringPtr(wb.WorkbookPr.CodeName)
+		if version, _ := strconv.Atoi(wb.WorkbookPr.DefaultThemeVersion); version > 0 {
+			opts.DefaultThemeVersion = intPtr(version)
+		}
+	}
+	if absPath, ok := wb.getAbsPath(); ok {
+		opts.AbsPath = stringPtr(absPath)
 	}
 	return opts, err
 }
 
+// SetCalcProps provides a function to sets calculation properties. The
+// CalcMode specifies the calculation mode, the possible values are "manual",
+// "auto" and "autoNoTable", and the RefMode specifies the reference style,
+// the possible values are "A1" and "R1C1". Set the FullCalcOnLoad to force
+// the spreadsheet application to recalculate all formulas when the workbook
+// has been opened, this is useful for the workbook containing volatile
+// functions such as NOW or RAND. For example:
+//
+//	err := f.SetCalcProps(&excelize.CalcPropsOptions{
+//	    FullCalcOnLoad: &fullCalcOnLoad,
+//	})
+func (f *File) SetCalcProps(opts *CalcPropsOptions) error {
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	if opts == nil {
+		return err
+	}
+	if opts.CalcMode != nil && inStrSlice([]string{"manual", "auto", "autoNoTable"}, *opts.CalcMode, true) == -1 {
+		return ErrParameterInvalid
+	}
+	if opts.RefMode != nil && inStrSlice([]string{"A1", "R1C1"}, *opts.RefMode, true) == -1 {
+		return ErrParameterInvalid
+	}
+	if wb.CalcPr == nil {
+		wb.CalcPr = new(xlsxCalcPr)
+	}
+	calcPr := wb.CalcPr
+	if opts.CalcID != nil {
+		calcPr.CalcID = strconv.FormatUint(uint64(*opts.CalcID), 10)
+	}
+	if opts.CalcMode != nil {
+		calcPr.CalcMode = *opts.CalcMode
+	}
+	if opts.FullCalcOnLoad != nil {
+		calcPr.FullCalcOnLoad = *opts.FullCalcOnLoad
+	}
+	if opts.RefMode != nil {
+		calcPr.RefMode = *opts.RefMode
+	}
+	if opts.Iterate != nil {
+		calcPr.Iterate = *opts.Iterate
+	}
+	if opts.IterateCount != nil {
+		calcPr.IterateCount = int(*opts.IterateCount)
+	}
+	if opts.IterateDelta != nil {
+		calcPr.IterateDelta = *opts.IterateDelta
+	}
+	if opts.FullPrecision != nil {
+		calcPr.FullPrecision = boolPtr(*opts.FullPrecision)
+	}
+	if opts.CalcCompleted != nil {
+		calcPr.CalcCompleted = boolPtr(*opts.CalcCompleted)
+	}
+	if opts.CalcOnSave != nil {
+		calcPr.CalcOnSave = boolPtr(*opts.CalcOnSave)
+	}
+	if opts.ConcurrentCalc != nil {
+		calcPr.ConcurrentCalc = boolPtr(*opts.ConcurrentCalc)
+	}
+	if opts.ConcurrentManualCount != nil {
+		calcPr.ConcurrentManualCount = int(*opts.ConcurrentManualCount)
+	}
+	if opts.ForceFullCalc != nil {
+		calcPr.ForceFullCalc = *opts.ForceFullCalc
+	}
+	return err
+}
+
+// GetCalcProps provides a function to gets calculation properties.
+func (f *File) GetCalcProps() (CalcPropsOptions, error) {
+	var opts CalcPropsOptions
+	wb, err := f.workbookReader()
+	if err != nil || wb.CalcPr == nil {
+		return opts, err
+	}
+	calcPr := wb.CalcPr
+	if calcID, err := strconv.ParseUint(calcPr.CalcID, 10, 32); err == nil {
+		opts.CalcID = uintPtr(uint(calcID))
+	}
+	opts.CalcMode = stringPtr(calcPr.CalcMode)
+	if calcPr.CalcMode == "" {
+		opts.CalcMode = stringPtr("auto")
+	}
+	opts.FullCalcOnLoad = boolPtr(calcPr.FullCalcOnLoad)
+	opts.RefMode = stringPtr(calcPr.RefMode)
+	if calcPr.RefMode == "" {
+		opts.RefMode = stringPtr("A1")
+	}
+	opts.Iterate = boolPtr(calcPr.Iterate)
+	opts.IterateCount, opts.IterateDelta = uintPtr(100), float64Ptr(0.001)
+	if calcPr.IterateCount > 0 {
+		opts.IterateCount = uintPtr(uint(calcPr.IterateCount))
+	}
+	if calcPr.IterateDelta > 0 {
+		opts.IterateDelta = float64Ptr(calcPr.IterateDelta)
+	}
+	opts.FullPrecision = boolPtr(calcPr.FullPrecision == nil || *calcPr.FullPrecision)
+	opts.CalcCompleted = boolPtr(calcPr.CalcCompleted == nil || *calcPr.CalcCompleted)
+	opts.CalcOnSave = boolPtr(calcPr.CalcOnSave == nil || *calcPr.CalcOnSave)
+	opts.ConcurrentCalc = boolPtr(calcPr.ConcurrentCalc == nil || *calcPr.ConcurrentCalc)
+	opts.ConcurrentManualCount = uintPtr(uint(calcPr.ConcurrentManualCount))
+	opts.ForceFullCalc = boolPtr(calcPr.ForceFullCalc)
+	return opts, err
+}
+
 // ProtectWorkbook provides a function to prevent other users from viewing
 // hidden worksheets, adding, moving, deleting, or hiding worksheets, and
 // renaming worksheets in a workbook. The optional field AlgorithmName
@@ -141,6 +322,164 @@ func (f *File) setWorkbook(name string, sheetID, rid int) {
 	})
 }
 
+// externalLinkIndex defined the regular expression for matching the index of
+// the external workbook reference in the formula, such as "[1]".
+var externalLinkIndex = regexp.MustCompile(`\[\d+\]`)
+
+// volatileFunctions defined the functions which will be recalculated whenever
+// the spreadsheet application calculates the workbook.
+var volatileFunctions = []string{"CELL", "INDIRECT", "INFO", "NOW", "OFFSET", "RAND", "RANDARRAY", "RANDBETWEEN", "TODAY"}
+
+// AuditFormulas provides a function to audit the formulas of the cells in all
+// worksheets and the defined names of the workbook, which is useful for the
+// compliance review of the spreadsheet before distribution. The report lists
+// the formulas which reference to external workbooks, use volatile functions,
+// reference to the hidden worksheets or exceed the formula length limit of
+// 8192 characters. For example:
+//
+//	report, err := f.AuditFormulas()
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, item := range report.ExternalLinks {
+//	    fmt.Println(item.Sheet, item.Cell, item.Formula, item.Detail)
+//	}
+func (f *File) AuditFormulas() (FormulaAuditReport, error) {
+	var report FormulaAuditReport
+	wb, err := f.workbookReader()
+	if err != nil {
+		return report, err
+	}
+	hiddenSheets := map[string]bool{}
+	for _, sheet := range wb.Sheets.Sheet {
+		if sheet.State == "hidden" || sheet.State == "veryHidden" {
+			hiddenSheets[strings.ToUpper(sheet.Name)] = true
+		}
+	}
+	externalLinks := f.getExternalLinkTargets()
+	definedNames := f.GetDefinedName()
+	for _, sheet := range f.GetSheetList() {
+		ws, err := f.workSheetReader(sheet)
+		if err != nil {
+			if errors.Is(err, ErrNotWorksheet{SheetName: sheet}) {
+				continue
+			}
+			return report, err
+		}
+		ws.mu.Lock()
+		for _, r := range ws.SheetData.Row {
+			for _, c := range r.C {
+				if c.F == nil {
+					continue
+				}
+				formula := c.F.Content
+				if c.F.T == STCellFormulaTypeShared && c.F.Si != nil {
+					formula = getSharedFormula(ws, *c.F.Si, c.R)
+				}
+				if formula != "" {
+					report.audit(FormulaAuditItem{Sheet: sheet, Cell: c.R, Formula: formula},
+						hiddenSheets, externalLinks, definedNames)
+				}
+			}
+		}
+		ws.mu.Unlock()
+	}
+	for _, dn := range definedNames {
+		report.audit(FormulaAuditItem{Sheet: dn.Scope, DefinedName: dn.Name, Formula: dn.RefersTo},
+			hiddenSheets, externalLinks, definedNames)
+	}
+	return report, err
+}
+
+// audit provides a function to check the formula and append the findings to
+// the formula auditing report by given formula auditing item, hidden
+// worksheets, external workbooks and defined names.
+func (report *FormulaAuditReport) audit(item FormulaAuditItem, hiddenSheets map[string]bool, externalLinks map[string]string, definedNames []DefinedName) {
+	var (
+		ps      = efp.ExcelParser()
+		details = map[*[]FormulaAuditItem]map[string]bool{}
+		add     = func(items *[]FormulaAuditItem, detail string) {
+			if details[items] == nil {
+				details[items] = map[string]bool{}
+			}
+			if !details[items][detail] {
+				details[items][detail] = true
+				finding := item
+				finding.Detail = detail
+				*items = append(*items, finding)
+			}
+		}
+		formula = strings.TrimPrefix(item.Formula, "=")
+	)
+	if length := utf8.RuneCountInString(formula); length > MaxFormulaLength {
+		add(&report.LongFormulas, strconv.Itoa(length))
+	}
+	for _, token := range ps.Parse(formula) {
+		if token.TType == efp.TokenTypeFunction && token.TSubType == efp.TokenSubTypeStart {
+			name := strings.ToUpper(strings.TrimPrefix(token.TValue, "_xlfn."))
+			if inStrSlice(volatileFunctions, name, true) != -1 {
+				add(&report.VolatileFunctions, name)
+			}
+		}
+		if token.TType != efp.TokenTypeOperand || token.TSubType != efp.TokenSubTypeRange ||
+			!externalReferenceFormat.MatchString(token.TValue) {
+			continue
+		}
+		detail := token.TValue
+		if i := strings.LastIndex(detail, "!"); i != -1 {
+			detail = strings.Trim(detail[:i], "'")
+		}
+		if target, ok := externalLinks[externalLinkIndex.FindString(detail)]; ok {
+			detail = target
+		}
+		add(&report.ExternalLinks, detail)
+	}
+	sheet := item.Sheet
+	if item.DefinedName != "" && sheet == "Workbook" {
+		sheet = ""
+	}
+	for _, cr := range getFormulaRefs(sheet, formula, definedNames) {
+		if hiddenSheets[strings.ToUpper(cr.From.Sheet)] && !strings.EqualFold(cr.From.Sheet, sheet) {
+			add(&report.HiddenSheetRefs, cr.From.Sheet)
+		}
+	}
+}
+
+// getExternalLinkTargets provides a function to get the targets of the
+// external workbook references, the keys of the result are the index of the
+// external workbook references in the formula, such as "[1]".
+func (f *File) getExternalLinkTargets() map[string]string {
+	targets := map[string]string{}
+	wb, _ := f.workbookReader()
+	if wb.ExternalReferences == nil {
+		return targets
+	}
+	rels, _ := f.relsReader(f.getWorkbookRelsPath())
+	if rels == nil {
+		return targets
+	}
+	rels.mu.Lock()
+	defer rels.mu.Unlock()
+	for idx, ref := range wb.ExternalReferences.ExternalReference {
+		for _, rel := range rels.Relationships {
+			if rel.ID != ref.RID {
+				continue
+			}
+			linkRels, _ := f.relsReader(getRelsPath(getRelsPartPath(f.getWorkbookPath(), rel.Target)))
+			if linkRels == nil {
+				continue
+			}
+			for _, linkRel := range linkRels.Relationships {
+				if linkRel.TargetMode == "External" {
+					targets["["+strconv.Itoa(idx+1)+"]"] = linkRel.Target
+				}
+			}
+		}
+	}
+	return targets
+}
+
 // getWorkbookPath provides a function to get the path of the workbook.xml in
 // the spreadsheet.
 func (f *File) getWorkbookPath() (path string) {
@@ -310,28 +649,38 @@ func (f *File) addContentTypePart(index int, contentType string) error {
 		"drawings": f.setContentTypePartImageExtensions,
 	}
 	partNames := map[string]string{
-		"chart":         "/xl/charts/chart" + strconv.Itoa(index) + ".xml",
-		"chartsheet":    "/xl/chartsheets/sheet" + strconv.Itoa(index) + ".xml",
-		"comments":      "/xl/comments" + strconv.Itoa(index) + ".xml",
-		"drawings":      "/xl/drawings/drawing" + strconv.Itoa(index) + ".xml",
-		"table":         "/xl/tables/table" + strconv.Itoa(index) + ".xml",
-		"pivotTable":    "/xl/pivotTables/pivotTable" + strconv.Itoa(index) + ".xml",
-		"pivotCache":    "/xl/pivotCache/pivotCacheDefinition" + strconv.Itoa(index) + ".xml",
-		"sharedStrings": "/xl/sharedStrings.xml",
-		"slicer":        "/xl/slicers/slicer" + strconv.Itoa(index) + ".xml",
-		"slicerCache":   "/xl/slicerCaches/slicerCache" + strconv.Itoa(index) + ".xml",
+		"calcChain":          "/" + defaultXMLPathCalcChain,
+		"chart":              "/xl/charts/chart" + strconv.Itoa(index) + ".xml",
+		"chartsheet":         "/xl/chartsheets/sheet" + strconv.Itoa(index) + ".xml",
+		"comments":           "/xl/comments" + strconv.Itoa(index) + ".xml",
+		"customProperty":     "/xl/customProperty" + strconv.Itoa(index) + ".bin",
+		"drawings":           "/xl/drawings/drawing" + strconv.Itoa(index) + ".xml",
+		"featurePropertyBag": "/" + defaultXMLPathFeaturePropertyBag,
+		"metadata":           "/" + defaultXMLMetadata,
+		"table":              "/xl/tables/table" + strconv.Itoa(index) + ".xml",
+		"pivotTable":         "/xl/pivotTables/pivotTable" + strconv.Itoa(index) + ".xml",
+		"pivotCache":         "/xl/pivotCache/pivotCacheDefinition" + strconv.Itoa(index) + ".xml",
+		"printerSettings":    "/xl/printerSettings/printerSettings" + strconv.Itoa(index) + ".bin",
+		"sharedStrings":      "/xl/sharedStrings.xml",
+		"slicer":             "/xl/slicers/slicer" + strconv.Itoa(index) + ".xml",
+		"slicerCache":        "/xl/slicerCaches/slicerCache" + strconv.Itoa(index) + ".xml",
 	}
 	contentTypes := map[string]string{
-		"chart":         ContentTypeDrawingML,
-		"chartsheet":    ContentTypeSpreadSheetMLChartsheet,
-		"comments":      ContentTypeSpreadSheetMLComments,
-		"drawings":      ContentTypeDrawing,
-		"table":         ContentTypeSpreadSheetMLTable,
-		"pivotTable":    ContentTypeSpreadSheetMLPivotTable,
-		"pivotCache":    ContentTypeSpreadSheetMLPivotCacheDefinition,
-		"sharedStrings": ContentTypeSpreadSheetMLSharedStrings,
-		"slicer":        ContentTypeSlicer,
-		"slicerCache":   ContentTypeSlicerCache,
+		"calcChain":          ContentTypeSpreadSheetMLCalcChain,
+		"chart":              ContentTypeDrawingML,
+		"chartsheet":         ContentTypeSpreadSheetMLChartsheet,
+		"comments":           ContentTypeSpreadSheetMLComments,
+		"customProperty":     ContentTypeSpreadSheetMLCustomProperty,
+		"drawings":           ContentTypeDrawing,
+		"featurePropertyBag": ContentTypeFeaturePropertyBag,
+		"metadata":           ContentTypeSpreadSheetMLMetadata,
+		"table":              ContentTypeSpreadSheetMLTable,
+		"pivotTable":         ContentTypeSpreadSheetMLPivotTable,
+		"pivotCache":         ContentTypeSpreadSheetMLPivotCacheDefinition,
+		"printerSettings":    ContentTypeSpreadSheetMLPrinterSettings,
+		"sharedStrings":      ContentTypeSpreadSheetMLSharedStrings,
+		"slicer":             ContentTypeSlicer,
+		"slicerCache":        ContentTypeSlicerCache,
 	}
 	s, ok := setContentType[contentType]
 	if ok {
@@ -1,6 +1,9 @@
 package excelize
 
 import (
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -21,6 +24,43 @@ func TestWorkbookProps(t *testing.T) {
 	opts, err := f.GetWorkbookProps()
 	assert.NoError(t, err)
 	assert.Equal(t, expected, opts)
+	// Test set default theme version and absolute path of the workbook
+	assert.NoError(t, f.SetWorkbookProps(&WorkbookPropsOptions{DefaultThemeVersion: intPtr(166925), AbsPath: stringPtr(`C:\Users\build\`)}))
+	opts, err = f.GetWorkbookProps()
+	assert.NoError(t, err)
+	assert.Equal(t, 166925, *opts.DefaultThemeVersion)
+	assert.Equal(t, `C:\Users\build\`, *opts.AbsPath)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestWorkbookProps.xlsx")))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestWorkbookProps.xlsx"))
+	assert.NoError(t, err)
+	opts, err = f.GetWorkbookProps()
+	assert.NoError(t, err)
+	assert.Equal(t, 166925, *opts.DefaultThemeVersion)
+	assert.Equal(t, `C:\Users\build\`, *opts.AbsPath)
+	// Test clear default theme version and absolute path of the workbook
+	assert.NoError(t, f.SetWorkbookProps(&WorkbookPropsOptions{DefaultThemeVersion: intPtr(0), AbsPath: stringPtr("")}))
+	opts, err = f.GetWorkbookProps()
+	assert.NoError(t, err)
+	assert.Nil(t, opts.DefaultThemeVersion)
+	assert.Nil(t, opts.AbsPath)
+	f.workBookWriter()
+	workbook, ok := f.Pkg.Load(defaultXMLPathWorkbook)
+	assert.True(t, ok)
+	assert.NotContains(t, string(workbook.([]byte)), "absPath")
+	assert.NoError(t, f.Close())
+	// Test set absolute path with the alternate content without absolute path
+	f = NewFile()
+	wb, err = f.workbookReader()
+	assert.NoError(t, err)
+	wb.DecodeAlternateContent = &xlsxInnerXML{Content: `<mc:Choice Requires="x15"><x15ac:unknown/></mc:Choice>`}
+	assert.NoError(t, f.SetWorkbookProps(&WorkbookPropsOptions{AbsPath: stringPtr("")}))
+	assert.NotNil(t, wb.DecodeAlternateContent)
+	// Test get absolute path with invalid alternate content
+	wb.DecodeAlternateContent = &xlsxInnerXML{Content: "<mc:Choice"}
+	opts, err = f.GetWorkbookProps()
+	assert.NoError(t, err)
+	assert.Nil(t, opts.AbsPath)
 	// Test set workbook properties with unsupported charset workbook
 	f.WorkBook = nil
 	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
@@ -32,6 +72,55 @@ func TestWorkbookProps(t *testing.T) {
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestCalcProps(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCalcProps(nil))
+	opts, err := f.GetCalcProps()
+	assert.NoError(t, err)
+	assert.Equal(t, "auto", *opts.CalcMode)
+	assert.Equal(t, "A1", *opts.RefMode)
+	assert.True(t, *opts.CalcOnSave)
+	assert.Equal(t, uint(100), *opts.IterateCount)
+	expected := CalcPropsOptions{
+		CalcID:                uintPtr(191029),
+		CalcMode:              stringPtr("manual"),
+		FullCalcOnLoad:        boolPtr(true),
+		RefMode:               stringPtr("R1C1"),
+		Iterate:               boolPtr(true),
+		IterateCount:          uintPtr(10),
+		IterateDelta:          float64Ptr(0.0001),
+		FullPrecision:         boolPtr(false),
+		CalcCompleted:         boolPtr(false),
+		CalcOnSave:            boolPtr(false),
+		ConcurrentCalc:        boolPtr(false),
+		ConcurrentManualCount: uintPtr(4),
+		ForceFullCalc:         boolPtr(true),
+	}
+	assert.NoError(t, f.SetCalcProps(&expected))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCalcProps.xlsx")))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestCalcProps.xlsx"))
+	assert.NoError(t, err)
+	opts, err = f.GetCalcProps()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, opts)
+	// Test set calculation properties with invalid calculation mode
+	assert.Equal(t, ErrParameterInvalid, f.SetCalcProps(&CalcPropsOptions{CalcMode: stringPtr("unknown")}))
+	// Test set calculation properties with invalid reference mode
+	assert.Equal(t, ErrParameterInvalid, f.SetCalcProps(&CalcPropsOptions{RefMode: stringPtr("unknown")}))
+	assert.NoError(t, f.Close())
+	// Test set calculation properties with unsupported charset workbook
+	f = NewFile()
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCalcProps(&expected), "XML syntax error on line 1: invalid UTF-8")
+	// Test get calculation properties with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	_, err = f.GetCalcProps()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestDeleteWorkbookRels(t *testing.T) {
 	f := NewFile()
 	// Test delete pivot table without worksheet relationships
@@ -41,3 +130,84 @@ func TestDeleteWorkbookRels(t *testing.T) {
 	assert.Empty(t, rID)
 	assert.NoError(t, err)
 }
+
+func TestAuditFormulas(t *testing.T) {
+	f := NewFile()
+	report, err := f.AuditFormulas()
+	assert.NoError(t, err)
+	assert.Equal(t, FormulaAuditReport{}, report)
+	for _, sheet := range []string{"Sheet2", "Sheet3"} {
+		_, err = f.NewSheet(sheet)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, f.SetSheetVisible("Sheet2", false))
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Name: "Sheet1!$A$1", Values: "Sheet1!$B$1:$B$2"}},
+	}))
+	// Prepare external workbook reference
+	wb, err := f.workbookReader()
+	assert.NoError(t, err)
+	wb.ExternalReferences = &xlsxExternalReferences{ExternalReference: []xlsxExternalReference{{RID: "rId10"}, {RID: "rId11"}}}
+	rels, err := f.relsReader(defaultXMLPathWorkbookRels)
+	assert.NoError(t, err)
+	rels.Relationships = append(rels.Relationships,
+		xlsxRelationship{ID: "rId10", Target: "externalLinks/externalLink1.xml"},
+		xlsxRelationship{ID: "rId11", Target: "/xl/externalLinks/externalLink2.xml"},
+	)
+	f.Pkg.Store("xl/externalLinks/_rels/externalLink1.xml.rels", []byte(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/externalLinkPath" Target="Book2.xlsx" TargetMode="External"/></Relationships>`))
+
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "SUM([1]Sheet1!A1:A2)+NOW()"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A2", "[1]Sheet1!A1+[2]Sheet1!A1+'C:\\Docs\\[Book3.xlsx]Sheet1'!A1"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A3", "Sheet2!A1+_xlfn.RANDARRAY(2)+rand()"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A4", "Hidden*2"))
+	assert.NoError(t, f.SetCellFormula("Sheet2", "A1", "A2+Sheet2!A3"))
+	assert.NoError(t, f.SetCellFormula("Sheet3", "A1", "SUM(B1:B2)"))
+	assert.NoError(t, f.SetCellFormula("Sheet3", "A2", strings.Repeat("A1+", MaxFormulaLength/3+1)+"A1"))
+	formulaType, ref := STCellFormulaTypeShared, "B1:B2"
+	assert.NoError(t, f.SetCellFormula("Sheet3", "B1", "INDIRECT(\"A1\")", FormulaOpts{Type: &formulaType, Ref: &ref}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Hidden", RefersTo: "Sheet2!$A$1"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "External", RefersTo: "[1]Sheet1!$A$1", Scope: "Sheet3"}))
+
+	report, err = f.AuditFormulas()
+	assert.NoError(t, err)
+	assert.Equal(t, []FormulaAuditItem{
+		{Sheet: "Sheet1", Cell: "A1", Formula: "SUM([1]Sheet1!A1:A2)+NOW()", Detail: "Book2.xlsx"},
+		{Sheet: "Sheet1", Cell: "A2", Formula: "[1]Sheet1!A1+[2]Sheet1!A1+'C:\\Docs\\[Book3.xlsx]Sheet1'!A1", Detail: "Book2.xlsx"},
+		{Sheet: "Sheet1", Cell: "A2", Formula: "[1]Sheet1!A1+[2]Sheet1!A1+'C:\\Docs\\[Book3.xlsx]Sheet1'!A1", Detail: "[2]Sheet1"},
+		{Sheet: "Sheet1", Cell: "A2", Formula: "[1]Sheet1!A1+[2]Sheet1!A1+'C:\\Docs\\[Book3.xlsx]Sheet1'!A1", Detail: "C:\\Docs\\[Book3.xlsx]Sheet1"},
+		{Sheet: "Sheet3", DefinedName: "External", Formula: "[1]Sheet1!$A$1", Detail: "Book2.xlsx"},
+	}, report.ExternalLinks)
+	assert.Equal(t, []FormulaAuditItem{
+		{Sheet: "Sheet1", Cell: "A1", Formula: "SUM([1]Sheet1!A1:A2)+NOW()", Detail: "NOW"},
+		{Sheet: "Sheet1", Cell: "A3", Formula: "Sheet2!A1+_xlfn.RANDARRAY(2)+rand()", Detail: "RANDARRAY"},
+		{Sheet: "Sheet1", Cell: "A3", Formula: "Sheet2!A1+_xlfn.RANDARRAY(2)+rand()", Detail: "RAND"},
+		{Sheet: "Sheet3", Cell: "B1", Formula: "INDIRECT(\"A1\")", Detail: "INDIRECT"},
+		{Sheet: "Sheet3", Cell: "B2", Formula: "INDIRECT(\"A1\")", Detail: "INDIRECT"},
+	}, report.VolatileFunctions)
+	assert.Equal(t, []FormulaAuditItem{
+		{Sheet: "Sheet1", Cell: "A3", Formula: "Sheet2!A1+_xlfn.RANDARRAY(2)+rand()", Detail: "Sheet2"},
+		{Sheet: "Sheet1", Cell: "A4", Formula: "Hidden*2", Detail: "Sheet2"},
+		{Sheet: "Workbook", DefinedName: "Hidden", Formula: "Sheet2!$A$1", Detail: "Sheet2"},
+	}, report.HiddenSheetRefs)
+	assert.Len(t, report.LongFormulas, 1)
+	assert.Equal(t, "Sheet3", report.LongFormulas[0].Sheet)
+	assert.Equal(t, "A2", report.LongFormulas[0].Cell)
+	assert.Equal(t, "8195", report.LongFormulas[0].Detail)
+
+	// Test audit formulas with unsupported charset worksheet
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	f.checked = sync.Map{}
+	_, err = f.AuditFormulas()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+
+	// Test audit formulas with unsupported charset workbook
+	f = NewFile()
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	_, err = f.AuditFormulas()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
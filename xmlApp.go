@@ -19,7 +19,10 @@ type AppProperties struct {
 	ScaleCrop         bool
 	DocSecurity       int
 	Company           string
+	Manager           string
 	LinksUpToDate     bool
+	SharedDoc         bool
+	HyperlinkBase     string
 	HyperlinksChanged bool
 	AppVersion        string
 }
@@ -69,6 +72,44 @@ type xlsxVectorLpstr struct {
 	Content string `xml:",innerxml"`
 }
 
+// xlsxVector directly maps the vector element in the namespace
+// http://schemas.openxmlformats.org/officeDocument/2006/docPropsVTypes. This
+// element specifies a one-dimensional array of the same type elements.
+type xlsxVector struct {
+	XMLName  xml.Name      `xml:"vt:vector"`
+	Size     int           `xml:"size,attr"`
+	BaseType string        `xml:"baseType,attr"`
+	Variant  []xlsxVariant `xml:"vt:variant"`
+	Lpstr    []string      `xml:"vt:lpstr"`
+}
+
+// xlsxVariant directly maps the variant element in the namespace
+// http://schemas.openxmlformats.org/officeDocument/2006/docPropsVTypes.
+type xlsxVariant struct {
+	Lpstr string `xml:"vt:lpstr,omitempty"`
+	I4    int    `xml:"vt:i4,omitempty"`
+}
+
+// decodeVector directly maps the vector element in the namespace
+// http://schemas.openxmlformats.org/officeDocument/2006/docPropsVTypes. In
+// order to solve the problem that the label structure is changed after
+// serialization and deserialization, two different structures are defined.
+// decodeVector just for deserialization.
+type decodeVector struct {
+	Size     int             `xml:"size,attr"`
+	BaseType string          `xml:"baseType,attr"`
+	Variant  []decodeVariant `xml:"variant"`
+	Lpstr    []string        `xml:"lpstr"`
+}
+
+// decodeVariant directly maps the variant element in the namespace
+// http://schemas.openxmlformats.org/officeDocument/2006/docPropsVTypes. This
+// element specifies a variant type, decodeVariant just for deserialization.
+type decodeVariant struct {
+	Lpstr string `xml:"lpstr"`
+	I4    int    `xml:"i4"`
+}
+
 // xlsxDigSig contains the signature of a digitally signed document.
 type xlsxDigSig struct {
 	Content string `xml:",innerxml"`
@@ -84,6 +84,14 @@ type xlsxCalcChainC struct {
 	A bool   `xml:"a,attr,omitempty"`
 }
 
+// CalcChainCell directly maps the cell in the calculation chain. The Array
+// specifies whether the formula of the cell is an array formula.
+type CalcChainCell struct {
+	Sheet string
+	Cell  string
+	Array bool
+}
+
 // xlsxVolTypes maps the volatileDependencies part provides a cache of data that
 // supports Real Time Data (RTD) and CUBE functions in the workbook.
 type xlsxVolTypes struct {
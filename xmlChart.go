@@ -58,8 +58,8 @@ type cTitle struct {
 	Tx      cTx          `xml:"tx,omitempty"`
 	Layout  string       `xml:"layout,omitempty"`
 	Overlay *attrValBool `xml:"overlay"`
-	SpPr    cSpPr        `xml:"spPr,omitempty"`
-	TxPr    cTxPr        `xml:"txPr,omitempty"`
+	SpPr    *cSpPr       `xml:"spPr"`
+	TxPr    *cTxPr       `xml:"txPr"`
 }
 
 // cTx (Chart Text) directly maps the tx element. This element specifies text
@@ -123,8 +123,69 @@ type aPPr struct {
 // specifies a solid color fill. The shape is filled entirely with the specified
 // color.
 type aSolidFill struct {
-	SchemeClr *aSchemeClr    `xml:"a:schemeClr"`
-	SrgbClr   *attrValString `xml:"a:srgbClr"`
+	SchemeClr *aSchemeClr `xml:"a:schemeClr"`
+	SrgbClr   *aSrgbClr   `xml:"a:srgbClr"`
+}
+
+// aSrgbClr (RGB Color Model - Hex Variant) directly maps the a:srgbClr
+// element. This element specifies a color using the red, green, blue RGB color
+// model, and the alpha color transform specifies its opacity.
+type aSrgbClr struct {
+	Val   *string     `xml:"val,attr"`
+	Alpha *attrValInt `xml:"a:alpha"`
+}
+
+// aGradFill (Gradient Fill) directly maps the a:gradFill element. This element
+// defines a gradient fill.
+type aGradFill struct {
+	RotWithShape bool       `xml:"rotWithShape,attr"`
+	GsLst        []aGs      `xml:"a:gsLst>a:gs"`
+	Lin          *aLin      `xml:"a:lin"`
+	Path         *aGradPath `xml:"a:path"`
+}
+
+// aGs (Gradient stops) directly maps the a:gs element. This element defines a
+// gradient stop by given position and color.
+type aGs struct {
+	Pos     int       `xml:"pos,attr"`
+	SrgbClr *aSrgbClr `xml:"a:srgbClr"`
+}
+
+// aLin (Linear Gradient Fill) directly maps the a:lin element. This element
+// specifies a linear gradient.
+type aLin struct {
+	Ang    int  `xml:"ang,attr"`
+	Scaled bool `xml:"scaled,attr"`
+}
+
+// aGradPath (Path Gradient) directly maps the a:path element. This element
+// defines that a gradient fill follows a path vs. a linear line.
+type aGradPath struct {
+	Path       string       `xml:"path,attr"`
+	FillToRect *aFillToRect `xml:"a:fillToRect"`
+}
+
+// aFillToRect directly maps the a:fillToRect element. This element defines
+// the focus rectangle for the center shade.
+type aFillToRect struct {
+	L int `xml:"l,attr"`
+	T int `xml:"t,attr"`
+	R int `xml:"r,attr"`
+	B int `xml:"b,attr"`
+}
+
+// aPattFill (Pattern Fill) directly maps the a:pattFill element. This element
+// specifies a pattern fill by given preset pattern, foreground and background
+// color.
+type aPattFill struct {
+	Prst  string     `xml:"prst,attr"`
+	FgClr aPattColor `xml:"a:fgClr"`
+	BgClr aPattColor `xml:"a:bgClr"`
+}
+
+// aPattColor directly maps the a:fgClr and a:bgClr element.
+type aPattColor struct {
+	SrgbClr *aSrgbClr `xml:"a:srgbClr"`
 }
 
 // aSchemeClr (Scheme Color) directly maps the a:schemeClr element. This
@@ -222,6 +283,8 @@ type aRPr struct {
 type cSpPr struct {
 	NoFill    *string     `xml:"a:noFill"`
 	SolidFill *aSolidFill `xml:"a:solidFill"`
+	GradFill  *aGradFill  `xml:"a:gradFill"`
+	PattFill  *aPattFill  `xml:"a:pattFill"`
 	Ln        *aLn        `xml:"a:ln"`
 	Sp3D      *aSp3D      `xml:"a:sp3d"`
 	EffectLst *string     `xml:"a:effectLst"`
@@ -253,8 +316,9 @@ type aLn struct {
 	Cmpd      string         `xml:"cmpd,attr,omitempty"`
 	W         int            `xml:"w,attr,omitempty"`
 	NoFill    *attrValString `xml:"a:noFill"`
-	Round     string         `xml:"a:round,omitempty"`
 	SolidFill *aSolidFill    `xml:"a:solidFill"`
+	PrstDash  *attrValString `xml:"a:prstDash"`
+	Round     string         `xml:"a:round,omitempty"`
 }
 
 // cTxPr (Text Properties) directly maps the txPr element. This element
@@ -291,8 +355,8 @@ type cAutoTitleDeleted struct {
 type cView3D struct {
 	RotX         *attrValInt `xml:"rotX"`
 	RotY         *attrValInt `xml:"rotY"`
-	RAngAx       *attrValInt `xml:"rAngAx"`
 	DepthPercent *attrValInt `xml:"depthPercent"`
+	RAngAx       *attrValInt `xml:"rAngAx"`
 	Perspective  *attrValInt `xml:"perspective"`
 	ExtLst       *xlsxExtLst `xml:"extLst"`
 }
@@ -300,47 +364,64 @@ type cView3D struct {
 // cPlotArea directly maps the plotArea element. This element specifies the
 // plot area of the chart.
 type cPlotArea struct {
-	Layout         *string  `xml:"layout"`
-	AreaChart      *cCharts `xml:"areaChart"`
-	Area3DChart    *cCharts `xml:"area3DChart"`
-	BarChart       *cCharts `xml:"barChart"`
-	Bar3DChart     *cCharts `xml:"bar3DChart"`
-	BubbleChart    *cCharts `xml:"bubbleChart"`
-	DoughnutChart  *cCharts `xml:"doughnutChart"`
-	LineChart      *cCharts `xml:"lineChart"`
-	Line3DChart    *cCharts `xml:"line3DChart"`
-	PieChart       *cCharts `xml:"pieChart"`
-	Pie3DChart     *cCharts `xml:"pie3DChart"`
-	OfPieChart     *cCharts `xml:"ofPieChart"`
-	RadarChart     *cCharts `xml:"radarChart"`
-	ScatterChart   *cCharts `xml:"scatterChart"`
-	Surface3DChart *cCharts `xml:"surface3DChart"`
-	SurfaceChart   *cCharts `xml:"surfaceChart"`
-	CatAx          []*cAxs  `xml:"catAx"`
-	ValAx          []*cAxs  `xml:"valAx"`
-	SerAx          []*cAxs  `xml:"serAx"`
-	SpPr           *cSpPr   `xml:"spPr"`
+	Layout         *string    `xml:"layout"`
+	AreaChart      *cCharts   `xml:"areaChart"`
+	Area3DChart    *cCharts   `xml:"area3DChart"`
+	BarChart       *cCharts   `xml:"barChart"`
+	Bar3DChart     *cCharts   `xml:"bar3DChart"`
+	BubbleChart    *cCharts   `xml:"bubbleChart"`
+	DoughnutChart  *cCharts   `xml:"doughnutChart"`
+	LineChart      *cCharts   `xml:"lineChart"`
+	Line3DChart    *cCharts   `xml:"line3DChart"`
+	PieChart       *cCharts   `xml:"pieChart"`
+	Pie3DChart     *cCharts   `xml:"pie3DChart"`
+	OfPieChart     *cCharts   `xml:"ofPieChart"`
+	RadarChart     *cCharts   `xml:"radarChart"`
+	ScatterChart   *cCharts   `xml:"scatterChart"`
+	Surface3DChart *cCharts   `xml:"surface3DChart"`
+	SurfaceChart   *cCharts   `xml:"surfaceChart"`
+	CatAx          []*cAxs    `xml:"catAx"`
+	DateAx         []*cDateAx `xml:"dateAx"`
+	ValAx          []*cAxs    `xml:"valAx"`
+	SerAx          []*cAxs    `xml:"serAx"`
+	DTable         *cDTable   `xml:"dTable"`
+	SpPr           *cSpPr     `xml:"spPr"`
+}
+
+// cDTable (Data Table) directly maps the dTable element. This element
+// specifies the data table under the chart.
+type cDTable struct {
+	ShowHorzBorder *attrValBool `xml:"showHorzBorder"`
+	ShowVertBorder *attrValBool `xml:"showVertBorder"`
+	ShowOutline    *attrValBool `xml:"showOutline"`
+	ShowKeys       *attrValBool `xml:"showKeys"`
+	TxPr           *cTxPr       `xml:"txPr"`
 }
 
 // cCharts specifies the common element of the chart.
 type cCharts struct {
-	BarDir       *attrValString `xml:"barDir"`
-	BubbleScale  *attrValFloat  `xml:"bubbleScale"`
-	Grouping     *attrValString `xml:"grouping"`
-	RadarStyle   *attrValString `xml:"radarStyle"`
-	ScatterStyle *attrValString `xml:"scatterStyle"`
-	OfPieType    *attrValString `xml:"ofPieType"`
-	VaryColors   *attrValBool   `xml:"varyColors"`
-	Wireframe    *attrValBool   `xml:"wireframe"`
-	Ser          *[]cSer        `xml:"ser"`
-	SplitPos     *attrValInt    `xml:"splitPos"`
-	SerLines     *attrValString `xml:"serLines"`
-	DLbls        *cDLbls        `xml:"dLbls"`
-	Shape        *attrValString `xml:"shape"`
-	HoleSize     *attrValInt    `xml:"holeSize"`
-	Smooth       *attrValBool   `xml:"smooth"`
-	Overlap      *attrValInt    `xml:"overlap"`
-	AxID         []*attrValInt  `xml:"axId"`
+	BarDir        *attrValString `xml:"barDir"`
+	BubbleScale   *attrValFloat  `xml:"bubbleScale"`
+	Grouping      *attrValString `xml:"grouping"`
+	RadarStyle    *attrValString `xml:"radarStyle"`
+	ScatterStyle  *attrValString `xml:"scatterStyle"`
+	OfPieType     *attrValString `xml:"ofPieType"`
+	VaryColors    *attrValBool   `xml:"varyColors"`
+	Wireframe     *attrValBool   `xml:"wireframe"`
+	Ser           *[]cSer        `xml:"ser"`
+	SplitPos      *attrValInt    `xml:"splitPos"`
+	SerLines      *attrValString `xml:"serLines"`
+	DLbls         *cDLbls        `xml:"dLbls"`
+	DropLines     *cChartLines   `xml:"dropLines"`
+	HiLowLines    *cChartLines   `xml:"hiLowLines"`
+	UpDownBars    *cUpDownBars   `xml:"upDownBars"`
+	GapWidth      *attrValInt    `xml:"gapWidth"`
+	Shape         *attrValString `xml:"shape"`
+	FirstSliceAng *attrValInt    `xml:"firstSliceAng"`
+	HoleSize      *attrValInt    `xml:"holeSize"`
+	Smooth        *attrValBool   `xml:"smooth"`
+	Overlap       *attrValInt    `xml:"overlap"`
+	AxID          []*attrValInt  `xml:"axId"`
 }
 
 // cAxs directly maps the catAx and valAx element.
@@ -363,6 +444,7 @@ type cAxs struct {
 	CrossBetween   *attrValString `xml:"crossBetween"`
 	MajorUnit      *attrValFloat  `xml:"majorUnit"`
 	MinorUnit      *attrValFloat  `xml:"minorUnit"`
+	DispUnits      *cDispUnits    `xml:"dispUnits"`
 	Auto           *attrValBool   `xml:"auto"`
 	LblAlgn        *attrValString `xml:"lblAlgn"`
 	LblOffset      *attrValInt    `xml:"lblOffset"`
@@ -371,11 +453,60 @@ type cAxs struct {
 	NoMultiLvlLbl  *attrValBool   `xml:"noMultiLvlLbl"`
 }
 
+// cDateAx (Date Axis) directly maps the dateAx element. This element specifies
+// a category axis which arranges the dates in chronological order with the
+// specified time units.
+type cDateAx struct {
+	AxID           *attrValInt    `xml:"axId"`
+	Scaling        *cScaling      `xml:"scaling"`
+	Delete         *attrValBool   `xml:"delete"`
+	AxPos          *attrValString `xml:"axPos"`
+	MajorGridlines *cChartLines   `xml:"majorGridlines"`
+	MinorGridlines *cChartLines   `xml:"minorGridlines"`
+	Title          *cTitle        `xml:"title"`
+	NumFmt         *cNumFmt       `xml:"numFmt"`
+	MajorTickMark  *attrValString `xml:"majorTickMark"`
+	MinorTickMark  *attrValString `xml:"minorTickMark"`
+	TickLblPos     *attrValString `xml:"tickLblPos"`
+	SpPr           *cSpPr         `xml:"spPr"`
+	TxPr           *cTxPr         `xml:"txPr"`
+	CrossAx        *attrValInt    `xml:"crossAx"`
+	Crosses        *attrValString `xml:"crosses"`
+	Auto           *attrValBool   `xml:"auto"`
+	LblOffset      *attrValInt    `xml:"lblOffset"`
+	BaseTimeUnit   *attrValString `xml:"baseTimeUnit"`
+	MajorUnit      *attrValFloat  `xml:"majorUnit"`
+	MajorTimeUnit  *attrValString `xml:"majorTimeUnit"`
+	MinorUnit      *attrValFloat  `xml:"minorUnit"`
+	MinorTimeUnit  *attrValString `xml:"minorTimeUnit"`
+}
+
+// cDispUnits (Display Units) directly maps the dispUnits element. This element
+// specifies the scaling value of the display units for the value axis.
+type cDispUnits struct {
+	BuiltInUnit  *attrValString `xml:"builtInUnit"`
+	DispUnitsLbl *cDispUnitsLbl `xml:"dispUnitsLbl"`
+}
+
+// cDispUnitsLbl (Display Units Label) directly maps the dispUnitsLbl element.
+// This element specifies the display units label shown on the chart.
+type cDispUnitsLbl struct {
+	Layout string `xml:"layout"`
+}
+
 // cChartLines directly maps the chart lines content model.
 type cChartLines struct {
 	SpPr *cSpPr `xml:"spPr"`
 }
 
+// cUpDownBars directly maps the upDownBars element. This element specifies
+// the up and down bars.
+type cUpDownBars struct {
+	GapWidth *attrValInt  `xml:"gapWidth"`
+	UpBars   *cChartLines `xml:"upBars"`
+	DownBars *cChartLines `xml:"downBars"`
+}
+
 // cScaling directly maps the scaling element. This element contains
 // additional axis settings.
 type cScaling struct {
@@ -399,6 +530,7 @@ type cSer struct {
 	Order            *attrValInt  `xml:"order"`
 	Tx               *cTx         `xml:"tx"`
 	SpPr             *cSpPr       `xml:"spPr"`
+	Explosion        *attrValInt  `xml:"explosion"`
 	DPt              []*cDPt      `xml:"dPt"`
 	DLbls            *cDLbls      `xml:"dLbls"`
 	Marker           *cMarker     `xml:"marker"`
@@ -423,9 +555,10 @@ type cMarker struct {
 // cDPt (Data Point) directly maps the dPt element. This element specifies a
 // single data point.
 type cDPt struct {
-	IDx      *attrValInt  `xml:"idx"`
-	Bubble3D *attrValBool `xml:"bubble3D"`
-	SpPr     *cSpPr       `xml:"spPr"`
+	IDx       *attrValInt  `xml:"idx"`
+	Bubble3D  *attrValBool `xml:"bubble3D"`
+	Explosion *attrValInt  `xml:"explosion"`
+	SpPr      *cSpPr       `xml:"spPr"`
 }
 
 // cCat (Category Axis Data) directly maps the cat element. This element
@@ -496,11 +629,21 @@ type cDLbls struct {
 // cLegend (Legend) directly maps the legend element. This element specifies
 // the legend.
 type cLegend struct {
-	Layout    *string        `xml:"layout"`
-	LegendPos *attrValString `xml:"legendPos"`
-	Overlay   *attrValBool   `xml:"overlay"`
-	SpPr      *cSpPr         `xml:"spPr"`
-	TxPr      *cTxPr         `xml:"txPr"`
+	LegendPos   *attrValString  `xml:"legendPos"`
+	LegendEntry []*cLegendEntry `xml:"legendEntry"`
+	Layout      *string         `xml:"layout"`
+	Overlay     *attrValBool    `xml:"overlay"`
+	SpPr        *cSpPr          `xml:"spPr"`
+	TxPr        *cTxPr          `xml:"txPr"`
+}
+
+// cLegendEntry (Legend Entry) directly maps the legendEntry element. This
+// element specifies a legend entry, the idx element specifies the zero-based
+// index of the series or data point of the entry, and the delete element
+// specifies the entry shall be removed from the legend.
+type cLegendEntry struct {
+	IDx    *attrValInt  `xml:"idx"`
+	Delete *attrValBool `xml:"delete"`
 }
 
 // cPrintSettings directly maps the printSettings element. This element
@@ -530,20 +673,30 @@ type ChartNumFmt struct {
 
 // ChartAxis directly maps the format settings of the chart axis.
 type ChartAxis struct {
-	None           bool
-	MajorGridLines bool
-	MinorGridLines bool
-	MajorUnit      float64
-	TickLabelSkip  int
-	ReverseOrder   bool
-	Secondary      bool
-	Maximum        *float64
-	Minimum        *float64
-	Font           Font
-	LogBase        float64
-	NumFmt         ChartNumFmt
-	Title          []RichTextRun
-	axID           int
+	None                bool
+	MajorGridLines      bool
+	MinorGridLines      bool
+	MajorUnit           float64
+	MinorUnit           float64
+	DateAxis            bool
+	BaseTimeUnit        string
+	MajorTimeUnit       string
+	MinorTimeUnit       string
+	MajorTickMark       string
+	MinorTickMark       string
+	TickLabelSkip       int
+	TickLabelRotation   int
+	DisplayUnits        string
+	DisplayUnitsVisible bool
+	ReverseOrder        bool
+	Secondary           bool
+	Maximum             *float64
+	Minimum             *float64
+	Font                Font
+	LogBase             float64
+	NumFmt              ChartNumFmt
+	Title               []RichTextRun
+	axID                int
 }
 
 // ChartDimension directly maps the dimension of the chart.
@@ -562,33 +715,82 @@ type ChartPlotArea struct {
 	ShowSerName      bool
 	ShowVal          bool
 	Fill             Fill
+	Border           ChartLine
 	NumFmt           ChartNumFmt
 }
 
 // Chart directly maps the format settings of the chart.
 type Chart struct {
-	Type         ChartType
-	Series       []ChartSeries
-	Format       GraphicOptions
-	Dimension    ChartDimension
-	Legend       ChartLegend
-	Title        []RichTextRun
-	VaryColors   *bool
-	XAxis        ChartAxis
-	YAxis        ChartAxis
-	PlotArea     ChartPlotArea
-	Fill         Fill
+	Type            ChartType
+	Series          []ChartSeries
+	Format          GraphicOptions
+	Dimension       ChartDimension
+	View3D          ChartView3D
+	Legend          ChartLegend
+	Title           []RichTextRun
+	VaryColors      *bool
+	XAxis           ChartAxis
+	YAxis           ChartAxis
+	PlotArea        ChartPlotArea
+	Fill            Fill
+	Border          ChartLine
+	RoundedCorners  bool
+	DataTable       *ChartDataTable
+	ShowBlanksAs    string
+	BubbleSize      int
+	HoleSize        int
+	GapWidth        *int
+	Overlap         *int
+	FirstSliceAngle int
+	DropLines       *ChartLine
+	HighLowLines    *ChartLine
+	UpDownBars      *ChartUpDownBars
+	order           int
+}
+
+// SheetChart directly maps the chart in the worksheet, the Cell is the cell
+// reference of the top-left anchor of the chart.
+type SheetChart struct {
+	Cell  string
+	Chart *Chart
+	Combo []*Chart
+}
+
+// ChartView3D directly maps the format settings of the 3D chart view.
+type ChartView3D struct {
+	RotX           *int
+	RotY           *int
+	Perspective    *int
+	RightAngleAxes *bool
+	DepthPercent   *int
+}
+
+// ChartDataTable directly maps the format settings of the chart data table.
+type ChartDataTable struct {
+	ShowHorizontalBorder bool
+	ShowVerticalBorder   bool
+	ShowOutline          bool
+	ShowLegendKeys       bool
+	Font                 Font
+}
+
+// ChartUpDownBars directly maps the format settings of the up and down bars
+// for the line chart.
+type ChartUpDownBars struct {
+	GapWidth     *int
+	UpBarsFill   Fill
+	DownBarsFill Fill
 	Border       ChartLine
-	ShowBlanksAs string
-	BubbleSize   int
-	HoleSize     int
-	order        int
 }
 
 // ChartLegend directly maps the format settings of the chart legend.
 type ChartLegend struct {
-	Position      string
-	ShowLegendKey bool
+	Position       string
+	ShowLegendKey  bool
+	Font           Font
+	Fill           Fill
+	Border         ChartLine
+	DeletedEntries []int
 }
 
 // ChartMarker directly maps the format settings of the chart marker.
@@ -600,9 +802,18 @@ type ChartMarker struct {
 
 // ChartLine directly maps the format settings of the chart line.
 type ChartLine struct {
-	Type   ChartLineType
-	Smooth bool
-	Width  float64
+	Type     ChartLineType
+	Smooth   bool
+	Width    float64
+	Color    string
+	DashType string
+}
+
+// ChartDataPoint directly maps the format settings of the chart data point.
+type ChartDataPoint struct {
+	Index     int
+	Fill      Fill
+	Explosion int
 }
 
 // ChartSeries directly maps the format settings of the chart series.
@@ -612,6 +823,8 @@ type ChartSeries struct {
 	Values            string
 	Sizes             string
 	Fill              Fill
+	DataPoint         []ChartDataPoint
+	Explosion         int
 	Line              ChartLine
 	Marker            ChartMarker
 	DataLabelPosition ChartDataLabelPositionType
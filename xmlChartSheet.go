@@ -88,3 +88,24 @@ type xlsxCustomChartsheetView struct {
 	PageSetup     []*xlsxPageSetUp    `xml:"pageSetup"`
 	HeaderFooter  []*xlsxHeaderFooter `xml:"headerFooter"`
 }
+
+// ChartSheet directly maps the settings of the chart sheet.
+type ChartSheet struct {
+	Name    string
+	Chart   *Chart
+	Combo   []*Chart
+	Options ChartSheetOptions
+}
+
+// ChartSheetOptions directly maps the view and page layout settings of the
+// chart sheet.
+type ChartSheetOptions struct {
+	// ZoomScale specifies a window zoom magnification for current view
+	// representing percent values. This attribute is restricted to values
+	// ranging from 10 to 400.
+	ZoomScale *float64
+	// ZoomToFit specifies whether the chart is zoomed to fit the window.
+	ZoomToFit *bool
+	// PageLayout specifies the page setup settings of the chart sheet.
+	PageLayout *PageLayoutOptions
+}
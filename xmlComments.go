@@ -72,6 +72,44 @@ type xlsxPhoneticRun struct {
 	T  string `xml:"t"`
 }
 
+// xlsxThreadedComments directly maps the ThreadedComments element in the
+// namespace http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments.
+// This element is the root element of the threaded comments part of the
+// worksheet.
+type xlsxThreadedComments struct {
+	XMLName         xml.Name              `xml:"http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments ThreadedComments"`
+	ThreadedComment []xlsxThreadedComment `xml:"threadedComment"`
+}
+
+// xlsxThreadedComment directly maps the threadedComment element. This element
+// represents a comment of the discussion thread on the cell.
+type xlsxThreadedComment struct {
+	Ref      string `xml:"ref,attr,omitempty"`
+	DT       string `xml:"dT,attr,omitempty"`
+	PersonID string `xml:"personId,attr"`
+	ID       string `xml:"id,attr"`
+	ParentID string `xml:"parentId,attr,omitempty"`
+	Done     *bool  `xml:"done,attr"`
+	Text     string `xml:"text"`
+}
+
+// xlsxPersonList directly maps the personList element. This element is the
+// root element of the persons part of the workbook, which holds the authors
+// of the threaded comments.
+type xlsxPersonList struct {
+	XMLName xml.Name     `xml:"http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments personList"`
+	Person  []xlsxPerson `xml:"person"`
+}
+
+// xlsxPerson directly maps the person element. This element represents an
+// author of the threaded comments.
+type xlsxPerson struct {
+	DisplayName string `xml:"displayName,attr"`
+	ID          string `xml:"id,attr"`
+	UserID      string `xml:"userId,attr,omitempty"`
+	ProviderID  string `xml:"providerId,attr"`
+}
+
 // Comment directly maps the comment information.
 type Comment struct {
 	Author    string
@@ -81,4 +119,8 @@ type Comment struct {
 	Width     uint
 	Height    uint
 	Paragraph []RichTextRun
+	Fill      Fill
+	Line      ShapeLine
+	Font      *Font
+	Shadow    *bool
 }
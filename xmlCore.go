@@ -31,6 +31,27 @@ type DocProperties struct {
 	Version        string
 }
 
+// InspectorOptions directly maps the settings of the hidden and personal
+// information to be removed by the document inspector.
+type InspectorOptions struct {
+	DocumentProperties   bool
+	Comments             bool
+	HiddenSheets         bool
+	HiddenRowsAndColumns bool
+	ExternalDefinedNames bool
+	PrinterSettings      bool
+}
+
+// SanitizeOptions directly maps the settings of the untrusted content to be
+// neutralized or removed by the sanitizer.
+type SanitizeOptions struct {
+	Formulas        bool
+	ExternalLinks   bool
+	Macros          bool
+	EmbeddedObjects bool
+	RemoteImages    bool
+}
+
 // decodeDcTerms directly maps the DCMI metadata terms for the coreProperties.
 type decodeDcTerms struct {
 	Text string `xml:",chardata"`
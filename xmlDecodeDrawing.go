@@ -23,11 +23,25 @@ type decodeCellAnchor struct {
 	To               *decodeTo               `xml:"to"`
 	Sp               *decodeSp               `xml:"sp"`
 	Pic              *decodePic              `xml:"pic"`
+	GraphicFrame     *decodeGraphicFrame     `xml:"graphicFrame"`
 	ClientData       *decodeClientData       `xml:"clientData"`
 	AlternateContent []*xlsxAlternateContent `xml:"mc:AlternateContent"`
 	Content          string                  `xml:",innerxml"`
 }
 
+// decodeGraphicFrame defines the structure used to deserialize the graphic
+// frame of the chart in the drawing.
+type decodeGraphicFrame struct {
+	CNvPr *decodeCNvPr             `xml:"nvGraphicFramePr>cNvPr"`
+	Chart *decodeGraphicFrameChart `xml:"graphic>graphicData>chart"`
+}
+
+// decodeGraphicFrameChart defines the structure used to deserialize the chart
+// reference of the graphic frame.
+type decodeGraphicFrameChart struct {
+	RID string `xml:"id,attr"`
+}
+
 // decodeCellAnchorPos defines the structure used to deserialize the cell anchor
 // for adjust drawing object on inserting/deleting column/rows.
 type decodeCellAnchorPos struct {
@@ -97,11 +111,30 @@ type decodeWsDr struct {
 // information that does not affect the appearance of the picture to be
 // stored.
 type decodeCNvPr struct {
-	XMLName xml.Name `xml:"cNvPr"`
-	ID      int      `xml:"id,attr"`
-	Name    string   `xml:"name,attr"`
-	Descr   string   `xml:"descr,attr"`
-	Title   string   `xml:"title,attr,omitempty"`
+	XMLName xml.Name           `xml:"cNvPr"`
+	ID      int                `xml:"id,attr"`
+	Name    string             `xml:"name,attr"`
+	Descr   string             `xml:"descr,attr"`
+	Title   string             `xml:"title,attr,omitempty"`
+	ExtLst  *decodeCNvPrExtLst `xml:"extLst"`
+}
+
+// decodeCNvPrExtLst directly maps the extLst element of the non-visual
+// drawing properties.
+type decodeCNvPrExtLst struct {
+	Ext []decodeCNvPrExt `xml:"ext"`
+}
+
+// decodeCNvPrExt directly maps the ext element of the non-visual drawing
+// properties.
+type decodeCNvPrExt struct {
+	URI        string            `xml:"uri,attr"`
+	Decorative *decodeDecorative `xml:"decorative"`
+}
+
+// decodeDecorative directly maps the decorative element.
+type decodeDecorative struct {
+	Val bool `xml:"val,attr"`
 }
 
 // decodePicLocks directly maps the picLocks (Picture Locks). This element
@@ -126,6 +159,7 @@ type decodePicLocks struct {
 // or picture) and contains a reference to the image data.
 type decodeBlip struct {
 	Embed  string `xml:"embed,attr"`
+	Link   string `xml:"link,attr"`
 	Cstate string `xml:"cstate,attr,omitempty"`
 	R      string `xml:"r,attr"`
 }
@@ -173,7 +207,26 @@ type decodeXfrm struct {
 // determine how certain properties are to be changed for the picture object in
 // question.
 type decodeCNvPicPr struct {
-	PicLocks decodePicLocks `xml:"picLocks"`
+	PicLocks decodePicLocks        `xml:"picLocks"`
+	ExtLst   *decodeCNvPicPrExtLst `xml:"extLst"`
+}
+
+// decodeCNvPicPrExtLst directly maps the extLst element of the non-visual
+// picture drawing properties.
+type decodeCNvPicPrExtLst struct {
+	Ext []decodeCNvPicPrExt `xml:"ext"`
+}
+
+// decodeCNvPicPrExt directly maps the ext element of the non-visual picture
+// drawing properties.
+type decodeCNvPicPrExt struct {
+	URI        string            `xml:"uri,attr"`
+	CameraTool *decodeCameraTool `xml:"cameraTool"`
+}
+
+// decodeCameraTool directly maps the cameraTool element.
+type decodeCameraTool struct {
+	CellRange string `xml:"cellRange,attr"`
 }
 
 // directly maps the nvPicPr (Non-Visual Properties for a Picture). This
@@ -254,3 +307,54 @@ type decodeCellImages struct {
 type decodeCellImage struct {
 	Pic decodePic `xml:"pic"`
 }
+
+// decodeChartSpace defines the structure used to deserialize the chart title
+// and plot area of the chart part.
+type decodeChartSpace struct {
+	XMLName xml.Name    `xml:"http://schemas.openxmlformats.org/drawingml/2006/chart chartSpace"`
+	Chart   decodeChart `xml:"chart"`
+}
+
+// decodeChart defines the structure used to deserialize the chart element.
+type decodeChart struct {
+	Title        *decodeChartTitle `xml:"title"`
+	PlotArea     *cPlotArea        `xml:"plotArea"`
+	Legend       *cLegend          `xml:"legend"`
+	DispBlanksAs *attrValString    `xml:"dispBlanksAs"`
+}
+
+// decodeChartAxes defines the structure used to deserialize the axis titles
+// of the chart part.
+type decodeChartAxes struct {
+	XMLName xml.Name          `xml:"http://schemas.openxmlformats.org/drawingml/2006/chart chartSpace"`
+	CatAx   []decodeChartAxis `xml:"chart>plotArea>catAx"`
+	DateAx  []decodeChartAxis `xml:"chart>plotArea>dateAx"`
+	ValAx   []decodeChartAxis `xml:"chart>plotArea>valAx"`
+	SerAx   []decodeChartAxis `xml:"chart>plotArea>serAx"`
+}
+
+// decodeChartAxis defines the structure used to deserialize the axis ID and
+// title of the chart axis.
+type decodeChartAxis struct {
+	AxID  attrValInt        `xml:"axId"`
+	Title *decodeChartTitle `xml:"title"`
+}
+
+// decodeChartTitle defines the structure used to deserialize the rich text
+// of the chart title.
+type decodeChartTitle struct {
+	F string              `xml:"tx>strRef>f"`
+	P []decodeChartTitleP `xml:"tx>rich>p"`
+}
+
+// decodeChartTitleP defines the structure used to deserialize the paragraph
+// of the chart title.
+type decodeChartTitleP struct {
+	R []decodeChartTitleR `xml:"r"`
+}
+
+// decodeChartTitleR defines the structure used to deserialize the text run of
+// the chart title.
+type decodeChartTitleR struct {
+	T string `xml:"t"`
+}
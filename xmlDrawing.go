@@ -20,11 +20,33 @@ import (
 // element specifies non-visual canvas properties. This allows for additional
 // information that does not affect the appearance of the picture to be stored.
 type xlsxCNvPr struct {
-	ID         int             `xml:"id,attr"`
-	Name       string          `xml:"name,attr"`
-	Descr      string          `xml:"descr,attr"`
-	Title      string          `xml:"title,attr,omitempty"`
-	HlinkClick *xlsxHlinkClick `xml:"a:hlinkClick"`
+	ID         int              `xml:"id,attr"`
+	Name       string           `xml:"name,attr"`
+	Descr      string           `xml:"descr,attr"`
+	Title      string           `xml:"title,attr,omitempty"`
+	HlinkClick *xlsxHlinkClick  `xml:"a:hlinkClick"`
+	ExtLst     *xlsxCNvPrExtLst `xml:"a:extLst"`
+}
+
+// xlsxCNvPrExtLst directly maps the extLst element of the non-visual drawing
+// properties.
+type xlsxCNvPrExtLst struct {
+	Ext []xlsxCNvPrExt `xml:"a:ext"`
+}
+
+// xlsxCNvPrExt directly maps the ext element of the non-visual drawing
+// properties.
+type xlsxCNvPrExt struct {
+	URI        string          `xml:"uri,attr"`
+	Decorative *xlsxDecorative `xml:"adec:decorative"`
+}
+
+// xlsxDecorative directly maps the decorative element. This element specifies
+// the drawing object is decorative and should be ignored by accessibility
+// tools.
+type xlsxDecorative struct {
+	XMLNSAdec string `xml:"xmlns:adec,attr"`
+	Val       bool   `xml:"val,attr"`
 }
 
 // xlsxHlinkClick (Click Hyperlink) Specifies the on-click hyperlink
@@ -112,7 +134,30 @@ type xlsxXfrm struct {
 // determine how certain properties are to be changed for the picture object in
 // question.
 type xlsxCNvPicPr struct {
-	PicLocks xlsxPicLocks `xml:"a:picLocks"`
+	PicLocks xlsxPicLocks        `xml:"a:picLocks"`
+	ExtLst   *xlsxCNvPicPrExtLst `xml:"a:extLst"`
+}
+
+// xlsxCNvPicPrExtLst directly maps the extLst element of the non-visual
+// picture drawing properties.
+type xlsxCNvPicPrExtLst struct {
+	Ext []xlsxCNvPicPrExt `xml:"a:ext"`
+}
+
+// xlsxCNvPicPrExt directly maps the ext element of the non-visual picture
+// drawing properties.
+type xlsxCNvPicPrExt struct {
+	URI        string          `xml:"uri,attr"`
+	CameraTool *xlsxCameraTool `xml:"a14:cameraTool"`
+}
+
+// xlsxCameraTool directly maps the cameraTool element. This element specifies
+// the picture is a linked picture created by the camera tool, which content
+// mirrors the cell range.
+type xlsxCameraTool struct {
+	XMLNSA14  string `xml:"xmlns:a14,attr"`
+	CellRange string `xml:"cellRange,attr"`
+	Spid      string `xml:"spid,attr,omitempty"`
 }
 
 // directly maps the nvPicPr (Non-Visual Properties for a Picture). This element
@@ -415,9 +460,23 @@ type Picture struct {
 	InsertType PictureInsertType
 }
 
+// PictureInfo maps the meta info of the picture, the Extension specifies the
+// image format, the Width and Height specifies the dimensions of the image in
+// pixels, and the Size specifies the size of the image contents in bytes.
+type PictureInfo struct {
+	Extension  string
+	Format     *GraphicOptions
+	InsertType PictureInsertType
+	Width      int
+	Height     int
+	Size       int64
+}
+
 // GraphicOptions directly maps the format settings of the picture.
 type GraphicOptions struct {
 	AltText         string
+	AltTextTitle    string
+	Decorative      bool
 	PrintObject     *bool
 	Locked          *bool
 	LockAspectRatio bool
@@ -429,6 +488,13 @@ type GraphicOptions struct {
 	Hyperlink       string
 	HyperlinkType   string
 	Positioning     string
+	Unit            string
+	Absolute        bool
+	PositionX       float64
+	PositionY       float64
+	Width           float64
+	Height          float64
+	LinkedRange     string
 }
 
 // Shape directly maps the format settings of the shape.
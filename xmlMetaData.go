@@ -24,7 +24,8 @@ import "encoding/xml"
 // can be propagated along with the value as it is referenced in formulas.
 type xlsxMetadata struct {
 	XMLName         xml.Name             `xml:"metadata"`
-	MetadataTypes   *xlsxInnerXML        `xml:"metadataTypes"`
+	XMLNS           string               `xml:"xmlns,attr,omitempty"`
+	MetadataTypes   *xlsxMetadataTypes   `xml:"metadataTypes"`
 	MetadataStrings *xlsxInnerXML        `xml:"metadataStrings"`
 	MdxMetadata     *xlsxInnerXML        `xml:"mdxMetadata"`
 	FutureMetadata  []xlsxFutureMetadata `xml:"futureMetadata"`
@@ -33,9 +34,52 @@ type xlsxMetadata struct {
 	ExtLst          *xlsxInnerXML        `xml:"extLst"`
 }
 
+// xlsxMetadataTypes directly maps the metadataTypes element. This element
+// represents the collection of metadata types in the workbook.
+type xlsxMetadataTypes struct {
+	Count        int                `xml:"count,attr,omitempty"`
+	MetadataType []xlsxMetadataType `xml:"metadataType"`
+}
+
+// xlsxMetadataType directly maps the metadataType element. This element
+// represents a single metadata type and the behaviors of the metadata on
+// cell operations.
+type xlsxMetadataType struct {
+	Name                string `xml:"name,attr"`
+	MinSupportedVersion int    `xml:"minSupportedVersion,attr"`
+	GhostRow            bool   `xml:"ghostRow,attr,omitempty"`
+	GhostCol            bool   `xml:"ghostCol,attr,omitempty"`
+	Edit                bool   `xml:"edit,attr,omitempty"`
+	Delete              bool   `xml:"delete,attr,omitempty"`
+	Copy                bool   `xml:"copy,attr,omitempty"`
+	PasteAll            bool   `xml:"pasteAll,attr,omitempty"`
+	PasteFormulas       bool   `xml:"pasteFormulas,attr,omitempty"`
+	PasteValues         bool   `xml:"pasteValues,attr,omitempty"`
+	PasteFormats        bool   `xml:"pasteFormats,attr,omitempty"`
+	PasteComments       bool   `xml:"pasteComments,attr,omitempty"`
+	PasteDataValidation bool   `xml:"pasteDataValidation,attr,omitempty"`
+	PasteBorders        bool   `xml:"pasteBorders,attr,omitempty"`
+	PasteColWidths      bool   `xml:"pasteColWidths,attr,omitempty"`
+	PasteNumberFormats  bool   `xml:"pasteNumberFormats,attr,omitempty"`
+	Merge               bool   `xml:"merge,attr,omitempty"`
+	SplitFirst          bool   `xml:"splitFirst,attr,omitempty"`
+	SplitAll            bool   `xml:"splitAll,attr,omitempty"`
+	RowColShift         bool   `xml:"rowColShift,attr,omitempty"`
+	ClearAll            bool   `xml:"clearAll,attr,omitempty"`
+	ClearFormats        bool   `xml:"clearFormats,attr,omitempty"`
+	ClearContents       bool   `xml:"clearContents,attr,omitempty"`
+	ClearComments       bool   `xml:"clearComments,attr,omitempty"`
+	Assign              bool   `xml:"assign,attr,omitempty"`
+	Coerce              bool   `xml:"coerce,attr,omitempty"`
+	Adjust              bool   `xml:"adjust,attr,omitempty"`
+	CellMeta            bool   `xml:"cellMeta,attr,omitempty"`
+}
+
 // xlsxFutureMetadata directly maps the futureMetadata element. This element
 // represents future metadata information.
 type xlsxFutureMetadata struct {
+	Name   string                    `xml:"name,attr"`
+	Count  int                       `xml:"count,attr,omitempty"`
 	Bk     []xlsxFutureMetadataBlock `xml:"bk"`
 	ExtLst *xlsxInnerXML             `xml:"extLst"`
 }
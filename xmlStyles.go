@@ -19,6 +19,8 @@ import (
 // xlsxStyleSheet is the root element of the Styles part.
 type xlsxStyleSheet struct {
 	mu           sync.Mutex
+	styleIDs     map[*styleDefinition]int
+	quoteIDs     map[int]int
 	XMLName      xml.Name          `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main styleSheet"`
 	NumFmts      *xlsxNumFmts      `xml:"numFmts"`
 	Fonts        *xlsxFonts        `xml:"fonts"`
@@ -223,6 +225,39 @@ type xlsxXf struct {
 	ApplyProtection   *bool           `xml:"applyProtection,attr"`
 	Alignment         *xlsxAlignment  `xml:"alignment"`
 	Protection        *xlsxProtection `xml:"protection"`
+	ExtLst            *xlsxExtLst     `xml:"extLst"`
+}
+
+// xlsxFeaturePropertyBags directly maps the FeaturePropertyBags element in the
+// part xl/featurePropertyBag/featurePropertyBag.xml. This element specifies
+// a collection of property bags used by the features, such as the in-cell
+// checkbox of the cell formatting.
+type xlsxFeaturePropertyBags struct {
+	XMLName xml.Name                 `xml:"http://schemas.microsoft.com/office/spreadsheetml/2022/featurepropertybag FeaturePropertyBags"`
+	Bag     []xlsxFeaturePropertyBag `xml:"bag"`
+}
+
+// xlsxFeaturePropertyBag directly maps the bag element, which specifies a
+// property bag with the given type.
+type xlsxFeaturePropertyBag struct {
+	Type   string                        `xml:"type,attr"`
+	ExtRef string                        `xml:"extRef,attr,omitempty"`
+	BagID  []xlsxFeaturePropertyBagID    `xml:"bagId"`
+	A      *xlsxFeaturePropertyBagsArray `xml:"a"`
+}
+
+// xlsxFeaturePropertyBagID directly maps the bagId element, which specifies a
+// reference to the other property bag by zero-based index.
+type xlsxFeaturePropertyBagID struct {
+	K   string `xml:"k,attr,omitempty"`
+	Val int    `xml:",chardata"`
+}
+
+// xlsxFeaturePropertyBagsArray directly maps the a element, which specifies an
+// array of property bag references.
+type xlsxFeaturePropertyBagsArray struct {
+	K     string                     `xml:"k,attr"`
+	BagID []xlsxFeaturePropertyBagID `xml:"bagId"`
 }
 
 // xlsxCellXfs directly maps the cellXfs element. This element contains the
@@ -335,6 +370,14 @@ type Border struct {
 	Style int
 }
 
+// RangeBorderOptions directly maps the border settings of the range, the
+// Type of each border will be ignored.
+type RangeBorderOptions struct {
+	Outline          *Border
+	InsideHorizontal *Border
+	InsideVertical   *Border
+}
+
 // Font directly maps the font settings of the fonts.
 type Font struct {
 	Bold         bool
@@ -350,12 +393,14 @@ type Font struct {
 	VertAlign    string
 }
 
-// Fill directly maps the fill settings of the cells.
+// Fill directly maps the fill settings of the cells. The Transparency only
+// works for the fill of the chart elements.
 type Fill struct {
-	Type    string
-	Pattern int
-	Color   []string
-	Shading int
+	Type         string
+	Pattern      int
+	Color        []string
+	Shading      int
+	Transparency int
 }
 
 // Protection directly maps the protection settings of the cells.
@@ -376,3 +421,23 @@ type Style struct {
 	CustomNumFmt  *string
 	NegRed        bool
 }
+
+// StyleSources directly maps the sources of the style attributes of the cell.
+type StyleSources struct {
+	NumFmt     StyleSource
+	Font       StyleSource
+	Fill       StyleSource
+	Border     StyleSource
+	Alignment  StyleSource
+	Protection StyleSource
+}
+
+// CellStyleDetails directly maps the resolved style settings of the cell.
+type CellStyleDetails struct {
+	StyleID    int
+	Source     StyleSource
+	NamedStyle string
+	NumFmtCode string
+	Style      *Style
+	Sources    StyleSources
+}
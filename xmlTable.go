@@ -65,7 +65,7 @@ type xlsxAutoFilter struct {
 type xlsxFilterColumn struct {
 	ColID         int                `xml:"colId,attr"`
 	HiddenButton  bool               `xml:"hiddenButton,attr,omitempty"`
-	ShowButton    bool               `xml:"showButton,attr,omitempty"`
+	ShowButton    *bool              `xml:"showButton,attr"`
 	CustomFilters *xlsxCustomFilters `xml:"customFilters"`
 	Filters       *xlsxFilters       `xml:"filters"`
 	ColorFilter   *xlsxColorFilter   `xml:"colorFilter"`
@@ -250,4 +250,5 @@ type Table struct {
 type AutoFilterOptions struct {
 	Column     string
 	Expression string
+	HideButton bool
 }
@@ -240,6 +240,26 @@ type xlsxAlternateContent struct {
 	Content string `xml:",innerxml"`
 }
 
+// xlsxAbsPath directly maps the absPath element. This element specifies the
+// absolute path of the location where the workbook was last saved.
+type xlsxAbsPath struct {
+	XMLName    xml.Name `xml:"x15ac:absPath"`
+	XMLNSX15AC string   `xml:"xmlns:x15ac,attr"`
+	URL        string   `xml:"url,attr"`
+}
+
+// decodeWorkbookAlternateContent defines the structure used to parse the
+// AlternateContent element of the workbook, which contains the absolute path
+// of the workbook.
+type decodeWorkbookAlternateContent struct {
+	Choice struct {
+		Requires string `xml:"Requires,attr"`
+		AbsPath  *struct {
+			URL string `xml:"url,attr"`
+		} `xml:"absPath"`
+	} `xml:"Choice"`
+}
+
 // xlsxChoice element shall be an element in the Markup Compatibility namespace
 // with local name "Choice". Parent elements of Choice elements shall be
 // AlternateContent elements.
@@ -314,15 +334,15 @@ type xlsxDefinedName struct {
 // and details. Calculation is the process of computing formulas and then
 // displaying the results as values in the cells that contain the formulas.
 type xlsxCalcPr struct {
-	CalcCompleted         bool    `xml:"calcCompleted,attr,omitempty"`
+	CalcCompleted         *bool   `xml:"calcCompleted,attr"`
 	CalcID                string  `xml:"calcId,attr,omitempty"`
 	CalcMode              string  `xml:"calcMode,attr,omitempty"`
-	CalcOnSave            bool    `xml:"calcOnSave,attr,omitempty"`
+	CalcOnSave            *bool   `xml:"calcOnSave,attr"`
 	ConcurrentCalc        *bool   `xml:"concurrentCalc,attr"`
 	ConcurrentManualCount int     `xml:"concurrentManualCount,attr,omitempty"`
 	ForceFullCalc         bool    `xml:"forceFullCalc,attr,omitempty"`
 	FullCalcOnLoad        bool    `xml:"fullCalcOnLoad,attr,omitempty"`
-	FullPrecision         bool    `xml:"fullPrecision,attr,omitempty"`
+	FullPrecision         *bool   `xml:"fullPrecision,attr"`
 	Iterate               bool    `xml:"iterate,attr,omitempty"`
 	IterateCount          int     `xml:"iterateCount,attr,omitempty"`
 	IterateDelta          float64 `xml:"iterateDelta,attr,omitempty"`
@@ -375,6 +395,54 @@ type xlsxCustomWorkbookView struct {
 	YWindow              *int     `xml:"yWindow,attr"`
 }
 
+// FormulaAuditItem directly maps the formula which was found by the formula
+// auditing. The Cell is empty and the DefinedName is the name of the defined
+// name if the formula is the reference of a defined name, and the Sheet is
+// "Workbook" for the workbook scoped defined names. The Detail specifies the
+// external workbook, the volatile function name, the hidden worksheet name or
+// the length of the formula of the finding.
+type FormulaAuditItem struct {
+	Sheet       string
+	Cell        string
+	DefinedName string
+	Formula     string
+	Detail      string
+}
+
+// FormulaAuditReport directly maps the result of the formula auditing.
+type FormulaAuditReport struct {
+	ExternalLinks     []FormulaAuditItem
+	VolatileFunctions []FormulaAuditItem
+	HiddenSheetRefs   []FormulaAuditItem
+	LongFormulas      []FormulaAuditItem
+}
+
+// VBAProject directly maps the properties of the VBA project in the workbook.
+// The CodePage specifies the code page of the VBA project, which is used to
+// encode the strings in the project, such as 1252 for Western European
+// (Windows). The Signed specifies if the digital signatures of the VBA project
+// are preserved in the workbook.
+type VBAProject struct {
+	CodePage int
+	Signed   bool
+}
+
+// TagOptions directly maps the settings of applying the tagged rows and
+// columns. The Tags specifies the tags to be applied, all tags will be applied
+// if it is empty. The tagged rows and columns will be hidden instead of
+// removed if the Hide is true.
+type TagOptions struct {
+	Tags []string
+	Hide bool
+}
+
+// SplitOptions directly maps the settings of splitting the worksheet into
+// workbooks. The HeaderRows specifies the number of header rows of the
+// worksheet, which will be kept in each workbook.
+type SplitOptions struct {
+	HeaderRows *int
+}
+
 // DefinedName directly maps the name for a cell or cell range on a
 // worksheet.
 type DefinedName struct {
@@ -384,11 +452,72 @@ type DefinedName struct {
 	Scope    string
 }
 
+// Relationship directly maps the relationship from a part to another part in
+// the package or to the external resource.
+type Relationship struct {
+	ID         string
+	Type       string
+	Target     string
+	TargetMode string
+}
+
+// PartOptions directly maps the settings of the relationship which references
+// the part on setting the part in the package. The RelationshipSource is the
+// source part name of the relationship, the empty value represents the
+// package.
+type PartOptions struct {
+	RelationshipSource string
+	RelationshipType   string
+}
+
 // WorkbookPropsOptions directly maps the settings of workbook proprieties.
 type WorkbookPropsOptions struct {
-	Date1904      *bool
-	FilterPrivacy *bool
-	CodeName      *string
+	Date1904            *bool
+	FilterPrivacy       *bool
+	CodeName            *string
+	DefaultThemeVersion *int
+	AbsPath             *string
+}
+
+// CalcPropsOptions defines the collection of properties the application uses
+// to record calculation status and details.
+type CalcPropsOptions struct {
+	CalcID                *uint
+	CalcMode              *string
+	FullCalcOnLoad        *bool
+	RefMode               *string
+	Iterate               *bool
+	IterateCount          *uint
+	IterateDelta          *float64
+	FullPrecision         *bool
+	CalcCompleted         *bool
+	CalcOnSave            *bool
+	ConcurrentCalc        *bool
+	ConcurrentManualCount *uint
+	ForceFullCalc         *bool
+}
+
+// SheetDependency directly maps the reference to a sheet from the other parts
+// of the workbook.
+type SheetDependency struct {
+	Type     SheetDependencyType
+	Sheet    string
+	Cell     string
+	Name     string
+	RefersTo string
+}
+
+// DeleteSheetOptions directly maps the settings of deleting a sheet.
+type DeleteSheetOptions struct {
+	CheckDependencies bool
+	Cascade           bool
+}
+
+// TOCOptions directly maps the settings of the table of contents worksheet.
+type TOCOptions struct {
+	Title               string
+	IncludeHidden       bool
+	IncludeDefinedNames bool
 }
 
 // WorkbookProtectionOptions directly maps the settings of workbook protection.
@@ -29,8 +29,8 @@ type xlsxWorksheet struct {
 	SheetData              xlsxSheetData                `xml:"sheetData"`
 	SheetCalcPr            *xlsxInnerXML                `xml:"sheetCalcPr"`
 	SheetProtection        *xlsxSheetProtection         `xml:"sheetProtection"`
-	ProtectedRanges        *xlsxInnerXML                `xml:"protectedRanges"`
-	Scenarios              *xlsxInnerXML                `xml:"scenarios"`
+	ProtectedRanges        *xlsxProtectedRanges         `xml:"protectedRanges"`
+	Scenarios              *xlsxScenarios               `xml:"scenarios"`
 	AutoFilter             *xlsxAutoFilter              `xml:"autoFilter"`
 	SortState              *xlsxSortState               `xml:"sortState"`
 	DataConsolidate        *xlsxInnerXML                `xml:"dataConsolidate"`
@@ -46,8 +46,8 @@ type xlsxWorksheet struct {
 	HeaderFooter           *xlsxHeaderFooter            `xml:"headerFooter"`
 	RowBreaks              *xlsxRowBreaks               `xml:"rowBreaks"`
 	ColBreaks              *xlsxColBreaks               `xml:"colBreaks"`
-	CustomProperties       *xlsxInnerXML                `xml:"customProperties"`
-	CellWatches            *xlsxInnerXML                `xml:"cellWatches"`
+	CustomProperties       *xlsxCustomProperties        `xml:"customProperties"`
+	CellWatches            *xlsxCellWatches             `xml:"cellWatches"`
 	IgnoredErrors          *xlsxInnerXML                `xml:"ignoredErrors"`
 	SmartTags              *xlsxInnerXML                `xml:"smartTags"`
 	Drawing                *xlsxDrawing                 `xml:"drawing"`
@@ -58,10 +58,11 @@ type xlsxWorksheet struct {
 	OleObjects             *xlsxInnerXML                `xml:"oleObjects"`
 	Controls               *xlsxInnerXML                `xml:"controls"`
 	WebPublishItems        *xlsxInnerXML                `xml:"webPublishItems"`
-	AlternateContent       *xlsxAlternateContent        `xml:"mc:AlternateContent"`
+	AlternateContent       []*xlsxAlternateContent      `xml:"mc:AlternateContent"`
 	TableParts             *xlsxTableParts              `xml:"tableParts"`
 	ExtLst                 *xlsxExtLst                  `xml:"extLst"`
-	DecodeAlternateContent *xlsxInnerXML                `xml:"http://schemas.openxmlformats.org/markup-compatibility/2006 AlternateContent"`
+	DecodeAlternateContent []*xlsxInnerXML              `xml:"http://schemas.openxmlformats.org/markup-compatibility/2006 AlternateContent"`
+	rowIndex               map[int]int
 }
 
 // xlsxDrawing change r:id to rid in the namespace.
@@ -385,10 +386,10 @@ type xlsxCustomSheetView struct {
 	ColorID        int               `xml:"colorId,attr,omitempty"`
 	ShowPageBreaks bool              `xml:"showPageBreaks,attr,omitempty"`
 	ShowFormulas   bool              `xml:"showFormulas,attr,omitempty"`
-	ShowGridLines  bool              `xml:"showGridLines,attr,omitempty"`
-	ShowRowCol     bool              `xml:"showRowCol,attr,omitempty"`
-	OutlineSymbols bool              `xml:"outlineSymbols,attr,omitempty"`
-	ZeroValues     bool              `xml:"zeroValues,attr,omitempty"`
+	ShowGridLines  *bool             `xml:"showGridLines,attr"`
+	ShowRowCol     *bool             `xml:"showRowCol,attr"`
+	OutlineSymbols *bool             `xml:"outlineSymbols,attr"`
+	ZeroValues     *bool             `xml:"zeroValues,attr"`
 	FitToPage      bool              `xml:"fitToPage,attr,omitempty"`
 	PrintArea      bool              `xml:"printArea,attr,omitempty"`
 	Filter         bool              `xml:"filter,attr,omitempty"`
@@ -398,7 +399,7 @@ type xlsxCustomSheetView struct {
 	State          string            `xml:"state,attr,omitempty"`
 	FilterUnique   bool              `xml:"filterUnique,attr,omitempty"`
 	View           string            `xml:"view,attr,omitempty"`
-	ShowRuler      bool              `xml:"showRuler,attr,omitempty"`
+	ShowRuler      *bool             `xml:"showRuler,attr"`
 	TopLeftCell    string            `xml:"topLeftCell,attr,omitempty"`
 }
 
@@ -526,6 +527,58 @@ type xlsxSheetProtection struct {
 	SelectUnlockedCells bool     `xml:"selectUnlockedCells,attr"`
 }
 
+// xlsxProtectedRanges directly maps the protectedRanges element. This
+// collection represents the ranges to be unlocked when the sheet is protected.
+type xlsxProtectedRanges struct {
+	ProtectedRange []*xlsxProtectedRange `xml:"protectedRange"`
+}
+
+// xlsxProtectedRange directly maps the protectedRange element. This element
+// specifies a range on a worksheet that can be edited when the sheet is
+// protected, optionally with a password.
+type xlsxProtectedRange struct {
+	SecurityDescriptors []string `xml:"securityDescriptor"`
+	Password            string   `xml:"password,attr,omitempty"`
+	Sqref               string   `xml:"sqref,attr"`
+	Name                string   `xml:"name,attr"`
+	SecurityDescriptor  string   `xml:"securityDescriptor,attr,omitempty"`
+	AlgorithmName       string   `xml:"algorithmName,attr,omitempty"`
+	HashValue           string   `xml:"hashValue,attr,omitempty"`
+	SaltValue           string   `xml:"saltValue,attr,omitempty"`
+	SpinCount           int      `xml:"spinCount,attr,omitempty"`
+}
+
+// xlsxScenarios directly maps the scenarios element. This collection
+// expresses the what-if analysis scenarios of the worksheet.
+type xlsxScenarios struct {
+	Scenario []*xlsxScenario `xml:"scenario"`
+	Current  *int            `xml:"current,attr"`
+	Show     *int            `xml:"show,attr"`
+	Sqref    string          `xml:"sqref,attr,omitempty"`
+}
+
+// xlsxScenario directly maps the scenario element. This element specifies a
+// named set of the input values of the changing cells.
+type xlsxScenario struct {
+	InputCells []*xlsxInputCells `xml:"inputCells"`
+	Name       string            `xml:"name,attr"`
+	Locked     bool              `xml:"locked,attr,omitempty"`
+	Hidden     bool              `xml:"hidden,attr,omitempty"`
+	Count      int               `xml:"count,attr,omitempty"`
+	User       string            `xml:"user,attr,omitempty"`
+	Comment    string            `xml:"comment,attr,omitempty"`
+}
+
+// xlsxInputCells directly maps the inputCells element. This element
+// specifies the input value of a changing cell of the scenario.
+type xlsxInputCells struct {
+	R        string `xml:"r,attr"`
+	Deleted  bool   `xml:"deleted,attr,omitempty"`
+	Undone   bool   `xml:"undone,attr,omitempty"`
+	Val      string `xml:"val,attr"`
+	NumFmtID *int   `xml:"numFmtId,attr"`
+}
+
 // xlsxPhoneticPr (Phonetic Properties) represents a collection of phonetic
 // properties that affect the display of phonetic text for this String Item
 // (si). Phonetic text is used to give hints as to the pronunciation of an East
@@ -627,6 +680,33 @@ type xlsxHyperlink struct {
 	RID      string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr,omitempty"`
 }
 
+// xlsxCustomProperties directly maps the customProperties element. This
+// collection specifies the custom properties of the worksheet.
+type xlsxCustomProperties struct {
+	CustomPr []xlsxCustomProperty `xml:"customPr"`
+}
+
+// xlsxCustomProperty directly maps the customPr element. This element
+// specifies the name of a custom property, and the value of the property is
+// stored in the custom property part referenced by the relationship ID.
+type xlsxCustomProperty struct {
+	Name string `xml:"name,attr"`
+	RID  string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+}
+
+// xlsxCellWatches directly maps the cellWatches element. This collection
+// specifies the cells in the worksheet which are watched in the Watch Window
+// of the spreadsheet application.
+type xlsxCellWatches struct {
+	CellWatch []xlsxCellWatch `xml:"cellWatch"`
+}
+
+// xlsxCellWatch directly maps the cellWatch element. This element specifies a
+// single cell which is watched in the Watch Window.
+type xlsxCellWatch struct {
+	R string `xml:"r,attr"`
+}
+
 // xlsxTableParts directly maps the tableParts element in the namespace
 // http://schemas.openxmlformats.org/spreadsheetml/2006/main - The table element
 // has several attributes applied to identify the table and the data range it
@@ -845,6 +925,58 @@ type xlsxX14Sparkline struct {
 	Sqref string `xml:"xm:sqref"`
 }
 
+// decodeControl defines the structure used to parse the control element in
+// the controls collection of the worksheet.
+type decodeControl struct {
+	ShapeID   int              `xml:"shapeId,attr"`
+	RID       string           `xml:"id,attr"`
+	Name      string           `xml:"name,attr"`
+	ControlPr *decodeControlPr `xml:"controlPr"`
+}
+
+// decodeControlPr defines the structure used to parse the controlPr element
+// which specifies the properties of the embedded control.
+type decodeControlPr struct {
+	AltText       string               `xml:"altText,attr"`
+	LinkedCell    string               `xml:"linkedCell,attr"`
+	ListFillRange string               `xml:"listFillRange,attr"`
+	Macro         string               `xml:"macro,attr"`
+	Anchor        *decodeControlAnchor `xml:"anchor"`
+}
+
+// decodeControlAnchor defines the structure used to parse the anchor element
+// of the embedded control properties.
+type decodeControlAnchor struct {
+	From decodeFrom `xml:"from"`
+}
+
+// decodeActiveXControl defines the structure used to parse the ocx element in
+// the ActiveX control part xl/activeX/activeX%d.xml.
+type decodeActiveXControl struct {
+	XMLName     xml.Name                 `xml:"ocx"`
+	ClassID     string                   `xml:"classid,attr"`
+	Persistence string                   `xml:"persistence,attr"`
+	OcxPr       []decodeActiveXControlPr `xml:"ocxPr"`
+}
+
+// decodeActiveXControlPr defines the structure used to parse the ocxPr element
+// which specifies a property of the ActiveX control.
+type decodeActiveXControlPr struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// ActiveXControl directly maps the ActiveX control information.
+type ActiveXControl struct {
+	Cell          string
+	Name          string
+	Type          string
+	ProgID        string
+	ClassID       string
+	LinkedCell    string
+	ListFillRange string
+}
+
 // DataValidation directly maps the settings of the data validation rule.
 type DataValidation struct {
 	AllowBlank       bool
@@ -961,6 +1093,73 @@ type SheetProtectionOptions struct {
 	Sort                bool
 }
 
+// AllowEditRange directly maps the settings of the range which allowed to be
+// edited when the worksheet is protected.
+type AllowEditRange struct {
+	Title       string
+	Range       string
+	HasPassword bool
+}
+
+// DataTableOptions directly maps the settings of the what-if analysis data
+// table. At least one of the row input cell and the column input cell is
+// required, the data table will be a two-variable data table if both of them
+// are specified.
+type DataTableOptions struct {
+	RowInputCell    string
+	ColumnInputCell string
+}
+
+// MergeCellOptions directly maps the settings of merging cells. The Overlap
+// specifies the handling for the merged cell which overlaps with the existing
+// merged cells.
+type MergeCellOptions struct {
+	Overlap MergeCellOverlapType
+}
+
+// RangeOverlapOptions directly maps the settings of checking the range which
+// overlaps with the existing ranges. The ErrorOnOverlap specifies if return
+// the ErrRangeOverlap error when the range overlaps with the existing ranges.
+type RangeOverlapOptions struct {
+	ErrorOnOverlap bool
+}
+
+// UnmergeCellOptions directly maps the settings of unmerging cells. The
+// FillValue specifies if fill all cells of the unmerged range with the value
+// of the top-left cell.
+type UnmergeCellOptions struct {
+	FillValue bool
+}
+
+// UsedRangeOptions directly maps the settings of getting the used range of the
+// worksheet. The IncludeStyles specifies if the cells with style but without
+// value are counted, the IncludeMergeCells specifies if the merged cell ranges
+// are counted, and the IncludeDrawings specifies if the cells covered by the
+// drawing objects such as pictures, shapes and charts are counted.
+type UsedRangeOptions struct {
+	IncludeStyles     bool
+	IncludeMergeCells bool
+	IncludeDrawings   bool
+}
+
+// Scenario directly maps the settings of the what-if analysis scenario of the
+// worksheet.
+type Scenario struct {
+	Name    string
+	Comment string
+	User    string
+	Locked  bool
+	Hidden  bool
+	Cells   []ScenarioCell
+}
+
+// ScenarioCell directly maps the changing cell and the input value of the
+// scenario.
+type ScenarioCell struct {
+	Cell  string
+	Value string
+}
+
 // HeaderFooterOptions directly maps the settings of header and footer.
 type HeaderFooterOptions struct {
 	AlignWithMargins *bool
@@ -991,21 +1190,46 @@ type PageLayoutMarginsOptions struct {
 type PageLayoutOptions struct {
 	// Size defines the paper size of the worksheet.
 	Size *int
+	// PaperWidth and PaperHeight specified the custom paper size with a unit
+	// of measurement, such as "210mm" or "8.5in", the supported units are
+	// "mm", "cm", "in", "pt", "pc" and "pi". These settings are required
+	// together, and override the paper size specified by the Size option.
+	PaperWidth  *string
+	PaperHeight *string
 	// Orientation defines the orientation of page layout for a worksheet.
 	Orientation *string
 	// FirstPageNumber specified the first printed page number. If no value is
-	// specified, then 'automatic' is assumed.
+	// specified or the value is 0, then 'automatic' is assumed.
 	FirstPageNumber *uint
 	// AdjustTo defines the print scaling. This attribute is restricted to
 	// value ranging from 10 (10%) to 400 (400%). This setting is overridden
-	// when fitToWidth and/or fitToHeight are in use.
+	// when fitToWidth and/or fitToHeight are in use. Set this option will
+	// disable the fit to page option of the worksheet.
 	AdjustTo *uint
-	// FitToHeight specified the number of vertical pages to fit on.
+	// FitToHeight specified the number of vertical pages to fit on. Set this
+	// option will enable the fit to page option of the worksheet.
 	FitToHeight *int
-	// FitToWidth specified the number of horizontal pages to fit on.
+	// FitToWidth specified the number of horizontal pages to fit on. Set this
+	// option will enable the fit to page option of the worksheet.
 	FitToWidth *int
 	// BlackAndWhite specified print black and white.
 	BlackAndWhite *bool
+	// Draft specified print without graphics.
+	Draft *bool
+	// CellComments specified how to print cell comments. The possible values
+	// are "none" (not printed), "atEnd" (printed at end of the sheet) and
+	// "asDisplayed" (printed as displayed on the sheet).
+	CellComments *string
+	// Errors specified how to print cell values for cells with errors. The
+	// possible values are "displayed", "blank", "dash" and "NA".
+	Errors *string
+}
+
+// RowRange directly maps the range of rows, the Start and End are the Excel
+// row number of the first and last row in the range.
+type RowRange struct {
+	Start int
+	End   int
 }
 
 // ViewOptions directly maps the settings of sheet view.
@@ -1046,6 +1270,59 @@ type ViewOptions struct {
 	ZoomScale *float64
 }
 
+// CustomSheetViewOptions directly maps the settings of the custom sheet view,
+// which stores the display and print settings of a worksheet for a named
+// custom view of the workbook.
+type CustomSheetViewOptions struct {
+	// Name specifies the name of the custom view of the workbook, the custom
+	// sheet views with the same name in different worksheets are parts of the
+	// same custom view. This field is required.
+	Name string
+	// ShowGridLines indicating whether this sheet should display grid lines.
+	ShowGridLines *bool
+	// ShowRowColHeaders indicating whether the sheet should display row and
+	// column headings.
+	ShowRowColHeaders *bool
+	// ShowFormulas indicating whether this sheet should display formulas.
+	ShowFormulas *bool
+	// ShowZeros indicating whether to "show a zero in cells that have zero
+	// value".
+	ShowZeros *bool
+	// ShowOutlineSymbols indicating whether the sheet should display the
+	// outline symbols.
+	ShowOutlineSymbols *bool
+	// ShowPageBreaks indicating whether the sheet should display the page
+	// breaks.
+	ShowPageBreaks *bool
+	// ShowRuler indicating this sheet should display ruler.
+	ShowRuler *bool
+	// HiddenRows indicating whether the view includes the hidden rows.
+	HiddenRows *bool
+	// HiddenColumns indicating whether the view includes the hidden columns.
+	HiddenColumns *bool
+	// FitToPage indicating whether the Fit to Page print option is enabled.
+	FitToPage *bool
+	// PrintArea indicating whether the view includes the print area.
+	PrintArea *bool
+	// IncludeFilter indicating whether the view saves the filter settings. If
+	// set to true, the current auto filter settings of the worksheet will be
+	// stored in the view, and the filter settings will be removed from the
+	// view if set to false.
+	IncludeFilter *bool
+	// State specifies the visible state of the sheet in the view.
+	State *SheetState
+	// TopLeftCell specifies a location of the top left visible cell in the
+	// view.
+	TopLeftCell *string
+	// View indicating how sheet is displayed, available options: normal,
+	// pageLayout, pageBreakPreview
+	View *string
+	// ZoomScale specifies a window zoom magnification for the view
+	// representing percent values. This attribute is restricted to values
+	// ranging from 10 to 400.
+	ZoomScale *int
+}
+
 // SheetPropsOptions directly maps the settings of sheet view.
 type SheetPropsOptions struct {
 	// Specifies a stable name of the sheet, which should not change over time,
@@ -1103,3 +1380,11 @@ type SheetPropsOptions struct {
 	// ThickBottom specifies if rows have a thick bottom border by default.
 	ThickBottom *bool
 }
+
+// SheetCustomProperty directly maps the custom property of the worksheet,
+// which is used to store the metadata of the worksheet by some applications,
+// such as business intelligence tools.
+type SheetCustomProperty struct {
+	Name  string
+	Value string
+}